@@ -49,5 +49,21 @@ func authActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		ActionResolver: newLogoutAction,
 	})
 
+	group.Add("list", &actions.ActionDescriptorOptions{
+		Command:        newAuthListCmd(),
+		ActionResolver: newAuthListAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat},
+		DefaultFormat:  output.TableFormat,
+	})
+
+	group.Add("switch", &actions.ActionDescriptorOptions{
+		Command:        newAuthSwitchCmd(),
+		ActionResolver: newAuthSwitchAction,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdAuthSwitchHelpDescription,
+			Footer:      getCmdAuthSwitchHelpFooter,
+		},
+	})
+
 	return group
 }
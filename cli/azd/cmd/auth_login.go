@@ -79,6 +79,7 @@ type loginFlags struct {
 	scopes                 []string
 	claims                 string
 	redirectPort           int
+	profile                string
 	global                 *internal.GlobalCommandOptions
 }
 
@@ -189,6 +190,11 @@ func (lf *loginFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandO
 		"redirect-port",
 		0,
 		"Choose the port to be used as part of the redirect URI during interactive login.")
+	local.StringVar(
+		&lf.profile,
+		"profile",
+		"",
+		"The auth profile to log in with. Each profile has its own account and token cache.")
 	if oneauth.Supported {
 		local.BoolVar(&lf.browser, "browser", false, "Authenticate in a web browser instead of an integrated dialog.")
 	}
@@ -292,6 +298,18 @@ func (la *loginAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		la.console.Message(ctx, "Authentication mode set to azd built-in. Continuing login...")
 	}
 
+	// The subscriptions are cached for the account of the profile azd uses
+	refreshSubscriptions := true
+	if la.flags.profile != "" && la.flags.profile != la.authManager.ProfileName() {
+		profileManager, err := la.authManager.ForProfile(la.flags.profile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", err, internal.ErrInvalidArgValue)
+		}
+
+		la.authManager = profileManager
+		refreshSubscriptions = false
+	}
+
 	if len(la.flags.scopes) == 0 {
 		la.flags.scopes = la.authManager.LoginScopes()
 	}
@@ -366,12 +384,18 @@ func (la *loginAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		return nil, err
 	}
 
+	if la.flags.profile != "" {
+		if err := la.switchProfile(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	forceRefresh := false
 	if v, err := strconv.ParseBool(os.Getenv("AZD_DEBUG_LOGIN_FORCE_SUBSCRIPTION_REFRESH")); err == nil && v {
 		forceRefresh = true
 	}
 
-	if la.flags.clientID == "" || forceRefresh {
+	if refreshSubscriptions && (la.flags.clientID == "" || forceRefresh) {
 		// Update the subscriptions cache for regular users (i.e. non-service-principals).
 		// The caching is done here to increase responsiveness of listing subscriptions in the application.
 		// It also allows an implicit command for the user to refresh cached subscriptions.
@@ -397,6 +421,23 @@ func (la *loginAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	return nil, nil
 }
 
+// switchProfile selects the profile the user logged in with, warning when the selected environment pins another profile.
+func (la *loginAction) switchProfile(ctx context.Context) error {
+	if err := la.authManager.SwitchProfile(la.flags.profile); err != nil {
+		return fmt.Errorf("switching to auth profile '%s': %w", la.flags.profile, err)
+	}
+
+	if pinned := la.authManager.PinnedProfile(); pinned != "" && pinned != la.flags.profile {
+		la.console.MessageUxItem(ctx, &ux.WarningMessage{
+			Description: fmt.Sprintf(
+				"The selected environment pins the auth profile '%s', which azd uses while the environment is selected.",
+				pinned),
+		})
+	}
+
+	return nil
+}
+
 // Verifies that the user has credentials stored,
 // and that the credentials stored is accepted by the identity server (can be exchanged for access token).
 func (la *loginAction) verifyLoggedIn(ctx context.Context) (*azcore.AccessToken, error) {
@@ -0,0 +1,156 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/spf13/cobra"
+)
+
+func newAuthListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: fmt.Sprintf("List the auth profiles. %s", output.WithWarningFormat("(Beta)")),
+		Args:  cobra.NoArgs,
+	}
+}
+
+type authListAction struct {
+	formatter   output.Formatter
+	writer      io.Writer
+	authManager *auth.Manager
+}
+
+func newAuthListAction(
+	formatter output.Formatter,
+	writer io.Writer,
+	authManager *auth.Manager,
+) actions.Action {
+	return &authListAction{
+		formatter:   formatter,
+		writer:      writer,
+		authManager: authManager,
+	}
+}
+
+func (a *authListAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	profiles, err := a.authManager.Profiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing auth profiles: %w", err)
+	}
+
+	if a.formatter.Kind() == output.TableFormat {
+		columns := []output.Column{
+			{
+				Heading:       "NAME",
+				ValueTemplate: "{{.Name}}",
+			},
+			{
+				Heading:       "CURRENT",
+				ValueTemplate: "{{.Current}}",
+			},
+			{
+				Heading:       "ACCOUNT",
+				ValueTemplate: "{{.Account}}",
+			},
+		}
+
+		err = a.formatter.Format(profiles, a.writer, output.TableFormatterOptions{
+			Columns: columns,
+		})
+	} else {
+		err = a.formatter.Format(profiles, a.writer, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func newAuthSwitchCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "switch <profile>",
+		Short: fmt.Sprintf("Switch to another auth profile. %s", output.WithWarningFormat("(Beta)")),
+		Args:  cobra.ExactArgs(1),
+	}
+}
+
+type authSwitchAction struct {
+	args        []string
+	console     input.Console
+	authManager *auth.Manager
+}
+
+func newAuthSwitchAction(
+	args []string,
+	console input.Console,
+	authManager *auth.Manager,
+) actions.Action {
+	return &authSwitchAction{
+		args:        args,
+		console:     console,
+		authManager: authManager,
+	}
+}
+
+func (a *authSwitchAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	name := a.args[0]
+	if err := a.authManager.SwitchProfile(name); err != nil {
+		if errors.Is(err, auth.ErrProfileNotFound) {
+			return nil, &internal.ErrorWithSuggestion{
+				Err: err,
+				Suggestion: fmt.Sprintf(
+					"Log in with the profile first with 'azd auth login --profile %s'.", name),
+			}
+		}
+
+		return nil, fmt.Errorf("%w: %w", err, internal.ErrInvalidArgValue)
+	}
+
+	if pinned := a.authManager.PinnedProfile(); pinned != "" && pinned != name {
+		a.console.MessageUxItem(ctx, &ux.WarningMessage{
+			Description: fmt.Sprintf(
+				"The selected environment pins the auth profile '%s', which azd uses while the environment is selected.",
+				pinned),
+		})
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Switched to the auth profile '%s'.", name),
+		},
+	}, nil
+}
+
+func getCmdAuthSwitchHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		fmt.Sprintf("Switch the auth profile azd uses. %s", output.WithWarningFormat("(Beta)")),
+		[]string{
+			formatHelpNote(fmt.Sprintf("Log in with a profile with %s.",
+				output.WithHighLightFormat("azd auth login --profile <name>"))),
+			formatHelpNote(fmt.Sprintf("An environment pins a profile when %s is set in its config.json, "+
+				"which azd uses while the environment is selected.", output.WithHighLightFormat(auth.ProfileConfigPath))),
+		})
+}
+
+func getCmdAuthSwitchHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Switch to the customer-a profile.":   output.WithHighLightFormat("azd auth switch customer-a"),
+		"Switch back to the default profile.": output.WithHighLightFormat("azd auth switch default"),
+		"Pin the prod profile to the prod environment.": output.WithHighLightFormat(
+			"azd env config set auth.profile prod -e prod",
+		),
+	})
+}
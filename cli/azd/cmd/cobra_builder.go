@@ -56,8 +56,8 @@ func (cb *CobraBuilder) BuildCommand(descriptor *actions.ActionDescriptor) (*cob
 		}
 	}
 
-	// Configure action resolver for leaf commands
-	if !cmd.HasSubCommands() {
+	// Configure action resolver for leaf commands and for parent commands that run an action of their own
+	if !cmd.HasSubCommands() || descriptor.Options.ActionResolver != nil {
 		if err := cb.configureActionResolver(cmd, descriptor); err != nil {
 			return nil, err
 		}
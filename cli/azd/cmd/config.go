@@ -99,8 +99,10 @@ func configActions(root *actions.ActionDescriptor, rootOptions *internal.GlobalC
 	group.Add("show", &actions.ActionDescriptorOptions{
 		Command: &cobra.Command{
 			Short: "Show all the configuration values.",
-			Long:  `Show all configuration values in ` + userConfigPath + `.`,
+			Long: `Show all configuration values, resolved from ` + userConfigPath +
+				` and the other layers of the configuration.`,
 		},
+		FlagsResolver:  newConfigShowFlags,
 		ActionResolver: newConfigShowAction,
 		OutputFormats:  []output.Format{output.JsonFormat},
 		DefaultFormat:  output.JsonFormat,
@@ -120,9 +122,11 @@ func configActions(root *actions.ActionDescriptor, rootOptions *internal.GlobalC
 		Command: &cobra.Command{
 			Use:   "get <path>",
 			Short: "Gets a configuration.",
-			Long:  `Gets a configuration in ` + userConfigPath + `.`,
-			Args:  cobra.ExactArgs(1),
+			Long: `Gets a configuration, resolved from ` + userConfigPath +
+				` and the other layers of the configuration.`,
+			Args: cobra.ExactArgs(1),
 		},
+		FlagsResolver:  newConfigGetFlags,
 		ActionResolver: newConfigGetAction,
 		OutputFormats:  []output.Format{output.JsonFormat},
 		DefaultFormat:  output.JsonFormat,
@@ -187,21 +191,53 @@ $ azd config set defaults.location eastus`,
 	return group
 }
 
+// bindShowOrigin binds the --show-origin flag of the commands reading the configuration
+func bindShowOrigin(cmd *cobra.Command, showOrigin *bool) {
+	cmd.Flags().BoolVar(
+		showOrigin,
+		"show-origin",
+		false,
+		"Shows the layer supplying each value (default, system, user, project, environment, env or policy) and its file.",
+	)
+}
+
+// layeredConfig returns the layers of the configuration, which only has the user layer when it isn't layered
+func layeredConfig(azdConfig config.Config) *config.LayeredConfig {
+	if layered, ok := azdConfig.(*config.LayeredConfig); ok {
+		return layered
+	}
+
+	return config.NewLayeredConfig(config.ConfigSource{Layer: config.LayerUser, Config: azdConfig})
+}
+
 // azd config show
 
+type configShowFlags struct {
+	showOrigin bool
+}
+
+func newConfigShowFlags(cmd *cobra.Command) *configShowFlags {
+	flags := &configShowFlags{}
+	bindShowOrigin(cmd, &flags.showOrigin)
+
+	return flags
+}
+
 type configShowAction struct {
 	configManager config.UserConfigManager
 	formatter     output.Formatter
 	writer        io.Writer
+	flags         *configShowFlags
 }
 
 func newConfigShowAction(
-	configManager config.UserConfigManager, formatter output.Formatter, writer io.Writer,
+	configManager config.UserConfigManager, formatter output.Formatter, writer io.Writer, flags *configShowFlags,
 ) actions.Action {
 	return &configShowAction{
 		configManager: configManager,
 		formatter:     formatter,
 		writer:        writer,
+		flags:         flags,
 	}
 }
 
@@ -212,7 +248,19 @@ func (a *configShowAction) Run(ctx context.Context) (*actions.ActionResult, erro
 		return nil, err
 	}
 
-	values := azdConfig.Raw()
+	resolved := layeredConfig(azdConfig).Values("")
+
+	var values any = resolved
+	if !a.flags.showOrigin {
+		effective := config.NewEmptyConfig()
+		for _, value := range resolved {
+			if err := effective.Set(value.Key, value.Value); err != nil {
+				return nil, fmt.Errorf("resolving configuration value '%s': %w", value.Key, err)
+			}
+		}
+
+		values = effective.Raw()
+	}
 
 	if a.formatter.Kind() == output.JsonFormat {
 		err := a.formatter.Format(values, a.writer, nil)
@@ -253,10 +301,22 @@ func (a *configListAction) Run(ctx context.Context) (*actions.ActionResult, erro
 
 // azd config get <path>
 
+type configGetFlags struct {
+	showOrigin bool
+}
+
+func newConfigGetFlags(cmd *cobra.Command) *configGetFlags {
+	flags := &configGetFlags{}
+	bindShowOrigin(cmd, &flags.showOrigin)
+
+	return flags
+}
+
 type configGetAction struct {
 	configManager config.UserConfigManager
 	formatter     output.Formatter
 	writer        io.Writer
+	flags         *configGetFlags
 	args          []string
 }
 
@@ -264,12 +324,14 @@ func newConfigGetAction(
 	configManager config.UserConfigManager,
 	formatter output.Formatter,
 	writer io.Writer,
+	flags *configGetFlags,
 	args []string,
 ) actions.Action {
 	return &configGetAction{
 		configManager: configManager,
 		formatter:     formatter,
 		writer:        writer,
+		flags:         flags,
 		args:          args,
 	}
 }
@@ -291,6 +353,10 @@ func (a *configGetAction) Run(ctx context.Context) (*actions.ActionResult, error
 		}
 	}
 
+	if a.flags.showOrigin {
+		value = layeredConfig(azdConfig).Values(key)
+	}
+
 	if a.formatter.Kind() == output.JsonFormat {
 		err := a.formatter.Format(value, a.writer, nil)
 		if err != nil {
@@ -519,6 +519,36 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		return cloud.NewCloud(&cloud.Config{Name: cloud.AzurePublicName})
 	})
 
+	// The auth profile pinned by the selected environment (.azure/<environment>/config.json). The environment is selected
+	// with the env flag `-e, --environment` or AZURE_ENV_NAME, or is the default environment.
+	container.MustRegisterScoped(func(
+		ctx context.Context,
+		lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext],
+		lazyLocalEnvStore *lazy.Lazy[environment.LocalDataStore],
+		envFlags internal.EnvFlag,
+	) auth.PinnedProfile {
+		localEnvStore, _ := lazyLocalEnvStore.GetValue()
+		azdCtx, err := lazyAzdContext.GetValue()
+		if err != nil || azdCtx == nil || localEnvStore == nil {
+			return ""
+		}
+
+		environmentName := envFlags.EnvironmentName
+		if environmentName == "" {
+			if environmentName, err = azdCtx.GetDefaultEnvironmentName(); err != nil || environmentName == "" {
+				return ""
+			}
+		}
+
+		if env, err := localEnvStore.Get(ctx, environmentName); err == nil {
+			if profile, has := env.Config.GetString(auth.ProfileConfigPath); has {
+				return auth.PinnedProfile(profile)
+			}
+		}
+
+		return ""
+	})
+
 	container.MustRegisterSingleton(func(transport policy.Transporter, cloud *cloud.Cloud) *azcore.ClientOptions {
 		return &azcore.ClientOptions{
 			Cloud: cloud.Configuration,
@@ -562,6 +592,9 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterSingleton(project.NewDotNetImporter)
 	container.MustRegisterScoped(project.NewImportManager)
 	container.MustRegisterScoped(project.NewServiceManager)
+	container.MustRegisterSingleton(project.NewHealthProber)
+	container.MustRegisterScoped(project.NewHealthChecker)
+	container.MustRegisterScoped(project.NewDeploymentHistoryManager)
 
 	// Even though the service manager is scoped based on its use of environment we can still
 	// register its internal cache as a singleton to ensure operation caching is consistent across all instances
@@ -598,8 +631,29 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	})
 
 	container.MustRegisterSingleton(repository.NewInitializer)
+	container.MustRegisterSingleton(repository.NewTemplateValidator)
+	container.MustRegisterSingleton(repository.NewTemplateTester)
 	container.MustRegisterSingleton(alpha.NewFeaturesManager)
-	container.MustRegisterSingleton(config.NewUserConfigManager)
+	container.MustRegisterScoped(func(
+		fileConfigManager config.FileConfigManager,
+		serviceLocator ioc.ServiceLocator,
+	) config.UserConfigManager {
+		// The user configuration is layered with the project of the current directory and the environment selected with
+		// the env flag `-e, --environment` or AZURE_ENV_NAME, or the default environment. The env flag is only resolved
+		// when the configuration is loaded, since the configuration is also used before a command is selected.
+		return config.NewLayeredUserConfigManager(
+			config.NewUserConfigManager(fileConfigManager),
+			fileConfigManager,
+			func() config.ConfigScope {
+				var envFlags internal.EnvFlag
+				if err := serviceLocator.Resolve(&envFlags); err != nil {
+					log.Printf("resolving the environment of the azd config: %v", err)
+				}
+
+				return config.CurrentConfigScope(envFlags.EnvironmentName)
+			},
+		)
+	})
 	container.MustRegisterSingleton(config.NewManager)
 	container.MustRegisterSingleton(config.NewFileConfigManager)
 	container.MustRegisterScoped(func() (auth.ExternalAuthConfiguration, error) {
@@ -666,6 +720,7 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 		}
 		return errorhandler.NewErrorHandlerPipeline(resolver)
 	})
+	container.MustRegisterNamedSingleton("policyViolationHandler", errorhandler.NewPolicyViolationHandler)
 	container.MustRegisterNamedSingleton("resourceNotAvailableHandler",
 		func(
 			locationService *azapi.ResourceTypeLocationService,
@@ -756,6 +811,8 @@ func registerCommonDependencies(container *ioc.NestedContainer) {
 	container.MustRegisterSingleton(infra.NewAzureResourceManager)
 	container.MustRegisterScoped(provisioning.NewManager)
 	container.MustRegisterScoped(provisioning.NewPrincipalIdProvider)
+	container.MustRegisterSingleton(provisioning.NewPriceSource)
+	container.MustRegisterSingleton(provisioning.NewCostEstimator)
 	container.MustRegisterScoped(prompt.NewDefaultPrompter)
 
 	// Other
@@ -5,12 +5,18 @@ package cmd
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/middleware"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/auth"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
 	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/project"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/require"
@@ -152,6 +158,94 @@ func Test_Lazy_AzdContext_Resolution(t *testing.T) {
 	require.Same(t, directValue, staticComponent.concrete)
 }
 
+func Test_PinnedProfile_Resolution(t *testing.T) {
+	azdContext := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	require.NoError(t, azdContext.SetProjectState(azdcontext.ProjectState{DefaultEnvironment: "dev"}))
+	for envName, profile := range map[string]string{"dev": "contoso-dev", "prod": "contoso-prod"} {
+		envDir := filepath.Join(azdContext.EnvironmentDirectory(), envName)
+		require.NoError(t, os.MkdirAll(envDir, osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(filepath.Join(envDir, ".env"), nil, osutil.PermissionFile))
+		require.NoError(t, os.WriteFile(
+			filepath.Join(envDir, azdcontext.ConfigFileName),
+			[]byte(`{"auth": {"profile": "`+profile+`"}}`),
+			osutil.PermissionFile))
+	}
+
+	resolvePinnedProfile := func(t *testing.T, envName string) auth.PinnedProfile {
+		cmd := &cobra.Command{}
+		cmd.Flags().String(internal.EnvironmentNameFlagName, "", "")
+		if envName != "" {
+			require.NoError(t, cmd.Flags().Set(internal.EnvironmentNameFlagName, envName))
+		}
+
+		container := ioc.NewNestedContainer(nil)
+		ioc.RegisterInstance(container, context.Background())
+		ioc.RegisterInstance(container, cmd)
+		registerCommonDependencies(container)
+
+		var lazyAzdContext *lazy.Lazy[*azdcontext.AzdContext]
+		require.NoError(t, container.Resolve(&lazyAzdContext))
+		lazyAzdContext.SetValue(azdContext)
+
+		var pinnedProfile auth.PinnedProfile
+		require.NoError(t, container.Resolve(&pinnedProfile))
+		return pinnedProfile
+	}
+
+	// The profile is pinned by the environment selected with -e, or the default environment
+	require.Equal(t, auth.PinnedProfile("contoso-prod"), resolvePinnedProfile(t, "prod"))
+	require.Equal(t, auth.PinnedProfile("contoso-dev"), resolvePinnedProfile(t, ""))
+}
+
+func Test_UserConfigManager_EnvironmentScope(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	t.Setenv("AZURE_ENV_NAME", "")
+
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "azure.yaml"), nil, osutil.PermissionFile))
+	t.Chdir(projectDir)
+
+	azdContext := azdcontext.NewAzdContextWithDirectory(projectDir)
+	require.NoError(t, azdContext.SetProjectState(azdcontext.ProjectState{DefaultEnvironment: "dev"}))
+	for _, envName := range []string{"dev", "prod"} {
+		envDir := filepath.Join(azdContext.EnvironmentDirectory(), envName)
+		require.NoError(t, os.MkdirAll(envDir, osutil.PermissionDirectory))
+		require.NoError(t, os.WriteFile(
+			filepath.Join(envDir, azdcontext.ConfigFileName),
+			[]byte(`{"defaults": {"location": "`+envName+`-location"}}`),
+			osutil.PermissionFile))
+	}
+
+	resolveLocation := func(t *testing.T, envName string) string {
+		cmd := &cobra.Command{}
+		cmd.Flags().String(internal.EnvironmentNameFlagName, os.Getenv("AZURE_ENV_NAME"), "")
+		if envName != "" {
+			require.NoError(t, cmd.Flags().Set(internal.EnvironmentNameFlagName, envName))
+		}
+
+		container := ioc.NewNestedContainer(nil)
+		ioc.RegisterInstance(container, context.Background())
+		ioc.RegisterInstance(container, cmd)
+		registerCommonDependencies(container)
+
+		var userConfigManager config.UserConfigManager
+		require.NoError(t, container.Resolve(&userConfigManager))
+
+		azdConfig, err := userConfigManager.Load()
+		require.NoError(t, err)
+
+		location, _ := azdConfig.GetString("defaults.location")
+		return location
+	}
+
+	// The environment layer is the environment selected with -e or AZURE_ENV_NAME, or the default environment
+	require.Equal(t, "prod-location", resolveLocation(t, "prod"))
+	require.Equal(t, "dev-location", resolveLocation(t, ""))
+
+	t.Setenv("AZURE_ENV_NAME", "prod")
+	require.Equal(t, "prod-location", resolveLocation(t, ""))
+}
+
 type testLazyComponent[T comparable] struct {
 	lazy *lazy.Lazy[T]
 }
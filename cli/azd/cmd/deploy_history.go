@@ -0,0 +1,175 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type deployHistoryFlags struct {
+	internal.EnvFlag
+}
+
+func (f *deployHistoryFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	f.EnvFlag.Bind(local, global)
+}
+
+func newDeployHistoryFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *deployHistoryFlags {
+	flags := &deployHistoryFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newDeployHistoryCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history [<service>]",
+		Short: "List the recorded deployments of the services in the environment.",
+		Args:  cobra.MaximumNArgs(1),
+	}
+}
+
+type deployHistoryAction struct {
+	args              []string
+	console           input.Console
+	formatter         output.Formatter
+	writer            io.Writer
+	env               *environment.Environment
+	projectConfig     *project.ProjectConfig
+	importManager     *project.ImportManager
+	deploymentHistory *project.DeploymentHistoryManager
+}
+
+func newDeployHistoryAction(
+	args []string,
+	console input.Console,
+	formatter output.Formatter,
+	writer io.Writer,
+	env *environment.Environment,
+	projectConfig *project.ProjectConfig,
+	importManager *project.ImportManager,
+	deploymentHistory *project.DeploymentHistoryManager,
+) actions.Action {
+	return &deployHistoryAction{
+		args:              args,
+		console:           console,
+		formatter:         formatter,
+		writer:            writer,
+		env:               env,
+		projectConfig:     projectConfig,
+		importManager:     importManager,
+		deploymentHistory: deploymentHistory,
+	}
+}
+
+// deploymentHistoryRow is the table representation of a deployment record
+type deploymentHistoryRow struct {
+	Id        string
+	Service   string
+	Time      string
+	Status    string
+	Artifact  string
+	Revision  string
+	GitCommit string
+	Note      string
+}
+
+func (a *deployHistoryAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	serviceName := ""
+	if len(a.args) == 1 {
+		serviceName = a.args[0]
+
+		if has, err := a.importManager.HasService(ctx, a.projectConfig, serviceName); err != nil {
+			return nil, err
+		} else if !has {
+			return nil, fmt.Errorf("service '%s': %w", serviceName, internal.ErrServiceNotFound)
+		}
+	}
+
+	records, err := a.deploymentHistory.List(ctx, a.env.Name(), serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.formatter.Kind() != output.TableFormat {
+		return nil, a.formatter.Format(records, a.writer, nil)
+	}
+
+	if len(records) == 0 {
+		a.console.Message(ctx, "No deployments have been recorded for this environment.")
+		return nil, nil
+	}
+
+	rows := make([]deploymentHistoryRow, 0, len(records))
+	for _, record := range records {
+		row := deploymentHistoryRow{
+			Id:        record.Id,
+			Service:   record.Service,
+			Time:      record.Timestamp.Local().Format(time.DateTime),
+			Status:    string(record.Status),
+			Artifact:  record.Image,
+			Revision:  record.Revision,
+			GitCommit: record.GitCommit,
+		}
+
+		if row.Artifact == "" && record.Package != "" {
+			row.Artifact = filepath.Base(record.Package)
+		}
+
+		if len(row.GitCommit) > 7 {
+			row.GitCommit = row.GitCommit[:7]
+		}
+
+		if record.RollbackOf != "" {
+			row.Note = fmt.Sprintf("rollback to %s", record.RollbackOf)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return nil, a.formatter.Format(rows, a.writer, output.TableFormatterOptions{
+		Columns: []output.Column{
+			{Heading: "ID", ValueTemplate: "{{.Id}}"},
+			{Heading: "SERVICE", ValueTemplate: "{{.Service}}"},
+			{Heading: "TIME", ValueTemplate: "{{.Time}}"},
+			{Heading: "STATUS", ValueTemplate: "{{.Status}}"},
+			{Heading: "ARTIFACT", ValueTemplate: "{{.Artifact}}"},
+			{Heading: "REVISION", ValueTemplate: "{{.Revision}}"},
+			{Heading: "COMMIT", ValueTemplate: "{{.GitCommit}}"},
+			{Heading: "NOTE", ValueTemplate: "{{.Note}}"},
+		},
+	})
+}
+
+func getCmdDeployHistoryHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription("List the recorded deployments of the services in the environment.", []string{
+		formatHelpNote("Deployments are recorded by 'azd deploy' and 'azd rollback', newest first."),
+		formatHelpNote(fmt.Sprintf(
+			"The last %d deployments of each service are kept in the environment directory.",
+			project.DeploymentHistoryLimit,
+		)),
+	})
+}
+
+func getCmdDeployHistoryHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"List the deployments of all services.": output.WithHighLightFormat("azd deploy history"),
+		"List the deployments of the service named 'api'.": output.WithHighLightFormat(
+			"azd deploy history api",
+		),
+	})
+}
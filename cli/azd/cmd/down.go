@@ -9,12 +9,14 @@ import (
 	"fmt"
 	"log"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	inf "github.com/azure/azure-dev/cli/azd/pkg/infra"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
@@ -29,6 +31,8 @@ import (
 type downFlags struct {
 	forceDelete bool
 	purgeDelete bool
+	service     string
+	resource    string
 	global      *internal.GlobalCommandOptions
 	internal.EnvFlag
 }
@@ -42,6 +46,18 @@ func (i *downFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOpt
 		//nolint:lll
 		"Does not require confirmation before it permanently deletes resources that are soft-deleted by default (for example, key vaults).",
 	)
+	local.StringVar(
+		&i.service,
+		"service",
+		"",
+		"Deletes only the resources of the given service, found by their 'azd-service-name' tag.",
+	)
+	local.StringVar(
+		&i.resource,
+		"resource",
+		"",
+		"Deletes only the given resource from the 'resources' section of azure.yaml.",
+	)
 
 	i.EnvFlag.Bind(local, global)
 	i.global = global
@@ -63,6 +79,10 @@ func newDownCmd() *cobra.Command {
 	return cmd
 }
 
+// downForceNoPromptConfigKey disallows 'azd down --force' with --no-prompt when set to 'off', usually enforced by the azd
+// policy of an organization
+const downForceNoPromptConfigKey = "down.forceNoPrompt"
+
 type downAction struct {
 	flags               *downFlags
 	args                []string
@@ -72,7 +92,9 @@ type downAction struct {
 	envManager          environment.Manager
 	console             input.Console
 	projectConfig       *project.ProjectConfig
+	resourceManager     project.ResourceManager
 	alphaFeatureManager *alpha.FeatureManager
+	configManager       config.UserConfigManager
 }
 
 func newDownAction(
@@ -85,6 +107,8 @@ func newDownAction(
 	console input.Console,
 	alphaFeatureManager *alpha.FeatureManager,
 	importManager *project.ImportManager,
+	resourceManager project.ResourceManager,
+	configManager config.UserConfigManager,
 ) actions.Action {
 	return &downAction{
 		flags:               flags,
@@ -94,20 +118,47 @@ func newDownAction(
 		console:             console,
 		projectConfig:       projectConfig,
 		importManager:       importManager,
+		resourceManager:     resourceManager,
 		alphaFeatureManager: alphaFeatureManager,
+		configManager:       configManager,
 		args:                args,
 	}
 }
 
 func (a *downAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if err := a.checkForceNoPrompt(); err != nil {
+		return nil, err
+	}
+
+	target := a.target()
+
 	// Command title
+	title := "Deleting all resources and deployed code on Azure (azd down)"
+	if target != "" {
+		title = fmt.Sprintf("Deleting the resources of %s on Azure (azd down)", target)
+	}
 	a.console.MessageUxItem(ctx, &ux.MessageTitle{
-		Title:     "Deleting all resources and deployed code on Azure (azd down)",
+		Title:     title,
 		TitleNote: "Local application code is not deleted when running 'azd down'.",
 	})
 
 	startTime := time.Now()
 
+	var targetResourceIds []string
+	if target != "" {
+		resourceIds, err := a.targetResourceIds(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(resourceIds) == 0 {
+			a.console.MessageUxItem(ctx, &ux.DoneMessage{Message: "No Azure resources were found."})
+			return nil, nil
+		}
+
+		targetResourceIds = resourceIds
+	}
+
 	infra, err := a.importManager.ProjectInfrastructure(ctx, a.projectConfig)
 	if err != nil {
 		return nil, err
@@ -133,6 +184,7 @@ func (a *downAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 	}
 	slices.Reverse(layers)
 
+	deletedResourceIds := []string{}
 	for _, layer := range layers {
 		if downLayer != "" || len(layers) > 1 {
 			a.console.EnsureBlankLine(ctx)
@@ -146,32 +198,177 @@ func (a *downAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		}
 
 		destroyOptions := provisioning.NewDestroyOptions(a.flags.forceDelete, a.flags.purgeDelete)
-		_, err := a.provisionManager.Destroy(ctx, destroyOptions)
-		if errors.Is(err, inf.ErrDeploymentsNotFound) || errors.Is(err, inf.ErrDeploymentResourcesNotFound) {
+		if target != "" {
+			destroyOptions = provisioning.NewDestroyResourcesOptions(
+				a.flags.forceDelete, a.flags.purgeDelete, targetResourceIds)
+		}
+		destroyResult, err := a.provisionManager.Destroy(ctx, destroyOptions)
+		if target != "" && (errors.Is(err, inf.ErrDeploymentsNotFound) ||
+			errors.Is(err, inf.ErrDeploymentResourcesNotFound)) {
+			// The resources of the target may be part of the deployment of another layer, they are reported below
+			continue
+		} else if errors.Is(err, inf.ErrDeploymentsNotFound) || errors.Is(err, inf.ErrDeploymentResourcesNotFound) {
 			a.console.MessageUxItem(ctx, &ux.DoneMessage{Message: "No Azure resources were found."})
 		} else if err != nil {
 			return nil, fmt.Errorf("deleting infrastructure: %w", err)
+		} else {
+			deletedResourceIds = append(deletedResourceIds, destroyResult.DeletedResourceIds...)
 		}
 	}
 
+	// Resources that weren't created by the deployment of any layer, like resources tagged manually, are never deleted
+	if skipped := skippedResourceIds(targetResourceIds, deletedResourceIds); len(skipped) > 0 {
+		a.console.EnsureBlankLine(ctx)
+		a.console.MessageUxItem(ctx, &ux.WarningMessage{
+			Description: fmt.Sprintf(
+				"The following resources aren't part of the deployment of environment '%s' and weren't deleted:",
+				a.env.Name()),
+		})
+		for _, resourceId := range skipped {
+			a.console.Message(ctx, fmt.Sprintf("  %s", output.WithGrayFormat(resourceId)))
+		}
+		a.console.Message(ctx, "")
+	}
+
 	// Invalidate cache after successful down so azd show will refresh
 	if err := a.envManager.InvalidateEnvCache(ctx, a.env.Name()); err != nil {
 		log.Printf("warning: failed to invalidate state cache: %v", err)
 	}
 
+	header := fmt.Sprintf("Your application was removed from Azure in %s.", ux.DurationAsText(since(startTime)))
+	if target != "" {
+		if len(deletedResourceIds) == 0 {
+			a.console.MessageUxItem(ctx, &ux.DoneMessage{
+				Message: fmt.Sprintf("No resources of %s were deleted.", target),
+			})
+			return nil, nil
+		}
+
+		// The deployment is kept, so 'azd provision' would skip an unchanged template unless its state is ignored
+		header = fmt.Sprintf(
+			"The resources of %s were removed from Azure in %s. Run '%s' to create them again.",
+			target,
+			ux.DurationAsText(since(startTime)),
+			"azd provision --no-state",
+		)
+	}
+
 	return &actions.ActionResult{
 		Message: &actions.ResultMessage{
-			Header: fmt.Sprintf("Your application was removed from Azure in %s.", ux.DurationAsText(since(startTime))),
+			Header: header,
 		},
 	}, nil
 }
 
+// target describes the service and resource selected with the --service and --resource flags, or returns an empty
+// string when all resources are deleted
+func (a *downAction) target() string {
+	var targets []string
+	if a.flags.service != "" {
+		targets = append(targets, fmt.Sprintf("service '%s'", a.flags.service))
+	}
+	if a.flags.resource != "" {
+		targets = append(targets, fmt.Sprintf("resource '%s'", a.flags.resource))
+	}
+
+	if len(targets) == 0 {
+		return ""
+	}
+
+	return ux.ListAsText(targets)
+}
+
+// targetResourceIds resolves the ids of the Azure resources of the service and resource selected with the --service
+// and --resource flags. The resources of a service are found by their azd-service-name tag, or by the resource name of
+// the service, while the id of a resource is an output of the deployment.
+func (a *downAction) targetResourceIds(ctx context.Context) ([]string, error) {
+	var resourceIds []string
+
+	if a.flags.service != "" {
+		serviceConfig, has := a.projectConfig.Services[a.flags.service]
+		if !has {
+			return nil, &internal.ErrorWithSuggestion{
+				Err:        fmt.Errorf("service '%s': %w", a.flags.service, internal.ErrServiceNotFound),
+				Suggestion: "Use the name of a service defined in the 'services' section of azure.yaml.",
+			}
+		}
+
+		subscriptionId := a.env.GetSubscriptionId()
+		resourceGroupName, err := a.resourceManager.GetResourceGroupName(
+			ctx, subscriptionId, serviceConfig.ResourceGroupName)
+		if err != nil {
+			return nil, &internal.ErrorWithSuggestion{
+				Err:        fmt.Errorf("finding the resource group of service '%s': %w", a.flags.service, err),
+				Suggestion: "Run 'azd provision' to provision the infrastructure of the service.",
+			}
+		}
+
+		resources, err := a.resourceManager.GetServiceResources(ctx, subscriptionId, resourceGroupName, serviceConfig)
+		if err != nil {
+			return nil, fmt.Errorf("finding the resources of service '%s': %w", a.flags.service, err)
+		}
+
+		for _, resource := range resources {
+			resourceIds = append(resourceIds, resource.Id)
+		}
+	}
+
+	if a.flags.resource != "" {
+		resourceConfig, has := a.projectConfig.Resources[a.flags.resource]
+		if !has {
+			return nil, &internal.ErrorWithSuggestion{
+				Err:        fmt.Errorf("resource '%s': %w", a.flags.resource, internal.ErrResourceNotConfigured),
+				Suggestion: "Use the name of a resource defined in the 'resources' section of azure.yaml.",
+			}
+		}
+
+		if resourceConfig.Existing {
+			return nil, &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf(
+					"resource '%s' is an existing resource: %w", a.flags.resource, internal.ErrUnsupportedOperation),
+				Suggestion: "Existing resources aren't created by azd and are never deleted by 'azd down'.",
+			}
+		}
+
+		resourceId, err := inf.ResourceId(a.flags.resource, a.env)
+		if err != nil {
+			return nil, &internal.ErrorWithSuggestion{
+				Err:        fmt.Errorf("resolving resource '%s': %w", a.flags.resource, internal.ErrInfraNotProvisioned),
+				Suggestion: "Run 'azd provision' to provision the resource before deleting it.",
+			}
+		}
+
+		resourceIds = append(resourceIds, resourceId.String())
+	}
+
+	return resourceIds, nil
+}
+
+// skippedResourceIds returns the target resources that weren't deleted, in the order of the targets
+func skippedResourceIds(targetResourceIds []string, deletedResourceIds []string) []string {
+	var skipped []string
+	for _, resourceId := range targetResourceIds {
+		if slices.ContainsFunc(deletedResourceIds, func(deletedId string) bool {
+			return strings.EqualFold(resourceId, deletedId)
+		}) || slices.Contains(skipped, resourceId) {
+			continue
+		}
+
+		skipped = append(skipped, resourceId)
+	}
+
+	return skipped
+}
+
 func getCmdDownHelpDescription(*cobra.Command) string {
 	return generateCmdHelpDescription(fmt.Sprintf(
 		"Delete Azure resources for an application. Running %s will not delete application"+
 			" files on your local machine.", output.WithHighLightFormat("azd down")), []string{
 		"When <layer> is specified, only deletes resources for the given layer." +
 			" When omitted, deletes resources for all layers defined in the project.",
+		"When --service or --resource is specified, only deletes the resources of the given service or resource." +
+			" The resource groups and the deployment are kept, run 'azd provision --no-state' to create the" +
+			" deleted resources again.",
 	})
 }
 
@@ -182,5 +379,40 @@ func getCmdDownHelpFooter(*cobra.Command) string {
 		"Forcibly delete all applications resources without confirmation.": output.WithHighLightFormat("azd down --force"),
 		"Permanently delete resources that are soft-deleted by default," +
 			" without confirmation.": output.WithHighLightFormat("azd down --purge"),
+		"Delete only the resources of the service 'api'.": output.WithHighLightFormat("azd down --service api"),
+		"Delete only the resource 'cache' from the resources of azure.yaml.": output.WithHighLightFormat(
+			"azd down --resource cache"),
 	})
 }
+
+// checkForceNoPrompt fails when 'azd down --force' runs with --no-prompt while the configuration, usually the azd policy,
+// disallows deleting resources without anyone confirming it.
+func (a *downAction) checkForceNoPrompt() error {
+	if !a.flags.forceDelete || !a.flags.global.NoPrompt {
+		return nil
+	}
+
+	azdConfig, err := a.configManager.Load()
+	if err != nil {
+		return fmt.Errorf("loading azd config: %w", err)
+	}
+
+	if value, _ := azdConfig.GetString(downForceNoPromptConfigKey); value != "off" {
+		return nil
+	}
+
+	if layered, ok := azdConfig.(*config.LayeredConfig); ok {
+		if violation := layered.Policy().Check(downForceNoPromptConfigKey); violation != nil {
+			return fmt.Errorf("'azd down --force' can't run with --no-prompt: %w", violation)
+		}
+	}
+
+	return &internal.ErrorWithSuggestion{
+		Err: fmt.Errorf(
+			"'azd down --force' can't run with --no-prompt since '%s' is 'off': %w",
+			downForceNoPromptConfigKey, internal.ErrInvalidArgValue),
+		Suggestion: fmt.Sprintf(
+			"Run 'azd down --force' interactively, or allow it with 'azd config set %s on'.",
+			downForceNoPromptConfigKey),
+	}
+}
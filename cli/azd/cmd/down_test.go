@@ -0,0 +1,77 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DownAction_CheckForceNoPrompt(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("AZD_CONFIG_DIR", configDir)
+
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+	configManager := config.NewLayeredUserConfigManager(
+		config.NewUserConfigManager(fileConfigManager),
+		fileConfigManager,
+		func() config.ConfigScope { return config.ConfigScope{} },
+	)
+
+	newAction := func(force bool, noPrompt bool) *downAction {
+		return &downAction{
+			flags: &downFlags{
+				forceDelete: force,
+				global:      &internal.GlobalCommandOptions{NoPrompt: noPrompt},
+			},
+			configManager: configManager,
+		}
+	}
+
+	// Allowed by default
+	require.NoError(t, newAction(true, true).checkForceNoPrompt())
+
+	azdConfig, err := configManager.Load()
+	require.NoError(t, err)
+	require.NoError(t, azdConfig.Set(downForceNoPromptConfigKey, "off"))
+	require.NoError(t, configManager.Save(azdConfig))
+
+	require.NoError(t, newAction(true, false).checkForceNoPrompt())
+	require.NoError(t, newAction(false, true).checkForceNoPrompt())
+
+	err = newAction(true, true).checkForceNoPrompt()
+	require.ErrorIs(t, err, internal.ErrInvalidArgValue)
+
+	// Reported as a violation of the policy when the policy disallows it
+	require.NoError(t, os.WriteFile(
+		filepath.Join(configDir, "policy.json"), []byte(`{"enforced":{"down.forceNoPrompt":"off"}}`), 0600))
+	t.Setenv("AZD_POLICY_FILE", filepath.Join(configDir, "policy.json"))
+
+	err = newAction(true, true).checkForceNoPrompt()
+	violation, ok := errors.AsType[*config.PolicyViolationError](err)
+	require.True(t, ok)
+	require.Equal(t, downForceNoPromptConfigKey, violation.PolicyKey)
+}
+
+func Test_SkippedResourceIds(t *testing.T) {
+	const (
+		storageId = "/subscriptions/SUB/resourceGroups/rg-core/providers/Microsoft.Storage/storageAccounts/st123"
+		siteId    = "/subscriptions/SUB/resourceGroups/rg-app/providers/Microsoft.Web/sites/app-123"
+		taggedId  = "/subscriptions/SUB/resourceGroups/rg-app/providers/Microsoft.Web/sites/app-tagged"
+	)
+
+	// Each layer deletes the resources of its own deployment, the others are reported once all layers were checked
+	deletedByLayers := []string{strings.ToUpper(storageId), siteId}
+
+	require.Equal(t, []string{taggedId}, skippedResourceIds([]string{storageId, siteId, taggedId}, deletedByLayers))
+	require.Empty(t, skippedResourceIds([]string{storageId, siteId}, deletedByLayers))
+	require.Equal(t, []string{taggedId}, skippedResourceIds([]string{taggedId, taggedId}, nil))
+}
@@ -69,6 +69,16 @@ func envActions(root *actions.ActionDescriptor) *actions.ActionDescriptor {
 		ActionResolver: newEnvSetSecretAction,
 	})
 
+	group.Add("rotate-secret", &actions.ActionDescriptorOptions{
+		Command:        newEnvRotateSecretCmd(),
+		FlagsResolver:  newEnvRotateSecretFlags,
+		ActionResolver: newEnvRotateSecretAction,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdEnvRotateSecretHelpDescription,
+			Footer:      getCmdEnvRotateSecretHelpFooter,
+		},
+	})
+
 	group.Add("select", &actions.ActionDescriptorOptions{
 		Command:        newEnvSelectCmd(),
 		ActionResolver: newEnvSelectAction,
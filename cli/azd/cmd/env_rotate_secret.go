@@ -0,0 +1,399 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/cmdsubst"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/keyvault"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/workflow"
+	"github.com/drone/envsubst"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type envRotateSecretFlags struct {
+	noProvision bool
+	noDeploy    bool
+	internal.EnvFlag
+}
+
+func (f *envRotateSecretFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.BoolVar(
+		&f.noProvision,
+		"no-provision",
+		false,
+		"Skip provisioning the infrastructure using the secret again. Its resources keep the previous value.",
+	)
+	local.BoolVar(&f.noDeploy, "no-deploy", false, "Skip redeploying the services consuming the secret.")
+	f.EnvFlag.Bind(local, global)
+}
+
+func newEnvRotateSecretFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *envRotateSecretFlags {
+	flags := &envRotateSecretFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newEnvRotateSecretCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rotate-secret <name>",
+		Short: "Rotate a Key Vault secret and update the resources and services using it.",
+		Args:  cobra.ExactArgs(1),
+	}
+}
+
+// rotatedSecret is a Key Vault secret rotated by `azd env rotate-secret`
+type rotatedSecret struct {
+	subscriptionId string
+	vaultName      string
+	secretName     string
+	// envKeys are the environment variables referencing the secret with an akvs:// reference
+	envKeys []string
+	// parameters are the infrastructure parameters using the secret, either generated with secretOrRandomPassword or
+	// set from an environment variable referencing the secret
+	parameters []string
+}
+
+type envRotateSecretAction struct {
+	args           []string
+	flags          *envRotateSecretFlags
+	console        input.Console
+	env            *environment.Environment
+	projectConfig  *project.ProjectConfig
+	kvService      keyvault.KeyVaultService
+	workflowRunner *workflow.Runner
+}
+
+func newEnvRotateSecretAction(
+	args []string,
+	flags *envRotateSecretFlags,
+	console input.Console,
+	env *environment.Environment,
+	projectConfig *project.ProjectConfig,
+	kvService keyvault.KeyVaultService,
+	workflowRunner *workflow.Runner,
+) actions.Action {
+	return &envRotateSecretAction{
+		args:           args,
+		flags:          flags,
+		console:        console,
+		env:            env,
+		projectConfig:  projectConfig,
+		kvService:      kvService,
+		workflowRunner: workflowRunner,
+	}
+}
+
+func (a *envRotateSecretAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	name := a.args[0]
+
+	secret, err := a.findSecret(name)
+	if err != nil {
+		return nil, err
+	}
+
+	a.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title: fmt.Sprintf("Rotating secret %s of Key Vault %s (azd env rotate-secret)", secret.secretName, secret.vaultName),
+	})
+
+	value, err := cmdsubst.GenerateRandomPassword()
+	if err != nil {
+		return nil, fmt.Errorf("generating secret value: %w", err)
+	}
+
+	stepMessage := "Writing a new version of the secret to Key Vault"
+	a.console.ShowSpinner(ctx, stepMessage, input.Step)
+	err = a.kvService.CreateKeyVaultSecret(ctx, secret.subscriptionId, secret.vaultName, secret.secretName, value)
+	a.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
+	if err != nil {
+		return nil, fmt.Errorf("writing secret '%s' to Key Vault '%s': %w", secret.secretName, secret.vaultName, err)
+	}
+
+	consumers := project.SecretConsumers(a.projectConfig, secret.envKeys)
+	a.reportConsumers(ctx, secret, consumers)
+
+	if len(secret.parameters) > 0 && !a.flags.noProvision {
+		provision := &workflow.Workflow{
+			Steps: []*workflow.Step{
+				workflow.NewAzdCommandStep("provision", "-e", a.env.Name()),
+			},
+		}
+
+		if err := a.workflowRunner.Run(ctx, provision); err != nil {
+			// The new version of the secret can't be undone, Key Vault is ahead of the provisioned resources
+			return nil, &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf(
+					"the secret was rotated in Key Vault, but provisioning its resources failed: %w", err),
+				Suggestion: fmt.Sprintf(
+					"Resources using the secret still have its previous value. Fix the error and run "+
+						"'azd provision -e %s' to update them with the new value.", a.env.Name()),
+			}
+		}
+	}
+
+	// Services receive the value of the secret in their configuration when they are deployed, restarting them would
+	// keep the previous value
+	services := secretServices(consumers)
+	followUp := ""
+	switch {
+	case len(services) == 0:
+	case a.flags.noDeploy:
+		followUp = fmt.Sprintf("Run 'azd deploy' for %s to update them with the new value.", ux.ListAsText(services))
+	default:
+		deploy := &workflow.Workflow{}
+		for _, service := range services {
+			deploy.Steps = append(deploy.Steps, workflow.NewAzdCommandStep("deploy", service, "-e", a.env.Name()))
+		}
+
+		if err := a.workflowRunner.Run(ctx, deploy); err != nil {
+			return nil, err
+		}
+
+		followUp = fmt.Sprintf("Redeployed %s with the new value.", ux.ListAsText(services))
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("The secret %s of Key Vault %s was rotated.",
+				output.WithBackticks(secret.secretName), output.WithBackticks(secret.vaultName)),
+			FollowUp: followUp,
+		},
+	}, nil
+}
+
+// findSecret resolves the Key Vault secret to rotate from the name of an environment variable referencing it, or from
+// the name of an infrastructure parameter or a secret generated with secretOrRandomPassword.
+func (a *envRotateSecretAction) findSecret(name string) (*rotatedSecret, error) {
+	parameters, err := a.secretParameters()
+	if err != nil {
+		return nil, err
+	}
+
+	var secret *rotatedSecret
+	if value, has := a.env.LookupEnv(name); has && keyvault.IsAzureKeyVaultSecret(value) {
+		akvs, err := keyvault.ParseAzureKeyVaultSecret(value)
+		if err != nil {
+			return nil, fmt.Errorf("parsing secret reference of '%s': %w", name, err)
+		}
+
+		secret = &rotatedSecret{
+			subscriptionId: akvs.SubscriptionId,
+			vaultName:      akvs.VaultName,
+			secretName:     akvs.SecretName,
+		}
+	} else {
+		for _, parameter := range parameters {
+			if !strings.EqualFold(parameter.name, name) && !strings.EqualFold(parameter.secretName, name) {
+				continue
+			}
+
+			if parameter.vaultName == "" {
+				return nil, &internal.ErrorWithSuggestion{
+					Err: fmt.Errorf(
+						"parameter '%s' generates a random password that isn't stored in Key Vault: %w",
+						parameter.name, internal.ErrUnsupportedOperation),
+					Suggestion: "Pass the Key Vault and the secret names to secretOrRandomPassword, for example " +
+						"'$(secretOrRandomPassword ${AZURE_KEY_VAULT_NAME} " + parameter.name + ")'.",
+				}
+			}
+
+			secret = &rotatedSecret{
+				subscriptionId: a.env.GetSubscriptionId(),
+				vaultName:      parameter.vaultName,
+				secretName:     parameter.secretName,
+			}
+			break
+		}
+	}
+
+	if secret == nil {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf("secret '%s' was not found in environment '%s': %w", name, a.env.Name(), internal.ErrKeyNotFound),
+			Suggestion: "Pass the name of an environment variable set with 'azd env set-secret', or the name of an " +
+				"infrastructure parameter or secret generated with secretOrRandomPassword.",
+		}
+	}
+
+	if secret.subscriptionId == "" {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"environment '%s' has not been provisioned: %w", a.env.Name(), internal.ErrInfraNotProvisioned),
+			Suggestion: "Run 'azd provision' to provision the infrastructure before rotating its secrets.",
+		}
+	}
+
+	// Find every environment variable and parameter using the same secret
+	for _, key := range slices.Sorted(maps.Keys(a.env.Dotenv())) {
+		akvs, err := keyvault.ParseAzureKeyVaultSecret(a.env.Getenv(key))
+		if err == nil && secret.matches(akvs.VaultName, akvs.SecretName) {
+			secret.envKeys = append(secret.envKeys, key)
+		}
+	}
+
+	for _, parameter := range parameters {
+		if secret.matches(parameter.vaultName, parameter.secretName) ||
+			slices.ContainsFunc(parameter.envKeys, func(key string) bool { return slices.Contains(secret.envKeys, key) }) {
+			secret.parameters = append(secret.parameters, parameter.name)
+		}
+	}
+
+	return secret, nil
+}
+
+// matches reports whether the secret is the secret of the given vault
+func (s *rotatedSecret) matches(vaultName string, secretName string) bool {
+	return strings.EqualFold(s.vaultName, vaultName) && strings.EqualFold(s.secretName, secretName)
+}
+
+// secretParameter is an infrastructure parameter that may use a secret
+type secretParameter struct {
+	name string
+	// The vault and the secret of the password generated with secretOrRandomPassword, if any
+	vaultName  string
+	secretName string
+	// The environment variables referenced by the parameter
+	envKeys []string
+}
+
+// secretParameters returns the parameters of the Bicep parameter files of every provisioning layer, with the secrets
+// they generate with secretOrRandomPassword and the environment variables they reference.
+func (a *envRotateSecretAction) secretParameters() ([]*secretParameter, error) {
+	parameters := []*secretParameter{}
+	for _, layer := range a.projectConfig.Infra.GetLayers() {
+		options, err := layer.GetWithDefaults()
+		if err != nil {
+			return nil, err
+		}
+
+		infraPath := options.Path
+		if !filepath.IsAbs(infraPath) {
+			infraPath = filepath.Join(a.projectConfig.Path, infraPath)
+		}
+
+		path := filepath.Join(infraPath, options.Module+".parameters.json")
+		content, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("reading parameters file: %w", err)
+		}
+
+		var parametersFile struct {
+			Parameters map[string]json.RawMessage `json:"parameters"`
+		}
+		if err := json.Unmarshal(content, &parametersFile); err != nil {
+			log.Printf("skipping parameters file %s: %v", path, err)
+			continue
+		}
+
+		for _, name := range slices.Sorted(maps.Keys(parametersFile.Parameters)) {
+			parameter := &secretParameter{name: name}
+			value, err := envsubst.Eval(string(parametersFile.Parameters[name]), func(key string) string {
+				parameter.envKeys = append(parameter.envKeys, key)
+				return a.env.Getenv(key)
+			})
+			if err != nil {
+				log.Printf("skipping parameter %s: %v", name, err)
+				continue
+			}
+
+			invocations := cmdsubst.FindCommandInvocations(value, cmdsubst.SecretOrRandomPasswordCommandName)
+			if len(invocations) > 0 && len(invocations[0]) == 2 {
+				parameter.vaultName = invocations[0][0]
+				parameter.secretName = invocations[0][1]
+			}
+
+			if len(invocations) > 0 || len(parameter.envKeys) > 0 {
+				parameters = append(parameters, parameter)
+			}
+		}
+	}
+
+	return parameters, nil
+}
+
+// reportConsumers lists the parameters, services and hooks using the secret
+func (a *envRotateSecretAction) reportConsumers(
+	ctx context.Context,
+	secret *rotatedSecret,
+	consumers []project.SecretConsumer,
+) {
+	lines := []string{}
+	for _, parameter := range secret.parameters {
+		lines = append(lines, fmt.Sprintf("  - infrastructure parameter %s", output.WithHighLightFormat(parameter)))
+	}
+
+	for _, consumer := range consumers {
+		switch {
+		case consumer.Service == "":
+			lines = append(lines, fmt.Sprintf("  - project hook %s (secret %s)",
+				output.WithHighLightFormat(consumer.Hook), consumer.EnvKey))
+		case consumer.Hook == "":
+			lines = append(lines, fmt.Sprintf("  - service %s (env %s)",
+				output.WithHighLightFormat(consumer.Service), consumer.EnvKey))
+		default:
+			lines = append(lines, fmt.Sprintf("  - %s hook of service %s (secret %s)",
+				output.WithHighLightFormat(consumer.Hook), consumer.Service, consumer.EnvKey))
+		}
+	}
+
+	if len(lines) == 0 {
+		a.console.Message(ctx, output.WithGrayFormat("No parameters, services or hooks of the project use the secret."))
+		return
+	}
+
+	a.console.Message(ctx, "The secret is used by:\n"+strings.Join(lines, "\n")+"\n")
+}
+
+// secretServices returns the names of the services consuming the secret through their environment
+func secretServices(consumers []project.SecretConsumer) []string {
+	services := []string{}
+	for _, consumer := range consumers {
+		if consumer.Service != "" && consumer.Hook == "" && !slices.Contains(services, consumer.Service) {
+			services = append(services, consumer.Service)
+		}
+	}
+
+	return services
+}
+
+func getCmdEnvRotateSecretHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		"Generate a new value for a Key Vault secret and update the resources and services using it.",
+		[]string{
+			formatHelpNote("The name is either an environment variable set with 'azd env set-secret', or an " +
+				"infrastructure parameter or Key Vault secret generated with secretOrRandomPassword."),
+			formatHelpNote("The new value is written to Key Vault as a new version of the secret. Infrastructure " +
+				"generating the secret is provisioned again and services using it are redeployed."),
+		})
+}
+
+func getCmdEnvRotateSecretHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Rotate the secret referenced by the DB_PASSWORD environment variable.": output.WithHighLightFormat(
+			"azd env rotate-secret DB_PASSWORD",
+		),
+		"Rotate the password generated for the sqlAdminPassword parameter.": output.WithHighLightFormat(
+			"azd env rotate-secret sqlAdminPassword",
+		),
+	})
+}
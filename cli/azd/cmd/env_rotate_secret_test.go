@@ -0,0 +1,113 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvRotateSecretFindSecret(t *testing.T) {
+	projectDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, "infra"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "infra", "main.parameters.json"), []byte(`{
+  "parameters": {
+    "location": { "value": "${AZURE_LOCATION}" },
+    "sqlAdminPassword": {
+      "value": "$(secretOrRandomPassword ${AZURE_KEY_VAULT_NAME} sqlAdminPassword)"
+    },
+    "apiKey": { "value": "${API_KEY}" },
+    "jwtSecret": { "value": "$(secretOrRandomPassword)" }
+  }
+}`), 0600))
+
+	env := environment.NewWithValues("dev", map[string]string{
+		environment.SubscriptionIdEnvVarName: "SUBSCRIPTION_ID",
+		"AZURE_LOCATION":                     "eastus2",
+		"AZURE_KEY_VAULT_NAME":               "kv-dev",
+		"API_KEY":                            "akvs://SUBSCRIPTION_ID/kv-dev/api-key",
+		"API_KEY_COPY":                       "akvs://SUBSCRIPTION_ID/KV-DEV/API-KEY",
+		"OTHER_SECRET":                       "akvs://SUBSCRIPTION_ID/kv-dev/other",
+	})
+
+	action := &envRotateSecretAction{
+		env:           env,
+		projectConfig: &project.ProjectConfig{Path: projectDir},
+	}
+
+	t.Run("EnvironmentVariable", func(t *testing.T) {
+		secret, err := action.findSecret("API_KEY")
+		require.NoError(t, err)
+		require.Equal(t, "kv-dev", secret.vaultName)
+		require.Equal(t, "api-key", secret.secretName)
+		require.Equal(t, []string{"API_KEY", "API_KEY_COPY"}, secret.envKeys)
+		require.Equal(t, []string{"apiKey"}, secret.parameters)
+	})
+
+	t.Run("Parameter", func(t *testing.T) {
+		secret, err := action.findSecret("sqlAdminPassword")
+		require.NoError(t, err)
+		require.Equal(t, "SUBSCRIPTION_ID", secret.subscriptionId)
+		require.Equal(t, "kv-dev", secret.vaultName)
+		require.Equal(t, "sqlAdminPassword", secret.secretName)
+		require.Empty(t, secret.envKeys)
+		require.Equal(t, []string{"sqlAdminPassword"}, secret.parameters)
+	})
+
+	t.Run("NotInKeyVault", func(t *testing.T) {
+		_, err := action.findSecret("jwtSecret")
+		require.True(t, errors.Is(err, internal.ErrUnsupportedOperation))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := action.findSecret("AZURE_LOCATION")
+		require.True(t, errors.Is(err, internal.ErrKeyNotFound))
+	})
+}
+
+func TestEnvRotateSecretServices(t *testing.T) {
+	services := secretServices([]project.SecretConsumer{
+		{Hook: "postprovision", EnvKey: "DB_PASSWORD"},
+		{Service: "api", EnvKey: "DB_PASSWORD"},
+		{Service: "api", Hook: "predeploy", EnvKey: "DB_PASSWORD"},
+		{Service: "worker", Hook: "prepackage", EnvKey: "DB_PASSWORD"},
+		{Service: "web", EnvKey: "DB_PASSWORD_COPY"},
+	})
+
+	// Hooks read the secret when they run, only the services consuming it through their environment are redeployed
+	require.Equal(t, []string{"api", "web"}, services)
+}
+
+func TestEnvRotateSecretAbsoluteInfraPath(t *testing.T) {
+	infraDir := filepath.Join(t.TempDir(), "shared-infra")
+	require.NoError(t, os.MkdirAll(infraDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(infraDir, "main.parameters.json"), []byte(`{
+  "parameters": {
+    "apiKey": { "value": "${API_KEY}" }
+  }
+}`), 0600))
+
+	action := &envRotateSecretAction{
+		env: environment.NewWithValues("dev", map[string]string{
+			"API_KEY": "akvs://SUBSCRIPTION_ID/kv-dev/api-key",
+		}),
+		projectConfig: &project.ProjectConfig{
+			Path:  t.TempDir(),
+			Infra: provisioning.Options{Path: infraDir},
+		},
+	}
+
+	parameters, err := action.secretParameters()
+	require.NoError(t, err)
+	require.Len(t, parameters, 1)
+	require.Equal(t, "apiKey", parameters[0].name)
+}
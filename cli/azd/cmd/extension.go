@@ -8,6 +8,8 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
+	"path/filepath"
 	"slices"
 	"strings"
 	"text/tabwriter"
@@ -15,10 +17,13 @@ import (
 	"github.com/Masterminds/semver/v3"
 	"github.com/azure/azure-dev/cli/azd/cmd/actions"
 	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
 	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
 	"github.com/azure/azure-dev/cli/azd/pkg/output"
 	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
 	uxlib "github.com/azure/azure-dev/cli/azd/pkg/ux"
 	"github.com/spf13/cobra"
 )
@@ -70,6 +75,38 @@ func extensionActions(root *actions.ActionDescriptor) *actions.ActionDescriptor
 		FlagsResolver:  newExtensionInstallFlags,
 	})
 
+	// azd extension lock
+	group.Add("lock", &actions.ActionDescriptorOptions{
+		Command: &cobra.Command{
+			Use:   "lock",
+			Short: "Resolve the extensions required by the project and record them in azd.lock.",
+			Long: "Resolve the extensions required by the project and record them in azd.lock.\n\n" +
+				"Resolves the extensions listed in 'requiredVersions.extensions' of azure.yaml together with\n" +
+				"their dependencies, and writes the exact versions and artifact checksums to azd.lock.\n" +
+				"Check azd.lock into source control and run 'azd extension install --from-lock' to\n" +
+				"reproduce the same set of extensions, for example in CI.",
+		},
+		ActionResolver: newExtensionLockAction,
+		OutputFormats:  []output.Format{output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+	})
+
+	// azd extension bundle <extension-id>
+	group.Add("bundle", &actions.ActionDescriptorOptions{
+		Command: &cobra.Command{
+			Use:   "bundle <extension-id>",
+			Short: "Export extensions and their artifacts into an offline bundle.",
+			Long: "Export extensions and their artifacts into an offline bundle.\n\n" +
+				"Resolves the specified extensions together with their dependencies and writes their registry\n" +
+				"entries and per-platform artifacts into a single archive. Copy the archive to a machine without\n" +
+				"internet access and run 'azd extension source add --type bundle <file>' to install from it.",
+		},
+		ActionResolver: newExtensionBundleAction,
+		FlagsResolver:  newExtensionBundleFlags,
+		OutputFormats:  []output.Format{output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+	})
+
 	// azd extension uninstall <extension-id>
 	group.Add("uninstall", &actions.ActionDescriptorOptions{
 		Command: &cobra.Command{
@@ -109,7 +146,7 @@ func extensionActions(root *actions.ActionDescriptor) *actions.ActionDescriptor
 
 	sourceGroup.Add("add", &actions.ActionDescriptorOptions{
 		Command: &cobra.Command{
-			Use:   "add",
+			Use:   "add [location]",
 			Short: "Add an extension source with the specified name",
 		},
 		ActionResolver: newExtensionSourceAddAction,
@@ -603,10 +640,11 @@ func (a *extensionShowAction) Run(ctx context.Context) (*actions.ActionResult, e
 }
 
 type extensionInstallFlags struct {
-	version string
-	source  string
-	force   bool
-	global  *internal.GlobalCommandOptions
+	version  string
+	source   string
+	force    bool
+	fromLock bool
+	global   *internal.GlobalCommandOptions
 }
 
 func newExtensionInstallFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *extensionInstallFlags {
@@ -618,6 +656,12 @@ func newExtensionInstallFlags(cmd *cobra.Command, global *internal.GlobalCommand
 	cmd.Flags().StringVarP(&flags.version, "version", "v", "", "The version of the extension to install")
 	cmd.Flags().
 		BoolVarP(&flags.force, "force", "f", false, "Force installation, including downgrades and reinstalls")
+	cmd.Flags().BoolVar(
+		&flags.fromLock,
+		"from-lock",
+		false,
+		"Install the exact extension versions recorded in the project's azd.lock file",
+	)
 
 	return flags
 }
@@ -628,6 +672,7 @@ type extensionInstallAction struct {
 	flags            *extensionInstallFlags
 	console          input.Console
 	extensionManager *extensions.Manager
+	lazyAzdCtx       *lazy.Lazy[*azdcontext.AzdContext]
 }
 
 func newExtensionInstallAction(
@@ -635,12 +680,14 @@ func newExtensionInstallAction(
 	flags *extensionInstallFlags,
 	console input.Console,
 	extensionManager *extensions.Manager,
+	lazyAzdCtx *lazy.Lazy[*azdcontext.AzdContext],
 ) actions.Action {
 	return &extensionInstallAction{
 		args:             args,
 		flags:            flags,
 		console:          console,
 		extensionManager: extensionManager,
+		lazyAzdCtx:       lazyAzdCtx,
 	}
 }
 
@@ -650,6 +697,10 @@ func (a *extensionInstallAction) Run(ctx context.Context) (*actions.ActionResult
 		TitleNote: "Installs the specified extension onto the local machine",
 	})
 
+	if a.flags.fromLock {
+		return a.runFromLock(ctx)
+	}
+
 	extensionIds := a.args
 	if len(extensionIds) == 0 {
 		return nil, &internal.ErrorWithSuggestion{
@@ -809,6 +860,272 @@ func (a *extensionInstallAction) Run(ctx context.Context) (*actions.ActionResult
 	}, nil
 }
 
+// runFromLock installs the extensions recorded in the project's lock file.
+func (a *extensionInstallAction) runFromLock(ctx context.Context) (*actions.ActionResult, error) {
+	if len(a.args) > 0 || a.flags.version != "" || a.flags.source != "" {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"cannot specify extensions, --version or --source with --from-lock: %w",
+				internal.ErrInvalidFlagCombination),
+			Suggestion: "Run 'azd extension install --from-lock' without additional arguments.",
+		}
+	}
+
+	azdCtx, err := a.lazyAzdCtx.GetValue()
+	if err != nil {
+		return nil, err
+	}
+
+	projectConfig, err := project.Load(ctx, azdCtx.ProjectPath())
+	if err != nil {
+		return nil, fmt.Errorf("loading project config: %w", err)
+	}
+
+	lockFile, err := extensions.LoadLockFile(filepath.Join(azdCtx.ProjectDirectory(), extensions.LockFileName))
+	if errors.Is(err, extensions.ErrLockFileNotFound) {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        err,
+			Suggestion: "Run 'azd extension lock' to create the lock file.",
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := lockFile.Validate(extensionRequirements(projectConfig)); err != nil {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        fmt.Errorf("%s is out of date: %w", extensions.LockFileName, err),
+			Suggestion: "Run 'azd extension lock' to update the lock file.",
+		}
+	}
+
+	if err := installLockedExtensions(ctx, a.console, a.extensionManager, lockFile); err != nil {
+		return nil, err
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: "Extension(s) installed successfully",
+		},
+	}, nil
+}
+
+// installLockedExtensions installs every extension recorded in the lock file, dependencies first.
+func installLockedExtensions(
+	ctx context.Context,
+	console input.Console,
+	extensionManager *extensions.Manager,
+	lockFile *extensions.LockFile,
+) error {
+	for _, locked := range lockFile.Extensions {
+		stepMessage := fmt.Sprintf("Installing %s extension", output.WithHighLightFormat(locked.Id))
+		console.ShowSpinner(ctx, stepMessage, input.Step)
+
+		extensionVersion, err := extensionManager.InstallLocked(ctx, locked)
+		if errors.Is(err, extensions.ErrExtensionInstalled) {
+			stepMessage += output.WithGrayFormat(" (version %s already installed)", locked.Version)
+			console.StopSpinner(ctx, stepMessage, input.StepSkipped)
+			continue
+		}
+		if err != nil {
+			console.StopSpinner(ctx, stepMessage, input.StepFailed)
+			return fmt.Errorf("installing extension %s: %w", locked.Id, err)
+		}
+
+		stepMessage += output.WithGrayFormat(" (%s)", extensionVersion.Version)
+		console.StopSpinner(ctx, stepMessage, input.StepDone)
+	}
+
+	return nil
+}
+
+// extensionRequirements returns the extension requirements declared in the project's azure.yaml.
+func extensionRequirements(projectConfig *project.ProjectConfig) []extensions.ExtensionRequirement {
+	if projectConfig.RequiredVersions == nil {
+		return nil
+	}
+
+	requirements := []extensions.ExtensionRequirement{}
+	for _, id := range slices.Sorted(maps.Keys(projectConfig.RequiredVersions.Extensions)) {
+		requirement := extensions.ExtensionRequirement{Id: id}
+		if constraint := projectConfig.RequiredVersions.Extensions[id]; constraint != nil {
+			requirement.Version = *constraint
+		}
+
+		requirements = append(requirements, requirement)
+	}
+
+	return requirements
+}
+
+// azd extension lock
+type extensionLockAction struct {
+	console          input.Console
+	azdCtx           *azdcontext.AzdContext
+	extensionManager *extensions.Manager
+}
+
+func newExtensionLockAction(
+	console input.Console,
+	azdCtx *azdcontext.AzdContext,
+	extensionManager *extensions.Manager,
+) actions.Action {
+	return &extensionLockAction{
+		console:          console,
+		azdCtx:           azdCtx,
+		extensionManager: extensionManager,
+	}
+}
+
+func (a *extensionLockAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	a.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title:     "Lock azd extensions (azd extension lock)",
+		TitleNote: "Resolves the extensions required by the project and records the exact versions",
+	})
+
+	projectConfig, err := project.Load(ctx, a.azdCtx.ProjectPath())
+	if err != nil {
+		return nil, fmt.Errorf("loading project config: %w", err)
+	}
+
+	requirements := extensionRequirements(projectConfig)
+	if len(requirements) == 0 {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        fmt.Errorf("the project does not require any extensions: %w", internal.ErrNoExtensionsAvailable),
+			Suggestion: "Add extensions to 'requiredVersions.extensions' in azure.yaml before running 'azd extension lock'.",
+		}
+	}
+
+	stepMessage := "Resolving extension dependencies"
+	a.console.ShowSpinner(ctx, stepMessage, input.Step)
+
+	resolved, err := extensions.NewDependencyResolver(a.extensionManager).Resolve(ctx, requirements)
+	if err != nil {
+		a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+		return nil, err
+	}
+
+	lockFile := extensions.NewLockFile(resolved)
+	if err := lockFile.Save(filepath.Join(a.azdCtx.ProjectDirectory(), extensions.LockFileName)); err != nil {
+		a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+		return nil, err
+	}
+
+	a.console.StopSpinner(ctx, stepMessage, input.StepDone)
+
+	for _, locked := range lockFile.Extensions {
+		a.console.Message(ctx, fmt.Sprintf("  %s %s", locked.Id, output.WithGrayFormat("(%s)", locked.Version)))
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Locked %d extension(s) in %s", len(lockFile.Extensions), extensions.LockFileName),
+		},
+	}, nil
+}
+
+// azd extension bundle
+type extensionBundleFlags struct {
+	version   string
+	source    string
+	file      string
+	platforms []string
+}
+
+func newExtensionBundleFlags(cmd *cobra.Command) *extensionBundleFlags {
+	flags := &extensionBundleFlags{}
+
+	cmd.Flags().StringVarP(&flags.source, "source", "s", "", "The extension source to bundle extensions from")
+	cmd.Flags().StringVarP(&flags.version, "version", "v", "", "The version constraint of the extension to bundle")
+	cmd.Flags().StringVar(&flags.file, "file", "azd-extensions.zip", "Path of the bundle file to create")
+	cmd.Flags().StringSliceVar(
+		&flags.platforms,
+		"platform",
+		nil,
+		"Platforms to include artifacts for (e.g. linux/amd64). Includes all platforms when not specified",
+	)
+
+	return flags
+}
+
+type extensionBundleAction struct {
+	args             []string
+	flags            *extensionBundleFlags
+	console          input.Console
+	extensionManager *extensions.Manager
+}
+
+func newExtensionBundleAction(
+	args []string,
+	flags *extensionBundleFlags,
+	console input.Console,
+	extensionManager *extensions.Manager,
+) actions.Action {
+	return &extensionBundleAction{
+		args:             args,
+		flags:            flags,
+		console:          console,
+		extensionManager: extensionManager,
+	}
+}
+
+func (a *extensionBundleAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if len(a.args) == 0 {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        internal.ErrNoArgsProvided,
+			Suggestion: "Run 'azd extension bundle <extension-id>' specifying one or more extensions.",
+		}
+	}
+
+	if len(a.args) > 1 && a.flags.version != "" {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"cannot specify --version with multiple extensions: %w",
+				internal.ErrInvalidFlagCombination),
+			Suggestion: "Bundle one extension at a time when using --version.",
+		}
+	}
+
+	a.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title:     "Bundle azd extensions (azd extension bundle)",
+		TitleNote: "Exports extensions and their artifacts for offline installs",
+	})
+
+	requirements := make([]extensions.ExtensionRequirement, 0, len(a.args))
+	for _, extensionId := range a.args {
+		requirements = append(requirements, extensions.ExtensionRequirement{
+			Id:      extensionId,
+			Version: a.flags.version,
+			Source:  a.flags.source,
+		})
+	}
+
+	stepMessage := fmt.Sprintf("Creating bundle %s", output.WithHighLightFormat(a.flags.file))
+	a.console.ShowSpinner(ctx, stepMessage, input.Step)
+
+	resolved, err := a.extensionManager.CreateBundle(ctx, requirements, a.flags.file, &extensions.BundleOptions{
+		Platforms: a.flags.platforms,
+	})
+	a.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range resolved {
+		a.console.Message(ctx, fmt.Sprintf("  %s %s", r.Metadata.Id, output.WithGrayFormat("(%s)", r.Version.Version)))
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("Bundled %d extension(s) into %s", len(resolved), a.flags.file),
+			FollowUp: fmt.Sprintf(
+				"Run %s on the target machine to install from the bundle.",
+				output.WithHighLightFormat("azd extension source add --type bundle %s", filepath.Base(a.flags.file)),
+			),
+		},
+	}, nil
+}
+
 // azd extension uninstall
 type extensionUninstallFlags struct {
 	all bool
@@ -1182,7 +1499,7 @@ func newExtensionSourceAddFlags(cmd *cobra.Command) *extensionSourceAddFlags {
 	cmd.Flags().StringVarP(&flags.name, "name", "n", "", "The name of the extension source")
 	cmd.Flags().StringVarP(&flags.location, "location", "l", "", "The location of the extension source")
 	cmd.Flags().StringVarP(&flags.kind,
-		"type", "t", "", "The type of the extension source. Supported types are 'file' and 'url'")
+		"type", "t", "", "The type of the extension source. Supported types are 'file', 'url' and 'bundle'")
 
 	return flags
 }
@@ -1191,6 +1508,7 @@ type extensionSourceAddAction struct {
 	flags         *extensionSourceAddFlags
 	console       input.Console
 	sourceManager *extensions.SourceManager
+	args          []string
 }
 
 func newExtensionSourceAddAction(
@@ -1203,6 +1521,7 @@ func newExtensionSourceAddAction(
 		flags:         flags,
 		console:       console,
 		sourceManager: sourceManager,
+		args:          args,
 	}
 }
 
@@ -1211,6 +1530,15 @@ func (a *extensionSourceAddAction) Run(ctx context.Context) (*actions.ActionResu
 		Title: "Add extension source (azd extension source add)",
 	})
 
+	if a.flags.location == "" && len(a.args) > 0 {
+		a.flags.location = a.args[0]
+	}
+
+	// Bundles default to the name of the bundle file
+	if a.flags.name == "" && extensions.SourceKind(a.flags.kind) == extensions.SourceKindBundle {
+		a.flags.name = strings.TrimSuffix(filepath.Base(a.flags.location), filepath.Ext(a.flags.location))
+	}
+
 	spinnerMessage := "Validating extension source"
 	a.console.ShowSpinner(ctx, spinnerMessage, input.Step)
 
@@ -1229,7 +1557,10 @@ func (a *extensionSourceAddAction) Run(ctx context.Context) (*actions.ActionResu
 				Err: fmt.Errorf(
 					"extension source type '%s' not supported: %w",
 					a.flags.kind, internal.ErrValidationFailed),
-				Suggestion: fmt.Sprintf("Supported source types are %s.", ux.ListAsText([]string{"'file'", "'url'"})),
+				Suggestion: fmt.Sprintf(
+					"Supported source types are %s.",
+					ux.ListAsText([]string{"'file'", "'url'", "'bundle'"}),
+				),
 			}
 		}
 
@@ -1610,6 +1941,8 @@ func (a *extensionSourceValidateAction) Run(ctx context.Context) (*actions.Actio
 		kind := extensions.SourceKindFile
 		if strings.HasPrefix(arg, "http://") || strings.HasPrefix(arg, "https://") {
 			kind = extensions.SourceKindUrl
+		} else if strings.EqualFold(filepath.Ext(arg), ".zip") {
+			kind = extensions.SourceKindBundle
 		}
 		sourceConfig = &extensions.SourceConfig{
 			Name:     "validate",
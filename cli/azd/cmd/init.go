@@ -62,6 +62,7 @@ type initFlags struct {
 	templatePath   string
 	templateBranch string
 	templateTags   []string
+	templateValues []string
 	subscription   string
 	location       string
 	global         *internal.GlobalCommandOptions
@@ -93,6 +94,12 @@ func (i *initFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOpt
 		[]string{},
 		"The tag(s) used to filter template results. Supports comma-separated values.",
 	)
+	local.StringArrayVar(
+		&i.templateValues,
+		"set",
+		[]string{},
+		"Sets an input of a parameterized template as <name>=<value>, instead of prompting for it. Can be repeated.",
+	)
 	local.StringVarP(
 		&i.subscription,
 		"subscription",
@@ -194,6 +201,14 @@ func (i *initAction) Run(ctx context.Context) (*actions.ActionResult, error) {
 		}
 	}
 
+	if len(i.flags.templateValues) > 0 && (i.flags.fromCode || i.flags.minimal) {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"'--set' cannot be used with '--from-code' or '--minimal': %w", internal.ErrInvalidFlagCombination),
+			Suggestion: "Use '--set' with '--template' to set the inputs of a parameterized template.",
+		}
+	}
+
 	// ensure that git is available
 	if err := tools.EnsureInstalled(ctx, []tools.ExternalTool{i.gitCli}...); err != nil {
 		return nil, err
@@ -619,7 +634,12 @@ func (i *initAction) initializeTemplate(
 		}
 	}
 
-	err = i.repoInitializer.Initialize(ctx, azdCtx, initFromTemplate, i.flags.templateBranch)
+	templateValues, err := parseTemplateValues(i.flags.templateValues)
+	if err != nil {
+		return templates.Template{}, err
+	}
+
+	err = i.repoInitializer.Initialize(ctx, azdCtx, initFromTemplate, i.flags.templateBranch, templateValues)
 	if err != nil {
 		return templates.Template{}, fmt.Errorf("init from template repository: %w", err)
 	}
@@ -713,6 +733,24 @@ func (i *initAction) initializeExtensions(ctx context.Context, azdCtx *azdcontex
 		return nil
 	}
 
+	// Prefer the exact versions recorded in the lock file when the project has one
+	lockFile, err := extensions.LoadLockFile(filepath.Join(azdCtx.ProjectDirectory(), extensions.LockFileName))
+	if err != nil && !errors.Is(err, extensions.ErrLockFileNotFound) {
+		return err
+	}
+
+	if lockFile != nil {
+		if err := lockFile.Validate(extensionRequirements(projectConfig)); err != nil {
+			return &internal.ErrorWithSuggestion{
+				Err:        fmt.Errorf("%s is out of date: %w", extensions.LockFileName, err),
+				Suggestion: "Run 'azd extension lock' to update the lock file.",
+			}
+		}
+
+		i.console.Message(ctx, "\nInstalling required extensions from lock file...")
+		return installLockedExtensions(ctx, i.console, i.extensionsManager, lockFile)
+	}
+
 	installedExtensions, err := i.extensionsManager.ListInstalled()
 	if err != nil {
 		return fmt.Errorf("listing installed extensions: %w", err)
@@ -798,6 +836,11 @@ func getCmdInitHelpFooter(*cobra.Command) string {
 			output.WithHighLightFormat("--branch"),
 			output.WithWarningFormat("[Branch name]"),
 		),
+		"Initialize a parameterized template without prompting for its inputs.": fmt.Sprintf("%s %s %s",
+			output.WithHighLightFormat("azd init --template"),
+			output.WithWarningFormat("[GitHub repo URL]"),
+			output.WithHighLightFormat("--set database=postgres --no-prompt"),
+		),
 	})
 }
 
@@ -870,3 +913,21 @@ type initModeRequiredErrorOptions struct {
 	Description string `json:"description"`
 	Command     string `json:"command"`
 }
+
+// parseTemplateValues parses the inputs of a parameterized template set as '--set <name>=<value>'
+func parseTemplateValues(args []string) (map[string]string, error) {
+	templateValues := map[string]string{}
+	for _, arg := range args {
+		name, value, err := parseKeyValue(arg)
+		if err != nil || name == "" {
+			return nil, &internal.ErrorWithSuggestion{
+				Err:        fmt.Errorf("invalid '--set' value '%s': %w", arg, internal.ErrInvalidArgValue),
+				Suggestion: "Set template inputs as '--set <name>=<value>'.",
+			}
+		}
+
+		templateValues[name] = value
+	}
+
+	return templateValues, nil
+}
@@ -0,0 +1,469 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type logsFlags struct {
+	follow bool
+	since  time.Duration
+	filter string
+	kql    string
+	global *internal.GlobalCommandOptions
+	internal.EnvFlag
+}
+
+func (f *logsFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.BoolVarP(&f.follow, "follow", "f", false, "Keep streaming new log entries until the command is cancelled.")
+	local.DurationVar(
+		&f.since,
+		"since",
+		0,
+		"Only include log entries newer than the specified duration, for example 30m or 1h. "+
+			"Supported when streaming the logs of services hosted on AKS, and with --kql.",
+	)
+	local.StringVar(&f.filter, "filter", "", "Only include log lines containing the specified text (case-insensitive).")
+	// --query is reserved for the JMESPath filter of JSON output
+	local.StringVar(
+		&f.kql,
+		"kql",
+		"",
+		"Run the specified KQL query against the Log Analytics workspace of the environment instead of streaming logs.",
+	)
+	f.EnvFlag.Bind(local, global)
+	f.global = global
+}
+
+func newLogsFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *logsFlags {
+	flags := &logsFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newLogsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "logs [<service>]",
+		Short: "Stream or query the logs of a deployed project.",
+		Args:  cobra.MaximumNArgs(1),
+	}
+}
+
+type logsAction struct {
+	args            []string
+	flags           *logsFlags
+	console         input.Console
+	formatter       output.Formatter
+	writer          io.Writer
+	env             *environment.Environment
+	projectConfig   *project.ProjectConfig
+	importManager   *project.ImportManager
+	serviceManager  project.ServiceManager
+	resourceManager infra.ResourceManager
+	resourceService *azapi.ResourceService
+	azureClient     *azapi.AzureClient
+}
+
+func newLogsAction(
+	args []string,
+	flags *logsFlags,
+	console input.Console,
+	formatter output.Formatter,
+	writer io.Writer,
+	env *environment.Environment,
+	projectConfig *project.ProjectConfig,
+	importManager *project.ImportManager,
+	serviceManager project.ServiceManager,
+	resourceManager infra.ResourceManager,
+	resourceService *azapi.ResourceService,
+	azureClient *azapi.AzureClient,
+) actions.Action {
+	return &logsAction{
+		args:            args,
+		flags:           flags,
+		console:         console,
+		formatter:       formatter,
+		writer:          writer,
+		env:             env,
+		projectConfig:   projectConfig,
+		importManager:   importManager,
+		serviceManager:  serviceManager,
+		resourceManager: resourceManager,
+		resourceService: resourceService,
+		azureClient:     azureClient,
+	}
+}
+
+func (a *logsAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if a.env.GetSubscriptionId() == "" {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        internal.ErrInfraNotProvisioned,
+			Suggestion: "Run 'azd provision' to set up infrastructure before viewing logs.",
+		}
+	}
+
+	if a.flags.kql != "" {
+		if len(a.args) > 0 {
+			return nil, &internal.ErrorWithSuggestion{
+				Err:        fmt.Errorf("a service name can't be combined with --kql: %w", internal.ErrInvalidFlagCombination),
+				Suggestion: "Filter the query on the resource of the service instead, for example with '_ResourceId'.",
+			}
+		}
+
+		return nil, a.runQuery(ctx)
+	}
+
+	if a.formatter.Kind() != output.NoneFormat {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"--output %s is only supported with --kql: %w", a.formatter.Kind(), internal.ErrInvalidFlagCombination),
+			Suggestion: "Remove --output to stream logs, or specify a KQL query with --kql.",
+		}
+	}
+
+	return nil, a.streamLogs(ctx)
+}
+
+// serviceLogStream is a service whose logs can be streamed from its target resource.
+type serviceLogStream struct {
+	serviceConfig  *project.ServiceConfig
+	streamer       project.ServiceLogStreamer
+	targetResource *environment.TargetResource
+}
+
+func (a *logsAction) streamLogs(ctx context.Context) error {
+	targetServiceName := ""
+	if len(a.args) == 1 {
+		targetServiceName = a.args[0]
+
+		if has, err := a.importManager.HasService(ctx, a.projectConfig, targetServiceName); err != nil {
+			return err
+		} else if !has {
+			return fmt.Errorf("service name '%s' doesn't exist", targetServiceName)
+		}
+	}
+
+	stableServices, err := a.importManager.ServiceStableFiltered(ctx, a.projectConfig, targetServiceName, a.env.Getenv)
+	if err != nil {
+		return err
+	}
+
+	stepMessage := "Resolving service resources"
+	a.console.ShowSpinner(ctx, stepMessage, input.Step)
+
+	streams := []*serviceLogStream{}
+	for _, svc := range stableServices {
+		serviceTarget, err := a.serviceManager.GetServiceTarget(ctx, svc)
+		if err != nil {
+			a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+			return err
+		}
+
+		streamer, ok := serviceTarget.(project.ServiceLogStreamer)
+		if !ok {
+			if targetServiceName != "" {
+				a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+				return &internal.ErrorWithSuggestion{
+					Err: fmt.Errorf(
+						"streaming logs is not supported for service '%s' with host '%s': %w",
+						svc.Name, svc.Host, internal.ErrUnsupportedOperation),
+					Suggestion: "Use 'azd logs --kql' to query the logs collected in Log Analytics instead.",
+				}
+			}
+
+			log.Printf("skipping logs of service '%s', host '%s' doesn't support streaming logs", svc.Name, svc.Host)
+			continue
+		}
+
+		if a.flags.since > 0 {
+			if sinceStreamer, ok := streamer.(project.ServiceLogSinceStreamer); !ok || !sinceStreamer.SupportsLogsSince() {
+				a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+				return &internal.ErrorWithSuggestion{
+					Err: fmt.Errorf(
+						"--since is not supported when streaming the logs of service '%s' with host '%s': %w",
+						svc.Name, svc.Host, internal.ErrInvalidFlagCombination),
+					Suggestion: "Remove --since to stream the most recent logs, or use 'azd logs --kql' with --since " +
+						"to query the logs collected in Log Analytics.",
+				}
+			}
+		}
+
+		targetResource, err := a.serviceManager.GetTargetResource(ctx, svc, serviceTarget)
+		if err != nil {
+			a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+			return fmt.Errorf("getting target resource for service '%s': %w", svc.Name, err)
+		}
+
+		streams = append(streams, &serviceLogStream{
+			serviceConfig:  svc,
+			streamer:       streamer,
+			targetResource: targetResource,
+		})
+	}
+
+	a.console.StopSpinner(ctx, stepMessage, input.StepDone)
+
+	if len(streams) == 0 {
+		return &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"none of the services of the project support streaming logs: %w", internal.ErrUnsupportedOperation),
+			Suggestion: "Use 'azd logs --kql' to query the logs collected in Log Analytics instead.",
+		}
+	}
+
+	// Interleave the logs of multiple services, prefixing each line with its service name
+	prefixWidth := 0
+	if len(streams) > 1 {
+		for _, stream := range streams {
+			prefixWidth = max(prefixWidth, len(stream.serviceConfig.Name))
+		}
+	}
+
+	options := &project.ServiceLogOptions{
+		Follow: a.flags.follow,
+		Since:  a.flags.since,
+	}
+
+	var outMu sync.Mutex
+	var errsMu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+
+	for _, stream := range streams {
+		prefix := ""
+		if prefixWidth > 0 {
+			prefix = output.WithHighLightFormat("%-*s | ", prefixWidth, stream.serviceConfig.Name)
+		}
+
+		lineWriter := newLogLineWriter(a.writer, &outMu, prefix, a.flags.filter)
+
+		wg.Go(func() {
+			err := stream.streamer.StreamLogs(ctx, stream.serviceConfig, stream.targetResource, options, lineWriter)
+			if flushErr := lineWriter.Flush(); err == nil {
+				err = flushErr
+			}
+
+			if err != nil {
+				errsMu.Lock()
+				errs = append(errs, fmt.Errorf("streaming logs for service '%s': %w", stream.serviceConfig.Name, err))
+				errsMu.Unlock()
+			}
+		})
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (a *logsAction) runQuery(ctx context.Context) error {
+	subscriptionId := a.env.GetSubscriptionId()
+
+	resourceGroups, err := a.resourceManager.GetResourceGroupsForEnvironment(ctx, subscriptionId, a.env.Name())
+	if err != nil {
+		return fmt.Errorf("discovering resource groups from deployment: %w", err)
+	}
+
+	var workspaces []*azapi.ResourceExtended
+	for _, resourceGroup := range resourceGroups {
+		resources, err := a.resourceService.ListResourceGroupResources(
+			ctx, azure.SubscriptionFromRID(resourceGroup.Id), resourceGroup.Name, nil)
+		if err != nil {
+			return fmt.Errorf("listing resources: %w", err)
+		}
+
+		for _, resource := range resources {
+			if strings.EqualFold(resource.Type, string(azapi.AzureResourceTypeLogAnalyticsWorkspace)) {
+				workspaces = append(workspaces, resource)
+			}
+		}
+	}
+
+	if len(workspaces) == 0 {
+		return &internal.ErrorWithSuggestion{
+			Err:        fmt.Errorf("no Log Analytics workspace found: %w", internal.ErrResourceNotConfigured),
+			Suggestion: "Ensure your infrastructure includes a Log Analytics workspace.",
+		}
+	}
+
+	slices.SortFunc(workspaces, func(a, b *azapi.ResourceExtended) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	workspace := workspaces[0]
+	if len(workspaces) > 1 {
+		log.Printf("found %d Log Analytics workspaces, querying '%s'", len(workspaces), workspace.Name)
+	}
+
+	stepMessage := fmt.Sprintf("Querying Log Analytics workspace %s", output.WithHighLightFormat(workspace.Name))
+	a.console.ShowSpinner(ctx, stepMessage, input.Step)
+
+	result, err := a.azureClient.QueryLogAnalyticsWorkspace(
+		ctx, azure.SubscriptionFromRID(workspace.Id), workspace.Id, a.flags.kql, a.flags.since)
+	if err != nil {
+		a.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+		return err
+	}
+
+	a.console.StopSpinner(ctx, stepMessage, input.StepDone)
+
+	if len(result.Tables) == 0 || len(result.Tables[0].Rows) == 0 {
+		if a.formatter.Kind() == output.JsonFormat {
+			return a.formatter.Format([]map[string]any{}, a.writer, nil)
+		}
+
+		a.console.Message(ctx, "No results found.")
+		return nil
+	}
+
+	// Only the primary result is rendered, additional tables are returned by queries using 'fork'
+	table := result.Tables[0]
+	if a.formatter.Kind() == output.JsonFormat {
+		return a.formatter.Format(table.RowMaps(), a.writer, nil)
+	}
+
+	tableFormatter := &output.TableFormatter{}
+	return tableFormatter.Format(table.RowMaps(), a.writer, logQueryTableOptions(table))
+}
+
+// logQueryTableOptions returns the table formatter options that render every column of the query result.
+func logQueryTableOptions(table azapi.LogAnalyticsTable) output.TableFormatterOptions {
+	columns := make([]output.Column, 0, len(table.Columns))
+	for _, column := range table.Columns {
+		columns = append(columns, output.Column{
+			Heading:       column.Name,
+			ValueTemplate: fmt.Sprintf("{{index . %q}}", column.Name),
+		})
+	}
+
+	return output.TableFormatterOptions{
+		Columns: columns,
+	}
+}
+
+// logLineWriter writes the complete lines of a service log to a writer shared with other services, prefixed with the
+// service name and skipping the lines that don't contain the filter.
+type logLineWriter struct {
+	out    io.Writer
+	outMu  *sync.Mutex
+	prefix string
+	filter string
+	buf    []byte
+}
+
+func newLogLineWriter(out io.Writer, outMu *sync.Mutex, prefix string, filter string) *logLineWriter {
+	return &logLineWriter{
+		out:    out,
+		outMu:  outMu,
+		prefix: prefix,
+		filter: strings.ToLower(filter),
+	}
+}
+
+func (w *logLineWriter) Write(p []byte) (int, error) {
+	w.outMu.Lock()
+	defer w.outMu.Unlock()
+
+	// Bytes of previous writes that weren't written yet, since they don't end with a new line
+	pending := len(w.buf)
+	w.buf = append(w.buf, p...)
+
+	written := 0
+	for {
+		i := bytes.IndexByte(w.buf[written:], '\n')
+		if i < 0 {
+			break
+		}
+
+		if err := w.writeLine(string(w.buf[written : written+i])); err != nil {
+			// The bytes of p from the failed line on aren't consumed, so that they can be written again
+			w.buf = w.buf[written:max(written, pending)]
+			return max(written-pending, 0), err
+		}
+
+		written += i + 1
+	}
+
+	w.buf = w.buf[written:]
+	return len(p), nil
+}
+
+// Flush writes the remaining partial line, if any.
+func (w *logLineWriter) Flush() error {
+	w.outMu.Lock()
+	defer w.outMu.Unlock()
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	line := string(w.buf)
+	w.buf = nil
+
+	return w.writeLine(line)
+}
+
+func (w *logLineWriter) writeLine(line string) error {
+	line = strings.TrimSuffix(line, "\r")
+	if w.filter != "" && !strings.Contains(strings.ToLower(line), w.filter) {
+		return nil
+	}
+
+	_, err := fmt.Fprintln(w.out, w.prefix+line)
+	return err
+}
+
+func getCmdLogsHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		fmt.Sprintf(
+			"Stream the console and system logs of the services of a deployed project %s. "+
+				"Logs of multiple services are interleaved and prefixed with the service name.",
+			output.WithWarningFormat("(Beta)"),
+		),
+		[]string{
+			formatHelpNote(
+				"Streaming logs is supported for services hosted on Container Apps, App Service, " +
+					"Azure Functions and AKS."),
+			formatHelpNote(
+				fmt.Sprintf("%s is supported when streaming the logs of services hosted on AKS, and with %s.",
+					output.WithHighLightFormat("--since"), output.WithHighLightFormat("--kql"))),
+			formatHelpNote(
+				fmt.Sprintf("Use %s to run a KQL query against the Log Analytics workspace of the environment.",
+					output.WithHighLightFormat("--kql"))),
+		})
+}
+
+func getCmdLogsHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Show the recent logs of all services.": output.WithHighLightFormat("azd logs"),
+		"Stream the logs of the api service.":   output.WithHighLightFormat("azd logs api --follow"),
+		"Stream the error logs of the last hour of a service hosted on AKS.": output.WithHighLightFormat(
+			"azd logs api --follow --since 1h --filter error"),
+		"Query the logs collected in Log Analytics.": output.WithHighLightFormat(
+			"azd logs --kql \"ContainerAppConsoleLogs_CL | take 20\" --output json"),
+	})
+}
@@ -0,0 +1,132 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLineWriter(t *testing.T) {
+	t.Run("PrefixesCompleteLines", func(t *testing.T) {
+		var out bytes.Buffer
+		var mu sync.Mutex
+		writer := newLogLineWriter(&out, &mu, "api | ", "")
+
+		_, err := writer.Write([]byte("first line\r\nsecond "))
+		require.NoError(t, err)
+		require.Equal(t, "api | first line\n", out.String())
+
+		_, err = writer.Write([]byte("line\npartial"))
+		require.NoError(t, err)
+		require.NoError(t, writer.Flush())
+		require.Equal(t, "api | first line\napi | second line\napi | partial\n", out.String())
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		var out bytes.Buffer
+		var mu sync.Mutex
+		writer := newLogLineWriter(&out, &mu, "", "Error")
+
+		_, err := writer.Write([]byte("info: started\nERROR: failed to connect\nwarning: retrying\n"))
+		require.NoError(t, err)
+		require.Equal(t, "ERROR: failed to connect\n", out.String())
+	})
+
+	t.Run("WriteError", func(t *testing.T) {
+		var mu sync.Mutex
+		out := &failingWriter{failOn: "third"}
+		writer := newLogLineWriter(out, &mu, "", "")
+
+		_, err := writer.Write([]byte("fir"))
+		require.NoError(t, err)
+
+		// Only the bytes of the lines written before the failed one are consumed
+		p := []byte("st\nsecond\nthird\nfourth\n")
+		n, err := writer.Write(p)
+		require.ErrorIs(t, err, errWriteFailed)
+		require.Equal(t, len("st\nsecond\n"), n)
+		require.Equal(t, "first\nsecond\n", out.String())
+
+		out.failOn = ""
+		n, err = writer.Write(p[n:])
+		require.NoError(t, err)
+		require.Equal(t, len("third\nfourth\n"), n)
+		require.Equal(t, "first\nsecond\nthird\nfourth\n", out.String())
+	})
+
+	t.Run("InterleavesServices", func(t *testing.T) {
+		var out bytes.Buffer
+		var mu sync.Mutex
+		api := newLogLineWriter(&out, &mu, "api | ", "")
+		web := newLogLineWriter(&out, &mu, "web | ", "")
+
+		var wg sync.WaitGroup
+		for i := range 50 {
+			wg.Go(func() {
+				writer := api
+				if i%2 == 0 {
+					writer = web
+				}
+
+				_, _ = writer.Write([]byte("log "))
+				_, _ = writer.Write([]byte("entry\n"))
+			})
+		}
+		wg.Wait()
+
+		lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		require.Len(t, lines, 50)
+		for _, line := range lines {
+			require.Contains(t, []string{"api | log entry", "web | log entry"}, line)
+		}
+	})
+}
+
+var errWriteFailed = errors.New("write failed")
+
+// failingWriter fails writing the lines that contain failOn
+type failingWriter struct {
+	bytes.Buffer
+	failOn string
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.failOn != "" && bytes.Contains(p, []byte(w.failOn)) {
+		return 0, errWriteFailed
+	}
+
+	return w.Buffer.Write(p)
+}
+
+func TestLogQueryTableOptions(t *testing.T) {
+	table := azapi.LogAnalyticsTable{
+		Name: "PrimaryResult",
+		Columns: []azapi.LogAnalyticsColumn{
+			{Name: "TimeGenerated", Type: "datetime"},
+			{Name: "Log_s", Type: "string"},
+		},
+		Rows: [][]any{
+			{"2024-01-01T00:00:00Z", "started"},
+			{"2024-01-01T00:00:01Z", "listening on port 8080"},
+		},
+	}
+
+	var buf bytes.Buffer
+	formatter := &output.TableFormatter{}
+	err := formatter.Format(table.RowMaps(), &buf, logQueryTableOptions(table))
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	require.Equal(t, []string{"TimeGenerated", "Log_s"}, strings.Fields(lines[0]))
+	require.Contains(t, lines[2], "listening on port 8080")
+}
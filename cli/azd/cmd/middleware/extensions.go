@@ -175,6 +175,16 @@ func (m *ExtensionsMiddleware) Run(ctx context.Context, next NextFn) (*actions.A
 					Environment: env,
 				}
 
+				// Reuse a long-lived daemon for extensions that opted into daemon mode
+				if extensions.DaemonEnabled(ext) {
+					err := m.attachDaemon(ctx, ext, serverInfo.Address, jwtToken, options)
+					if err == nil {
+						return
+					}
+
+					log.Printf("failed to use daemon for '%s', starting listen process: %v", ext.Id, err)
+				}
+
 				if _, err := m.extensionRunner.Invoke(ctx, ext, options); err != nil {
 					log.Printf("%v", err)
 					ext.Fail(err)
@@ -0,0 +1,112 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+)
+
+// daemonHealthCheckTimeout bounds the health check of a previously started daemon.
+const daemonHealthCheckTimeout = 2 * time.Second
+
+// attachDaemon connects the daemon of an extension to the gRPC server of the current invocation, starting a new
+// daemon when none is running or the running one is unhealthy.
+// When an error is returned the extension must be started as a regular listen process instead.
+func (m *ExtensionsMiddleware) attachDaemon(
+	ctx context.Context,
+	extension *extensions.Extension,
+	serverAddress string,
+	accessToken string,
+	options *extensions.InvokeOptions,
+) error {
+	statePath, err := extensions.DaemonStatePath(extension.Id)
+	if err != nil {
+		return err
+	}
+
+	state, err := extensions.LoadDaemonState(statePath)
+	if err != nil && !errors.Is(err, extensions.ErrDaemonNotRunning) {
+		log.Printf("ignoring daemon state for '%s': %v", extension.Id, err)
+	}
+
+	if state != nil {
+		client, err := azdext.NewDaemonClient(state)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		healthCtx, cancel := context.WithTimeout(ctx, daemonHealthCheckTimeout)
+		response, err := client.Ping(healthCtx)
+		cancel()
+
+		switch {
+		case err == nil && response.Attached:
+			return fmt.Errorf("daemon for '%s' is attached to another azd invocation", extension.Id)
+		case err == nil:
+			log.Printf("reusing daemon for '%s' (pid %d)", extension.Id, response.Pid)
+			return client.Attach(ctx, newDaemonAttachRequest(serverAddress, accessToken, options))
+		default:
+			log.Printf("daemon for '%s' failed health check, restarting: %v", extension.Id, err)
+			if err := extensions.StopDaemon(extension.Id); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Start the daemon within the same budget used for extensions to become ready
+	startCtx, cancel := getReadyContext(ctx)
+	defer cancel()
+
+	state, err = m.extensionRunner.StartDaemon(startCtx, extension)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("started daemon for '%s' (pid %d)", extension.Id, state.Pid)
+
+	client, err := azdext.NewDaemonClient(state)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	return client.Attach(ctx, newDaemonAttachRequest(serverAddress, accessToken, options))
+}
+
+// newDaemonAttachRequest creates the attach request carrying the per-invocation state that a regular listen process
+// would receive through its environment and working directory.
+func newDaemonAttachRequest(
+	serverAddress string,
+	accessToken string,
+	options *extensions.InvokeOptions,
+) *azdext.DaemonAttachRequest {
+	env := map[string]string{}
+	for _, entry := range options.Environ() {
+		if key, value, has := strings.Cut(entry, "="); has {
+			env[key] = value
+		}
+	}
+
+	workingDirectory, err := os.Getwd()
+	if err != nil {
+		log.Printf("failed to get working directory: %v", err)
+	}
+
+	return &azdext.DaemonAttachRequest{
+		ServerAddress:    serverAddress,
+		AccessToken:      accessToken,
+		Env:              env,
+		WorkingDirectory: workingDirectory,
+	}
+}
@@ -0,0 +1,295 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type rollbackFlags struct {
+	to string
+	internal.EnvFlag
+}
+
+func (f *rollbackFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
+	local.StringVar(
+		&f.to,
+		"to",
+		"",
+		"The id of the deployment to restore, as listed by 'azd deploy history'. Defaults to the previous deployment.",
+	)
+	f.EnvFlag.Bind(local, global)
+}
+
+func newRollbackFlags(cmd *cobra.Command, global *internal.GlobalCommandOptions) *rollbackFlags {
+	flags := &rollbackFlags{}
+	flags.Bind(cmd.Flags(), global)
+
+	return flags
+}
+
+func newRollbackCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rollback <service>",
+		Short: "Roll a service back to a previous deployment.",
+		Args:  cobra.ExactArgs(1),
+	}
+}
+
+type rollbackAction struct {
+	args              []string
+	flags             *rollbackFlags
+	console           input.Console
+	formatter         output.Formatter
+	writer            io.Writer
+	env               *environment.Environment
+	envManager        environment.Manager
+	projectConfig     *project.ProjectConfig
+	importManager     *project.ImportManager
+	serviceManager    project.ServiceManager
+	deploymentHistory *project.DeploymentHistoryManager
+}
+
+func newRollbackAction(
+	args []string,
+	flags *rollbackFlags,
+	console input.Console,
+	formatter output.Formatter,
+	writer io.Writer,
+	env *environment.Environment,
+	envManager environment.Manager,
+	projectConfig *project.ProjectConfig,
+	importManager *project.ImportManager,
+	serviceManager project.ServiceManager,
+	deploymentHistory *project.DeploymentHistoryManager,
+) actions.Action {
+	return &rollbackAction{
+		args:              args,
+		flags:             flags,
+		console:           console,
+		formatter:         formatter,
+		writer:            writer,
+		env:               env,
+		envManager:        envManager,
+		projectConfig:     projectConfig,
+		importManager:     importManager,
+		serviceManager:    serviceManager,
+		deploymentHistory: deploymentHistory,
+	}
+}
+
+func (a *rollbackAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	serviceName := a.args[0]
+
+	if a.env.GetSubscriptionId() == "" {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        internal.ErrInfraNotProvisioned,
+			Suggestion: "Run 'azd provision' and 'azd deploy' before rolling back a service.",
+		}
+	}
+
+	if has, err := a.importManager.HasService(ctx, a.projectConfig, serviceName); err != nil {
+		return nil, err
+	} else if !has {
+		return nil, fmt.Errorf("service '%s': %w", serviceName, internal.ErrServiceNotFound)
+	}
+
+	records, err := a.deploymentHistory.List(ctx, a.env.Name(), serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	restore, err := a.findDeployment(serviceName, records)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := a.importManager.ServiceStableFiltered(ctx, a.projectConfig, serviceName, a.env.Getenv)
+	if err != nil {
+		return nil, err
+	}
+
+	svc := services[0]
+	serviceTarget, err := a.serviceManager.GetServiceTarget(ctx, svc)
+	if err != nil {
+		return nil, err
+	}
+
+	rollbacker, ok := serviceTarget.(project.ServiceRollbacker)
+	if !ok {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"rolling back is not supported for service '%s' with host '%s': %w",
+				svc.Name, svc.Host, internal.ErrUnsupportedOperation),
+			Suggestion: "Check out the commit of the previous deployment and run 'azd deploy " + svc.Name +
+				"' to redeploy it instead.",
+		}
+	}
+
+	targetResource, err := a.serviceManager.GetTargetResource(ctx, svc, serviceTarget)
+	if err != nil {
+		return nil, fmt.Errorf("getting target resource for service '%s': %w", svc.Name, err)
+	}
+
+	a.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title: fmt.Sprintf("Rolling back service %s to deployment %s (azd rollback)", svc.Name, restore.Id),
+	})
+
+	startTime := time.Now()
+	stepMessage := fmt.Sprintf("Rolling back service %s", svc.Name)
+	a.console.ShowSpinner(ctx, stepMessage, input.Step)
+
+	deployResult, err := async.RunWithProgress(
+		func(rollbackProgress project.ServiceProgress) {
+			progressMessage := fmt.Sprintf("Rolling back service %s (%s)", svc.Name, rollbackProgress.Message)
+			a.console.ShowSpinner(ctx, progressMessage, input.Step)
+		},
+		func(progress *async.Progress[project.ServiceProgress]) (*project.ServiceDeployResult, error) {
+			return rollbacker.Rollback(ctx, svc, targetResource, restore, progress)
+		},
+	)
+	a.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := a.deploymentHistory.RecordRollback(ctx, a.env.Name(), svc, restore, deployResult)
+	if err != nil {
+		log.Printf("failed recording deployment history for service '%s': %v", svc.Name, err)
+	}
+
+	a.console.MessageUxItem(ctx, deployResult.Artifacts)
+
+	if a.formatter.Kind() == output.JsonFormat && record != nil {
+		if err := a.formatter.Format(record, a.writer, nil); err != nil {
+			return nil, fmt.Errorf("rollback result could not be displayed: %w", err)
+		}
+	}
+
+	// Invalidate cache after successful rollback so azd show will refresh
+	if err := a.envManager.InvalidateEnvCache(ctx, a.env.Name()); err != nil {
+		log.Printf("warning: failed to invalidate state cache: %v", err)
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf(
+				"Service %s was rolled back to deployment %s in %s.",
+				svc.Name,
+				restore.Id,
+				ux.DurationAsText(since(startTime)),
+			),
+		},
+	}, nil
+}
+
+// findDeployment returns the deployment to restore from the recorded deployments of the service, newest first.
+func (a *rollbackAction) findDeployment(
+	serviceName string,
+	records []*project.DeploymentRecord,
+) (*project.DeploymentRecord, error) {
+	historySuggestion := fmt.Sprintf("Run 'azd deploy history %s' to list the recorded deployments.", serviceName)
+
+	if len(records) == 0 {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"no deployments of service '%s' have been recorded in environment '%s'", serviceName, a.env.Name()),
+			Suggestion: "Deployments are recorded by 'azd deploy', a service can be rolled back after it was deployed twice.",
+		}
+	}
+
+	// A rollback runs the deployment it restored again, which is then the current deployment of the service
+	currentId := records[0].Id
+	if records[0].RollbackOf != "" {
+		currentId = records[0].RollbackOf
+	}
+
+	if a.flags.to == "" {
+		previous := records[1:]
+		if i := slices.IndexFunc(records, func(record *project.DeploymentRecord) bool {
+			return record.Id == currentId
+		}); i >= 0 {
+			previous = records[i+1:]
+		}
+
+		// Rollbacks are skipped so that rolling back again goes further back instead of returning to the deployment
+		// that was rolled away from. Deployments that are known to be bad, like unhealthy ones, are skipped too.
+		for _, record := range previous {
+			if record.RollbackOf == "" && record.Restorable() {
+				return record, nil
+			}
+		}
+
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        fmt.Errorf("service '%s' does not have a previous healthy deployment", serviceName),
+			Suggestion: historySuggestion,
+		}
+	}
+
+	for i, record := range records {
+		if record.Id != a.flags.to {
+			continue
+		}
+
+		if i == 0 || record.Id == currentId {
+			return nil, &internal.ErrorWithSuggestion{
+				Err:        fmt.Errorf("deployment '%s' is the current deployment of service '%s'", record.Id, serviceName),
+				Suggestion: historySuggestion,
+			}
+		}
+
+		if !record.Restorable() {
+			return nil, &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf(
+					"deployment '%s' of service '%s' can't be restored, its status is '%s'",
+					record.Id, serviceName, record.Status),
+				Suggestion: historySuggestion,
+			}
+		}
+
+		return record, nil
+	}
+
+	return nil, &internal.ErrorWithSuggestion{
+		Err:        fmt.Errorf("deployment '%s' of service '%s' was not found", a.flags.to, serviceName),
+		Suggestion: historySuggestion,
+	}
+}
+
+func getCmdRollbackHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription("Roll a service back to a previous deployment.", []string{
+		formatHelpNote("Deployments are recorded per environment by 'azd deploy', " +
+			"run 'azd deploy history' to list them. Unhealthy deployments are never restored."),
+		formatHelpNote("Rolling back again restores the deployment before the restored one."),
+		formatHelpNote("Container Apps reactivate the revision of the deployment, App Service and Azure Functions " +
+			"redeploy its retained package and AKS rolls the k8s deployment back to its revision."),
+	})
+}
+
+func getCmdRollbackHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Roll the service named 'api' back to its previous deployment.": output.WithHighLightFormat(
+			"azd rollback api",
+		),
+		"Roll the service named 'api' back to a specific deployment.": output.WithHighLightFormat(
+			"azd rollback api --to <deployment-id>",
+		),
+	})
+}
@@ -0,0 +1,72 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollbackFindDeployment(t *testing.T) {
+	// Newest first, as listed by the deployment history
+	records := []*project.DeploymentRecord{
+		{Id: "4", Status: project.DeploymentStatusSucceeded},
+		{Id: "3", Status: project.DeploymentStatusUnhealthy},
+		{Id: "2"},
+		{Id: "1", Status: project.DeploymentStatusSucceeded},
+	}
+
+	newAction := func(to string) *rollbackAction {
+		return &rollbackAction{
+			flags: &rollbackFlags{to: to},
+			env:   environment.NewWithValues("dev", nil),
+		}
+	}
+
+	t.Run("SkipsUnhealthyDeployments", func(t *testing.T) {
+		record, err := newAction("").findDeployment("api", records)
+		require.NoError(t, err)
+		require.Equal(t, "2", record.Id)
+	})
+
+	t.Run("NoHealthyPreviousDeployment", func(t *testing.T) {
+		_, err := newAction("").findDeployment("api", records[:2])
+		require.ErrorContains(t, err, "does not have a previous healthy deployment")
+	})
+
+	t.Run("RejectsUnhealthyDeployment", func(t *testing.T) {
+		_, err := newAction("3").findDeployment("api", records)
+		require.ErrorContains(t, err, "can't be restored")
+	})
+
+	t.Run("RestoresRequestedDeployment", func(t *testing.T) {
+		record, err := newAction("1").findDeployment("api", records)
+		require.NoError(t, err)
+		require.Equal(t, "1", record.Id)
+	})
+
+	t.Run("RejectsCurrentDeployment", func(t *testing.T) {
+		_, err := newAction("4").findDeployment("api", records)
+		require.ErrorContains(t, err, "is the current deployment")
+	})
+
+	t.Run("SkipsRollbacks", func(t *testing.T) {
+		// 2 was restored by rolling back from 4, rolling back again restores the deployment before 2
+		withRollback := append([]*project.DeploymentRecord{{Id: "5", RollbackOf: "2"}}, records...)
+
+		record, err := newAction("").findDeployment("api", withRollback)
+		require.NoError(t, err)
+		require.Equal(t, "1", record.Id)
+	})
+
+	t.Run("RejectsRestoredDeployment", func(t *testing.T) {
+		withRollback := append([]*project.DeploymentRecord{{Id: "5", RollbackOf: "2"}}, records...)
+
+		_, err := newAction("2").findDeployment("api", withRollback)
+		require.ErrorContains(t, err, "is the current deployment")
+	})
+}
@@ -308,7 +308,7 @@ func NewRootCmd(
 		UseMiddleware("hooks", middleware.NewHooksMiddleware).
 		UseMiddleware("extensions", middleware.NewExtensionsMiddleware)
 
-	root.
+	deploy := root.
 		Add("deploy", &actions.ActionDescriptorOptions{
 			Command:        cmd.NewDeployCmd(),
 			FlagsResolver:  cmd.NewDeployFlags,
@@ -324,6 +324,38 @@ func NewRootCmd(
 			},
 			RequireLogin: true,
 		}).
+		// Deploy hooks and extensions don't apply to the 'deploy' subcommands
+		UseMiddlewareWhen("hooks", middleware.NewHooksMiddleware, isDeployDescriptor).
+		UseMiddlewareWhen("extensions", middleware.NewExtensionsMiddleware, isDeployDescriptor)
+
+	deploy.Add("history", &actions.ActionDescriptorOptions{
+		Command:        newDeployHistoryCmd(),
+		FlagsResolver:  newDeployHistoryFlags,
+		ActionResolver: newDeployHistoryAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat},
+		DefaultFormat:  output.TableFormat,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdDeployHistoryHelpDescription,
+			Footer:      getCmdDeployHistoryHelpFooter,
+		},
+	})
+
+	root.
+		Add("rollback", &actions.ActionDescriptorOptions{
+			Command:        newRollbackCmd(),
+			FlagsResolver:  newRollbackFlags,
+			ActionResolver: newRollbackAction,
+			OutputFormats:  []output.Format{output.JsonFormat, output.NoneFormat},
+			DefaultFormat:  output.NoneFormat,
+			HelpOptions: actions.ActionHelpOptions{
+				Description: getCmdRollbackHelpDescription,
+				Footer:      getCmdRollbackHelpFooter,
+			},
+			GroupingOptions: actions.CommandGroupOptions{
+				RootLevelHelp: actions.CmdGroupBeta,
+			},
+			RequireLogin: true,
+		}).
 		UseMiddleware("hooks", middleware.NewHooksMiddleware).
 		UseMiddleware("extensions", middleware.NewExtensionsMiddleware)
 
@@ -377,6 +409,22 @@ func NewRootCmd(
 		},
 	})
 
+	root.Add("logs", &actions.ActionDescriptorOptions{
+		Command:        newLogsCmd(),
+		FlagsResolver:  newLogsFlags,
+		ActionResolver: newLogsAction,
+		OutputFormats:  []output.Format{output.JsonFormat, output.TableFormat, output.NoneFormat},
+		DefaultFormat:  output.NoneFormat,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdLogsHelpDescription,
+			Footer:      getCmdLogsHelpFooter,
+		},
+		GroupingOptions: actions.CommandGroupOptions{
+			RootLevelHelp: actions.CmdGroupBeta,
+		},
+		RequireLogin: true,
+	})
+
 	root.
 		Add("down", &actions.ActionDescriptorOptions{
 			Command:        newDownCmd(),
@@ -572,3 +620,8 @@ func clearCommandContext(cmd *cobra.Command) {
 		clearCommandContext(child)
 	}
 }
+
+// isDeployDescriptor returns true for the 'azd deploy' action descriptor itself, excluding its subcommands.
+func isDeployDescriptor(descriptor *actions.ActionDescriptor) bool {
+	return descriptor.Name == "deploy"
+}
@@ -0,0 +1,136 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/internal/repository"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"github.com/spf13/cobra"
+)
+
+func newTemplateUpgradeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use: "upgrade",
+		Short: fmt.Sprintf("Upgrade the project to the latest revision of its template. %s",
+			output.WithWarningFormat("(Beta)")),
+		Args: cobra.NoArgs,
+	}
+}
+
+type templateUpgradeAction struct {
+	azdCtx          *azdcontext.AzdContext
+	console         input.Console
+	gitCli          *git.Cli
+	repoInitializer *repository.Initializer
+}
+
+func newTemplateUpgradeAction(
+	azdCtx *azdcontext.AzdContext,
+	console input.Console,
+	gitCli *git.Cli,
+	repoInitializer *repository.Initializer,
+) actions.Action {
+	return &templateUpgradeAction{
+		azdCtx:          azdCtx,
+		console:         console,
+		gitCli:          gitCli,
+		repoInitializer: repoInitializer,
+	}
+}
+
+func (a *templateUpgradeAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	a.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title: "Upgrading the project to the latest revision of its template (azd template upgrade)",
+	})
+
+	if err := tools.EnsureInstalled(ctx, a.gitCli); err != nil {
+		return nil, err
+	}
+
+	stepMessage := "Merging the changes of the template"
+	a.console.ShowSpinner(ctx, stepMessage, input.Step)
+	upgrade, err := a.repoInitializer.Upgrade(ctx, a.azdCtx)
+	a.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
+	if errors.Is(err, repository.ErrTemplateLockNotFound) {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf("the project doesn't record the template it was initialized from: %w", err),
+			Suggestion: fmt.Sprintf("Only projects initialized with 'azd init --template' record their template in %s.",
+				repository.TemplateLockFileName),
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if upgrade.UpToDate() {
+		return &actions.ActionResult{
+			Message: &actions.ResultMessage{
+				Header: "The project is up to date with its template.",
+			},
+		}, nil
+	}
+
+	for _, files := range []struct {
+		description string
+		paths       []string
+	}{
+		{"Added", upgrade.Added},
+		{"Updated", upgrade.Updated},
+		{"Merged", upgrade.Merged},
+		{"Removed", upgrade.Removed},
+	} {
+		for _, path := range files.paths {
+			a.console.Message(ctx, fmt.Sprintf("  %s %s", output.WithGrayFormat("%-8s", files.description), path))
+		}
+	}
+
+	if len(upgrade.Conflicts) > 0 {
+		lines := make([]string, len(upgrade.Conflicts))
+		for index, conflict := range upgrade.Conflicts {
+			lines[index] = fmt.Sprintf("  %s: %s", output.WithHighLightFormat(conflict.Path), conflict.Reason)
+		}
+
+		a.console.Message(ctx, "")
+		a.console.MessageUxItem(ctx, &ux.WarningMessage{
+			Description: fmt.Sprintf("%d files have conflicts to resolve:", len(upgrade.Conflicts)),
+		})
+		a.console.Message(ctx, strings.Join(lines, "\n"))
+	}
+
+	followUp := "Review the changes before committing them."
+	if len(upgrade.Conflicts) > 0 {
+		followUp = "Resolve the conflicts marked with <<<<<<< and >>>>>>>, then review the changes before committing them."
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header:   "The project was upgraded to the latest revision of its template.",
+			FollowUp: followUp,
+		},
+	}, nil
+}
+
+func getCmdTemplateUpgradeHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		fmt.Sprintf("Upgrade the project to the latest revision of the template it was initialized from. %s",
+			output.WithWarningFormat("(Beta)")),
+		[]string{
+			formatHelpNote(fmt.Sprintf("The template is recorded in %s when running %s.",
+				output.WithHighLightFormat(repository.TemplateLockFileName),
+				output.WithHighLightFormat("azd init --template"))),
+			formatHelpNote("Files changed by both the project and the template are merged. " +
+				"Conflicting changes are marked in the files for you to resolve."),
+		})
+}
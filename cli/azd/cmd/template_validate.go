@@ -0,0 +1,281 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/azure/azure-dev/cli/azd/cmd/actions"
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/internal/repository"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+type templateCheckFlags struct {
+	templateValues []string
+	junit          string
+}
+
+func (f *templateCheckFlags) Bind(local *pflag.FlagSet) {
+	local.StringArrayVar(
+		&f.templateValues,
+		"set",
+		[]string{},
+		"Sets an input of a parameterized template as <name>=<value>. Can be repeated.",
+	)
+	local.StringVar(&f.junit, "junit", "", "Writes the results as a JUnit XML report to the given file.")
+}
+
+type templateValidateFlags struct {
+	templateCheckFlags
+	catalog string
+}
+
+func newTemplateValidateFlags(cmd *cobra.Command) *templateValidateFlags {
+	flags := &templateValidateFlags{}
+	flags.templateCheckFlags.Bind(cmd.Flags())
+	cmd.Flags().StringVar(
+		&flags.catalog,
+		"catalog",
+		"",
+		"Checks the entry of the template in the given templates.json catalog.",
+	)
+
+	return flags
+}
+
+func newTemplateValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use: "validate [<path>]",
+		Short: fmt.Sprintf("Validate a template before publishing it. %s",
+			output.WithWarningFormat("(Beta)")),
+		Args: cobra.MaximumNArgs(1),
+	}
+}
+
+type templateValidateAction struct {
+	args      []string
+	flags     *templateValidateFlags
+	console   input.Console
+	validator *repository.TemplateValidator
+}
+
+func newTemplateValidateAction(
+	args []string,
+	flags *templateValidateFlags,
+	console input.Console,
+	validator *repository.TemplateValidator,
+) actions.Action {
+	return &templateValidateAction{
+		args:      args,
+		flags:     flags,
+		console:   console,
+		validator: validator,
+	}
+}
+
+func (a *templateValidateAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	a.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title: "Validating the template (azd template validate)",
+	})
+
+	templateDir := "."
+	if len(a.args) > 0 {
+		templateDir = a.args[0]
+	}
+
+	if info, err := os.Stat(templateDir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("template directory '%s' doesn't exist: %w", templateDir, internal.ErrInvalidArgValue)
+	}
+
+	templateValues, err := parseTemplateValues(a.flags.templateValues)
+	if err != nil {
+		return nil, err
+	}
+
+	stepMessage := "Checking the template"
+	a.console.ShowSpinner(ctx, stepMessage, input.Step)
+	report, err := a.validator.Validate(ctx, templateDir, repository.TemplateValidateOptions{
+		Inputs:  templateValues,
+		Catalog: a.flags.catalog,
+	})
+	a.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
+	if err != nil {
+		return nil, err
+	}
+
+	return reportTemplateChecks(ctx, a.console, report, "azd template validate", a.flags.junit,
+		"The template is valid.")
+}
+
+type templateTestFlags struct {
+	templateCheckFlags
+	branch string
+}
+
+func newTemplateTestFlags(cmd *cobra.Command) *templateTestFlags {
+	flags := &templateTestFlags{}
+	flags.templateCheckFlags.Bind(cmd.Flags())
+	cmd.Flags().StringVarP(&flags.branch, "branch", "b", "", "The template branch to test.")
+
+	return flags
+}
+
+func newTemplateTestCmd() *cobra.Command {
+	return &cobra.Command{
+		Use: "test [<template>]",
+		Short: fmt.Sprintf("Test a template by initializing it and previewing its provisioning. %s",
+			output.WithWarningFormat("(Beta)")),
+		Args: cobra.MaximumNArgs(1),
+	}
+}
+
+type templateTestAction struct {
+	args    []string
+	flags   *templateTestFlags
+	console input.Console
+	tester  *repository.TemplateTester
+}
+
+func newTemplateTestAction(
+	args []string,
+	flags *templateTestFlags,
+	console input.Console,
+	tester *repository.TemplateTester,
+) actions.Action {
+	return &templateTestAction{
+		args:    args,
+		flags:   flags,
+		console: console,
+		tester:  tester,
+	}
+}
+
+func (a *templateTestAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	a.console.MessageUxItem(ctx, &ux.MessageTitle{
+		Title: "Testing the template (azd template test)",
+	})
+
+	template := "."
+	if len(a.args) > 0 {
+		template = a.args[0]
+	}
+
+	templateValues, err := parseTemplateValues(a.flags.templateValues)
+	if err != nil {
+		return nil, err
+	}
+
+	stepMessage := "Initializing the template and previewing its provisioning"
+	a.console.ShowSpinner(ctx, stepMessage, input.Step)
+	report, err := a.tester.Test(ctx, template, repository.TemplateTestOptions{
+		Branch: a.flags.branch,
+		Inputs: templateValues,
+	})
+	a.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
+	if err != nil {
+		return nil, err
+	}
+
+	return reportTemplateChecks(ctx, a.console, report, "azd template test", a.flags.junit,
+		"The template passed the tests.")
+}
+
+// reportTemplateChecks displays the checks of a template and writes them as a JUnit XML report when requested, failing
+// when any check failed
+func reportTemplateChecks(
+	ctx context.Context,
+	console input.Console,
+	report *repository.TemplateReport,
+	name string,
+	junitPath string,
+	header string,
+) (*actions.ActionResult, error) {
+	for _, check := range report.Checks {
+		var line string
+		switch check.Status {
+		case repository.TemplateCheckPassed:
+			line = fmt.Sprintf("  %s %s", output.WithSuccessFormat("(✓) Passed:"), check.Name)
+		case repository.TemplateCheckFailed:
+			line = fmt.Sprintf("  %s %s\n    %s", output.WithErrorFormat("(x) Failed:"), check.Name, check.Message)
+		case repository.TemplateCheckSkipped:
+			line = fmt.Sprintf("  %s %s (%s)", output.WithGrayFormat("(-) Skipped:"), check.Name, check.Message)
+		}
+
+		console.Message(ctx, line)
+	}
+
+	if junitPath != "" {
+		file, err := os.Create(junitPath)
+		if err != nil {
+			return nil, fmt.Errorf("creating JUnit report: %w", err)
+		}
+		defer file.Close()
+
+		if err := report.WriteJUnit(name, file); err != nil {
+			return nil, err
+		}
+	}
+
+	if failed := report.Failed(); len(failed) > 0 {
+		return nil, fmt.Errorf("%d of %d checks of the template failed: %w",
+			len(failed), len(report.Checks), internal.ErrValidationFailed)
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: header,
+		},
+	}, nil
+}
+
+func getCmdTemplateValidateHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		fmt.Sprintf("Validate the template at the given path, or the current directory, before publishing it. %s",
+			output.WithWarningFormat("(Beta)")),
+		[]string{
+			formatHelpNote(fmt.Sprintf("Checks %s against its schema, the project path of each service, "+
+				"the compilation of the infrastructure and its parameters file.",
+				output.WithHighLightFormat("azure.yaml"))),
+			formatHelpNote(fmt.Sprintf("Parameterized templates are rendered with the values set with %s "+
+				"and the default values of the other inputs.", output.WithHighLightFormat("--set"))),
+		})
+}
+
+func getCmdTemplateValidateHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Validate the template in the current directory.": output.WithHighLightFormat("azd template validate"),
+		"Validate a template and its entry in a template catalog.": output.WithHighLightFormat(
+			"azd template validate ./my-template --catalog ./templates.json",
+		),
+	})
+}
+
+func getCmdTemplateTestHelpDescription(*cobra.Command) string {
+	return generateCmdHelpDescription(
+		fmt.Sprintf("Test a template by initializing it into a temporary directory "+
+			"and previewing its provisioning. %s", output.WithWarningFormat("(Beta)")),
+		[]string{
+			formatHelpNote(fmt.Sprintf("The provisioning is previewed with the %s provider, "+
+				"which doesn't create or read any Azure resource.", output.WithHighLightFormat("test"))),
+			formatHelpNote("The template is a local path or a repository, like for 'azd init --template'."),
+		})
+}
+
+func getCmdTemplateTestHelpFooter(*cobra.Command) string {
+	return generateCmdHelpSamplesBlock(map[string]string{
+		"Test the template in the current directory and write a JUnit report.": output.WithHighLightFormat(
+			"azd template test --junit results.xml",
+		),
+		"Test a variant of a parameterized template.": output.WithHighLightFormat(
+			"azd template test ./my-template --set database=postgres",
+		),
+	})
+}
@@ -48,6 +48,34 @@ func templatesActions(root *actions.ActionDescriptor) *actions.ActionDescriptor
 		DefaultFormat:  output.NoneFormat,
 	})
 
+	group.Add("upgrade", &actions.ActionDescriptorOptions{
+		Command:        newTemplateUpgradeCmd(),
+		ActionResolver: newTemplateUpgradeAction,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdTemplateUpgradeHelpDescription,
+		},
+	})
+
+	group.Add("validate", &actions.ActionDescriptorOptions{
+		Command:        newTemplateValidateCmd(),
+		FlagsResolver:  newTemplateValidateFlags,
+		ActionResolver: newTemplateValidateAction,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdTemplateValidateHelpDescription,
+			Footer:      getCmdTemplateValidateHelpFooter,
+		},
+	})
+
+	group.Add("test", &actions.ActionDescriptorOptions{
+		Command:        newTemplateTestCmd(),
+		FlagsResolver:  newTemplateTestFlags,
+		ActionResolver: newTemplateTestAction,
+		HelpOptions: actions.ActionHelpOptions{
+			Description: getCmdTemplateTestHelpDescription,
+			Footer:      getCmdTemplateTestHelpFooter,
+		},
+	})
+
 	_ = templateSourceActions(group)
 
 	return group
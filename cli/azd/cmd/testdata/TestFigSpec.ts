@@ -1323,6 +1323,10 @@ const completionSpec: Fig.Spec = {
 			name: ['auth'],
 			description: 'Authenticate with Azure.',
 			subcommands: [
+				{
+					name: ['list'],
+					description: 'List the auth profiles. (Beta)',
+				},
 				{
 					name: ['login'],
 					description: 'Log in to Azure.',
@@ -1372,6 +1376,15 @@ const completionSpec: Fig.Spec = {
 							name: ['--managed-identity'],
 							description: 'Use a managed identity to authenticate.',
 						},
+						{
+							name: ['--profile'],
+							description: 'The auth profile to log in with. Each profile has its own account and token cache.',
+							args: [
+								{
+									name: 'profile',
+								},
+							],
+						},
 						{
 							name: ['--redirect-port'],
 							description: 'Choose the port to be used as part of the redirect URI during interactive login.',
@@ -1404,6 +1417,13 @@ const completionSpec: Fig.Spec = {
 					name: ['status'],
 					description: 'Show the current authentication status.',
 				},
+				{
+					name: ['switch'],
+					description: 'Switch to another auth profile. (Beta)',
+					args: {
+						name: 'profile',
+					},
+				},
 			],
 		},
 		{
@@ -1515,6 +1535,12 @@ const completionSpec: Fig.Spec = {
 				{
 					name: ['get'],
 					description: 'Gets a configuration.',
+					options: [
+						{
+							name: ['--show-origin'],
+							description: 'Shows the layer supplying each value (default, system, user, project, environment, env or policy) and its file.',
+						},
+					],
 					args: {
 						name: 'path',
 						generators: azdGenerators.listConfigKeys,
@@ -1555,6 +1581,12 @@ const completionSpec: Fig.Spec = {
 				{
 					name: ['show'],
 					description: 'Show all the configuration values.',
+					options: [
+						{
+							name: ['--show-origin'],
+							description: 'Shows the layer supplying each value (default, system, user, project, environment, env or policy) and its file.',
+						},
+					],
 				},
 				{
 					name: ['unset'],
@@ -1754,6 +1786,86 @@ const completionSpec: Fig.Spec = {
 						},
 					],
 				},
+				{
+					name: ['test'],
+					description: 'Test a template by initializing it and previewing its provisioning. (Beta)',
+					options: [
+						{
+							name: ['--branch', '-b'],
+							description: 'The template branch to test.',
+							args: [
+								{
+									name: 'branch',
+								},
+							],
+						},
+						{
+							name: ['--junit'],
+							description: 'Writes the results as a JUnit XML report to the given file.',
+							args: [
+								{
+									name: 'junit',
+								},
+							],
+						},
+						{
+							name: ['--set'],
+							description: 'Sets an input of a parameterized template as <name>=<value>. Can be repeated.',
+							isRepeatable: true,
+							args: [
+								{
+									name: 'set',
+								},
+							],
+						},
+					],
+					args: {
+						name: 'template',
+						isOptional: true,
+					},
+				},
+				{
+					name: ['upgrade'],
+					description: 'Upgrade the project to the latest revision of its template. (Beta)',
+				},
+				{
+					name: ['validate'],
+					description: 'Validate a template before publishing it. (Beta)',
+					options: [
+						{
+							name: ['--catalog'],
+							description: 'Checks the entry of the template in the given templates.json catalog.',
+							args: [
+								{
+									name: 'catalog',
+								},
+							],
+						},
+						{
+							name: ['--junit'],
+							description: 'Writes the results as a JUnit XML report to the given file.',
+							args: [
+								{
+									name: 'junit',
+								},
+							],
+						},
+						{
+							name: ['--set'],
+							description: 'Sets an input of a parameterized template as <name>=<value>. Can be repeated.',
+							isRepeatable: true,
+							args: [
+								{
+									name: 'set',
+								},
+							],
+						},
+					],
+					args: {
+						name: 'path',
+						isOptional: true,
+					},
+				},
 			],
 		},
 		{
@@ -1821,6 +1933,27 @@ const completionSpec: Fig.Spec = {
 		{
 			name: ['deploy'],
 			description: 'Deploy your project code to Azure.',
+			subcommands: [
+				{
+					name: ['history'],
+					description: 'List the recorded deployments of the services in the environment.',
+					options: [
+						{
+							name: ['--environment', '-e'],
+							description: 'The name of the environment to use.',
+							args: [
+								{
+									name: 'environment',
+								},
+							],
+						},
+					],
+					args: {
+						name: 'service',
+						isOptional: true,
+					},
+				},
+			],
 			options: [
 				{
 					name: ['--all'],
@@ -1873,6 +2006,24 @@ const completionSpec: Fig.Spec = {
 					description: 'Does not require confirmation before it permanently deletes resources that are soft-deleted by default (for example, key vaults).',
 					isDangerous: true,
 				},
+				{
+					name: ['--resource'],
+					description: 'Deletes only the given resource from the \'resources\' section of azure.yaml.',
+					args: [
+						{
+							name: 'resource',
+						},
+					],
+				},
+				{
+					name: ['--service'],
+					description: 'Deletes only the resources of the given service, found by their \'azd-service-name\' tag.',
+					args: [
+						{
+							name: 'service',
+						},
+					],
+				},
 			],
 			args: {
 				name: 'layer',
@@ -1980,6 +2131,18 @@ const completionSpec: Fig.Spec = {
 								},
 							],
 						},
+						{
+							name: ['test'],
+							description: 'Test a template by initializing it and previewing its provisioning. (Beta)',
+						},
+						{
+							name: ['upgrade'],
+							description: 'Upgrade the project to the latest revision of its template. (Beta)',
+						},
+						{
+							name: ['validate'],
+							description: 'Validate a template before publishing it. (Beta)',
+						},
 					],
 				},
 				{
@@ -2072,6 +2235,32 @@ const completionSpec: Fig.Spec = {
 						name: 'environment',
 					},
 				},
+				{
+					name: ['rotate-secret'],
+					description: 'Rotate a Key Vault secret and update the resources and services using it.',
+					options: [
+						{
+							name: ['--environment', '-e'],
+							description: 'The name of the environment to use.',
+							args: [
+								{
+									name: 'environment',
+								},
+							],
+						},
+						{
+							name: ['--no-deploy'],
+							description: 'Skip redeploying the services consuming the secret.',
+						},
+						{
+							name: ['--no-provision'],
+							description: 'Skip provisioning the infrastructure using the secret again. Its resources keep the previous value.',
+						},
+					],
+					args: {
+						name: 'name',
+					},
+				},
 				{
 					name: ['select'],
 					description: 'Set the default environment.',
@@ -2139,6 +2328,52 @@ const completionSpec: Fig.Spec = {
 			name: ['extension', 'ext'],
 			description: 'Manage azd extensions.',
 			subcommands: [
+				{
+					name: ['bundle'],
+					description: 'Export extensions and their artifacts into an offline bundle.',
+					options: [
+						{
+							name: ['--file'],
+							description: 'Path of the bundle file to create',
+							args: [
+								{
+									name: 'file',
+								},
+							],
+						},
+						{
+							name: ['--platform'],
+							description: 'Platforms to include artifacts for (e.g. linux/amd64). Includes all platforms when not specified',
+							isRepeatable: true,
+							args: [
+								{
+									name: 'platform',
+								},
+							],
+						},
+						{
+							name: ['--source', '-s'],
+							description: 'The extension source to bundle extensions from',
+							args: [
+								{
+									name: 'source',
+								},
+							],
+						},
+						{
+							name: ['--version', '-v'],
+							description: 'The version constraint of the extension to bundle',
+							args: [
+								{
+									name: 'version',
+								},
+							],
+						},
+					],
+					args: {
+						name: 'extension-id',
+					},
+				},
 				{
 					name: ['install'],
 					description: 'Installs specified extensions.',
@@ -2148,6 +2383,10 @@ const completionSpec: Fig.Spec = {
 							description: 'Force installation, including downgrades and reinstalls',
 							isDangerous: true,
 						},
+						{
+							name: ['--from-lock'],
+							description: 'Install the exact extension versions recorded in the project\'s azd.lock file',
+						},
 						{
 							name: ['--source', '-s'],
 							description: 'The extension source to use for installs',
@@ -2201,6 +2440,10 @@ const completionSpec: Fig.Spec = {
 						},
 					],
 				},
+				{
+					name: ['lock'],
+					description: 'Resolve the extensions required by the project and record them in azd.lock.',
+				},
 				{
 					name: ['show'],
 					description: 'Show details for a specific extension.',
@@ -2248,7 +2491,7 @@ const completionSpec: Fig.Spec = {
 								},
 								{
 									name: ['--type', '-t'],
-									description: 'The type of the extension source. Supported types are \'file\' and \'url\'',
+									description: 'The type of the extension source. Supported types are \'file\', \'url\' and \'bundle\'',
 									args: [
 										{
 											name: 'type',
@@ -2256,6 +2499,10 @@ const completionSpec: Fig.Spec = {
 									],
 								},
 							],
+							args: {
+								name: 'location',
+								isOptional: true,
+							},
 						},
 						{
 							name: ['list'],
@@ -2469,6 +2716,16 @@ const completionSpec: Fig.Spec = {
 					name: ['--minimal', '-m'],
 					description: 'Initializes a minimal project.',
 				},
+				{
+					name: ['--set'],
+					description: 'Sets an input of a parameterized template as <name>=<value>, instead of prompting for it. Can be repeated.',
+					isRepeatable: true,
+					args: [
+						{
+							name: 'set',
+						},
+					],
+				},
 				{
 					name: ['--subscription', '-s'],
 					description: 'ID of an Azure subscription to use for the new environment',
@@ -2494,6 +2751,56 @@ const completionSpec: Fig.Spec = {
 				},
 			],
 		},
+		{
+			name: ['logs'],
+			description: 'Stream or query the logs of a deployed project.',
+			options: [
+				{
+					name: ['--environment', '-e'],
+					description: 'The name of the environment to use.',
+					args: [
+						{
+							name: 'environment',
+						},
+					],
+				},
+				{
+					name: ['--filter'],
+					description: 'Only include log lines containing the specified text (case-insensitive).',
+					args: [
+						{
+							name: 'filter',
+						},
+					],
+				},
+				{
+					name: ['--follow', '-f'],
+					description: 'Keep streaming new log entries until the command is cancelled.',
+				},
+				{
+					name: ['--kql'],
+					description: 'Run the specified KQL query against the Log Analytics workspace of the environment instead of streaming logs.',
+					args: [
+						{
+							name: 'kql',
+						},
+					],
+				},
+				{
+					name: ['--since'],
+					description: 'Only include log entries newer than the specified duration, for example 30m or 1h. Supported when streaming the logs of services hosted on AKS, and with --kql.',
+					args: [
+						{
+							name: 'since',
+						},
+					],
+				},
+			],
+			args: {
+				name: 'service',
+				isOptional: true,
+			},
+		},
 		{
 			name: ['mcp'],
 			description: 'Manage Model Context Protocol (MCP) server. (Alpha)',
@@ -2654,6 +2961,14 @@ const completionSpec: Fig.Spec = {
 			name: ['provision'],
 			description: 'Provision Azure resources for your project.',
 			options: [
+				{
+					name: ['--check-drift'],
+					description: 'Reports changes made to the provisioned Azure resources outside of azd. Exits with a non-zero code when drift is detected.',
+				},
+				{
+					name: ['--cost'],
+					description: 'Estimates the monthly cost of the previewed Azure resources. Requires --preview.',
+				},
 				{
 					name: ['--environment', '-e'],
 					description: 'The name of the environment to use.',
@@ -2759,6 +3074,33 @@ const completionSpec: Fig.Spec = {
 				isOptional: true,
 			},
 		},
+		{
+			name: ['rollback'],
+			description: 'Roll a service back to a previous deployment.',
+			options: [
+				{
+					name: ['--environment', '-e'],
+					description: 'The name of the environment to use.',
+					args: [
+						{
+							name: 'environment',
+						},
+					],
+				},
+				{
+					name: ['--to'],
+					description: 'The id of the deployment to restore, as listed by \'azd deploy history\'. Defaults to the previous deployment.',
+					args: [
+						{
+							name: 'to',
+						},
+					],
+				},
+			],
+			args: {
+				name: 'service',
+			},
+		},
 		{
 			name: ['show'],
 			description: 'Display information about your project and its resources.',
@@ -2772,11 +3114,24 @@ const completionSpec: Fig.Spec = {
 						},
 					],
 				},
+				{
+					name: ['--interval'],
+					description: 'The time between two refreshes of the status when --watch is set.',
+					args: [
+						{
+							name: 'interval',
+						},
+					],
+				},
 				{
 					name: ['--show-secrets'],
 					description: 'Unmask secrets in output.',
 					isDangerous: true,
 				},
+				{
+					name: ['--watch'],
+					description: 'Continuously refresh the status, revisions, replicas and endpoint health of the deployed services.',
+				},
 			],
 			args: {
 				name: 'resource-name|resource-id',
@@ -3296,6 +3651,10 @@ const completionSpec: Fig.Spec = {
 					name: ['auth'],
 					description: 'Authenticate with Azure.',
 					subcommands: [
+						{
+							name: ['list'],
+							description: 'List the auth profiles. (Beta)',
+						},
 						{
 							name: ['login'],
 							description: 'Log in to Azure.',
@@ -3308,6 +3667,10 @@ const completionSpec: Fig.Spec = {
 							name: ['status'],
 							description: 'Show the current authentication status.',
 						},
+						{
+							name: ['switch'],
+							description: 'Switch to another auth profile. (Beta)',
+						},
 					],
 				},
 				{
@@ -3487,6 +3850,12 @@ const completionSpec: Fig.Spec = {
 				{
 					name: ['deploy'],
 					description: 'Deploy your project code to Azure.',
+					subcommands: [
+						{
+							name: ['history'],
+							description: 'List the recorded deployments of the services in the environment.',
+						},
+					],
 				},
 				{
 					name: ['down'],
@@ -3538,6 +3907,10 @@ const completionSpec: Fig.Spec = {
 							name: ['remove', 'rm'],
 							description: 'Remove an environment.',
 						},
+						{
+							name: ['rotate-secret'],
+							description: 'Rotate a Key Vault secret and update the resources and services using it.',
+						},
 						{
 							name: ['select'],
 							description: 'Set the default environment.',
@@ -3556,6 +3929,10 @@ const completionSpec: Fig.Spec = {
 					name: ['extension', 'ext'],
 					description: 'Manage azd extensions.',
 					subcommands: [
+						{
+							name: ['bundle'],
+							description: 'Export extensions and their artifacts into an offline bundle.',
+						},
 						{
 							name: ['install'],
 							description: 'Installs specified extensions.',
@@ -3564,6 +3941,10 @@ const completionSpec: Fig.Spec = {
 							name: ['list'],
 							description: 'List available extensions.',
 						},
+						{
+							name: ['lock'],
+							description: 'Resolve the extensions required by the project and record them in azd.lock.',
+						},
 						{
 							name: ['show'],
 							description: 'Show details for a specific extension.',
@@ -3624,6 +4005,10 @@ const completionSpec: Fig.Spec = {
 					name: ['init'],
 					description: 'Initialize a new application.',
 				},
+				{
+					name: ['logs'],
+					description: 'Stream or query the logs of a deployed project.',
+				},
 				{
 					name: ['mcp'],
 					description: 'Manage Model Context Protocol (MCP) server. (Alpha)',
@@ -3664,6 +4049,10 @@ const completionSpec: Fig.Spec = {
 					name: ['restore'],
 					description: 'Restores the project\'s dependencies.',
 				},
+				{
+					name: ['rollback'],
+					description: 'Roll a service back to a previous deployment.',
+				},
 				{
 					name: ['show'],
 					description: 'Display information about your project and its resources.',
@@ -0,0 +1,418 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"azureaiagent/internal/exterrors"
+	"azureaiagent/internal/pkg/agents/agent_eval"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/spf13/cobra"
+)
+
+type evalFlags struct {
+	dataset       string
+	local         bool
+	name          string
+	port          int
+	timeout       int
+	judgeModel    string
+	output        string
+	junit         string
+	passThreshold float64
+}
+
+// EvalAction handles the execution of the eval command.
+type EvalAction struct {
+	flags *evalFlags
+	// target is the agent the cases are sent to
+	target agent_eval.Target
+	// targetName describes the target in the results
+	targetName string
+	// judge scores the llm_judge evaluators, nil when no judge model is configured
+	judge agent_eval.Judge
+}
+
+func newEvalCommand() *cobra.Command {
+	flags := &evalFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "eval [name]",
+		Short: "Evaluate your agent against a dataset of test cases.",
+		Long: `Evaluate your agent against a dataset of test cases.
+
+Sends the input of each case of a JSONL dataset to the agent and scores its
+response with the evaluators of the case:
+
+  exact        the response equals "value"
+  contains     the response contains "value"
+  regex        the response matches the regular expression "value"
+  json_schema  the response is JSON valid against "schema"
+  tool_call    the agent called "tool", with "arguments" when set,
+               or didn't call it with "not_called"
+  llm_judge    a model decides whether the response meets "criteria"
+               (requires --judge-model)
+
+Each line of the dataset is a case, for example:
+  {"id": "greeting", "input": "Hi!", "evaluators": [{"type": "contains", "value": "Hello", "ignore_case": true}]}
+
+By default the agent is invoked remotely on Foundry. Use --local to evaluate
+a locally running agent (started via 'azd ai agent run') instead.
+
+The command fails when the ratio of passing cases is below --pass-threshold,
+so that it can gate deployments in CI. Use --junit to write a JUnit XML report.`,
+		Example: `  # Evaluate the remote agent on Foundry (auto-detects agent from azure.yaml)
+  azd ai agent eval --dataset cases.jsonl
+
+  # Evaluate the locally running agent
+  azd ai agent eval --dataset cases.jsonl --local
+
+  # Score llm_judge evaluators with a model deployment and write a JUnit report for CI
+  azd ai agent eval --dataset cases.jsonl --judge-model gpt-4o --junit eval-results.xml
+
+  # Output the results as JSON
+  azd ai agent eval --dataset cases.jsonl --output json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := azdext.WithAccessToken(cmd.Context())
+			setupDebugLogging(cmd.Flags())
+
+			if len(args) > 0 {
+				flags.name = args[0]
+			}
+
+			if err := validateEvalFlags(flags); err != nil {
+				return err
+			}
+
+			cases, err := agent_eval.LoadCases(flags.dataset)
+			if err != nil {
+				return exterrors.Validation(
+					exterrors.CodeInvalidEvalDataset,
+					err.Error(),
+					"Each line of the dataset must be a JSON object with an 'input' and a list of 'evaluators'",
+				)
+			}
+
+			if agent_eval.UsesEvaluator(cases, agent_eval.EvaluatorLLMJudge) && flags.judgeModel == "" {
+				return exterrors.Validation(
+					exterrors.CodeInvalidEvalDataset,
+					"the dataset has llm_judge evaluators, which require a judge model",
+					"Provide the name of a model deployment of the Foundry project with --judge-model",
+				)
+			}
+
+			action := &EvalAction{flags: flags}
+			if err := action.resolveTargets(ctx); err != nil {
+				return err
+			}
+
+			return action.Run(ctx, cases)
+		},
+	}
+
+	cmd.Flags().StringVarP(&flags.dataset, "dataset", "d", "", "Path to the JSONL dataset of test cases (required)")
+	cmd.Flags().BoolVarP(&flags.local, "local", "l", false, "Evaluate the agent on localhost instead of Foundry")
+	cmd.Flags().IntVar(&flags.port, "port", DefaultPort, "Local server port")
+	cmd.Flags().IntVarP(&flags.timeout, "timeout", "t", 120, "Timeout of each case in seconds (0 for no timeout)")
+	cmd.Flags().StringVar(&flags.judgeModel, "judge-model", "",
+		"Model deployment of the Foundry project scoring llm_judge evaluators")
+	cmd.Flags().StringVarP(&flags.output, "output", "o", "table", "Output format (table or json)")
+	cmd.Flags().StringVar(&flags.junit, "junit", "", "Path of a JUnit XML report to write")
+	cmd.Flags().Float64Var(&flags.passThreshold, "pass-threshold", 1,
+		"Minimum ratio of passing cases, between 0 and 1, for the command to succeed")
+
+	_ = cmd.MarkFlagRequired("dataset")
+
+	return cmd
+}
+
+func validateEvalFlags(flags *evalFlags) error {
+	if flags.output != "table" && flags.output != "json" {
+		return fmt.Errorf("--output must be 'table' or 'json', got '%s'", flags.output)
+	}
+
+	if flags.passThreshold < 0 || flags.passThreshold > 1 {
+		return fmt.Errorf("--pass-threshold must be between 0 and 1, got %g", flags.passThreshold)
+	}
+
+	if flags.name != "" && flags.local {
+		return fmt.Errorf("cannot use --local with a named agent; named agents are always evaluated remotely on Foundry")
+	}
+
+	return nil
+}
+
+// resolveTargets creates the target of the evaluated agent and the judge of llm_judge evaluators.
+func (a *EvalAction) resolveTargets(ctx context.Context) error {
+	client := &http.Client{Timeout: a.httpTimeout()}
+
+	if a.flags.local {
+		a.target = &agent_eval.HTTPTarget{
+			URL:    fmt.Sprintf("http://localhost:%d/responses", a.flags.port),
+			Client: client,
+		}
+		a.targetName = fmt.Sprintf("localhost:%d (local)", a.flags.port)
+
+		if a.flags.judgeModel == "" {
+			return nil
+		}
+	}
+
+	endpoint, err := resolveAgentEndpoint(ctx, "", "")
+	if err != nil {
+		return err
+	}
+
+	token, err := agentToken()
+	if err != nil {
+		return err
+	}
+	// Surface login errors before running the cases
+	if _, err := token(ctx); err != nil {
+		return fmt.Errorf("failed to get auth token: %w", err)
+	}
+	url := fmt.Sprintf("%s/openai/responses?api-version=%s", endpoint, DefaultAgentAPIVersion)
+
+	if !a.flags.local {
+		name, err := a.resolveAgentName(ctx)
+		if err != nil {
+			return err
+		}
+
+		a.target = &agent_eval.HTTPTarget{
+			URL: url,
+			Body: map[string]any{
+				"agent": map[string]string{
+					"name": name,
+					"type": "agent_reference",
+				},
+			},
+			Token: token,
+			// Each case runs in its own session, so that cases don't depend on each other
+			NewSession: true,
+			Client:     client,
+		}
+		a.targetName = fmt.Sprintf("%s (remote)", name)
+	}
+
+	if a.flags.judgeModel != "" {
+		a.judge = &agent_eval.ModelJudge{
+			Target: &agent_eval.HTTPTarget{
+				URL:    url,
+				Body:   map[string]any{"model": a.flags.judgeModel},
+				Token:  token,
+				Client: client,
+			},
+		}
+	}
+
+	return nil
+}
+
+// resolveAgentName returns the name of the evaluated agent, from the name argument or azure.yaml.
+func (a *EvalAction) resolveAgentName(ctx context.Context) (string, error) {
+	azdClient, err := azdext.NewAzdClient()
+	if err != nil {
+		return "", fmt.Errorf("failed to create azd client: %w", err)
+	}
+	defer azdClient.Close()
+
+	name := a.flags.name
+	if info, err := resolveAgentServiceFromProject(ctx, azdClient, name, rootFlags.NoPrompt); err == nil {
+		if name == "" && info.AgentName != "" {
+			name = info.AgentName
+		}
+	}
+
+	if name == "" {
+		return "", fmt.Errorf(
+			"agent name is required; provide as the first argument or define an azure.ai.agent service in azure.yaml")
+	}
+
+	return name, nil
+}
+
+func (a *EvalAction) httpTimeout() time.Duration {
+	if a.flags.timeout <= 0 {
+		return 0 // no timeout
+	}
+	return time.Duration(a.flags.timeout) * time.Second
+}
+
+// Run evaluates the cases, prints the results and fails when the pass rate is below the threshold.
+func (a *EvalAction) Run(ctx context.Context, cases []*agent_eval.Case) error {
+	fmt.Fprintf(os.Stderr, "Target:  %s\n", a.targetName)
+	fmt.Fprintf(os.Stderr, "Dataset: %s (%d cases)\n\n", a.flags.dataset, len(cases))
+
+	completed := 0
+	report, runErr := agent_eval.Run(ctx, a.target, cases, agent_eval.RunOptions{
+		Judge: a.judge,
+		OnCase: func(result *agent_eval.CaseResult) {
+			completed++
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s: %s\n", completed, len(cases), result.ID, caseStatus(result))
+		},
+	})
+	report.Dataset = a.flags.dataset
+	report.Target = a.targetName
+	fmt.Fprintln(os.Stderr)
+
+	if a.flags.junit != "" {
+		if err := writeJUnitReport(a.flags.junit, report); err != nil {
+			return err
+		}
+	}
+
+	switch a.flags.output {
+	case "json":
+		if err := printEvalJSON(report); err != nil {
+			return err
+		}
+	default:
+		if err := printEvalTable(report); err != nil {
+			return err
+		}
+	}
+
+	if runErr != nil {
+		return fmt.Errorf("evaluation stopped after %d of %d cases: %w", report.Total, len(cases), runErr)
+	}
+
+	if report.PassRate() < a.flags.passThreshold {
+		return fmt.Errorf(
+			"%d of %d evaluation cases failed (pass rate %.0f%%, required %.0f%%)",
+			report.Failed, report.Total, report.PassRate()*100, a.flags.passThreshold*100,
+		)
+	}
+
+	return nil
+}
+
+// agentTokenRefreshMargin is how long before it expires the token of the Foundry APIs is refreshed
+const agentTokenRefreshMargin = 5 * time.Minute
+
+// agentToken returns a function getting the token of the requests to the Foundry agent and model APIs. The token is
+// reused until it's about to expire, evaluations outliving a token get a new one from the credential.
+func agentToken() (func(ctx context.Context) (string, error), error) {
+	credential, err := newAgentCredential()
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	var token azcore.AccessToken
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if time.Until(token.ExpiresOn) > agentTokenRefreshMargin {
+			return token.Token, nil
+		}
+
+		refreshed, err := credential.GetToken(ctx, policy.TokenRequestOptions{
+			Scopes: []string{"https://ai.azure.com/.default"},
+		})
+		if err != nil {
+			return "", err
+		}
+
+		token = refreshed
+		return token.Token, nil
+	}, nil
+}
+
+// caseStatus returns the status of a case result for display: PASS, FAIL or ERROR.
+func caseStatus(result *agent_eval.CaseResult) string {
+	switch {
+	case result.Error != "":
+		return "ERROR"
+	case result.Passed:
+		return "PASS"
+	default:
+		return "FAIL"
+	}
+}
+
+// caseDetails summarizes why a case didn't pass, for display.
+func caseDetails(result *agent_eval.CaseResult) string {
+	if result.Error != "" {
+		return truncateDetails(result.Error)
+	}
+
+	failed := result.FailedEvaluators()
+	if len(failed) == 0 {
+		return ""
+	}
+
+	details := fmt.Sprintf("%s: %s", failed[0].Type, failed[0].Message)
+	if len(failed) > 1 {
+		details = fmt.Sprintf("%s (+%d more)", truncateDetails(details), len(failed)-1)
+	}
+	return truncateDetails(details)
+}
+
+// truncateDetails keeps table rows on a single, readable line.
+func truncateDetails(details string) string {
+	const maxLength = 100
+	details = strings.Join(strings.Fields(details), " ")
+	if len(details) <= maxLength {
+		return details
+	}
+	return details[:maxLength-3] + "..."
+}
+
+func printEvalTable(report *agent_eval.Report) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CASE\tRESULT\tDURATION\tDETAILS")
+	fmt.Fprintln(w, "----\t------\t--------\t-------")
+
+	for _, result := range report.Cases {
+		fmt.Fprintf(w, "%s\t%s\t%dms\t%s\n", result.ID, caseStatus(result), result.DurationMs, caseDetails(result))
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\nPassed %d of %d cases (%.0f%%) in %s\n",
+		report.Passed, report.Total, report.PassRate()*100, time.Duration(report.DurationMs)*time.Millisecond)
+	return nil
+}
+
+func printEvalJSON(report *agent_eval.Report) error {
+	jsonBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal results to JSON: %w", err)
+	}
+	fmt.Println(string(jsonBytes))
+	return nil
+}
+
+// writeJUnitReport writes the results as a JUnit XML report to path.
+func writeJUnitReport(path string, report *agent_eval.Report) error {
+	var buf bytes.Buffer
+	if err := agent_eval.WriteJUnit(&buf, report, "azd ai agent eval"); err != nil {
+		return err
+	}
+
+	//nolint:gosec // G306: the report is meant to be read by CI systems
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write JUnit report %s: %w", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"azureaiagent/internal/exterrors"
+	"azureaiagent/internal/pkg/agents/agent_eval"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/stretchr/testify/require"
+)
+
+func writeEvalDataset(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cases.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestEvalCommand_RequiresDataset(t *testing.T) {
+	cmd := newEvalCommand()
+	cmd.SetArgs([]string{"--local"})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dataset")
+}
+
+func TestEvalCommand_InvalidDataset(t *testing.T) {
+	cmd := newEvalCommand()
+	cmd.SetArgs([]string{"--local", "--dataset", writeEvalDataset(t, `{"input": "Hi", "evaluators": []}`)})
+
+	err := cmd.Execute()
+
+	var localErr *azdext.LocalError
+	require.True(t, errors.As(err, &localErr))
+	require.Equal(t, exterrors.CodeInvalidEvalDataset, localErr.Code)
+	require.Contains(t, localErr.Message, "at least one evaluator is required")
+}
+
+func TestEvalCommand_JudgeModelRequired(t *testing.T) {
+	dataset := writeEvalDataset(t, `{"input": "Hi", "evaluators": [{"type": "llm_judge", "criteria": "Polite"}]}`)
+	cmd := newEvalCommand()
+	cmd.SetArgs([]string{"--local", "--dataset", dataset})
+
+	err := cmd.Execute()
+
+	var localErr *azdext.LocalError
+	require.True(t, errors.As(err, &localErr))
+	require.Equal(t, exterrors.CodeInvalidEvalDataset, localErr.Code)
+	require.Contains(t, localErr.Suggestion, "--judge-model")
+}
+
+func TestValidateEvalFlags(t *testing.T) {
+	tests := []struct {
+		name   string
+		flags  evalFlags
+		errMsg string
+	}{
+		{
+			name:  "defaults",
+			flags: evalFlags{output: "table", passThreshold: 1},
+		},
+		{
+			name:  "json output with partial threshold",
+			flags: evalFlags{output: "json", passThreshold: 0.8},
+		},
+		{
+			name:   "unsupported output",
+			flags:  evalFlags{output: "yaml", passThreshold: 1},
+			errMsg: "--output must be 'table' or 'json'",
+		},
+		{
+			name:   "threshold above 1",
+			flags:  evalFlags{output: "table", passThreshold: 80},
+			errMsg: "--pass-threshold must be between 0 and 1",
+		},
+		{
+			name:   "local with named agent",
+			flags:  evalFlags{output: "table", passThreshold: 1, local: true, name: "my-agent"},
+			errMsg: "cannot use --local with a named agent",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEvalFlags(&tt.flags)
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestEvalAction_Run(t *testing.T) {
+	server := httptest.NewServer(&agent_eval.StubAgent{
+		Responses: map[string]*agent_eval.Response{
+			"What's 2+2?": {Text: "4"},
+		},
+	})
+	t.Cleanup(server.Close)
+
+	cases, err := agent_eval.LoadCases(writeEvalDataset(t,
+		`{"id": "math", "input": "What's 2+2?", "evaluators": [{"type": "exact", "value": "4"}]}
+{"id": "greeting", "input": "Hi", "evaluators": [{"type": "contains", "value": "Hello"}]}
+`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		output    string
+		threshold float64
+		errMsg    string
+	}{
+		{
+			name:      "below threshold",
+			output:    "table",
+			threshold: 1,
+			errMsg:    "1 of 2 evaluation cases failed (pass rate 50%, required 100%)",
+		},
+		{
+			name:      "meets threshold",
+			output:    "json",
+			threshold: 0.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			junitPath := filepath.Join(t.TempDir(), "results.xml")
+			action := &EvalAction{
+				flags: &evalFlags{
+					dataset:       "cases.jsonl",
+					output:        tt.output,
+					junit:         junitPath,
+					passThreshold: tt.threshold,
+				},
+				target:     &agent_eval.HTTPTarget{URL: server.URL + "/responses"},
+				targetName: "stub",
+			}
+
+			err := action.Run(t.Context(), cases)
+			if tt.errMsg == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.errMsg)
+			}
+
+			junit, err := os.ReadFile(junitPath)
+			require.NoError(t, err)
+			require.Contains(t, string(junit), `<testcase name="math"`)
+			require.Contains(t, string(junit), `contains: expected response to contain &#34;Hello&#34;`)
+		})
+	}
+}
+
+func TestCaseDetails(t *testing.T) {
+	require.Equal(t, "", caseDetails(&agent_eval.CaseResult{Passed: true}))
+	require.Equal(t, "HTTP 500: boom", caseDetails(&agent_eval.CaseResult{Error: "HTTP 500:\n  boom"}))
+	require.Equal(t, `exact: expected "4", got "5" (+1 more)`, caseDetails(&agent_eval.CaseResult{
+		Evaluators: []agent_eval.EvaluatorResult{
+			{Type: agent_eval.EvaluatorExact, Message: `expected "4", got "5"`},
+			{Type: agent_eval.EvaluatorRegex, Message: "expected response to match /^4$/"},
+			{Type: agent_eval.EvaluatorContains, Passed: true},
+		},
+	}))
+}
@@ -1039,6 +1039,16 @@ func (a *InitAction) downloadAgentYaml(
 			)
 		}
 
+		// Inline the shared tool and prompt files referenced by the manifest
+		content, err = agent_yaml.ResolveRefs(content, manifestPointer)
+		if err != nil {
+			return nil, "", exterrors.Validation(
+				exterrors.CodeInvalidAgentManifest,
+				fmt.Sprintf("resolving references of manifest file %s: %s", manifestPointer, err),
+				"verify the files referenced with $ref exist, relative to the manifest file",
+			)
+		}
+
 		// Parse the YAML content into genericManifest
 		var genericManifest map[string]any
 		if err := yaml.Unmarshal(content, &genericManifest); err != nil {
@@ -1466,12 +1476,21 @@ func (a *InitAction) addToProject(ctx context.Context, targetDir string, agentMa
 		}
 	}
 
+	graph, err := a.connectedAgentGraph(serviceConfig.Name, agentDef.ConnectedAgents)
+	if err != nil {
+		return err
+	}
+
 	req := &azdext.AddServiceRequest{Service: serviceConfig}
 
 	if _, err := a.azdClient.Project().AddService(ctx, req); err != nil {
 		return fmt.Errorf("adding agent service to project: %w", err)
 	}
 
+	if err := a.addConnectedAgentsToUses(ctx, graph); err != nil {
+		return err
+	}
+
 	fmt.Printf("\nAdded your agent as a service entry named '%s' under the file azure.yaml.\n", agentDef.Name)
 	fmt.Printf("To provision and deploy the whole solution, use %s.\n", color.HiBlueString("azd up"))
 	fmt.Printf(
@@ -0,0 +1,131 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"azureaiagent/internal/exterrors"
+	"azureaiagent/internal/pkg/agents/agent_yaml"
+	"azureaiagent/internal/project"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// connectedAgentGraph returns the graph of the agents of the project including the agent being added as
+// serviceName, and checks that connected agents don't form cycles. Connected agents which are not agents of
+// the project yet only produce a warning, as they may be added next.
+func (a *InitAction) connectedAgentGraph(
+	serviceName string,
+	connectedAgents []agent_yaml.ConnectedAgent,
+) (agent_yaml.AgentGraph, error) {
+	graph := agent_yaml.AgentGraph{}
+	if a.projectConfig != nil {
+		environmentName := ""
+		if a.environment != nil {
+			environmentName = a.environment.Name
+		}
+
+		var err error
+		graph, err = project.BuildAgentGraph(a.projectConfig, environmentName)
+		if err != nil {
+			return nil, exterrors.Validation(
+				exterrors.CodeInvalidAgentGraph,
+				fmt.Sprintf("failed to load the agents of the project: %s", err),
+				"fix the agent.yaml files of the azure.ai.agent services in azure.yaml",
+			)
+		}
+	}
+
+	graph[serviceName] = nil
+	for _, connected := range connectedAgents {
+		graph[serviceName] = append(graph[serviceName], connected.Service)
+	}
+
+	for _, connected := range connectedAgents {
+		if _, has := graph[connected.Service]; !has {
+			fmt.Println(output.WithWarningFormat(
+				"Connected agent '%s' is not an agent of the project yet. Add it to azure.yaml with "+
+					"'azd ai agent init' before deploying '%s'.",
+				connected.Service, serviceName))
+		}
+	}
+
+	if _, err := graph.DeploymentOrder(); err != nil {
+		return nil, exterrors.Validation(
+			exterrors.CodeInvalidAgentGraph,
+			err.Error(),
+			"remove one of the connections of the cycle from the connectedAgents of its agent.yaml",
+		)
+	}
+
+	return graph, nil
+}
+
+// addConnectedAgentsToUses adds the connected agents of each agent of the graph to 'uses' of its service in
+// azure.yaml, so that azd deploys connected agents first and their endpoints are known when deploying the
+// agents connecting to them
+func (a *InitAction) addConnectedAgentsToUses(ctx context.Context, graph agent_yaml.AgentGraph) error {
+	for service, uses := range connectedAgentUses(graph) {
+		resp, err := a.azdClient.Project().GetServiceConfigValue(ctx, &azdext.GetServiceConfigValueRequest{
+			ServiceName: service,
+			Path:        "uses",
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get 'uses' of service '%s': %w", service, err)
+		}
+
+		var existing []any
+		if resp.Found {
+			existing = resp.Value.GetListValue().AsSlice()
+		}
+
+		updated := existing
+		var added []string
+		for _, connected := range uses {
+			if !slices.Contains(existing, any(connected)) {
+				updated = append(updated, connected)
+				added = append(added, connected)
+			}
+		}
+
+		if len(added) == 0 {
+			continue
+		}
+
+		value, err := structpb.NewValue(updated)
+		if err != nil {
+			return fmt.Errorf("failed to convert 'uses' of service '%s': %w", service, err)
+		}
+
+		if _, err := a.azdClient.Project().SetServiceConfigValue(ctx, &azdext.SetServiceConfigValueRequest{
+			ServiceName: service,
+			Path:        "uses",
+			Value:       value,
+		}); err != nil {
+			return fmt.Errorf("failed to set 'uses' of service '%s': %w", service, err)
+		}
+
+		fmt.Printf("Added connected agents %v to 'uses' of service '%s' in azure.yaml.\n", added, service)
+	}
+
+	return nil
+}
+
+// connectedAgentUses returns the connected agents of each agent of the graph which are agents of the graph
+func connectedAgentUses(graph agent_yaml.AgentGraph) map[string][]string {
+	uses := map[string][]string{}
+	for service, connectedAgents := range graph {
+		for _, connected := range connectedAgents {
+			if _, has := graph[connected]; has {
+				uses[service] = append(uses[service], connected)
+			}
+		}
+	}
+	return uses
+}
@@ -0,0 +1,64 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"azureaiagent/internal/exterrors"
+	"azureaiagent/internal/pkg/agents/agent_yaml"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitAction_ConnectedAgentGraph(t *testing.T) {
+	projectPath := t.TempDir()
+	billingDir := filepath.Join(projectPath, "src", "billing")
+	require.NoError(t, os.MkdirAll(billingDir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(billingDir, "agent.yaml"), []byte(`
+kind: hosted
+name: billing
+connectedAgents:
+  - service: triage
+`), 0600))
+
+	action := &InitAction{
+		projectConfig: &azdext.ProjectConfig{
+			Path: projectPath,
+			Services: map[string]*azdext.ServiceConfig{
+				"billing": {Name: "billing", Host: AiAgentHost, RelativePath: "src/billing"},
+			},
+		},
+	}
+
+	// Connecting to an agent which isn't in the project yet only warns
+	graph, err := action.connectedAgentGraph("shipping", []agent_yaml.ConnectedAgent{{Service: "tracking"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"tracking"}, graph["shipping"])
+
+	// billing connects to triage, triage connecting back to billing is a cycle
+	_, err = action.connectedAgentGraph("triage", []agent_yaml.ConnectedAgent{{Service: "billing"}})
+
+	var localErr *azdext.LocalError
+	require.True(t, errors.As(err, &localErr))
+	require.Equal(t, exterrors.CodeInvalidAgentGraph, localErr.Code)
+	require.Contains(t, localErr.Message, "billing -> triage -> billing")
+}
+
+func TestConnectedAgentUses(t *testing.T) {
+	uses := connectedAgentUses(agent_yaml.AgentGraph{
+		"triage":  {"billing", "tracking"},
+		"billing": {"ledger"},
+		"ledger":  nil,
+	})
+
+	require.Equal(t, map[string][]string{
+		"triage":  {"billing"},
+		"billing": {"ledger"},
+	}, uses)
+}
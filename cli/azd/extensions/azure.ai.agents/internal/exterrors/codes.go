@@ -28,6 +28,8 @@ const (
 	CodeMissingPublishedContainer = "missing_published_container_artifact"
 	CodeScaffoldTemplateFailed    = "scaffold_template_failed"
 	CodeModelDeploymentNotFound   = "model_deployment_not_found"
+	CodeInvalidEvalDataset        = "invalid_eval_dataset"
+	CodeInvalidAgentGraph         = "invalid_agent_graph"
 )
 
 // Error codes for dependency errors.
@@ -43,6 +45,7 @@ const (
 	CodeMissingAgentEnvVars       = "missing_agent_env_vars"
 	CodeGitHubDownloadFailed      = "github_download_failed"
 	CodePromptFailed              = "prompt_failed"
+	CodeConnectedAgentNotDeployed = "connected_agent_not_deployed"
 )
 
 // Error codes for auth errors.
@@ -0,0 +1,234 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_eval
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// EvaluatorType is the type of an evaluator scoring the response of an agent
+type EvaluatorType string
+
+const (
+	// EvaluatorExact checks that the response text equals a value
+	EvaluatorExact EvaluatorType = "exact"
+	// EvaluatorContains checks that the response text contains a value
+	EvaluatorContains EvaluatorType = "contains"
+	// EvaluatorRegex checks that the response text matches a regular expression
+	EvaluatorRegex EvaluatorType = "regex"
+	// EvaluatorJSONSchema checks that the response text is JSON valid against a schema
+	EvaluatorJSONSchema EvaluatorType = "json_schema"
+	// EvaluatorToolCall checks that the agent called, or didn't call, a tool
+	EvaluatorToolCall EvaluatorType = "tool_call"
+	// EvaluatorLLMJudge asks a model whether the response meets criteria
+	EvaluatorLLMJudge EvaluatorType = "llm_judge"
+)
+
+// EvaluatorTypes lists the supported evaluator types
+var EvaluatorTypes = []EvaluatorType{
+	EvaluatorExact,
+	EvaluatorContains,
+	EvaluatorRegex,
+	EvaluatorJSONSchema,
+	EvaluatorToolCall,
+	EvaluatorLLMJudge,
+}
+
+// maxCaseLineSize is the largest line read from a dataset of cases
+const maxCaseLineSize = 16 * 1024 * 1024
+
+// Case is an evaluation case: an input sent to the agent and the evaluators scoring its response
+type Case struct {
+	// ID identifies the case in the results. Defaults to case-<n>.
+	ID         string       `json:"id,omitempty"`
+	Input      string       `json:"input"`
+	Evaluators []*Evaluator `json:"evaluators"`
+}
+
+// Evaluator is the configuration of an evaluator of a case
+type Evaluator struct {
+	Type EvaluatorType `json:"type"`
+
+	// Value is the expected text of exact and contains, or the pattern of regex
+	Value string `json:"value,omitempty"`
+	// IgnoreCase makes exact, contains and regex case-insensitive
+	IgnoreCase bool `json:"ignore_case,omitempty"`
+
+	// Schema is the JSON schema of json_schema
+	Schema json.RawMessage `json:"schema,omitempty"`
+
+	// Tool is the name of the tool of tool_call
+	Tool string `json:"tool,omitempty"`
+	// Arguments are the arguments the tool of tool_call is expected to be called with.
+	// Arguments which aren't listed are not checked.
+	Arguments map[string]any `json:"arguments,omitempty"`
+	// NotCalled makes tool_call check that the tool wasn't called
+	NotCalled bool `json:"not_called,omitempty"`
+
+	// Criteria are the criteria of llm_judge, such as "The response politely declines"
+	Criteria string `json:"criteria,omitempty"`
+
+	pattern *regexp.Regexp
+	schema  *jsonschema.Schema
+}
+
+// LoadCases reads a JSONL dataset of evaluation cases, one case per line, and validates them.
+// Blank lines are skipped.
+func LoadCases(path string) ([]*Case, error) {
+	file, err := os.Open(path) //nolint:gosec // G304: path is the dataset explicitly provided by the user
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxCaseLineSize)
+
+	var cases []*Case
+	ids := map[string]int{}
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+
+		var c Case
+		if err := decoder.Decode(&c); err != nil {
+			return nil, fmt.Errorf("line %d: invalid case: %w", line, err)
+		}
+
+		if c.ID == "" {
+			c.ID = fmt.Sprintf("case-%d", len(cases)+1)
+		}
+		if first, has := ids[c.ID]; has {
+			return nil, fmt.Errorf("line %d: duplicate case id '%s', first used on line %d", line, c.ID, first)
+		}
+		ids[c.ID] = line
+
+		if err := c.validate(); err != nil {
+			return nil, fmt.Errorf("line %d: case '%s': %w", line, c.ID, err)
+		}
+
+		cases = append(cases, &c)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dataset %s: %w", path, err)
+	}
+
+	if len(cases) == 0 {
+		return nil, fmt.Errorf("dataset %s has no cases", path)
+	}
+
+	return cases, nil
+}
+
+// UsesEvaluator returns true if any of the cases has an evaluator of type evaluatorType
+func UsesEvaluator(cases []*Case, evaluatorType EvaluatorType) bool {
+	for _, c := range cases {
+		for _, e := range c.Evaluators {
+			if e.Type == evaluatorType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// validate checks the case and compiles the patterns and schemas of its evaluators
+func (c *Case) validate() error {
+	if c.Input == "" {
+		return fmt.Errorf("'input' is required")
+	}
+	if len(c.Evaluators) == 0 {
+		return fmt.Errorf("at least one evaluator is required")
+	}
+
+	for i, e := range c.Evaluators {
+		if err := e.compile(); err != nil {
+			return fmt.Errorf("evaluator %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// compile checks the configuration of the evaluator and compiles its pattern or schema
+func (e *Evaluator) compile() error {
+	switch e.Type {
+	case EvaluatorExact, EvaluatorContains:
+		if e.Value == "" {
+			return fmt.Errorf("%s requires 'value'", e.Type)
+		}
+	case EvaluatorRegex:
+		if e.Value == "" {
+			return fmt.Errorf("regex requires 'value'")
+		}
+		pattern := e.Value
+		if e.IgnoreCase {
+			pattern = "(?i)" + pattern
+		}
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid regex: %w", err)
+		}
+		e.pattern = compiled
+	case EvaluatorJSONSchema:
+		if len(e.Schema) == 0 {
+			return fmt.Errorf("json_schema requires 'schema'")
+		}
+		compiled, err := compileSchema(e.Schema)
+		if err != nil {
+			return err
+		}
+		e.schema = compiled
+	case EvaluatorToolCall:
+		if e.Tool == "" {
+			return fmt.Errorf("tool_call requires 'tool'")
+		}
+	case EvaluatorLLMJudge:
+		if e.Criteria == "" {
+			return fmt.Errorf("llm_judge requires 'criteria'")
+		}
+	case "":
+		return fmt.Errorf("'type' is required")
+	default:
+		return fmt.Errorf("unsupported evaluator type '%s', supported types: %v", e.Type, EvaluatorTypes)
+	}
+
+	return nil
+}
+
+// compileSchema compiles the JSON schema of a json_schema evaluator
+func compileSchema(schema json.RawMessage) (*jsonschema.Schema, error) {
+	document, err := jsonschema.UnmarshalJSON(bytes.NewReader(schema))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	const resourceURI = "mem://eval-case-schema.json"
+	compiler := jsonschema.NewCompiler()
+	if err := compiler.AddResource(resourceURI, document); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	compiled, err := compiler.Compile(resourceURI)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	return compiled, nil
+}
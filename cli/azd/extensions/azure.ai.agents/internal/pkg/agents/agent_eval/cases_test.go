@@ -0,0 +1,126 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_eval
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeDataset(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cases.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadCases(t *testing.T) {
+	path := writeDataset(t, `{"id": "greeting", "input": "Hi", "evaluators": [{"type": "contains", "value": "Hello"}]}
+
+{"input": "Weather?", "evaluators": [{"type": "tool_call", "tool": "get_weather", "arguments": {"city": "Paris"}}]}
+{"input": "JSON", "evaluators": [{"type": "json_schema", "schema": {"type": "object", "required": ["a"]}}]}
+{"input": "Shout", "evaluators": [{"type": "regex", "value": "^HELLO", "ignore_case": true}]}
+`)
+
+	cases, err := LoadCases(path)
+	require.NoError(t, err)
+	require.Len(t, cases, 4)
+
+	require.Equal(t, "greeting", cases[0].ID)
+	require.Equal(t, "case-2", cases[1].ID)
+	require.Equal(t, "get_weather", cases[1].Evaluators[0].Tool)
+	require.Equal(t, map[string]any{"city": "Paris"}, cases[1].Evaluators[0].Arguments)
+	require.NotNil(t, cases[2].Evaluators[0].schema)
+	require.True(t, cases[3].Evaluators[0].pattern.MatchString("hello world"))
+
+	require.True(t, UsesEvaluator(cases, EvaluatorToolCall))
+	require.False(t, UsesEvaluator(cases, EvaluatorLLMJudge))
+}
+
+func TestLoadCases_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{
+			name:    "empty dataset",
+			content: "\n\n",
+			errMsg:  "has no cases",
+		},
+		{
+			name:    "invalid JSON",
+			content: `{"input": `,
+			errMsg:  "line 1: invalid case",
+		},
+		{
+			name:    "unknown field",
+			content: `{"input": "Hi", "expected": "Hello", "evaluators": [{"type": "exact", "value": "Hello"}]}`,
+			errMsg:  "unknown field",
+		},
+		{
+			name:    "missing input",
+			content: `{"evaluators": [{"type": "exact", "value": "Hello"}]}`,
+			errMsg:  "line 1: case 'case-1': 'input' is required",
+		},
+		{
+			name:    "missing evaluators",
+			content: `{"input": "Hi"}`,
+			errMsg:  "at least one evaluator is required",
+		},
+		{
+			name: "duplicate id",
+			content: `{"id": "a", "input": "Hi", "evaluators": [{"type": "contains", "value": "Hello"}]}
+{"id": "a", "input": "Bye", "evaluators": [{"type": "contains", "value": "Bye"}]}`,
+			errMsg: "line 2: duplicate case id 'a', first used on line 1",
+		},
+		{
+			name:    "unsupported evaluator",
+			content: `{"input": "Hi", "evaluators": [{"type": "bleu"}]}`,
+			errMsg:  "evaluator 1: unsupported evaluator type 'bleu'",
+		},
+		{
+			name:    "missing value",
+			content: `{"input": "Hi", "evaluators": [{"type": "exact"}]}`,
+			errMsg:  "exact requires 'value'",
+		},
+		{
+			name:    "invalid regex",
+			content: `{"input": "Hi", "evaluators": [{"type": "regex", "value": "("}]}`,
+			errMsg:  "invalid regex",
+		},
+		{
+			name:    "invalid schema",
+			content: `{"input": "Hi", "evaluators": [{"type": "json_schema", "schema": {"type": 42}}]}`,
+			errMsg:  "invalid schema",
+		},
+		{
+			name:    "missing tool",
+			content: `{"input": "Hi", "evaluators": [{"type": "tool_call"}]}`,
+			errMsg:  "tool_call requires 'tool'",
+		},
+		{
+			name:    "missing criteria",
+			content: `{"input": "Hi", "evaluators": [{"type": "llm_judge"}]}`,
+			errMsg:  "llm_judge requires 'criteria'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadCases(writeDataset(t, tt.content))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestLoadCases_MissingFile(t *testing.T) {
+	_, err := LoadCases(filepath.Join(t.TempDir(), "missing.jsonl"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to open dataset")
+}
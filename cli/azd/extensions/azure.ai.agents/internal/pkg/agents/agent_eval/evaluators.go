@@ -0,0 +1,170 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// EvaluatorResult is the score of a response by an evaluator
+type EvaluatorResult struct {
+	Type    EvaluatorType `json:"type"`
+	Passed  bool          `json:"passed"`
+	Message string        `json:"message,omitempty"`
+}
+
+// Evaluate scores the response of the agent to input. The judge is only used by llm_judge evaluators.
+func (e *Evaluator) Evaluate(ctx context.Context, input string, response *Response, judge Judge) EvaluatorResult {
+	result := EvaluatorResult{Type: e.Type}
+
+	switch e.Type {
+	case EvaluatorExact:
+		actual := strings.TrimSpace(response.Text)
+		result.Passed = actual == e.Value || (e.IgnoreCase && strings.EqualFold(actual, e.Value))
+		if !result.Passed {
+			result.Message = fmt.Sprintf("expected %q, got %q", e.Value, truncate(actual))
+		}
+	case EvaluatorContains:
+		if e.IgnoreCase {
+			result.Passed = strings.Contains(strings.ToLower(response.Text), strings.ToLower(e.Value))
+		} else {
+			result.Passed = strings.Contains(response.Text, e.Value)
+		}
+		if !result.Passed {
+			result.Message = fmt.Sprintf("expected response to contain %q", e.Value)
+		}
+	case EvaluatorRegex:
+		result.Passed = e.pattern.MatchString(response.Text)
+		if !result.Passed {
+			result.Message = fmt.Sprintf("expected response to match /%s/", e.Value)
+		}
+	case EvaluatorJSONSchema:
+		result.Passed, result.Message = e.evaluateSchema(response.Text)
+	case EvaluatorToolCall:
+		result.Passed, result.Message = e.evaluateToolCall(response.ToolCalls)
+	case EvaluatorLLMJudge:
+		if judge == nil {
+			result.Message = "llm_judge requires a judge model"
+			break
+		}
+		verdict, err := judge.Judge(ctx, e.Criteria, input, response.Text)
+		if err != nil {
+			result.Message = fmt.Sprintf("judge failed: %v", err)
+			break
+		}
+		result.Passed = verdict.Pass
+		result.Message = verdict.Reason
+	default:
+		result.Message = fmt.Sprintf("unsupported evaluator type '%s'", e.Type)
+	}
+
+	return result
+}
+
+// evaluateSchema checks that the text is a JSON document valid against the schema of the evaluator
+func (e *Evaluator) evaluateSchema(text string) (bool, string) {
+	document, err := jsonschema.UnmarshalJSON(strings.NewReader(stripCodeFence(text)))
+	if err != nil {
+		return false, fmt.Sprintf("response is not JSON: %v", err)
+	}
+
+	if err := e.schema.Validate(document); err != nil {
+		return false, fmt.Sprintf("response doesn't match the schema: %v", err)
+	}
+
+	return true, ""
+}
+
+// evaluateToolCall checks whether the tool of the evaluator was called with its arguments
+func (e *Evaluator) evaluateToolCall(calls []ToolCall) (bool, string) {
+	var names []string
+	calledWithOtherArguments := false
+	for _, call := range calls {
+		if !slices.Contains(names, call.Name) {
+			names = append(names, call.Name)
+		}
+		if call.Name != e.Tool {
+			continue
+		}
+
+		if hasArguments(call.Arguments, e.Arguments) {
+			if e.NotCalled {
+				return false, fmt.Sprintf("expected tool '%s' not to be called", e.Tool)
+			}
+			return true, ""
+		}
+		calledWithOtherArguments = true
+	}
+
+	if e.NotCalled {
+		return true, ""
+	}
+
+	if calledWithOtherArguments {
+		expected, _ := json.Marshal(e.Arguments)
+		return false, fmt.Sprintf("tool '%s' was not called with arguments %s", e.Tool, expected)
+	}
+
+	called := "no tools"
+	if len(names) > 0 {
+		called = strings.Join(names, ", ")
+	}
+	return false, fmt.Sprintf("tool '%s' was not called (called: %s)", e.Tool, called)
+}
+
+// hasArguments returns true if the arguments of a call include all the expected arguments
+func hasArguments(actual map[string]any, expected map[string]any) bool {
+	for name, value := range expected {
+		actualValue, has := actual[name]
+		if !has || !reflect.DeepEqual(normalizeJSON(actualValue), normalizeJSON(value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeJSON round-trips a value through JSON, so that values decoded differently compare equal
+func normalizeJSON(value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+
+	var normalized any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
+
+// stripCodeFence removes a markdown code fence around a response, as models often fence JSON
+func stripCodeFence(text string) string {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "```") || !strings.HasSuffix(text, "```") || len(text) < 6 {
+		return text
+	}
+
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "```"), "```")
+	// Drop the language of the fence, such as ```json
+	if newline := strings.IndexByte(text, '\n'); newline >= 0 && !strings.ContainsAny(text[:newline], "{[\"") {
+		text = text[newline+1:]
+	}
+	return strings.TrimSpace(text)
+}
+
+// truncate shortens long texts in messages
+func truncate(text string) string {
+	const maxLength = 80
+	if len(text) <= maxLength {
+		return text
+	}
+	return text[:maxLength] + "..."
+}
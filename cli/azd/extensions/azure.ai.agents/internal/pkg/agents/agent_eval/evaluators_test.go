@@ -0,0 +1,223 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_eval
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeJudge struct {
+	verdict *Verdict
+	err     error
+}
+
+func (j *fakeJudge) Judge(ctx context.Context, criteria string, input string, response string) (*Verdict, error) {
+	return j.verdict, j.err
+}
+
+func compiledEvaluator(t *testing.T, e *Evaluator) *Evaluator {
+	t.Helper()
+	require.NoError(t, e.compile())
+	return e
+}
+
+func TestEvaluator_Evaluate(t *testing.T) {
+	weatherCall := []ToolCall{
+		{Name: "search", Arguments: map[string]any{"query": "weather"}},
+		{Name: "get_weather", Arguments: map[string]any{"city": "Paris", "days": float64(3)}},
+	}
+
+	tests := []struct {
+		name       string
+		evaluator  *Evaluator
+		response   *Response
+		judge      Judge
+		wantPassed bool
+		wantMsg    string
+	}{
+		{
+			name:       "exact match ignores surrounding whitespace",
+			evaluator:  &Evaluator{Type: EvaluatorExact, Value: "Paris"},
+			response:   &Response{Text: " Paris\n"},
+			wantPassed: true,
+		},
+		{
+			name:      "exact mismatch",
+			evaluator: &Evaluator{Type: EvaluatorExact, Value: "Paris"},
+			response:  &Response{Text: "paris"},
+			wantMsg:   `expected "Paris", got "paris"`,
+		},
+		{
+			name:       "exact ignoring case",
+			evaluator:  &Evaluator{Type: EvaluatorExact, Value: "Paris", IgnoreCase: true},
+			response:   &Response{Text: "PARIS"},
+			wantPassed: true,
+		},
+		{
+			name:       "contains",
+			evaluator:  &Evaluator{Type: EvaluatorContains, Value: "42"},
+			response:   &Response{Text: "The answer is 42."},
+			wantPassed: true,
+		},
+		{
+			name:      "contains mismatch",
+			evaluator: &Evaluator{Type: EvaluatorContains, Value: "Hello"},
+			response:  &Response{Text: "hello"},
+			wantMsg:   `expected response to contain "Hello"`,
+		},
+		{
+			name:       "contains ignoring case",
+			evaluator:  &Evaluator{Type: EvaluatorContains, Value: "Hello", IgnoreCase: true},
+			response:   &Response{Text: "hello there"},
+			wantPassed: true,
+		},
+		{
+			name:       "regex",
+			evaluator:  &Evaluator{Type: EvaluatorRegex, Value: `\d{3}-\d{4}`},
+			response:   &Response{Text: "Call 555-1234"},
+			wantPassed: true,
+		},
+		{
+			name:      "regex mismatch",
+			evaluator: &Evaluator{Type: EvaluatorRegex, Value: `^\d+$`},
+			response:  &Response{Text: "forty-two"},
+			wantMsg:   `expected response to match /^\d+$/`,
+		},
+		{
+			name: "json schema",
+			evaluator: &Evaluator{
+				Type:   EvaluatorJSONSchema,
+				Schema: []byte(`{"type": "object", "required": ["city"], "properties": {"days": {"type": "integer"}}}`),
+			},
+			response:   &Response{Text: "```json\n{\"city\": \"Paris\", \"days\": 3}\n```"},
+			wantPassed: true,
+		},
+		{
+			name: "json schema violation",
+			evaluator: &Evaluator{
+				Type:   EvaluatorJSONSchema,
+				Schema: []byte(`{"type": "object", "required": ["city"]}`),
+			},
+			response: &Response{Text: `{"town": "Paris"}`},
+			wantMsg:  "response doesn't match the schema",
+		},
+		{
+			name:      "json schema on text",
+			evaluator: &Evaluator{Type: EvaluatorJSONSchema, Schema: []byte(`{"type": "object"}`)},
+			response:  &Response{Text: "It's sunny in Paris"},
+			wantMsg:   "response is not JSON",
+		},
+		{
+			name:       "tool called",
+			evaluator:  &Evaluator{Type: EvaluatorToolCall, Tool: "get_weather"},
+			response:   &Response{ToolCalls: weatherCall},
+			wantPassed: true,
+		},
+		{
+			name: "tool called with a subset of arguments",
+			evaluator: &Evaluator{
+				Type: EvaluatorToolCall, Tool: "get_weather", Arguments: map[string]any{"days": 3},
+			},
+			response:   &Response{ToolCalls: weatherCall},
+			wantPassed: true,
+		},
+		{
+			name: "tool called with other arguments",
+			evaluator: &Evaluator{
+				Type: EvaluatorToolCall, Tool: "get_weather", Arguments: map[string]any{"city": "London"},
+			},
+			response: &Response{ToolCalls: weatherCall},
+			wantMsg:  `tool 'get_weather' was not called with arguments {"city":"London"}`,
+		},
+		{
+			name:      "tool not called",
+			evaluator: &Evaluator{Type: EvaluatorToolCall, Tool: "book_flight"},
+			response:  &Response{ToolCalls: weatherCall},
+			wantMsg:   "tool 'book_flight' was not called (called: search, get_weather)",
+		},
+		{
+			name:      "no tools called",
+			evaluator: &Evaluator{Type: EvaluatorToolCall, Tool: "book_flight"},
+			response:  &Response{Text: "Sure"},
+			wantMsg:   "tool 'book_flight' was not called (called: no tools)",
+		},
+		{
+			name:       "tool expected not to be called",
+			evaluator:  &Evaluator{Type: EvaluatorToolCall, Tool: "book_flight", NotCalled: true},
+			response:   &Response{ToolCalls: weatherCall},
+			wantPassed: true,
+		},
+		{
+			name:      "tool called when expected not to be",
+			evaluator: &Evaluator{Type: EvaluatorToolCall, Tool: "search", NotCalled: true},
+			response:  &Response{ToolCalls: weatherCall},
+			wantMsg:   "expected tool 'search' not to be called",
+		},
+		{
+			name:       "llm judge pass",
+			evaluator:  &Evaluator{Type: EvaluatorLLMJudge, Criteria: "Polite"},
+			response:   &Response{Text: "Thank you!"},
+			judge:      &fakeJudge{verdict: &Verdict{Pass: true, Reason: "The response thanks the user"}},
+			wantPassed: true,
+			wantMsg:    "The response thanks the user",
+		},
+		{
+			name:      "llm judge fail",
+			evaluator: &Evaluator{Type: EvaluatorLLMJudge, Criteria: "Polite"},
+			response:  &Response{Text: "No."},
+			judge:     &fakeJudge{verdict: &Verdict{Pass: false, Reason: "The response is curt"}},
+			wantMsg:   "The response is curt",
+		},
+		{
+			name:      "llm judge error",
+			evaluator: &Evaluator{Type: EvaluatorLLMJudge, Criteria: "Polite"},
+			response:  &Response{Text: "No."},
+			judge:     &fakeJudge{err: errors.New("HTTP 429")},
+			wantMsg:   "judge failed: HTTP 429",
+		},
+		{
+			name:      "llm judge without judge",
+			evaluator: &Evaluator{Type: EvaluatorLLMJudge, Criteria: "Polite"},
+			response:  &Response{Text: "No."},
+			wantMsg:   "llm_judge requires a judge model",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := compiledEvaluator(t, tt.evaluator)
+			result := e.Evaluate(t.Context(), "input", tt.response, tt.judge)
+
+			require.Equal(t, tt.evaluator.Type, result.Type)
+			require.Equal(t, tt.wantPassed, result.Passed)
+			if tt.wantMsg != "" {
+				require.Contains(t, result.Message, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestStripCodeFence(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "no fence", text: ` {"a": 1} `, want: `{"a": 1}`},
+		{name: "fence with language", text: "```json\n{\"a\": 1}\n```", want: `{"a": 1}`},
+		{name: "fence without language", text: "```\n[1, 2]\n```", want: `[1, 2]`},
+		{name: "single line fence", text: "```{\"a\": 1}```", want: `{"a": 1}`},
+		{name: "only backticks", text: "```", want: "```"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, stripCodeFence(tt.text))
+		})
+	}
+}
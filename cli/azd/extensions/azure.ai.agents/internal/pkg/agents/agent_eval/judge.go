@@ -0,0 +1,57 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Judge decides whether the response of an agent meets criteria
+type Judge interface {
+	Judge(ctx context.Context, criteria string, input string, response string) (*Verdict, error)
+}
+
+// Verdict is the decision of a Judge
+type Verdict struct {
+	Pass   bool   `json:"pass"`
+	Reason string `json:"reason"`
+}
+
+// judgePrompt is the prompt of ModelJudge. It asks for a JSON verdict, to be parsed reliably.
+const judgePrompt = `You are evaluating the response of an AI agent against criteria.
+
+Criteria:
+%s
+
+Input sent to the agent:
+%s
+
+Response of the agent:
+%s
+
+Decide whether the response meets all the criteria. Reply with only a JSON object of the form
+{"pass": true or false, "reason": "<one sentence explaining the decision>"}`
+
+// ModelJudge is a Judge asking a model whether responses meet criteria
+type ModelJudge struct {
+	// Target sends the prompts to the model, such as an HTTPTarget with the model in its body
+	Target Target
+}
+
+// Judge asks the model whether the response meets the criteria
+func (j *ModelJudge) Judge(ctx context.Context, criteria string, input string, response string) (*Verdict, error) {
+	answer, err := j.Target.Invoke(ctx, fmt.Sprintf(judgePrompt, criteria, input, response))
+	if err != nil {
+		return nil, err
+	}
+
+	var verdict Verdict
+	if err := json.Unmarshal([]byte(stripCodeFence(answer.Text)), &verdict); err != nil {
+		return nil, fmt.Errorf("unexpected judge answer %q: %w", truncate(answer.Text), err)
+	}
+
+	return &verdict, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_eval
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// junitTestSuites is the root element of a JUnit XML report
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report in the JUnit XML format understood by CI systems, with a test case
+// for each evaluation case. Cases whose evaluators failed are failures, and cases whose agent
+// invocation failed are errors.
+func WriteJUnit(w io.Writer, report *Report, suiteName string) error {
+	suite := junitTestSuite{
+		Name:  suiteName,
+		Tests: report.Total,
+		Time:  seconds(report.DurationMs),
+	}
+
+	for _, result := range report.Cases {
+		testCase := junitTestCase{
+			Name:      result.ID,
+			ClassName: suiteName,
+			Time:      seconds(result.DurationMs),
+		}
+		if result.Response != nil {
+			testCase.SystemOut = result.Response.Text
+		}
+
+		switch {
+		case result.Error != "":
+			suite.Errors++
+			testCase.Error = &junitProblem{Message: "agent invocation failed", Text: result.Error}
+		case !result.Passed:
+			suite.Failures++
+			failed := result.FailedEvaluators()
+			lines := make([]string, len(failed))
+			for i, evaluation := range failed {
+				lines[i] = fmt.Sprintf("%s: %s", evaluation.Type, evaluation.Message)
+			}
+			testCase.Failure = &junitProblem{
+				Message: fmt.Sprintf("%d of %d evaluators failed", len(failed), len(result.Evaluators)),
+				Text:    strings.Join(lines, "\n"),
+			}
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	suites := junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// seconds formats a duration in milliseconds as the seconds of JUnit time attributes
+func seconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
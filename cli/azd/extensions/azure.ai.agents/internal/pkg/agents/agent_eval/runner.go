@@ -0,0 +1,119 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_eval
+
+import (
+	"context"
+	"time"
+)
+
+// CaseResult is the result of an evaluation case
+type CaseResult struct {
+	ID       string    `json:"id"`
+	Input    string    `json:"input"`
+	Response *Response `json:"response,omitempty"`
+	// Error is the error invoking the agent, in which case the response isn't evaluated
+	Error      string            `json:"error,omitempty"`
+	Passed     bool              `json:"passed"`
+	DurationMs int64             `json:"duration_ms"`
+	Evaluators []EvaluatorResult `json:"evaluators"`
+}
+
+// FailedEvaluators returns the results of the evaluators which failed
+func (r *CaseResult) FailedEvaluators() []EvaluatorResult {
+	var failed []EvaluatorResult
+	for _, result := range r.Evaluators {
+		if !result.Passed {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Report is the result of an evaluation run
+type Report struct {
+	Dataset    string        `json:"dataset"`
+	Target     string        `json:"target"`
+	Total      int           `json:"total"`
+	Passed     int           `json:"passed"`
+	Failed     int           `json:"failed"`
+	DurationMs int64         `json:"duration_ms"`
+	Cases      []*CaseResult `json:"cases"`
+}
+
+// PassRate returns the ratio of cases which passed, between 0 and 1
+func (r *Report) PassRate() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+	return float64(r.Passed) / float64(r.Total)
+}
+
+// RunOptions configures Run
+type RunOptions struct {
+	// Judge is used by the llm_judge evaluators. They fail when it's not set.
+	Judge Judge
+	// OnCase is called with the result of each case once it's evaluated, when set
+	OnCase func(result *CaseResult)
+}
+
+// Run invokes the target with the input of each case, in order, and scores its responses with
+// the evaluators of the case. A case passes when the agent responds and all its evaluators pass.
+// Run stops when ctx is done, returning the results of the cases run so far with the error of ctx.
+func Run(ctx context.Context, target Target, cases []*Case, options RunOptions) (*Report, error) {
+	report := &Report{Cases: []*CaseResult{}}
+	start := time.Now()
+
+	for _, c := range cases {
+		if err := ctx.Err(); err != nil {
+			report.DurationMs = time.Since(start).Milliseconds()
+			return report, err
+		}
+
+		result := runCase(ctx, target, c, options.Judge)
+
+		report.Cases = append(report.Cases, result)
+		report.Total++
+		if result.Passed {
+			report.Passed++
+		} else {
+			report.Failed++
+		}
+
+		if options.OnCase != nil {
+			options.OnCase(result)
+		}
+	}
+
+	report.DurationMs = time.Since(start).Milliseconds()
+	return report, nil
+}
+
+// runCase invokes the target with the input of a case and evaluates its response
+func runCase(ctx context.Context, target Target, c *Case, judge Judge) *CaseResult {
+	result := &CaseResult{
+		ID:         c.ID,
+		Input:      c.Input,
+		Evaluators: []EvaluatorResult{},
+	}
+	start := time.Now()
+
+	response, err := target.Invoke(ctx, c.Input)
+	if err != nil {
+		result.Error = err.Error()
+		result.DurationMs = time.Since(start).Milliseconds()
+		return result
+	}
+	result.Response = response
+
+	result.Passed = true
+	for _, e := range c.Evaluators {
+		evaluation := e.Evaluate(ctx, c.Input, response, judge)
+		result.Evaluators = append(result.Evaluators, evaluation)
+		result.Passed = result.Passed && evaluation.Passed
+	}
+
+	result.DurationMs = time.Since(start).Milliseconds()
+	return result
+}
@@ -0,0 +1,250 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_eval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// textTarget is a Target answering every input with the same text
+type textTarget struct {
+	text   string
+	inputs []string
+}
+
+func (t *textTarget) Invoke(ctx context.Context, input string) (*Response, error) {
+	t.inputs = append(t.inputs, input)
+	return &Response{Text: t.text}, nil
+}
+
+func newStubServer(t *testing.T, responses map[string]*Response) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(&StubAgent{Responses: responses})
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestRun_StubAgent(t *testing.T) {
+	server := newStubServer(t, map[string]*Response{
+		"What's the weather in Paris?": {
+			Text:      "It's sunny in Paris.",
+			ToolCalls: []ToolCall{{Name: "get_weather", Arguments: map[string]any{"city": "Paris"}}},
+		},
+		"Tell me a joke": {Text: "No."},
+	})
+
+	cases, err := LoadCases(writeDataset(t, strings.Join([]string{
+		`{"id": "weather", "input": "What's the weather in Paris?", "evaluators": [` +
+			`{"type": "tool_call", "tool": "get_weather", "arguments": {"city": "Paris"}}, ` +
+			`{"type": "contains", "value": "sunny"}]}`,
+		`{"id": "echo", "input": "ping", "evaluators": [{"type": "exact", "value": "ping"}]}`,
+		`{"id": "joke", "input": "Tell me a joke", "evaluators": [` +
+			`{"type": "contains", "value": "No"}, {"type": "llm_judge", "criteria": "The response is a joke"}]}`,
+	}, "\n")))
+	require.NoError(t, err)
+
+	judgeTarget := &textTarget{text: "```json\n{\"pass\": false, \"reason\": \"The response isn't funny\"}\n```"}
+	var reported []string
+
+	report, err := Run(t.Context(), &HTTPTarget{URL: server.URL + "/responses", NewSession: true}, cases, RunOptions{
+		Judge:  &ModelJudge{Target: judgeTarget},
+		OnCase: func(result *CaseResult) { reported = append(reported, result.ID) },
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"weather", "echo", "joke"}, reported)
+	require.Equal(t, 3, report.Total)
+	require.Equal(t, 2, report.Passed)
+	require.Equal(t, 1, report.Failed)
+	require.InDelta(t, 2.0/3, report.PassRate(), 0.001)
+
+	require.True(t, report.Cases[0].Passed)
+	require.Equal(t, "It's sunny in Paris.", report.Cases[0].Response.Text)
+	require.True(t, report.Cases[1].Passed)
+
+	joke := report.Cases[2]
+	require.False(t, joke.Passed)
+	require.Equal(t, []EvaluatorResult{
+		{Type: EvaluatorLLMJudge, Passed: false, Message: "The response isn't funny"},
+	}, joke.FailedEvaluators())
+
+	require.Len(t, judgeTarget.inputs, 1)
+	require.Contains(t, judgeTarget.inputs[0], "The response is a joke")
+	require.Contains(t, judgeTarget.inputs[0], "Tell me a joke")
+}
+
+func TestRun_InvocationError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte(`{"code": "server_error"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	cases := []*Case{{ID: "a", Input: "Hi", Evaluators: []*Evaluator{{Type: EvaluatorContains, Value: "Hi"}}}}
+	report, err := Run(t.Context(), &HTTPTarget{URL: server.URL + "/responses"}, cases, RunOptions{})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, report.Failed)
+	require.Contains(t, report.Cases[0].Error, "HTTP 500")
+	require.Empty(t, report.Cases[0].Evaluators)
+}
+
+func TestRun_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	cases := []*Case{{ID: "a", Input: "Hi", Evaluators: []*Evaluator{{Type: EvaluatorContains, Value: "Hi"}}}}
+	report, err := Run(ctx, &textTarget{text: "Hi"}, cases, RunOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, 0, report.Total)
+}
+
+func TestHTTPTarget_Request(t *testing.T) {
+	var request map[string]any
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		_, _ = w.Write([]byte("plain text answer"))
+	}))
+	t.Cleanup(server.Close)
+
+	target := &HTTPTarget{
+		URL:        server.URL,
+		Body:       map[string]any{"agent": map[string]any{"name": "my-agent", "type": "agent_reference"}},
+		Headers:    map[string]string{"Authorization": "Bearer token"},
+		NewSession: true,
+	}
+	response, err := target.Invoke(t.Context(), "Hi")
+	require.NoError(t, err)
+
+	require.Equal(t, "plain text answer", response.Text)
+	require.Equal(t, "Bearer token", authorization)
+	require.Equal(t, "Hi", request["input"])
+	require.Equal(t, map[string]any{"name": "my-agent", "type": "agent_reference"}, request["agent"])
+	require.NotEmpty(t, request["session_id"])
+	// The body of the target is not modified by requests
+	require.NotContains(t, target.Body, "input")
+}
+
+func TestHTTPTarget_Token(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte("answer"))
+	}))
+	t.Cleanup(server.Close)
+
+	tokens := 0
+	target := &HTTPTarget{
+		URL: server.URL,
+		Token: func(ctx context.Context) (string, error) {
+			tokens++
+			return fmt.Sprintf("token-%d", tokens), nil
+		},
+	}
+
+	// The token is requested for every request, so a refreshed token is used once the previous one expired
+	for range 2 {
+		_, err := target.Invoke(t.Context(), "Hi")
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"Bearer token-1", "Bearer token-2"}, authorizations)
+
+	target.Token = func(ctx context.Context) (string, error) {
+		return "", fmt.Errorf("not logged in")
+	}
+	_, err := target.Invoke(t.Context(), "Hi")
+	require.ErrorContains(t, err, "failed to get auth token: not logged in")
+	require.Len(t, authorizations, 2)
+}
+
+func TestHTTPTarget_Stream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "event: response.output_text.delta\n"+`data: {"delta":"Hel"}`+"\n\n")
+		fmt.Fprint(w, "event: response.completed\n"+
+			`data: {"response":{"status":"completed","output":[`+
+			`{"type":"mcp_call","name":"search","arguments":"{\"q\":\"docs\"}"},`+
+			`{"type":"web_search_call"},`+
+			`{"type":"message","content":[{"type":"output_text","text":"Hello"}]}]}}`+"\n\n")
+	}))
+	t.Cleanup(server.Close)
+
+	response, err := (&HTTPTarget{URL: server.URL}).Invoke(t.Context(), "Hi")
+	require.NoError(t, err)
+
+	require.Equal(t, "Hello", response.Text)
+	require.Equal(t, []ToolCall{
+		{Name: "search", Arguments: map[string]any{"q": "docs"}},
+		{Name: "web_search"},
+	}, response.ToolCalls)
+}
+
+func TestParseResponse_Errors(t *testing.T) {
+	_, err := ParseResponse(map[string]any{
+		"status": "failed",
+		"error":  map[string]any{"code": "runtime_error", "message": "agent crashed"},
+	})
+	require.EqualError(t, err, "agent failed (runtime_error): agent crashed")
+
+	_, err = ParseResponse(map[string]any{"code": "server_error", "message": "boom"})
+	require.EqualError(t, err, "agent error (server_error): boom")
+
+	response, err := ParseResponse(map[string]any{"output_text": "fallback"})
+	require.NoError(t, err)
+	require.Equal(t, "fallback", response.Text)
+}
+
+func TestModelJudge_UnexpectedAnswer(t *testing.T) {
+	judge := &ModelJudge{Target: &textTarget{text: "Yes, it does."}}
+	_, err := judge.Judge(t.Context(), "Polite", "Hi", "Hello")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unexpected judge answer "Yes, it does."`)
+}
+
+func TestWriteJUnit(t *testing.T) {
+	report := &Report{
+		Total:      3,
+		Passed:     1,
+		Failed:     2,
+		DurationMs: 1500,
+		Cases: []*CaseResult{
+			{ID: "pass", Passed: true, DurationMs: 500, Response: &Response{Text: "Hello"}},
+			{
+				ID:         "fail",
+				DurationMs: 250,
+				Response:   &Response{Text: "Bye"},
+				Evaluators: []EvaluatorResult{
+					{Type: EvaluatorContains, Passed: false, Message: `expected response to contain "Hello"`},
+					{Type: EvaluatorRegex, Passed: true},
+				},
+			},
+			{ID: "error", Error: "HTTP 500: <oops>", DurationMs: 5},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJUnit(&buf, report, "agent eval"))
+	output := buf.String()
+
+	require.True(t, strings.HasPrefix(output, "<?xml"))
+	require.Contains(t, output, `<testsuites tests="3" failures="1" errors="1" time="1.500">`)
+	require.Contains(t, output, `<testsuite name="agent eval" tests="3" failures="1" errors="1" time="1.500">`)
+	require.Contains(t, output, `<testcase name="pass" classname="agent eval" time="0.500">`)
+	require.Contains(t, output, `<failure message="1 of 2 evaluators failed">`+
+		`contains: expected response to contain &#34;Hello&#34;</failure>`)
+	require.Contains(t, output, `<error message="agent invocation failed">HTTP 500: &lt;oops&gt;</error>`)
+	require.Contains(t, output, `<system-out>Bye</system-out>`)
+}
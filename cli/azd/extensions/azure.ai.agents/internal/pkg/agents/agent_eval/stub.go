@@ -0,0 +1,66 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_eval
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// StubAgent is a local agent endpoint serving canned responses through the Responses API, so that
+// evaluations can be tested without an agent. Run it with httptest.NewServer and target its
+// /responses path. Inputs without a canned response are echoed back.
+type StubAgent struct {
+	// Responses are the responses to the inputs sent to the agent, keyed by input
+	Responses map[string]*Response
+}
+
+// ServeHTTP answers POST requests to a responses path with the canned response of their input
+func (s *StubAgent) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/responses") {
+		http.NotFound(w, r)
+		return
+	}
+
+	var request struct {
+		Input string `json:"input"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"code": "invalid_request", "message": err.Error()})
+		return
+	}
+
+	response, has := s.Responses[request.Input]
+	if !has {
+		response = &Response{Text: request.Input}
+	}
+
+	output := []any{}
+	for _, call := range response.ToolCalls {
+		arguments, _ := json.Marshal(call.Arguments)
+		output = append(output, map[string]any{
+			"type":      "function_call",
+			"name":      call.Name,
+			"arguments": string(arguments),
+		})
+	}
+	output = append(output, map[string]any{
+		"type": "message",
+		"role": "assistant",
+		"content": []any{
+			map[string]any{"type": "output_text", "text": response.Text},
+		},
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"id":     "resp_stub",
+		"object": "response",
+		"status": "completed",
+		"output": output,
+	})
+}
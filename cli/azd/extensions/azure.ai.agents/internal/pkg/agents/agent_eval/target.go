@@ -0,0 +1,244 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_eval
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ToolCall is a call of a tool by an agent
+type ToolCall struct {
+	Name      string         `json:"name"`
+	Arguments map[string]any `json:"arguments,omitempty"`
+}
+
+// Response is the response of an agent to an evaluation case
+type Response struct {
+	Text      string     `json:"text"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+}
+
+// Target is an agent evaluation cases are sent to
+type Target interface {
+	// Invoke sends an input to the agent and returns its response
+	Invoke(ctx context.Context, input string) (*Response, error)
+}
+
+// HTTPTarget is a Target invoking an agent, or a model, through the Responses API
+type HTTPTarget struct {
+	// URL is the URL of the responses endpoint
+	URL string
+	// Body holds the fields of the request besides the input, such as the agent reference
+	Body map[string]any
+	// Headers are added to the request
+	Headers map[string]string
+	// Token returns the bearer token of each request, when set. It's called for every request so that long evaluations
+	// keep sending a valid token.
+	Token func(ctx context.Context) (string, error)
+	// NewSession sends a new session ID with each request, so that cases don't share a session
+	NewSession bool
+	// Client sends the requests. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Invoke sends an input to the agent and returns its response
+func (t *HTTPTarget) Invoke(ctx context.Context, input string) (*Response, error) {
+	body := maps.Clone(t.Body)
+	if body == nil {
+		body = map[string]any{}
+	}
+	body["input"] = input
+	if t.NewSession {
+		body["session_id"] = uuid.New().String()
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range t.Headers {
+		req.Header.Set(name, value)
+	}
+	if t.Token != nil {
+		token, err := t.Token(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get auth token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req) //nolint:gosec // G704: URL is localhost or resolved from azd environment configuration
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return readStreamResponse(resp.Body)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		// Not JSON, the whole body is the response text
+		return &Response{Text: string(respBody)}, nil
+	}
+
+	return ParseResponse(result)
+}
+
+// ParseResponse extracts the output text and the tool calls of a Responses API response
+func ParseResponse(result map[string]any) (*Response, error) {
+	// Agent-level errors, such as agent runtime failures
+	if status, _ := result["status"].(string); status == "failed" {
+		if errObj, ok := result["error"].(map[string]any); ok {
+			msg, _ := errObj["message"].(string)
+			code, _ := errObj["code"].(string)
+			return nil, fmt.Errorf("agent failed (%s): %s", code, msg)
+		}
+		return nil, fmt.Errorf("agent returned failed status")
+	}
+
+	// Server-level errors, such as {"code": "server_error", "message": "..."} of the local agentserver
+	if code, ok := result["code"].(string); ok && code != "" {
+		msg, _ := result["message"].(string)
+		return nil, fmt.Errorf("agent error (%s): %s", code, msg)
+	}
+
+	response := &Response{}
+	var texts []string
+
+	outputItems, _ := result["output"].([]any)
+	for _, item := range outputItems {
+		itemMap, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		itemType, _ := itemMap["type"].(string)
+		if itemType != "message" && strings.HasSuffix(itemType, "_call") {
+			response.ToolCalls = append(response.ToolCalls, parseToolCall(itemType, itemMap))
+			continue
+		}
+
+		contentItems, _ := itemMap["content"].([]any)
+		for _, content := range contentItems {
+			contentMap, ok := content.(map[string]any)
+			if !ok || contentMap["type"] != "output_text" {
+				continue
+			}
+			if text, ok := contentMap["text"].(string); ok {
+				texts = append(texts, text)
+			}
+		}
+	}
+
+	response.Text = strings.Join(texts, "\n")
+	if response.Text == "" {
+		// Some servers also return the concatenated output text
+		response.Text, _ = result["output_text"].(string)
+	}
+
+	return response, nil
+}
+
+// parseToolCall converts a tool call output item, such as function_call or mcp_call, to a ToolCall.
+// Built-in tools without a name, such as web_search_call, are named after their item type.
+func parseToolCall(itemType string, item map[string]any) ToolCall {
+	call := ToolCall{Name: strings.TrimSuffix(itemType, "_call")}
+	if name, ok := item["name"].(string); ok && name != "" {
+		call.Name = name
+	}
+
+	switch arguments := item["arguments"].(type) {
+	case string:
+		// Function call arguments are a JSON encoded object
+		var parsed map[string]any
+		if err := json.Unmarshal([]byte(arguments), &parsed); err == nil {
+			call.Arguments = parsed
+		}
+	case map[string]any:
+		call.Arguments = arguments
+	}
+
+	return call
+}
+
+// readStreamResponse reads the completed response of a Server-Sent Events stream of the Responses API
+func readStreamResponse(body io.Reader) (*Response, error) {
+	scanner := bufio.NewScanner(body)
+	// Allow large SSE data lines (up to 1 MB)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var currentEvent string
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if after, ok := strings.CutPrefix(line, "event: "); ok {
+			currentEvent = after
+			continue
+		}
+
+		data, ok := strings.CutPrefix(line, "data: ")
+		if !ok {
+			continue
+		}
+
+		switch currentEvent {
+		case "response.completed", "response.failed":
+			var event struct {
+				Response map[string]any `json:"response"`
+			}
+			if err := json.Unmarshal([]byte(data), &event); err != nil || event.Response == nil {
+				return nil, fmt.Errorf("invalid %s event: %s", currentEvent, data)
+			}
+			return ParseResponse(event.Response)
+		case "error":
+			var sseErr struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			}
+			if err := json.Unmarshal([]byte(data), &sseErr); err == nil {
+				return nil, fmt.Errorf("agent error (%s): %s", sseErr.Code, sseErr.Message)
+			}
+			return nil, fmt.Errorf("agent stream error: %s", data)
+		}
+		currentEvent = ""
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading response stream: %w", err)
+	}
+
+	return nil, fmt.Errorf("response stream ended without a completed response")
+}
@@ -0,0 +1,230 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_yaml
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// RefKey is the key of a reference to a shared file in an agent definition, such as
+// `instructions: {$ref: ../shared/prompts/triage.md}` or `tools: [{$ref: ../shared/tools/search.yaml}]`.
+// References to YAML or JSON files are replaced by the content of the file, and references to other
+// files, such as prompts, by their text. References within lists to files holding lists are replaced by
+// the items of the list. Paths are relative to the file holding the reference.
+const RefKey = "$ref"
+
+// ComposeAgentDefinition reads the agent definition at path, resolves its references to shared files
+// and applies the overlay of the environment, if any. The overlay of an environment is the file next
+// to the definition named after the environment, such as agent.prod.yaml for agent.yaml, and is merged
+// into the definition: maps are merged, other values are replaced, and null values remove keys.
+// Returns the composed definition as YAML.
+func ComposeAgentDefinition(path string, environmentName string) ([]byte, error) {
+	definition, err := loadComposedFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if environmentName != "" {
+		overlayPath := OverlayPath(path, environmentName)
+		if _, err := os.Stat(overlayPath); err == nil {
+			overlay, err := loadComposedFile(overlayPath)
+			if err != nil {
+				return nil, err
+			}
+			definition = mergeOverlay(definition, overlay)
+		}
+	}
+
+	composed, err := yaml.Marshal(definition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal composed agent definition: %w", err)
+	}
+
+	return composed, nil
+}
+
+// ResolveRefs resolves the references to shared files of YAML content read from path
+func ResolveRefs(content []byte, path string) ([]byte, error) {
+	var document any
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("YAML content is not valid: %w", err)
+	}
+
+	resolved, err := resolveRefs(document, filepath.Dir(path), []string{absPath(path)})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvedContent, err := yaml.Marshal(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resolved YAML: %w", err)
+	}
+
+	return resolvedContent, nil
+}
+
+// OverlayPath returns the path of the overlay of an environment for the agent definition at path,
+// such as agent.prod.yaml for agent.yaml
+func OverlayPath(path string, environmentName string) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s.%s%s", strings.TrimSuffix(path, ext), environmentName, ext)
+}
+
+// loadComposedFile reads a YAML file and resolves its references
+func loadComposedFile(path string) (map[string]any, error) {
+	content, err := os.ReadFile(path) //nolint:gosec // G304: path is an agent definition of the project
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agent definition %s: %w", path, err)
+	}
+
+	var document map[string]any
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("agent definition %s is not valid YAML: %w", path, err)
+	}
+
+	resolved, err := resolveRefs(document, filepath.Dir(path), []string{absPath(path)})
+	if err != nil {
+		return nil, fmt.Errorf("agent definition %s: %w", path, err)
+	}
+
+	definition, _ := resolved.(map[string]any)
+	return definition, nil
+}
+
+// resolveRefs replaces the references of a YAML value by the content of the files they reference.
+// dir is the directory references are relative to, and stack the files being resolved, to detect cycles.
+func resolveRefs(value any, dir string, stack []string) (any, error) {
+	switch typed := value.(type) {
+	case map[string]any:
+		if ref, isRef := refPath(typed); isRef {
+			return loadRef(ref, dir, stack)
+		}
+
+		for key, item := range typed {
+			resolved, err := resolveRefs(item, dir, stack)
+			if err != nil {
+				return nil, err
+			}
+			typed[key] = resolved
+		}
+		return typed, nil
+	case []any:
+		items := make([]any, 0, len(typed))
+		for _, item := range typed {
+			resolved, err := resolveRefs(item, dir, stack)
+			if err != nil {
+				return nil, err
+			}
+
+			// A reference to a list within a list, such as a set of shared tools, adds its items
+			if resolvedItems, isList := resolved.([]any); isList {
+				if itemMap, ok := item.(map[string]any); ok {
+					if _, isRef := refPath(itemMap); isRef {
+						items = append(items, resolvedItems...)
+						continue
+					}
+				}
+			}
+			items = append(items, resolved)
+		}
+		return items, nil
+	default:
+		return value, nil
+	}
+}
+
+// refPath returns the path of a reference, a map with the single key $ref
+func refPath(value map[string]any) (string, bool) {
+	if len(value) != 1 {
+		return "", false
+	}
+
+	ref, has := value[RefKey]
+	if !has {
+		return "", false
+	}
+
+	path, _ := ref.(string)
+	return path, true
+}
+
+// loadRef reads the file of a reference: YAML and JSON files are parsed and their own references
+// resolved, other files are text
+func loadRef(ref string, dir string, stack []string) (any, error) {
+	if ref == "" {
+		return nil, fmt.Errorf("%s must be the path of a file", RefKey)
+	}
+
+	path := ref
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	abs := absPath(path)
+	if slices.Contains(stack, abs) {
+		return nil, fmt.Errorf("circular %s to %s", RefKey, ref)
+	}
+
+	content, err := os.ReadFile(path) //nolint:gosec // G304: path is referenced by an agent definition of the project
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s %s: %w", RefKey, ref, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		var value any
+		if err := yaml.Unmarshal(content, &value); err != nil {
+			return nil, fmt.Errorf("%s %s is not valid YAML: %w", RefKey, ref, err)
+		}
+
+		resolved, err := resolveRefs(value, filepath.Dir(path), append(stack, abs))
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", RefKey, ref, err)
+		}
+		return resolved, nil
+	default:
+		return string(content), nil
+	}
+}
+
+// mergeOverlay merges an overlay into a definition. Maps are merged recursively, other values are
+// replaced, and null values remove keys.
+func mergeOverlay(definition map[string]any, overlay map[string]any) map[string]any {
+	if definition == nil {
+		definition = map[string]any{}
+	}
+
+	for key, value := range overlay {
+		if value == nil {
+			delete(definition, key)
+			continue
+		}
+
+		overlayMap, isMap := value.(map[string]any)
+		definitionMap, wasMap := definition[key].(map[string]any)
+		if isMap && wasMap {
+			definition[key] = mergeOverlay(definitionMap, overlayMap)
+			continue
+		}
+
+		definition[key] = value
+	}
+
+	return definition
+}
+
+// absPath returns the absolute path of path, or path when it can't be determined
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	return abs
+}
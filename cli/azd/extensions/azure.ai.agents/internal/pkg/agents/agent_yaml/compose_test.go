@@ -0,0 +1,237 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_yaml
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// writeFiles writes files, keyed by path relative to dir, and returns dir
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+func composeToMap(t *testing.T, path string, environmentName string) map[string]any {
+	t.Helper()
+	composed, err := ComposeAgentDefinition(path, environmentName)
+	if err != nil {
+		t.Fatalf("ComposeAgentDefinition failed: %v", err)
+	}
+
+	var definition map[string]any
+	if err := yaml.Unmarshal(composed, &definition); err != nil {
+		t.Fatalf("Composed definition is not valid YAML: %v", err)
+	}
+	return definition
+}
+
+// TestComposeAgentDefinition_Refs tests that references to shared prompt and tool files are resolved
+func TestComposeAgentDefinition_Refs(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"shared/prompts/triage.md": "Route the request to the right agent.",
+		"shared/tools/search.yaml": `
+kind: webSearch
+name: search
+`,
+		"shared/tools/all.yaml": `
+- $ref: search.yaml
+- kind: codeInterpreter
+  name: python
+`,
+		"triage/agent.yaml": `
+kind: prompt
+name: triage
+model:
+  id: gpt-4o
+instructions:
+  $ref: ../shared/prompts/triage.md
+tools:
+  - $ref: ../shared/tools/all.yaml
+  - kind: function
+    name: lookup
+`,
+	})
+
+	definition := composeToMap(t, filepath.Join(dir, "triage", "agent.yaml"), "")
+
+	if definition["instructions"] != "Route the request to the right agent." {
+		t.Errorf("Expected instructions from the prompt file, got %v", definition["instructions"])
+	}
+
+	tools, ok := definition["tools"].([]any)
+	if !ok {
+		t.Fatalf("Expected tools to be a list, got %T", definition["tools"])
+	}
+
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.(map[string]any)["name"].(string))
+	}
+	if !reflect.DeepEqual(names, []string{"search", "python", "lookup"}) {
+		t.Errorf("Expected the shared tools followed by the local tool, got %v", names)
+	}
+
+	// The composed definition parses as an agent definition
+	composed, _ := ComposeAgentDefinition(filepath.Join(dir, "triage", "agent.yaml"), "")
+	if err := ValidateAgentDefinition(composed); err != nil {
+		t.Errorf("Expected composed definition to be valid, got %v", err)
+	}
+}
+
+// TestComposeAgentDefinition_Overlay tests that the overlay of the environment is merged into the definition
+func TestComposeAgentDefinition_Overlay(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"agent.yaml": `
+kind: hosted
+name: triage
+description: Triage agent
+metadata:
+  team: support
+  tier: dev
+protocols:
+  - protocol: responses
+    version: v1
+environment_variables:
+  - name: LOG_LEVEL
+    value: debug
+`,
+		"agent.prod.yaml": `
+description: null
+metadata:
+  tier: prod
+environment_variables:
+  - name: LOG_LEVEL
+    value: warning
+`,
+	})
+	path := filepath.Join(dir, "agent.yaml")
+
+	definition := composeToMap(t, path, "prod")
+
+	if _, has := definition["description"]; has {
+		t.Errorf("Expected null in the overlay to remove description, got %v", definition["description"])
+	}
+
+	expectedMetadata := map[string]any{"team": "support", "tier": "prod"}
+	if !reflect.DeepEqual(definition["metadata"], expectedMetadata) {
+		t.Errorf("Expected merged metadata %v, got %v", expectedMetadata, definition["metadata"])
+	}
+
+	expectedEnv := []any{map[string]any{"name": "LOG_LEVEL", "value": "warning"}}
+	if !reflect.DeepEqual(definition["environment_variables"], expectedEnv) {
+		t.Errorf("Expected lists to be replaced by the overlay, got %v", definition["environment_variables"])
+	}
+
+	// Environments without an overlay use the definition as is
+	definition = composeToMap(t, path, "dev")
+	if definition["description"] != "Triage agent" {
+		t.Errorf("Expected the description of the definition, got %v", definition["description"])
+	}
+}
+
+// TestComposeAgentDefinition_Errors tests invalid references
+func TestComposeAgentDefinition_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		files    map[string]string
+		errorMsg string
+	}{
+		{
+			name: "missing file",
+			files: map[string]string{
+				"agent.yaml": "instructions:\n  $ref: prompts/missing.md\n",
+			},
+			errorMsg: "failed to read $ref prompts/missing.md",
+		},
+		{
+			name: "circular reference",
+			files: map[string]string{
+				"agent.yaml": "tools:\n  - $ref: a.yaml\n",
+				"a.yaml":     "- $ref: b.yaml\n",
+				"b.yaml":     "- $ref: a.yaml\n",
+			},
+			errorMsg: "circular $ref to a.yaml",
+		},
+		{
+			name: "empty path",
+			files: map[string]string{
+				"agent.yaml": "instructions:\n  $ref: \"\"\n",
+			},
+			errorMsg: "$ref must be the path of a file",
+		},
+		{
+			name: "invalid referenced YAML",
+			files: map[string]string{
+				"agent.yaml": "tools:\n  - $ref: tools.yaml\n",
+				"tools.yaml": "- [unclosed\n",
+			},
+			errorMsg: "$ref tools.yaml is not valid YAML",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := writeFiles(t, tc.files)
+			_, err := ComposeAgentDefinition(filepath.Join(dir, "agent.yaml"), "")
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tc.errorMsg) {
+				t.Errorf("Expected error message to contain '%s', got '%s'", tc.errorMsg, err.Error())
+			}
+		})
+	}
+}
+
+// TestResolveRefs tests resolving the references of a manifest
+func TestResolveRefs(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"prompts/triage.md": "Be helpful.",
+	})
+
+	content := []byte(`
+name: triage
+template:
+  kind: prompt
+  name: triage
+  instructions:
+    $ref: prompts/triage.md
+`)
+
+	resolved, err := ResolveRefs(content, filepath.Join(dir, "agent.manifest.yaml"))
+	if err != nil {
+		t.Fatalf("ResolveRefs failed: %v", err)
+	}
+
+	if !strings.Contains(string(resolved), "instructions: Be helpful.") {
+		t.Errorf("Expected the instructions to be inlined, got:\n%s", resolved)
+	}
+}
+
+// TestOverlayPath tests the path of the overlay of an environment
+func TestOverlayPath(t *testing.T) {
+	if got := OverlayPath(filepath.Join("src", "agent.yaml"), "prod"); got != filepath.Join("src", "agent.prod.yaml") {
+		t.Errorf("Expected agent.prod.yaml, got %s", got)
+	}
+	if got := OverlayPath("agent.yml", "dev"); got != "agent.dev.yml" {
+		t.Errorf("Expected agent.dev.yml, got %s", got)
+	}
+}
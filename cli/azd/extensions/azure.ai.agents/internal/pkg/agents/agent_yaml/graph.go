@@ -0,0 +1,128 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_yaml
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// ConnectedAgent is another agent of the project an agent connects to. The name and the endpoint of
+// the connected agent are wired into the agent as environment values when deploying.
+type ConnectedAgent struct {
+	// Service is the name of the service of the connected agent in azure.yaml
+	Service     string  `json:"service" yaml:"service"`
+	Description *string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// ExtractConnectedAgents returns the connected agents of an agent definition
+func ExtractConnectedAgents(definitionYamlContent []byte) ([]ConnectedAgent, error) {
+	var agentDef AgentDefinition
+	if err := yaml.Unmarshal(definitionYamlContent, &agentDef); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal to AgentDefinition: %w", err)
+	}
+
+	services := map[string]bool{}
+	for i, connected := range agentDef.ConnectedAgents {
+		if connected.Service == "" {
+			return nil, fmt.Errorf("connectedAgents[%d].service is required", i)
+		}
+		if services[connected.Service] {
+			return nil, fmt.Errorf("connectedAgents has duplicate service '%s'", connected.Service)
+		}
+		services[connected.Service] = true
+	}
+
+	return agentDef.ConnectedAgents, nil
+}
+
+// AgentGraph is the graph of the agents of a project, mapping the service of each agent to the
+// services of the agents it connects to
+type AgentGraph map[string][]string
+
+// Missing returns the connections to agents which are not in the graph, such as "triage -> billing"
+func (g AgentGraph) Missing() []string {
+	var missing []string
+	for _, service := range g.services() {
+		for _, connected := range g[service] {
+			if _, has := g[connected]; !has {
+				missing = append(missing, fmt.Sprintf("%s -> %s", service, connected))
+			}
+		}
+	}
+	return missing
+}
+
+// DeploymentOrder returns the services of the agents in the order they can be deployed, each agent after
+// the agents it connects to, so that their endpoints are known. Connections to agents which are not in
+// the graph are ignored. Fails when connections form a cycle.
+func (g AgentGraph) DeploymentOrder() ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := map[string]int{}
+	var order []string
+	var path []string
+
+	var visit func(service string) error
+	visit = func(service string) error {
+		switch state[service] {
+		case visited:
+			return nil
+		case visiting:
+			cycle := append(slices.Clone(path[slices.Index(path, service):]), service)
+			return fmt.Errorf("connected agents form a cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[service] = visiting
+		path = append(path, service)
+		for _, connected := range g[service] {
+			if _, has := g[connected]; !has {
+				continue
+			}
+			if err := visit(connected); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[service] = visited
+		order = append(order, service)
+		return nil
+	}
+
+	for _, service := range g.services() {
+		if err := visit(service); err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// Validate checks that agents only connect to agents of the graph, and that connections don't form cycles
+func (g AgentGraph) Validate() error {
+	if missing := g.Missing(); len(missing) > 0 {
+		return fmt.Errorf(
+			"agents connect to services which are not agents of the project: %s", strings.Join(missing, ", "))
+	}
+
+	_, err := g.DeploymentOrder()
+	return err
+}
+
+// services returns the services of the graph in a stable order
+func (g AgentGraph) services() []string {
+	services := make([]string, 0, len(g))
+	for service := range g {
+		services = append(services, service)
+	}
+	slices.Sort(services)
+	return services
+}
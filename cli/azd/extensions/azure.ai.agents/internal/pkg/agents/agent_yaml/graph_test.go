@@ -0,0 +1,154 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package agent_yaml
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestExtractConnectedAgents tests parsing the connected agents of an agent definition
+func TestExtractConnectedAgents(t *testing.T) {
+	connectedAgents, err := ExtractConnectedAgents([]byte(`
+kind: hosted
+name: triage
+connectedAgents:
+  - service: billing
+    description: Handles refunds
+  - service: shipping
+`))
+	if err != nil {
+		t.Fatalf("ExtractConnectedAgents failed: %v", err)
+	}
+
+	if len(connectedAgents) != 2 {
+		t.Fatalf("Expected 2 connected agents, got %d", len(connectedAgents))
+	}
+	if connectedAgents[0].Service != "billing" || *connectedAgents[0].Description != "Handles refunds" {
+		t.Errorf("Unexpected first connected agent: %+v", connectedAgents[0])
+	}
+	if connectedAgents[1].Service != "shipping" || connectedAgents[1].Description != nil {
+		t.Errorf("Unexpected second connected agent: %+v", connectedAgents[1])
+	}
+}
+
+// TestExtractConnectedAgents_Invalid tests invalid connected agents
+func TestExtractConnectedAgents_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		yaml     string
+		errorMsg string
+	}{
+		{
+			name:     "missing service",
+			yaml:     "connectedAgents:\n  - description: Handles refunds\n",
+			errorMsg: "connectedAgents[0].service is required",
+		},
+		{
+			name:     "duplicate service",
+			yaml:     "connectedAgents:\n  - service: billing\n  - service: billing\n",
+			errorMsg: "connectedAgents has duplicate service 'billing'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ExtractConnectedAgents([]byte(tc.yaml))
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tc.errorMsg) {
+				t.Errorf("Expected error message to contain '%s', got '%s'", tc.errorMsg, err.Error())
+			}
+		})
+	}
+}
+
+// TestAgentGraph_DeploymentOrder tests that agents are deployed after the agents they connect to
+func TestAgentGraph_DeploymentOrder(t *testing.T) {
+	graph := AgentGraph{
+		"triage":   {"billing", "shipping"},
+		"billing":  {"ledger"},
+		"shipping": nil,
+		"ledger":   nil,
+	}
+
+	order, err := graph.DeploymentOrder()
+	if err != nil {
+		t.Fatalf("DeploymentOrder failed: %v", err)
+	}
+
+	expected := []string{"ledger", "billing", "shipping", "triage"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Errorf("Expected order %v, got %v", expected, order)
+	}
+
+	if err := graph.Validate(); err != nil {
+		t.Errorf("Expected graph to be valid, got %v", err)
+	}
+}
+
+// TestAgentGraph_Invalid tests cycles and connections to unknown agents
+func TestAgentGraph_Invalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		graph    AgentGraph
+		errorMsg string
+	}{
+		{
+			name:     "cycle",
+			graph:    AgentGraph{"a": {"b"}, "b": {"c"}, "c": {"a"}},
+			errorMsg: "connected agents form a cycle: a -> b -> c -> a",
+		},
+		{
+			name:     "self connection",
+			graph:    AgentGraph{"a": {"a"}},
+			errorMsg: "connected agents form a cycle: a -> a",
+		},
+		{
+			name:     "unknown agent",
+			graph:    AgentGraph{"triage": {"billing", "web"}, "billing": nil},
+			errorMsg: "not agents of the project: triage -> web",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.graph.Validate()
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tc.errorMsg) {
+				t.Errorf("Expected error message to contain '%s', got '%s'", tc.errorMsg, err.Error())
+			}
+		})
+	}
+
+	// Connections to unknown agents don't prevent ordering the known agents
+	order, err := AgentGraph{"triage": {"billing", "web"}, "billing": nil}.DeploymentOrder()
+	if err != nil {
+		t.Fatalf("DeploymentOrder failed: %v", err)
+	}
+	if !reflect.DeepEqual(order, []string{"billing", "triage"}) {
+		t.Errorf("Expected [billing triage], got %v", order)
+	}
+}
+
+// TestValidateAgentDefinition_ConnectedAgentsRequireHostedAgent tests that only hosted agents can declare connected agents
+func TestValidateAgentDefinition_ConnectedAgentsRequireHostedAgent(t *testing.T) {
+	connectedAgents := "connectedAgents:\n  - service: billing\n"
+
+	err := ValidateAgentDefinition([]byte("kind: prompt\nname: support\nmodel:\n  id: gpt-4o\n" + connectedAgents))
+	if err == nil {
+		t.Fatal("Expected error for a prompt agent with connected agents, got nil")
+	}
+	if !strings.Contains(err.Error(), "template.connectedAgents is only supported by hosted agents") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if err := ValidateAgentDefinition([]byte("kind: hosted\nname: support\n" + connectedAgents)); err != nil {
+		t.Errorf("Unexpected error for a hosted agent with connected agents: %v", err)
+	}
+}
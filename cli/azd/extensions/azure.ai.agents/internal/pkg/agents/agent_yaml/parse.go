@@ -370,6 +370,11 @@ func ValidateAgentDefinition(templateBytes []byte) error {
 					if agent.Model.Id == "" {
 						errors = append(errors, "template.model.id is required")
 					}
+					// Connected agents are wired into hosted agents as environment variables, which prompt agents
+					// don't have
+					if len(agent.ConnectedAgents) > 0 {
+						errors = append(errors, "template.connectedAgents is only supported by hosted agents")
+					}
 				} else {
 					errors = append(errors, fmt.Sprintf("failed to unmarshal to PromptAgent: %v", err))
 				}
@@ -389,6 +394,9 @@ func ValidateAgentDefinition(templateBytes []byte) error {
 					if agent.Name == "" {
 						errors = append(errors, "template.name is required")
 					}
+					if len(agent.ConnectedAgents) > 0 {
+						errors = append(errors, "template.connectedAgents is only supported by hosted agents")
+					}
 					// Workflow doesn't have models, so no model validation needed
 				} else {
 					errors = append(errors, fmt.Sprintf("failed to unmarshal to Workflow: %v", err))
@@ -70,6 +70,9 @@ type AgentDefinition struct {
 	Metadata     *map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	InputSchema  *PropertySchema `json:"inputSchema,omitempty" yaml:"inputSchema,omitempty"`
 	OutputSchema *PropertySchema `json:"outputSchema,omitempty" yaml:"outputSchema,omitempty"`
+	// ConnectedAgents are the other agents of the project the agent connects to, such as to hand off to them.
+	// Only supported by hosted agents.
+	ConnectedAgents []ConnectedAgent `json:"connectedAgents,omitempty" yaml:"connectedAgents,omitempty"`
 }
 
 // PromptAgent Prompt based agent definition. Used to create agents that can be executed directly.
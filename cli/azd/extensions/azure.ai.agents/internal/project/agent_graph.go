@@ -0,0 +1,63 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"azureaiagent/internal/pkg/agents/agent_yaml"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+)
+
+// agentServiceHost is the host of the services of agents in azure.yaml
+const agentServiceHost = "azure.ai.agent"
+
+// FindAgentDefinition returns the path of the agent definition, agent.yaml or agent.yml, in the directory of a service
+func FindAgentDefinition(serviceDir string) (string, bool) {
+	for _, name := range []string{"agent.yaml", "agent.yml"} {
+		path := filepath.Join(serviceDir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// BuildAgentGraph builds the graph of the agents of a project from the connected agents declared by the
+// definitions of its azure.ai.agent services, composed for the environment. Services without an agent
+// definition connect to no agents.
+func BuildAgentGraph(project *azdext.ProjectConfig, environmentName string) (agent_yaml.AgentGraph, error) {
+	graph := agent_yaml.AgentGraph{}
+
+	for _, service := range project.Services {
+		if service.Host != agentServiceHost {
+			continue
+		}
+
+		graph[service.Name] = nil
+		path, found := FindAgentDefinition(filepath.Join(project.Path, service.RelativePath))
+		if !found {
+			continue
+		}
+
+		data, err := agent_yaml.ComposeAgentDefinition(path, environmentName)
+		if err != nil {
+			return nil, fmt.Errorf("service '%s': %w", service.Name, err)
+		}
+
+		connectedAgents, err := agent_yaml.ExtractConnectedAgents(data)
+		if err != nil {
+			return nil, fmt.Errorf("service '%s': %w", service.Name, err)
+		}
+
+		for _, connected := range connectedAgents {
+			graph[service.Name] = append(graph[service.Name], connected.Service)
+		}
+	}
+
+	return graph, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"azureaiagent/internal/exterrors"
+	"azureaiagent/internal/pkg/agents/agent_yaml"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/stretchr/testify/require"
+)
+
+func writeAgentDefinition(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+}
+
+func TestBuildAgentGraph(t *testing.T) {
+	projectPath := t.TempDir()
+	writeAgentDefinition(t, filepath.Join(projectPath, "src", "triage"), "agent.yaml", `
+kind: hosted
+name: triage
+connectedAgents:
+  - service: billing
+`)
+	// The prod overlay connects triage to shipping too
+	writeAgentDefinition(t, filepath.Join(projectPath, "src", "triage"), "agent.prod.yaml", `
+connectedAgents:
+  - service: billing
+  - service: shipping
+`)
+	writeAgentDefinition(t, filepath.Join(projectPath, "src", "billing"), "agent.yml", `
+kind: hosted
+name: billing
+`)
+
+	project := &azdext.ProjectConfig{
+		Path: projectPath,
+		Services: map[string]*azdext.ServiceConfig{
+			"triage":  {Name: "triage", Host: agentServiceHost, RelativePath: "src/triage"},
+			"billing": {Name: "billing", Host: agentServiceHost, RelativePath: "src/billing"},
+			"draft":   {Name: "draft", Host: agentServiceHost, RelativePath: "src/draft"},
+			"web":     {Name: "web", Host: "containerapp", RelativePath: "src/web"},
+		},
+	}
+
+	graph, err := BuildAgentGraph(project, "dev")
+	require.NoError(t, err)
+	require.Equal(t, agent_yaml.AgentGraph{
+		"triage":  {"billing"},
+		"billing": nil,
+		"draft":   nil,
+	}, graph)
+	require.NoError(t, graph.Validate())
+
+	graph, err = BuildAgentGraph(project, "prod")
+	require.NoError(t, err)
+	require.Equal(t, []string{"billing", "shipping"}, graph["triage"])
+	require.ErrorContains(t, graph.Validate(), "triage -> shipping")
+}
+
+func TestBuildAgentGraph_InvalidDefinition(t *testing.T) {
+	projectPath := t.TempDir()
+	writeAgentDefinition(t, filepath.Join(projectPath, "triage"), "agent.yaml", `
+kind: hosted
+name: triage
+connectedAgents:
+  - description: missing service
+`)
+
+	_, err := BuildAgentGraph(&azdext.ProjectConfig{
+		Path: projectPath,
+		Services: map[string]*azdext.ServiceConfig{
+			"triage": {Name: "triage", Host: agentServiceHost, RelativePath: "triage"},
+		},
+	}, "")
+	require.ErrorContains(t, err, "service 'triage': connectedAgents[0].service is required")
+}
+
+func TestConnectedAgentEnvironmentVariables(t *testing.T) {
+	provider := &AgentServiceTargetProvider{}
+	connectedAgents := []agent_yaml.ConnectedAgent{{Service: "billing-agent"}}
+
+	envVars, err := provider.connectedAgentEnvironmentVariables(connectedAgents, map[string]string{
+		"AGENT_BILLING_AGENT_NAME":     "billing",
+		"AGENT_BILLING_AGENT_VERSION":  "3",
+		"AGENT_BILLING_AGENT_ENDPOINT": "https://project/agents/billing/versions/3",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{
+		"AGENT_BILLING_AGENT_NAME":     "billing",
+		"AGENT_BILLING_AGENT_ENDPOINT": "https://project/agents/billing/versions/3",
+	}, envVars)
+
+	_, err = provider.connectedAgentEnvironmentVariables(connectedAgents, map[string]string{})
+
+	var localErr *azdext.LocalError
+	require.True(t, errors.As(err, &localErr))
+	require.Equal(t, exterrors.CodeConnectedAgentNotDeployed, localErr.Code)
+	require.Contains(t, localErr.Suggestion, "azd deploy billing-agent")
+}
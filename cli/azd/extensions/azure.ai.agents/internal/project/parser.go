@@ -52,8 +52,8 @@ func shouldRun(ctx context.Context, project *azdext.ProjectConfig) (bool, error)
 				agentPath = agentYmlPath
 			}
 			if agentPath != "" {
-				// read the file content into bytes and close the file
-				content, err := os.ReadFile(agentPath)
+				// read the file content, with the shared files it references
+				content, err := agent_yaml.ComposeAgentDefinition(agentPath, "")
 				if err != nil {
 					return false, fmt.Errorf("failed to read agent yaml file: %w", err)
 				}
@@ -433,16 +433,21 @@ func (p *AgentServiceTargetProvider) Deploy(
 		fmt.Println("Loaded custom service target configuration")
 	}
 
-	// Load and validate the agent manifest
-	data, err := os.ReadFile(p.agentDefinitionPath)
+	// Load the agent manifest, with its references and the overlay of the environment, and validate it
+	data, err := agent_yaml.ComposeAgentDefinition(p.agentDefinitionPath, p.env.Name)
 	if err != nil {
 		return nil, exterrors.Validation(
 			exterrors.CodeInvalidAgentManifest,
-			fmt.Sprintf("failed to read agent manifest file: %s", err),
-			"verify the agent.yaml file exists and is readable",
+			fmt.Sprintf("failed to load agent manifest file: %s", err),
+			"verify the agent.yaml file, the files it references and its environment overlay exist and are valid YAML",
 		)
 	}
 
+	overlayPath := agent_yaml.OverlayPath(p.agentDefinitionPath, p.env.Name)
+	if _, err := os.Stat(overlayPath); err == nil {
+		fmt.Printf("Applying overlay for environment '%s': %s\n", p.env.Name, color.New(color.FgHiGreen).Sprint(overlayPath))
+	}
+
 	err = agent_yaml.ValidateAgentDefinition(data)
 	if err != nil {
 		return nil, exterrors.Validation(
@@ -470,6 +475,10 @@ func (p *AgentServiceTargetProvider) Deploy(
 		)
 	}
 
+	if err := p.validateConnectedAgents(ctx, data); err != nil {
+		return nil, err
+	}
+
 	switch kind {
 	case string(agent_yaml.AgentKindPrompt):
 		var agentDef agent_yaml.PromptAgent
@@ -502,7 +511,7 @@ func (p *AgentServiceTargetProvider) Deploy(
 
 func (p *AgentServiceTargetProvider) isContainerAgent() bool {
 	// Load and validate the agent manifest
-	data, err := os.ReadFile(p.agentDefinitionPath)
+	data, err := agent_yaml.ComposeAgentDefinition(p.agentDefinitionPath, p.env.Name)
 	if err != nil {
 		return false
 	}
@@ -635,6 +644,17 @@ func (p *AgentServiceTargetProvider) deployHostedAgent(
 		}
 	}
 
+	// Wire the names and endpoints of the connected agents, unless set explicitly in the YAML
+	connectedEnvVars, err := p.connectedAgentEnvironmentVariables(agentDef.ConnectedAgents, azdEnv)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range connectedEnvVars {
+		if _, has := resolvedEnvVars[key]; !has {
+			resolvedEnvVars[key] = value
+		}
+	}
+
 	// Step 3: Create agent request with image URL and resolved environment variables
 	var foundryAgentConfig *ServiceTargetAgentConfig
 	if err := UnmarshalStruct(serviceConfig.Config, &foundryAgentConfig); err != nil {
@@ -1062,6 +1082,81 @@ func (p *AgentServiceTargetProvider) registerAgentEnvironmentVariables(
 	return nil
 }
 
+// validateConnectedAgents checks that the connected agents of the agent definition are agents of the
+// project, and that the connected agents of the project don't form cycles
+func (p *AgentServiceTargetProvider) validateConnectedAgents(ctx context.Context, data []byte) error {
+	connectedAgents, err := agent_yaml.ExtractConnectedAgents(data)
+	if err != nil {
+		return exterrors.Validation(
+			exterrors.CodeInvalidAgentManifest,
+			fmt.Sprintf("agent.yaml is not valid: %s", err),
+			"declare each connected agent once, with the name of its service in azure.yaml",
+		)
+	}
+
+	if len(connectedAgents) == 0 {
+		return nil
+	}
+
+	proj, err := p.azdClient.Project().Get(ctx, nil)
+	if err != nil {
+		return exterrors.Dependency(
+			exterrors.CodeProjectNotFound,
+			fmt.Sprintf("failed to get project: %s", err),
+			"run 'azd init' to initialize your project",
+		)
+	}
+
+	graph, err := BuildAgentGraph(proj.Project, p.env.Name)
+	if err == nil {
+		err = graph.Validate()
+	}
+	if err != nil {
+		return exterrors.Validation(
+			exterrors.CodeInvalidAgentGraph,
+			fmt.Sprintf("connected agents are not valid: %s", err),
+			"connect agents to azure.ai.agent services of azure.yaml, without cycles between agents",
+		)
+	}
+
+	return nil
+}
+
+// connectedAgentEnvironmentVariables returns the environment variables wiring the connected agents into an
+// agent: AGENT_<SERVICE>_NAME and AGENT_<SERVICE>_ENDPOINT of each connected agent, from the azd environment
+func (p *AgentServiceTargetProvider) connectedAgentEnvironmentVariables(
+	connectedAgents []agent_yaml.ConnectedAgent,
+	azdEnv map[string]string,
+) (map[string]string, error) {
+	envVars := map[string]string{}
+
+	for _, connected := range connectedAgents {
+		serviceKey := p.getServiceKey(connected.Service)
+		nameKey := fmt.Sprintf("AGENT_%s_NAME", serviceKey)
+		endpointKey := fmt.Sprintf("AGENT_%s_ENDPOINT", serviceKey)
+
+		if azdEnv[nameKey] == "" || azdEnv[endpointKey] == "" {
+			return nil, exterrors.Dependency(
+				exterrors.CodeConnectedAgentNotDeployed,
+				fmt.Sprintf(
+					"connected agent '%s' is not deployed: %s and %s are not set",
+					connected.Service, nameKey, endpointKey,
+				),
+				fmt.Sprintf(
+					"run 'azd deploy %s' first, or add '%s' to 'uses' of the service in azure.yaml "+
+						"so that azd deploys it first",
+					connected.Service, connected.Service,
+				),
+			)
+		}
+
+		envVars[nameKey] = azdEnv[nameKey]
+		envVars[endpointKey] = azdEnv[endpointKey]
+	}
+
+	return envVars, nil
+}
+
 // resolveEnvironmentVariables resolves ${ENV_VAR} style references in value using azd environment variables.
 // Supports default values (e.g., "${VAR:-default}") and multiple expressions (e.g., "${VAR1}-${VAR2}").
 func (p *AgentServiceTargetProvider) resolveEnvironmentVariables(value string, azdEnv map[string]string) string {
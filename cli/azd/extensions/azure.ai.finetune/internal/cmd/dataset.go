@@ -0,0 +1,237 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"azure.ai.finetune/internal/utils"
+	"azure.ai.finetune/pkg/models"
+)
+
+// maxDisplayedDatasetIssues is the number of issues of each kind displayed in the table output
+const maxDisplayedDatasetIssues = 20
+
+// newDatasetCommand creates the dataset command group. Dataset commands run locally and don't
+// require an initialized environment, so datasets can be checked before a job is submitted.
+func newDatasetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dataset",
+		Short: "Validate and prepare fine-tuning datasets locally.",
+	}
+
+	cmd.AddCommand(newDatasetValidateCommand())
+	cmd.AddCommand(newDatasetSplitCommand())
+	cmd.AddCommand(newDatasetConvertCommand())
+
+	return cmd
+}
+
+// newDatasetValidateCommand creates a command to validate a JSONL dataset
+func newDatasetValidateCommand() *cobra.Command {
+	var file string
+	var format string
+	var maxTokens int
+	var strict bool
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validates a JSONL dataset and estimates its token count.",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			switch models.DatasetFormat(format) {
+			case "", models.DatasetFormatChat, models.DatasetFormatCompletion:
+				return nil
+			default:
+				return fmt.Errorf("unsupported dataset format: %s (supported: chat, completion)", format)
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report, err := utils.ValidateDataset(utils.GetLocalFilePath(file), utils.DatasetValidationOptions{
+				Format:    models.DatasetFormat(format),
+				MaxTokens: maxTokens,
+			})
+			if err != nil {
+				return err
+			}
+
+			switch output {
+			case "json":
+				_ = utils.PrintObject(report, utils.FormatJSON)
+			case "table", "":
+				printDatasetReport(report)
+			default:
+				return fmt.Errorf("unsupported output format: %s (supported: table, json)", output)
+			}
+
+			if !report.Valid() {
+				return fmt.Errorf("dataset %s has %d errors", report.Path, len(report.Errors))
+			}
+			if strict && report.HasWarnings() {
+				return fmt.Errorf("dataset %s has warnings and --strict is set", report.Path)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Path to the JSONL dataset (required)")
+	cmd.Flags().StringVar(&format, "format", "",
+		"Expected dataset format: chat, completion. Detected from the first example if not provided")
+	cmd.Flags().IntVar(&maxTokens, "max-tokens", 0,
+		"Warn about examples estimated to have more tokens than this limit. Not checked if 0")
+	cmd.Flags().BoolVar(&strict, "strict", false, "Fail on duplicates, PII-like content and other warnings")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "Output format: table, json")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// newDatasetSplitCommand creates a command to split a JSONL dataset into training and validation files
+func newDatasetSplitCommand() *cobra.Command {
+	var file string
+	var validationRatio float64
+	var seed uint64
+	var trainingOutput string
+	var validationOutput string
+
+	cmd := &cobra.Command{
+		Use:   "split",
+		Short: "Splits a JSONL dataset into training and validation files.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := utils.GetLocalFilePath(file)
+			if trainingOutput == "" {
+				trainingOutput = datasetOutputPath(path, "train")
+			}
+			if validationOutput == "" {
+				validationOutput = datasetOutputPath(path, "validation")
+			}
+
+			result, err := utils.SplitDataset(path, trainingOutput, validationOutput, validationRatio, seed)
+			if err != nil {
+				return err
+			}
+
+			color.Green("✓ Dataset split successfully")
+			fmt.Println()
+			fmt.Printf("  Training:    %s (%d examples)\n", trainingOutput, result.TrainingExamples)
+			fmt.Printf("  Validation:  %s (%d examples)\n", validationOutput, result.ValidationExamples)
+			fmt.Println()
+			fmt.Printf("Submit with: azd ai finetuning jobs submit --model <model> --training-file local:%s "+
+				"--validation-file local:%s\n", trainingOutput, validationOutput)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Path to the JSONL dataset (required)")
+	cmd.Flags().Float64VarP(&validationRatio, "validation-ratio", "r", 0.1,
+		"Fraction of the examples used for validation, between 0 and 1")
+	cmd.Flags().Uint64Var(&seed, "seed", 42, "Seed of the split. The same seed always produces the same split")
+	cmd.Flags().StringVar(&trainingOutput, "training-output", "",
+		"Path of the training file. Defaults to <file>_train.jsonl")
+	cmd.Flags().StringVar(&validationOutput, "validation-output", "",
+		"Path of the validation file. Defaults to <file>_validation.jsonl")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// newDatasetConvertCommand creates a command to convert a dataset to the chat JSONL format
+func newDatasetConvertCommand() *cobra.Command {
+	var file string
+	var from string
+	var systemPrompt string
+	var outputFile string
+
+	cmd := &cobra.Command{
+		Use:   "convert",
+		Short: "Converts a CSV, completion, ShareGPT or Alpaca dataset to the chat JSONL format.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := utils.GetLocalFilePath(file)
+			if outputFile == "" {
+				outputFile = datasetOutputPath(path, "chat")
+			}
+
+			count, err := utils.ConvertDataset(path, outputFile, utils.DatasetConversionOptions{
+				From:         utils.DatasetSourceFormat(strings.ToLower(from)),
+				SystemPrompt: systemPrompt,
+			})
+			if err != nil {
+				return err
+			}
+
+			color.Green("✓ Converted %d examples to %s", count, outputFile)
+			fmt.Println()
+			fmt.Printf("Validate with: azd ai finetuning dataset validate --file %s\n", outputFile)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Path to the dataset to convert (required)")
+	cmd.Flags().StringVar(&from, "from", "",
+		"Format of the dataset: csv, completion, sharegpt, alpaca. Detected from the file if not provided")
+	cmd.Flags().StringVar(&systemPrompt, "system-prompt", "",
+		"System message added to the examples without one")
+	cmd.Flags().StringVar(&outputFile, "output-file", "",
+		"Path of the converted dataset. Defaults to <file>_chat.jsonl")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+// datasetOutputPath returns the path of a file generated from a dataset, next to the dataset
+func datasetOutputPath(path string, suffix string) string {
+	base := strings.TrimSuffix(path, filepath.Ext(path))
+	return fmt.Sprintf("%s_%s.jsonl", base, suffix)
+}
+
+// printDatasetReport prints the summary and the issues of a dataset validation
+func printDatasetReport(report *models.DatasetReport) {
+	indent := "  "
+	fmt.Println("Dataset:")
+	_ = utils.PrintObjectWithIndent(report.ToSummaryView(), utils.FormatTable, indent)
+
+	sections := []struct {
+		title  string
+		issues []models.DatasetIssue
+	}{
+		{"Errors", report.Errors},
+		{"Warnings", report.Warnings},
+		{"Duplicates", report.Duplicates},
+		{"PII-like content", report.PII},
+	}
+
+	for _, section := range sections {
+		if len(section.issues) == 0 {
+			continue
+		}
+
+		fmt.Printf("\n%s:\n", section.title)
+		for _, issue := range section.issues[:min(len(section.issues), maxDisplayedDatasetIssues)] {
+			if issue.Line > 0 {
+				fmt.Printf("%sline %d: %s\n", indent, issue.Line, issue.Message)
+			} else {
+				fmt.Printf("%s%s\n", indent, issue.Message)
+			}
+		}
+		if len(section.issues) > maxDisplayedDatasetIssues {
+			fmt.Printf("%s... and %d more\n", indent, len(section.issues)-maxDisplayedDatasetIssues)
+		}
+	}
+
+	fmt.Println()
+	switch {
+	case !report.Valid():
+		color.Red("✗ Dataset is not valid. Fix the errors above before submitting a job.")
+	case report.HasWarnings():
+		color.Yellow("✓ Dataset is valid, review the warnings above before submitting a job.")
+	default:
+		color.Green("✓ Dataset is valid")
+	}
+}
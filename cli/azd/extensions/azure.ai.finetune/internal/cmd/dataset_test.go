@@ -0,0 +1,89 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDatasetCommand(t *testing.T) {
+	cmd := newDatasetCommand()
+
+	require.Equal(t, "dataset", cmd.Use)
+	// Dataset commands run offline, without environment validation
+	require.Nil(t, cmd.PersistentPreRunE)
+
+	subcommands := []string{}
+	for _, subcmd := range cmd.Commands() {
+		subcommands = append(subcommands, subcmd.Use)
+		require.NotNil(t, subcmd.Flags().Lookup("file"), "%s should have a --file flag", subcmd.Use)
+	}
+	require.ElementsMatch(t, []string{"validate", "split", "convert"}, subcommands)
+}
+
+func TestDatasetValidateCommand(t *testing.T) {
+	dir := t.TempDir()
+	valid := make([]string, 10)
+	for i := range valid {
+		valid[i] = `{"messages":[{"role":"user","content":"Hi ` + strings.Repeat("!", i) + `"},` +
+			`{"role":"assistant","content":"Hello"}]}`
+	}
+
+	validPath := filepath.Join(dir, "valid.jsonl")
+	require.NoError(t, os.WriteFile(validPath, []byte(strings.Join(valid, "\n")), 0600))
+
+	invalidPath := filepath.Join(dir, "invalid.jsonl")
+	require.NoError(t, os.WriteFile(invalidPath, []byte(`{"messages":[]}`), 0600))
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{name: "Valid", args: []string{"--file", validPath, "--output", "json"}},
+		{name: "LocalPrefix", args: []string{"--file", "local:" + validPath, "--output", "json"}},
+		{
+			name:          "Invalid",
+			args:          []string{"--file", invalidPath, "--output", "json"},
+			expectedError: "has 1 errors",
+		},
+		{
+			name:          "StrictWarnings",
+			args:          []string{"--file", validPath, "--max-tokens", "5", "--strict", "--output", "json"},
+			expectedError: "has warnings and --strict is set",
+		},
+		{
+			name:          "UnsupportedFormat",
+			args:          []string{"--file", validPath, "--format", "dpo"},
+			expectedError: "unsupported dataset format: dpo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newDatasetValidateCommand()
+			cmd.SetArgs(tt.args)
+			cmd.SilenceUsage = true
+
+			err := cmd.Execute()
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestDatasetOutputPath(t *testing.T) {
+	require.Equal(t, filepath.Join("data", "train_chat.jsonl"),
+		datasetOutputPath(filepath.Join("data", "train.csv"), "chat"))
+	require.Equal(t, "data_validation.jsonl", datasetOutputPath("data.jsonl", "validation"))
+	require.Equal(t, "data_train.jsonl", datasetOutputPath("data", "train"))
+}
@@ -51,6 +51,7 @@ func newOperationCommand() *cobra.Command {
 	cmd.AddCommand(newOperationResumeCommand())
 	cmd.AddCommand(newOperationCancelCommand())
 	cmd.AddCommand(newOperationDeployModelCommand())
+	cmd.AddCommand(newOperationSweepCommand())
 
 	return cmd
 }
@@ -38,6 +38,7 @@ func TestNewOperationCommand_HasAllSubcommands(t *testing.T) {
 		"resume",
 		"cancel",
 		"deploy",
+		"sweep",
 	}
 
 	for _, expected := range expectedCommands {
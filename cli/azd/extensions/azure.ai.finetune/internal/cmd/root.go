@@ -47,6 +47,7 @@ func NewRootCommand() *cobra.Command {
 	rootCmd.AddCommand(newVersionCommand())
 	rootCmd.AddCommand(newInitCommand(rootFlags))
 	rootCmd.AddCommand(newOperationCommand())
+	rootCmd.AddCommand(newDatasetCommand())
 	rootCmd.AddCommand(newMetadataCommand())
 
 	return rootCmd
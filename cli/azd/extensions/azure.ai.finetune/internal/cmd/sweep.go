@@ -0,0 +1,294 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/ux"
+
+	"azure.ai.finetune/internal/providers/factory"
+	"azure.ai.finetune/internal/services"
+	"azure.ai.finetune/internal/utils"
+	"azure.ai.finetune/pkg/models"
+)
+
+func newOperationSweepCommand() *cobra.Command {
+	var filename string
+	var name string
+	var deploymentName string
+	var noWait bool
+	var pollInterval time.Duration
+	var maxPollInterval time.Duration
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "sweep",
+		Short: "Submit a fine-tuning job for each hyperparameter combination and deploy the best checkpoint.",
+		Long: "Submit a fine-tuning job for each hyperparameter combination of a sweep file, wait for the jobs, " +
+			"and compare their checkpoints on the validation metric of the sweep. The best checkpoint is " +
+			"deployed when the sweep file has a deploy section or --deployment-name is provided.\n\n" +
+			"Sweeps are tracked in the azd environment: run with --name to resume waiting for a sweep.",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return validateSweepFlags(filename, name)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := azdext.WithAccessToken(cmd.Context())
+
+			azdClient, err := azdext.NewAzdClient()
+			if err != nil {
+				return fmt.Errorf("failed to create azd client: %w", err)
+			}
+			defer azdClient.Close()
+
+			// Wait for debugger if AZD_EXT_DEBUG is set
+			if err := azdext.WaitForDebugger(ctx, azdClient); err != nil {
+				if errors.Is(err, context.Canceled) || errors.Is(err, azdext.ErrDebuggerAborted) {
+					return nil
+				}
+				return fmt.Errorf("failed waiting for debugger: %w", err)
+			}
+
+			var spec *models.SweepSpec
+			if filename != "" {
+				spec, err = utils.ParseSweepSpec(filename)
+				if err != nil {
+					return err
+				}
+			}
+
+			statePath, err := utils.GetStateFilePath(ctx, azdClient)
+			if err != nil {
+				return err
+			}
+
+			sweepSvc, err := services.NewSweepService(
+				ctx, factory.NewProviderFactory(azdClient), services.NewFileStateStore(statePath))
+			if err != nil {
+				return err
+			}
+
+			var sweep *models.Sweep
+			if spec != nil {
+				spinner := ux.NewSpinner(&ux.SpinnerOptions{
+					Text: fmt.Sprintf("submitting %d fine-tuning jobs...", len(spec.Hyperparameters)),
+				})
+				if err := spinner.Start(ctx); err != nil {
+					fmt.Printf("failed to start spinner: %v\n", err)
+				}
+
+				sweep, err = sweepSvc.SubmitSweep(ctx, spec)
+				_ = spinner.Stop(ctx)
+				fmt.Println()
+				if err != nil {
+					return err
+				}
+
+				color.Green("Submitted %d fine-tuning jobs for sweep '%s'\n", len(sweep.Runs), sweep.Name)
+			} else {
+				sweep, err = sweepSvc.GetSweep(ctx, name)
+				if err != nil {
+					return fmt.Errorf("failed to get sweep '%s': %w", name, err)
+				}
+			}
+
+			if err := utils.PrintObject(sweep.Runs, utils.FormatTable); err != nil {
+				return err
+			}
+
+			if noWait {
+				fmt.Printf("\nTo wait for the sweep and select its best checkpoint, run: "+
+					"azd ai finetuning jobs sweep --name %s\n", sweep.Name)
+				return nil
+			}
+
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			if sweep.Status == models.SweepStatusRunning {
+				spinner := ux.NewSpinner(&ux.SpinnerOptions{
+					Text: "waiting for the fine-tuning jobs of the sweep...",
+				})
+				if err := spinner.Start(ctx); err != nil {
+					fmt.Printf("failed to start spinner: %v\n", err)
+				}
+
+				err = sweepSvc.WaitForSweep(ctx, sweep, services.SweepWaitOptions{
+					PollInterval:    pollInterval,
+					MaxPollInterval: maxPollInterval,
+					OnPoll: func(sweep *models.Sweep) {
+						spinner.UpdateText(fmt.Sprintf(
+							"waiting for the fine-tuning jobs of the sweep (%d of %d finished)...",
+							finishedSweepRuns(sweep), len(sweep.Runs)))
+					},
+				})
+				_ = spinner.Stop(ctx)
+				fmt.Println()
+				if errors.Is(err, context.DeadlineExceeded) {
+					return fmt.Errorf(
+						"timed out waiting for sweep '%s', to resume run: azd ai finetuning jobs sweep --name %s",
+						sweep.Name, sweep.Name)
+				}
+				if err != nil {
+					return fmt.Errorf("failed waiting for sweep '%s': %w", sweep.Name, err)
+				}
+
+				if err := utils.PrintObject(sweep.Runs, utils.FormatTable); err != nil {
+					return err
+				}
+			}
+
+			best, err := sweepSvc.SelectBestCheckpoint(ctx, sweep)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println()
+			fmt.Println(strings.Repeat("=", 60))
+			color.Green("Best checkpoint by %s:\n", sweep.Metric)
+			if err := utils.PrintObject(best, utils.FormatTable); err != nil {
+				return err
+			}
+			fmt.Println(strings.Repeat("=", 60))
+
+			deploy := sweepDeployConfig(sweep.Deploy, deploymentName)
+			if deploy == nil {
+				fmt.Printf("\nTo deploy the best checkpoint, run: azd ai finetuning jobs sweep --name %s "+
+					"--deployment-name <name>\n", sweep.Name)
+				return nil
+			}
+			if sweep.Status == models.SweepStatusDeployed && sweep.Deployment == deploy.DeploymentName {
+				fmt.Printf("\nBest checkpoint is deployed as '%s'\n", sweep.Deployment)
+				return nil
+			}
+
+			envValueMap, err := utils.GetEnvironmentValues(ctx, azdClient)
+			if err != nil {
+				return err
+			}
+
+			target, err := sweepDeploymentRequest(deploy, envValueMap)
+			if err != nil {
+				return err
+			}
+
+			spinner := ux.NewSpinner(&ux.SpinnerOptions{
+				Text: "deploying best checkpoint...",
+			})
+			if err := spinner.Start(ctx); err != nil {
+				fmt.Printf("failed to start spinner: %v\n", err)
+			}
+
+			result, err := sweepSvc.DeployBestCheckpoint(ctx, sweep, target)
+			_ = spinner.Stop(ctx)
+			fmt.Println()
+			if err != nil {
+				return err
+			}
+
+			color.Green("\nSuccessfully deployed best checkpoint!\n")
+			fmt.Printf("Deployment Name:  %s\n", result.Deployment.Name)
+			fmt.Printf("Status:           %s\n", result.Status)
+			fmt.Printf("Message:          %s\n", result.Message)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&filename, "file", "f", "", "Path to the sweep YAML file to submit")
+	cmd.Flags().StringVarP(&name, "name", "n", "", "Name of a tracked sweep to resume")
+	cmd.Flags().StringVarP(&deploymentName, "deployment-name", "d", "",
+		"Deploy the best checkpoint with this deployment name, overriding the sweep file")
+	cmd.Flags().BoolVar(&noWait, "no-wait", false, "Do not wait for the jobs of the sweep to complete")
+	cmd.Flags().DurationVar(&pollInterval, "poll-interval", services.DefaultSweepPollInterval,
+		"Initial interval between status checks, doubled after each check")
+	cmd.Flags().DurationVar(&maxPollInterval, "max-poll-interval", services.DefaultSweepMaxPollInterval,
+		"Longest interval between status checks")
+	cmd.Flags().DurationVar(&timeout, "timeout", 0, "Maximum time to wait for the jobs, 0 to wait until they finish")
+
+	return cmd
+}
+
+// finishedSweepRuns returns the number of jobs of a sweep in a terminal state
+func finishedSweepRuns(sweep *models.Sweep) int {
+	finished := 0
+	for _, run := range sweep.Runs {
+		if utils.IsTerminalStatus(run.Status) {
+			finished++
+		}
+	}
+	return finished
+}
+
+// sweepDeployConfig returns the deployment of the best checkpoint of a sweep, with deploymentName
+// overriding the deployment name of the sweep file. Returns nil when nothing is to be deployed.
+func sweepDeployConfig(deploy *models.SweepDeployConfig, deploymentName string) *models.SweepDeployConfig {
+	if deploymentName == "" {
+		return deploy
+	}
+
+	config := models.SweepDeployConfig{}
+	if deploy != nil {
+		config = *deploy
+	}
+	config.DeploymentName = deploymentName
+	return &config
+}
+
+// sweepDeploymentRequest creates the deployment request of the best checkpoint of a sweep in the
+// account of the current environment, with the defaults of 'jobs deploy'
+func sweepDeploymentRequest(
+	deploy *models.SweepDeployConfig,
+	envValueMap map[string]string,
+) (*models.DeploymentRequest, error) {
+	requiredEnvVars := []string{
+		utils.EnvAzureSubscriptionID,
+		utils.EnvAzureResourceGroupName,
+		utils.EnvAzureAccountName,
+		utils.EnvAzureTenantID,
+	}
+	for _, envVar := range requiredEnvVars {
+		if envValueMap[envVar] == "" {
+			return nil, fmt.Errorf("required environment variable %s is not set or empty", envVar)
+		}
+	}
+
+	req := &models.DeploymentRequest{
+		DeploymentName:    deploy.DeploymentName,
+		ModelFormat:       deploy.ModelFormat,
+		SKU:               deploy.SKU,
+		Version:           deploy.Version,
+		Capacity:          deploy.Capacity,
+		SubscriptionID:    envValueMap[utils.EnvAzureSubscriptionID],
+		ResourceGroup:     envValueMap[utils.EnvAzureResourceGroupName],
+		AccountName:       envValueMap[utils.EnvAzureAccountName],
+		TenantID:          envValueMap[utils.EnvAzureTenantID],
+		WaitForCompletion: true,
+	}
+	if req.ModelFormat == "" {
+		req.ModelFormat = "OpenAI"
+	}
+	if req.SKU == "" {
+		req.SKU = "GlobalStandard"
+	}
+	if req.Version == "" {
+		req.Version = "1"
+	}
+	if req.Capacity == 0 {
+		req.Capacity = 1
+	}
+
+	return req, nil
+}
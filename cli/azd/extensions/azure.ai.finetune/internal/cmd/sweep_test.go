@@ -0,0 +1,65 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"azure.ai.finetune/pkg/models"
+)
+
+func TestNewOperationSweepCommand(t *testing.T) {
+	cmd := newOperationSweepCommand()
+
+	require.Equal(t, "sweep", cmd.Use)
+	require.NotNil(t, cmd.PreRunE, "sweep command should have PreRunE for validation")
+	require.NotNil(t, cmd.RunE)
+
+	for _, name := range []string{"file", "name", "deployment-name", "no-wait", "poll-interval", "timeout"} {
+		require.NotNil(t, cmd.Flags().Lookup(name), "Flag --%s should be defined", name)
+	}
+	require.Equal(t, "30s", cmd.Flags().Lookup("poll-interval").DefValue)
+}
+
+func TestSweepDeployConfig(t *testing.T) {
+	fromFile := &models.SweepDeployConfig{DeploymentName: "best", SKU: "Standard", Capacity: 5}
+
+	require.Nil(t, sweepDeployConfig(nil, ""))
+	require.Equal(t, fromFile, sweepDeployConfig(fromFile, ""))
+	require.Equal(t, &models.SweepDeployConfig{DeploymentName: "override"}, sweepDeployConfig(nil, "override"))
+	require.Equal(t,
+		&models.SweepDeployConfig{DeploymentName: "override", SKU: "Standard", Capacity: 5},
+		sweepDeployConfig(fromFile, "override"))
+	require.Equal(t, "best", fromFile.DeploymentName)
+}
+
+func TestSweepDeploymentRequest(t *testing.T) {
+	envValueMap := map[string]string{
+		"AZURE_SUBSCRIPTION_ID":     "sub",
+		"AZURE_RESOURCE_GROUP_NAME": "rg",
+		"AZURE_ACCOUNT_NAME":        "account",
+		"AZURE_TENANT_ID":           "tenant",
+	}
+
+	req, err := sweepDeploymentRequest(&models.SweepDeployConfig{DeploymentName: "best", Capacity: 5}, envValueMap)
+	require.NoError(t, err)
+	require.Equal(t, &models.DeploymentRequest{
+		DeploymentName:    "best",
+		ModelFormat:       "OpenAI",
+		SKU:               "GlobalStandard",
+		Version:           "1",
+		Capacity:          5,
+		SubscriptionID:    "sub",
+		ResourceGroup:     "rg",
+		AccountName:       "account",
+		TenantID:          "tenant",
+		WaitForCompletion: true,
+	}, req)
+
+	delete(envValueMap, "AZURE_ACCOUNT_NAME")
+	_, err = sweepDeploymentRequest(&models.SweepDeployConfig{DeploymentName: "best"}, envValueMap)
+	require.ErrorContains(t, err, "AZURE_ACCOUNT_NAME is not set")
+}
@@ -55,6 +55,10 @@ const (
 	HintSubmitJobUsage = `Usage options:
   1. Provide a config file:    azd ai finetuning jobs submit --file config.yaml
   2. Provide model and data:   azd ai finetuning jobs submit --model <model> --training-file <file>`
+
+	HintSweepUsage = `Usage options:
+  1. Submit a sweep:           azd ai finetuning jobs sweep --file sweep.yaml
+  2. Resume a tracked sweep:   azd ai finetuning jobs sweep --name <sweep-name>`
 )
 
 // validateRequiredFlags checks if any of the provided flag values are empty and returns
@@ -136,6 +140,22 @@ func validateSubmitFlags(file, model, trainingFile string) error {
 	return nil
 }
 
+// validateSweepFlags validates the sweep command flag combinations.
+// Either --file must be provided to submit a sweep, or --name to resume a tracked sweep.
+func validateSweepFlags(file, name string) error {
+	if file == "" && name == "" {
+		errorMsg := "either --file or --name is required"
+		hint := color.YellowString("\n\n%s\n", HintSweepUsage)
+		return fmt.Errorf("%s%s", errorMsg, hint)
+	}
+
+	if file != "" && name != "" {
+		return fmt.Errorf("--file and --name cannot both be provided")
+	}
+
+	return nil
+}
+
 // validateOrInitEnvironment checks if environment is configured, and if not, attempts implicit initialization
 // using the provided subscription ID and project endpoint flags.
 func validateOrInitEnvironment(ctx context.Context, subscriptionId, projectEndpoint string) error {
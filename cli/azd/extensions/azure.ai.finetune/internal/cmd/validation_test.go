@@ -162,6 +162,17 @@ func TestValidateSubmitFlags_ConfigFileOverridesModelAndTrainingFile(t *testing.
 	require.NoError(t, err)
 }
 
+func TestValidateSweepFlags(t *testing.T) {
+	require.NoError(t, validateSweepFlags("sweep.yaml", ""))
+	require.NoError(t, validateSweepFlags("", "lr-sweep"))
+
+	err := validateSweepFlags("", "")
+	require.ErrorContains(t, err, "either --file or --name is required")
+	require.Contains(t, err.Error(), HintSweepUsage)
+
+	require.ErrorContains(t, validateSweepFlags("sweep.yaml", "lr-sweep"), "cannot both be provided")
+}
+
 func TestHintConstants_AreNotEmpty(t *testing.T) {
 	hints := []struct {
 		name  string
@@ -170,6 +181,7 @@ func TestHintConstants_AreNotEmpty(t *testing.T) {
 		{"HintFindJobID", HintFindJobID},
 		{"HintDeploymentName", HintDeploymentName},
 		{"HintSubmitJobUsage", HintSubmitJobUsage},
+		{"HintSweepUsage", HintSweepUsage},
 	}
 
 	for _, hint := range hints {
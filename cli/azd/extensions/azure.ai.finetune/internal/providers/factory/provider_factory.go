@@ -143,6 +143,67 @@ func NewModelDeploymentProvider(subscriptionId string, credential azcore.TokenCr
 	return azureprovider.NewAzureProvider(clientFactory), err
 }
 
+// ProviderFactory creates the providers used by the services. Services which create their
+// providers through a ProviderFactory can be tested with fake providers.
+type ProviderFactory interface {
+	// NewFineTuningProvider creates the FineTuningProvider of the current environment
+	NewFineTuningProvider(ctx context.Context) (providers.FineTuningProvider, error)
+
+	// NewModelDeploymentProvider creates the ModelDeploymentProvider of the current environment
+	NewModelDeploymentProvider(ctx context.Context) (providers.ModelDeploymentProvider, error)
+}
+
+// azdProviderFactory creates providers from the values of the current azd environment
+type azdProviderFactory struct {
+	azdClient *azdext.AzdClient
+}
+
+// NewProviderFactory creates a ProviderFactory for the current azd environment
+func NewProviderFactory(azdClient *azdext.AzdClient) ProviderFactory {
+	return &azdProviderFactory{azdClient: azdClient}
+}
+
+func (f *azdProviderFactory) NewFineTuningProvider(ctx context.Context) (providers.FineTuningProvider, error) {
+	return NewFineTuningProvider(ctx, f.azdClient)
+}
+
+func (f *azdProviderFactory) NewModelDeploymentProvider(ctx context.Context) (providers.ModelDeploymentProvider, error) {
+	envValueMap, err := utils.GetEnvironmentValues(ctx, f.azdClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get environment values: %w", err)
+	}
+
+	credential, err := azidentity.NewAzureDeveloperCLICredential(&azidentity.AzureDeveloperCLICredentialOptions{
+		TenantID:                   envValueMap[utils.EnvAzureTenantID],
+		AdditionallyAllowedTenants: []string{"*"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create azure credential: %w", err)
+	}
+
+	return NewModelDeploymentProvider(envValueMap[utils.EnvAzureSubscriptionID], credential)
+}
+
+// StaticProviderFactory is a ProviderFactory returning existing providers, such as fakes in tests
+type StaticProviderFactory struct {
+	FineTuning      providers.FineTuningProvider
+	ModelDeployment providers.ModelDeploymentProvider
+}
+
+func (f *StaticProviderFactory) NewFineTuningProvider(ctx context.Context) (providers.FineTuningProvider, error) {
+	if f.FineTuning == nil {
+		return nil, fmt.Errorf("no fine-tuning provider configured")
+	}
+	return f.FineTuning, nil
+}
+
+func (f *StaticProviderFactory) NewModelDeploymentProvider(ctx context.Context) (providers.ModelDeploymentProvider, error) {
+	if f.ModelDeployment == nil {
+		return nil, fmt.Errorf("no model deployment provider configured")
+	}
+	return f.ModelDeployment, nil
+}
+
 type policyAdapter option.MiddlewareNext
 
 func (mp policyAdapter) Do(req *policy.Request) (*http.Response, error) {
@@ -52,8 +52,12 @@ func (p *OpenAIProvider) CreateFineTuningJob(ctx context.Context, req *models.Cr
 
 // GetFineTuningStatus retrieves the status of a fine-tuning job
 func (p *OpenAIProvider) GetFineTuningStatus(ctx context.Context, jobID string) (*models.FineTuningJob, error) {
-	// TODO: Implement
-	return nil, nil
+	job, err := p.client.FineTuning.Jobs.Get(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertOpenAIJobToModel(*job), nil
 }
 
 // ListFineTuningJobs lists all fine-tuning jobs
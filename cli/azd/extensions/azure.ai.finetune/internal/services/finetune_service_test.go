@@ -116,6 +116,9 @@ type MockStateStore struct {
 	ListDeploymentsFunc        func(ctx context.Context) ([]*models.Deployment, error)
 	UpdateDeploymentStatusFunc func(ctx context.Context, deploymentID string, status models.DeploymentStatus) error
 	DeleteDeploymentFunc       func(ctx context.Context, deploymentID string) error
+	SaveSweepFunc              func(ctx context.Context, sweep *models.Sweep) error
+	GetSweepFunc               func(ctx context.Context, name string) (*models.Sweep, error)
+	ListSweepsFunc             func(ctx context.Context) ([]*models.Sweep, error)
 }
 
 func (m *MockStateStore) SaveJob(ctx context.Context, job *models.FineTuningJob) error {
@@ -188,6 +191,27 @@ func (m *MockStateStore) DeleteDeployment(ctx context.Context, deploymentID stri
 	return nil
 }
 
+func (m *MockStateStore) SaveSweep(ctx context.Context, sweep *models.Sweep) error {
+	if m.SaveSweepFunc != nil {
+		return m.SaveSweepFunc(ctx, sweep)
+	}
+	return nil
+}
+
+func (m *MockStateStore) GetSweep(ctx context.Context, name string) (*models.Sweep, error) {
+	if m.GetSweepFunc != nil {
+		return m.GetSweepFunc(ctx, name)
+	}
+	return nil, nil
+}
+
+func (m *MockStateStore) ListSweeps(ctx context.Context) ([]*models.Sweep, error) {
+	if m.ListSweepsFunc != nil {
+		return m.ListSweepsFunc(ctx)
+	}
+	return nil, nil
+}
+
 // newTestFineTuningService creates a new FineTuningService with a mock provider for testing
 func newTestFineTuningService(provider *MockFineTuningProvider, stateStore StateStore) *fineTuningServiceImpl {
 	return &fineTuningServiceImpl{
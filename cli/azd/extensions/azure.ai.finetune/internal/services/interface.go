@@ -65,3 +65,23 @@ type DeploymentService interface {
 	// WaitForDeployment waits for a deployment to become active
 	WaitForDeployment(ctx context.Context, deploymentID string, timeoutSeconds int) (*models.Deployment, error)
 }
+
+// SweepService defines the business logic interface for hyperparameter sweeps
+type SweepService interface {
+	// SubmitSweep submits a fine-tuning job for each hyperparameter combination of a sweep
+	// and tracks the sweep and its jobs in the state store
+	SubmitSweep(ctx context.Context, spec *models.SweepSpec) (*models.Sweep, error)
+
+	// GetSweep retrieves a sweep tracked in the state store
+	GetSweep(ctx context.Context, name string) (*models.Sweep, error)
+
+	// WaitForSweep polls the jobs of a sweep, backing off between polls, until they all finish
+	WaitForSweep(ctx context.Context, sweep *models.Sweep, options SweepWaitOptions) error
+
+	// SelectBestCheckpoint compares the checkpoints of the succeeded jobs of a sweep on its metric
+	SelectBestCheckpoint(ctx context.Context, sweep *models.Sweep) (*models.SweepCheckpoint, error)
+
+	// DeployBestCheckpoint deploys the best checkpoint of a sweep with the settings of target
+	DeployBestCheckpoint(
+		ctx context.Context, sweep *models.Sweep, target *models.DeploymentRequest) (*models.DeployModelResult, error)
+}
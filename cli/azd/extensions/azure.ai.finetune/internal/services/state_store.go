@@ -5,10 +5,20 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
 
 	"azure.ai.finetune/pkg/models"
 )
 
+// ErrNotTracked is returned when a job, deployment or sweep isn't tracked in the state store
+var ErrNotTracked = errors.New("not tracked in the local state")
+
 // StateStore defines the interface for persisting job state
 // This allows tracking jobs across CLI sessions
 type StateStore interface {
@@ -41,6 +51,15 @@ type StateStore interface {
 
 	// DeleteDeployment removes a deployment from local storage
 	DeleteDeployment(ctx context.Context, deploymentID string) error
+
+	// SaveSweep persists a hyperparameter sweep to local storage
+	SaveSweep(ctx context.Context, sweep *models.Sweep) error
+
+	// GetSweep retrieves a hyperparameter sweep from local storage
+	GetSweep(ctx context.Context, name string) (*models.Sweep, error)
+
+	// ListSweeps lists all locally tracked hyperparameter sweeps
+	ListSweeps(ctx context.Context) ([]*models.Sweep, error)
 }
 
 // ErrorTransformer defines the interface for transforming vendor-specific errors
@@ -49,3 +68,221 @@ type ErrorTransformer interface {
 	// TransformError converts a vendor-specific error to a standardized ErrorDetail
 	TransformError(vendorError error, vendorCode string) *models.ErrorDetail
 }
+
+// Ensure fileStateStore implements StateStore interface
+var _ StateStore = (*fileStateStore)(nil)
+
+// fileStateStore implements the StateStore interface with a JSON file
+type fileStateStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// stateFile is the content of the file of a fileStateStore
+type stateFile struct {
+	Jobs        map[string]*models.FineTuningJob `json:"jobs,omitempty"`
+	Deployments map[string]*models.Deployment    `json:"deployments,omitempty"`
+	Sweeps      map[string]*models.Sweep         `json:"sweeps,omitempty"`
+}
+
+// NewFileStateStore creates a StateStore persisting its state to a JSON file at path.
+// The file and its directory are created on the first save.
+func NewFileStateStore(path string) StateStore {
+	return &fileStateStore{path: path}
+}
+
+// SaveJob persists a job to local storage
+func (s *fileStateStore) SaveJob(ctx context.Context, job *models.FineTuningJob) error {
+	return s.update(func(state *stateFile) error {
+		state.Jobs[job.ID] = job
+		return nil
+	})
+}
+
+// GetJob retrieves a job from local storage
+func (s *fileStateStore) GetJob(ctx context.Context, jobID string) (*models.FineTuningJob, error) {
+	return getTracked(s, "job", jobID, func(state *stateFile) map[string]*models.FineTuningJob { return state.Jobs })
+}
+
+// ListJobs lists all locally tracked jobs
+func (s *fileStateStore) ListJobs(ctx context.Context) ([]*models.FineTuningJob, error) {
+	return listTracked(s, func(state *stateFile) map[string]*models.FineTuningJob { return state.Jobs })
+}
+
+// UpdateJobStatus updates the status of a tracked job
+func (s *fileStateStore) UpdateJobStatus(ctx context.Context, jobID string, status models.JobStatus) error {
+	return s.update(func(state *stateFile) error {
+		job, has := state.Jobs[jobID]
+		if !has {
+			return fmt.Errorf("job %s: %w", jobID, ErrNotTracked)
+		}
+		job.Status = status
+		return nil
+	})
+}
+
+// DeleteJob removes a job from local storage
+func (s *fileStateStore) DeleteJob(ctx context.Context, jobID string) error {
+	return s.update(func(state *stateFile) error {
+		delete(state.Jobs, jobID)
+		return nil
+	})
+}
+
+// SaveDeployment persists a deployment to local storage
+func (s *fileStateStore) SaveDeployment(ctx context.Context, deployment *models.Deployment) error {
+	return s.update(func(state *stateFile) error {
+		state.Deployments[deployment.ID] = deployment
+		return nil
+	})
+}
+
+// GetDeployment retrieves a deployment from local storage
+func (s *fileStateStore) GetDeployment(ctx context.Context, deploymentID string) (*models.Deployment, error) {
+	return getTracked(s, "deployment", deploymentID,
+		func(state *stateFile) map[string]*models.Deployment { return state.Deployments })
+}
+
+// ListDeployments lists all locally tracked deployments
+func (s *fileStateStore) ListDeployments(ctx context.Context) ([]*models.Deployment, error) {
+	return listTracked(s, func(state *stateFile) map[string]*models.Deployment { return state.Deployments })
+}
+
+// UpdateDeploymentStatus updates the status of a tracked deployment
+func (s *fileStateStore) UpdateDeploymentStatus(
+	ctx context.Context, deploymentID string, status models.DeploymentStatus,
+) error {
+	return s.update(func(state *stateFile) error {
+		deployment, has := state.Deployments[deploymentID]
+		if !has {
+			return fmt.Errorf("deployment %s: %w", deploymentID, ErrNotTracked)
+		}
+		deployment.Status = status
+		return nil
+	})
+}
+
+// DeleteDeployment removes a deployment from local storage
+func (s *fileStateStore) DeleteDeployment(ctx context.Context, deploymentID string) error {
+	return s.update(func(state *stateFile) error {
+		delete(state.Deployments, deploymentID)
+		return nil
+	})
+}
+
+// SaveSweep persists a hyperparameter sweep to local storage
+func (s *fileStateStore) SaveSweep(ctx context.Context, sweep *models.Sweep) error {
+	return s.update(func(state *stateFile) error {
+		state.Sweeps[sweep.Name] = sweep
+		return nil
+	})
+}
+
+// GetSweep retrieves a hyperparameter sweep from local storage
+func (s *fileStateStore) GetSweep(ctx context.Context, name string) (*models.Sweep, error) {
+	return getTracked(s, "sweep", name, func(state *stateFile) map[string]*models.Sweep { return state.Sweeps })
+}
+
+// ListSweeps lists all locally tracked hyperparameter sweeps
+func (s *fileStateStore) ListSweeps(ctx context.Context) ([]*models.Sweep, error) {
+	return listTracked(s, func(state *stateFile) map[string]*models.Sweep { return state.Sweeps })
+}
+
+// load reads the state file. A missing file is an empty state.
+func (s *fileStateStore) load() (*stateFile, error) {
+	state := &stateFile{}
+
+	//nolint:gosec // path is the state file of the current project environment
+	content, err := os.ReadFile(s.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read state file %s: %w", s.path, err)
+	}
+	if len(content) > 0 {
+		if err := json.Unmarshal(content, state); err != nil {
+			return nil, fmt.Errorf("failed to parse state file %s: %w", s.path, err)
+		}
+	}
+
+	if state.Jobs == nil {
+		state.Jobs = map[string]*models.FineTuningJob{}
+	}
+	if state.Deployments == nil {
+		state.Deployments = map[string]*models.Deployment{}
+	}
+	if state.Sweeps == nil {
+		state.Sweeps = map[string]*models.Sweep{}
+	}
+
+	return state, nil
+}
+
+// update applies a change to the state file and saves it
+func (s *fileStateStore) update(change func(state *stateFile) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.load()
+	if err != nil {
+		return err
+	}
+	if err := change(state); err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := os.WriteFile(s.path, content, 0600); err != nil {
+		return fmt.Errorf("failed to write state file %s: %w", s.path, err)
+	}
+
+	return nil
+}
+
+// getTracked returns an item of the state, or an ErrNotTracked error if there is none with the id
+func getTracked[T any](s *fileStateStore, kind string, id string, items func(*stateFile) map[string]*T) (*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	item, has := items(state)[id]
+	if !has {
+		return nil, fmt.Errorf("%s %s: %w", kind, id, ErrNotTracked)
+	}
+
+	return item, nil
+}
+
+// listTracked returns the items of a kind of the state, sorted by id
+func listTracked[T any](s *fileStateStore, items func(*stateFile) map[string]*T) ([]*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	tracked := items(state)
+	ids := make([]string, 0, len(tracked))
+	for id := range tracked {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	result := make([]*T, len(ids))
+	for i, id := range ids {
+		result[i] = tracked[id]
+	}
+
+	return result, nil
+}
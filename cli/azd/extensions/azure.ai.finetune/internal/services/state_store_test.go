@@ -0,0 +1,81 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package services
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"azure.ai.finetune/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStateStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "env", "finetuning", "state.json")
+	store := NewFileStateStore(path)
+	ctx := t.Context()
+
+	// A missing state file is an empty state
+	jobs, err := store.ListJobs(ctx)
+	require.NoError(t, err)
+	require.Empty(t, jobs)
+
+	_, err = store.GetJob(ctx, "ftjob-1")
+	require.ErrorIs(t, err, ErrNotTracked)
+
+	require.NoError(t, store.SaveJob(ctx, &models.FineTuningJob{ID: "ftjob-2", Status: models.StatusRunning}))
+	require.NoError(t, store.SaveJob(ctx, &models.FineTuningJob{ID: "ftjob-1", Status: models.StatusQueued}))
+	require.NoError(t, store.UpdateJobStatus(ctx, "ftjob-1", models.StatusSucceeded))
+	require.ErrorIs(t, store.UpdateJobStatus(ctx, "ftjob-3", models.StatusSucceeded), ErrNotTracked)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	if filepath.Separator == '/' {
+		require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// The state is read back by other stores of the same file, ordered by ID
+	jobs, err = NewFileStateStore(path).ListJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+	require.Equal(t, "ftjob-1", jobs[0].ID)
+	require.Equal(t, models.StatusSucceeded, jobs[0].Status)
+
+	require.NoError(t, store.DeleteJob(ctx, "ftjob-2"))
+	jobs, err = store.ListJobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+
+	require.NoError(t, store.SaveDeployment(ctx, &models.Deployment{ID: "deployment-1"}))
+	require.NoError(t, store.UpdateDeploymentStatus(ctx, "deployment-1", models.DeploymentActive))
+	deployment, err := store.GetDeployment(ctx, "deployment-1")
+	require.NoError(t, err)
+	require.Equal(t, models.DeploymentActive, deployment.Status)
+
+	sweep := &models.Sweep{
+		Name:   "lr-sweep",
+		Status: models.SweepStatusRunning,
+		Runs:   []*models.SweepRun{{JobID: "ftjob-1", Status: models.StatusQueued}},
+	}
+	require.NoError(t, store.SaveSweep(ctx, sweep))
+	tracked, err := store.GetSweep(ctx, "lr-sweep")
+	require.NoError(t, err)
+	require.Equal(t, sweep.Runs, tracked.Runs)
+
+	sweeps, err := store.ListSweeps(ctx)
+	require.NoError(t, err)
+	require.Len(t, sweeps, 1)
+
+	_, err = store.GetSweep(ctx, "other")
+	require.ErrorIs(t, err, ErrNotTracked)
+}
+
+func TestFileStateStore_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+
+	_, err := NewFileStateStore(path).ListJobs(t.Context())
+	require.ErrorContains(t, err, "failed to parse state file")
+}
@@ -0,0 +1,388 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/sethvargo/go-retry"
+
+	"azure.ai.finetune/internal/providers"
+	"azure.ai.finetune/internal/providers/factory"
+	"azure.ai.finetune/internal/utils"
+	"azure.ai.finetune/pkg/models"
+)
+
+const (
+	// DefaultSweepPollInterval is the default initial interval between polls of the jobs of a sweep
+	DefaultSweepPollInterval = 30 * time.Second
+	// DefaultSweepMaxPollInterval is the default longest interval between polls of the jobs of a sweep
+	DefaultSweepMaxPollInterval = 5 * time.Minute
+
+	// sweepMetadataKey is the metadata key identifying the sweep of a job
+	sweepMetadataKey = "sweep"
+	// maxJobMetadata is the maximum number of metadata key-value pairs of a job
+	maxJobMetadata = 16
+)
+
+// errSweepRunning is returned by polls while jobs of the sweep are still running
+var errSweepRunning = errors.New("sweep jobs are still running")
+
+// SweepWaitOptions configures WaitForSweep
+type SweepWaitOptions struct {
+	// PollInterval is the initial interval between polls, doubled after each poll.
+	// Defaults to DefaultSweepPollInterval.
+	PollInterval time.Duration
+	// MaxPollInterval is the longest interval between polls. Defaults to DefaultSweepMaxPollInterval.
+	MaxPollInterval time.Duration
+	// OnPoll is called with the sweep after each poll, when set
+	OnPoll func(sweep *models.Sweep)
+}
+
+// Ensure sweepServiceImpl implements SweepService interface
+var _ SweepService = (*sweepServiceImpl)(nil)
+
+// sweepServiceImpl implements the SweepService interface
+type sweepServiceImpl struct {
+	providerFactory factory.ProviderFactory
+	provider        providers.FineTuningProvider
+	stateStore      StateStore
+}
+
+// NewSweepService creates a new instance of SweepService with the providers of providerFactory
+func NewSweepService(
+	ctx context.Context,
+	providerFactory factory.ProviderFactory,
+	stateStore StateStore,
+) (SweepService, error) {
+	if stateStore == nil {
+		return nil, fmt.Errorf("sweeps require a state store")
+	}
+
+	provider, err := providerFactory.NewFineTuningProvider(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize sweep service: %w", err)
+	}
+
+	return &sweepServiceImpl{
+		providerFactory: providerFactory,
+		provider:        provider,
+		stateStore:      stateStore,
+	}, nil
+}
+
+// SubmitSweep submits a fine-tuning job for each hyperparameter combination of a sweep
+// and tracks the sweep and its jobs in the state store
+func (s *sweepServiceImpl) SubmitSweep(ctx context.Context, spec *models.SweepSpec) (*models.Sweep, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("sweep cannot be nil")
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid sweep: %w", err)
+	}
+
+	if _, err := s.stateStore.GetSweep(ctx, spec.Name); err == nil {
+		return nil, fmt.Errorf("sweep '%s' already exists, use another name to submit a new sweep", spec.Name)
+	} else if !errors.Is(err, ErrNotTracked) {
+		return nil, err
+	}
+
+	// Upload local files once for all the jobs of the sweep
+	base := *spec.Job
+	trainingFile, err := s.uploadLocalFile(ctx, base.TrainingFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload training file: %w", err)
+	}
+	validationFile, err := s.uploadLocalFile(ctx, *base.ValidationFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload validation file: %w", err)
+	}
+	base.TrainingFile = trainingFile
+	base.ValidationFile = &validationFile
+
+	metric := spec.Metric
+	if metric == "" {
+		metric = models.MetricValidLoss
+	}
+
+	sweep := &models.Sweep{
+		Name:      spec.Name,
+		Status:    models.SweepStatusRunning,
+		Metric:    metric,
+		CreatedAt: time.Now().UTC(),
+		Runs:      []*models.SweepRun{},
+		Deploy:    spec.Deploy,
+	}
+
+	for i, hyperparameters := range spec.Hyperparameters {
+		req := sweepJobRequest(&base, spec.Name, hyperparameters)
+
+		var job *models.FineTuningJob
+		err := utils.RetryOperation(ctx, utils.DefaultRetryConfig(), func() error {
+			var err error
+			job, err = s.provider.CreateFineTuningJob(ctx, req)
+			return err
+		})
+		if err != nil {
+			// Keep tracking the jobs already submitted
+			if len(sweep.Runs) > 0 {
+				_ = s.stateStore.SaveSweep(ctx, sweep)
+			}
+			return nil, fmt.Errorf("failed to submit job %d of sweep '%s': %w", i+1, spec.Name, err)
+		}
+
+		sweep.Runs = append(sweep.Runs, &models.SweepRun{
+			JobID:           job.ID,
+			Hyperparameters: hyperparameters.String(),
+			Status:          job.Status,
+		})
+
+		if err := s.stateStore.SaveJob(ctx, job); err != nil {
+			return nil, fmt.Errorf("failed to persist job: %w", err)
+		}
+	}
+
+	if err := s.stateStore.SaveSweep(ctx, sweep); err != nil {
+		return nil, fmt.Errorf("failed to persist sweep: %w", err)
+	}
+
+	return sweep, nil
+}
+
+// GetSweep retrieves a sweep tracked in the state store
+func (s *sweepServiceImpl) GetSweep(ctx context.Context, name string) (*models.Sweep, error) {
+	return s.stateStore.GetSweep(ctx, name)
+}
+
+// WaitForSweep polls the jobs of a sweep, backing off between polls, until they all finish
+func (s *sweepServiceImpl) WaitForSweep(ctx context.Context, sweep *models.Sweep, options SweepWaitOptions) error {
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultSweepPollInterval
+	}
+	if options.MaxPollInterval <= 0 {
+		options.MaxPollInterval = DefaultSweepMaxPollInterval
+	}
+
+	backoff := retry.WithCappedDuration(options.MaxPollInterval, retry.NewExponential(options.PollInterval))
+
+	return retry.Do(ctx, backoff, func(ctx context.Context) error {
+		running, err := s.refreshSweep(ctx, sweep)
+		if err != nil {
+			return err
+		}
+
+		if options.OnPoll != nil {
+			options.OnPoll(sweep)
+		}
+
+		if running > 0 {
+			return retry.RetryableError(errSweepRunning)
+		}
+		return nil
+	})
+}
+
+// refreshSweep updates the status of the unfinished jobs of a sweep, returning how many are still running
+func (s *sweepServiceImpl) refreshSweep(ctx context.Context, sweep *models.Sweep) (int, error) {
+	running := 0
+	for _, run := range sweep.Runs {
+		if utils.IsTerminalStatus(run.Status) {
+			continue
+		}
+
+		var job *models.FineTuningJob
+		err := utils.RetryOperation(ctx, utils.DefaultRetryConfig(), func() error {
+			var err error
+			job, err = s.provider.GetFineTuningStatus(ctx, run.JobID)
+			return err
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to get status of job %s: %w", run.JobID, err)
+		}
+
+		run.Status = job.Status
+		run.FineTunedModel = job.FineTunedModel
+		if err := s.stateStore.SaveJob(ctx, job); err != nil {
+			return 0, fmt.Errorf("failed to persist job: %w", err)
+		}
+
+		if !utils.IsTerminalStatus(run.Status) {
+			running++
+		}
+	}
+
+	if running == 0 && sweep.Status == models.SweepStatusRunning {
+		sweep.Status = models.SweepStatusCompleted
+	}
+
+	if err := s.stateStore.SaveSweep(ctx, sweep); err != nil {
+		return 0, fmt.Errorf("failed to persist sweep: %w", err)
+	}
+
+	return running, nil
+}
+
+// SelectBestCheckpoint compares the checkpoints of the succeeded jobs of a sweep on its metric
+func (s *sweepServiceImpl) SelectBestCheckpoint(
+	ctx context.Context,
+	sweep *models.Sweep,
+) (*models.SweepCheckpoint, error) {
+	var best *models.SweepCheckpoint
+	succeeded := 0
+
+	for _, run := range sweep.Runs {
+		if run.Status != models.StatusSucceeded {
+			continue
+		}
+		succeeded++
+
+		var checkpoints *models.JobCheckpointsList
+		err := utils.RetryOperation(ctx, utils.DefaultRetryConfig(), func() error {
+			var err error
+			checkpoints, err = s.provider.GetJobCheckpoints(ctx, run.JobID)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get checkpoints of job %s: %w", run.JobID, err)
+		}
+
+		for _, checkpoint := range checkpoints.Data {
+			value, has := sweep.Metric.Value(checkpoint.Metrics)
+			if !has || (best != nil && !sweep.Metric.Better(value, best.Value)) {
+				continue
+			}
+
+			best = &models.SweepCheckpoint{
+				JobID:           run.JobID,
+				CheckpointID:    checkpoint.ID,
+				Model:           checkpoint.FineTunedModelCheckpoint,
+				StepNumber:      checkpoint.StepNumber,
+				Hyperparameters: run.Hyperparameters,
+				Value:           value,
+			}
+		}
+	}
+
+	if succeeded == 0 {
+		return nil, fmt.Errorf("no job of sweep '%s' succeeded", sweep.Name)
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no checkpoint of sweep '%s' has %s metrics", sweep.Name, sweep.Metric)
+	}
+
+	sweep.Best = best
+	if err := s.stateStore.SaveSweep(ctx, sweep); err != nil {
+		return nil, fmt.Errorf("failed to persist sweep: %w", err)
+	}
+
+	return best, nil
+}
+
+// DeployBestCheckpoint deploys the best checkpoint of a sweep with the settings of target
+func (s *sweepServiceImpl) DeployBestCheckpoint(
+	ctx context.Context,
+	sweep *models.Sweep,
+	target *models.DeploymentRequest,
+) (*models.DeployModelResult, error) {
+	if sweep.Best == nil {
+		return nil, fmt.Errorf("sweep '%s' has no best checkpoint to deploy", sweep.Name)
+	}
+	if target == nil || target.DeploymentName == "" {
+		return nil, fmt.Errorf("deployment name must be provided")
+	}
+
+	deployProvider, err := s.providerFactory.NewModelDeploymentProvider(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deployment provider: %w", err)
+	}
+
+	req := *target
+	req.ModelName = sweep.Best.Model
+
+	result, err := deployProvider.DeployModel(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deploy model: %w", err)
+	}
+
+	sweep.Status = models.SweepStatusDeployed
+	sweep.Deployment = req.DeploymentName
+	if err := s.stateStore.SaveSweep(ctx, sweep); err != nil {
+		return nil, fmt.Errorf("failed to persist sweep: %w", err)
+	}
+
+	return result, nil
+}
+
+// uploadLocalFile uploads a 'local:' file, returning its file ID. Other file IDs are returned as is.
+func (s *sweepServiceImpl) uploadLocalFile(ctx context.Context, fileID string) (string, error) {
+	if !utils.IsLocalFilePath(fileID) {
+		return fileID, nil
+	}
+
+	var uploadedFileID string
+	err := utils.RetryOperation(ctx, utils.DefaultRetryConfig(), func() error {
+		var err error
+		uploadedFileID, err = s.provider.UploadFile(ctx, utils.GetLocalFilePath(fileID))
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return uploadedFileID, nil
+}
+
+// sweepJobRequest returns the request of the job of a sweep for a hyperparameter combination,
+// applying the combination to the hyperparameters of the method of the base request
+func sweepJobRequest(
+	base *models.CreateFineTuningRequest,
+	sweepName string,
+	hyperparameters models.HyperparametersConfig,
+) *models.CreateFineTuningRequest {
+	req := *base
+	method := base.Method
+	if method.Type == "" {
+		method.Type = string(models.Supervised)
+	}
+
+	switch models.MethodType(method.Type) {
+	case models.DPO:
+		config := models.DPOConfig{}
+		if method.DPO != nil {
+			config = *method.DPO
+		}
+		config.Hyperparameters = config.Hyperparameters.Merge(hyperparameters)
+		method.DPO = &config
+	case models.Reinforcement:
+		config := models.ReinforcementConfig{}
+		if method.Reinforcement != nil {
+			config = *method.Reinforcement
+		}
+		config.Hyperparameters = config.Hyperparameters.Merge(hyperparameters)
+		method.Reinforcement = &config
+	default:
+		config := models.SupervisedConfig{}
+		if method.Supervised != nil {
+			config = *method.Supervised
+		}
+		config.Hyperparameters = config.Hyperparameters.Merge(hyperparameters)
+		method.Supervised = &config
+	}
+	req.Method = method
+
+	// Tag the jobs with the sweep, when the metadata has room for it
+	req.Metadata = maps.Clone(base.Metadata)
+	if len(req.Metadata) < maxJobMetadata {
+		if req.Metadata == nil {
+			req.Metadata = map[string]string{}
+		}
+		req.Metadata[sweepMetadataKey] = sweepName
+	}
+
+	return &req
+}
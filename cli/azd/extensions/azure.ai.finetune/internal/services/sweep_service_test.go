@@ -0,0 +1,337 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"azure.ai.finetune/internal/providers/factory"
+	"azure.ai.finetune/pkg/models"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSweepProvider fakes the fine-tuning jobs of a sweep, which succeed after a number of polls
+type fakeSweepProvider struct {
+	requests    []*models.CreateFineTuningRequest
+	uploads     []string
+	polls       map[string]int
+	pollsToDone int
+	status      map[string]models.JobStatus
+	checkpoints map[string][]models.JobCheckpoint
+}
+
+func newFakeSweepProvider(pollsToDone int) (*fakeSweepProvider, *MockFineTuningProvider) {
+	fake := &fakeSweepProvider{
+		polls:       map[string]int{},
+		pollsToDone: pollsToDone,
+		status:      map[string]models.JobStatus{},
+		checkpoints: map[string][]models.JobCheckpoint{},
+	}
+
+	return fake, &MockFineTuningProvider{
+		UploadFileFunc: func(ctx context.Context, filePath string) (string, error) {
+			fake.uploads = append(fake.uploads, filePath)
+			return "file-" + filepath.Base(filePath), nil
+		},
+		CreateFineTuningJobFunc: func(
+			ctx context.Context, req *models.CreateFineTuningRequest) (*models.FineTuningJob, error) {
+			fake.requests = append(fake.requests, req)
+			return &models.FineTuningJob{
+				ID:     fmt.Sprintf("ftjob-%d", len(fake.requests)),
+				Status: models.StatusQueued,
+			}, nil
+		},
+		GetFineTuningStatusFunc: func(ctx context.Context, jobID string) (*models.FineTuningJob, error) {
+			fake.polls[jobID]++
+			job := &models.FineTuningJob{ID: jobID, Status: models.StatusRunning}
+			if fake.polls[jobID] >= fake.pollsToDone {
+				job.Status = models.StatusSucceeded
+				if status, has := fake.status[jobID]; has {
+					job.Status = status
+				}
+				job.FineTunedModel = "ft:" + jobID
+			}
+			return job, nil
+		},
+		GetJobCheckpointsFunc: func(ctx context.Context, jobID string) (*models.JobCheckpointsList, error) {
+			return &models.JobCheckpointsList{Data: fake.checkpoints[jobID]}, nil
+		},
+	}
+}
+
+func checkpoint(id string, step int64, loss float64, accuracy float64) models.JobCheckpoint {
+	return models.JobCheckpoint{
+		ID:                       id,
+		FineTunedModelCheckpoint: "ft:" + id,
+		StepNumber:               step,
+		Metrics:                  &models.CheckpointMetrics{FullValidLoss: loss, FullValidMeanTokenAccuracy: accuracy},
+	}
+}
+
+func testSweepSpec() *models.SweepSpec {
+	validationFile := "local:./data/validation.jsonl"
+	return &models.SweepSpec{
+		Name: "lr-sweep",
+		Job: &models.CreateFineTuningRequest{
+			BaseModel:      "gpt-4o-mini",
+			TrainingFile:   "local:./data/training.jsonl",
+			ValidationFile: &validationFile,
+			Metadata:       map[string]string{"project": "sweeps"},
+		},
+		Hyperparameters: []models.HyperparametersConfig{
+			{LearningRateMultiplier: 0.5},
+			{LearningRateMultiplier: 2.0, Epochs: 2},
+		},
+	}
+}
+
+func newTestSweepService(
+	t *testing.T,
+	provider *MockFineTuningProvider,
+	deployProvider *MockModelDeploymentProvider,
+) (SweepService, StateStore) {
+	stateStore := NewFileStateStore(filepath.Join(t.TempDir(), "state.json"))
+	providerFactory := &factory.StaticProviderFactory{FineTuning: provider}
+	if deployProvider != nil {
+		providerFactory.ModelDeployment = deployProvider
+	}
+
+	svc, err := NewSweepService(t.Context(), providerFactory, stateStore)
+	require.NoError(t, err)
+	return svc, stateStore
+}
+
+var testSweepWaitOptions = SweepWaitOptions{PollInterval: time.Millisecond, MaxPollInterval: 2 * time.Millisecond}
+
+func TestNewSweepService_RequiresStateStore(t *testing.T) {
+	_, err := NewSweepService(t.Context(), &factory.StaticProviderFactory{FineTuning: &MockFineTuningProvider{}}, nil)
+	require.ErrorContains(t, err, "sweeps require a state store")
+}
+
+func TestSweepService_SubmitSweep(t *testing.T) {
+	fake, provider := newFakeSweepProvider(1)
+	svc, stateStore := newTestSweepService(t, provider, nil)
+
+	spec := testSweepSpec()
+	spec.Job.Method = models.MethodConfig{
+		Supervised: &models.SupervisedConfig{
+			Hyperparameters: models.HyperparametersConfig{Epochs: 3, BatchSize: 8},
+		},
+	}
+
+	sweep, err := svc.SubmitSweep(t.Context(), spec)
+	require.NoError(t, err)
+
+	// Local files are uploaded once for all the jobs
+	require.Equal(t, []string{"./data/training.jsonl", "./data/validation.jsonl"}, fake.uploads)
+	require.Len(t, fake.requests, 2)
+	for _, req := range fake.requests {
+		require.Equal(t, "file-training.jsonl", req.TrainingFile)
+		require.Equal(t, "file-validation.jsonl", *req.ValidationFile)
+		require.Equal(t, string(models.Supervised), req.Method.Type)
+		require.Equal(t, map[string]string{"project": "sweeps", "sweep": "lr-sweep"}, req.Metadata)
+	}
+
+	// The combinations override the hyperparameters of the job
+	require.Equal(t,
+		models.HyperparametersConfig{Epochs: 3, BatchSize: 8, LearningRateMultiplier: 0.5},
+		fake.requests[0].Method.Supervised.Hyperparameters)
+	require.Equal(t,
+		models.HyperparametersConfig{Epochs: 2, BatchSize: 8, LearningRateMultiplier: 2.0},
+		fake.requests[1].Method.Supervised.Hyperparameters)
+	require.Equal(t, map[string]string{"project": "sweeps"}, spec.Job.Metadata)
+	require.Equal(t, 3, spec.Job.Method.Supervised.Hyperparameters.Epochs)
+
+	require.Equal(t, models.SweepStatusRunning, sweep.Status)
+	require.Equal(t, models.MetricValidLoss, sweep.Metric)
+	require.Equal(t, []*models.SweepRun{
+		{JobID: "ftjob-1", Hyperparameters: "learning_rate_multiplier=0.5", Status: models.StatusQueued},
+		{JobID: "ftjob-2", Hyperparameters: "epochs=2, learning_rate_multiplier=2", Status: models.StatusQueued},
+	}, sweep.Runs)
+
+	// The sweep and its jobs are tracked
+	tracked, err := svc.GetSweep(t.Context(), "lr-sweep")
+	require.NoError(t, err)
+	require.Equal(t, sweep.Runs, tracked.Runs)
+	jobs, err := stateStore.ListJobs(t.Context())
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	_, err = svc.SubmitSweep(t.Context(), testSweepSpec())
+	require.ErrorContains(t, err, "sweep 'lr-sweep' already exists")
+}
+
+func TestSweepService_SubmitSweep_Invalid(t *testing.T) {
+	_, provider := newFakeSweepProvider(1)
+	svc, _ := newTestSweepService(t, provider, nil)
+
+	spec := testSweepSpec()
+	spec.Job.ValidationFile = nil
+
+	_, err := svc.SubmitSweep(t.Context(), spec)
+	require.ErrorContains(t, err, "validation_file is required")
+}
+
+func TestSweepService_WaitForSweep(t *testing.T) {
+	fake, provider := newFakeSweepProvider(3)
+	fake.status["ftjob-2"] = models.StatusFailed
+	svc, stateStore := newTestSweepService(t, provider, nil)
+
+	sweep, err := svc.SubmitSweep(t.Context(), testSweepSpec())
+	require.NoError(t, err)
+
+	polls := 0
+	options := testSweepWaitOptions
+	options.OnPoll = func(*models.Sweep) { polls++ }
+
+	require.NoError(t, svc.WaitForSweep(t.Context(), sweep, options))
+	require.Equal(t, 3, polls)
+	require.Equal(t, models.SweepStatusCompleted, sweep.Status)
+	require.Equal(t, models.StatusSucceeded, sweep.Runs[0].Status)
+	require.Equal(t, "ft:ftjob-1", sweep.Runs[0].FineTunedModel)
+	require.Equal(t, models.StatusFailed, sweep.Runs[1].Status)
+
+	tracked, err := stateStore.GetSweep(t.Context(), "lr-sweep")
+	require.NoError(t, err)
+	require.Equal(t, models.SweepStatusCompleted, tracked.Status)
+	job, err := stateStore.GetJob(t.Context(), "ftjob-2")
+	require.NoError(t, err)
+	require.Equal(t, models.StatusFailed, job.Status)
+
+	// Finished jobs aren't polled again
+	require.NoError(t, svc.WaitForSweep(t.Context(), sweep, options))
+	require.Equal(t, 3, fake.polls["ftjob-1"])
+}
+
+func TestSweepService_WaitForSweep_Canceled(t *testing.T) {
+	_, provider := newFakeSweepProvider(1000)
+	svc, _ := newTestSweepService(t, provider, nil)
+
+	sweep, err := svc.SubmitSweep(t.Context(), testSweepSpec())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	err = svc.WaitForSweep(ctx, sweep, testSweepWaitOptions)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Equal(t, models.SweepStatusRunning, sweep.Status)
+}
+
+func TestSweepService_SelectBestCheckpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		metric   models.SweepMetric
+		expected string
+	}{
+		{name: "LowestLoss", metric: models.MetricValidLoss, expected: "checkpoint-2b"},
+		{name: "HighestAccuracy", metric: models.MetricValidMeanTokenAccuracy, expected: "checkpoint-1b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, provider := newFakeSweepProvider(1)
+			fake.checkpoints["ftjob-1"] = []models.JobCheckpoint{
+				checkpoint("checkpoint-1a", 10, 0.9, 0.70),
+				checkpoint("checkpoint-1b", 20, 0.6, 0.85),
+			}
+			fake.checkpoints["ftjob-2"] = []models.JobCheckpoint{
+				checkpoint("checkpoint-2a", 10, 0.7, 0.75),
+				checkpoint("checkpoint-2b", 20, 0.4, 0.80),
+				// Checkpoints without validation metrics are ignored
+				{ID: "checkpoint-2c", StepNumber: 30},
+			}
+			svc, _ := newTestSweepService(t, provider, nil)
+
+			spec := testSweepSpec()
+			spec.Metric = tt.metric
+			sweep, err := svc.SubmitSweep(t.Context(), spec)
+			require.NoError(t, err)
+			require.NoError(t, svc.WaitForSweep(t.Context(), sweep, testSweepWaitOptions))
+
+			best, err := svc.SelectBestCheckpoint(t.Context(), sweep)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, best.CheckpointID)
+			require.Equal(t, "ft:"+tt.expected, best.Model)
+			require.Equal(t, best, sweep.Best)
+
+			tracked, err := svc.GetSweep(t.Context(), "lr-sweep")
+			require.NoError(t, err)
+			require.Equal(t, best, tracked.Best)
+		})
+	}
+}
+
+func TestSweepService_SelectBestCheckpoint_Errors(t *testing.T) {
+	t.Run("NoSucceededJob", func(t *testing.T) {
+		fake, provider := newFakeSweepProvider(1)
+		fake.status["ftjob-1"] = models.StatusFailed
+		fake.status["ftjob-2"] = models.StatusCancelled
+		svc, _ := newTestSweepService(t, provider, nil)
+
+		sweep, err := svc.SubmitSweep(t.Context(), testSweepSpec())
+		require.NoError(t, err)
+		require.NoError(t, svc.WaitForSweep(t.Context(), sweep, testSweepWaitOptions))
+
+		_, err = svc.SelectBestCheckpoint(t.Context(), sweep)
+		require.ErrorContains(t, err, "no job of sweep 'lr-sweep' succeeded")
+	})
+
+	t.Run("NoMetrics", func(t *testing.T) {
+		fake, provider := newFakeSweepProvider(1)
+		fake.checkpoints["ftjob-1"] = []models.JobCheckpoint{{ID: "checkpoint-1a"}}
+		svc, _ := newTestSweepService(t, provider, nil)
+
+		sweep, err := svc.SubmitSweep(t.Context(), testSweepSpec())
+		require.NoError(t, err)
+		require.NoError(t, svc.WaitForSweep(t.Context(), sweep, testSweepWaitOptions))
+
+		_, err = svc.SelectBestCheckpoint(t.Context(), sweep)
+		require.ErrorContains(t, err, "has full_valid_loss metrics")
+	})
+}
+
+func TestSweepService_DeployBestCheckpoint(t *testing.T) {
+	fake, provider := newFakeSweepProvider(1)
+	fake.checkpoints["ftjob-1"] = []models.JobCheckpoint{checkpoint("checkpoint-1a", 10, 0.5, 0.8)}
+
+	var deployed *models.DeploymentRequest
+	deployProvider := &MockModelDeploymentProvider{
+		DeployModelFunc: func(ctx context.Context, req *models.DeploymentRequest) (*models.DeployModelResult, error) {
+			deployed = req
+			return &models.DeployModelResult{
+				Deployment: models.Deployment{Name: req.DeploymentName},
+				Status:     "succeeded",
+			}, nil
+		},
+	}
+	svc, _ := newTestSweepService(t, provider, deployProvider)
+
+	sweep, err := svc.SubmitSweep(t.Context(), testSweepSpec())
+	require.NoError(t, err)
+
+	target := &models.DeploymentRequest{DeploymentName: "best", SKU: "GlobalStandard", Capacity: 1}
+	_, err = svc.DeployBestCheckpoint(t.Context(), sweep, target)
+	require.ErrorContains(t, err, "has no best checkpoint to deploy")
+
+	require.NoError(t, svc.WaitForSweep(t.Context(), sweep, testSweepWaitOptions))
+	_, err = svc.SelectBestCheckpoint(t.Context(), sweep)
+	require.NoError(t, err)
+
+	result, err := svc.DeployBestCheckpoint(t.Context(), sweep, target)
+	require.NoError(t, err)
+	require.Equal(t, "best", result.Deployment.Name)
+	require.Equal(t, "ft:checkpoint-1a", deployed.ModelName)
+	require.Equal(t, "GlobalStandard", deployed.SKU)
+	require.Empty(t, target.ModelName)
+
+	tracked, err := svc.GetSweep(t.Context(), "lr-sweep")
+	require.NoError(t, err)
+	require.Equal(t, models.SweepStatusDeployed, tracked.Status)
+	require.Equal(t, "best", tracked.Deployment)
+}
@@ -0,0 +1,401 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"azure.ai.finetune/pkg/models"
+)
+
+const (
+	// MinDatasetExamples is the minimum number of examples the service accepts in a training file
+	MinDatasetExamples = 10
+
+	// tokensPerMessage and tokensPerExample approximate the tokens the chat format adds
+	// around each message and each example
+	tokensPerMessage = 3
+	tokensPerExample = 3
+
+	// maxDatasetLineSize is the largest line read from a dataset
+	maxDatasetLineSize = 64 * 1024 * 1024
+)
+
+// datasetChatRoles are the roles accepted in the messages of chat examples
+var datasetChatRoles = map[string]bool{
+	"system":    true,
+	"user":      true,
+	"assistant": true,
+	"tool":      true,
+}
+
+// piiPatterns are the patterns of content that looks like personally identifiable information
+var piiPatterns = []struct {
+	name    string
+	pattern *regexp.Regexp
+	check   func(match string) bool
+}{
+	{name: "email address", pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)},
+	{name: "phone number", pattern: regexp.MustCompile(`(?:\+\d{1,3}[ .-]?)?\(?\b\d{3}\)?[ .-]\d{3}[ .-]\d{4}\b`)},
+	{name: "social security number", pattern: regexp.MustCompile(`\b\d{3}-\d{2}-\d{4}\b`)},
+	{name: "credit card number", pattern: regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`), check: isLuhnValid},
+	{
+		name:    "IP address",
+		pattern: regexp.MustCompile(`\b(?:(?:25[0-5]|2[0-4]\d|1?\d?\d)\.){3}(?:25[0-5]|2[0-4]\d|1?\d?\d)\b`),
+	},
+}
+
+// DatasetValidationOptions configures the checks of ValidateDataset
+type DatasetValidationOptions struct {
+	// Format is the expected format of the examples. Detected from the first example when empty.
+	Format models.DatasetFormat
+	// MaxTokens is the maximum estimated tokens of an example. Not checked when zero.
+	MaxTokens int
+}
+
+// datasetChatMessage is a message of a chat example
+type datasetChatMessage struct {
+	Role      string          `json:"role"`
+	Content   json.RawMessage `json:"content"`
+	ToolCalls json.RawMessage `json:"tool_calls"`
+	Weight    *float64        `json:"weight"`
+}
+
+// datasetExample is the parsed content of an example used to estimate tokens and detect PII
+type datasetExample struct {
+	texts  []string
+	tokens int
+}
+
+// ValidateDataset validates a JSONL fine-tuning dataset without uploading it. Problems with the
+// format of the examples are reported as errors with their line numbers, while duplicate examples,
+// PII-like content and examples over the token limit are reported as warnings.
+func ValidateDataset(path string, options DatasetValidationOptions) (*models.DatasetReport, error) {
+	//nolint:gosec // path is an explicit user-provided dataset path
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset %s: %w", path, err)
+	}
+	defer file.Close()
+
+	report := &models.DatasetReport{
+		Path:       path,
+		Format:     options.Format,
+		Errors:     []models.DatasetIssue{},
+		Warnings:   []models.DatasetIssue{},
+		Duplicates: []models.DatasetIssue{},
+		PII:        []models.DatasetIssue{},
+	}
+	seen := map[string]int{}
+
+	err = scanDatasetLines(file, func(line int, text []byte) {
+		addError := func(format string, args ...any) {
+			report.Errors = append(report.Errors, models.DatasetIssue{Line: line, Message: fmt.Sprintf(format, args...)})
+		}
+
+		if len(bytes.TrimSpace(text)) == 0 {
+			addError("empty line")
+			return
+		}
+
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(text, &fields); err != nil {
+			addError("invalid JSON: %v", err)
+			return
+		}
+
+		if report.Format == "" {
+			report.Format = detectDatasetFormat(fields)
+			if report.Format == "" {
+				addError("unrecognized example, expected 'messages' (chat) or 'prompt' and 'completion' (completion)")
+				return
+			}
+		}
+
+		var example *datasetExample
+		var problems []string
+		switch report.Format {
+		case models.DatasetFormatChat:
+			example, problems = parseChatExample(fields)
+		case models.DatasetFormatCompletion:
+			example, problems = parseCompletionExample(fields)
+		default:
+			problems = []string{fmt.Sprintf("unsupported dataset format '%s'", report.Format)}
+		}
+
+		for _, problem := range problems {
+			addError("%s", problem)
+		}
+		if len(problems) > 0 {
+			return
+		}
+
+		report.Examples++
+		report.EstimatedTokens += example.tokens
+		report.MaxExampleTokens = max(report.MaxExampleTokens, example.tokens)
+
+		if options.MaxTokens > 0 && example.tokens > options.MaxTokens {
+			report.Warnings = append(report.Warnings, models.DatasetIssue{
+				Line: line,
+				Message: fmt.Sprintf(
+					"example has about %d tokens, more than the limit of %d, and will be truncated",
+					example.tokens, options.MaxTokens),
+			})
+		}
+
+		key := canonicalDatasetExample(text)
+		if first, has := seen[key]; has {
+			report.Duplicates = append(report.Duplicates, models.DatasetIssue{
+				Line:    line,
+				Message: fmt.Sprintf("duplicate of line %d", first),
+			})
+		} else {
+			seen[key] = line
+		}
+
+		for _, kind := range detectPII(example.texts) {
+			report.PII = append(report.PII, models.DatasetIssue{Line: line, Message: "possible " + kind})
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset %s: %w", path, err)
+	}
+
+	if report.Examples < MinDatasetExamples {
+		report.Warnings = append(report.Warnings, models.DatasetIssue{
+			Message: fmt.Sprintf(
+				"dataset has %d valid examples, fine-tuning requires at least %d", report.Examples, MinDatasetExamples),
+		})
+	}
+
+	return report, nil
+}
+
+// EstimateTokens approximates the number of tokens of a text with the common heuristic of
+// four characters per token. The estimate is meant for sizing datasets, not for billing.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// scanDatasetLines calls fn with each line of a JSONL dataset and its 1-based line number
+func scanDatasetLines(r io.Reader, fn func(line int, text []byte)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxDatasetLineSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		fn(line, scanner.Bytes())
+	}
+
+	return scanner.Err()
+}
+
+// detectDatasetFormat returns the format of an example from its fields, or an empty format
+// if the example doesn't match any
+func detectDatasetFormat(fields map[string]json.RawMessage) models.DatasetFormat {
+	if _, has := fields["messages"]; has {
+		return models.DatasetFormatChat
+	}
+	if _, has := fields["prompt"]; has {
+		return models.DatasetFormatCompletion
+	}
+	return ""
+}
+
+// parseChatExample validates an example in the chat format, returning its problems
+func parseChatExample(fields map[string]json.RawMessage) (*datasetExample, []string) {
+	rawMessages, has := fields["messages"]
+	if !has {
+		return nil, []string{"missing 'messages'"}
+	}
+
+	var messages []datasetChatMessage
+	if err := json.Unmarshal(rawMessages, &messages); err != nil {
+		return nil, []string{"'messages' must be a list of objects with 'role' and 'content'"}
+	}
+	if len(messages) == 0 {
+		return nil, []string{"'messages' is empty"}
+	}
+
+	example := &datasetExample{tokens: tokensPerExample}
+	var problems []string
+	hasAssistant := false
+
+	for i, message := range messages {
+		prefix := fmt.Sprintf("message %d", i+1)
+
+		if message.Role == "" {
+			problems = append(problems, prefix+": missing 'role'")
+		} else if !datasetChatRoles[message.Role] {
+			problems = append(problems, fmt.Sprintf("%s: unsupported role '%s'", prefix, message.Role))
+		}
+
+		hasToolCalls := len(message.ToolCalls) > 0 && string(message.ToolCalls) != "null"
+		content, err := chatMessageContent(message.Content)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("%s: %v", prefix, err))
+		case content == "" && !(message.Role == "assistant" && hasToolCalls):
+			problems = append(problems, prefix+": missing 'content'")
+		}
+
+		if message.Weight != nil {
+			if message.Role != "assistant" {
+				problems = append(problems, prefix+": 'weight' is only supported on assistant messages")
+			} else if *message.Weight != 0 && *message.Weight != 1 {
+				problems = append(problems, prefix+": 'weight' must be 0 or 1")
+			}
+		}
+
+		if message.Role == "assistant" {
+			hasAssistant = true
+		}
+
+		example.texts = append(example.texts, content)
+		example.tokens += tokensPerMessage + EstimateTokens(content)
+		if hasToolCalls {
+			example.tokens += EstimateTokens(string(message.ToolCalls))
+		}
+	}
+
+	if !hasAssistant {
+		problems = append(problems, "no 'assistant' message to learn from")
+	}
+
+	return example, problems
+}
+
+// chatMessageContent returns the text of the content of a chat message, which is either
+// a string or a list of content parts
+func chatMessageContent(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text, nil
+	}
+
+	var parts []map[string]any
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return "", fmt.Errorf("'content' must be a string or a list of content parts")
+	}
+
+	var texts []string
+	for _, part := range parts {
+		if _, has := part["type"]; !has {
+			return "", fmt.Errorf("content part is missing 'type'")
+		}
+		if partText, ok := part["text"].(string); ok {
+			texts = append(texts, partText)
+		}
+	}
+
+	return strings.Join(texts, "\n"), nil
+}
+
+// parseCompletionExample validates an example in the prompt/completion format, returning its problems
+func parseCompletionExample(fields map[string]json.RawMessage) (*datasetExample, []string) {
+	var problems []string
+	texts := make([]string, 0, 2)
+
+	for _, name := range []string{"prompt", "completion"} {
+		raw, has := fields[name]
+		if !has {
+			problems = append(problems, fmt.Sprintf("missing '%s'", name))
+			continue
+		}
+
+		var text string
+		if err := json.Unmarshal(raw, &text); err != nil {
+			problems = append(problems, fmt.Sprintf("'%s' must be a string", name))
+			continue
+		}
+		if name == "completion" && strings.TrimSpace(text) == "" {
+			problems = append(problems, "'completion' is empty")
+		}
+
+		texts = append(texts, text)
+	}
+
+	example := &datasetExample{texts: texts}
+	for _, text := range texts {
+		example.tokens += EstimateTokens(text)
+	}
+
+	return example, problems
+}
+
+// canonicalDatasetExample returns a key of an example which is the same for examples that
+// only differ in the order of their fields or in their whitespace
+func canonicalDatasetExample(text []byte) string {
+	var value any
+	if err := json.Unmarshal(text, &value); err != nil {
+		return string(text)
+	}
+
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return string(text)
+	}
+
+	return string(canonical)
+}
+
+// detectPII returns the kinds of PII-like content found in the texts of an example
+func detectPII(texts []string) []string {
+	var kinds []string
+	for _, pii := range piiPatterns {
+		for _, text := range texts {
+			if matchesPII(text, pii.pattern, pii.check) {
+				kinds = append(kinds, pii.name)
+				break
+			}
+		}
+	}
+	return kinds
+}
+
+func matchesPII(text string, pattern *regexp.Regexp, check func(string) bool) bool {
+	for _, match := range pattern.FindAllString(text, -1) {
+		if check == nil || check(match) {
+			return true
+		}
+	}
+	return false
+}
+
+// isLuhnValid returns true if the digits of a number pass the Luhn checksum used by credit card numbers
+func isLuhnValid(number string) bool {
+	sum := 0
+	digits := 0
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+
+	return digits >= 13 && sum%10 == 0
+}
@@ -0,0 +1,332 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DatasetSourceFormat represents a format datasets can be converted from
+type DatasetSourceFormat string
+
+// DatasetSourceFormat constants define the formats ConvertDataset converts to the chat format
+const (
+	// SourceFormatCSV is a CSV file with a header row and prompt and completion columns
+	SourceFormatCSV DatasetSourceFormat = "csv"
+	// SourceFormatCompletion is JSONL with "prompt" and "completion" fields
+	SourceFormatCompletion DatasetSourceFormat = "completion"
+	// SourceFormatShareGPT is JSONL with "conversations" lists of "from" and "value" turns
+	SourceFormatShareGPT DatasetSourceFormat = "sharegpt"
+	// SourceFormatAlpaca is JSONL with "instruction", "input" and "output" fields
+	SourceFormatAlpaca DatasetSourceFormat = "alpaca"
+)
+
+// DatasetSourceFormats lists the formats ConvertDataset supports
+var DatasetSourceFormats = []DatasetSourceFormat{
+	SourceFormatCSV,
+	SourceFormatCompletion,
+	SourceFormatShareGPT,
+	SourceFormatAlpaca,
+}
+
+// csvColumnNames are the accepted names of the CSV columns of each chat role
+var csvColumnNames = map[string][]string{
+	"system":    {"system"},
+	"user":      {"user", "prompt", "question", "input"},
+	"assistant": {"assistant", "completion", "answer", "output"},
+}
+
+// shareGPTRoles maps the speakers of ShareGPT conversations to chat roles
+var shareGPTRoles = map[string]string{
+	"system":    "system",
+	"human":     "user",
+	"user":      "user",
+	"gpt":       "assistant",
+	"assistant": "assistant",
+}
+
+// DatasetConversionOptions configures ConvertDataset
+type DatasetConversionOptions struct {
+	// From is the format of the source dataset. Detected from the file when empty.
+	From DatasetSourceFormat
+	// SystemPrompt is added as the system message of examples without one, when set.
+	SystemPrompt string
+}
+
+// datasetMessage is a message of a converted chat example
+type datasetMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// ConvertDataset converts a CSV, completion, ShareGPT or Alpaca dataset to the chat JSONL format
+// used to fine-tune chat models, returning the number of converted examples. Conversion stops at
+// the first example which can't be converted, reporting its line.
+func ConvertDataset(path string, outputPath string, options DatasetConversionOptions) (int, error) {
+	//nolint:gosec // path is an explicit user-provided dataset path
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read dataset %s: %w", path, err)
+	}
+
+	from := options.From
+	if from == "" {
+		from, err = detectDatasetSourceFormat(path, content)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	var examples [][]datasetMessage
+	switch from {
+	case SourceFormatCSV:
+		examples, err = convertCSVDataset(content)
+	case SourceFormatCompletion, SourceFormatShareGPT, SourceFormatAlpaca:
+		examples, err = convertJSONLDataset(content, from)
+	default:
+		return 0, fmt.Errorf("unsupported dataset format '%s', supported formats: %s", from, joinSourceFormats())
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert %s dataset %s: %w", from, path, err)
+	}
+	if len(examples) == 0 {
+		return 0, fmt.Errorf("dataset %s has no examples to convert", path)
+	}
+
+	var output bytes.Buffer
+	encoder := json.NewEncoder(&output)
+	encoder.SetEscapeHTML(false)
+
+	for _, messages := range examples {
+		if options.SystemPrompt != "" && messages[0].Role != "system" {
+			messages = append([]datasetMessage{{Role: "system", Content: options.SystemPrompt}}, messages...)
+		}
+
+		if err := encoder.Encode(map[string][]datasetMessage{"messages": messages}); err != nil {
+			return 0, fmt.Errorf("failed to encode example: %w", err)
+		}
+	}
+
+	if err := writeDatasetFile(outputPath, output.Bytes()); err != nil {
+		return 0, err
+	}
+
+	return len(examples), nil
+}
+
+// detectDatasetSourceFormat detects the format of a dataset from its extension or the fields
+// of its first example
+func detectDatasetSourceFormat(path string, content []byte) (DatasetSourceFormat, error) {
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return SourceFormatCSV, nil
+	}
+
+	firstLine, _, _ := bytes.Cut(bytes.TrimSpace(content), []byte("\n"))
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(firstLine, &fields); err == nil {
+		switch {
+		case fields["messages"] != nil:
+			return "", fmt.Errorf("dataset %s is already in the chat format", path)
+		case fields["prompt"] != nil:
+			return SourceFormatCompletion, nil
+		case fields["conversations"] != nil:
+			return SourceFormatShareGPT, nil
+		case fields["instruction"] != nil:
+			return SourceFormatAlpaca, nil
+		}
+	}
+
+	return "", fmt.Errorf(
+		"could not detect the format of dataset %s, specify it with --from (%s)", path, joinSourceFormats())
+}
+
+// convertCSVDataset converts the rows of a CSV dataset to chat examples
+func convertCSVDataset(content []byte) ([][]datasetMessage, error) {
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for role, names := range csvColumnNames {
+			if _, has := columns[role]; !has && slices.Contains(names, name) {
+				columns[role] = i
+			}
+		}
+	}
+
+	if _, has := columns["user"]; !has {
+		return nil, errors.New("CSV header must have a 'prompt' or 'user' column")
+	}
+	if _, has := columns["assistant"]; !has {
+		return nil, errors.New("CSV header must have a 'completion' or 'assistant' column")
+	}
+
+	var examples [][]datasetMessage
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		var messages []datasetMessage
+		for _, role := range []string{"system", "user", "assistant"} {
+			column, has := columns[role]
+			value := ""
+			if has && column < len(record) {
+				value = strings.TrimSpace(record[column])
+			}
+
+			if value == "" {
+				if role == "system" {
+					continue
+				}
+				return nil, fmt.Errorf("line %d: empty %s column", line, header[column])
+			}
+			messages = append(messages, datasetMessage{Role: role, Content: value})
+		}
+
+		examples = append(examples, messages)
+	}
+
+	return examples, nil
+}
+
+// convertJSONLDataset converts the examples of a JSONL dataset in the given format to chat examples
+func convertJSONLDataset(content []byte, from DatasetSourceFormat) ([][]datasetMessage, error) {
+	var examples [][]datasetMessage
+	var lineErr error
+
+	err := scanDatasetLines(bytes.NewReader(content), func(line int, text []byte) {
+		if lineErr != nil || len(bytes.TrimSpace(text)) == 0 {
+			return
+		}
+
+		var messages []datasetMessage
+		var err error
+		switch from {
+		case SourceFormatCompletion:
+			messages, err = convertCompletionExample(text)
+		case SourceFormatShareGPT:
+			messages, err = convertShareGPTExample(text)
+		case SourceFormatAlpaca:
+			messages, err = convertAlpacaExample(text)
+		}
+
+		if err != nil {
+			lineErr = fmt.Errorf("line %d: %w", line, err)
+			return
+		}
+		examples = append(examples, messages)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if lineErr != nil {
+		return nil, lineErr
+	}
+
+	return examples, nil
+}
+
+func convertCompletionExample(text []byte) ([]datasetMessage, error) {
+	var example struct {
+		Prompt     string `json:"prompt"`
+		Completion string `json:"completion"`
+	}
+	if err := json.Unmarshal(text, &example); err != nil {
+		return nil, fmt.Errorf("invalid example: %w", err)
+	}
+
+	return userAssistantMessages(example.Prompt, example.Completion, "prompt", "completion")
+}
+
+func convertAlpacaExample(text []byte) ([]datasetMessage, error) {
+	var example struct {
+		Instruction string `json:"instruction"`
+		Input       string `json:"input"`
+		Output      string `json:"output"`
+	}
+	if err := json.Unmarshal(text, &example); err != nil {
+		return nil, fmt.Errorf("invalid example: %w", err)
+	}
+
+	prompt := strings.TrimSpace(example.Instruction)
+	if input := strings.TrimSpace(example.Input); input != "" {
+		prompt += "\n\n" + input
+	}
+
+	return userAssistantMessages(prompt, example.Output, "instruction", "output")
+}
+
+func convertShareGPTExample(text []byte) ([]datasetMessage, error) {
+	var example struct {
+		Conversations []struct {
+			From  string `json:"from"`
+			Value string `json:"value"`
+		} `json:"conversations"`
+	}
+	if err := json.Unmarshal(text, &example); err != nil {
+		return nil, fmt.Errorf("invalid example: %w", err)
+	}
+	if len(example.Conversations) == 0 {
+		return nil, errors.New("'conversations' is empty")
+	}
+
+	messages := make([]datasetMessage, 0, len(example.Conversations))
+	for _, turn := range example.Conversations {
+		role, has := shareGPTRoles[strings.ToLower(turn.From)]
+		if !has {
+			return nil, fmt.Errorf("unsupported speaker '%s'", turn.From)
+		}
+		messages = append(messages, datasetMessage{Role: role, Content: strings.TrimSpace(turn.Value)})
+	}
+
+	return messages, nil
+}
+
+// userAssistantMessages returns the messages of an example with a single prompt and answer
+func userAssistantMessages(prompt, answer, promptField, answerField string) ([]datasetMessage, error) {
+	prompt = strings.TrimSpace(prompt)
+	answer = strings.TrimSpace(answer)
+
+	if prompt == "" {
+		return nil, fmt.Errorf("missing '%s'", promptField)
+	}
+	if answer == "" {
+		return nil, fmt.Errorf("missing '%s'", answerField)
+	}
+
+	return []datasetMessage{
+		{Role: "user", Content: prompt},
+		{Role: "assistant", Content: answer},
+	}, nil
+}
+
+func joinSourceFormats() string {
+	formats := make([]string, len(DatasetSourceFormats))
+	for i, format := range DatasetSourceFormats {
+		formats[i] = string(format)
+	}
+	return strings.Join(formats, ", ")
+}
@@ -0,0 +1,176 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertDataset(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		lines    []string
+		options  DatasetConversionOptions
+		expected []string
+	}{
+		{
+			name: "CSV",
+			file: "data.csv",
+			lines: []string{
+				`Question,Answer,Category`,
+				`What is azd?,"The Azure Developer CLI, a developer tool",tools`,
+				`"Multi
+line?",Yes,misc`,
+			},
+			expected: []string{
+				`{"messages":[{"role":"user","content":"What is azd?"},` +
+					`{"role":"assistant","content":"The Azure Developer CLI, a developer tool"}]}`,
+				`{"messages":[{"role":"user","content":"Multi\nline?"},{"role":"assistant","content":"Yes"}]}`,
+			},
+		},
+		{
+			name: "CSVWithSystemColumn",
+			file: "data.csv",
+			lines: []string{
+				`system,prompt,completion`,
+				`Be brief,Hi,Hello`,
+				`,Bye,Goodbye`,
+			},
+			options: DatasetConversionOptions{SystemPrompt: "Be nice"},
+			expected: []string{
+				`{"messages":[{"role":"system","content":"Be brief"},{"role":"user","content":"Hi"},` +
+					`{"role":"assistant","content":"Hello"}]}`,
+				`{"messages":[{"role":"system","content":"Be nice"},{"role":"user","content":"Bye"},` +
+					`{"role":"assistant","content":"Goodbye"}]}`,
+			},
+		},
+		{
+			name:  "Completion",
+			file:  "data.jsonl",
+			lines: []string{`{"prompt":"2+2 =","completion":" 4"}`},
+			expected: []string{
+				`{"messages":[{"role":"user","content":"2+2 ="},{"role":"assistant","content":"4"}]}`,
+			},
+		},
+		{
+			name: "ShareGPT",
+			file: "data.jsonl",
+			lines: []string{
+				`{"conversations":[{"from":"system","value":"Be brief"},{"from":"human","value":"Hi"},` +
+					`{"from":"gpt","value":"Hello <b>there</b>"}]}`,
+			},
+			expected: []string{
+				`{"messages":[{"role":"system","content":"Be brief"},{"role":"user","content":"Hi"},` +
+					`{"role":"assistant","content":"Hello <b>there</b>"}]}`,
+			},
+		},
+		{
+			name: "Alpaca",
+			file: "data.jsonl",
+			lines: []string{
+				`{"instruction":"Translate to French","input":"Hello","output":"Bonjour"}`,
+				`{"instruction":"Say hi","input":"","output":"Hi"}`,
+			},
+			options: DatasetConversionOptions{From: SourceFormatAlpaca},
+			expected: []string{
+				`{"messages":[{"role":"user","content":"Translate to French\n\nHello"},` +
+					`{"role":"assistant","content":"Bonjour"}]}`,
+				`{"messages":[{"role":"user","content":"Say hi"},{"role":"assistant","content":"Hi"}]}`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestDataset(t, tt.file, tt.lines...)
+			outputPath := filepath.Join(t.TempDir(), "chat.jsonl")
+
+			count, err := ConvertDataset(path, outputPath, tt.options)
+			require.NoError(t, err)
+			require.Equal(t, len(tt.expected), count)
+			require.Equal(t, tt.expected, readTestDatasetLines(t, outputPath))
+
+			// Converted datasets are valid chat datasets
+			report, err := ValidateDataset(outputPath, DatasetValidationOptions{})
+			require.NoError(t, err)
+			require.Empty(t, report.Errors)
+		})
+	}
+}
+
+func TestConvertDataset_Errors(t *testing.T) {
+	tests := []struct {
+		name          string
+		file          string
+		lines         []string
+		options       DatasetConversionOptions
+		expectedError string
+	}{
+		{
+			name:          "AlreadyChat",
+			file:          "data.jsonl",
+			lines:         chatExamples(1),
+			expectedError: "is already in the chat format",
+		},
+		{
+			name:          "UnknownFormat",
+			file:          "data.jsonl",
+			lines:         []string{`{"text":"Hello"}`},
+			expectedError: "could not detect the format of dataset",
+		},
+		{
+			name:          "UnsupportedFormat",
+			file:          "data.jsonl",
+			lines:         []string{`{"text":"Hello"}`},
+			options:       DatasetConversionOptions{From: "parquet"},
+			expectedError: "unsupported dataset format 'parquet'",
+		},
+		{
+			name:          "CSVMissingColumn",
+			file:          "data.csv",
+			lines:         []string{`prompt,label`, `Hi,greeting`},
+			expectedError: "CSV header must have a 'completion' or 'assistant' column",
+		},
+		{
+			name:          "CSVEmptyValue",
+			file:          "data.csv",
+			lines:         []string{`prompt,completion`, `Hi,Hello`, `Bye,`},
+			expectedError: "line 3: empty completion column",
+		},
+		{
+			name: "ShareGPTUnknownSpeaker",
+			file: "data.jsonl",
+			lines: []string{
+				`{"conversations":[{"from":"human","value":"Hi"},{"from":"gpt","value":"Hello"}]}`,
+				`{"conversations":[{"from":"narrator","value":"Once"}]}`,
+			},
+			expectedError: "line 2: unsupported speaker 'narrator'",
+		},
+		{
+			name:          "CompletionMissingCompletion",
+			file:          "data.jsonl",
+			lines:         []string{`{"prompt":"Hi"}`},
+			expectedError: "line 1: missing 'completion'",
+		},
+		{
+			name:          "NoExamples",
+			file:          "data.csv",
+			lines:         []string{`prompt,completion`},
+			expectedError: "has no examples to convert",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTestDataset(t, tt.file, tt.lines...)
+
+			_, err := ConvertDataset(path, filepath.Join(t.TempDir(), "chat.jsonl"), tt.options)
+			require.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"os"
+	"slices"
+)
+
+// DatasetSplitResult represents the number of examples written by SplitDataset
+type DatasetSplitResult struct {
+	TrainingExamples   int
+	ValidationExamples int
+}
+
+// SplitDataset splits the examples of a JSONL dataset into a training and a validation file.
+// The validation examples are picked with a random generator seeded with seed, so the same
+// dataset, ratio and seed always produce the same split. Examples keep their original order.
+func SplitDataset(
+	path string,
+	trainingPath string,
+	validationPath string,
+	validationRatio float64,
+	seed uint64,
+) (*DatasetSplitResult, error) {
+	if validationRatio <= 0 || validationRatio >= 1 {
+		return nil, fmt.Errorf("validation ratio must be between 0 and 1, got %g", validationRatio)
+	}
+
+	//nolint:gosec // path is an explicit user-provided dataset path
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dataset %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var examples [][]byte
+	err = scanDatasetLines(file, func(_ int, text []byte) {
+		if len(bytes.TrimSpace(text)) > 0 {
+			examples = append(examples, bytes.Clone(text))
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dataset %s: %w", path, err)
+	}
+
+	if len(examples) < 2 {
+		return nil, fmt.Errorf("dataset %s has %d examples, at least 2 are required to split it", path, len(examples))
+	}
+
+	validationCount := int(math.Round(float64(len(examples)) * validationRatio))
+	validationCount = min(max(validationCount, 1), len(examples)-1)
+
+	//nolint:gosec // the split only needs to be reproducible, not cryptographically random
+	random := rand.New(rand.NewPCG(seed, seed))
+	validationIndexes := random.Perm(len(examples))[:validationCount]
+	slices.Sort(validationIndexes)
+
+	var training, validation bytes.Buffer
+	for i, example := range examples {
+		target := &training
+		if _, isValidation := slices.BinarySearch(validationIndexes, i); isValidation {
+			target = &validation
+		}
+		target.Write(example)
+		target.WriteByte('\n')
+	}
+
+	if err := writeDatasetFile(trainingPath, training.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := writeDatasetFile(validationPath, validation.Bytes()); err != nil {
+		return nil, err
+	}
+
+	return &DatasetSplitResult{
+		TrainingExamples:   len(examples) - validationCount,
+		ValidationExamples: validationCount,
+	}, nil
+}
+
+// writeDatasetFile writes the content of a generated dataset file
+func writeDatasetFile(path string, content []byte) error {
+	//nolint:gosec // generated dataset files should be readable by project tooling
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed to write dataset %s: %w", path, err)
+	}
+	return nil
+}
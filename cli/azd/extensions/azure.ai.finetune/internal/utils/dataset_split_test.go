@@ -0,0 +1,83 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readTestDatasetLines(t *testing.T, path string) []string {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
+
+func TestSplitDataset(t *testing.T) {
+	lines := chatExamples(20)
+	path := writeTestDataset(t, "data.jsonl", append(lines, "")...)
+	dir := t.TempDir()
+
+	split := func(name string, seed uint64) ([]string, []string) {
+		trainingPath := filepath.Join(dir, name+"_train.jsonl")
+		validationPath := filepath.Join(dir, name+"_validation.jsonl")
+
+		result, err := SplitDataset(path, trainingPath, validationPath, 0.2, seed)
+		require.NoError(t, err)
+		require.Equal(t, &DatasetSplitResult{TrainingExamples: 16, ValidationExamples: 4}, result)
+
+		return readTestDatasetLines(t, trainingPath), readTestDatasetLines(t, validationPath)
+	}
+
+	training, validation := split("first", 42)
+	require.Len(t, training, 16)
+	require.Len(t, validation, 4)
+	require.ElementsMatch(t, lines, append(training, validation...))
+
+	// Examples keep their order
+	require.True(t, isSubsequence(training, lines))
+	require.True(t, isSubsequence(validation, lines))
+
+	// The same seed produces the same split
+	sameTraining, sameValidation := split("second", 42)
+	require.Equal(t, training, sameTraining)
+	require.Equal(t, validation, sameValidation)
+
+	_, otherValidation := split("third", 7)
+	require.NotEqual(t, validation, otherValidation)
+}
+
+func TestSplitDataset_Errors(t *testing.T) {
+	dir := t.TempDir()
+	trainingPath := filepath.Join(dir, "train.jsonl")
+	validationPath := filepath.Join(dir, "validation.jsonl")
+
+	path := writeTestDataset(t, "data.jsonl", chatExamples(1)...)
+	_, err := SplitDataset(path, trainingPath, validationPath, 0.1, 42)
+	require.ErrorContains(t, err, "at least 2 are required")
+
+	_, err = SplitDataset(path, trainingPath, validationPath, 1, 42)
+	require.ErrorContains(t, err, "validation ratio must be between 0 and 1")
+
+	// Small datasets keep at least one example in each file
+	path = writeTestDataset(t, "data.jsonl", chatExamples(3)...)
+	result, err := SplitDataset(path, trainingPath, validationPath, 0.01, 42)
+	require.NoError(t, err)
+	require.Equal(t, &DatasetSplitResult{TrainingExamples: 2, ValidationExamples: 1}, result)
+}
+
+func isSubsequence(subset []string, lines []string) bool {
+	i := 0
+	for _, line := range lines {
+		if i < len(subset) && subset[i] == line {
+			i++
+		}
+	}
+	return i == len(subset)
+}
@@ -0,0 +1,168 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"azure.ai.finetune/pkg/models"
+)
+
+func writeTestDataset(t *testing.T, name string, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+	return path
+}
+
+func chatExamples(count int) []string {
+	lines := make([]string, count)
+	for i := range lines {
+		lines[i] = `{"messages":[{"role":"system","content":"You are helpful."},` +
+			`{"role":"user","content":"Question ` + strings.Repeat("x", i+1) + `"},` +
+			`{"role":"assistant","content":"Answer"}]}`
+	}
+	return lines
+}
+
+func TestValidateDataset_ValidChat(t *testing.T) {
+	path := writeTestDataset(t, "train.jsonl", chatExamples(10)...)
+
+	report, err := ValidateDataset(path, DatasetValidationOptions{})
+	require.NoError(t, err)
+
+	require.True(t, report.Valid())
+	require.False(t, report.HasWarnings())
+	require.Equal(t, models.DatasetFormatChat, report.Format)
+	require.Equal(t, 10, report.Examples)
+	require.Positive(t, report.EstimatedTokens)
+	require.Greater(t, report.EstimatedTokens, report.MaxExampleTokens)
+}
+
+func TestValidateDataset_ChatErrors(t *testing.T) {
+	lines := chatExamples(10)
+	lines = append(lines,
+		``,
+		`{"messages":[{"role":"user","content":"Hi"}`,
+		`{"messages":[]}`,
+		`{"messages":[{"role":"user","content":"Hi"}]}`,
+		`{"messages":[{"role":"robot","content":"Hi"},{"role":"assistant","content":"Hello"}]}`,
+		`{"messages":[{"role":"user"},{"role":"assistant","content":"Hello","weight":2}]}`,
+		`{"prompt":"Hi","completion":"Hello"}`,
+		`{"messages":[{"role":"user","content":"Weather?"},`+
+			`{"role":"assistant","tool_calls":[{"id":"call_1","type":"function"}]}]}`,
+	)
+	path := writeTestDataset(t, "train.jsonl", lines...)
+
+	report, err := ValidateDataset(path, DatasetValidationOptions{})
+	require.NoError(t, err)
+
+	require.False(t, report.Valid())
+	require.Equal(t, 11, report.Examples)
+	require.Equal(t, []models.DatasetIssue{
+		{Line: 11, Message: "empty line"},
+		{Line: 12, Message: "invalid JSON: unexpected end of JSON input"},
+		{Line: 13, Message: "'messages' is empty"},
+		{Line: 14, Message: "no 'assistant' message to learn from"},
+		{Line: 15, Message: "message 1: unsupported role 'robot'"},
+		{Line: 16, Message: "message 1: missing 'content'"},
+		{Line: 16, Message: "message 2: 'weight' must be 0 or 1"},
+		{Line: 17, Message: "missing 'messages'"},
+	}, report.Errors)
+}
+
+func TestValidateDataset_Completion(t *testing.T) {
+	path := writeTestDataset(t, "train.jsonl",
+		`{"prompt":"What is 2+2?","completion":"4"}`,
+		`{"prompt":"What is 3+3?"}`,
+		`{"prompt":"What is 4+4?","completion":" "}`,
+		`{"prompt":1,"completion":"8"}`,
+	)
+
+	report, err := ValidateDataset(path, DatasetValidationOptions{})
+	require.NoError(t, err)
+
+	require.Equal(t, models.DatasetFormatCompletion, report.Format)
+	require.Equal(t, 1, report.Examples)
+	require.Equal(t, []models.DatasetIssue{
+		{Line: 2, Message: "missing 'completion'"},
+		{Line: 3, Message: "'completion' is empty"},
+		{Line: 4, Message: "'prompt' must be a string"},
+	}, report.Errors)
+
+	// Too few examples
+	require.Equal(t, []models.DatasetIssue{
+		{Message: "dataset has 1 valid examples, fine-tuning requires at least 10"},
+	}, report.Warnings)
+}
+
+func TestValidateDataset_ExpectedFormat(t *testing.T) {
+	path := writeTestDataset(t, "train.jsonl", chatExamples(1)...)
+
+	report, err := ValidateDataset(path, DatasetValidationOptions{Format: models.DatasetFormatCompletion})
+	require.NoError(t, err)
+
+	require.Equal(t, []models.DatasetIssue{
+		{Line: 1, Message: "missing 'prompt'"},
+		{Line: 1, Message: "missing 'completion'"},
+	}, report.Errors)
+}
+
+func TestValidateDataset_Warnings(t *testing.T) {
+	lines := chatExamples(10)
+	lines = append(lines,
+		// Same example as the first one, with its fields in another order
+		`{"messages":[{"content":"You are helpful.","role":"system"},{"role":"user","content":"Question x"},`+
+			`{"role":"assistant","content":"Answer"}]}`,
+		`{"messages":[{"role":"user","content":"Mail jane.doe@contoso.com or call 425-555-0100"},`+
+			`{"role":"assistant","content":"Card 4111 1111 1111 1111, SSN 123-45-6789, host 10.0.0.1"}]}`,
+		`{"messages":[{"role":"user","content":"Order 1234567890123"},{"role":"assistant","content":"v1.2.3"}]}`,
+		`{"messages":[{"role":"user","content":"`+strings.Repeat("long ", 100)+`"},`+
+			`{"role":"assistant","content":"Answer"}]}`,
+	)
+	path := writeTestDataset(t, "train.jsonl", lines...)
+
+	report, err := ValidateDataset(path, DatasetValidationOptions{MaxTokens: 100})
+	require.NoError(t, err)
+
+	require.True(t, report.Valid())
+	require.True(t, report.HasWarnings())
+	require.Equal(t, []models.DatasetIssue{
+		{Line: 11, Message: "duplicate of line 1"},
+	}, report.Duplicates)
+	require.Equal(t, []models.DatasetIssue{
+		{Line: 12, Message: "possible email address"},
+		{Line: 12, Message: "possible phone number"},
+		{Line: 12, Message: "possible social security number"},
+		{Line: 12, Message: "possible credit card number"},
+		{Line: 12, Message: "possible IP address"},
+	}, report.PII)
+	require.Len(t, report.Warnings, 1)
+	require.Equal(t, 14, report.Warnings[0].Line)
+	require.Contains(t, report.Warnings[0].Message, "more than the limit of 100")
+}
+
+func TestValidateDataset_MissingFile(t *testing.T) {
+	_, err := ValidateDataset(filepath.Join(t.TempDir(), "missing.jsonl"), DatasetValidationOptions{})
+	require.ErrorContains(t, err, "failed to open dataset")
+}
+
+func TestEstimateTokens(t *testing.T) {
+	require.Equal(t, 0, EstimateTokens(""))
+	require.Equal(t, 1, EstimateTokens("Hi"))
+	require.Equal(t, 3, EstimateTokens("Hello, world"))
+	require.Equal(t, 2, EstimateTokens("日本語の文章"))
+}
+
+func TestIsLuhnValid(t *testing.T) {
+	require.True(t, isLuhnValid("4111 1111 1111 1111"))
+	require.True(t, isLuhnValid("5500-0000-0000-0004"))
+	require.False(t, isLuhnValid("4111 1111 1111 1112"))
+	require.False(t, isLuhnValid("0"))
+}
@@ -6,6 +6,7 @@ package utils
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
 )
@@ -15,6 +16,7 @@ const (
 	EnvAzureSubscriptionID    = "AZURE_SUBSCRIPTION_ID"
 	EnvAzureLocation          = "AZURE_LOCATION"
 	EnvAzureAccountName       = "AZURE_ACCOUNT_NAME"
+	EnvAzureResourceGroupName = "AZURE_RESOURCE_GROUP_NAME"
 	EnvAzureOpenAIProjectName = "AZURE_PROJECT_NAME"
 	EnvAPIVersion             = "AZURE_API_VERSION"
 	EnvFinetuningRoute        = "AZURE_FINETUNING_ROUTE"
@@ -45,3 +47,20 @@ func GetEnvironmentValues(ctx context.Context, azdClient *azdext.AzdClient) (map
 
 	return envValueMap, nil
 }
+
+// GetStateFilePath returns the path of the file tracking the fine-tuning jobs, deployments and
+// sweeps of the current environment, under the .azure directory of the project
+func GetStateFilePath(ctx context.Context, azdClient *azdext.AzdClient) (string, error) {
+	projectResponse, err := azdClient.Project().Get(ctx, &azdext.EmptyRequest{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get project: %w", err)
+	}
+
+	envResponse, err := azdClient.Environment().GetCurrent(ctx, &azdext.EmptyRequest{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get current environment: %w", err)
+	}
+
+	return filepath.Join(
+		projectResponse.Project.Path, ".azure", envResponse.Environment.Name, "finetuning", "state.json"), nil
+}
@@ -6,6 +6,7 @@ package utils
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"azure.ai.finetune/pkg/models"
 	"github.com/braydonk/yaml"
@@ -32,3 +33,41 @@ func ParseCreateFineTuningRequestConfig(filePath string) (*models.CreateFineTuni
 
 	return &config, nil
 }
+
+// ParseSweepSpec parses a hyperparameter sweep file. The job configuration of the sweep is either
+// inline or loaded from job_file, relative to the sweep file.
+func ParseSweepSpec(filePath string) (*models.SweepSpec, error) {
+	//nolint:gosec // filePath is an explicit user-provided sweep path
+	yamlFile, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sweep file %s: %w", filePath, err)
+	}
+
+	var spec models.SweepSpec
+	if err := yaml.Unmarshal(yamlFile, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML sweep: %w", err)
+	}
+
+	if spec.JobFile != "" {
+		if spec.Job != nil {
+			return nil, fmt.Errorf("invalid sweep: job and job_file cannot both be set")
+		}
+
+		jobFile := spec.JobFile
+		if !filepath.IsAbs(jobFile) {
+			jobFile = filepath.Join(filepath.Dir(filePath), jobFile)
+		}
+
+		job, err := ParseCreateFineTuningRequestConfig(jobFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load job_file: %w", err)
+		}
+		spec.Job = job
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid sweep: %w", err)
+	}
+
+	return &spec, nil
+}
@@ -9,6 +9,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"azure.ai.finetune/pkg/models"
 )
 
 func TestParseCreateFineTuningRequestConfig_ValidMinimal(t *testing.T) {
@@ -228,3 +230,67 @@ another_unknown: 123
 	require.NotNil(t, config)
 	require.Equal(t, "gpt-4o-mini", config.BaseModel)
 }
+
+func TestParseSweepSpec_JobFile(t *testing.T) {
+	spec, err := ParseSweepSpec(filepath.Join("testdata", "valid_sweep.yaml"))
+	require.NoError(t, err)
+
+	require.Equal(t, "lr-sweep", spec.Name)
+	require.Equal(t, models.MetricValidMeanTokenAccuracy, spec.Metric)
+	// The job file is loaded relative to the sweep file
+	require.NotNil(t, spec.Job)
+	require.Equal(t, "gpt-4o-mini", spec.Job.BaseModel)
+	require.Len(t, spec.Hyperparameters, 3)
+	require.Equal(t, "epochs=2, learning_rate_multiplier=1", spec.Hyperparameters[1].String())
+	require.Equal(t, &models.SweepDeployConfig{DeploymentName: "lr-sweep-best", Capacity: 10}, spec.Deploy)
+}
+
+func TestParseSweepSpec_InlineJob(t *testing.T) {
+	spec, err := ParseSweepSpec(filepath.Join("testdata", "valid_sweep_inline.yaml"))
+	require.NoError(t, err)
+
+	require.Equal(t, "batch-sweep", spec.Name)
+	require.Equal(t, "local:./data/training.jsonl", spec.Job.TrainingFile)
+	require.Len(t, spec.Hyperparameters, 2)
+	require.Nil(t, spec.Deploy)
+}
+
+func TestParseSweepSpec_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedError string
+	}{
+		{
+			name:          "JobAndJobFile",
+			content:       "name: s\njob_file: job.yaml\njob:\n  model: gpt-4o-mini\n",
+			expectedError: "job and job_file cannot both be set",
+		},
+		{
+			name:          "MissingJobFile",
+			content:       "name: s\njob_file: missing.yaml\nhyperparameters:\n  - epochs: 2\n",
+			expectedError: "failed to load job_file",
+		},
+		{
+			name:          "MissingHyperparameters",
+			content:       "name: s\njob_file: job.yaml\n",
+			expectedError: "at least one hyperparameters combination is required",
+		},
+	}
+
+	job, err := os.ReadFile(filepath.Join("testdata", "valid_complete.yaml"))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "job.yaml"), job, 0600))
+
+			path := filepath.Join(dir, "sweep.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+
+			_, err := ParseSweepSpec(path)
+			require.ErrorContains(t, err, tt.expectedError)
+		})
+	}
+}
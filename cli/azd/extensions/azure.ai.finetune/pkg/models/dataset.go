@@ -0,0 +1,42 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package models
+
+// DatasetFormat represents the format of the examples of a fine-tuning dataset
+type DatasetFormat string
+
+// DatasetFormat constants define the JSONL formats accepted for fine-tuning
+const (
+	DatasetFormatChat       DatasetFormat = "chat"
+	DatasetFormatCompletion DatasetFormat = "completion"
+)
+
+// DatasetIssue represents a problem found on a line of a dataset
+type DatasetIssue struct {
+	Line    int    `json:"line" yaml:"line"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// DatasetReport represents the result of validating a fine-tuning dataset locally
+type DatasetReport struct {
+	Path             string         `json:"path" yaml:"path"`
+	Format           DatasetFormat  `json:"format" yaml:"format"`
+	Examples         int            `json:"examples" yaml:"examples"`
+	EstimatedTokens  int            `json:"estimated_tokens" yaml:"estimated_tokens"`
+	MaxExampleTokens int            `json:"max_example_tokens" yaml:"max_example_tokens"`
+	Errors           []DatasetIssue `json:"errors" yaml:"errors"`
+	Warnings         []DatasetIssue `json:"warnings" yaml:"warnings"`
+	Duplicates       []DatasetIssue `json:"duplicates" yaml:"duplicates"`
+	PII              []DatasetIssue `json:"pii" yaml:"pii"`
+}
+
+// Valid returns true if no errors were found in the dataset
+func (r *DatasetReport) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// HasWarnings returns true if warnings, duplicates or PII-like content were found in the dataset
+func (r *DatasetReport) HasWarnings() bool {
+	return len(r.Warnings) > 0 || len(r.Duplicates) > 0 || len(r.PII) > 0
+}
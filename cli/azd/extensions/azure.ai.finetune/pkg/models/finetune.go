@@ -60,6 +60,28 @@ func (d Duration) MarshalJSON() ([]byte, error) {
 	return fmt.Appendf(nil, `"%dh %02dm"`, h, m), nil
 }
 
+// UnmarshalJSON implements json.Unmarshaler for Duration
+// Parses the "Xh XXm" format of MarshalJSON, so that tracked jobs can be read back
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return fmt.Errorf("invalid duration %s: %w", data, err)
+	}
+
+	if text == "-" || text == "" {
+		*d = 0
+		return nil
+	}
+
+	var h, m int
+	if _, err := fmt.Sscanf(text, "%dh %dm", &h, &m); err != nil {
+		return fmt.Errorf("invalid duration %q: %w", text, err)
+	}
+
+	*d = Duration(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+	return nil
+}
+
 // MarshalYAML implements yaml.Marshaler for Duration
 // Returns the duration formatted as "Xh XXm" or "-" if zero
 func (d Duration) MarshalYAML() (any, error) {
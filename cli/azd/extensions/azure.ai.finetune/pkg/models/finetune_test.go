@@ -113,6 +113,21 @@ func TestDuration_MarshalJSON(t *testing.T) {
 	}
 }
 
+func TestDuration_UnmarshalJSON(t *testing.T) {
+	for _, duration := range []Duration{0, Duration(30 * time.Minute), Duration(26*time.Hour + 5*time.Minute)} {
+		data, err := json.Marshal(duration)
+		require.NoError(t, err)
+
+		var result Duration
+		require.NoError(t, json.Unmarshal(data, &result))
+		require.Equal(t, duration, result)
+	}
+
+	var result Duration
+	require.Error(t, json.Unmarshal([]byte(`"soon"`), &result))
+	require.Error(t, json.Unmarshal([]byte(`42`), &result))
+}
+
 func TestDuration_MarshalYAML(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,224 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SweepMetric represents the checkpoint metric used to compare the jobs of a sweep
+type SweepMetric string
+
+// SweepMetric constants define the checkpoint metrics sweeps can be compared on
+const (
+	// MetricValidLoss selects the checkpoint with the lowest validation loss
+	MetricValidLoss SweepMetric = "full_valid_loss"
+	// MetricValidMeanTokenAccuracy selects the checkpoint with the highest validation token accuracy
+	MetricValidMeanTokenAccuracy SweepMetric = "full_valid_mean_token_accuracy"
+)
+
+// SweepStatus represents the status of a hyperparameter sweep
+type SweepStatus string
+
+// SweepStatus constants define the possible states of a sweep
+const (
+	SweepStatusRunning   SweepStatus = "running"
+	SweepStatusCompleted SweepStatus = "completed"
+	SweepStatusDeployed  SweepStatus = "deployed"
+)
+
+// SweepSpec represents a hyperparameter sweep: a fine-tuning job submitted once for each
+// combination of hyperparameters, whose best checkpoint is optionally deployed
+type SweepSpec struct {
+	// Required: Name of the sweep, used to track it across sessions
+	Name string `yaml:"name"`
+
+	// Fine-tuning job configuration shared by all the jobs of the sweep.
+	// Either job or job_file is required.
+	Job *CreateFineTuningRequest `yaml:"job,omitempty"`
+
+	// Path to a job configuration file, like the one of 'jobs submit --file',
+	// relative to the sweep file
+	JobFile string `yaml:"job_file,omitempty"`
+
+	// Required: Hyperparameter combinations, one job is submitted for each
+	Hyperparameters []HyperparametersConfig `yaml:"hyperparameters"`
+
+	// Optional: Metric used to compare checkpoints. Defaults to full_valid_loss.
+	Metric SweepMetric `yaml:"metric,omitempty"`
+
+	// Optional: Deploys the best checkpoint when set
+	Deploy *SweepDeployConfig `yaml:"deploy,omitempty"`
+}
+
+// SweepDeployConfig represents the deployment of the best checkpoint of a sweep
+type SweepDeployConfig struct {
+	DeploymentName string `yaml:"deployment_name"`
+	ModelFormat    string `yaml:"model_format,omitempty"`
+	SKU            string `yaml:"sku,omitempty"`
+	Version        string `yaml:"version,omitempty"`
+	Capacity       int32  `yaml:"capacity,omitempty"`
+}
+
+// Sweep represents a hyperparameter sweep tracked in the state store
+type Sweep struct {
+	Name       string             `json:"name"`
+	Status     SweepStatus        `json:"status"`
+	Metric     SweepMetric        `json:"metric"`
+	CreatedAt  time.Time          `json:"created_at"`
+	Runs       []*SweepRun        `json:"runs"`
+	Deploy     *SweepDeployConfig `json:"deploy,omitempty"`
+	Best       *SweepCheckpoint   `json:"best,omitempty"`
+	Deployment string             `json:"deployment,omitempty"`
+}
+
+// SweepRun represents a job of a sweep and the hyperparameters it was submitted with
+type SweepRun struct {
+	JobID           string    `json:"job_id" table:"JOB ID"`
+	Hyperparameters string    `json:"hyperparameters" table:"HYPERPARAMETERS"`
+	Status          JobStatus `json:"status" table:"STATUS"`
+	FineTunedModel  string    `json:"fine_tuned_model,omitempty" table:"-"`
+}
+
+// SweepCheckpoint represents the checkpoint of a sweep selected by its metric
+type SweepCheckpoint struct {
+	JobID           string  `json:"job_id" table:"Job ID"`
+	CheckpointID    string  `json:"checkpoint_id" table:"Checkpoint ID"`
+	Model           string  `json:"model" table:"Model"`
+	StepNumber      int64   `json:"step_number" table:"Step"`
+	Hyperparameters string  `json:"hyperparameters" table:"Hyperparameters"`
+	Value           float64 `json:"value" table:"Metric Value"`
+}
+
+// Validate checks if the sweep specification is valid
+func (s *SweepSpec) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	if s.Job == nil {
+		return fmt.Errorf("either job or job_file is required")
+	}
+	if err := s.Job.Validate(); err != nil {
+		return fmt.Errorf("invalid job configuration: %w", err)
+	}
+	if s.Job.ValidationFile == nil || *s.Job.ValidationFile == "" {
+		return fmt.Errorf("job validation_file is required to compare the checkpoints of the sweep")
+	}
+
+	if len(s.Hyperparameters) == 0 {
+		return fmt.Errorf("at least one hyperparameters combination is required")
+	}
+
+	switch s.Metric {
+	case "", MetricValidLoss, MetricValidMeanTokenAccuracy:
+	default:
+		return fmt.Errorf(
+			"invalid metric: %s (must be '%s' or '%s')", s.Metric, MetricValidLoss, MetricValidMeanTokenAccuracy)
+	}
+
+	if s.Deploy != nil && s.Deploy.DeploymentName == "" {
+		return fmt.Errorf("deploy requires deployment_name")
+	}
+
+	return nil
+}
+
+// Value returns the value of the metric in checkpoint metrics, and whether the metrics have it.
+// Checkpoints of jobs without validation data have no validation metrics.
+func (m SweepMetric) Value(metrics *CheckpointMetrics) (float64, bool) {
+	if metrics == nil {
+		return 0, false
+	}
+
+	switch m {
+	case MetricValidMeanTokenAccuracy:
+		return metrics.FullValidMeanTokenAccuracy, metrics.FullValidMeanTokenAccuracy != 0
+	default:
+		return metrics.FullValidLoss, metrics.FullValidLoss != 0
+	}
+}
+
+// Better returns true if value a of the metric is better than value b
+func (m SweepMetric) Better(a, b float64) bool {
+	if m == MetricValidMeanTokenAccuracy {
+		return a > b
+	}
+	return a < b
+}
+
+// String returns the hyperparameters that are set, as a comma-separated list of name=value
+func (h HyperparametersConfig) String() string {
+	values := []struct {
+		name  string
+		value any
+	}{
+		{"epochs", h.Epochs},
+		{"batch_size", h.BatchSize},
+		{"learning_rate_multiplier", h.LearningRateMultiplier},
+		{"prompt_loss_weight", h.PromptLossWeight},
+		{"beta", h.Beta},
+		{"compute_multiplier", h.ComputeMultiplier},
+		{"reasoning_effort", h.ReasoningEffort},
+		{"eval_interval", h.EvalInterval},
+		{"eval_samples", h.EvalSamples},
+	}
+
+	var parts []string
+	for _, v := range values {
+		switch value := v.value.(type) {
+		case nil:
+			continue
+		case *float64:
+			if value == nil {
+				continue
+			}
+			parts = append(parts, fmt.Sprintf("%s=%g", v.name, *value))
+		case string:
+			if value == "" {
+				continue
+			}
+			parts = append(parts, fmt.Sprintf("%s=%s", v.name, value))
+		default:
+			parts = append(parts, fmt.Sprintf("%s=%v", v.name, value))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// Merge returns the hyperparameters with the values set in other replacing their values
+func (h HyperparametersConfig) Merge(other HyperparametersConfig) HyperparametersConfig {
+	merged := h
+	if other.Epochs != nil {
+		merged.Epochs = other.Epochs
+	}
+	if other.BatchSize != nil {
+		merged.BatchSize = other.BatchSize
+	}
+	if other.LearningRateMultiplier != nil {
+		merged.LearningRateMultiplier = other.LearningRateMultiplier
+	}
+	if other.PromptLossWeight != nil {
+		merged.PromptLossWeight = other.PromptLossWeight
+	}
+	if other.Beta != nil {
+		merged.Beta = other.Beta
+	}
+	if other.ComputeMultiplier != nil {
+		merged.ComputeMultiplier = other.ComputeMultiplier
+	}
+	if other.ReasoningEffort != "" {
+		merged.ReasoningEffort = other.ReasoningEffort
+	}
+	if other.EvalInterval != nil {
+		merged.EvalInterval = other.EvalInterval
+	}
+	if other.EvalSamples != nil {
+		merged.EvalSamples = other.EvalSamples
+	}
+	return merged
+}
@@ -0,0 +1,120 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func validSweepSpec() *SweepSpec {
+	validationFile := "local:./validation.jsonl"
+	return &SweepSpec{
+		Name: "lr-sweep",
+		Job: &CreateFineTuningRequest{
+			BaseModel:      "gpt-4o-mini",
+			TrainingFile:   "local:./training.jsonl",
+			ValidationFile: &validationFile,
+		},
+		Hyperparameters: []HyperparametersConfig{{LearningRateMultiplier: 0.5}},
+	}
+}
+
+func TestSweepSpec_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		modify        func(spec *SweepSpec)
+		expectedError string
+	}{
+		{name: "Valid", modify: func(spec *SweepSpec) {}},
+		{name: "ValidWithDeploy", modify: func(spec *SweepSpec) {
+			spec.Metric = MetricValidMeanTokenAccuracy
+			spec.Deploy = &SweepDeployConfig{DeploymentName: "best"}
+		}},
+		{
+			name:          "MissingName",
+			modify:        func(spec *SweepSpec) { spec.Name = "" },
+			expectedError: "name is required",
+		},
+		{
+			name:          "MissingJob",
+			modify:        func(spec *SweepSpec) { spec.Job = nil },
+			expectedError: "either job or job_file is required",
+		},
+		{
+			name:          "InvalidJob",
+			modify:        func(spec *SweepSpec) { spec.Job.BaseModel = "" },
+			expectedError: "invalid job configuration: model is required",
+		},
+		{
+			name:          "MissingValidationFile",
+			modify:        func(spec *SweepSpec) { spec.Job.ValidationFile = nil },
+			expectedError: "validation_file is required",
+		},
+		{
+			name:          "NoHyperparameters",
+			modify:        func(spec *SweepSpec) { spec.Hyperparameters = nil },
+			expectedError: "at least one hyperparameters combination is required",
+		},
+		{
+			name:          "InvalidMetric",
+			modify:        func(spec *SweepSpec) { spec.Metric = "train_loss" },
+			expectedError: "invalid metric: train_loss",
+		},
+		{
+			name:          "DeployWithoutName",
+			modify:        func(spec *SweepSpec) { spec.Deploy = &SweepDeployConfig{SKU: "Standard"} },
+			expectedError: "deploy requires deployment_name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := validSweepSpec()
+			tt.modify(spec)
+
+			err := spec.Validate()
+			if tt.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorContains(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestSweepMetric(t *testing.T) {
+	metrics := &CheckpointMetrics{FullValidLoss: 0.4, FullValidMeanTokenAccuracy: 0.8}
+
+	value, has := MetricValidLoss.Value(metrics)
+	require.True(t, has)
+	require.Equal(t, 0.4, value)
+	require.True(t, MetricValidLoss.Better(0.3, 0.4))
+
+	value, has = MetricValidMeanTokenAccuracy.Value(metrics)
+	require.True(t, has)
+	require.Equal(t, 0.8, value)
+	require.True(t, MetricValidMeanTokenAccuracy.Better(0.9, 0.8))
+
+	_, has = MetricValidLoss.Value(nil)
+	require.False(t, has)
+	_, has = MetricValidMeanTokenAccuracy.Value(&CheckpointMetrics{FullValidLoss: 0.4})
+	require.False(t, has)
+}
+
+func TestHyperparametersConfig_StringAndMerge(t *testing.T) {
+	weight := 0.1
+	base := HyperparametersConfig{Epochs: 3, BatchSize: "auto", PromptLossWeight: &weight}
+	combination := HyperparametersConfig{Epochs: 5, LearningRateMultiplier: 1.5, ReasoningEffort: "high"}
+
+	require.Equal(t, "epochs=3, batch_size=auto, prompt_loss_weight=0.1", base.String())
+	require.Equal(t, "", HyperparametersConfig{}.String())
+
+	merged := base.Merge(combination)
+	require.Equal(t,
+		"epochs=5, batch_size=auto, learning_rate_multiplier=1.5, prompt_loss_weight=0.1, reasoning_effort=high",
+		merged.String())
+	require.Equal(t, 3, base.Epochs)
+}
@@ -183,3 +183,31 @@ func formatTimePointerOrDash(t *time.Time) string {
 	}
 	return t.Format("2006-01-02 15:04")
 }
+
+// DatasetSummaryView represents the summary of a dataset validation for display
+type DatasetSummaryView struct {
+	Path             string `table:"Path"`
+	Format           string `table:"Format"`
+	Examples         int    `table:"Examples"`
+	EstimatedTokens  int    `table:"Estimated Tokens"`
+	MaxExampleTokens int    `table:"Max Example Tokens"`
+	Errors           int    `table:"Errors"`
+	Warnings         int    `table:"Warnings"`
+	Duplicates       int    `table:"Duplicates"`
+	PII              int    `table:"PII Findings"`
+}
+
+// ToSummaryView converts a DatasetReport to its summary view (for dataset validate command)
+func (r *DatasetReport) ToSummaryView() *DatasetSummaryView {
+	return &DatasetSummaryView{
+		Path:             r.Path,
+		Format:           stringOrDash(string(r.Format)),
+		Examples:         r.Examples,
+		EstimatedTokens:  r.EstimatedTokens,
+		MaxExampleTokens: r.MaxExampleTokens,
+		Errors:           len(r.Errors),
+		Warnings:         len(r.Warnings),
+		Duplicates:       len(r.Duplicates),
+		PII:              len(r.PII),
+	}
+}
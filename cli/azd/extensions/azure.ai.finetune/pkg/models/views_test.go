@@ -411,3 +411,29 @@ func TestDataView_ValidationFile(t *testing.T) {
 		require.Equal(t, "-", views.Data.ValidationFile)
 	})
 }
+
+func TestDatasetReport_ToSummaryView(t *testing.T) {
+	report := &DatasetReport{
+		Path:             "train.jsonl",
+		Format:           DatasetFormatChat,
+		Examples:         2,
+		EstimatedTokens:  120,
+		MaxExampleTokens: 70,
+		Errors:           []DatasetIssue{{Line: 3, Message: "empty line"}},
+		Duplicates:       []DatasetIssue{{Line: 2, Message: "duplicate of line 1"}},
+	}
+
+	require.Equal(t, &DatasetSummaryView{
+		Path:             "train.jsonl",
+		Format:           "chat",
+		Examples:         2,
+		EstimatedTokens:  120,
+		MaxExampleTokens: 70,
+		Errors:           1,
+		Duplicates:       1,
+	}, report.ToSummaryView())
+	require.False(t, report.Valid())
+	require.True(t, report.HasWarnings())
+
+	require.Equal(t, "-", (&DatasetReport{}).ToSummaryView().Format)
+}
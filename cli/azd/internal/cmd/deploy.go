@@ -125,6 +125,8 @@ type DeployAction struct {
 	commandRunner       exec.CommandRunner
 	alphaFeatureManager *alpha.FeatureManager
 	importManager       *project.ImportManager
+	deploymentHistory   *project.DeploymentHistoryManager
+	healthChecker       *project.HealthChecker
 }
 
 func NewDeployAction(
@@ -146,6 +148,8 @@ func NewDeployAction(
 	writer io.Writer,
 	alphaFeatureManager *alpha.FeatureManager,
 	importManager *project.ImportManager,
+	deploymentHistory *project.DeploymentHistoryManager,
+	healthChecker *project.HealthChecker,
 ) actions.Action {
 	return &DeployAction{
 		flags:               flags,
@@ -166,6 +170,8 @@ func NewDeployAction(
 		commandRunner:       commandRunner,
 		alphaFeatureManager: alphaFeatureManager,
 		importManager:       importManager,
+		deploymentHistory:   deploymentHistory,
+		healthChecker:       healthChecker,
 	}
 }
 
@@ -318,6 +324,12 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 				return err
 			}
 
+			// Record the deployment before temporary packages are removed, so that it can be rolled back to later
+			record, err := da.deploymentHistory.Record(ctx, da.env.Name(), svc, serviceContext, deployResult)
+			if err != nil {
+				log.Printf("failed recording deployment history for service '%s': %v", svc.Name, err)
+			}
+
 			// clean up for packages automatically created in temp dir
 			if da.flags.fromPackage == "" {
 				for _, artifact := range serviceContext.Package {
@@ -329,6 +341,30 @@ func (da *DeployAction) Run(ctx context.Context) (*actions.ActionResult, error)
 				}
 			}
 
+			// Fail fast when the deployed service is unhealthy, before the remaining services are deployed
+			err = async.RunWithProgressE(
+				func(checkProgress project.ServiceProgress) {
+					progressMessage := fmt.Sprintf("Deploying service %s (%s)", svc.Name, checkProgress.Message)
+					da.console.ShowSpinner(ctx, progressMessage, input.Step)
+				},
+				func(progress *async.Progress[project.ServiceProgress]) error {
+					return da.healthChecker.Check(ctx, svc, progress)
+				},
+			)
+
+			if err != nil {
+				// Unhealthy deployments must not be restored by 'azd rollback'
+				if record != nil {
+					if statusErr := da.deploymentHistory.SetStatus(
+						ctx, da.env.Name(), record, project.DeploymentStatusUnhealthy); statusErr != nil {
+						log.Printf("failed recording deployment status for service '%s': %v", svc.Name, statusErr)
+					}
+				}
+
+				da.console.StopSpinner(ctx, stepMessage, input.StepFailed)
+				return err
+			}
+
 			da.console.StopSpinner(ctx, stepMessage, input.GetStepResultFormat(err))
 			deployResults[svc.Name] = deployResult
 
@@ -21,17 +21,20 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/internal/agent/consent"
+	"github.com/azure/azure-dev/cli/azd/internal/repository"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/pipeline"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
 	"github.com/azure/azure-dev/cli/azd/pkg/update"
@@ -223,6 +226,8 @@ func classifySentinel(err error) string {
 		return "internal.cannot_change_location"
 	case errors.Is(err, internal.ErrPreviewMultipleLayers):
 		return "internal.preview_multiple_layers"
+	case errors.Is(err, internal.ErrInfraDriftDetected):
+		return "internal.infra_drift_detected"
 	case errors.Is(err, internal.ErrNoKeyNameProvided),
 		errors.Is(err, internal.ErrNoEnvValuesProvided),
 		errors.Is(err, internal.ErrInvalidFlagCombination):
@@ -270,6 +275,10 @@ func classifySentinel(err error) string {
 		return "internal.timeout"
 	case errors.Is(err, auth.ErrNoCurrentUser):
 		return "auth.not_logged_in"
+	case errors.Is(err, auth.ErrProfileNotFound):
+		return "auth.profile_not_found"
+	case errors.Is(err, config.ErrPolicyViolation):
+		return "config.policy_violation"
 	case errors.Is(err, consent.ErrToolExecutionDenied):
 		return "user.tool_denied"
 	case errors.Is(err, git.ErrNotRepository):
@@ -282,6 +291,12 @@ func classifySentinel(err error) string {
 		return "update.elevationRequired"
 	case errors.Is(err, pipeline.ErrRemoteHostIsNotAzDo):
 		return "internal.remote_not_azdo"
+	case errors.Is(err, project.ErrRolloutAborted):
+		return "service.rollout_aborted"
+	case errors.Is(err, project.ErrServiceUnhealthy):
+		return "service.unhealthy"
+	case errors.Is(err, repository.ErrTemplateLockNotFound):
+		return "template.no_lock"
 	default:
 		return ""
 	}
@@ -23,16 +23,19 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/internal/agent/consent"
+	"github.com/azure/azure-dev/cli/azd/internal/repository"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
 	"github.com/azure/azure-dev/cli/azd/pkg/auth"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
 	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	"github.com/azure/azure-dev/cli/azd/pkg/pipeline"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
 	"github.com/azure/azure-dev/cli/azd/test/mocks/mocktracing"
@@ -279,6 +282,20 @@ func Test_MapError(t *testing.T) {
 			wantErrReason:  "auth.not_logged_in",
 			wantErrDetails: nil,
 		},
+		{
+			name:           "WithErrProfileNotFound",
+			err:            fmt.Errorf("'customer-a': %w", auth.ErrProfileNotFound),
+			wantErrReason:  "auth.profile_not_found",
+			wantErrDetails: nil,
+		},
+		{
+			name: "WithErrPolicyViolation",
+			err: fmt.Errorf("failed setting configuration value: %w", &config.PolicyViolationError{
+				Key: "provision.preflight", PolicyKey: "provision.preflight", Path: "/etc/azd/policy.json",
+			}),
+			wantErrReason:  "config.policy_violation",
+			wantErrDetails: nil,
+		},
 		{
 			name:           "WithWrappedErrNoCurrentUser",
 			err:            fmt.Errorf("failed to create credential: %w: %w", errors.New("inner"), auth.ErrNoCurrentUser),
@@ -303,6 +320,24 @@ func Test_MapError(t *testing.T) {
 			wantErrReason:  "internal.preview_not_supported",
 			wantErrDetails: nil,
 		},
+		{
+			name:           "WithErrRolloutAborted",
+			err:            project.ErrRolloutAborted,
+			wantErrReason:  "service.rollout_aborted",
+			wantErrDetails: nil,
+		},
+		{
+			name:           "WithErrServiceUnhealthy",
+			err:            project.ErrServiceUnhealthy,
+			wantErrReason:  "service.unhealthy",
+			wantErrDetails: nil,
+		},
+		{
+			name:           "WithErrTemplateLockNotFound",
+			err:            repository.ErrTemplateLockNotFound,
+			wantErrReason:  "template.no_lock",
+			wantErrDetails: nil,
+		},
 		{
 			name:           "WithErrBindMountOperationDisabled",
 			err:            provisioning.ErrBindMountOperationDisabled,
@@ -549,6 +584,20 @@ func Test_MapError(t *testing.T) {
 					"internal.preview_multiple_layers"),
 			},
 		},
+		{
+			name: "WithErrInfraDriftDetected",
+			err: &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf(
+					"2 resources changed: %w",
+					internal.ErrInfraDriftDetected),
+				Suggestion: "Run azd provision.",
+			},
+			wantErrReason: "error.suggestion",
+			wantErrDetails: []attribute.KeyValue{
+				fields.ErrType.String(
+					"internal.infra_drift_detected"),
+			},
+		},
 		{
 			name: "WithErrNoKeyNameProvided",
 			err: &internal.ErrorWithSuggestion{
@@ -978,6 +1027,8 @@ func Test_ClassifySuggestionType_MatchesMapError(t *testing.T) {
 		{name: "context.Canceled", err: context.Canceled},
 		{name: "context.DeadlineExceeded", err: context.DeadlineExceeded},
 		{name: "ErrNoCurrentUser", err: auth.ErrNoCurrentUser},
+		{name: "ErrProfileNotFound", err: auth.ErrProfileNotFound},
+		{name: "ErrPolicyViolation", err: config.ErrPolicyViolation},
 		{name: "ErrNoProject", err: azdcontext.ErrNoProject},
 		{name: "ErrNotFound", err: environment.ErrNotFound},
 		{name: "ErrToolExecutionDenied", err: consent.ErrToolExecutionDenied},
@@ -985,7 +1036,11 @@ func Test_ClassifySuggestionType_MatchesMapError(t *testing.T) {
 		{name: "ErrPreviewNotSupported", err: azapi.ErrPreviewNotSupported},
 		{name: "ErrBindMountDisabled", err: provisioning.ErrBindMountOperationDisabled},
 		{name: "ErrRemoteHostIsNotAzDo", err: pipeline.ErrRemoteHostIsNotAzDo},
+		{name: "ErrRolloutAborted", err: project.ErrRolloutAborted},
+		{name: "ErrServiceUnhealthy", err: project.ErrServiceUnhealthy},
+		{name: "ErrTemplateLockNotFound", err: repository.ErrTemplateLockNotFound},
 		{name: "ErrInfraNotProvisioned", err: internal.ErrInfraNotProvisioned},
+		{name: "ErrInfraDriftDetected", err: internal.ErrInfraDriftDetected},
 		{name: "ErrKeyNotFound", err: internal.ErrKeyNotFound},
 		{name: "ErrExtensionNotFound", err: internal.ErrExtensionNotFound},
 		{name: "ErrOperationCancelled", err: internal.ErrOperationCancelled},
@@ -33,6 +33,8 @@ import (
 type ProvisionFlags struct {
 	noProgress            bool
 	preview               bool
+	cost                  bool
+	checkDrift            bool
 	ignoreDeploymentState bool
 	subscription          string
 	location              string
@@ -79,6 +81,17 @@ func (i *ProvisionFlags) Location() string {
 
 func (i *ProvisionFlags) bindCommon(local *pflag.FlagSet, global *internal.GlobalCommandOptions) {
 	local.BoolVar(&i.preview, "preview", false, "Preview changes to Azure resources.")
+	local.BoolVar(
+		&i.cost,
+		"cost",
+		false,
+		"Estimates the monthly cost of the previewed Azure resources. Requires --preview.")
+	local.BoolVar(
+		&i.checkDrift,
+		"check-drift",
+		false,
+		"Reports changes made to the provisioned Azure resources outside of azd. "+
+			"Exits with a non-zero code when drift is detected.")
 	local.BoolVar(
 		&i.ignoreDeploymentState,
 		"no-state",
@@ -135,6 +148,7 @@ type ProvisionAction struct {
 	subManager          *account.SubscriptionsManager
 	importManager       *project.ImportManager
 	alphaFeatureManager *alpha.FeatureManager
+	costEstimator       *provisioning.CostEstimator
 	portalUrlBase       string
 }
 
@@ -153,6 +167,7 @@ func NewProvisionAction(
 	writer io.Writer,
 	subManager *account.SubscriptionsManager,
 	alphaFeatureManager *alpha.FeatureManager,
+	costEstimator *provisioning.CostEstimator,
 	cloud *cloud.Cloud,
 ) actions.Action {
 	return &ProvisionAction{
@@ -170,6 +185,7 @@ func NewProvisionAction(
 		subManager:          subManager,
 		importManager:       importManager,
 		alphaFeatureManager: alphaFeatureManager,
+		costEstimator:       costEstimator,
 		portalUrlBase:       cloud.PortalUrlBase,
 	}
 }
@@ -194,6 +210,21 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 		)
 	}
 	previewMode := p.flags.preview
+	driftMode := p.flags.checkDrift
+
+	if previewMode && driftMode {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        fmt.Errorf("'--preview' and '--check-drift': %w", internal.ErrInvalidFlagCombination),
+			Suggestion: "Use either '--preview' to preview local changes or '--check-drift' to detect remote changes.",
+		}
+	}
+
+	if p.flags.cost && !previewMode {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        fmt.Errorf("'--cost' without '--preview': %w", internal.ErrInvalidFlagCombination),
+			Suggestion: "Run 'azd provision --preview --cost' to estimate the cost of the previewed resources.",
+		}
+	}
 
 	// Command title
 	defaultTitle := "Provisioning Azure resources (azd provision)"
@@ -201,6 +232,9 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 	if previewMode {
 		defaultTitle = "Previewing Azure resource changes (azd provision --preview)"
 		defaultTitleNote = "This is a preview. No changes will be applied to your Azure resources."
+	} else if driftMode {
+		defaultTitle = "Checking Azure resources for drift (azd provision --check-drift)"
+		defaultTitleNote = "No changes will be applied to your Azure resources."
 	}
 
 	p.console.MessageUxItem(ctx, &ux.MessageTitle{
@@ -279,6 +313,7 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 	}
 
 	allSkipped := true
+	drift := []*DriftedResource{}
 	for i, layer := range layers {
 		layer.IgnoreDeploymentState = p.flags.ignoreDeploymentState
 		if err := p.provisionManager.Initialize(ctx, p.projectConfig.Path, layer); err != nil {
@@ -337,6 +372,21 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 			p.console.WarnForFeature(ctx, azapi.FeatureDeploymentStacks)
 		}
 
+		// Drift detection doesn't apply any change, the drift of every layer is reported once all layers are checked
+		if driftMode {
+			driftResult, err := p.provisionManager.CheckDrift(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("checking drift: %w", err)
+			}
+
+			if driftResult.HasDrift() {
+				p.console.MessageUxItem(ctx, previewChangesToUx(driftResult.Changes))
+			}
+
+			drift = append(drift, newDriftedResources(layer.Name, driftResult.Changes)...)
+			continue
+		}
+
 		// Do not raise pre/postprovision events in preview mode
 		if previewMode {
 			deployPreviewResult, err = p.provisionManager.Preview(ctx)
@@ -407,6 +457,16 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 		if previewMode {
 			p.console.MessageUxItem(ctx, deployResultToUx(deployPreviewResult))
 
+			if p.flags.cost {
+				estimate, err := p.costEstimator.Estimate(ctx, deployPreviewResult.Preview.Properties.Changes)
+				if err != nil {
+					return nil, fmt.Errorf("estimating cost: %w", err)
+				}
+
+				p.console.Message(ctx, "")
+				p.console.MessageUxItem(ctx, costEstimateToUx(estimate))
+			}
+
 			return &actions.ActionResult{
 				Message: &actions.ResultMessage{
 					Header: fmt.Sprintf(
@@ -470,6 +530,10 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 		}
 	}
 
+	if driftMode {
+		return p.driftResult(ctx, drift, startTime)
+	}
+
 	if allSkipped {
 		return &actions.ActionResult{
 			Message: &actions.ResultMessage{
@@ -502,8 +566,13 @@ func (p *ProvisionAction) Run(ctx context.Context) (*actions.ActionResult, error
 
 // deployResultToUx creates the ux element to display from a provision preview
 func deployResultToUx(previewResult *provisioning.DeployPreviewResult) ux.UxItem {
+	return previewChangesToUx(previewResult.Preview.Properties.Changes)
+}
+
+// previewChangesToUx creates the ux element to display from a list of resource changes
+func previewChangesToUx(changes []*provisioning.DeploymentPreviewChange) ux.UxItem {
 	var operations []*ux.Resource
-	for _, change := range previewResult.Preview.Properties.Changes {
+	for _, change := range changes {
 		// Convert property deltas to UX format
 		var propertyDeltas []ux.PropertyDelta
 		for _, delta := range change.Delta {
@@ -527,6 +596,119 @@ func deployResultToUx(previewResult *provisioning.DeployPreviewResult) ux.UxItem
 	}
 }
 
+// costEstimateToUx creates the ux element to display from the cost estimate of a provision preview
+func costEstimateToUx(estimate *provisioning.CostEstimate) ux.UxItem {
+	resources := make([]*ux.ResourceCost, 0, len(estimate.Resources))
+	for _, resource := range estimate.Resources {
+		resources = append(resources, &ux.ResourceCost{
+			Operation:          ux.OperationType(resource.ChangeType),
+			Type:               resource.ResourceType,
+			Name:               resource.Name,
+			Sku:                resource.Sku,
+			Location:           resource.Location,
+			Capacity:           resource.Capacity,
+			CurrentMonthlyCost: resource.CurrentMonthlyCost,
+			MonthlyCost:        resource.MonthlyCost,
+			Priced:             resource.Priced,
+		})
+	}
+
+	return &ux.CostEstimate{
+		Currency:           estimate.Currency,
+		CurrentMonthlyCost: estimate.CurrentMonthlyCost,
+		MonthlyCost:        estimate.MonthlyCost,
+		MonthlyCostDelta:   estimate.MonthlyCostDelta,
+		Resources:          resources,
+	}
+}
+
+// ProvisionDriftResult is the result of 'azd provision --check-drift'
+type ProvisionDriftResult struct {
+	Timestamp time.Time          `json:"timestamp"`
+	Drifted   bool               `json:"drifted"`
+	Resources []*DriftedResource `json:"resources"`
+}
+
+// DriftedResource is a provisioned resource changed outside of azd
+type DriftedResource struct {
+	Layer        string                  `json:"layer,omitempty"`
+	ChangeType   provisioning.ChangeType `json:"changeType"`
+	ResourceId   string                  `json:"resourceId,omitempty"`
+	ResourceType string                  `json:"resourceType"`
+	Name         string                  `json:"name"`
+	Properties   []DriftedProperty       `json:"properties,omitempty"`
+}
+
+// DriftedProperty is a property of a resource changed outside of azd
+type DriftedProperty struct {
+	ChangeType provisioning.PropertyChangeType `json:"changeType"`
+	Path       string                          `json:"path"`
+	Before     any                             `json:"before,omitempty"`
+	After      any                             `json:"after,omitempty"`
+}
+
+// newDriftedResources converts the drift of a layer into its structured result
+func newDriftedResources(layer string, changes []*provisioning.DeploymentPreviewChange) []*DriftedResource {
+	resources := make([]*DriftedResource, 0, len(changes))
+	for _, change := range changes {
+		resource := &DriftedResource{
+			Layer:        layer,
+			ChangeType:   change.ChangeType,
+			ResourceId:   change.ResourceId.Id,
+			ResourceType: change.ResourceType,
+			Name:         change.Name,
+		}
+
+		for _, delta := range change.Delta {
+			resource.Properties = append(resource.Properties, DriftedProperty{
+				ChangeType: delta.ChangeType,
+				Path:       delta.Path,
+				Before:     delta.Before,
+				After:      delta.After,
+			})
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources
+}
+
+// driftResult reports the drift of all the checked layers. Drift is returned as an error so that the command exits with a
+// non-zero code, which scheduled jobs can alert on.
+func (p *ProvisionAction) driftResult(
+	ctx context.Context,
+	drift []*DriftedResource,
+	startTime time.Time,
+) (*actions.ActionResult, error) {
+	if p.formatter.Kind() == output.JsonFormat {
+		result := ProvisionDriftResult{
+			Timestamp: time.Now(),
+			Drifted:   len(drift) > 0,
+			Resources: drift,
+		}
+
+		if err := p.formatter.Format(result, p.writer, nil); err != nil {
+			return nil, fmt.Errorf("drift result could not be displayed: %w", err)
+		}
+	}
+
+	if len(drift) > 0 {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"%d resource(s) changed outside of azd: %w", len(drift), internal.ErrInfraDriftDetected),
+			Suggestion: "Run 'azd provision --no-state' to restore the provisioned resources, " +
+				"or update your infrastructure files to keep the changes.",
+		}
+	}
+
+	return &actions.ActionResult{
+		Message: &actions.ResultMessage{
+			Header: fmt.Sprintf("No infrastructure drift detected in %s.", ux.DurationAsText(since(startTime))),
+		},
+	}, nil
+}
+
 func GetCmdProvisionHelpDescription(c *cobra.Command) string {
 	return generateCmdHelpDescription(
 		fmt.Sprintf(
@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
@@ -43,6 +44,8 @@ import (
 type showFlags struct {
 	global      *internal.GlobalCommandOptions
 	showSecrets bool
+	watch       bool
+	interval    time.Duration
 	internal.EnvFlag
 }
 
@@ -54,6 +57,18 @@ func (s *showFlags) Bind(local *pflag.FlagSet, global *internal.GlobalCommandOpt
 		false,
 		"Unmask secrets in output.",
 	)
+	local.BoolVar(
+		&s.watch,
+		"watch",
+		false,
+		"Continuously refresh the status, revisions, replicas and endpoint health of the deployed services.",
+	)
+	local.DurationVar(
+		&s.interval,
+		"interval",
+		defaultWatchInterval,
+		"The time between two refreshes of the status when --watch is set.",
+	)
 	s.global = global
 }
 
@@ -93,6 +108,8 @@ type showAction struct {
 	lazyResourceManager  *lazy.Lazy[project.ResourceManager]
 	portalUrlBase        string
 	stateCacheManager    *state.StateCacheManager
+	healthProber         *project.HealthProber
+	deploymentHistory    *project.DeploymentHistoryManager
 }
 
 func NewShowAction(
@@ -114,6 +131,8 @@ func NewShowAction(
 	lazyServiceManager *lazy.Lazy[project.ServiceManager],
 	lazyResourceManager *lazy.Lazy[project.ResourceManager],
 	cloud *cloud.Cloud,
+	healthProber *project.HealthProber,
+	deploymentHistory *project.DeploymentHistoryManager,
 ) actions.Action {
 	return &showAction{
 		projectConfig:        projectConfig,
@@ -135,10 +154,16 @@ func NewShowAction(
 		lazyResourceManager:  lazyResourceManager,
 		portalUrlBase:        cloud.PortalUrlBase,
 		stateCacheManager:    envManager.GetStateCacheManager(),
+		healthProber:         healthProber,
+		deploymentHistory:    deploymentHistory,
 	}
 }
 
 func (s *showAction) Run(ctx context.Context) (*actions.ActionResult, error) {
+	if s.flags.watch {
+		return nil, s.watch(ctx)
+	}
+
 	s.console.ShowSpinner(ctx, "Gathering information about your app and its resources...", input.Step)
 	defer s.console.StopSpinner(ctx, "", input.Step)
 
@@ -0,0 +1,317 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package show
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/azure/azure-dev/cli/azd/pkg/output/ux"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	uxlib "github.com/azure/azure-dev/cli/azd/pkg/ux"
+)
+
+const (
+	defaultWatchInterval = 10 * time.Second
+	// The time to wait for the endpoint of a service to respond, unless its health check sets a shorter timeout
+	watchEndpointTimeout = 5 * time.Second
+)
+
+// watchedService is a service whose status is refreshed by `azd show --watch`
+type watchedService struct {
+	serviceConfig  *project.ServiceConfig
+	serviceTarget  project.ServiceTarget
+	targetResource *environment.TargetResource
+}
+
+// watch refreshes the status of the services of the project every interval until the context is cancelled. The status
+// is rendered as a dashboard, or written as a line of JSON per refresh when the output format is JSON.
+func (s *showAction) watch(ctx context.Context) error {
+	if len(s.args) > 0 {
+		return &internal.ErrorWithSuggestion{
+			Err:        fmt.Errorf("--watch cannot be used with a resource: %w", internal.ErrInvalidFlagCombination),
+			Suggestion: "Run 'azd show --watch' to watch the status of all the services of the project.",
+		}
+	}
+
+	if s.flags.interval <= 0 {
+		return fmt.Errorf("--interval must be a positive duration, got %s: %w",
+			s.flags.interval, internal.ErrInvalidFlagCombination)
+	}
+
+	env, err := s.watchedEnvironment(ctx)
+	if err != nil {
+		return err
+	}
+
+	stableServices, err := s.importManager.ServiceStable(ctx, s.projectConfig)
+	if err != nil {
+		return err
+	}
+
+	services := make([]*watchedService, len(stableServices))
+	for index, serviceConfig := range stableServices {
+		services[index] = &watchedService{serviceConfig: serviceConfig}
+	}
+	slices.SortFunc(services, func(a, b *watchedService) int {
+		return strings.Compare(a.serviceConfig.Name, b.serviceConfig.Name)
+	})
+
+	var render func(status *contracts.ShowStatusResult) error
+	if s.formatter.Kind() == output.JsonFormat {
+		encoder := json.NewEncoder(s.writer)
+		render = func(status *contracts.ShowStatusResult) error {
+			return encoder.Encode(status)
+		}
+	} else {
+		var mu sync.Mutex
+		dashboard := &ux.ShowStatus{Interval: s.flags.interval}
+
+		canvas := uxlib.NewCanvas(uxlib.NewVisualElement(func(printer uxlib.Printer) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			printer.Fprintln()
+			printer.Fprintf("%s", dashboard.ToString("  "))
+			return nil
+		})).WithWriter(s.writer)
+		defer canvas.Close()
+
+		render = func(status *contracts.ShowStatusResult) error {
+			mu.Lock()
+			dashboard.Status = status
+			mu.Unlock()
+
+			return canvas.Run()
+		}
+	}
+
+	ticker := time.NewTicker(s.flags.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := render(s.collectStatus(ctx, env, services)); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchedEnvironment loads the environment whose services are watched, which must have been provisioned
+func (s *showAction) watchedEnvironment(ctx context.Context) (*environment.Environment, error) {
+	environmentName := s.flags.EnvironmentName
+	if environmentName == "" {
+		var err error
+		environmentName, err = s.azdCtx.GetDefaultEnvironmentName()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if environmentName == "" {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        fmt.Errorf("no environment selected: %w", internal.ErrInfraNotProvisioned),
+			Suggestion: "Run 'azd up' to provision and deploy the project, or select an environment with '-e'.",
+		}
+	}
+
+	env, err := s.envManager.Get(ctx, environmentName)
+	if errors.Is(err, environment.ErrNotFound) {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        fmt.Errorf("environment '%s' does not exist: %w", environmentName, environment.ErrNotFound),
+			Suggestion: "Run 'azd env new <name>' to create an environment.",
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if env.GetSubscriptionId() == "" {
+		return nil, &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"environment '%s' has not been provisioned: %w", environmentName, internal.ErrInfraNotProvisioned),
+			Suggestion: "Run 'azd up' to provision and deploy the project before watching its status.",
+		}
+	}
+
+	return env, nil
+}
+
+// collectStatus returns the status of every service. Failures are reported in the status of the service, so one
+// unreachable service doesn't stop the dashboard from refreshing the others.
+func (s *showAction) collectStatus(
+	ctx context.Context,
+	env *environment.Environment,
+	services []*watchedService,
+) *contracts.ShowStatusResult {
+	result := &contracts.ShowStatusResult{
+		Name:        s.projectConfig.Name,
+		Environment: env.Name(),
+		Timestamp:   time.Now(),
+		Services:    make([]contracts.ShowServiceStatus, len(services)),
+	}
+
+	for index, service := range services {
+		result.Services[index] = s.serviceStatus(ctx, env, service)
+	}
+
+	return result
+}
+
+// serviceStatus returns the runtime status and the endpoint health of a service
+func (s *showAction) serviceStatus(
+	ctx context.Context,
+	env *environment.Environment,
+	service *watchedService,
+) contracts.ShowServiceStatus {
+	serviceConfig := service.serviceConfig
+	status := contracts.ShowServiceStatus{
+		Name:           serviceConfig.Name,
+		Host:           string(serviceConfig.Host),
+		Status:         contracts.ShowServiceStateUnknown,
+		EndpointHealth: contracts.ShowEndpointHealthUnknown,
+	}
+
+	if s.deploymentHistory != nil {
+		if records, err := s.deploymentHistory.List(ctx, env.Name(), serviceConfig.Name); err != nil {
+			log.Printf("failed loading deployment history of service '%s': %v", serviceConfig.Name, err)
+		} else if len(records) > 0 {
+			status.LastDeployed = &records[0].Timestamp
+		}
+	}
+
+	// Target resources are resolved once, until the service has been deployed
+	if service.targetResource == nil {
+		if err := s.resolveWatchedService(ctx, env, service); err != nil {
+			status.Error = err.Error()
+			return status
+		}
+	}
+
+	targetResource := service.targetResource
+	if targetResource.ResourceName() != "" && targetResource.ResourceType() != "" {
+		status.ResourceId = fmt.Sprintf("%s/providers/%s/%s",
+			azure.ResourceGroupRID(targetResource.SubscriptionId(), targetResource.ResourceGroupName()),
+			targetResource.ResourceType(),
+			targetResource.ResourceName())
+	}
+
+	if reporter, ok := service.serviceTarget.(project.ServiceStatusReporter); ok {
+		serviceStatus, err := reporter.Status(ctx, serviceConfig, targetResource)
+		if err != nil {
+			status.Error = err.Error()
+		} else {
+			status.Status = contracts.ShowServiceState(strings.ToLower(string(serviceStatus.State)))
+			status.Revision = serviceStatus.Revision
+			status.ReadyReplicas = serviceStatus.ReadyReplicas
+			status.DesiredReplicas = serviceStatus.DesiredReplicas
+			if serviceStatus.LastDeployed != nil {
+				status.LastDeployed = serviceStatus.LastDeployed
+			}
+		}
+	}
+
+	endpoints, err := service.serviceTarget.Endpoints(ctx, serviceConfig, targetResource)
+	if err != nil {
+		log.Printf("failed getting endpoints of service '%s': %v", serviceConfig.Name, err)
+	}
+
+	if overridden := project.OverriddenEndpoints(ctx, serviceConfig, env); len(overridden) > 0 {
+		endpoints = overridden
+	}
+
+	if len(endpoints) > 0 {
+		status.Endpoint = endpoints[0]
+		status.EndpointHealth = s.endpointHealth(ctx, serviceConfig, status.Endpoint)
+	}
+
+	return status
+}
+
+// resolveWatchedService resolves the service target and the target resource of a watched service
+func (s *showAction) resolveWatchedService(
+	ctx context.Context,
+	env *environment.Environment,
+	service *watchedService,
+) error {
+	resourceManager, err := s.lazyResourceManager.GetValue()
+	if err != nil {
+		return err
+	}
+
+	serviceManager, err := s.lazyServiceManager.GetValue()
+	if err != nil {
+		return err
+	}
+
+	if service.serviceTarget == nil {
+		// Initialize the service to ensure external service targets can create provider instances
+		if err := serviceManager.Initialize(ctx, service.serviceConfig); err != nil {
+			return fmt.Errorf("initializing service: %w", err)
+		}
+
+		serviceTarget, err := serviceManager.GetServiceTarget(ctx, service.serviceConfig)
+		if err != nil {
+			return fmt.Errorf("getting service target: %w", err)
+		}
+
+		service.serviceTarget = serviceTarget
+	}
+
+	targetResource, err := s.resolveTargetResource(
+		ctx, service.serviceTarget, resourceManager, env.GetSubscriptionId(), service.serviceConfig)
+	if err != nil {
+		return fmt.Errorf("the service has not been deployed: %w", err)
+	}
+
+	service.targetResource = targetResource
+	return nil
+}
+
+// endpointHealth requests the endpoint of a service once, on the path of its health check when it has one
+func (s *showAction) endpointHealth(
+	ctx context.Context,
+	serviceConfig *project.ServiceConfig,
+	endpoint string,
+) contracts.ShowEndpointHealth {
+	if s.healthProber == nil {
+		return contracts.ShowEndpointHealthUnknown
+	}
+
+	check := &project.HealthCheck{
+		Timeout: watchEndpointTimeout,
+		Retries: new(0),
+	}
+
+	if serviceConfig.HealthCheck != nil {
+		check.Path = serviceConfig.HealthCheck.Path
+		check.ExpectedStatus = serviceConfig.HealthCheck.ExpectedStatus
+		if timeout := serviceConfig.HealthCheck.Timeout; timeout > 0 && timeout < check.Timeout {
+			check.Timeout = timeout
+		}
+	}
+
+	if err := s.healthProber.Check(ctx, check, endpoint); err != nil {
+		log.Printf("endpoint %s of service '%s' is unhealthy: %v", endpoint, serviceConfig.Name, err)
+		return contracts.ShowEndpointHealthUnhealthy
+	}
+
+	return contracts.ShowEndpointHealthHealthy
+}
@@ -0,0 +1,68 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package show
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_showWatch_InvalidFlags(t *testing.T) {
+	t.Run("WithResource", func(t *testing.T) {
+		action := &showAction{
+			args:  []string{"cache"},
+			flags: &showFlags{watch: true, interval: time.Second},
+		}
+
+		err := action.watch(context.Background())
+		require.ErrorIs(t, err, internal.ErrInvalidFlagCombination)
+	})
+
+	t.Run("InvalidInterval", func(t *testing.T) {
+		action := &showAction{
+			flags: &showFlags{watch: true},
+		}
+
+		err := action.watch(context.Background())
+		require.ErrorIs(t, err, internal.ErrInvalidFlagCombination)
+	})
+}
+
+func Test_showWatch_EndpointHealth(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+
+	var requested string
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.URL.Host == "api.example.com"
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		requested = request.URL.String()
+		if request.URL.Path == "/health" {
+			return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+		}
+
+		return mocks.CreateEmptyHttpResponse(request, http.StatusServiceUnavailable)
+	})
+
+	action := &showAction{healthProber: project.NewHealthProber(mockContext.HttpClient)}
+
+	// The root of the endpoint is requested when the service has no health check
+	health := action.endpointHealth(*mockContext.Context, &project.ServiceConfig{Name: "api"}, "https://api.example.com/")
+	require.Equal(t, contracts.ShowEndpointHealthUnhealthy, health)
+	require.Equal(t, "https://api.example.com/", requested)
+
+	health = action.endpointHealth(*mockContext.Context, &project.ServiceConfig{
+		Name:        "api",
+		HealthCheck: &project.HealthCheck{Path: "/health"},
+	}, "https://api.example.com/")
+	require.Equal(t, contracts.ShowEndpointHealthHealthy, health)
+	require.Equal(t, "https://api.example.com/health", requested)
+}
@@ -55,6 +55,7 @@ var (
 	ErrCannotChangeSubscription = errors.New("cannot change subscription for existing environment")
 	ErrCannotChangeLocation     = errors.New("cannot change location for existing environment")
 	ErrPreviewMultipleLayers    = errors.New("--preview cannot be used when provisioning multiple layers")
+	ErrInfraDriftDetected       = errors.New("infrastructure drift detected")
 )
 
 // Init command errors
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/internal/mapper"
 	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
@@ -29,6 +30,19 @@ var noEnvResolver = func(name string) string {
 	return ""
 }
 
+// defaultEventHandlerTimeout is the maximum time azd waits for an extension event handler
+// when the extension does not request a specific timeout in its subscription.
+const defaultEventHandlerTimeout = 15 * time.Minute
+
+// handlerTimeout returns the timeout requested by a subscription or the default timeout.
+func handlerTimeout(timeoutSeconds int32) time.Duration {
+	if timeoutSeconds <= 0 {
+		return defaultEventHandlerTimeout
+	}
+
+	return time.Duration(timeoutSeconds) * time.Second
+}
+
 // eventService implements azdext.EventServiceServer.
 type eventService struct {
 	azdext.UnimplementedEventServiceServer
@@ -116,7 +130,9 @@ func (s *eventService) onSubscribeProjectEvent(
 
 		evt := ext.Event(eventName)
 		// Pass the stream context (ctx) which has extension claims
-		handler := s.createProjectEventHandler(ctx, extension, eventName, broker)
+		handler := s.createProjectEventHandler(
+			ctx, extension, eventName, handlerTimeout(subscribeMsg.TimeoutSeconds), broker,
+		)
 		if err := projectConfig.AddHandler(ctx, evt, handler); err != nil {
 			return fmt.Errorf("failed to add handler for event %s: %w", eventName, err)
 		}
@@ -129,6 +145,7 @@ func (s *eventService) createProjectEventHandler(
 	streamCtx context.Context,
 	extension *extensions.Extension,
 	eventName string,
+	timeout time.Duration,
 	broker *grpcbroker.MessageBroker[azdext.EventMessage],
 ) ext.EventHandlerFn[project.ProjectLifecycleEventArgs] {
 	return func(ctx context.Context, args project.ProjectLifecycleEventArgs) error {
@@ -158,7 +175,13 @@ func (s *eventService) createProjectEventHandler(
 
 		return s.runWithEnvReload(ctx, func() error {
 			// Use streamCtx which has extension claims for correlation
-			response, err := broker.SendAndWait(streamCtx, invokeMsg)
+			waitCtx, cancel := context.WithTimeout(streamCtx, timeout)
+			defer cancel()
+
+			response, err := broker.SendAndWait(waitCtx, invokeMsg)
+			if errors.Is(err, context.DeadlineExceeded) {
+				return fmt.Errorf("extension %s project hook %s timed out after %s", extension.Id, eventName, timeout)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to send invoke message for event %s: %w", eventName, err)
 			}
@@ -178,7 +201,7 @@ func (s *eventService) createProjectEventHandler(
 				)
 			}
 
-			return nil
+			return s.applyEnvValues(ctx, statusMsg.ProjectHandlerStatus.Env)
 		})
 	}
 }
@@ -208,7 +231,9 @@ func (s *eventService) onSubscribeServiceEvent(
 			}
 
 			// Pass the stream context (ctx) which has extension claims
-			handler := s.createServiceEventHandler(ctx, serviceConfig, extension, eventName, broker)
+			handler := s.createServiceEventHandler(
+				ctx, serviceConfig, extension, eventName, handlerTimeout(subscribeMsg.TimeoutSeconds), broker,
+			)
 			if err := serviceConfig.AddHandler(ctx, evt, handler); err != nil {
 				return fmt.Errorf("failed to add handler for event %s: %w", eventName, err)
 			}
@@ -223,6 +248,7 @@ func (s *eventService) createServiceEventHandler(
 	serviceConfig *project.ServiceConfig,
 	extension *extensions.Extension,
 	eventName string,
+	timeout time.Duration,
 	broker *grpcbroker.MessageBroker[azdext.EventMessage],
 ) ext.EventHandlerFn[project.ServiceLifecycleEventArgs] {
 	return func(ctx context.Context, args project.ServiceLifecycleEventArgs) error {
@@ -266,7 +292,19 @@ func (s *eventService) createServiceEventHandler(
 
 		return s.runWithEnvReload(ctx, func() error {
 			// Use streamCtx which has extension claims for correlation
-			response, err := broker.SendAndWait(streamCtx, invokeMsg)
+			waitCtx, cancel := context.WithTimeout(streamCtx, timeout)
+			defer cancel()
+
+			response, err := broker.SendAndWait(waitCtx, invokeMsg)
+			if errors.Is(err, context.DeadlineExceeded) {
+				return fmt.Errorf(
+					"extension %s service hook %s.%s timed out after %s",
+					extension.Id,
+					args.Service.Name,
+					eventName,
+					timeout,
+				)
+			}
 			if err != nil {
 				return fmt.Errorf("failed to send invoke message for service event %s: %w", eventName, err)
 			}
@@ -287,11 +325,65 @@ func (s *eventService) createServiceEventHandler(
 				)
 			}
 
-			return nil
+			if err := applyServiceContext(statusMsg.ServiceHandlerStatus.ServiceContext, args.ServiceContext); err != nil {
+				return fmt.Errorf(
+					"applying service context from extension %s service hook %s.%s: %w",
+					extension.Id,
+					args.Service.Name,
+					eventName,
+					err,
+				)
+			}
+
+			return s.applyEnvValues(ctx, statusMsg.ServiceHandlerStatus.Env)
 		})
 	}
 }
 
+// applyEnvValues sets the environment values returned by an extension event handler
+// and persists them so they are visible to the remainder of the operation.
+func (s *eventService) applyEnvValues(ctx context.Context, values map[string]string) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	envManager, err := s.lazyEnvManager.GetValue()
+	if err != nil {
+		return err
+	}
+
+	env, err := s.lazyEnv.GetValue()
+	if err != nil {
+		return err
+	}
+
+	for key, value := range values {
+		env.DotenvSet(key, value)
+	}
+
+	if err := envManager.Save(ctx, env); err != nil {
+		return fmt.Errorf("saving environment values from extension event handler: %w", err)
+	}
+
+	return nil
+}
+
+// applyServiceContext replaces the artifacts of the target service context with the ones returned
+// by an extension event handler. A nil source leaves the target unchanged.
+func applyServiceContext(src *azdext.ServiceContext, target *project.ServiceContext) error {
+	if src == nil || target == nil {
+		return nil
+	}
+
+	var serviceContext *project.ServiceContext
+	if err := mapper.Convert(src, &serviceContext); err != nil {
+		return err
+	}
+
+	*target = *serviceContext
+	return nil
+}
+
 // syncExtensionOutput displays the extension output in the preview experience.
 // defer the returned function to stop the previewer when the function exits.
 func (s *eventService) syncExtensionOutput(
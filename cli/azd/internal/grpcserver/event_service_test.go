@@ -6,6 +6,7 @@ package grpcserver
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
 	"github.com/azure/azure-dev/cli/azd/pkg/environment"
@@ -294,7 +295,7 @@ func TestEventService_createProjectEventHandler(t *testing.T) {
 	var mockBroker *grpcbroker.MessageBroker[azdext.EventMessage]
 
 	// Create the handler
-	handler := service.createProjectEventHandler(streamCtx, extension, eventName, mockBroker)
+	handler := service.createProjectEventHandler(streamCtx, extension, eventName, time.Minute, mockBroker)
 	require.NotNil(t, handler)
 
 	// Test that the handler function is created correctly
@@ -324,7 +325,9 @@ func TestEventService_createServiceEventHandler(t *testing.T) {
 	var mockBroker *grpcbroker.MessageBroker[azdext.EventMessage]
 
 	// Create the handler
-	handler := service.createServiceEventHandler(streamCtx, serviceConfig, extension, eventName, mockBroker)
+	handler := service.createServiceEventHandler(
+		streamCtx, serviceConfig, extension, eventName, time.Minute, mockBroker,
+	)
 	require.NotNil(t, handler)
 
 	// Test that the handler function is created correctly
@@ -360,3 +363,47 @@ func TestEventService_New(t *testing.T) {
 	assert.NotNil(t, eventSvc.lazyEnv)
 	assert.NotNil(t, eventSvc.console)
 }
+
+func TestEventService_handlerTimeout(t *testing.T) {
+	assert.Equal(t, defaultEventHandlerTimeout, handlerTimeout(0))
+	assert.Equal(t, defaultEventHandlerTimeout, handlerTimeout(-5))
+	assert.Equal(t, 90*time.Second, handlerTimeout(90))
+}
+
+func TestEventService_applyServiceContext(t *testing.T) {
+	t.Run("replaces artifacts", func(t *testing.T) {
+		target := project.NewServiceContext()
+		require.NoError(t, target.Package.Add(&project.Artifact{
+			Kind:         project.ArtifactKindContainer,
+			Location:     "original:latest",
+			LocationKind: project.LocationKindLocal,
+		}))
+
+		src := &azdext.ServiceContext{
+			Package: []*azdext.Artifact{
+				{
+					Kind:         azdext.ArtifactKind_ARTIFACT_KIND_CONTAINER,
+					Location:     "patched:latest",
+					LocationKind: azdext.LocationKind_LOCATION_KIND_LOCAL,
+				},
+			},
+		}
+
+		require.NoError(t, applyServiceContext(src, target))
+		require.Len(t, target.Package, 1)
+		assert.Equal(t, "patched:latest", target.Package[0].Location)
+	})
+
+	t.Run("nil source leaves target unchanged", func(t *testing.T) {
+		target := project.NewServiceContext()
+		require.NoError(t, target.Build.Add(&project.Artifact{
+			Kind:         project.ArtifactKindDirectory,
+			Location:     "./out",
+			LocationKind: project.LocationKindLocal,
+		}))
+
+		require.NoError(t, applyServiceContext(nil, target))
+		require.Len(t, target.Build, 1)
+		assert.Equal(t, "./out", target.Build[0].Location)
+	})
+}
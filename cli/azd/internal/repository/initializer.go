@@ -62,12 +62,14 @@ func NewInitializer(
 
 // Initializes a local repository in the project directory from a remote repository or local template directory.
 //
+// Parameterized templates are rendered with the given input values, prompting for the inputs without a value.
 // A confirmation prompt is displayed for any existing files to be overwritten.
 func (i *Initializer) Initialize(
 	ctx context.Context,
 	azdCtx *azdcontext.AzdContext,
 	template *templates.Template,
-	templateBranch string) error {
+	templateBranch string,
+	templateValues map[string]string) error {
 	var err error
 
 	staging, err := os.MkdirTemp("", "az-dev-template")
@@ -114,15 +116,13 @@ func (i *Initializer) Initialize(
 		i.console.StopSpinner(ctx, stepMessage+"\n", input.GetStepResultFormat(err))
 	}()
 
-	var filesWithExecPerms []string
-	if templates.IsLocalPath(templateUrl) {
-		err = i.copyLocalTemplate(templateUrl, staging)
-		if err == nil {
-			filesWithExecPerms, err = findExecutableFiles(staging)
-		}
-	} else {
-		filesWithExecPerms, err = i.fetchCode(ctx, templateUrl, templateBranch, staging)
+	var staged *stagedTemplate
+	staged, err = i.fetchTemplate(ctx, templateUrl, templateBranch, "", staging)
+	if err != nil {
+		return err
 	}
+
+	err = i.renderTemplate(ctx, staged, templateValues)
 	if err != nil {
 		return err
 	}
@@ -161,7 +161,24 @@ func (i *Initializer) Initialize(
 		return fmt.Errorf("initializing project: %w", err)
 	}
 
-	err = i.gitInitialize(ctx, target, filesWithExecPerms, isEmpty)
+	// Record the template so the project can be upgraded to its newer revisions
+	lock := &TemplateLock{
+		RepositoryPath: templateUrl,
+		Branch:         templateBranch,
+		Commit:         staged.commit,
+		Inputs:         staged.inputs,
+	}
+	lock.Files, err = hashTemplateFiles(staging)
+	if err != nil {
+		return err
+	}
+
+	err = lock.Save(target)
+	if err != nil {
+		return err
+	}
+
+	err = i.gitInitialize(ctx, target, staged.executableFiles, isEmpty)
 	if err != nil {
 		return err
 	}
@@ -169,31 +186,79 @@ func (i *Initializer) Initialize(
 	return nil
 }
 
+// stagedTemplate is a template fetched to a staging directory
+type stagedTemplate struct {
+	dir string
+	// commit is the commit the template was fetched from, empty for local templates
+	commit          string
+	executableFiles []string
+	// inputs are the values the template was rendered with, when parameterized
+	inputs map[string]string
+}
+
+// fetchTemplate fetches the template to the staging directory. Remote templates are fetched at the given commit when
+// set, or else at the head of the given branch.
+func (i *Initializer) fetchTemplate(
+	ctx context.Context,
+	templateUrl string,
+	templateBranch string,
+	commit string,
+	staging string,
+) (*stagedTemplate, error) {
+	staged := &stagedTemplate{dir: staging}
+
+	var err error
+	if templates.IsLocalPath(templateUrl) {
+		err = i.copyLocalTemplate(templateUrl, staging)
+		if err == nil {
+			staged.executableFiles, err = findExecutableFiles(staging)
+		}
+	} else {
+		staged.executableFiles, staged.commit, err = i.fetchCode(ctx, templateUrl, templateBranch, commit, staging)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return staged, nil
+}
+
 func (i *Initializer) fetchCode(
 	ctx context.Context,
 	templateUrl string,
 	templateBranch string,
-	destination string) (executableFilePaths []string, err error) {
-	err = i.gitCli.ShallowClone(ctx, templateUrl, templateBranch, destination)
+	commit string,
+	destination string) (executableFilePaths []string, headCommit string, err error) {
+	if commit != "" {
+		err = i.gitCli.FetchCommit(ctx, templateUrl, commit, destination)
+	} else {
+		err = i.gitCli.ShallowClone(ctx, templateUrl, templateBranch, destination)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("fetching template: %w", err)
+		return nil, "", fmt.Errorf("fetching template: %w", err)
 	}
 
 	stagedFilesOutput, err := i.gitCli.ListStagedFiles(ctx, destination)
 	if err != nil {
-		return nil, fmt.Errorf("listing files with permissions: %w", err)
+		return nil, "", fmt.Errorf("listing files with permissions: %w", err)
 	}
 
 	executableFilePaths, err = parseExecutableFiles(stagedFilesOutput)
 	if err != nil {
-		return nil, fmt.Errorf("parsing file permissions output: %w", err)
+		return nil, "", fmt.Errorf("parsing file permissions output: %w", err)
+	}
+
+	// The commit is only needed to upgrade the project later, so it doesn't fail the initialization
+	headCommit, err = i.gitCli.GetCurrentCommit(ctx, destination)
+	if err != nil {
+		log.Printf("failed getting the commit of template %s: %v", templateUrl, err)
 	}
 
 	if err := os.RemoveAll(filepath.Join(destination, ".git")); err != nil {
-		return nil, fmt.Errorf("removing .git folder after clone: %w", err)
+		return nil, "", fmt.Errorf("removing .git folder after clone: %w", err)
 	}
 
-	return executableFilePaths, nil
+	return executableFilePaths, headCommit, nil
 }
 
 // copyLocalTemplate copies a local template directory to the destination, respecting .gitignore
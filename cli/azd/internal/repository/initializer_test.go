@@ -67,7 +67,7 @@ func Test_Initializer_Initialize(t *testing.T) {
 				mockContext.AlphaFeaturesManager,
 				lazy.From[environment.Manager](mockEnv),
 			)
-			err := i.Initialize(*mockContext.Context, azdCtx, &templates.Template{RepositoryPath: "local"}, "")
+			err := i.Initialize(*mockContext.Context, azdCtx, &templates.Template{RepositoryPath: "local"}, "", nil)
 			require.NoError(t, err)
 
 			verifyTemplateCopied(t, testDataPath(tt.templateDir), projectDir, verifyOptions{})
@@ -112,7 +112,7 @@ func Test_Initializer_DevCenter(t *testing.T) {
 		mockContext.AlphaFeaturesManager,
 		lazy.From[environment.Manager](mockEnv),
 	)
-	err := i.Initialize(*mockContext.Context, azdCtx, template, "")
+	err := i.Initialize(*mockContext.Context, azdCtx, template, "", nil)
 	require.NoError(t, err)
 
 	prj, err := project.Load(*mockContext.Context, azdCtx.ProjectPath())
@@ -183,7 +183,7 @@ func Test_Initializer_InitializeWithOverwritePrompt(t *testing.T) {
 				alpha.NewFeaturesManagerWithConfig(config.NewEmptyConfig()),
 				lazy.From[environment.Manager](mockEnv),
 			)
-			err = i.Initialize(context.Background(), azdCtx, &templates.Template{RepositoryPath: "local"}, "")
+			err = i.Initialize(context.Background(), azdCtx, &templates.Template{RepositoryPath: "local"}, "", nil)
 			require.NoError(t, err)
 
 			switch tt.selection {
@@ -833,7 +833,7 @@ func Test_Initializer_Initialize_LocalTemplate(t *testing.T) {
 
 	err := i.Initialize(context.Background(), azdCtx, &templates.Template{
 		RepositoryPath: localTemplateDir,
-	}, "")
+	}, "", nil)
 	require.NoError(t, err)
 
 	// Verify template files were copied
@@ -857,6 +857,71 @@ func Test_Initializer_Initialize_LocalTemplate(t *testing.T) {
 	require.DirExists(t, azdCtx.EnvironmentDirectory())
 }
 
+func Test_Initializer_Initialize_ParameterizedTemplate(t *testing.T) {
+	localTemplateDir := createLocalTemplateDir(t, testDataPath("template-minimal"))
+
+	files := map[string]string{
+		templates.ManifestFileName: `inputs:
+  - name: appName
+    pattern: "[a-z]+"
+  - name: database
+    type: bool
+    prompt: Include a database?
+include:
+  - path: infra/db
+    when: .database
+`,
+		"azure.yaml.tmpl":     "name: {{ .appName }}\n",
+		"infra/db/main.bicep": "// database",
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(localTemplateDir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(localTemplateDir, name), []byte(content), 0600))
+	}
+
+	projectDir := t.TempDir()
+	azdCtx := azdcontext.NewAzdContextWithDirectory(projectDir)
+
+	realRunner := exec.NewCommandRunner(nil)
+
+	mockEnv := &mockenv.MockEnvManager{}
+	mockEnv.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	console := mockinput.NewMockConsole()
+	console.WhenConfirm(func(options input.ConsoleOptions) bool {
+		return options.Message == "Include a database?"
+	}).Respond(false)
+
+	i := NewInitializer(
+		console,
+		git.NewCli(realRunner),
+		dotnet.NewCli(realRunner),
+		alpha.NewFeaturesManagerWithConfig(config.NewEmptyConfig()),
+		lazy.From[environment.Manager](mockEnv),
+	)
+
+	t.Run("InvalidValue", func(t *testing.T) {
+		err := i.Initialize(context.Background(), azdCtx, &templates.Template{
+			RepositoryPath: localTemplateDir,
+		}, "", map[string]string{"appName": "Todo App"})
+		require.ErrorContains(t, err, "must match")
+		require.NoFileExists(t, filepath.Join(projectDir, "azure.yaml"))
+	})
+
+	err := i.Initialize(context.Background(), azdCtx, &templates.Template{
+		RepositoryPath: localTemplateDir,
+	}, "", map[string]string{"appName": "todo"})
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(filepath.Join(projectDir, "azure.yaml"))
+	require.NoError(t, err)
+	require.Contains(t, string(content), "name: todo")
+
+	require.NoFileExists(t, filepath.Join(projectDir, "azure.yaml.tmpl"))
+	require.NoFileExists(t, filepath.Join(projectDir, templates.ManifestFileName))
+	require.NoDirExists(t, filepath.Join(projectDir, "infra", "db"))
+}
+
 func Test_Initializer_Initialize_LocalTemplateWithGitDir(t *testing.T) {
 	// Create a local template that also has a .git directory
 	localTemplateDir := createLocalTemplateDir(t, testDataPath("template-minimal"))
@@ -887,7 +952,7 @@ func Test_Initializer_Initialize_LocalTemplateWithGitDir(t *testing.T) {
 
 	err := i.Initialize(context.Background(), azdCtx, &templates.Template{
 		RepositoryPath: localTemplateDir,
-	}, "")
+	}, "", nil)
 	require.NoError(t, err)
 
 	// Verify template files were copied
@@ -959,7 +1024,7 @@ func Test_Initializer_Initialize_LocalTemplateRespectsGitignore(t *testing.T) {
 
 	err := i.Initialize(context.Background(), azdCtx, &templates.Template{
 		RepositoryPath: localTemplateDir,
-	}, "")
+	}, "", nil)
 	require.NoError(t, err)
 
 	// Verify tracked template files were copied
@@ -1019,7 +1084,7 @@ func Test_Initializer_Initialize_LocalTemplateGitignoreNegation(t *testing.T) {
 
 	err := i.Initialize(context.Background(), azdCtx, &templates.Template{
 		RepositoryPath: localTemplateDir,
-	}, "")
+	}, "", nil)
 	require.NoError(t, err)
 
 	// debug.log should be excluded by *.log pattern
@@ -1059,7 +1124,7 @@ func Test_Initializer_Initialize_LocalTemplateGitFile(t *testing.T) {
 
 	err := i.Initialize(context.Background(), azdCtx, &templates.Template{
 		RepositoryPath: localTemplateDir,
-	}, "")
+	}, "", nil)
 	require.NoError(t, err)
 
 	// Verify template files were copied
@@ -1115,7 +1180,7 @@ func Test_Initializer_Initialize_LocalTemplateNestedGitignore(t *testing.T) {
 
 	err := i.Initialize(context.Background(), azdCtx, &templates.Template{
 		RepositoryPath: localTemplateDir,
-	}, "")
+	}, "", nil)
 	require.NoError(t, err)
 
 	// Verify the kept file was copied
@@ -1149,7 +1214,7 @@ func Test_Initializer_Initialize_LocalTemplateOverlapRejected(t *testing.T) {
 		azdCtx := azdcontext.NewAzdContextWithDirectory(localTemplateDir)
 		err := i.Initialize(context.Background(), azdCtx, &templates.Template{
 			RepositoryPath: localTemplateDir,
-		}, "")
+		}, "", nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "overlaps with template source")
 	})
@@ -1161,7 +1226,7 @@ func Test_Initializer_Initialize_LocalTemplateOverlapRejected(t *testing.T) {
 		azdCtx := azdcontext.NewAzdContextWithDirectory(subDir)
 		err := i.Initialize(context.Background(), azdCtx, &templates.Template{
 			RepositoryPath: localTemplateDir,
-		}, "")
+		}, "", nil)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "overlaps with template source")
 	})
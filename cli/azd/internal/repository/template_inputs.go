@@ -0,0 +1,139 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/templates"
+)
+
+// renderTemplate renders the staged template when parameterized, prompting for the inputs without a value. The paths of
+// the executable files are updated for the rendered files.
+func (i *Initializer) renderTemplate(
+	ctx context.Context,
+	staged *stagedTemplate,
+	templateValues map[string]string,
+) error {
+	manifest, err := templates.LoadManifest(staged.dir)
+	if err != nil {
+		return err
+	}
+
+	if manifest == nil {
+		if len(templateValues) > 0 {
+			return fmt.Errorf("the template doesn't declare any inputs in %s to set", templates.ManifestFileName)
+		}
+
+		return nil
+	}
+
+	for name := range templateValues {
+		if _, has := manifest.Input(name); !has {
+			return fmt.Errorf("the template doesn't declare input '%s'", name)
+		}
+	}
+
+	values := make(map[string]any, len(manifest.Inputs))
+	staged.inputs = make(map[string]string, len(manifest.Inputs))
+	for _, templateInput := range manifest.Inputs {
+		var value any
+		if provided, has := templateValues[templateInput.Name]; has {
+			value, err = templateInput.Parse(provided)
+		} else {
+			value, err = i.promptTemplateInput(ctx, templateInput)
+		}
+		if err != nil {
+			return err
+		}
+
+		values[templateInput.Name] = value
+		staged.inputs[templateInput.Name] = fmt.Sprint(value)
+	}
+
+	if err := templates.Render(staged.dir, manifest, values); err != nil {
+		return fmt.Errorf("rendering template: %w", err)
+	}
+
+	rendered := []string{}
+	for _, file := range staged.executableFiles {
+		file = strings.TrimSuffix(file, templates.RenderedFileSuffix)
+		if _, err := os.Stat(filepath.Join(staged.dir, file)); err == nil {
+			rendered = append(rendered, file)
+		}
+	}
+	staged.executableFiles = rendered
+
+	return nil
+}
+
+// promptTemplateInput prompts for the value of a template input. The default value is used without prompting when
+// prompts are disabled.
+func (i *Initializer) promptTemplateInput(ctx context.Context, templateInput *templates.Input) (any, error) {
+	if i.console.IsNoPromptMode() {
+		if templateInput.Default == "" {
+			return nil, fmt.Errorf(
+				"template input '%s' has no default value, set it with '--set %s=<value>'",
+				templateInput.Name,
+				templateInput.Name)
+		}
+
+		return templateInput.Parse(templateInput.Default)
+	}
+
+	i.console.StopSpinner(ctx, "", input.StepDone)
+
+	message := templateInput.Prompt
+	if message == "" {
+		message = fmt.Sprintf("Enter a value for %s:", templateInput.Name)
+	}
+
+	switch {
+	case len(templateInput.Allowed) > 0:
+		options := input.ConsoleOptions{Message: message, Options: templateInput.Allowed}
+		if slices.Contains(templateInput.Allowed, templateInput.Default) {
+			options.DefaultValue = templateInput.Default
+		}
+
+		selection, err := i.console.Select(ctx, options)
+		if err != nil {
+			return nil, fmt.Errorf("prompting for template input '%s': %w", templateInput.Name, err)
+		}
+
+		return templateInput.Parse(templateInput.Allowed[selection])
+	case templateInput.Type == templates.InputTypeBool:
+		defaultValue, _ := strconv.ParseBool(templateInput.Default)
+		confirmed, err := i.console.Confirm(ctx, input.ConsoleOptions{Message: message, DefaultValue: defaultValue})
+		if err != nil {
+			return nil, fmt.Errorf("prompting for template input '%s': %w", templateInput.Name, err)
+		}
+
+		return confirmed, nil
+	}
+
+	for {
+		value, err := i.console.Prompt(ctx, input.ConsoleOptions{
+			Message:      message,
+			DefaultValue: templateInput.Default,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("prompting for template input '%s': %w", templateInput.Name, err)
+		}
+
+		parsed, err := templateInput.Parse(value)
+		if err != nil {
+			i.console.Message(ctx, fmt.Sprintf("%v. Please enter a valid value.", err))
+			continue
+		}
+
+		return parsed, nil
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package repository
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+)
+
+// TemplateLockFileName is the name of the file recording the template a project was initialized from, at the root of
+// the project. The file is meant to be committed with the project so the project can be upgraded by anyone.
+const TemplateLockFileName = "azd-template.lock.json"
+
+// ErrTemplateLockNotFound is returned when the project has no record of the template it was initialized from
+var ErrTemplateLockNotFound = errors.New("project has no template lock file")
+
+// TemplateLock records the template a project was initialized from and the files copied from it, which are the base of
+// the three-way merge when the project is upgraded to a newer revision of the template.
+type TemplateLock struct {
+	// RepositoryPath is the git repository URL or the local directory of the template
+	RepositoryPath string `json:"repositoryPath"`
+	Branch         string `json:"branch,omitempty"`
+	// Commit is the commit of the template the files were copied from. Empty for templates copied from a local
+	// directory, whose files can't be fetched again.
+	Commit string `json:"commit,omitempty"`
+	// Inputs are the values the parameterized template was rendered with
+	Inputs map[string]string `json:"inputs,omitempty"`
+	// Files maps the slash separated path of every file copied from the template to the SHA-256 hash of its content
+	Files map[string]string `json:"files"`
+}
+
+// LoadTemplateLock loads the template lock of the project at the given directory
+func LoadTemplateLock(projectDir string) (*TemplateLock, error) {
+	content, err := os.ReadFile(filepath.Join(projectDir, TemplateLockFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrTemplateLockNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("reading template lock file: %w", err)
+	}
+
+	lock := &TemplateLock{}
+	if err := json.Unmarshal(content, lock); err != nil {
+		return nil, fmt.Errorf("parsing template lock file %s: %w", TemplateLockFileName, err)
+	}
+
+	return lock, nil
+}
+
+// Save writes the template lock to the project at the given directory
+func (l *TemplateLock) Save(projectDir string) error {
+	content, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling template lock: %w", err)
+	}
+
+	content = append(content, '\n')
+	if err := os.WriteFile(filepath.Join(projectDir, TemplateLockFileName), content, osutil.PermissionFile); err != nil {
+		return fmt.Errorf("writing template lock file: %w", err)
+	}
+
+	return nil
+}
+
+// hashTemplateFiles returns the SHA-256 hash of every file of the template staged at the given directory, keyed by
+// their slash separated path
+func hashTemplateFiles(templateDir string) (map[string]string, error) {
+	hashes := map[string]string{}
+	err := filepath.WalkDir(templateDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(templateDir, path)
+		if err != nil {
+			return err
+		}
+
+		hash, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+
+		hashes[filepath.ToSlash(relativePath)] = hash
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("hashing template files: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// hashFile returns the hex encoded SHA-256 hash of the content of a file
+func hashFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:]), nil
+}
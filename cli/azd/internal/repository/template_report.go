@@ -0,0 +1,159 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package repository
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// TemplateCheckStatus is the outcome of a check of a template
+type TemplateCheckStatus string
+
+const (
+	TemplateCheckPassed  TemplateCheckStatus = "passed"
+	TemplateCheckFailed  TemplateCheckStatus = "failed"
+	TemplateCheckSkipped TemplateCheckStatus = "skipped"
+)
+
+// TemplateCheck is a single check of a template, like the compilation of its infrastructure
+type TemplateCheck struct {
+	Name   string
+	Status TemplateCheckStatus
+	// Message explains why the check failed or was skipped
+	Message  string
+	Duration time.Duration
+}
+
+// TemplateReport is the result of validating or testing a template
+type TemplateReport struct {
+	// Template is the path or repository of the checked template
+	Template string
+	Checks   []*TemplateCheck
+}
+
+// Failed returns the checks that failed
+func (r *TemplateReport) Failed() []*TemplateCheck {
+	failed := []*TemplateCheck{}
+	for _, check := range r.Checks {
+		if check.Status == TemplateCheckFailed {
+			failed = append(failed, check)
+		}
+	}
+
+	return failed
+}
+
+// run runs a check and records its outcome and duration
+func (r *TemplateReport) run(name string, check func() (TemplateCheckStatus, string)) *TemplateCheck {
+	start := time.Now()
+	status, message := check()
+	result := &TemplateCheck{
+		Name:     name,
+		Status:   status,
+		Message:  message,
+		Duration: time.Since(start),
+	}
+
+	r.Checks = append(r.Checks, result)
+	return result
+}
+
+// skip records a check that wasn't run
+func (r *TemplateReport) skip(name string, message string) {
+	r.Checks = append(r.Checks, &TemplateCheck{Name: name, Status: TemplateCheckSkipped, Message: message})
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Details string `xml:",chardata"`
+}
+
+// WriteJUnit writes the report as a JUnit XML test suite, with a test case for each check
+func (r *TemplateReport) WriteJUnit(name string, writer io.Writer) error {
+	suite := junitTestSuite{Name: r.Template}
+	var duration time.Duration
+	for _, check := range r.Checks {
+		testCase := junitTestCase{
+			Name:      check.Name,
+			ClassName: name,
+			Time:      junitSeconds(check.Duration),
+		}
+
+		switch check.Status {
+		case TemplateCheckFailed:
+			testCase.Failure = &junitMessage{Message: firstLine(check.Message), Details: check.Message}
+			suite.Failures++
+		case TemplateCheckSkipped:
+			testCase.Skipped = &junitMessage{Message: check.Message}
+			suite.Skipped++
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+		duration += check.Duration
+	}
+	suite.Tests = len(suite.Cases)
+	suite.Time = junitSeconds(duration)
+
+	suites := junitTestSuites{
+		Name:     name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return fmt.Errorf("writing JUnit report: %w", err)
+	}
+
+	_, err := io.WriteString(writer, "\n")
+	return err
+}
+
+func junitSeconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
+
+func firstLine(message string) string {
+	line, _, _ := strings.Cut(message, "\n")
+	return line
+}
@@ -0,0 +1,183 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/templates"
+)
+
+const (
+	// templateTestEnvName is the name of the environment the template is initialized with when tested
+	templateTestEnvName = "azd-template-test"
+	// templateTestSubscriptionId is the placeholder subscription of the test environment. The test provider doesn't
+	// create any resource, so the subscription is never used.
+	templateTestSubscriptionId = "00000000-0000-0000-0000-000000000000"
+	templateTestLocation       = "eastus2"
+)
+
+// TemplateTester tests a template by initializing it into a temp directory and previewing its provisioning with the test
+// provider, which doesn't create any resource. Each step runs azd in its own process, as a user would.
+// The test provider doesn't compile or preview the infrastructure, the preview only proves the initialized project
+// loads. The infrastructure itself is checked by TemplateValidator.
+type TemplateTester struct {
+	commandRunner exec.CommandRunner
+	azdPath       func() (string, error)
+}
+
+// NewTemplateTester creates a new TemplateTester
+func NewTemplateTester(commandRunner exec.CommandRunner) *TemplateTester {
+	return &TemplateTester{
+		commandRunner: commandRunner,
+		azdPath:       os.Executable,
+	}
+}
+
+// TemplateTestOptions are the options of testing a template
+type TemplateTestOptions struct {
+	Branch string
+	// Inputs are the values the parameterized template is initialized with
+	Inputs map[string]string
+}
+
+// Test tests the template at the given local path or repository
+func (t *TemplateTester) Test(ctx context.Context, template string, options TemplateTestOptions) (*TemplateReport, error) {
+	azdPath, err := t.azdPath()
+	if err != nil {
+		return nil, fmt.Errorf("finding azd: %w", err)
+	}
+
+	// init runs in the temp directory, relative local paths are resolved before
+	templatePath, err := templates.Absolute(template)
+	if err != nil {
+		return nil, err
+	}
+
+	projectDir, err := os.MkdirTemp("", "az-dev-template-test")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp folder: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(projectDir)
+	}()
+
+	report := &TemplateReport{Template: template}
+	initArgs := []string{"init", "--template", templatePath, "--environment", templateTestEnvName, "--no-prompt"}
+	if options.Branch != "" {
+		initArgs = append(initArgs, "--branch", options.Branch)
+	}
+	for _, name := range slices.Sorted(maps.Keys(options.Inputs)) {
+		initArgs = append(initArgs, "--set", fmt.Sprintf("%s=%s", name, options.Inputs[name]))
+	}
+
+	var layers []string
+	check := report.run("init", func() (TemplateCheckStatus, string) {
+		if status, message := t.runAzd(ctx, azdPath, projectDir, initArgs...); status != TemplateCheckPassed {
+			return status, message
+		}
+
+		layers, err = useTestProvider(ctx, projectDir)
+		return checkResult(err)
+	})
+	if check.Status != TemplateCheckPassed {
+		report.skip("provision --preview", "the template couldn't be initialized")
+		return report, nil
+	}
+
+	for _, layer := range layers {
+		args := []string{"provision", "--preview", "--environment", templateTestEnvName, "--no-prompt"}
+		if layer != "" {
+			args = append(args, layer)
+		}
+
+		name := "provision --preview"
+		if layer != "" {
+			name = fmt.Sprintf("%s %s", name, layer)
+		}
+
+		report.run(name, func() (TemplateCheckStatus, string) {
+			return t.runAzd(ctx, azdPath, projectDir, args...)
+		})
+	}
+
+	return report, nil
+}
+
+// runAzd runs azd in the project directory, failing the check with the output of azd when it fails
+func (t *TemplateTester) runAzd(
+	ctx context.Context,
+	azdPath string,
+	projectDir string,
+	args ...string,
+) (TemplateCheckStatus, string) {
+	runArgs := exec.NewRunArgs(azdPath, args...).
+		WithCwd(projectDir).
+		WithEnv([]string{
+			fmt.Sprintf("AZURE_SUBSCRIPTION_ID=%s", templateTestSubscriptionId),
+			fmt.Sprintf("AZURE_LOCATION=%s", templateTestLocation),
+			fmt.Sprintf("%s=true", provisioning.TestProviderEnvVarName),
+		})
+
+	result, err := t.commandRunner.Run(ctx, runArgs)
+	if err != nil {
+		// The output of azd explains the failure better than its exit code
+		output := strings.TrimSpace(result.Stderr)
+		if output == "" {
+			output = strings.TrimSpace(result.Stdout)
+		}
+		if output == "" {
+			output = err.Error()
+		}
+
+		return TemplateCheckFailed, fmt.Sprintf("azd %s failed:\n%s", args[0], output)
+	}
+
+	return TemplateCheckPassed, ""
+}
+
+// useTestProvider switches the provisioning of the initialized project to the test provider, returning the names of its
+// provisioning layers, or a single empty name when the project doesn't define layers
+func useTestProvider(ctx context.Context, projectDir string) ([]string, error) {
+	projectFilePath := filepath.Join(projectDir, azdcontext.ProjectFileName)
+	rawConfig, err := project.LoadConfig(ctx, projectFilePath)
+	if err != nil {
+		return nil, err
+	}
+
+	layers := []string{""}
+	if rawLayers, has := rawConfig.GetSlice("infra.layers"); !has || len(rawLayers) == 0 {
+		if err := rawConfig.Set("infra.provider", string(provisioning.Test)); err != nil {
+			return nil, err
+		}
+	} else {
+		// The layers are updated in place, as the config can't address the items of a list
+		layers = []string{}
+		for _, rawLayer := range rawLayers {
+			layer, ok := rawLayer.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("unexpected provisioning layer: %v", rawLayer)
+			}
+
+			layer["provider"] = string(provisioning.Test)
+			layers = append(layers, fmt.Sprint(layer["name"]))
+		}
+	}
+
+	if err := project.SaveConfig(ctx, rawConfig, projectFilePath); err != nil {
+		return nil, err
+	}
+
+	return layers, nil
+}
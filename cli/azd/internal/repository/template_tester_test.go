@@ -0,0 +1,140 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package repository
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockexec"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TemplateTester_Test(t *testing.T) {
+	tests := []struct {
+		name        string
+		azureYaml   string
+		previewErr  bool
+		wantChecks  map[string]TemplateCheckStatus
+		wantPreview [][]string
+	}{
+		{
+			name:      "SingleLayer",
+			azureYaml: "name: todo\n",
+			wantChecks: map[string]TemplateCheckStatus{
+				"init":                TemplateCheckPassed,
+				"provision --preview": TemplateCheckPassed,
+			},
+			wantPreview: [][]string{
+				{"provision", "--preview", "--environment", templateTestEnvName, "--no-prompt"},
+			},
+		},
+		{
+			name: "Layers",
+			azureYaml: "name: todo\ninfra:\n  layers:\n" +
+				"    - name: network\n      path: infra/network\n    - name: app\n      path: infra/app\n",
+			wantChecks: map[string]TemplateCheckStatus{
+				"init":                        TemplateCheckPassed,
+				"provision --preview network": TemplateCheckPassed,
+				"provision --preview app":     TemplateCheckPassed,
+			},
+			wantPreview: [][]string{
+				{"provision", "--preview", "--environment", templateTestEnvName, "--no-prompt", "network"},
+				{"provision", "--preview", "--environment", templateTestEnvName, "--no-prompt", "app"},
+			},
+		},
+		{
+			name:       "PreviewFails",
+			azureYaml:  "name: todo\n",
+			previewErr: true,
+			wantChecks: map[string]TemplateCheckStatus{
+				"init":                TemplateCheckPassed,
+				"provision --preview": TemplateCheckFailed,
+			},
+			wantPreview: [][]string{
+				{"provision", "--preview", "--environment", templateTestEnvName, "--no-prompt"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var projectDir string
+			previews := [][]string{}
+			commandRunner := mockexec.NewMockCommandRunner()
+			commandRunner.When(func(args exec.RunArgs, command string) bool {
+				return args.Cmd == "azd" && args.Args[0] == "init"
+			}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+				require.Equal(t, []string{
+					"init", "--template", "https://github.com/contoso/todo", "--environment", templateTestEnvName,
+					"--no-prompt", "--set", "appName=todo", "--set", "database=postgres",
+				}, args.Args)
+				require.Contains(t, args.Env, "AZURE_SUBSCRIPTION_ID="+templateTestSubscriptionId)
+
+				projectDir = args.Cwd
+				err := os.WriteFile(filepath.Join(args.Cwd, "azure.yaml"), []byte(tt.azureYaml), 0600)
+				return exec.RunResult{}, err
+			})
+			commandRunner.When(func(args exec.RunArgs, command string) bool {
+				return args.Cmd == "azd" && args.Args[0] == "provision"
+			}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+				require.Equal(t, projectDir, args.Cwd)
+				require.Contains(t, args.Env, provisioning.TestProviderEnvVarName+"=true")
+				previews = append(previews, slices.Clone(args.Args))
+
+				// The project is provisioned with the test provider
+				projectConfig, err := project.Load(context.Background(), filepath.Join(args.Cwd, "azure.yaml"))
+				require.NoError(t, err)
+				for _, layer := range projectConfig.Infra.GetLayers() {
+					require.Equal(t, "test", string(layer.Provider))
+				}
+
+				if tt.previewErr {
+					return exec.RunResult{ExitCode: 1, Stdout: "ERROR: preview failed"}, errors.New("exit code: 1")
+				}
+
+				return exec.RunResult{}, nil
+			})
+
+			tester := NewTemplateTester(commandRunner)
+			tester.azdPath = func() (string, error) {
+				return "azd", nil
+			}
+
+			report, err := tester.Test(context.Background(), "contoso/todo", TemplateTestOptions{
+				Inputs: map[string]string{"database": "postgres", "appName": "todo"},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.wantPreview, previews)
+
+			checks := map[string]TemplateCheckStatus{}
+			for _, check := range report.Checks {
+				checks[check.Name] = check.Status
+			}
+			require.Equal(t, tt.wantChecks, checks)
+
+			// The project is removed once tested
+			_, err = os.Stat(projectDir)
+			require.ErrorIs(t, err, os.ErrNotExist)
+
+			var junit bytes.Buffer
+			require.NoError(t, report.WriteJUnit("azd template test", &junit))
+			require.True(t, strings.HasPrefix(junit.String(), "<?xml"))
+			require.Contains(t, junit.String(), `<testcase name="init" classname="azd template test"`)
+			if tt.previewErr {
+				require.Contains(t, junit.String(), `failures="1"`)
+				require.Contains(t, junit.String(), "ERROR: preview failed")
+			}
+		})
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/templates"
+)
+
+// TemplateUpgrade is the result of upgrading a project to the latest revision of its template
+type TemplateUpgrade struct {
+	// PreviousCommit and Commit are the commits of the template before and after the upgrade, empty for local templates
+	PreviousCommit string
+	Commit         string
+	// Added, Updated and Removed are the files taken from the template, as the project didn't change them
+	Added   []string
+	Updated []string
+	Removed []string
+	// Merged are the files changed by both the project and the template, merged without conflicts
+	Merged    []string
+	Conflicts []TemplateConflict
+}
+
+// TemplateConflict is a file of the project whose changes conflict with the changes of the template
+type TemplateConflict struct {
+	Path   string
+	Reason string
+}
+
+// UpToDate reports whether the upgrade didn't change any file of the project
+func (u *TemplateUpgrade) UpToDate() bool {
+	return len(u.Added)+len(u.Updated)+len(u.Removed)+len(u.Merged)+len(u.Conflicts) == 0
+}
+
+// Upgrade upgrades the project to the latest revision of the template it was initialized from, as recorded in its
+// template lock. Each file changed by the template is merged with the changes of the project, using the revision of the
+// template the project was initialized from, or last upgraded to, as the base of a three-way merge.
+//
+// Conflicting changes are written with conflict markers. When the base revision can't be fetched, like for templates
+// copied from a local directory, files changed by both the project and the template are reported as conflicts.
+func (i *Initializer) Upgrade(ctx context.Context, azdCtx *azdcontext.AzdContext) (*TemplateUpgrade, error) {
+	projectDir := azdCtx.ProjectDirectory()
+	lock, err := LoadTemplateLock(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	latestDir, err := os.MkdirTemp("", "az-dev-template")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp folder: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(latestDir)
+	}()
+
+	latest, err := i.fetchTemplate(ctx, lock.RepositoryPath, lock.Branch, "", latestDir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TemplateUpgrade{PreviousCommit: lock.Commit, Commit: latest.commit}
+	if lock.Commit != "" && lock.Commit == latest.commit {
+		return result, nil
+	}
+
+	// Inputs removed from the newer revision are dropped, inputs added to it are prompted for
+	if err := i.renderTemplate(ctx, latest, declaredInputs(latest.dir, lock.Inputs)); err != nil {
+		return nil, err
+	}
+
+	baseDir := i.fetchBaseTemplate(ctx, lock)
+	if baseDir != "" {
+		defer func() {
+			_ = os.RemoveAll(baseDir)
+		}()
+	}
+
+	latestFiles, err := hashTemplateFiles(latest.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := slices.Sorted(maps.Keys(latestFiles))
+	for path := range lock.Files {
+		if _, has := latestFiles[path]; !has {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		if err := i.upgradeFile(ctx, result, path, projectDir, latest.dir, baseDir, lock.Files, latestFiles); err != nil {
+			return nil, fmt.Errorf("upgrading %s: %w", path, err)
+		}
+	}
+
+	lock.Commit = latest.commit
+	lock.Inputs = latest.inputs
+	lock.Files = latestFiles
+	if err := lock.Save(projectDir); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// upgradeFile applies the changes of the template to a file of the project
+func (i *Initializer) upgradeFile(
+	ctx context.Context,
+	result *TemplateUpgrade,
+	path string,
+	projectDir string,
+	latestDir string,
+	baseDir string,
+	baseFiles map[string]string,
+	latestFiles map[string]string,
+) error {
+	baseHash, inBase := baseFiles[path]
+	latestHash, inLatest := latestFiles[path]
+	projectPath := filepath.Join(projectDir, filepath.FromSlash(path))
+	latestPath := filepath.Join(latestDir, filepath.FromSlash(path))
+
+	projectHash, err := hashFile(projectPath)
+	inProject := err == nil
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	switch {
+	case inBase && inLatest && baseHash == latestHash:
+		// Unchanged by the template
+		return nil
+	case !inLatest:
+		if !inProject {
+			return nil
+		}
+
+		if projectHash != baseHash {
+			result.Conflicts = append(result.Conflicts, TemplateConflict{
+				Path:   path,
+				Reason: "removed from the template but changed in the project, the project file was kept",
+			})
+			return nil
+		}
+
+		if err := os.Remove(projectPath); err != nil {
+			return err
+		}
+
+		result.Removed = append(result.Removed, path)
+		return nil
+	case !inProject:
+		if inBase {
+			result.Conflicts = append(result.Conflicts, TemplateConflict{
+				Path:   path,
+				Reason: "changed in the template but removed from the project, the file wasn't restored",
+			})
+			return nil
+		}
+
+		if err := copyTemplateFile(latestPath, projectPath); err != nil {
+			return err
+		}
+
+		result.Added = append(result.Added, path)
+		return nil
+	case projectHash == latestHash:
+		// The project already has the changes of the template
+		return nil
+	case inBase && projectHash == baseHash:
+		if err := copyTemplateFile(latestPath, projectPath); err != nil {
+			return err
+		}
+
+		result.Updated = append(result.Updated, path)
+		return nil
+	}
+
+	// Changed by both the project and the template. Without a base, every difference is a conflict.
+	basePath := filepath.Join(baseDir, filepath.FromSlash(path))
+	if baseDir == "" || !inBase {
+		emptyBase, err := os.CreateTemp("", "az-dev-template-base")
+		if err != nil {
+			return err
+		}
+		_ = emptyBase.Close()
+		defer func() {
+			_ = os.Remove(emptyBase.Name())
+		}()
+
+		basePath = emptyBase.Name()
+	}
+
+	conflicts, err := i.gitCli.MergeFile(ctx, projectPath, basePath, latestPath)
+	if err != nil {
+		log.Printf("failed merging %s: %v", path, err)
+		result.Conflicts = append(result.Conflicts, TemplateConflict{
+			Path:   path,
+			Reason: "changed in both the project and the template and can't be merged, the project file was kept",
+		})
+		return nil
+	}
+
+	if conflicts > 0 {
+		result.Conflicts = append(result.Conflicts, TemplateConflict{
+			Path:   path,
+			Reason: fmt.Sprintf("changed in both the project and the template, %d conflicts were marked", conflicts),
+		})
+		return nil
+	}
+
+	result.Merged = append(result.Merged, path)
+	return nil
+}
+
+// fetchBaseTemplate fetches and renders the revision of the template recorded in the lock to a temp directory. An empty
+// directory is returned when the revision can't be fetched.
+func (i *Initializer) fetchBaseTemplate(ctx context.Context, lock *TemplateLock) string {
+	if lock.Commit == "" || templates.IsLocalPath(lock.RepositoryPath) {
+		return ""
+	}
+
+	baseDir, err := os.MkdirTemp("", "az-dev-template")
+	if err != nil {
+		log.Printf("failed creating temp folder for the base template: %v", err)
+		return ""
+	}
+
+	base, err := i.fetchTemplate(ctx, lock.RepositoryPath, "", lock.Commit, baseDir)
+	if err == nil {
+		err = i.renderTemplate(ctx, base, declaredInputs(baseDir, lock.Inputs))
+	}
+	if err != nil {
+		log.Printf("failed fetching commit %s of template %s: %v", lock.Commit, lock.RepositoryPath, err)
+		_ = os.RemoveAll(baseDir)
+		return ""
+	}
+
+	return baseDir
+}
+
+// declaredInputs returns the input values declared by the manifest of the template staged at the given directory
+func declaredInputs(templateDir string, values map[string]string) map[string]string {
+	manifest, err := templates.LoadManifest(templateDir)
+	if err != nil || manifest == nil {
+		return nil
+	}
+
+	declared := map[string]string{}
+	for name, value := range values {
+		if _, has := manifest.Input(name); has {
+			declared[name] = value
+		}
+	}
+
+	return declared
+}
+
+// copyTemplateFile copies a file of the template to the project, keeping its permissions
+func copyTemplateFile(source string, target string) error {
+	info, err := os.Stat(source)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), osutil.PermissionDirectory); err != nil {
+		return err
+	}
+
+	return os.WriteFile(target, content, info.Mode().Perm())
+}
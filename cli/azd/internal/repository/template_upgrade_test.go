@@ -0,0 +1,138 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/pkg/templates"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/dotnet"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockenv"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockinput"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Initializer_Upgrade(t *testing.T) {
+	ctx := context.Background()
+	runner := exec.NewCommandRunner(nil)
+
+	// A template repository with a first revision
+	templateDir := t.TempDir()
+	commitTemplate := func(files map[string]string, removed ...string) {
+		for name, content := range files {
+			require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(templateDir, name)), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(templateDir, name), []byte(content), 0600))
+		}
+		for _, name := range removed {
+			require.NoError(t, os.Remove(filepath.Join(templateDir, name)))
+		}
+
+		for _, args := range [][]string{
+			{"add", "-A"},
+			{"-c", "user.name=azd", "-c", "user.email=azd@example.com", "commit", "-q", "-m", "revision"},
+		} {
+			_, err := runner.Run(ctx, exec.NewRunArgs("git", append([]string{"-C", templateDir}, args...)...))
+			require.NoError(t, err)
+		}
+	}
+
+	_, err := runner.Run(ctx, exec.NewRunArgs("git", "-C", templateDir, "init", "-q"))
+	require.NoError(t, err)
+	commitTemplate(map[string]string{
+		"azure.yaml":       "name: todo\n",
+		"infra/main.bicep": "param location string\n\nparam name string\n\nparam sku string = 'B1'\n",
+		"infra/old.bicep":  "// old\n",
+		"README.md":        "# Todo\n",
+		"src/app.py":       "print('hello')\n",
+	})
+
+	projectDir := t.TempDir()
+	azdCtx := azdcontext.NewAzdContextWithDirectory(projectDir)
+
+	mockEnv := &mockenv.MockEnvManager{}
+	mockEnv.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	i := NewInitializer(
+		mockinput.NewMockConsole(),
+		git.NewCli(runner),
+		dotnet.NewCli(runner),
+		alpha.NewFeaturesManagerWithConfig(config.NewEmptyConfig()),
+		lazy.From[environment.Manager](mockEnv),
+	)
+
+	templateUrl := "file://" + filepath.ToSlash(templateDir)
+	err = i.Initialize(ctx, azdCtx, &templates.Template{RepositoryPath: templateUrl}, "", nil)
+	require.NoError(t, err)
+
+	lock, err := LoadTemplateLock(projectDir)
+	require.NoError(t, err)
+	require.Equal(t, templateUrl, lock.RepositoryPath)
+	require.NotEmpty(t, lock.Commit)
+	require.Contains(t, lock.Files, "infra/main.bicep")
+
+	upgrade, err := i.Upgrade(ctx, azdCtx)
+	require.NoError(t, err)
+	require.True(t, upgrade.UpToDate())
+
+	// The project and the template change the files independently
+	writeProjectFile := func(name string, content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(projectDir, name), []byte(content), 0600))
+	}
+	writeProjectFile("infra/main.bicep", "param location string = 'eastus2'\n\nparam name string\n\nparam sku string = 'B1'\n")
+	writeProjectFile("src/app.py", "print('hello world')\n")
+
+	commitTemplate(map[string]string{
+		"infra/main.bicep": "param location string\n\nparam name string\n\nparam sku string = 'P1v3'\n",
+		"infra/new.bicep":  "// new\n",
+		"README.md":        "# Todo app\n",
+		"src/app.py":       "print('hello template')\n",
+	}, "infra/old.bicep")
+
+	upgrade, err = i.Upgrade(ctx, azdCtx)
+	require.NoError(t, err)
+	require.NotEqual(t, upgrade.PreviousCommit, upgrade.Commit)
+
+	require.Equal(t, []string{"infra/new.bicep"}, upgrade.Added)
+	require.Equal(t, []string{"README.md"}, upgrade.Updated)
+	require.Equal(t, []string{"infra/old.bicep"}, upgrade.Removed)
+	require.Equal(t, []string{"infra/main.bicep"}, upgrade.Merged)
+	require.Len(t, upgrade.Conflicts, 1)
+	require.Equal(t, "src/app.py", upgrade.Conflicts[0].Path)
+
+	content, err := os.ReadFile(filepath.Join(projectDir, "infra", "main.bicep"))
+	require.NoError(t, err)
+	require.Equal(t, "param location string = 'eastus2'\n\nparam name string\n\nparam sku string = 'P1v3'\n", string(content))
+
+	content, err = os.ReadFile(filepath.Join(projectDir, "src", "app.py"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(content), "<<<<<<< current\n"))
+	require.Contains(t, string(content), ">>>>>>> template\n")
+
+	require.FileExists(t, filepath.Join(projectDir, "infra", "new.bicep"))
+	require.NoFileExists(t, filepath.Join(projectDir, "infra", "old.bicep"))
+
+	// The lock records the new revision as the base of the next upgrade
+	lock, err = LoadTemplateLock(projectDir)
+	require.NoError(t, err)
+	require.Equal(t, upgrade.Commit, lock.Commit)
+	require.NotContains(t, lock.Files, "infra/old.bicep")
+}
+
+func Test_Initializer_Upgrade_NoLock(t *testing.T) {
+	i := &Initializer{console: mockinput.NewMockConsole()}
+	_, err := i.Upgrade(context.Background(), azdcontext.NewAzdContextWithDirectory(t.TempDir()))
+	require.ErrorIs(t, err, ErrTemplateLockNotFound)
+}
@@ -0,0 +1,502 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/project"
+	"github.com/azure/azure-dev/cli/azd/pkg/templates"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/bicep"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/terraform"
+	"github.com/otiai10/copy"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"go.yaml.in/yaml/v3"
+)
+
+// azureYamlSchemaUrl is the schema azure.yaml is validated against when it doesn't reference one
+const azureYamlSchemaUrl = "https://raw.githubusercontent.com/Azure/azure-dev/refs/heads/main/schemas/v1.0/azure.yaml.json"
+
+// schemaCommentRegex matches the comment referencing the schema of a yaml file, like
+// # yaml-language-server: $schema=https://raw.githubusercontent.com/Azure/azure-dev/main/schemas/v1.0/azure.yaml.json
+var schemaCommentRegex = regexp.MustCompile(`(?m)^#\s*yaml-language-server:\s*\$schema=(\S+)`)
+
+// TemplateValidator checks a template for the issues that would fail its initialization or provisioning
+type TemplateValidator struct {
+	bicepCli     *bicep.Cli
+	terraformCli *terraform.Cli
+	schemaLoader jsonschema.URLLoader
+}
+
+// NewTemplateValidator creates a new TemplateValidator
+func NewTemplateValidator(bicepCli *bicep.Cli, terraformCli *terraform.Cli) *TemplateValidator {
+	return &TemplateValidator{
+		bicepCli:     bicepCli,
+		terraformCli: terraformCli,
+		schemaLoader: jsonschema.SchemeURLLoader{
+			"file":  jsonschema.FileLoader{},
+			"https": &httpsSchemaLoader{client: &http.Client{Timeout: 15 * time.Second}},
+		},
+	}
+}
+
+// TemplateValidateOptions are the options of validating a template
+type TemplateValidateOptions struct {
+	// Inputs are the values the parameterized template is rendered with. Inputs without a value use their default value.
+	Inputs map[string]string
+	// Catalog is the path of a templates.json catalog whose entry for the template is checked for completeness
+	Catalog string
+}
+
+// Validate validates the template at the given directory. The checks that can't run because an earlier check failed,
+// or because the template doesn't use what they check, are reported as skipped.
+func (v *TemplateValidator) Validate(
+	ctx context.Context,
+	templateDir string,
+	options TemplateValidateOptions,
+) (*TemplateReport, error) {
+	report := &TemplateReport{Template: templateDir}
+
+	manifest, err := templates.LoadManifest(templateDir)
+	if err != nil {
+		report.run("inputs", func() (TemplateCheckStatus, string) {
+			return TemplateCheckFailed, err.Error()
+		})
+		return report, nil
+	}
+
+	if manifest == nil && len(options.Inputs) > 0 {
+		return nil, fmt.Errorf("the template doesn't declare any inputs in %s to set", templates.ManifestFileName)
+	}
+
+	projectDir := templateDir
+	if manifest != nil {
+		renderedDir, err := os.MkdirTemp("", "az-dev-template")
+		if err != nil {
+			return nil, fmt.Errorf("creating temp folder: %w", err)
+		}
+		defer func() {
+			_ = os.RemoveAll(renderedDir)
+		}()
+
+		check := report.run("inputs", func() (TemplateCheckStatus, string) {
+			return checkResult(renderManifest(templateDir, renderedDir, manifest, options.Inputs))
+		})
+		if check.Status == TemplateCheckFailed {
+			return report, nil
+		}
+
+		projectDir = renderedDir
+	}
+
+	projectFilePath := filepath.Join(projectDir, azdcontext.ProjectFileName)
+	check := report.run("azure.yaml schema", func() (TemplateCheckStatus, string) {
+		return v.validateSchema(projectFilePath)
+	})
+	if check.Status == TemplateCheckFailed && errors.Is(statErr(projectFilePath), os.ErrNotExist) {
+		return report, nil
+	}
+
+	var projectConfig *project.ProjectConfig
+	check = report.run("azure.yaml", func() (TemplateCheckStatus, string) {
+		projectConfig, err = project.Load(ctx, projectFilePath)
+		return checkResult(err)
+	})
+	if check.Status == TemplateCheckFailed {
+		return report, nil
+	}
+
+	report.run("metadata", func() (TemplateCheckStatus, string) {
+		return checkResult(checkMetadata(projectConfig))
+	})
+
+	if options.Catalog != "" {
+		report.run("catalog entry", func() (TemplateCheckStatus, string) {
+			return checkResult(checkCatalogEntry(options.Catalog, projectConfig))
+		})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(projectConfig.Services)) {
+		serviceConfig := projectConfig.Services[name]
+		if serviceConfig.RelativePath == "" {
+			continue
+		}
+
+		report.run(fmt.Sprintf("service %s", name), func() (TemplateCheckStatus, string) {
+			if _, err := os.Stat(serviceConfig.Path()); err != nil {
+				return TemplateCheckFailed, fmt.Sprintf("the project path '%s' of the service doesn't exist",
+					serviceConfig.RelativePath)
+			}
+
+			return TemplateCheckPassed, ""
+		})
+	}
+
+	for _, layer := range projectConfig.Infra.GetLayers() {
+		layer, err := layer.GetWithDefaults()
+		if err != nil {
+			return nil, err
+		}
+
+		v.validateInfra(ctx, report, projectDir, layer)
+	}
+
+	return report, nil
+}
+
+// validateInfra compiles the infrastructure of a provisioning layer and checks its parameters file
+func (v *TemplateValidator) validateInfra(
+	ctx context.Context,
+	report *TemplateReport,
+	projectDir string,
+	layer provisioning.Options,
+) {
+	infraName, parametersName := "infrastructure", "parameters"
+	if layer.Name != "" {
+		infraName = fmt.Sprintf("infrastructure %s", layer.Name)
+		parametersName = fmt.Sprintf("parameters %s", layer.Name)
+	}
+
+	infraDir := filepath.Join(projectDir, layer.Path)
+	if _, err := os.Stat(infraDir); err != nil {
+		report.skip(infraName, fmt.Sprintf("the template has no infrastructure in '%s'", layer.Path))
+		return
+	}
+
+	if layer.Provider == provisioning.Terraform {
+		report.run(infraName, func() (TemplateCheckStatus, string) {
+			if err := tools.EnsureInstalled(ctx, v.terraformCli); err != nil {
+				return TemplateCheckSkipped, err.Error()
+			}
+
+			// terraform init writes its providers and lock file next to the configuration, the template is
+			// validated from a copy so it isn't changed
+			workDir, err := os.MkdirTemp("", "az-dev-template")
+			if err != nil {
+				return TemplateCheckFailed, fmt.Sprintf("creating temp folder: %v", err)
+			}
+			defer func() {
+				_ = os.RemoveAll(workDir)
+			}()
+
+			if err := copyTemplateFiles(projectDir, workDir); err != nil {
+				return TemplateCheckFailed, err.Error()
+			}
+
+			workInfraDir := filepath.Join(workDir, layer.Path)
+			if _, err := v.terraformCli.Init(ctx, workInfraDir, "-backend=false"); err != nil {
+				return TemplateCheckFailed, err.Error()
+			}
+
+			_, err = v.terraformCli.Validate(ctx, workInfraDir)
+			return checkResult(err)
+		})
+		return
+	}
+
+	modulePath := filepath.Join(infraDir, layer.Module+".bicep")
+	var compiled azure.ArmTemplate
+	check := report.run(infraName, func() (TemplateCheckStatus, string) {
+		if _, err := os.Stat(modulePath); err != nil {
+			return TemplateCheckFailed, fmt.Sprintf("the module '%s' doesn't exist", layer.Module+".bicep")
+		}
+
+		result, err := v.bicepCli.Build(ctx, modulePath)
+		if err != nil {
+			return TemplateCheckFailed, err.Error()
+		}
+
+		if err := json.Unmarshal([]byte(result.Compiled), &compiled); err != nil {
+			return TemplateCheckFailed, fmt.Sprintf("parsing the compiled template: %v", err)
+		}
+
+		return TemplateCheckPassed, ""
+	})
+	if check.Status != TemplateCheckPassed {
+		report.skip(parametersName, "the infrastructure doesn't compile")
+		return
+	}
+
+	parametersPath := filepath.Join(infraDir, layer.Module+".parameters.json")
+	bicepParamPath := filepath.Join(infraDir, layer.Module+".bicepparam")
+	switch {
+	case statErr(parametersPath) == nil:
+		report.run(parametersName, func() (TemplateCheckStatus, string) {
+			return checkResult(checkParametersFile(parametersPath, compiled))
+		})
+	case statErr(bicepParamPath) == nil:
+		report.run(parametersName, func() (TemplateCheckStatus, string) {
+			_, err := v.bicepCli.BuildBicepParam(ctx, bicepParamPath, nil)
+			return checkResult(err)
+		})
+	default:
+		report.skip(parametersName, "the infrastructure has no parameters file")
+	}
+}
+
+// validateSchema validates azure.yaml against the schema it references, or the stable schema. The check is skipped when
+// the schema can't be loaded, like when offline.
+func (v *TemplateValidator) validateSchema(projectFilePath string) (TemplateCheckStatus, string) {
+	content, err := os.ReadFile(projectFilePath)
+	if err != nil {
+		return TemplateCheckFailed, fmt.Sprintf("reading %s: %v", azdcontext.ProjectFileName, err)
+	}
+
+	var document any
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return TemplateCheckFailed, fmt.Sprintf("parsing %s: %v", azdcontext.ProjectFileName, err)
+	}
+
+	// Round trip through JSON for the types the schema validation expects
+	jsonContent, err := json.Marshal(document)
+	if err != nil {
+		return TemplateCheckFailed, fmt.Sprintf("converting %s to JSON: %v", azdcontext.ProjectFileName, err)
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(bytes.NewReader(jsonContent))
+	if err != nil {
+		return TemplateCheckFailed, fmt.Sprintf("converting %s to JSON: %v", azdcontext.ProjectFileName, err)
+	}
+
+	schemaUrl := azureYamlSchemaUrl
+	if match := schemaCommentRegex.FindSubmatch(content); match != nil {
+		schemaUrl = string(match[1])
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(v.schemaLoader)
+	schema, err := compiler.Compile(schemaUrl)
+	if err != nil {
+		return TemplateCheckSkipped, fmt.Sprintf("the schema %s couldn't be loaded: %v", schemaUrl, err)
+	}
+
+	return checkResult(schema.Validate(instance))
+}
+
+// checkMetadata checks the template is identified with a name and a version in azure.yaml
+func checkMetadata(projectConfig *project.ProjectConfig) error {
+	if projectConfig.Metadata == nil || projectConfig.Metadata.Template == "" {
+		return errors.New("metadata.template isn't set, set it to <template name>@<version>")
+	}
+
+	name, version, _ := strings.Cut(projectConfig.Metadata.Template, "@")
+	if name == "" || version == "" {
+		return fmt.Errorf(
+			"metadata.template '%s' isn't formatted as <template name>@<version>", projectConfig.Metadata.Template)
+	}
+
+	return nil
+}
+
+// checkCatalogEntry checks the templates.json catalog lists the template, identified by the name of its metadata, with
+// the properties displayed by 'azd template list' and 'azd template show'
+func checkCatalogEntry(catalogPath string, projectConfig *project.ProjectConfig) error {
+	content, err := os.ReadFile(catalogPath)
+	if err != nil {
+		return fmt.Errorf("reading the catalog: %w", err)
+	}
+
+	var catalog []*templates.Template
+	if err := json.Unmarshal(content, &catalog); err != nil {
+		return fmt.Errorf("parsing the catalog: %w", err)
+	}
+
+	if projectConfig.Metadata == nil || projectConfig.Metadata.Template == "" {
+		return errors.New("the template can't be found in the catalog without metadata.template")
+	}
+
+	name, _, _ := strings.Cut(projectConfig.Metadata.Template, "@")
+	index := slices.IndexFunc(catalog, func(entry *templates.Template) bool {
+		repository := strings.TrimSuffix(strings.TrimRight(entry.RepositoryPath, "/"), ".git")
+		return entry.Id == name || path.Base(repository) == name
+	})
+	if index < 0 {
+		return fmt.Errorf("the catalog has no entry for template '%s'", name)
+	}
+
+	entry := catalog[index]
+	missing := []string{}
+	for property, value := range map[string]string{
+		"name":           entry.Name,
+		"description":    entry.Description,
+		"repositoryPath": entry.RepositoryPath,
+	} {
+		if strings.TrimSpace(value) == "" {
+			missing = append(missing, property)
+		}
+	}
+	if len(entry.Tags) == 0 {
+		missing = append(missing, "tags")
+	}
+
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		return fmt.Errorf("the catalog entry of template '%s' is missing %s", name, strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// checkParametersFile checks every parameter set by the parameters file is declared by the compiled template, and every
+// required parameter of the compiled template is set by the parameters file
+func checkParametersFile(parametersPath string, compiled azure.ArmTemplate) error {
+	content, err := os.ReadFile(parametersPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", filepath.Base(parametersPath), err)
+	}
+
+	var parametersFile azure.ArmParameterFile
+	if err := json.Unmarshal(content, &parametersFile); err != nil {
+		return fmt.Errorf("parsing %s: %w", filepath.Base(parametersPath), err)
+	}
+
+	unknown := []string{}
+	for name := range parametersFile.Parameters {
+		if _, has := compiled.Parameters[name]; !has {
+			unknown = append(unknown, name)
+		}
+	}
+
+	// Parameters without a default value that aren't nullable must be set by the parameters file
+	missing := []string{}
+	for name, definition := range compiled.Parameters {
+		if definition.DefaultValue != nil || (definition.Nullable != nil && *definition.Nullable) {
+			continue
+		}
+
+		if _, has := parametersFile.Parameters[name]; !has {
+			missing = append(missing, name)
+		}
+	}
+
+	problems := []string{}
+	if len(unknown) > 0 {
+		slices.Sort(unknown)
+		problems = append(problems, fmt.Sprintf(
+			"%s sets parameters the template doesn't declare: %s",
+			filepath.Base(parametersPath),
+			strings.Join(unknown, ", ")))
+	}
+
+	if len(missing) > 0 {
+		slices.Sort(missing)
+		problems = append(problems, fmt.Sprintf(
+			"%s doesn't set required parameters of the template: %s",
+			filepath.Base(parametersPath),
+			strings.Join(missing, ", ")))
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+
+	return nil
+}
+
+// renderManifest renders a copy of the parameterized template with the given input values, or the default values of the
+// inputs without a value
+func renderManifest(
+	templateDir string,
+	targetDir string,
+	manifest *templates.Manifest,
+	inputs map[string]string,
+) error {
+	for name := range inputs {
+		if _, has := manifest.Input(name); !has {
+			return fmt.Errorf("the template doesn't declare input '%s'", name)
+		}
+	}
+
+	values := make(map[string]any, len(manifest.Inputs))
+	for _, templateInput := range manifest.Inputs {
+		value, has := inputs[templateInput.Name]
+		if !has {
+			value = templateInput.Default
+		}
+
+		if value == "" {
+			return fmt.Errorf(
+				"template input '%s' has no default value, set it with '--set %s=<value>'",
+				templateInput.Name,
+				templateInput.Name)
+		}
+
+		parsed, err := templateInput.Parse(value)
+		if err != nil {
+			return err
+		}
+
+		values[templateInput.Name] = parsed
+	}
+
+	if err := copyTemplateFiles(templateDir, targetDir); err != nil {
+		return err
+	}
+
+	return templates.Render(targetDir, manifest, values)
+}
+
+// copyTemplateFiles copies the files of the template, except its git repository, to the target directory
+func copyTemplateFiles(templateDir string, targetDir string) error {
+	if err := copy.Copy(templateDir, targetDir, copy.Options{
+		Skip: func(_ os.FileInfo, src string, _ string) (bool, error) {
+			return filepath.Base(src) == ".git", nil
+		},
+	}); err != nil {
+		return fmt.Errorf("copying template: %w", err)
+	}
+
+	return nil
+}
+
+// checkResult returns the outcome of a check failing with the given error
+func checkResult(err error) (TemplateCheckStatus, string) {
+	if err != nil {
+		return TemplateCheckFailed, err.Error()
+	}
+
+	return TemplateCheckPassed, ""
+}
+
+func statErr(path string) error {
+	_, err := os.Stat(path)
+	return err
+}
+
+// httpsSchemaLoader loads JSON schemas over HTTPS
+type httpsSchemaLoader struct {
+	client *http.Client
+}
+
+func (l *httpsSchemaLoader) Load(url string) (any, error) {
+	//nolint:gosec // G107: the schema URL is referenced by the template being validated
+	response, err := l.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned status code %d", url, response.StatusCode)
+	}
+
+	return jsonschema.UnmarshalJSON(response.Body)
+}
@@ -0,0 +1,282 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/bicep"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/terraform"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockexec"
+	"github.com/azure/azure-dev/cli/azd/test/mocks/mockinput"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TemplateValidator_Validate(t *testing.T) {
+	schemaPath := filepath.Join(t.TempDir(), "azure.yaml.json")
+	require.NoError(t, os.WriteFile(schemaPath, []byte(`{
+		"type": "object",
+		"required": ["name"],
+		"properties": {"name": {"type": "string"}}
+	}`), 0600))
+	schemaComment := "# yaml-language-server: $schema=file://" + filepath.ToSlash(schemaPath) + "\n"
+
+	validTemplate := map[string]string{
+		"azure.yaml": schemaComment + `name: todo
+metadata:
+  template: todo@1.0.0
+services:
+  api:
+    project: ./src/api
+    language: py
+    host: containerapp
+`,
+		"src/api/app.py":             "print('hello')\n",
+		"infra/main.bicep":           "param location string\n",
+		"infra/main.parameters.json": `{"parameters": {"location": {"value": "${AZURE_LOCATION}"}}}`,
+	}
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		options TemplateValidateOptions
+		// compiled is the ARM template built from main.bicep, declaring only the location parameter when empty
+		compiled string
+		want     map[string]TemplateCheckStatus
+		message  string
+	}{
+		{
+			name:  "Valid",
+			files: validTemplate,
+			want: map[string]TemplateCheckStatus{
+				"azure.yaml schema": TemplateCheckPassed,
+				"azure.yaml":        TemplateCheckPassed,
+				"metadata":          TemplateCheckPassed,
+				"service api":       TemplateCheckPassed,
+				"infrastructure":    TemplateCheckPassed,
+				"parameters":        TemplateCheckPassed,
+			},
+		},
+		{
+			name: "SchemaViolation",
+			files: map[string]string{
+				"azure.yaml": schemaComment + "name: 1\nmetadata:\n  template: todo@1.0.0\n",
+			},
+			want: map[string]TemplateCheckStatus{
+				"azure.yaml schema": TemplateCheckFailed,
+				"azure.yaml":        TemplateCheckPassed,
+				"metadata":          TemplateCheckPassed,
+				"infrastructure":    TemplateCheckSkipped,
+			},
+		},
+		{
+			name: "MissingServicePath",
+			files: map[string]string{
+				"azure.yaml": schemaComment + "name: todo\nmetadata:\n  template: todo@1.0.0\n" +
+					"services:\n  web:\n    project: ./src/web\n    language: js\n    host: appservice\n",
+			},
+			want: map[string]TemplateCheckStatus{
+				"service web": TemplateCheckFailed,
+			},
+			message: "the project path './src/web' of the service doesn't exist",
+		},
+		{
+			name: "MissingMetadata",
+			files: map[string]string{
+				"azure.yaml": schemaComment + "name: todo\n",
+			},
+			want: map[string]TemplateCheckStatus{
+				"metadata": TemplateCheckFailed,
+			},
+			message: "metadata.template isn't set",
+		},
+		{
+			name: "UnknownParameter",
+			files: map[string]string{
+				"azure.yaml":                 validTemplate["azure.yaml"],
+				"src/api/app.py":             validTemplate["src/api/app.py"],
+				"infra/main.bicep":           validTemplate["infra/main.bicep"],
+				"infra/main.parameters.json": `{"parameters": {"location": {"value": ""}, "sku": {"value": "B1"}}}`,
+			},
+			want: map[string]TemplateCheckStatus{
+				"infrastructure": TemplateCheckPassed,
+				"parameters":     TemplateCheckFailed,
+			},
+			message: "main.parameters.json sets parameters the template doesn't declare: sku",
+		},
+		{
+			name: "MissingRequiredParameter",
+			files: map[string]string{
+				"azure.yaml":                 validTemplate["azure.yaml"],
+				"src/api/app.py":             validTemplate["src/api/app.py"],
+				"infra/main.bicep":           "param location string\nparam environmentName string\nparam sku string = 'B1'\n",
+				"infra/main.parameters.json": validTemplate["infra/main.parameters.json"],
+			},
+			compiled: `{"parameters": {
+				"location": {"type": "string"},
+				"environmentName": {"type": "string"},
+				"sku": {"type": "string", "defaultValue": "B1"},
+				"tags": {"type": "object", "nullable": true}
+			}}`,
+			want: map[string]TemplateCheckStatus{
+				"infrastructure": TemplateCheckPassed,
+				"parameters":     TemplateCheckFailed,
+			},
+			message: "main.parameters.json doesn't set required parameters of the template: environmentName",
+		},
+		{
+			name: "IncompleteCatalogEntry",
+			files: map[string]string{
+				"azure.yaml": validTemplate["azure.yaml"],
+				"templates.json": `[
+					{"name": "Other", "description": "Other", "repositoryPath": "contoso/other", "tags": ["python"]},
+					{"name": "Todo", "repositoryPath": "https://github.com/contoso/todo.git"}
+				]`,
+			},
+			options: TemplateValidateOptions{Catalog: "templates.json"},
+			want: map[string]TemplateCheckStatus{
+				"catalog entry": TemplateCheckFailed,
+			},
+			message: "the catalog entry of template 'todo' is missing description, tags",
+		},
+		{
+			name: "ParameterizedTemplate",
+			files: map[string]string{
+				"azd-template.yaml": "inputs:\n  - name: appName\n",
+				"azure.yaml.tmpl":   schemaComment + "name: {{ .appName }}\nmetadata:\n  template: todo@1.0.0\n",
+			},
+			options: TemplateValidateOptions{Inputs: map[string]string{"appName": "todo"}},
+			want: map[string]TemplateCheckStatus{
+				"inputs":            TemplateCheckPassed,
+				"azure.yaml schema": TemplateCheckPassed,
+				"azure.yaml":        TemplateCheckPassed,
+			},
+		},
+		{
+			name: "ParameterizedTemplateWithoutValue",
+			files: map[string]string{
+				"azd-template.yaml": "inputs:\n  - name: appName\n",
+				"azure.yaml.tmpl":   schemaComment + "name: {{ .appName }}\n",
+			},
+			want: map[string]TemplateCheckStatus{
+				"inputs": TemplateCheckFailed,
+			},
+			message: "template input 'appName' has no default value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			templateDir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(templateDir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+			}
+			if tt.options.Catalog != "" {
+				tt.options.Catalog = filepath.Join(templateDir, tt.options.Catalog)
+			}
+
+			t.Setenv("AZD_BICEP_TOOL_PATH", "bicep")
+			commandRunner := mockexec.NewMockCommandRunner()
+			commandRunner.When(func(args exec.RunArgs, command string) bool {
+				return args.Cmd == "bicep" && args.Args[0] == "--version"
+			}).Respond(exec.RunResult{Stdout: "Bicep CLI version " + bicep.Version.String() + " (abcdef0123)"})
+			commandRunner.When(func(args exec.RunArgs, command string) bool {
+				return args.Cmd == "bicep" && args.Args[0] == "build"
+			}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+				if tt.compiled != "" {
+					return exec.RunResult{Stdout: tt.compiled}, nil
+				}
+
+				return exec.RunResult{Stdout: `{"parameters": {"location": {"type": "string"}}}`}, nil
+			})
+
+			validator := NewTemplateValidator(
+				bicep.NewCli(mockinput.NewMockConsole(), commandRunner),
+				terraform.NewCli(commandRunner),
+			)
+			validator.schemaLoader = jsonschema.SchemeURLLoader{"file": jsonschema.FileLoader{}}
+
+			report, err := validator.Validate(context.Background(), templateDir, tt.options)
+			require.NoError(t, err)
+
+			checks := map[string]*TemplateCheck{}
+			for _, check := range report.Checks {
+				checks[check.Name] = check
+			}
+
+			for name, status := range tt.want {
+				require.Contains(t, checks, name)
+				require.Equal(t, status, checks[name].Status, "check %s: %s", name, checks[name].Message)
+				if status == TemplateCheckFailed && tt.message != "" {
+					require.True(t, strings.Contains(checks[name].Message, tt.message), checks[name].Message)
+				}
+			}
+		})
+	}
+}
+
+func Test_TemplateValidator_Validate_Terraform(t *testing.T) {
+	templateDir := t.TempDir()
+	files := map[string]string{
+		"azure.yaml":    "name: todo\nmetadata:\n  template: todo@1.0.0\ninfra:\n  provider: terraform\n",
+		"infra/main.tf": "variable \"location\" {}\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(templateDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+
+	commandRunner := mockexec.NewMockCommandRunner()
+	commandRunner.MockToolInPath("terraform", nil)
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && args.Args[0] == "version"
+	}).Respond(exec.RunResult{Stdout: `{"terraform_version": "1.9.0"}`})
+
+	var initDir string
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && slices.Contains(args.Args, "init")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		initDir = strings.TrimPrefix(args.Args[0], "-chdir=")
+
+		// terraform init writes its lock file next to the configuration
+		err := os.WriteFile(filepath.Join(initDir, ".terraform.lock.hcl"), nil, 0600)
+		return exec.RunResult{}, err
+	})
+	commandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && slices.Contains(args.Args, "validate")
+	}).Respond(exec.RunResult{})
+
+	validator := NewTemplateValidator(
+		bicep.NewCli(mockinput.NewMockConsole(), commandRunner),
+		terraform.NewCli(commandRunner),
+	)
+	validator.schemaLoader = jsonschema.SchemeURLLoader{"file": jsonschema.FileLoader{}}
+
+	report, err := validator.Validate(context.Background(), templateDir, TemplateValidateOptions{})
+	require.NoError(t, err)
+
+	var infraCheck *TemplateCheck
+	for _, check := range report.Checks {
+		if check.Name == "infrastructure" {
+			infraCheck = check
+		}
+	}
+	require.NotNil(t, infraCheck)
+	require.Equal(t, TemplateCheckPassed, infraCheck.Status, infraCheck.Message)
+
+	// The template is initialized from a copy and left unchanged
+	require.NotEmpty(t, initDir)
+	require.NotEqual(t, filepath.Join(templateDir, "infra"), initDir)
+	require.NoFileExists(t, filepath.Join(templateDir, "infra", ".terraform.lock.hcl"))
+}
@@ -32,6 +32,9 @@ import (
 // the equivalent of AZURE_CORE_COLLECT_TELEMETRY
 const collectTelemetryEnvVar = "AZURE_DEV_COLLECT_TELEMETRY"
 
+// collectTelemetryConfigKey turns telemetry off when set to 'off', usually enforced by the azd policy of an organization
+const collectTelemetryConfigKey = "telemetry.collect"
+
 const telemetryItemExtension = ".trn"
 
 //nolint:lll
@@ -71,6 +74,10 @@ func IsTelemetryEnabled() bool {
 		return false
 	}
 
+	if isTelemetryTurnedOff() {
+		return false
+	}
+
 	// If we're in cloud shell, only enable telemetry after showing notice once
 	if runcontext.IsRunningInCloudShell() && !noticeShown() {
 		return false
@@ -79,6 +86,34 @@ func IsTelemetryEnabled() bool {
 	return true
 }
 
+// isTelemetryTurnedOff returns a value indicating whether telemetry is turned off in the azd configuration. Telemetry
+// starts before the command line is parsed, so the environment of the project is the one selected with AZURE_ENV_NAME or
+// the default one.
+func isTelemetryTurnedOff() bool {
+	// The azd policy can turn telemetry off, so telemetry stays off when the policy is configured but can't be loaded
+	policy, err := config.LoadPolicy()
+	if err != nil {
+		log.Printf("failed loading the azd policy, turning telemetry off: %v", err)
+		return true
+	}
+
+	fileConfigManager := config.NewFileConfigManager(config.NewManager())
+	configManager := config.NewLayeredUserConfigManager(
+		config.NewUserConfigManager(fileConfigManager),
+		fileConfigManager,
+		func() config.ConfigScope { return config.CurrentConfigScope("") },
+	)
+
+	azdConfig, err := configManager.Load()
+	if err != nil {
+		log.Printf("failed loading the azd config to check whether telemetry is turned off: %v", err)
+		azdConfig = policy.Source().Config
+	}
+
+	value, _ := azdConfig.GetString(collectTelemetryConfigKey)
+	return value == "off"
+}
+
 // Returns the singleton TelemetrySystem instance.
 // Returns nil if telemetry failed to initialize, or user has disabled telemetry.
 func GetTelemetrySystem() *TelemetrySystem {
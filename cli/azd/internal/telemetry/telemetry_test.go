@@ -5,6 +5,8 @@ package telemetry
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -84,6 +86,47 @@ func TestGetTelemetrySystem(t *testing.T) {
 	}
 }
 
+func TestIsTelemetryEnabled_TurnedOffByPolicy(t *testing.T) {
+	ostest.Unsetenv(t, collectTelemetryEnvVar)
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+	require.True(t, IsTelemetryEnabled())
+
+	policyPath := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(policyPath, []byte(`{"enforced":{"telemetry.collect":"off"}}`), 0600))
+	t.Setenv("AZD_POLICY_FILE", policyPath)
+
+	require.False(t, IsTelemetryEnabled())
+}
+
+func TestIsTelemetryEnabled_PolicyUnavailable(t *testing.T) {
+	ostest.Unsetenv(t, collectTelemetryEnvVar)
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	policyPath := filepath.Join(t.TempDir(), "policy.json")
+	require.NoError(t, os.WriteFile(policyPath, []byte(`{"enforced":`), 0600))
+	t.Setenv("AZD_POLICY_FILE", policyPath)
+
+	require.False(t, IsTelemetryEnabled())
+}
+
+func TestIsTelemetryEnabled_TurnedOffByEnvironment(t *testing.T) {
+	ostest.Unsetenv(t, collectTelemetryEnvVar)
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	projectDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "azure.yaml"), []byte("name: test\n"), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, ".azure", "dev"), 0755))
+	require.NoError(t, os.WriteFile(
+		filepath.Join(projectDir, ".azure", "dev", "config.json"), []byte(`{"telemetry":{"collect":"off"}}`), 0600))
+	ostest.Chdir(t, projectDir)
+
+	t.Setenv("AZURE_ENV_NAME", "prod")
+	require.True(t, IsTelemetryEnabled())
+
+	t.Setenv("AZURE_ENV_NAME", "dev")
+	require.False(t, IsTelemetryEnabled())
+}
+
 func TestTelemetrySystem_RunBackgroundUpload(t *testing.T) {
 	type args struct {
 		ctx                context.Context
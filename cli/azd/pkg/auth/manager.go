@@ -99,6 +99,10 @@ type Manager struct {
 	externalAuthCfg     ExternalAuthConfiguration
 	azCli               az.AzCli
 	userAgent           string
+	// profile is the name of the auth profile, empty for the default profile
+	profile string
+	// pinnedProfile is the name of the profile pinned by the selected environment
+	pinnedProfile string
 }
 
 // UserAgent is a typed string for the application user-agent,
@@ -120,28 +124,73 @@ func NewManager(
 	externalAuthCfg ExternalAuthConfiguration,
 	azCli az.AzCli,
 	userAgent UserAgent,
+	pinnedProfile PinnedProfile,
 ) (*Manager, error) {
+	m := &Manager{
+		cloud:             cloud,
+		configManager:     configManager,
+		userConfigManager: userConfigManager,
+		httpClient:        httpClient,
+		console:           console,
+		externalAuthCfg:   externalAuthCfg,
+		azCli:             azCli,
+		userAgent:         string(userAgent),
+	}
+
+	// The profile pinned by the environment takes precedence over the profile selected with 'azd auth switch'
+	profile := string(pinnedProfile)
+	m.pinnedProfile = profile
+	if profile == "" {
+		if authConfig, err := m.readAuthConfig(); err == nil {
+			profile, _ = authConfig.GetString(ProfileConfigPath)
+		} else {
+			log.Printf("failed reading the selected auth profile: %v", err)
+		}
+	}
+
+	if err := m.useProfile(profile); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// useProfile sets up the token and credential caches of the given profile, which are stored in a directory of their
+// own. The caches of the default profile are stored where they were before profiles existed.
+func (m *Manager) useProfile(profile string) error {
+	if profile == DefaultProfileName {
+		profile = ""
+	}
+
 	cfgRoot, err := config.GetUserConfigDir()
 	if err != nil {
-		return nil, fmt.Errorf("getting config dir: %w", err)
+		return fmt.Errorf("getting config dir: %w", err)
 	}
 
 	authRoot := filepath.Join(cfgRoot, "auth")
+	if profile != "" {
+		if err := ValidateProfileName(profile); err != nil {
+			return err
+		}
+
+		authRoot = filepath.Join(authRoot, "profiles", profile)
+	}
+
 	if err := os.MkdirAll(authRoot, osutil.PermissionDirectoryOwnerOnly); err != nil {
-		return nil, fmt.Errorf("creating auth root: %w", err)
+		return fmt.Errorf("creating auth root: %w", err)
 	}
 
 	cacheRoot := filepath.Join(authRoot, "msal")
 	if err := os.MkdirAll(cacheRoot, osutil.PermissionDirectoryOwnerOnly); err != nil {
-		return nil, fmt.Errorf("creating msal cache root: %w", err)
+		return fmt.Errorf("creating msal cache root: %w", err)
 	}
 
-	authorityUrl, err := url.JoinPath(cloud.Configuration.ActiveDirectoryAuthorityHost, "organizations")
+	authorityUrl, err := url.JoinPath(m.cloud.Configuration.ActiveDirectoryAuthorityHost, "organizations")
 	if err != nil {
-		return nil, fmt.Errorf("joining authority url: %w", err)
+		return fmt.Errorf("joining authority url: %w", err)
 	}
 
-	msalClient := newUserAgentClient(httpClient, string(userAgent))
+	msalClient := newUserAgentClient(m.httpClient, m.userAgent)
 
 	options := []public.Option{
 		public.WithCache(newCache(cacheRoot)),
@@ -151,22 +200,14 @@ func NewManager(
 
 	publicClientApp, err := public.New(azdClientID, options...)
 	if err != nil {
-		return nil, fmt.Errorf("creating msal client: %w", err)
-	}
-
-	return &Manager{
-		publicClient:        &msalPublicClientAdapter{client: &publicClientApp},
-		publicClientOptions: options,
-		cloud:               cloud,
-		configManager:       configManager,
-		userConfigManager:   userConfigManager,
-		credentialCache:     newCredentialCache(authRoot),
-		httpClient:          httpClient,
-		console:             console,
-		externalAuthCfg:     externalAuthCfg,
-		azCli:               azCli,
-		userAgent:           string(userAgent),
-	}, nil
+		return fmt.Errorf("creating msal client: %w", err)
+	}
+
+	m.profile = profile
+	m.publicClient = &msalPublicClientAdapter{client: &publicClientApp}
+	m.publicClientOptions = options
+	m.credentialCache = newCredentialCache(authRoot)
+	return nil
 }
 
 // authClientOptions returns azcore.ClientOptions configured with the custom user-agent policy
@@ -269,7 +310,7 @@ func (m *Manager) CredentialForCurrentUser(
 		return nil, fmt.Errorf("reading auth config: %w", err)
 	}
 
-	currentUser, err := readUserProperties(authConfig)
+	currentUser, err := readUserProperties(authConfig, m.currentUserKey())
 	if errors.Is(err, ErrNoCurrentUser) {
 		// User is not logged in, not using az credentials, try CloudShell if possible
 		if runcontext.IsRunningInCloudShell() {
@@ -283,7 +324,7 @@ func (m *Manager) CredentialForCurrentUser(
 			// Try logging in the active OS account. If that fails for any reason, tell the user to run `azd auth login`.
 			if err := m.LoginWithBrokerAccount(); err == nil {
 				if config, err := m.readAuthConfig(); err == nil {
-					user, err := readUserProperties(config)
+					user, err := readUserProperties(config, m.currentUserKey())
 					if err == nil && user != nil && user.HomeAccountID != nil && *user.HomeAccountID != "" {
 						tenant := options.TenantID
 						if tenant == "" {
@@ -448,7 +489,7 @@ func (m *Manager) GetLoggedInServicePrincipalTenantID(ctx context.Context) (*str
 		return nil, fmt.Errorf("fetching auth config: %w", err)
 	}
 
-	currentUser, err := readUserProperties(authCfg)
+	currentUser, err := readUserProperties(authCfg, m.currentUserKey())
 	if err != nil {
 		// No user is logged in, if running in CloudShell use tenant id from
 		// CloudShell session (single tenant)
@@ -1027,7 +1068,7 @@ func (m *Manager) Logout(ctx context.Context) error {
 	}
 
 	// we are fine to ignore the error here, it just means there's nothing to clean up.
-	currentUser, _ := readUserProperties(cfg)
+	currentUser, _ := readUserProperties(cfg, m.currentUserKey())
 	if currentUser != nil {
 		if currentUser.FromOneAuth {
 			if err := oneauth.Logout(azdClientID); err != nil {
@@ -1043,8 +1084,12 @@ func (m *Manager) Logout(ctx context.Context) error {
 		}
 	}
 
-	if err := cfg.Unset(currentUserKey); err != nil {
-		return fmt.Errorf("un-setting current user: %w", err)
+	if m.profile == "" {
+		if err := cfg.Unset(currentUserKey); err != nil {
+			return fmt.Errorf("un-setting current user: %w", err)
+		}
+	} else if err := m.removeProfile(cfg); err != nil {
+		return err
 	}
 
 	if err := m.saveAuthConfig(cfg); err != nil {
@@ -1103,7 +1148,7 @@ func (m *Manager) getSignedInAccount(ctx context.Context) (*public.Account, erro
 		return nil, fmt.Errorf("fetching current user: %w", err)
 	}
 
-	currentUser, err := readUserProperties(cfg)
+	currentUser, err := readUserProperties(cfg, m.currentUserKey())
 	if err != nil {
 		return nil, ErrNoCurrentUser
 	}
@@ -1123,14 +1168,14 @@ func (m *Manager) getSignedInAccount(ctx context.Context) (*public.Account, erro
 	return nil, nil
 }
 
-// saveUserProperties writes the properties under [cCurrentUserKey], overwriting any existing value.
+// saveUserProperties writes the properties under the current user key of the profile, overwriting any existing value.
 func (m *Manager) saveUserProperties(user *userProperties) error {
 	cfg, err := m.readAuthConfig()
 	if err != nil {
 		return fmt.Errorf("fetching current user: %w", err)
 	}
 
-	if err := cfg.Set(currentUserKey, *user); err != nil {
+	if err := cfg.Set(m.currentUserKey(), *user); err != nil {
 		return fmt.Errorf("setting account id in config: %w", err)
 	}
 
@@ -1280,8 +1325,8 @@ type userProperties struct {
 	TenantID        *string `json:"tenantId,omitempty"`
 }
 
-func readUserProperties(cfg config.Config) (*userProperties, error) {
-	currentUser, has := cfg.Get(currentUserKey)
+func readUserProperties(cfg config.Config, key string) (*userProperties, error) {
+	currentUser, has := cfg.Get(key)
 	if !has {
 		return nil, ErrNoCurrentUser
 	}
@@ -1404,7 +1449,7 @@ func (m *Manager) LogInDetails(ctx context.Context) (*LogInDetails, error) {
 		return nil, fmt.Errorf("fetching current user: %w", err)
 	}
 
-	currentUser, err := readUserProperties(cfg)
+	currentUser, err := readUserProperties(cfg, m.currentUserKey())
 	if err != nil {
 		return nil, ErrNoCurrentUser
 	}
@@ -33,7 +33,7 @@ func TestReadUserProperties(t *testing.T) {
 		cfg := config.NewEmptyConfig()
 		require.NoError(t, cfg.Set("auth.account.currentUser.homeAccountId", "testAccountId"))
 
-		props, err := readUserProperties(cfg)
+		props, err := readUserProperties(cfg, currentUserKey)
 
 		require.NoError(t, err)
 		require.Nil(t, props.ClientID)
@@ -46,7 +46,7 @@ func TestReadUserProperties(t *testing.T) {
 		require.NoError(t, cfg.Set("auth.account.currentUser.clientId", "testClientId"))
 		require.NoError(t, cfg.Set("auth.account.currentUser.tenantId", "testTenantId"))
 
-		props, err := readUserProperties(cfg)
+		props, err := readUserProperties(cfg, currentUserKey)
 
 		require.NoError(t, err)
 		require.Nil(t, props.HomeAccountID)
@@ -281,7 +281,7 @@ func TestAuthFileConfigUpgrade(t *testing.T) {
 	cfg, err := m.readAuthConfig()
 	require.NoError(t, err)
 
-	properties, err := readUserProperties(cfg)
+	properties, err := readUserProperties(cfg, currentUserKey)
 	require.NoError(t, err)
 	require.NotNil(t, properties.HomeAccountID)
 	require.Equal(t, "homeAccountID", *properties.HomeAccountID)
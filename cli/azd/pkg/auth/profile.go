@@ -0,0 +1,191 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+)
+
+// ProfileConfigPath is the config path of the auth profile. In auth.json it holds the profile selected with
+// 'azd auth switch'; in the config of an environment it pins the profile used while the environment is selected.
+const ProfileConfigPath = "auth.profile"
+
+// DefaultProfileName is the name of the profile azd uses when no other profile is selected. Its account and token cache
+// are the ones azd used before profiles existed.
+const DefaultProfileName = "default"
+
+// profilesKey is the key we use in auth.json for storing the identity information of the named profiles.
+const profilesKey = "auth.profiles"
+
+// ErrProfileNotFound indicates that no account is logged in with the profile.
+var ErrProfileNotFound = errors.New("auth profile not found")
+
+// PinnedProfile is the name of the auth profile pinned by the selected environment, empty when the environment doesn't
+// pin a profile.
+type PinnedProfile string
+
+var profileNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]{0,63}$`)
+
+// ValidateProfileName checks the name of a profile can be used as a config key and as the name of its token cache
+// directory.
+func ValidateProfileName(name string) error {
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf(
+			"invalid profile name '%s': names start with a letter or digit and only contain letters, digits, "+
+				"'-' and '_'", name)
+	}
+
+	return nil
+}
+
+// Profile is an auth profile and the account logged in with it.
+type Profile struct {
+	Name string `json:"name"`
+	// Current is set for the profile used by azd
+	Current bool `json:"current"`
+	// Account is the account logged in with the profile, empty when logged out
+	Account   string    `json:"account,omitempty"`
+	LoginType LoginType `json:"loginType,omitempty"`
+}
+
+// ProfileName returns the name of the profile used by the manager.
+func (m *Manager) ProfileName() string {
+	if m.profile == "" {
+		return DefaultProfileName
+	}
+
+	return m.profile
+}
+
+// PinnedProfile returns the name of the profile pinned by the selected environment, or empty when the profile isn't
+// pinned.
+func (m *Manager) PinnedProfile() string {
+	return m.pinnedProfile
+}
+
+// ForProfile returns a manager using the token cache and the account of the given profile.
+func (m *Manager) ForProfile(name string) (*Manager, error) {
+	if name == m.ProfileName() {
+		return m, nil
+	}
+
+	profileManager := *m
+	if err := profileManager.useProfile(name); err != nil {
+		return nil, err
+	}
+
+	return &profileManager, nil
+}
+
+// Profiles returns the default profile, the profiles logged in with 'azd auth login --profile', sorted by name, and the
+// profile of the manager.
+func (m *Manager) Profiles(ctx context.Context) ([]Profile, error) {
+	cfg, err := m.readAuthConfig()
+	if err != nil {
+		return nil, fmt.Errorf("fetching profiles: %w", err)
+	}
+
+	names := []string{DefaultProfileName}
+	if profiles, has := cfg.GetMap(profilesKey); has {
+		names = append(names, slices.Sorted(maps.Keys(profiles))...)
+	}
+
+	// The profile pinned by the environment is listed before being logged in
+	if !slices.Contains(names, m.ProfileName()) {
+		names = append(names, m.ProfileName())
+	}
+
+	result := make([]Profile, 0, len(names))
+	for _, name := range names {
+		profile := Profile{
+			Name:    name,
+			Current: name == m.ProfileName(),
+		}
+
+		profileManager, err := m.ForProfile(name)
+		if err != nil {
+			return nil, err
+		}
+
+		details, err := profileManager.LogInDetails(ctx)
+		if err != nil && !errors.Is(err, ErrNoCurrentUser) {
+			return nil, err
+		}
+		if details != nil {
+			profile.Account = details.Account
+			profile.LoginType = details.LoginType
+		}
+
+		result = append(result, profile)
+	}
+
+	return result, nil
+}
+
+// SwitchProfile selects the profile azd uses, unless the selected environment pins another one.
+func (m *Manager) SwitchProfile(name string) error {
+	cfg, err := m.readAuthConfig()
+	if err != nil {
+		return fmt.Errorf("fetching profiles: %w", err)
+	}
+
+	if name == DefaultProfileName {
+		if err := cfg.Unset(ProfileConfigPath); err != nil {
+			return fmt.Errorf("un-setting profile: %w", err)
+		}
+
+		return m.saveAuthConfig(cfg)
+	}
+
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	if _, has := cfg.Get(profileKey(name)); !has {
+		return fmt.Errorf("'%s': %w", name, ErrProfileNotFound)
+	}
+
+	if err := cfg.Set(ProfileConfigPath, name); err != nil {
+		return fmt.Errorf("setting profile: %w", err)
+	}
+
+	return m.saveAuthConfig(cfg)
+}
+
+// currentUserKey returns the key we use in auth.json for storing the identity information of the user logged in with
+// the profile of the manager.
+func (m *Manager) currentUserKey() string {
+	if m.profile == "" {
+		return currentUserKey
+	}
+
+	return profileKey(m.profile) + ".currentUser"
+}
+
+// removeProfile removes the profile of the manager from the auth config, selecting the default profile again when it
+// was the selected one.
+func (m *Manager) removeProfile(cfg config.Config) error {
+	if err := cfg.Unset(profileKey(m.profile)); err != nil {
+		return fmt.Errorf("un-setting profile: %w", err)
+	}
+
+	if selected, _ := cfg.GetString(ProfileConfigPath); selected == m.profile {
+		if err := cfg.Unset(ProfileConfigPath); err != nil {
+			return fmt.Errorf("un-setting profile: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func profileKey(name string) string {
+	return profilesKey + "." + name
+}
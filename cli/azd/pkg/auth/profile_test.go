@@ -0,0 +1,118 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/cloud"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/az"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("AZD_CONFIG_DIR", configDir)
+
+	m := &Manager{
+		configManager:     newMemoryConfigManager(),
+		userConfigManager: newMemoryUserConfigManager(),
+		credentialCache:   &memoryCache{cache: make(map[string][]byte)},
+		cloud:             cloud.AzurePublic(),
+		httpClient:        http.DefaultClient,
+	}
+
+	_, err := m.LoginWithServicePrincipalSecret(context.Background(), "corpTenantId", "corpClientId", "secret")
+	require.NoError(t, err)
+
+	customer, err := m.ForProfile("customer-a")
+	require.NoError(t, err)
+	require.Equal(t, "customer-a", customer.ProfileName())
+	require.DirExists(t, filepath.Join(configDir, "auth", "profiles", "customer-a", "msal"))
+
+	// The profile isn't logged in until 'azd auth login --profile'
+	_, err = customer.LogInDetails(context.Background())
+	require.ErrorIs(t, err, ErrNoCurrentUser)
+	require.ErrorIs(t, m.SwitchProfile("customer-a"), ErrProfileNotFound)
+
+	_, err = customer.LoginWithServicePrincipalSecret(
+		context.Background(), "customerTenantId", "customerClientId", "secret",
+	)
+	require.NoError(t, err)
+	require.NoError(t, m.SwitchProfile("customer-a"))
+
+	profiles, err := m.Profiles(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []Profile{
+		{Name: DefaultProfileName, Current: true, Account: "corpClientId", LoginType: ClientIdLoginType},
+		{Name: "customer-a", Account: "customerClientId", LoginType: ClientIdLoginType},
+	}, profiles)
+
+	cfg, err := m.readAuthConfig()
+	require.NoError(t, err)
+	selected, _ := cfg.GetString(ProfileConfigPath)
+	require.Equal(t, "customer-a", selected)
+
+	// Logging out of a profile removes it and selects the default profile again
+	require.NoError(t, customer.Logout(context.Background()))
+
+	cfg, err = m.readAuthConfig()
+	require.NoError(t, err)
+	_, has := cfg.Get(ProfileConfigPath)
+	require.False(t, has)
+
+	profiles, err = m.Profiles(context.Background())
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+
+	details, err := m.LogInDetails(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "corpClientId", details.Account)
+}
+
+func TestNewManagerProfile(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("AZD_CONFIG_DIR", configDir)
+
+	configManager := config.NewFileConfigManager(config.NewManager())
+	authConfig := config.NewEmptyConfig()
+	require.NoError(t, authConfig.Set(ProfileConfigPath, "customer-a"))
+	require.NoError(t, configManager.Save(authConfig, filepath.Join(configDir, authConfigFileName)))
+
+	newManager := func(pinned PinnedProfile) (*Manager, error) {
+		return NewManager(
+			configManager, config.NewUserConfigManager(configManager), cloud.AzurePublic(), http.DefaultClient, nil,
+			ExternalAuthConfiguration{}, az.AzCli{}, "", pinned,
+		)
+	}
+
+	t.Run("Selected", func(t *testing.T) {
+		m, err := newManager("")
+		require.NoError(t, err)
+		require.Equal(t, "customer-a", m.ProfileName())
+		require.Empty(t, m.PinnedProfile())
+	})
+
+	t.Run("Pinned", func(t *testing.T) {
+		m, err := newManager("prod")
+		require.NoError(t, err)
+		require.Equal(t, "prod", m.ProfileName())
+		require.Equal(t, "prod", m.PinnedProfile())
+
+		_, err = os.Stat(filepath.Join(configDir, "auth", "profiles", "prod", "msal"))
+		require.NoError(t, err)
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		_, err := newManager("../prod")
+		require.Error(t, err)
+		require.False(t, errors.Is(err, ErrProfileNotFound))
+	})
+}
@@ -63,6 +63,9 @@ type ResourceDeployment struct {
 	// The outputs from the deployment
 	Outputs any
 
+	// The parameters of the deployment. The values of secure parameters are not included.
+	Parameters any
+
 	// The hash produced for the template.
 	TemplateHash *string
 
@@ -211,6 +214,17 @@ type DeploymentService interface {
 		armTemplate azure.RawArmTemplate,
 		parameters azure.ArmParameters,
 	) (*armresources.WhatIfOperationResult, error)
+	ExportSubscriptionDeploymentTemplate(
+		ctx context.Context,
+		subscriptionId string,
+		deploymentName string,
+	) (azure.RawArmTemplate, error)
+	ExportResourceGroupDeploymentTemplate(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		deploymentName string,
+	) (azure.RawArmTemplate, error)
 	DeleteSubscriptionDeployment(
 		ctx context.Context,
 		subscriptionId string,
@@ -6,10 +6,19 @@ package azapi
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	armruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/operationalinsights/armoperationalinsights/v2"
 )
 
+// The api version of the log analytics query API exposed through Azure Resource Manager
+const logAnalyticsQueryApiVersion = "2020-08-01"
+
 type AzCliLogAnalyticsWorkspace struct {
 	Id   string `json:"id"`
 	Name string `json:"name"`
@@ -37,6 +46,112 @@ func (cli *AzureClient) GetLogAnalyticsWorkspace(
 	}, nil
 }
 
+// LogAnalyticsQueryResult is the result of a KQL query against a log analytics workspace.
+type LogAnalyticsQueryResult struct {
+	Tables []LogAnalyticsTable `json:"tables"`
+}
+
+// LogAnalyticsTable is a table of results returned by a KQL query.
+type LogAnalyticsTable struct {
+	Name    string               `json:"name"`
+	Columns []LogAnalyticsColumn `json:"columns"`
+	Rows    [][]any              `json:"rows"`
+}
+
+// LogAnalyticsColumn describes a column of a LogAnalyticsTable.
+type LogAnalyticsColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// QueryLogAnalyticsWorkspace runs the KQL query against the log analytics workspace with the specified resource id.
+// When timespan is greater than zero, the query only considers records of the most recent timespan.
+func (cli *AzureClient) QueryLogAnalyticsWorkspace(
+	ctx context.Context,
+	subscriptionId string,
+	workspaceId string,
+	query string,
+	timespan time.Duration,
+) (*LogAnalyticsQueryResult, error) {
+	credential, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	queryOptions := &arm.ClientOptions{}
+	if cli.armClientOptions != nil {
+		optionsCopy := *cli.armClientOptions
+		queryOptions = &optionsCopy
+	}
+
+	// The query API is a data plane API proxied by Azure Resource Manager, there is no provider to register
+	queryOptions.DisableRPRegistration = true
+
+	pipeline, err := armruntime.NewPipeline(
+		"log-analytics-query", "1.0.0", credential, runtime.PipelineOptions{}, queryOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating HTTP pipeline: %w", err)
+	}
+
+	endpoint := cloud.AzurePublic.Services[cloud.ResourceManager].Endpoint
+	if armEndpoint, has := queryOptions.Cloud.Services[cloud.ResourceManager]; has && armEndpoint.Endpoint != "" {
+		endpoint = armEndpoint.Endpoint
+	}
+
+	request, err := runtime.NewRequest(ctx, http.MethodPost, runtime.JoinPaths(endpoint, workspaceId, "api", "query"))
+	if err != nil {
+		return nil, fmt.Errorf("creating query request: %w", err)
+	}
+
+	queryParams := request.Raw().URL.Query()
+	queryParams.Set("api-version", logAnalyticsQueryApiVersion)
+	request.Raw().URL.RawQuery = queryParams.Encode()
+
+	body := map[string]string{
+		"query": query,
+	}
+	if timespan > 0 {
+		body["timespan"] = fmt.Sprintf("PT%dS", int64(timespan.Seconds()))
+	}
+
+	if err := runtime.MarshalAsJSON(request, body); err != nil {
+		return nil, fmt.Errorf("marshalling query request: %w", err)
+	}
+
+	response, err := pipeline.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("querying log analytics workspace: %w", err)
+	}
+
+	if !runtime.HasStatusCode(response, http.StatusOK) {
+		return nil, fmt.Errorf("querying log analytics workspace: %w", runtime.NewResponseError(response))
+	}
+
+	var result LogAnalyticsQueryResult
+	if err := runtime.UnmarshalAsJSON(response, &result); err != nil {
+		return nil, fmt.Errorf("unmarshalling query response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// RowMaps returns the rows of the table as maps of column names to values.
+func (t LogAnalyticsTable) RowMaps() []map[string]any {
+	rows := make([]map[string]any, 0, len(t.Rows))
+	for _, row := range t.Rows {
+		values := make(map[string]any, len(t.Columns))
+		for i, column := range t.Columns {
+			if i < len(row) {
+				values[column.Name] = row[i]
+			}
+		}
+
+		rows = append(rows, values)
+	}
+
+	return rows
+}
+
 func (cli *AzureClient) PurgeLogAnalyticsWorkspace(
 	ctx context.Context,
 	subscriptionId string,
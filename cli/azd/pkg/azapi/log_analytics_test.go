@@ -0,0 +1,64 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_QueryLogAnalyticsWorkspace(t *testing.T) {
+	workspaceId := "/subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP/providers/" +
+		"Microsoft.OperationalInsights/workspaces/WORKSPACE"
+
+	mockContext := mocks.NewMockContext(context.Background())
+	azCli := newAzureClientFromMockContext(mockContext)
+
+	var requestBody map[string]string
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost && strings.HasSuffix(request.URL.Path, "/WORKSPACE/api/query")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		require.Equal(t, logAnalyticsQueryApiVersion, request.URL.Query().Get("api-version"))
+		require.NoError(t, json.NewDecoder(request.Body).Decode(&requestBody))
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, LogAnalyticsQueryResult{
+			Tables: []LogAnalyticsTable{
+				{
+					Name: "PrimaryResult",
+					Columns: []LogAnalyticsColumn{
+						{Name: "TimeGenerated", Type: "datetime"},
+						{Name: "Log_s", Type: "string"},
+					},
+					Rows: [][]any{
+						{"2024-01-01T00:00:00Z", "started"},
+					},
+				},
+			},
+		})
+	})
+
+	result, err := azCli.QueryLogAnalyticsWorkspace(
+		*mockContext.Context,
+		"SUBSCRIPTION_ID",
+		workspaceId,
+		"ContainerAppConsoleLogs_CL | take 1",
+		time.Hour,
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, "ContainerAppConsoleLogs_CL | take 1", requestBody["query"])
+	require.Equal(t, "PT3600S", requestBody["timespan"])
+
+	require.Len(t, result.Tables, 1)
+	require.Equal(t, []map[string]any{
+		{"TimeGenerated": "2024-01-01T00:00:00Z", "Log_s": "started"},
+	}, result.Tables[0].RowMaps())
+}
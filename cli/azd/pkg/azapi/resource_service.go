@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
@@ -319,6 +320,80 @@ func (rs *ResourceService) DeleteResourceGroup(ctx context.Context, subscription
 	return nil
 }
 
+// DeleteResource deletes the resource with the given id, using the default API version of its resource type.
+func (rs *ResourceService) DeleteResource(ctx context.Context, resourceId arm.ResourceID) error {
+	apiVersion, err := rs.resourceTypeApiVersion(ctx, resourceId)
+	if err != nil {
+		return err
+	}
+
+	client, err := rs.createResourcesClient(ctx, resourceId.SubscriptionID)
+	if err != nil {
+		return err
+	}
+
+	poller, err := client.BeginDeleteByID(ctx, resourceId.String(), apiVersion, nil)
+	// Resource is already deleted
+	if respErr, ok := errors.AsType[*azcore.ResponseError](err); ok &&
+		respErr.StatusCode == 404 {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("beginning resource deletion: %w", err)
+	}
+
+	_, err = poller.PollUntilDone(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("deleting resource: %w", err)
+	}
+
+	return nil
+}
+
+// resourceTypeApiVersion returns the API version used to manage resources of the type of the given resource, as
+// registered by its resource provider. The default API version is preferred, then the latest stable API version.
+func (rs *ResourceService) resourceTypeApiVersion(ctx context.Context, resourceId arm.ResourceID) (string, error) {
+	credential, err := rs.credentialProvider.CredentialForSubscription(ctx, resourceId.SubscriptionID)
+	if err != nil {
+		return "", err
+	}
+
+	client, err := armresources.NewProvidersClient(resourceId.SubscriptionID, credential, rs.armClientOptions)
+	if err != nil {
+		return "", fmt.Errorf("creating Providers client: %w", err)
+	}
+
+	provider, err := client.Get(ctx, resourceId.ResourceType.Namespace, nil)
+	if err != nil {
+		return "", fmt.Errorf("getting resource provider %s: %w", resourceId.ResourceType.Namespace, err)
+	}
+
+	resourceType := strings.Join(resourceId.ResourceType.Types, "/")
+	for _, providerType := range provider.ResourceTypes {
+		if providerType.ResourceType == nil || !strings.EqualFold(*providerType.ResourceType, resourceType) {
+			continue
+		}
+
+		if providerType.DefaultAPIVersion != nil {
+			return *providerType.DefaultAPIVersion, nil
+		}
+
+		// API versions are listed from the latest
+		for _, apiVersion := range providerType.APIVersions {
+			if apiVersion != nil && !strings.HasSuffix(*apiVersion, "-preview") {
+				return *apiVersion, nil
+			}
+		}
+
+		if len(providerType.APIVersions) > 0 && providerType.APIVersions[0] != nil {
+			return *providerType.APIVersions[0], nil
+		}
+	}
+
+	return "", fmt.Errorf("no API version found for resource type %s", resourceId.ResourceType)
+}
+
 func (rs *ResourceService) createResourcesClient(ctx context.Context, subscriptionId string) (*armresources.Client, error) {
 	credential, err := rs.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
 	if err != nil {
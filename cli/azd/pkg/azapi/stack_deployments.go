@@ -413,6 +413,23 @@ func (d *StackDeployments) WhatIfDeployToResourceGroup(
 	return nil, ErrPreviewNotSupported
 }
 
+func (d *StackDeployments) ExportSubscriptionDeploymentTemplate(
+	ctx context.Context,
+	subscriptionId string,
+	deploymentName string,
+) (azure.RawArmTemplate, error) {
+	return nil, ErrPreviewNotSupported
+}
+
+func (d *StackDeployments) ExportResourceGroupDeploymentTemplate(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	deploymentName string,
+) (azure.RawArmTemplate, error) {
+	return nil, ErrPreviewNotSupported
+}
+
 func (d *StackDeployments) ListSubscriptionDeploymentResources(
 	ctx context.Context,
 	subscriptionId string,
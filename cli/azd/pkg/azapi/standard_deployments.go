@@ -621,6 +621,63 @@ func (ds *StandardDeployments) WhatIfDeployToResourceGroup(
 	return &deployResult.WhatIfOperationResult, nil
 }
 
+func (ds *StandardDeployments) ExportSubscriptionDeploymentTemplate(
+	ctx context.Context,
+	subscriptionId string,
+	deploymentName string,
+) (azure.RawArmTemplate, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	exported, err := deploymentClient.ExportTemplateAtSubscriptionScope(ctx, deploymentName, nil)
+	if err != nil {
+		if errDetails, ok := errors.AsType[*azcore.ResponseError](err); ok && errDetails.StatusCode == 404 {
+			return nil, ErrDeploymentNotFound
+		}
+		return nil, fmt.Errorf("exporting deployment template from subscription: %w", err)
+	}
+
+	return marshalExportedTemplate(exported.Template)
+}
+
+func (ds *StandardDeployments) ExportResourceGroupDeploymentTemplate(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	deploymentName string,
+) (azure.RawArmTemplate, error) {
+	deploymentClient, err := ds.createDeploymentsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("creating deployments client: %w", err)
+	}
+
+	exported, err := deploymentClient.ExportTemplate(ctx, resourceGroupName, deploymentName, nil)
+	if err != nil {
+		if errDetails, ok := errors.AsType[*azcore.ResponseError](err); ok && errDetails.StatusCode == 404 {
+			return nil, ErrDeploymentNotFound
+		}
+		return nil, fmt.Errorf("exporting deployment template from resource group: %w", err)
+	}
+
+	return marshalExportedTemplate(exported.Template)
+}
+
+// marshalExportedTemplate converts the template exported from a deployment back to its raw form
+func marshalExportedTemplate(template any) (azure.RawArmTemplate, error) {
+	if template == nil {
+		return nil, errors.New("the deployment has no template")
+	}
+
+	rawTemplate, err := json.Marshal(template)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling exported template: %w", err)
+	}
+
+	return rawTemplate, nil
+}
+
 func (ds *StandardDeployments) createDeploymentsOperationsClient(
 	ctx context.Context,
 	subscriptionId string,
@@ -651,6 +708,7 @@ func (ds *StandardDeployments) convertFromArmDeployment(deployment *armresources
 		Timestamp:         *deployment.Properties.Timestamp,
 		TemplateHash:      deployment.Properties.TemplateHash,
 		Outputs:           deployment.Properties.Outputs,
+		Parameters:        deployment.Properties.Parameters,
 		Resources:         deployment.Properties.OutputResources,
 		Dependencies:      deployment.Properties.Dependencies,
 
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
 )
@@ -18,6 +19,8 @@ type AzCliStaticWebAppProperties struct {
 type AzCliStaticWebAppEnvironmentProperties struct {
 	Hostname string
 	Status   string
+	// The time the environment was last updated, such as by a deployment
+	LastUpdated *time.Time
 }
 
 func (cli *AzureClient) GetStaticWebAppProperties(
@@ -59,8 +62,9 @@ func (cli *AzureClient) GetStaticWebAppEnvironmentProperties(
 	}
 
 	return &AzCliStaticWebAppEnvironmentProperties{
-		Hostname: *build.Properties.Hostname,
-		Status:   string(*build.Properties.Status),
+		Hostname:    *build.Properties.Hostname,
+		Status:      string(*build.Properties.Status),
+		LastUpdated: build.Properties.LastUpdatedOn,
 	}, nil
 }
 
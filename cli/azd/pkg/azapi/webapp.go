@@ -10,12 +10,16 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appservice/armappservice/v2"
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
 )
 
+// The time to wait for new log entries before the recent logs of a web app are considered complete
+const appServiceLogStreamIdleTimeout = 5 * time.Second
+
 type AzCliAppServiceProperties struct {
 	HostNames []string
 }
@@ -58,6 +62,70 @@ func (cli *AzureClient) GetAppServiceSlotProperties(
 	}, nil
 }
 
+// AppServiceStatus is the runtime status of a web app or function app
+type AppServiceStatus struct {
+	// The state of the app, such as Running or Stopped
+	State string
+	// The time the app was last updated, such as by a deployment
+	LastModified *time.Time
+	// The number of instances running the app
+	Instances int
+	// The percentage of the production traffic routed to each deployment slot
+	SlotTraffic map[string]float64
+}
+
+// GetAppServiceStatus returns the runtime status of the specified web app or function app
+func (cli *AzureClient) GetAppServiceStatus(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+) (*AppServiceStatus, error) {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	webApp, err := client.Get(ctx, resourceGroup, appName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving webapp properties: %w", err)
+	}
+
+	status := &AppServiceStatus{
+		SlotTraffic: map[string]float64{},
+	}
+	if webApp.Properties != nil {
+		if webApp.Properties.State != nil {
+			status.State = *webApp.Properties.State
+		}
+		status.LastModified = webApp.Properties.LastModifiedTimeUTC
+	}
+
+	pager := client.NewListInstanceIdentifiersPager(resourceGroup, appName, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("listing webapp instances: %w", err)
+		}
+		status.Instances += len(page.Value)
+	}
+
+	config, err := client.GetConfiguration(ctx, resourceGroup, appName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving webapp configuration: %w", err)
+	}
+
+	if config.Properties != nil && config.Properties.Experiments != nil {
+		for _, rule := range config.Properties.Experiments.RampUpRules {
+			if rule.Name != nil && rule.ReroutePercentage != nil {
+				status.SlotTraffic[*rule.Name] = *rule.ReroutePercentage
+			}
+		}
+	}
+
+	return status, nil
+}
+
 func (cli *AzureClient) appService(
 	ctx context.Context,
 	subscriptionId string,
@@ -226,6 +294,50 @@ func (cli *AzureClient) createZipDeployClient(
 	return client, nil
 }
 
+// StreamAppServiceLogs writes the console and application logs of the web app, as served by the log stream endpoint of
+// its repository (Kudu) host, to the writer. When follow is false, reading stops once the recent logs have been
+// received.
+func (cli *AzureClient) StreamAppServiceLogs(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	follow bool,
+	writer io.Writer,
+) error {
+	app, err := cli.appService(ctx, subscriptionId, resourceGroup, appName)
+	if err != nil {
+		return err
+	}
+
+	hostName, err := appServiceRepositoryHost(app, appName)
+	if err != nil {
+		return err
+	}
+
+	credential, err := cli.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	client, err := azsdk.NewLogStreamClient(credential, cli.armClientOptions)
+	if err != nil {
+		return fmt.Errorf("creating log stream client: %w", err)
+	}
+
+	// The log stream endpoint never completes the response, so stop once the recent logs stop arriving
+	options := &azsdk.LogStreamOptions{}
+	if !follow {
+		options.IdleTimeout = appServiceLogStreamIdleTimeout
+	}
+
+	if err := client.Stream(ctx, fmt.Sprintf("https://%s/api/logstream", hostName), options, writer); err != nil {
+		return fmt.Errorf("streaming logs for webapp %s: %w", appName, err)
+	}
+
+	return nil
+}
+
 // HasAppServiceDeployments checks if the web app has at least one previous deployment.
 func (cli *AzureClient) HasAppServiceDeployments(
 	ctx context.Context,
@@ -337,3 +449,78 @@ func (cli *AzureClient) DeployAppServiceSlotZip(
 
 	return new(response.StatusText), nil
 }
+
+// SetAppServiceSlotTraffic routes the percentage of the production traffic of the web app to the deployment slot, using
+// the ramp up rules of the app. A percentage of 0 removes the ramp up rules, routing all traffic to production.
+func (cli *AzureClient) SetAppServiceSlotTraffic(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+	percentage float64,
+) error {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	rampUpRules := []*armappservice.RampUpRule{}
+	if percentage > 0 {
+		slot, err := client.GetSlot(ctx, resourceGroup, appName, slotName, nil)
+		if err != nil {
+			return fmt.Errorf("failed retrieving webapp slot: %w", err)
+		}
+
+		if slot.Properties == nil || slot.Properties.DefaultHostName == nil {
+			return fmt.Errorf("failed to find host name for slot %s", slotName)
+		}
+
+		rampUpRules = append(rampUpRules, &armappservice.RampUpRule{
+			Name:              &slotName,
+			ActionHostName:    slot.Properties.DefaultHostName,
+			ReroutePercentage: &percentage,
+		})
+	}
+
+	_, err = client.UpdateConfiguration(ctx, resourceGroup, appName, armappservice.SiteConfigResource{
+		Properties: &armappservice.SiteConfig{
+			Experiments: &armappservice.Experiments{
+				RampUpRules: rampUpRules,
+			},
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("updating webapp traffic routing: %w", err)
+	}
+
+	return nil
+}
+
+// SwapAppServiceSlot swaps the deployment slot with the production slot of the web app.
+func (cli *AzureClient) SwapAppServiceSlot(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroup string,
+	appName string,
+	slotName string,
+) error {
+	client, err := cli.createWebAppsClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	poller, err := client.BeginSwapSlotWithProduction(ctx, resourceGroup, appName, armappservice.CsmSlotEntity{
+		TargetSlot:   &slotName,
+		PreserveVnet: new(true),
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("swapping webapp slot %s: %w", slotName, err)
+	}
+
+	if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+		return fmt.Errorf("swapping webapp slot %s: %w", slotName, err)
+	}
+
+	return nil
+}
@@ -6,6 +6,7 @@ package azd
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/azsdk/storage"
@@ -14,6 +15,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
 	infraBicep "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/bicep"
 	infraTerraform "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/terraform"
+	infraTest "github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning/test"
 	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
 	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
 	"github.com/azure/azure-dev/cli/azd/pkg/platform"
@@ -82,6 +84,11 @@ func (p *DefaultPlatform) ConfigureContainer(container *ioc.NestedContainer) err
 		provisioning.Terraform: infraTerraform.NewTerraformProvider,
 	}
 
+	// The test provider doesn't create any resource, it's only available to the azd processes run by 'azd template test'
+	if os.Getenv(provisioning.TestProviderEnvVarName) == "true" {
+		provisionProviderMap[provisioning.Test] = infraTest.NewTestProvider
+	}
+
 	for provider, constructor := range provisionProviderMap {
 		container.MustRegisterNamedTransient(string(provider), constructor)
 	}
@@ -5,7 +5,6 @@ package azdext
 
 import (
 	"context"
-	"os"
 	"strings"
 
 	"google.golang.org/grpc"
@@ -50,7 +49,7 @@ func WithAddress(address string) AzdClientOption {
 func WithAccessToken(ctx context.Context, params ...string) context.Context {
 	tokenValue := strings.Join(params, "")
 	if tokenValue == "" {
-		tokenValue = os.Getenv("AZD_ACCESS_TOKEN")
+		tokenValue = Getenv(ctx, "AZD_ACCESS_TOKEN")
 	}
 
 	md := metadata.Pairs("authorization", tokenValue)
@@ -59,8 +58,14 @@ func WithAccessToken(ctx context.Context, params ...string) context.Context {
 
 // NewAzdClient creates a new `azd` client.
 func NewAzdClient(opts ...AzdClientOption) (*AzdClient, error) {
+	return NewAzdClientFromContext(context.Background(), opts...)
+}
+
+// NewAzdClientFromContext creates a new `azd` client. Without options, the client connects to the `azd` gRPC server of
+// the azd invocation served by the context, see [LookupEnv].
+func NewAzdClientFromContext(ctx context.Context, opts ...AzdClientOption) (*AzdClient, error) {
 	if opts == nil {
-		opts = append(opts, WithAddress(os.Getenv("AZD_SERVER")))
+		opts = append(opts, WithAddress(Getenv(ctx, "AZD_SERVER")))
 	}
 
 	client := &AzdClient{}
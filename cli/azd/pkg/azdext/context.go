@@ -22,9 +22,13 @@ const (
 
 // NewContext initializes a new context with tracing information extracted from environment variables.
 func NewContext() context.Context {
-	ctx := context.Background()
-	parent := os.Getenv(TraceparentEnv)
-	state := os.Getenv(TracestateEnv)
+	return withTraceContext(context.Background())
+}
+
+// withTraceContext returns a context with tracing information extracted from the environment variables of the context.
+func withTraceContext(ctx context.Context) context.Context {
+	parent := Getenv(ctx, TraceparentEnv)
+	state := Getenv(ctx, TracestateEnv)
 
 	if parent != "" {
 		tc := propagation.TraceContext{}
@@ -36,3 +40,30 @@ func NewContext() context.Context {
 
 	return ctx
 }
+
+type sessionEnvKey struct{}
+
+// WithSessionEnv returns a context carrying the environment variables of the azd invocation served by the context.
+// Long-lived processes, such as extension daemons, serve several azd invocations and must not change their process
+// environment for each of them, which would leak the values of one invocation into the next.
+func WithSessionEnv(ctx context.Context, env map[string]string) context.Context {
+	return context.WithValue(ctx, sessionEnvKey{}, env)
+}
+
+// LookupEnv returns the value of an environment variable of the azd invocation served by the context, falling back to
+// the process environment when the context doesn't carry the environment of an invocation.
+func LookupEnv(ctx context.Context, key string) (string, bool) {
+	if env, has := ctx.Value(sessionEnvKey{}).(map[string]string); has {
+		value, has := env[key]
+		return value, has
+	}
+
+	return os.LookupEnv(key)
+}
+
+// Getenv returns the value of an environment variable of the azd invocation served by the context, or an empty string
+// when it is not set. See [LookupEnv].
+func Getenv(ctx context.Context, key string) string {
+	value, _ := LookupEnv(ctx, key)
+	return value
+}
@@ -0,0 +1,302 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// daemonSessionRunner runs a single attached session until the azd invocation ends.
+type daemonSessionRunner func(ctx context.Context, request *DaemonAttachRequest) error
+
+// ExtensionDaemon keeps an extension listen process alive across azd invocations.
+//
+// Every azd invocation attaches to the daemon, which then runs a new ExtensionHost session connected to the gRPC server
+// of that invocation. The daemon exits after it has not been attached for the configured idle timeout.
+type ExtensionDaemon struct {
+	UnimplementedExtensionDaemonServiceServer
+
+	runSession    daemonSessionRunner
+	secret        string
+	idleTimeout   time.Duration
+	checkInterval time.Duration
+
+	mu           sync.Mutex
+	ctx          context.Context
+	attached     bool
+	lastActivity time.Time
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+}
+
+// NewExtensionDaemon creates a daemon that configures a new ExtensionHost for every attached azd invocation.
+func NewExtensionDaemon(configure func(host *ExtensionHost)) *ExtensionDaemon {
+	runSession := func(ctx context.Context, request *DaemonAttachRequest) error {
+		client, err := NewAzdClient(WithAddress(request.ServerAddress))
+		if err != nil {
+			return fmt.Errorf("failed to create azd client: %w", err)
+		}
+		defer client.Close()
+
+		host := NewExtensionHost(client)
+		if configure != nil {
+			configure(host)
+		}
+
+		return host.Run(WithAccessToken(withTraceContext(ctx), request.AccessToken))
+	}
+
+	return newExtensionDaemon(runSession, os.Getenv(extensions.DaemonSecretEnvVar), extensions.DaemonIdleTimeout())
+}
+
+func newExtensionDaemon(runSession daemonSessionRunner, secret string, idleTimeout time.Duration) *ExtensionDaemon {
+	return &ExtensionDaemon{
+		runSession:    runSession,
+		secret:        secret,
+		idleTimeout:   idleTimeout,
+		checkInterval: min(idleTimeout/2, 5*time.Second),
+		shutdown:      make(chan struct{}),
+	}
+}
+
+// Run starts the daemon gRPC endpoint, records the daemon state for azd and blocks until the daemon is idle for longer
+// than the idle timeout, is shut down by azd, or the context is cancelled.
+func (d *ExtensionDaemon) Run(ctx context.Context) error {
+	statePath := os.Getenv(extensions.DaemonStateFileEnvVar)
+	if statePath == "" || d.secret == "" {
+		return errors.New("daemon mode must be started by azd")
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	server := grpc.NewServer(grpc.UnaryInterceptor(d.authorize))
+	RegisterExtensionDaemonServiceServer(server, d)
+
+	d.mu.Lock()
+	d.ctx = ctx
+	d.lastActivity = time.Now()
+	d.mu.Unlock()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+	// Stop gracefully so the response of a Shutdown call reaches azd
+	defer server.GracefulStop()
+
+	state := &extensions.DaemonState{
+		Pid:     os.Getpid(),
+		Address: listener.Addr().String(),
+		Secret:  d.secret,
+	}
+	if err := state.Save(statePath); err != nil {
+		return err
+	}
+	defer removeDaemonState(statePath, state.Pid)
+
+	ticker := time.NewTicker(d.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-d.shutdown:
+			log.Println("Extension daemon shut down by azd")
+			return nil
+		case err := <-serveErr:
+			return err
+		case <-ticker.C:
+			if d.idle() {
+				log.Printf("Extension daemon idle for %v, exiting", d.idleTimeout)
+				return nil
+			}
+		}
+	}
+}
+
+// Ping reports the health of the daemon.
+func (d *ExtensionDaemon) Ping(ctx context.Context, request *DaemonPingRequest) (*DaemonPingResponse, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return &DaemonPingResponse{
+		Pid:      int32(os.Getpid()), //nolint:gosec // G115: pids fit in int32
+		Attached: d.attached,
+	}, nil
+}
+
+// Attach starts a new session connected to the gRPC server of the calling azd invocation.
+// The session signals readiness to azd in the same way as a regular listen process.
+func (d *ExtensionDaemon) Attach(ctx context.Context, request *DaemonAttachRequest) (*DaemonAttachResponse, error) {
+	if request.ServerAddress == "" {
+		return nil, status.Error(codes.InvalidArgument, "server address is required")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.attached {
+		return nil, status.Error(codes.FailedPrecondition, "daemon is already attached to another azd invocation")
+	}
+
+	// Sessions run one at a time, so the working directory of the process is the one of the attached invocation
+	if request.WorkingDirectory != "" {
+		if err := os.Chdir(request.WorkingDirectory); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to change working directory: %v", err)
+		}
+	}
+
+	sessionCtx := d.ctx
+	if sessionCtx == nil {
+		sessionCtx = context.Background()
+	}
+	sessionCtx = WithSessionEnv(sessionCtx, sessionEnv(request))
+
+	d.attached = true
+	d.lastActivity = time.Now()
+
+	go func() {
+		if err := d.runSession(sessionCtx, request); err != nil {
+			log.Printf("extension daemon session ended with error: %v", err)
+		}
+
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		d.attached = false
+		d.lastActivity = time.Now()
+	}()
+
+	return &DaemonAttachResponse{}, nil
+}
+
+// sessionEnv returns the environment of an attached azd invocation, as a regular listen process would receive it.
+// The environment is carried by the context of the session rather than set on the process, so that the values of an
+// invocation never leak into the sessions of later invocations.
+func sessionEnv(request *DaemonAttachRequest) map[string]string {
+	env := maps.Clone(request.Env)
+	if env == nil {
+		env = map[string]string{}
+	}
+
+	env["AZD_SERVER"] = request.ServerAddress
+	env["AZD_ACCESS_TOKEN"] = request.AccessToken
+	return env
+}
+
+// Shutdown stops the daemon.
+func (d *ExtensionDaemon) Shutdown(
+	ctx context.Context,
+	request *DaemonShutdownRequest,
+) (*DaemonShutdownResponse, error) {
+	d.shutdownOnce.Do(func() {
+		close(d.shutdown)
+	})
+
+	return &DaemonShutdownResponse{}, nil
+}
+
+// idle returns true when the daemon has not been attached for longer than the idle timeout.
+func (d *ExtensionDaemon) idle() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return !d.attached && time.Since(d.lastActivity) > d.idleTimeout
+}
+
+// authorize rejects calls that don't carry the daemon secret, which is only shared with azd through the
+// owner-only daemon state file.
+func (d *ExtensionDaemon) authorize(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) != 1 || subtle.ConstantTimeCompare([]byte(values[0]), []byte(d.secret)) != 1 {
+		return nil, status.Error(codes.Unauthenticated, "invalid daemon secret")
+	}
+
+	return handler(ctx, req)
+}
+
+// removeDaemonState removes the state file unless it was replaced by another daemon process.
+func removeDaemonState(path string, pid int) {
+	state, err := extensions.LoadDaemonState(path)
+	if err != nil || state.Pid != pid {
+		return
+	}
+
+	if err := os.Remove(path); err != nil {
+		log.Printf("failed to remove daemon state: %v", err)
+	}
+}
+
+// DaemonClient is used by azd to reconnect to a running extension daemon.
+type DaemonClient struct {
+	connection *grpc.ClientConn
+	client     ExtensionDaemonServiceClient
+	secret     string
+}
+
+// NewDaemonClient creates a client for the daemon described by the specified state.
+func NewDaemonClient(state *extensions.DaemonState) (*DaemonClient, error) {
+	connection, err := grpc.NewClient(state.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+
+	return &DaemonClient{
+		connection: connection,
+		client:     NewExtensionDaemonServiceClient(connection),
+		secret:     state.Secret,
+	}, nil
+}
+
+// Ping checks that the daemon is healthy.
+func (c *DaemonClient) Ping(ctx context.Context) (*DaemonPingResponse, error) {
+	return c.client.Ping(c.withSecret(ctx), &DaemonPingRequest{})
+}
+
+// Attach connects the daemon to the azd gRPC server of the current invocation.
+func (c *DaemonClient) Attach(ctx context.Context, request *DaemonAttachRequest) error {
+	_, err := c.client.Attach(c.withSecret(ctx), request)
+	return err
+}
+
+// Shutdown stops the daemon.
+func (c *DaemonClient) Shutdown(ctx context.Context) error {
+	_, err := c.client.Shutdown(c.withSecret(ctx), &DaemonShutdownRequest{})
+	return err
+}
+
+// Close closes the connection to the daemon.
+func (c *DaemonClient) Close() error {
+	return c.connection.Close()
+}
+
+func (c *DaemonClient) withSecret(ctx context.Context) context.Context {
+	return metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", c.secret))
+}
@@ -0,0 +1,380 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.32.1
+// source: daemon.proto
+
+package azdext
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type DaemonPingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DaemonPingRequest) Reset() {
+	*x = DaemonPingRequest{}
+	mi := &file_daemon_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DaemonPingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DaemonPingRequest) ProtoMessage() {}
+
+func (x *DaemonPingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DaemonPingRequest.ProtoReflect.Descriptor instead.
+func (*DaemonPingRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{0}
+}
+
+type DaemonPingResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Pid   int32                  `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	// Whether the daemon is currently attached to an azd invocation
+	Attached      bool `protobuf:"varint,2,opt,name=attached,proto3" json:"attached,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DaemonPingResponse) Reset() {
+	*x = DaemonPingResponse{}
+	mi := &file_daemon_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DaemonPingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DaemonPingResponse) ProtoMessage() {}
+
+func (x *DaemonPingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DaemonPingResponse.ProtoReflect.Descriptor instead.
+func (*DaemonPingResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *DaemonPingResponse) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *DaemonPingResponse) GetAttached() bool {
+	if x != nil {
+		return x.Attached
+	}
+	return false
+}
+
+type DaemonAttachRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Address of the azd gRPC server (AZD_SERVER)
+	ServerAddress string `protobuf:"bytes,1,opt,name=server_address,json=serverAddress,proto3" json:"server_address,omitempty"`
+	// Access token for the azd gRPC server (AZD_ACCESS_TOKEN)
+	AccessToken string `protobuf:"bytes,2,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	// Environment variables of the invocation (AZD_DEBUG, AZD_ENVIRONMENT, trace context, ...)
+	Env map[string]string `protobuf:"bytes,3,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Working directory of the invocation
+	WorkingDirectory string `protobuf:"bytes,4,opt,name=working_directory,json=workingDirectory,proto3" json:"working_directory,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DaemonAttachRequest) Reset() {
+	*x = DaemonAttachRequest{}
+	mi := &file_daemon_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DaemonAttachRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DaemonAttachRequest) ProtoMessage() {}
+
+func (x *DaemonAttachRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DaemonAttachRequest.ProtoReflect.Descriptor instead.
+func (*DaemonAttachRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *DaemonAttachRequest) GetServerAddress() string {
+	if x != nil {
+		return x.ServerAddress
+	}
+	return ""
+}
+
+func (x *DaemonAttachRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *DaemonAttachRequest) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *DaemonAttachRequest) GetWorkingDirectory() string {
+	if x != nil {
+		return x.WorkingDirectory
+	}
+	return ""
+}
+
+type DaemonAttachResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DaemonAttachResponse) Reset() {
+	*x = DaemonAttachResponse{}
+	mi := &file_daemon_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DaemonAttachResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DaemonAttachResponse) ProtoMessage() {}
+
+func (x *DaemonAttachResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DaemonAttachResponse.ProtoReflect.Descriptor instead.
+func (*DaemonAttachResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{3}
+}
+
+type DaemonShutdownRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DaemonShutdownRequest) Reset() {
+	*x = DaemonShutdownRequest{}
+	mi := &file_daemon_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DaemonShutdownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DaemonShutdownRequest) ProtoMessage() {}
+
+func (x *DaemonShutdownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DaemonShutdownRequest.ProtoReflect.Descriptor instead.
+func (*DaemonShutdownRequest) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{4}
+}
+
+type DaemonShutdownResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DaemonShutdownResponse) Reset() {
+	*x = DaemonShutdownResponse{}
+	mi := &file_daemon_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DaemonShutdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DaemonShutdownResponse) ProtoMessage() {}
+
+func (x *DaemonShutdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_daemon_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DaemonShutdownResponse.ProtoReflect.Descriptor instead.
+func (*DaemonShutdownResponse) Descriptor() ([]byte, []int) {
+	return file_daemon_proto_rawDescGZIP(), []int{5}
+}
+
+var File_daemon_proto protoreflect.FileDescriptor
+
+const file_daemon_proto_rawDesc = "" +
+	"\n" +
+	"\fdaemon.proto\x12\x06azdext\"\x13\n" +
+	"\x11DaemonPingRequest\"B\n" +
+	"\x12DaemonPingResponse\x12\x10\n" +
+	"\x03pid\x18\x01 \x01(\x05R\x03pid\x12\x1a\n" +
+	"\battached\x18\x02 \x01(\bR\battached\"\xfc\x01\n" +
+	"\x13DaemonAttachRequest\x12%\n" +
+	"\x0eserver_address\x18\x01 \x01(\tR\rserverAddress\x12!\n" +
+	"\faccess_token\x18\x02 \x01(\tR\vaccessToken\x126\n" +
+	"\x03env\x18\x03 \x03(\v2$.azdext.DaemonAttachRequest.EnvEntryR\x03env\x12+\n" +
+	"\x11working_directory\x18\x04 \x01(\tR\x10workingDirectory\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x16\n" +
+	"\x14DaemonAttachResponse\"\x17\n" +
+	"\x15DaemonShutdownRequest\"\x18\n" +
+	"\x16DaemonShutdownResponse2\xe7\x01\n" +
+	"\x16ExtensionDaemonService\x12=\n" +
+	"\x04Ping\x12\x19.azdext.DaemonPingRequest\x1a\x1a.azdext.DaemonPingResponse\x12C\n" +
+	"\x06Attach\x12\x1b.azdext.DaemonAttachRequest\x1a\x1c.azdext.DaemonAttachResponse\x12I\n" +
+	"\bShutdown\x12\x1d.azdext.DaemonShutdownRequest\x1a\x1e.azdext.DaemonShutdownResponseB/Z-github.com/azure/azure-dev/cli/azd/pkg/azdextb\x06proto3"
+
+var (
+	file_daemon_proto_rawDescOnce sync.Once
+	file_daemon_proto_rawDescData []byte
+)
+
+func file_daemon_proto_rawDescGZIP() []byte {
+	file_daemon_proto_rawDescOnce.Do(func() {
+		file_daemon_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_daemon_proto_rawDesc), len(file_daemon_proto_rawDesc)))
+	})
+	return file_daemon_proto_rawDescData
+}
+
+var file_daemon_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_daemon_proto_goTypes = []any{
+	(*DaemonPingRequest)(nil),      // 0: azdext.DaemonPingRequest
+	(*DaemonPingResponse)(nil),     // 1: azdext.DaemonPingResponse
+	(*DaemonAttachRequest)(nil),    // 2: azdext.DaemonAttachRequest
+	(*DaemonAttachResponse)(nil),   // 3: azdext.DaemonAttachResponse
+	(*DaemonShutdownRequest)(nil),  // 4: azdext.DaemonShutdownRequest
+	(*DaemonShutdownResponse)(nil), // 5: azdext.DaemonShutdownResponse
+	nil,                            // 6: azdext.DaemonAttachRequest.EnvEntry
+}
+var file_daemon_proto_depIdxs = []int32{
+	6, // 0: azdext.DaemonAttachRequest.env:type_name -> azdext.DaemonAttachRequest.EnvEntry
+	0, // 1: azdext.ExtensionDaemonService.Ping:input_type -> azdext.DaemonPingRequest
+	2, // 2: azdext.ExtensionDaemonService.Attach:input_type -> azdext.DaemonAttachRequest
+	4, // 3: azdext.ExtensionDaemonService.Shutdown:input_type -> azdext.DaemonShutdownRequest
+	1, // 4: azdext.ExtensionDaemonService.Ping:output_type -> azdext.DaemonPingResponse
+	3, // 5: azdext.ExtensionDaemonService.Attach:output_type -> azdext.DaemonAttachResponse
+	5, // 6: azdext.ExtensionDaemonService.Shutdown:output_type -> azdext.DaemonShutdownResponse
+	4, // [4:7] is the sub-list for method output_type
+	1, // [1:4] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_daemon_proto_init() }
+func file_daemon_proto_init() {
+	if File_daemon_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_daemon_proto_rawDesc), len(file_daemon_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_daemon_proto_goTypes,
+		DependencyIndexes: file_daemon_proto_depIdxs,
+		MessageInfos:      file_daemon_proto_msgTypes,
+	}.Build()
+	File_daemon_proto = out.File
+	file_daemon_proto_goTypes = nil
+	file_daemon_proto_depIdxs = nil
+}
@@ -0,0 +1,213 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.32.1
+// source: daemon.proto
+
+package azdext
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ExtensionDaemonService_Ping_FullMethodName     = "/azdext.ExtensionDaemonService/Ping"
+	ExtensionDaemonService_Attach_FullMethodName   = "/azdext.ExtensionDaemonService/Attach"
+	ExtensionDaemonService_Shutdown_FullMethodName = "/azdext.ExtensionDaemonService/Shutdown"
+)
+
+// ExtensionDaemonServiceClient is the client API for ExtensionDaemonService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Served by extensions running in daemon mode so azd can reuse the extension process across invocations
+type ExtensionDaemonServiceClient interface {
+	// Check the health of the daemon
+	Ping(ctx context.Context, in *DaemonPingRequest, opts ...grpc.CallOption) (*DaemonPingResponse, error)
+	// Connect the daemon to the azd gRPC server of the current azd invocation.
+	// Fails with FAILED_PRECONDITION when the daemon is already attached to another invocation.
+	Attach(ctx context.Context, in *DaemonAttachRequest, opts ...grpc.CallOption) (*DaemonAttachResponse, error)
+	// Stop the daemon
+	Shutdown(ctx context.Context, in *DaemonShutdownRequest, opts ...grpc.CallOption) (*DaemonShutdownResponse, error)
+}
+
+type extensionDaemonServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExtensionDaemonServiceClient(cc grpc.ClientConnInterface) ExtensionDaemonServiceClient {
+	return &extensionDaemonServiceClient{cc}
+}
+
+func (c *extensionDaemonServiceClient) Ping(ctx context.Context, in *DaemonPingRequest, opts ...grpc.CallOption) (*DaemonPingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DaemonPingResponse)
+	err := c.cc.Invoke(ctx, ExtensionDaemonService_Ping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extensionDaemonServiceClient) Attach(ctx context.Context, in *DaemonAttachRequest, opts ...grpc.CallOption) (*DaemonAttachResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DaemonAttachResponse)
+	err := c.cc.Invoke(ctx, ExtensionDaemonService_Attach_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *extensionDaemonServiceClient) Shutdown(ctx context.Context, in *DaemonShutdownRequest, opts ...grpc.CallOption) (*DaemonShutdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DaemonShutdownResponse)
+	err := c.cc.Invoke(ctx, ExtensionDaemonService_Shutdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ExtensionDaemonServiceServer is the server API for ExtensionDaemonService service.
+// All implementations must embed UnimplementedExtensionDaemonServiceServer
+// for forward compatibility.
+//
+// Served by extensions running in daemon mode so azd can reuse the extension process across invocations
+type ExtensionDaemonServiceServer interface {
+	// Check the health of the daemon
+	Ping(context.Context, *DaemonPingRequest) (*DaemonPingResponse, error)
+	// Connect the daemon to the azd gRPC server of the current azd invocation.
+	// Fails with FAILED_PRECONDITION when the daemon is already attached to another invocation.
+	Attach(context.Context, *DaemonAttachRequest) (*DaemonAttachResponse, error)
+	// Stop the daemon
+	Shutdown(context.Context, *DaemonShutdownRequest) (*DaemonShutdownResponse, error)
+	mustEmbedUnimplementedExtensionDaemonServiceServer()
+}
+
+// UnimplementedExtensionDaemonServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedExtensionDaemonServiceServer struct{}
+
+func (UnimplementedExtensionDaemonServiceServer) Ping(context.Context, *DaemonPingRequest) (*DaemonPingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (UnimplementedExtensionDaemonServiceServer) Attach(context.Context, *DaemonAttachRequest) (*DaemonAttachResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Attach not implemented")
+}
+func (UnimplementedExtensionDaemonServiceServer) Shutdown(context.Context, *DaemonShutdownRequest) (*DaemonShutdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedExtensionDaemonServiceServer) mustEmbedUnimplementedExtensionDaemonServiceServer() {
+}
+func (UnimplementedExtensionDaemonServiceServer) testEmbeddedByValue() {}
+
+// UnsafeExtensionDaemonServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExtensionDaemonServiceServer will
+// result in compilation errors.
+type UnsafeExtensionDaemonServiceServer interface {
+	mustEmbedUnimplementedExtensionDaemonServiceServer()
+}
+
+func RegisterExtensionDaemonServiceServer(s grpc.ServiceRegistrar, srv ExtensionDaemonServiceServer) {
+	// If the following call pancis, it indicates UnimplementedExtensionDaemonServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ExtensionDaemonService_ServiceDesc, srv)
+}
+
+func _ExtensionDaemonService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DaemonPingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtensionDaemonServiceServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtensionDaemonService_Ping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtensionDaemonServiceServer).Ping(ctx, req.(*DaemonPingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtensionDaemonService_Attach_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DaemonAttachRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtensionDaemonServiceServer).Attach(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtensionDaemonService_Attach_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtensionDaemonServiceServer).Attach(ctx, req.(*DaemonAttachRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExtensionDaemonService_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DaemonShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExtensionDaemonServiceServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ExtensionDaemonService_Shutdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExtensionDaemonServiceServer).Shutdown(ctx, req.(*DaemonShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ExtensionDaemonService_ServiceDesc is the grpc.ServiceDesc for ExtensionDaemonService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ExtensionDaemonService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "azdext.ExtensionDaemonService",
+	HandlerType: (*ExtensionDaemonServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Ping",
+			Handler:    _ExtensionDaemonService_Ping_Handler,
+		},
+		{
+			MethodName: "Attach",
+			Handler:    _ExtensionDaemonService_Attach_Handler,
+		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _ExtensionDaemonService_Shutdown_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "daemon.proto",
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azdext
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// startTestDaemon runs a daemon in the background and waits until its state has been recorded.
+func startTestDaemon(
+	t *testing.T,
+	runSession daemonSessionRunner,
+	idleTimeout time.Duration,
+) (*extensions.DaemonState, string, <-chan error) {
+	statePath := filepath.Join(t.TempDir(), "test.extension.json")
+	t.Setenv(extensions.DaemonStateFileEnvVar, statePath)
+
+	// Attach changes the working directory of the process
+	cwd, err := os.Getwd()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = os.Chdir(cwd)
+	})
+
+	ctx, cancel := context.WithCancel(t.Context())
+	t.Cleanup(cancel)
+
+	daemon := newExtensionDaemon(runSession, "secret", idleTimeout)
+	done := make(chan error, 1)
+	go func() {
+		done <- daemon.Run(ctx)
+	}()
+
+	var state *extensions.DaemonState
+	require.Eventually(t, func() bool {
+		state, err = extensions.LoadDaemonState(statePath)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return state, statePath, done
+}
+
+func Test_ExtensionDaemon_AttachSessions(t *testing.T) {
+	t.Setenv("AZD_ENVIRONMENT", "")
+
+	type session struct {
+		ctx     context.Context
+		request *DaemonAttachRequest
+	}
+
+	sessions := make(chan session, 2)
+	endSession := make(chan struct{})
+	runSession := func(ctx context.Context, request *DaemonAttachRequest) error {
+		sessions <- session{ctx: ctx, request: request}
+		<-endSession
+		return nil
+	}
+
+	state, statePath, done := startTestDaemon(t, runSession, time.Minute)
+	require.Equal(t, os.Getpid(), state.Pid)
+
+	client, err := NewDaemonClient(state)
+	require.NoError(t, err)
+	defer client.Close()
+
+	response, err := client.Ping(t.Context())
+	require.NoError(t, err)
+	require.False(t, response.Attached)
+
+	workingDirectory := t.TempDir()
+	err = client.Attach(t.Context(), &DaemonAttachRequest{
+		ServerAddress:    "127.0.0.1:1234",
+		AccessToken:      "token",
+		Env:              map[string]string{"AZD_ENVIRONMENT": "dev"},
+		WorkingDirectory: workingDirectory,
+	})
+	require.NoError(t, err)
+
+	attached := <-sessions
+	require.Equal(t, "127.0.0.1:1234", attached.request.ServerAddress)
+	require.Equal(t, "127.0.0.1:1234", Getenv(attached.ctx, "AZD_SERVER"))
+	require.Equal(t, "token", Getenv(attached.ctx, "AZD_ACCESS_TOKEN"))
+	require.Equal(t, "dev", Getenv(attached.ctx, "AZD_ENVIRONMENT"))
+
+	// The environment of the invocation is carried by the session, and never set on the process
+	require.Empty(t, os.Getenv("AZD_ENVIRONMENT"))
+
+	response, err = client.Ping(t.Context())
+	require.NoError(t, err)
+	require.True(t, response.Attached)
+
+	// Only a single invocation can be attached at a time
+	err = client.Attach(t.Context(), &DaemonAttachRequest{ServerAddress: "127.0.0.1:1235"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	close(endSession)
+	require.Eventually(t, func() bool {
+		response, err := client.Ping(t.Context())
+		return err == nil && !response.Attached
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Values of an invocation don't leak into the sessions of later invocations
+	err = client.Attach(t.Context(), &DaemonAttachRequest{ServerAddress: "127.0.0.1:1236"})
+	require.NoError(t, err)
+
+	attached = <-sessions
+	require.Equal(t, "127.0.0.1:1236", Getenv(attached.ctx, "AZD_SERVER"))
+	_, has := LookupEnv(attached.ctx, "AZD_ENVIRONMENT")
+	require.False(t, has)
+
+	require.Eventually(t, func() bool {
+		response, err := client.Ping(t.Context())
+		return err == nil && !response.Attached
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, client.Shutdown(t.Context()))
+	require.NoError(t, <-done)
+
+	_, err = extensions.LoadDaemonState(statePath)
+	require.ErrorIs(t, err, extensions.ErrDaemonNotRunning)
+}
+
+func Test_ExtensionDaemon_RequiresSecret(t *testing.T) {
+	state, _, _ := startTestDaemon(t, nil, time.Minute)
+
+	client, err := NewDaemonClient(&extensions.DaemonState{Address: state.Address, Secret: "wrong"})
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.Ping(t.Context())
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func Test_ExtensionDaemon_IdleTimeout(t *testing.T) {
+	_, statePath, done := startTestDaemon(t, nil, 50*time.Millisecond)
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "daemon did not exit after the idle timeout")
+	}
+
+	_, err := extensions.LoadDaemonState(statePath)
+	require.ErrorIs(t, err, extensions.ErrDaemonNotRunning)
+}
+
+func Test_ExtensionDaemon_RequiresAzd(t *testing.T) {
+	t.Setenv(extensions.DaemonStateFileEnvVar, "")
+
+	daemon := newExtensionDaemon(nil, "secret", time.Minute)
+	require.Error(t, daemon.Run(t.Context()))
+}
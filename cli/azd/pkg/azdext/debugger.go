@@ -28,7 +28,7 @@ var ErrDebuggerAborted = errors.New("debugger attach aborted")
 // Returns [ErrDebuggerAborted] if the user declines to attach a debugger.
 // Returns [context.Canceled] if the user cancels the prompt (e.g., via Ctrl+C).
 func WaitForDebugger(ctx context.Context, azdClient *AzdClient) error {
-	debugValue := os.Getenv("AZD_EXT_DEBUG")
+	debugValue := Getenv(ctx, "AZD_EXT_DEBUG")
 	if debugValue == "" {
 		return nil
 	}
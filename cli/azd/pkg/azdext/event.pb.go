@@ -175,9 +175,12 @@ func (*EventMessage_ServiceHandlerStatus) isEventMessage_MessageType() {}
 type SubscribeProjectEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// List of event names to subscribe to.
-	EventNames    []string `protobuf:"bytes,1,rep,name=event_names,json=eventNames,proto3" json:"event_names,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	EventNames []string `protobuf:"bytes,1,rep,name=event_names,json=eventNames,proto3" json:"event_names,omitempty"`
+	// Maximum time in seconds azd waits for the handler to complete.
+	// When zero, azd uses its default handler timeout.
+	TimeoutSeconds int32 `protobuf:"varint,2,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SubscribeProjectEvent) Reset() {
@@ -217,15 +220,25 @@ func (x *SubscribeProjectEvent) GetEventNames() []string {
 	return nil
 }
 
+func (x *SubscribeProjectEvent) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
 // Client subscribes to service-related events
 type SubscribeServiceEvent struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// List of event names to subscribe to.
-	EventNames    []string `protobuf:"bytes,1,rep,name=event_names,json=eventNames,proto3" json:"event_names,omitempty"`
-	Language      string   `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
-	Host          string   `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	EventNames []string `protobuf:"bytes,1,rep,name=event_names,json=eventNames,proto3" json:"event_names,omitempty"`
+	Language   string   `protobuf:"bytes,2,opt,name=language,proto3" json:"language,omitempty"`
+	Host       string   `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	// Maximum time in seconds azd waits for the handler to complete.
+	// When zero, azd uses its default handler timeout.
+	TimeoutSeconds int32 `protobuf:"varint,4,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *SubscribeServiceEvent) Reset() {
@@ -279,6 +292,13 @@ func (x *SubscribeServiceEvent) GetHost() string {
 	return ""
 }
 
+func (x *SubscribeServiceEvent) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
 // Server invokes the project event handler
 type InvokeProjectHandler struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -415,7 +435,9 @@ type ProjectHandlerStatus struct {
 	// Status such as "running", "completed", "failed", etc.
 	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
 	// Optional message providing further details.
-	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// Environment values to set in the current azd environment before the operation continues.
+	Env           map[string]string `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -471,6 +493,13 @@ func (x *ProjectHandlerStatus) GetMessage() string {
 	return ""
 }
 
+func (x *ProjectHandlerStatus) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
 // Client sends status updates for service events
 type ServiceHandlerStatus struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -481,9 +510,13 @@ type ServiceHandlerStatus struct {
 	// Status such as "running", "completed", "failed", etc.
 	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
 	// Optional message providing further details.
-	Message       string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	// Environment values to set in the current azd environment before the operation continues.
+	Env map[string]string `protobuf:"bytes,5,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Optional service context that replaces the artifacts azd uses for the remainder of the operation.
+	ServiceContext *ServiceContext `protobuf:"bytes,6,opt,name=service_context,json=serviceContext,proto3" json:"service_context,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *ServiceHandlerStatus) Reset() {
@@ -544,6 +577,20 @@ func (x *ServiceHandlerStatus) GetMessage() string {
 	return ""
 }
 
+func (x *ServiceHandlerStatus) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *ServiceHandlerStatus) GetServiceContext() *ServiceContext {
+	if x != nil {
+		return x.ServiceContext
+	}
+	return nil
+}
+
 var File_event_proto protoreflect.FileDescriptor
 
 const file_event_proto_rawDesc = "" +
@@ -556,15 +603,17 @@ const file_event_proto_rawDesc = "" +
 	"\x17subscribe_service_event\x18\x04 \x01(\v2\x1d.azdext.SubscribeServiceEventH\x00R\x15subscribeServiceEvent\x12T\n" +
 	"\x16invoke_service_handler\x18\x05 \x01(\v2\x1c.azdext.InvokeServiceHandlerH\x00R\x14invokeServiceHandler\x12T\n" +
 	"\x16service_handler_status\x18\x06 \x01(\v2\x1c.azdext.ServiceHandlerStatusH\x00R\x14serviceHandlerStatusB\x0e\n" +
-	"\fmessage_type\"8\n" +
+	"\fmessage_type\"a\n" +
 	"\x15SubscribeProjectEvent\x12\x1f\n" +
 	"\vevent_names\x18\x01 \x03(\tR\n" +
-	"eventNames\"h\n" +
+	"eventNames\x12'\n" +
+	"\x0ftimeout_seconds\x18\x02 \x01(\x05R\x0etimeoutSeconds\"\x91\x01\n" +
 	"\x15SubscribeServiceEvent\x12\x1f\n" +
 	"\vevent_names\x18\x01 \x03(\tR\n" +
 	"eventNames\x12\x1a\n" +
 	"\blanguage\x18\x02 \x01(\tR\blanguage\x12\x12\n" +
-	"\x04host\x18\x03 \x01(\tR\x04host\"f\n" +
+	"\x04host\x18\x03 \x01(\tR\x04host\x12'\n" +
+	"\x0ftimeout_seconds\x18\x04 \x01(\x05R\x0etimeoutSeconds\"f\n" +
 	"\x14InvokeProjectHandler\x12\x1d\n" +
 	"\n" +
 	"event_name\x18\x01 \x01(\tR\teventName\x12/\n" +
@@ -574,18 +623,27 @@ const file_event_proto_rawDesc = "" +
 	"event_name\x18\x01 \x01(\tR\teventName\x12/\n" +
 	"\aproject\x18\x02 \x01(\v2\x15.azdext.ProjectConfigR\aproject\x12/\n" +
 	"\aservice\x18\x03 \x01(\v2\x15.azdext.ServiceConfigR\aservice\x12?\n" +
-	"\x0fservice_context\x18\x04 \x01(\v2\x16.azdext.ServiceContextR\x0eserviceContext\"g\n" +
+	"\x0fservice_context\x18\x04 \x01(\v2\x16.azdext.ServiceContextR\x0eserviceContext\"\xd8\x01\n" +
 	"\x14ProjectHandlerStatus\x12\x1d\n" +
 	"\n" +
 	"event_name\x18\x01 \x01(\tR\teventName\x12\x16\n" +
 	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\x8a\x01\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x127\n" +
+	"\x03env\x18\x04 \x03(\v2%.azdext.ProjectHandlerStatus.EnvEntryR\x03env\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbc\x02\n" +
 	"\x14ServiceHandlerStatus\x12\x1d\n" +
 	"\n" +
 	"event_name\x18\x01 \x01(\tR\teventName\x12!\n" +
 	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x16\n" +
 	"\x06status\x18\x03 \x01(\tR\x06status\x12\x18\n" +
-	"\amessage\x18\x04 \x01(\tR\amessage2M\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x127\n" +
+	"\x03env\x18\x05 \x03(\v2%.azdext.ServiceHandlerStatus.EnvEntryR\x03env\x12?\n" +
+	"\x0fservice_context\x18\x06 \x01(\v2\x16.azdext.ServiceContextR\x0eserviceContext\x1a6\n" +
+	"\bEnvEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012M\n" +
 	"\fEventService\x12=\n" +
 	"\vEventStream\x12\x14.azdext.EventMessage\x1a\x14.azdext.EventMessage(\x010\x01B/Z-github.com/azure/azure-dev/cli/azd/pkg/azdextb\x06proto3"
 
@@ -601,7 +659,7 @@ func file_event_proto_rawDescGZIP() []byte {
 	return file_event_proto_rawDescData
 }
 
-var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_event_proto_goTypes = []any{
 	(*EventMessage)(nil),          // 0: azdext.EventMessage
 	(*SubscribeProjectEvent)(nil), // 1: azdext.SubscribeProjectEvent
@@ -610,9 +668,11 @@ var file_event_proto_goTypes = []any{
 	(*InvokeServiceHandler)(nil),  // 4: azdext.InvokeServiceHandler
 	(*ProjectHandlerStatus)(nil),  // 5: azdext.ProjectHandlerStatus
 	(*ServiceHandlerStatus)(nil),  // 6: azdext.ServiceHandlerStatus
-	(*ProjectConfig)(nil),         // 7: azdext.ProjectConfig
-	(*ServiceConfig)(nil),         // 8: azdext.ServiceConfig
-	(*ServiceContext)(nil),        // 9: azdext.ServiceContext
+	nil,                           // 7: azdext.ProjectHandlerStatus.EnvEntry
+	nil,                           // 8: azdext.ServiceHandlerStatus.EnvEntry
+	(*ProjectConfig)(nil),         // 9: azdext.ProjectConfig
+	(*ServiceConfig)(nil),         // 10: azdext.ServiceConfig
+	(*ServiceContext)(nil),        // 11: azdext.ServiceContext
 }
 var file_event_proto_depIdxs = []int32{
 	1,  // 0: azdext.EventMessage.subscribe_project_event:type_name -> azdext.SubscribeProjectEvent
@@ -621,17 +681,20 @@ var file_event_proto_depIdxs = []int32{
 	2,  // 3: azdext.EventMessage.subscribe_service_event:type_name -> azdext.SubscribeServiceEvent
 	4,  // 4: azdext.EventMessage.invoke_service_handler:type_name -> azdext.InvokeServiceHandler
 	6,  // 5: azdext.EventMessage.service_handler_status:type_name -> azdext.ServiceHandlerStatus
-	7,  // 6: azdext.InvokeProjectHandler.project:type_name -> azdext.ProjectConfig
-	7,  // 7: azdext.InvokeServiceHandler.project:type_name -> azdext.ProjectConfig
-	8,  // 8: azdext.InvokeServiceHandler.service:type_name -> azdext.ServiceConfig
-	9,  // 9: azdext.InvokeServiceHandler.service_context:type_name -> azdext.ServiceContext
-	0,  // 10: azdext.EventService.EventStream:input_type -> azdext.EventMessage
-	0,  // 11: azdext.EventService.EventStream:output_type -> azdext.EventMessage
-	11, // [11:12] is the sub-list for method output_type
-	10, // [10:11] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	9,  // 6: azdext.InvokeProjectHandler.project:type_name -> azdext.ProjectConfig
+	9,  // 7: azdext.InvokeServiceHandler.project:type_name -> azdext.ProjectConfig
+	10, // 8: azdext.InvokeServiceHandler.service:type_name -> azdext.ServiceConfig
+	11, // 9: azdext.InvokeServiceHandler.service_context:type_name -> azdext.ServiceContext
+	7,  // 10: azdext.ProjectHandlerStatus.env:type_name -> azdext.ProjectHandlerStatus.EnvEntry
+	8,  // 11: azdext.ServiceHandlerStatus.env:type_name -> azdext.ServiceHandlerStatus.EnvEntry
+	11, // 12: azdext.ServiceHandlerStatus.service_context:type_name -> azdext.ServiceContext
+	0,  // 13: azdext.EventService.EventStream:input_type -> azdext.EventMessage
+	0,  // 14: azdext.EventService.EventStream:output_type -> azdext.EventMessage
+	14, // [14:15] is the sub-list for method output_type
+	13, // [13:14] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_event_proto_init() }
@@ -654,7 +717,7 @@ func file_event_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
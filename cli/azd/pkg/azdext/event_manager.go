@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	"sync"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/grpcbroker"
 )
@@ -18,8 +19,11 @@ type EventManager struct {
 	broker        *grpcbroker.MessageBroker[EventMessage]
 	projectEvents map[string]ProjectEventHandler
 	serviceEvents map[string]ServiceEventHandler
-	eventsMutex   sync.RWMutex // Protects both projectEvents and serviceEvents maps
-	brokerLogger  *log.Logger
+	// Handlers that return structured results take precedence over plain handlers for the same event.
+	projectResultEvents map[string]ProjectEventResultHandler
+	serviceResultEvents map[string]ServiceEventResultHandler
+	eventsMutex         sync.RWMutex // Protects all event handler maps
+	brokerLogger        *log.Logger
 
 	// Synchronization for concurrent access
 	mu sync.RWMutex
@@ -39,13 +43,45 @@ type ProjectEventHandler func(ctx context.Context, args *ProjectEventArgs) error
 
 type ServiceEventHandler func(ctx context.Context, args *ServiceEventArgs) error
 
+// ProjectEventResult is the structured result of a project event handler.
+// Returning an error from the handler aborts the azd operation that raised the event.
+type ProjectEventResult struct {
+	// Env contains values azd sets in the current environment before the operation continues.
+	Env map[string]string
+}
+
+// ServiceEventResult is the structured result of a service event handler.
+// Returning an error from the handler aborts the azd operation that raised the event.
+type ServiceEventResult struct {
+	// Env contains values azd sets in the current environment before the operation continues.
+	Env map[string]string
+	// ServiceContext, when set, replaces the artifacts azd uses for the remainder of the operation.
+	// Handlers typically modify args.ServiceContext and return it here.
+	ServiceContext *ServiceContext
+}
+
+// ProjectEventResultHandler handles a project event and returns a structured result to azd.
+type ProjectEventResultHandler func(ctx context.Context, args *ProjectEventArgs) (*ProjectEventResult, error)
+
+// ServiceEventResultHandler handles a service event and returns a structured result to azd.
+type ServiceEventResultHandler func(ctx context.Context, args *ServiceEventArgs) (*ServiceEventResult, error)
+
+// ProjectEventOptions configures a project event subscription.
+type ProjectEventOptions struct {
+	// Timeout is the maximum time azd waits for the handler. Zero uses the azd default.
+	Timeout time.Duration
+}
+
 func NewEventManager(extensionId string, azdClient *AzdClient, brokerLogger *log.Logger) *EventManager {
 	return &EventManager{
 		extensionId:   extensionId,
 		client:        azdClient,
 		projectEvents: make(map[string]ProjectEventHandler),
 		serviceEvents: make(map[string]ServiceEventHandler),
-		brokerLogger:  brokerLogger,
+
+		projectResultEvents: make(map[string]ProjectEventResultHandler),
+		serviceResultEvents: make(map[string]ServiceEventResultHandler),
+		brokerLogger:        brokerLogger,
 	}
 }
 
@@ -130,39 +166,107 @@ func (em *EventManager) Ready(ctx context.Context) error {
 }
 
 func (em *EventManager) AddProjectEventHandler(ctx context.Context, eventName string, handler ProjectEventHandler) error {
+	if err := em.subscribeProjectEvent(ctx, eventName, nil); err != nil {
+		return err
+	}
+
+	em.eventsMutex.Lock()
+	defer em.eventsMutex.Unlock()
+	em.projectEvents[eventName] = handler
+
+	return nil
+}
+
+// AddProjectEventResultHandler registers a project event handler that can return environment values
+// to azd or abort the operation by returning an error.
+func (em *EventManager) AddProjectEventResultHandler(
+	ctx context.Context,
+	eventName string,
+	handler ProjectEventResultHandler,
+	options *ProjectEventOptions,
+) error {
+	if err := em.subscribeProjectEvent(ctx, eventName, options); err != nil {
+		return err
+	}
+
+	em.eventsMutex.Lock()
+	defer em.eventsMutex.Unlock()
+	em.projectResultEvents[eventName] = handler
+
+	return nil
+}
+
+func (em *EventManager) subscribeProjectEvent(
+	ctx context.Context,
+	eventName string,
+	options *ProjectEventOptions,
+) error {
 	if err := em.ensureStream(ctx); err != nil {
 		return err
 	}
 
+	if options == nil {
+		options = &ProjectEventOptions{}
+	}
+
 	msg := &EventMessage{
 		MessageType: &EventMessage_SubscribeProjectEvent{
 			SubscribeProjectEvent: &SubscribeProjectEvent{
-				EventNames: []string{eventName},
+				EventNames:     []string{eventName},
+				TimeoutSeconds: timeoutSeconds(options.Timeout),
 			},
 		},
 	}
 
-	err := em.broker.Send(ctx, msg)
-	if err != nil {
+	return em.broker.Send(ctx, msg)
+}
+
+type ServiceEventOptions struct {
+	Host     string
+	Language string
+	// Timeout is the maximum time azd waits for the handler. Zero uses the azd default.
+	Timeout time.Duration
+}
+
+func (em *EventManager) AddServiceEventHandler(
+	ctx context.Context,
+	eventName string,
+	handler ServiceEventHandler,
+	options *ServiceEventOptions,
+) error {
+	if err := em.subscribeServiceEvent(ctx, eventName, options); err != nil {
 		return err
 	}
 
 	em.eventsMutex.Lock()
 	defer em.eventsMutex.Unlock()
-	em.projectEvents[eventName] = handler
+	em.serviceEvents[eventName] = handler
 
 	return nil
 }
 
-type ServiceEventOptions struct {
-	Host     string
-	Language string
+// AddServiceEventResultHandler registers a service event handler that can return environment values,
+// replace the service context artifacts or abort the operation by returning an error.
+func (em *EventManager) AddServiceEventResultHandler(
+	ctx context.Context,
+	eventName string,
+	handler ServiceEventResultHandler,
+	options *ServiceEventOptions,
+) error {
+	if err := em.subscribeServiceEvent(ctx, eventName, options); err != nil {
+		return err
+	}
+
+	em.eventsMutex.Lock()
+	defer em.eventsMutex.Unlock()
+	em.serviceResultEvents[eventName] = handler
+
+	return nil
 }
 
-func (em *EventManager) AddServiceEventHandler(
+func (em *EventManager) subscribeServiceEvent(
 	ctx context.Context,
 	eventName string,
-	handler ServiceEventHandler,
 	options *ServiceEventOptions,
 ) error {
 	if err := em.ensureStream(ctx); err != nil {
@@ -176,35 +280,72 @@ func (em *EventManager) AddServiceEventHandler(
 	msg := &EventMessage{
 		MessageType: &EventMessage_SubscribeServiceEvent{
 			SubscribeServiceEvent: &SubscribeServiceEvent{
-				EventNames: []string{eventName},
-				Host:       options.Host,
-				Language:   options.Language,
+				EventNames:     []string{eventName},
+				Host:           options.Host,
+				Language:       options.Language,
+				TimeoutSeconds: timeoutSeconds(options.Timeout),
 			},
 		},
 	}
 
-	err := em.broker.Send(ctx, msg)
-	if err != nil {
-		return err
-	}
+	return em.broker.Send(ctx, msg)
+}
 
-	em.eventsMutex.Lock()
-	defer em.eventsMutex.Unlock()
-	em.serviceEvents[eventName] = handler
+// timeoutSeconds converts a handler timeout to whole seconds, rounding up partial seconds.
+func timeoutSeconds(timeout time.Duration) int32 {
+	if timeout <= 0 {
+		return 0
+	}
 
-	return nil
+	return int32((timeout + time.Second - 1) / time.Second)
 }
 
 func (em *EventManager) RemoveProjectEventHandler(eventName string) {
 	em.eventsMutex.Lock()
 	defer em.eventsMutex.Unlock()
 	delete(em.projectEvents, eventName)
+	delete(em.projectResultEvents, eventName)
 }
 
 func (em *EventManager) RemoveServiceEventHandler(eventName string) {
 	em.eventsMutex.Lock()
 	defer em.eventsMutex.Unlock()
 	delete(em.serviceEvents, eventName)
+	delete(em.serviceResultEvents, eventName)
+}
+
+// projectHandler returns the handler registered for the project event, adapting plain handlers
+// to the result handler signature.
+func (em *EventManager) projectHandler(eventName string) (ProjectEventResultHandler, bool) {
+	if handler, has := em.projectResultEvents[eventName]; has {
+		return handler, true
+	}
+
+	handler, has := em.projectEvents[eventName]
+	if !has {
+		return nil, false
+	}
+
+	return func(ctx context.Context, args *ProjectEventArgs) (*ProjectEventResult, error) {
+		return nil, handler(ctx, args)
+	}, true
+}
+
+// serviceHandler returns the handler registered for the service event, adapting plain handlers
+// to the result handler signature.
+func (em *EventManager) serviceHandler(eventName string) (ServiceEventResultHandler, bool) {
+	if handler, has := em.serviceResultEvents[eventName]; has {
+		return handler, true
+	}
+
+	handler, has := em.serviceEvents[eventName]
+	if !has {
+		return nil, false
+	}
+
+	return func(ctx context.Context, args *ServiceEventArgs) (*ServiceEventResult, error) {
+		return nil, handler(ctx, args)
+	}, true
 }
 
 // Handler methods - these are registered with the broker to handle incoming requests
@@ -216,7 +357,7 @@ func (em *EventManager) onInvokeProjectHandler(
 ) (*EventMessage, error) {
 	em.eventsMutex.RLock()
 	defer em.eventsMutex.RUnlock()
-	handler, exists := em.projectEvents[req.EventName]
+	handler, exists := em.projectHandler(req.EventName)
 
 	if !exists {
 		// No handler registered, return empty response (not an error)
@@ -231,21 +372,27 @@ func (em *EventManager) onInvokeProjectHandler(
 	handlerMessage := ""
 
 	// Call the project event handler
-	err := handler(ctx, args)
+	result, err := handler(ctx, args)
 	if err != nil {
 		handlerStatus = "failed"
 		handlerMessage = err.Error()
 		log.Printf("invokeProjectHandler error for event %s: %v", req.EventName, err)
 	}
 
+	handlerStatusMsg := &ProjectHandlerStatus{
+		EventName: req.EventName,
+		Status:    handlerStatus,
+		Message:   handlerMessage,
+	}
+
+	if err == nil && result != nil {
+		handlerStatusMsg.Env = result.Env
+	}
+
 	// Return status message
 	return &EventMessage{
 		MessageType: &EventMessage_ProjectHandlerStatus{
-			ProjectHandlerStatus: &ProjectHandlerStatus{
-				EventName: req.EventName,
-				Status:    handlerStatus,
-				Message:   handlerMessage,
-			},
+			ProjectHandlerStatus: handlerStatusMsg,
 		},
 	}, nil
 }
@@ -257,7 +404,7 @@ func (em *EventManager) onInvokeServiceHandler(
 ) (*EventMessage, error) {
 	em.eventsMutex.RLock()
 	defer em.eventsMutex.RUnlock()
-	handler, exists := em.serviceEvents[req.EventName]
+	handler, exists := em.serviceHandler(req.EventName)
 
 	if !exists {
 		// No handler registered, return empty response (not an error)
@@ -280,22 +427,29 @@ func (em *EventManager) onInvokeServiceHandler(
 	handlerMessage := ""
 
 	// Call the service event handler
-	err := handler(ctx, args)
+	result, err := handler(ctx, args)
 	if err != nil {
 		handlerStatus = "failed"
 		handlerMessage = err.Error()
 		log.Printf("invokeServiceHandler error for event %s: %v", req.EventName, err)
 	}
 
+	handlerStatusMsg := &ServiceHandlerStatus{
+		EventName:   req.EventName,
+		ServiceName: req.Service.Name,
+		Status:      handlerStatus,
+		Message:     handlerMessage,
+	}
+
+	if err == nil && result != nil {
+		handlerStatusMsg.Env = result.Env
+		handlerStatusMsg.ServiceContext = result.ServiceContext
+	}
+
 	// Return status message
 	return &EventMessage{
 		MessageType: &EventMessage_ServiceHandlerStatus{
-			ServiceHandlerStatus: &ServiceHandlerStatus{
-				EventName:   req.EventName,
-				ServiceName: req.Service.Name,
-				Status:      handlerStatus,
-				Message:     handlerMessage,
-			},
+			ServiceHandlerStatus: handlerStatusMsg,
 		},
 	}, nil
 }
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -171,6 +172,38 @@ func TestEventManager_onInvokeProjectHandler_Success(t *testing.T) {
 	assert.Equal(t, "", status.Message)
 }
 
+// Test onInvokeProjectHandler with a result handler returning environment values
+func TestEventManager_onInvokeProjectHandler_Result(t *testing.T) {
+	ctx := context.Background()
+	client := &AzdClient{}
+
+	eventManager := NewEventManager("microsoft.azd.demo", client, nil)
+
+	// A result handler takes precedence over a plain handler for the same event
+	eventManager.projectEvents["predeploy"] = func(ctx context.Context, args *ProjectEventArgs) error {
+		return errors.New("plain handler should not be called")
+	}
+	eventManager.projectResultEvents["predeploy"] = func(
+		ctx context.Context,
+		args *ProjectEventArgs,
+	) (*ProjectEventResult, error) {
+		return &ProjectEventResult{
+			Env: map[string]string{"BUILD_ARGS": "--no-cache"},
+		}, nil
+	}
+
+	resp, err := eventManager.onInvokeProjectHandler(ctx, &InvokeProjectHandler{
+		EventName: "predeploy",
+		Project:   createTestProjectConfigForEvents(),
+	})
+
+	require.NoError(t, err)
+	status := resp.GetProjectHandlerStatus()
+	require.NotNil(t, status)
+	assert.Equal(t, "completed", status.Status)
+	assert.Equal(t, map[string]string{"BUILD_ARGS": "--no-cache"}, status.Env)
+}
+
 // Test onInvokeProjectHandler with handler error
 func TestEventManager_onInvokeProjectHandler_HandlerError(t *testing.T) {
 	ctx := context.Background()
@@ -272,6 +305,76 @@ func TestEventManager_onInvokeServiceHandler_Success(t *testing.T) {
 	assert.Equal(t, "", status.Message)
 }
 
+// Test onInvokeServiceHandler with a result handler that modifies the service context
+func TestEventManager_onInvokeServiceHandler_Result(t *testing.T) {
+	ctx := context.Background()
+	client := &AzdClient{}
+
+	eventManager := NewEventManager("microsoft.azd.demo", client, nil)
+
+	eventManager.serviceResultEvents["postpackage"] = func(
+		ctx context.Context,
+		args *ServiceEventArgs,
+	) (*ServiceEventResult, error) {
+		args.ServiceContext.Package[0].Metadata["scanned"] = "true"
+		return &ServiceEventResult{
+			Env:            map[string]string{"SCAN_RESULT": "passed"},
+			ServiceContext: args.ServiceContext,
+		}, nil
+	}
+
+	resp, err := eventManager.onInvokeServiceHandler(ctx, &InvokeServiceHandler{
+		EventName:      "postpackage",
+		Project:        createTestProjectConfigForEvents(),
+		Service:        createTestServiceConfigForEvents(),
+		ServiceContext: createTestServiceContextForEvents(),
+	})
+
+	require.NoError(t, err)
+	status := resp.GetServiceHandlerStatus()
+	require.NotNil(t, status)
+	assert.Equal(t, "completed", status.Status)
+	assert.Equal(t, map[string]string{"SCAN_RESULT": "passed"}, status.Env)
+	require.NotNil(t, status.ServiceContext)
+	assert.Equal(t, "true", status.ServiceContext.Package[0].Metadata["scanned"])
+}
+
+// Test onInvokeServiceHandler with a result handler that aborts the operation
+func TestEventManager_onInvokeServiceHandler_ResultError(t *testing.T) {
+	ctx := context.Background()
+	client := &AzdClient{}
+
+	eventManager := NewEventManager("microsoft.azd.demo", client, nil)
+
+	eventManager.serviceResultEvents["predeploy"] = func(
+		ctx context.Context,
+		args *ServiceEventArgs,
+	) (*ServiceEventResult, error) {
+		return &ServiceEventResult{Env: map[string]string{"IGNORED": "true"}}, errors.New("policy check failed")
+	}
+
+	resp, err := eventManager.onInvokeServiceHandler(ctx, &InvokeServiceHandler{
+		EventName: "predeploy",
+		Project:   createTestProjectConfigForEvents(),
+		Service:   createTestServiceConfigForEvents(),
+	})
+
+	require.NoError(t, err)
+	status := resp.GetServiceHandlerStatus()
+	require.NotNil(t, status)
+	assert.Equal(t, "failed", status.Status)
+	assert.Equal(t, "policy check failed", status.Message)
+	assert.Empty(t, status.Env)
+	assert.Nil(t, status.ServiceContext)
+}
+
+func TestTimeoutSeconds(t *testing.T) {
+	assert.Equal(t, int32(0), timeoutSeconds(0))
+	assert.Equal(t, int32(0), timeoutSeconds(-time.Second))
+	assert.Equal(t, int32(30), timeoutSeconds(30*time.Second))
+	assert.Equal(t, int32(2), timeoutSeconds(1500*time.Millisecond))
+}
+
 // Test onInvokeServiceHandler with nil ServiceContext (should default to empty)
 func TestEventManager_onInvokeServiceHandler_NilServiceContext(t *testing.T) {
 	ctx := context.Background()
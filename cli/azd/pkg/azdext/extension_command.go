@@ -11,7 +11,6 @@ import (
 	"strconv"
 
 	"github.com/spf13/cobra"
-	"go.opentelemetry.io/otel/propagation"
 )
 
 // ExtensionContext holds parsed global state available to extension commands.
@@ -86,9 +85,15 @@ func NewExtensionRootCommand(opts ExtensionCommandOptions) (*cobra.Command, *Ext
 	_ = flags.MarkHidden("trace-log-url")
 
 	cmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
-		// Env-var fallback for flags not explicitly set
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		// Env-var fallback for flags not explicitly set. The environment is the one of the azd invocation served by the
+		// context, which differs from the process environment in daemon mode.
 		if !cmd.Flags().Changed("debug") {
-			if v := os.Getenv("AZD_DEBUG"); v != "" {
+			if v := Getenv(ctx, "AZD_DEBUG"); v != "" {
 				if b, err := strconv.ParseBool(v); err == nil {
 					extCtx.Debug = b
 				}
@@ -96,7 +101,7 @@ func NewExtensionRootCommand(opts ExtensionCommandOptions) (*cobra.Command, *Ext
 		}
 
 		if !cmd.Flags().Changed("no-prompt") {
-			if v := os.Getenv("AZD_NO_PROMPT"); v != "" {
+			if v := Getenv(ctx, "AZD_NO_PROMPT"); v != "" {
 				if b, err := strconv.ParseBool(v); err == nil {
 					extCtx.NoPrompt = b
 				}
@@ -104,13 +109,13 @@ func NewExtensionRootCommand(opts ExtensionCommandOptions) (*cobra.Command, *Ext
 		}
 
 		if !cmd.Flags().Changed("cwd") {
-			if v := os.Getenv("AZD_CWD"); v != "" {
+			if v := Getenv(ctx, "AZD_CWD"); v != "" {
 				extCtx.Cwd = v
 			}
 		}
 
 		if !cmd.Flags().Changed("environment") {
-			if v := os.Getenv("AZD_ENVIRONMENT"); v != "" {
+			if v := Getenv(ctx, "AZD_ENVIRONMENT"); v != "" {
 				extCtx.Environment = v
 			}
 		}
@@ -129,18 +134,7 @@ func NewExtensionRootCommand(opts ExtensionCommandOptions) (*cobra.Command, *Ext
 		}
 
 		// Extract OTel trace context from environment
-		ctx := cmd.Context()
-		if ctx == nil {
-			ctx = context.Background()
-		}
-
-		if parent := os.Getenv(TraceparentEnv); parent != "" {
-			tc := propagation.TraceContext{}
-			ctx = tc.Extract(ctx, propagation.MapCarrier{
-				TraceparentKey: parent,
-				TracestateKey:  os.Getenv(TracestateEnv),
-			})
-		}
+		ctx = withTraceContext(ctx)
 
 		// Inject gRPC access token
 		ctx = WithAccessToken(ctx)
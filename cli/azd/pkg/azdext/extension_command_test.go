@@ -95,6 +95,34 @@ func TestExtensionCommand_EnvVarFallback(t *testing.T) {
 	require.Equal(t, "staging", extCtx.Environment)
 }
 
+func TestExtensionCommand_SessionEnv(t *testing.T) {
+	// A daemon serves later invocations with the environment of each invocation, not the one of its process
+	t.Setenv("AZD_ENVIRONMENT", "from-process")
+	t.Setenv("AZD_DEBUG", "true")
+
+	cmd, extCtx := NewExtensionRootCommand(ExtensionCommandOptions{
+		Name: "test-ext",
+	})
+
+	sub := &cobra.Command{Use: "sub", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
+	cmd.AddCommand(sub)
+
+	cmd.SetContext(WithSessionEnv(context.Background(), map[string]string{
+		"AZD_ENVIRONMENT": "from-session",
+		"TRACEPARENT":     "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}))
+	cmd.SetArgs([]string{"sub"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	require.Equal(t, "from-session", extCtx.Environment)
+	require.False(t, extCtx.Debug)
+
+	sc := trace.SpanContextFromContext(extCtx.Context())
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+}
+
 func TestExtensionCommand_FlagOverridesEnvVar(t *testing.T) {
 	t.Setenv("AZD_ENVIRONMENT", "from-env")
 
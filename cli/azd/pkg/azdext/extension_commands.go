@@ -14,15 +14,24 @@ import (
 // The configure function receives an ExtensionHost to register service targets,
 // framework services, and event handlers before the host starts.
 // If configure is nil, the host runs with no custom registrations.
+//
+// When started with --daemon (by azd, for extensions that declare the daemon capability), the command runs an
+// ExtensionDaemon that keeps the process alive across azd invocations and configures a new host for each of them.
 func NewListenCommand(configure func(host *ExtensionHost)) *cobra.Command {
-	return &cobra.Command{
+	var daemon bool
+
+	cmd := &cobra.Command{
 		Use:          "listen",
 		Hidden:       true,
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if daemon {
+				return NewExtensionDaemon(configure).Run(cmd.Context())
+			}
+
 			ctx := WithAccessToken(cmd.Context())
 
-			client, err := NewAzdClient()
+			client, err := NewAzdClientFromContext(ctx)
 			if err != nil {
 				return fmt.Errorf("failed to create azd client: %w", err)
 			}
@@ -36,6 +45,10 @@ func NewListenCommand(configure func(host *ExtensionHost)) *cobra.Command {
 			return host.Run(ctx)
 		},
 	}
+
+	cmd.Flags().BoolVar(&daemon, "daemon", false, "Keep the extension running across azd invocations")
+
+	return cmd
 }
 
 // NewMetadataCommand creates the standard "metadata" command that outputs
@@ -6,7 +6,6 @@ package azdext
 import (
 	"context"
 	"fmt"
-	"os"
 )
 
 // ReportError sends a structured extension error to the azd host via gRPC.
@@ -17,7 +16,7 @@ func ReportError(ctx context.Context, err error) error {
 		return nil
 	}
 
-	server := os.Getenv("AZD_SERVER")
+	server := Getenv(ctx, "AZD_SERVER")
 	if server == "" {
 		return fmt.Errorf("AZD_SERVER not set")
 	}
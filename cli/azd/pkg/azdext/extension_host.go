@@ -39,6 +39,12 @@ type extensionEventManager interface {
 	AddServiceEventHandler(
 		ctx context.Context, eventName string, handler ServiceEventHandler, options *ServiceEventOptions,
 	) error
+	AddProjectEventResultHandler(
+		ctx context.Context, eventName string, handler ProjectEventResultHandler, options *ProjectEventOptions,
+	) error
+	AddServiceEventResultHandler(
+		ctx context.Context, eventName string, handler ServiceEventResultHandler, options *ServiceEventOptions,
+	) error
 	Close() error
 }
 
@@ -55,16 +61,21 @@ type FrameworkServiceRegistration struct {
 }
 
 // ProjectEventRegistration describes a project-level event handler to register.
+// Either Handler or ResultHandler is set.
 type ProjectEventRegistration struct {
-	EventName string
-	Handler   ProjectEventHandler
+	EventName     string
+	Handler       ProjectEventHandler
+	ResultHandler ProjectEventResultHandler
+	Options       *ProjectEventOptions
 }
 
 // ServiceEventRegistration describes a service-level event handler to register.
+// Either Handler or ResultHandler is set.
 type ServiceEventRegistration struct {
-	EventName string
-	Handler   ServiceEventHandler
-	Options   *ServiceEventOptions
+	EventName     string
+	Handler       ServiceEventHandler
+	ResultHandler ServiceEventResultHandler
+	Options       *ServiceEventOptions
 }
 
 // ProviderFactory describes a function that creates a provider instance
@@ -154,6 +165,37 @@ func (er *ExtensionHost) WithServiceEventHandler(
 	return er
 }
 
+// WithProjectEventResultHandler registers a project-level event handler that returns a structured result
+// to azd, such as environment values to set. Returning an error aborts the azd operation.
+func (er *ExtensionHost) WithProjectEventResultHandler(
+	eventName string,
+	handler ProjectEventResultHandler,
+	options *ProjectEventOptions,
+) *ExtensionHost {
+	er.projectHandlers = append(er.projectHandlers, ProjectEventRegistration{
+		EventName:     eventName,
+		ResultHandler: handler,
+		Options:       options,
+	})
+	return er
+}
+
+// WithServiceEventResultHandler registers a service-level event handler that returns a structured result
+// to azd, such as environment values or modified service context artifacts. Returning an error aborts
+// the azd operation.
+func (er *ExtensionHost) WithServiceEventResultHandler(
+	eventName string,
+	handler ServiceEventResultHandler,
+	options *ServiceEventOptions,
+) *ExtensionHost {
+	er.serviceHandlers = append(er.serviceHandlers, ServiceEventRegistration{
+		EventName:     eventName,
+		ResultHandler: handler,
+		Options:       options,
+	})
+	return er
+}
+
 // Run wires the configured service targets and event handlers, signals readiness, and blocks until shutdown.
 func (er *ExtensionHost) Run(ctx context.Context) error {
 	extensionId := getExtensionId(ctx)
@@ -171,9 +213,9 @@ func (er *ExtensionHost) Run(ctx context.Context) error {
 	// Uses strconv.ParseBool to match WaitForDebugger semantics (accepts
 	// "1", "t", "TRUE", "true", etc.).
 	var brokerLogger *log.Logger
-	if isDebug, err := strconv.ParseBool(os.Getenv("AZD_EXT_DEBUG")); err == nil && isDebug {
+	if isDebug, err := strconv.ParseBool(Getenv(ctx, "AZD_EXT_DEBUG")); err == nil && isDebug {
 		brokerLogger = log.New(os.Stderr, "", log.LstdFlags)
-	} else if isDebug, err := strconv.ParseBool(os.Getenv("AZD_DEBUG")); err == nil && isDebug {
+	} else if isDebug, err := strconv.ParseBool(Getenv(ctx, "AZD_DEBUG")); err == nil && isDebug {
 		brokerLogger = log.New(os.Stderr, "", log.LstdFlags)
 	}
 	er.initManagers(extensionId, brokerLogger)
@@ -274,13 +316,19 @@ func (er *ExtensionHost) Run(ctx context.Context) error {
 
 	// Register project event handlers in parallel
 	for _, reg := range er.projectHandlers {
-		if reg.Handler == nil {
+		if reg.Handler == nil && reg.ResultHandler == nil {
 			return fmt.Errorf("project event handler for '%s' is nil", reg.EventName)
 		}
 
 		r := reg
 		registrationsWaitGroup.Go(func() {
-			if err := er.eventManager.AddProjectEventHandler(ctx, r.EventName, r.Handler); err != nil {
+			var err error
+			if r.ResultHandler != nil {
+				err = er.eventManager.AddProjectEventResultHandler(ctx, r.EventName, r.ResultHandler, r.Options)
+			} else {
+				err = er.eventManager.AddProjectEventHandler(ctx, r.EventName, r.Handler)
+			}
+			if err != nil {
 				registrationErrChan <- fmt.Errorf("failed to add project event handler '%s': %w", r.EventName, err)
 			}
 		})
@@ -288,13 +336,19 @@ func (er *ExtensionHost) Run(ctx context.Context) error {
 
 	// Register service event handlers in parallel
 	for _, reg := range er.serviceHandlers {
-		if reg.Handler == nil {
+		if reg.Handler == nil && reg.ResultHandler == nil {
 			return fmt.Errorf("service event handler for '%s' is nil", reg.EventName)
 		}
 
 		r := reg
 		registrationsWaitGroup.Go(func() {
-			if err := er.eventManager.AddServiceEventHandler(ctx, r.EventName, r.Handler, r.Options); err != nil {
+			var err error
+			if r.ResultHandler != nil {
+				err = er.eventManager.AddServiceEventResultHandler(ctx, r.EventName, r.ResultHandler, r.Options)
+			} else {
+				err = er.eventManager.AddServiceEventHandler(ctx, r.EventName, r.Handler, r.Options)
+			}
+			if err != nil {
 				registrationErrChan <- fmt.Errorf("failed to add service event handler '%s': %w", r.EventName, err)
 			}
 		})
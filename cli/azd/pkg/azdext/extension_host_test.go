@@ -122,6 +122,26 @@ func (m *MockExtensionEventManager) AddServiceEventHandler(
 	return args.Error(0)
 }
 
+func (m *MockExtensionEventManager) AddProjectEventResultHandler(
+	ctx context.Context,
+	eventName string,
+	handler ProjectEventResultHandler,
+	options *ProjectEventOptions,
+) error {
+	args := m.Called(ctx, eventName, handler, options)
+	return args.Error(0)
+}
+
+func (m *MockExtensionEventManager) AddServiceEventResultHandler(
+	ctx context.Context,
+	eventName string,
+	handler ServiceEventResultHandler,
+	options *ServiceEventOptions,
+) error {
+	args := m.Called(ctx, eventName, handler, options)
+	return args.Error(0)
+}
+
 func (m *MockExtensionEventManager) Receive(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
@@ -346,6 +366,76 @@ func TestExtensionHost_EventHandlersOnly(t *testing.T) {
 	mockEventManager.AssertExpectations(t)
 }
 
+func TestExtensionHost_EventResultHandlers(t *testing.T) {
+	t.Parallel()
+
+	projectOptions := &ProjectEventOptions{Timeout: time.Minute}
+	serviceOptions := &ServiceEventOptions{Host: "containerapp", Timeout: 30 * time.Second}
+
+	// Setup mocks
+	mockEventManager := &MockExtensionEventManager{}
+	var wg sync.WaitGroup
+	wg.Add(2) // We expect 2 registrations
+	registrationComplete := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(registrationComplete)
+	}()
+	mockEventManager.On("AddProjectEventResultHandler", mock.Anything, "predeploy", mock.Anything, projectOptions).
+		Run(func(args mock.Arguments) {
+			wg.Done()
+		}).
+		Return(nil)
+	mockEventManager.On("AddServiceEventResultHandler", mock.Anything, "postpackage", mock.Anything, serviceOptions).
+		Run(func(args mock.Arguments) {
+			wg.Done()
+		}).
+		Return(nil)
+	mockEventManager.On("Ready", mock.Anything).Return(nil)
+	mockEventManager.On("Receive", mock.Anything).Run(func(args mock.Arguments) {
+		ctx := args.Get(0).(context.Context)
+		<-ctx.Done()
+	}).Return(nil)
+	mockEventManager.On("Close").Return(nil)
+
+	// Setup extension host
+	client := newTestAzdClient()
+	runner := NewExtensionHost(client)
+	runner.eventManager = mockEventManager
+
+	runner.WithProjectEventResultHandler(
+		"predeploy",
+		func(ctx context.Context, args *ProjectEventArgs) (*ProjectEventResult, error) {
+			return &ProjectEventResult{Env: map[string]string{"POLICY_CHECKED": "true"}}, nil
+		},
+		projectOptions,
+	)
+	runner.WithServiceEventResultHandler(
+		"postpackage",
+		func(ctx context.Context, args *ServiceEventArgs) (*ServiceEventResult, error) {
+			return &ServiceEventResult{ServiceContext: args.ServiceContext}, nil
+		},
+		serviceOptions,
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- runner.Run(ctx)
+	}()
+
+	<-registrationComplete
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	err := <-done
+
+	require.NoError(t, err)
+	mockEventManager.AssertExpectations(t)
+}
+
 func TestExtensionHost_ServiceTargetsAndEvents(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,373 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// aiModelService is a fake of the azd AiModelService that resolves the seeded models and usages instead of querying
+// the model catalog and quota of the subscription. It returns the same error reasons as azd.
+type aiModelService struct {
+	azdext.UnimplementedAiModelServiceServer
+	server *Server
+}
+
+func (s *aiModelService) ListModels(
+	ctx context.Context,
+	req *azdext.ListModelsRequest,
+) (*azdext.ListModelsResponse, error) {
+	if err := requireSubscriptionId(req.AzureContext); err != nil {
+		return nil, err
+	}
+
+	filter := req.Filter
+	if filter == nil {
+		filter = &azdext.AiModelFilterOptions{}
+	}
+
+	models := []*azdext.AiModel{}
+	for _, model := range s.server.aiModelsSnapshot() {
+		if slices.Contains(filter.ExcludeModelNames, model.Name) ||
+			!matchesAny(filter.Formats, model.Format) ||
+			!matchesAny(filter.Statuses, model.LifecycleStatus) ||
+			!matchesAny(filter.Capabilities, model.Capabilities...) ||
+			!matchesAny(filter.Locations, model.Locations...) {
+			continue
+		}
+
+		models = append(models, model)
+	}
+
+	return &azdext.ListModelsResponse{Models: models}, nil
+}
+
+func (s *aiModelService) ResolveModelDeployments(
+	ctx context.Context,
+	req *azdext.ResolveModelDeploymentsRequest,
+) (*azdext.ResolveModelDeploymentsResponse, error) {
+	if err := requireSubscriptionId(req.AzureContext); err != nil {
+		return nil, err
+	}
+
+	options := req.Options
+	if options == nil {
+		options = &azdext.AiModelDeploymentOptions{}
+	}
+
+	if req.Quota != nil && len(options.Locations) != 1 {
+		return nil, aiStatusError(
+			codes.InvalidArgument,
+			azdext.AiErrorReasonQuotaLocation,
+			fmt.Sprintf("quota checking requires exactly one location, got %d", len(options.Locations)),
+			nil,
+		)
+	}
+
+	// Like azd, only the models available in the requested locations are resolved
+	model, err := s.findModel(req.ModelName)
+	if err != nil {
+		return nil, err
+	}
+
+	if !matchesAny(options.Locations, model.Locations...) {
+		return nil, modelNotFoundError(req.ModelName)
+	}
+
+	location := ""
+	if len(options.Locations) == 1 {
+		location = options.Locations[0]
+	}
+
+	var usages map[string]*azdext.AiModelUsage
+	if req.Quota != nil {
+		usages = s.server.aiUsagesSnapshot(location)
+	}
+
+	deployments := []*azdext.AiModelDeployment{}
+	for _, version := range model.Versions {
+		if len(options.Versions) > 0 && !slices.Contains(options.Versions, version.Version) {
+			continue
+		}
+
+		for _, sku := range version.Skus {
+			if len(options.Skus) > 0 && !slices.Contains(options.Skus, sku.Name) {
+				continue
+			}
+
+			if !req.IncludeFinetuneSkus && strings.HasSuffix(strings.ToLower(sku.UsageName), "-finetune") {
+				continue
+			}
+
+			deployment := &azdext.AiModelDeployment{
+				ModelName: model.Name,
+				Format:    model.Format,
+				Version:   version.Version,
+				Location:  location,
+				Sku:       sku,
+				Capacity:  resolveCapacity(sku, options.Capacity),
+			}
+
+			if req.Quota != nil {
+				usage, has := usages[sku.UsageName]
+				if !has {
+					continue
+				}
+
+				remaining := usage.Limit - usage.CurrentValue
+				if remaining < minRemainingQuota(req.Quota.MinRemainingCapacity) || float64(deployment.Capacity) > remaining {
+					continue
+				}
+
+				deployment.RemainingQuota = &remaining
+			}
+
+			deployments = append(deployments, deployment)
+		}
+	}
+
+	if len(deployments) == 0 {
+		return nil, aiStatusError(
+			codes.FailedPrecondition,
+			azdext.AiErrorReasonNoDeploymentMatch,
+			fmt.Sprintf("no deployment found for model %q with the specified options", req.ModelName),
+			map[string]string{"model_name": req.ModelName},
+		)
+	}
+
+	return &azdext.ResolveModelDeploymentsResponse{Deployments: deployments}, nil
+}
+
+func (s *aiModelService) ListUsages(
+	ctx context.Context,
+	req *azdext.ListUsagesRequest,
+) (*azdext.ListUsagesResponse, error) {
+	if err := requireSubscriptionId(req.AzureContext); err != nil {
+		return nil, err
+	}
+
+	if req.Location == "" {
+		return nil, aiStatusError(
+			codes.InvalidArgument,
+			azdext.AiErrorReasonLocationRequired,
+			"location is required for listing usages",
+			nil,
+		)
+	}
+
+	usages := slices.Collect(maps.Values(s.server.aiUsagesSnapshot(req.Location)))
+	slices.SortFunc(usages, func(a, b *azdext.AiModelUsage) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return &azdext.ListUsagesResponse{Usages: usages}, nil
+}
+
+func (s *aiModelService) ListLocationsWithQuota(
+	ctx context.Context,
+	req *azdext.ListLocationsWithQuotaRequest,
+) (*azdext.ListLocationsWithQuotaResponse, error) {
+	if err := requireSubscriptionId(req.AzureContext); err != nil {
+		return nil, err
+	}
+
+	locations := []*azdext.Location{}
+	for _, location := range s.server.aiUsageLocations() {
+		if !matchesAny(req.AllowedLocations, location) {
+			continue
+		}
+
+		usages := s.server.aiUsagesSnapshot(location)
+		hasQuota := true
+		for _, requirement := range req.Requirements {
+			usage, has := usages[requirement.UsageName]
+			if !has || usage.Limit-usage.CurrentValue < minRemainingQuota(requirement.MinCapacity) {
+				hasQuota = false
+				break
+			}
+		}
+
+		if hasQuota {
+			locations = append(locations, &azdext.Location{Name: location})
+		}
+	}
+
+	return &azdext.ListLocationsWithQuotaResponse{Locations: locations}, nil
+}
+
+func (s *aiModelService) ListModelLocationsWithQuota(
+	ctx context.Context,
+	req *azdext.ListModelLocationsWithQuotaRequest,
+) (*azdext.ListModelLocationsWithQuotaResponse, error) {
+	if err := requireSubscriptionId(req.AzureContext); err != nil {
+		return nil, err
+	}
+
+	if req.ModelName == "" {
+		return nil, status.Error(codes.InvalidArgument, "model_name is required")
+	}
+
+	model, err := s.findModel(req.ModelName)
+	if err != nil {
+		return nil, err
+	}
+
+	minRemaining := minRemainingQuota(req.Quota.GetMinRemainingCapacity())
+
+	locations := []*azdext.ModelLocationQuota{}
+	for _, location := range slices.Sorted(slices.Values(model.Locations)) {
+		if !matchesAny(req.AllowedLocations, location) {
+			continue
+		}
+
+		// The quota of a location is the highest remaining quota of the skus of the model
+		usages := s.server.aiUsagesSnapshot(location)
+		maxRemaining, found := float64(0), false
+		for _, version := range model.Versions {
+			for _, sku := range version.Skus {
+				if usage, has := usages[sku.UsageName]; has {
+					remaining := usage.Limit - usage.CurrentValue
+					if !found || remaining > maxRemaining {
+						maxRemaining, found = remaining, true
+					}
+				}
+			}
+		}
+
+		if found && maxRemaining >= minRemaining {
+			locations = append(locations, &azdext.ModelLocationQuota{
+				Location:          &azdext.Location{Name: location},
+				MaxRemainingQuota: maxRemaining,
+			})
+		}
+	}
+
+	return &azdext.ListModelLocationsWithQuotaResponse{Locations: locations}, nil
+}
+
+// findModel returns a copy of the seeded model with the specified name.
+func (s *aiModelService) findModel(modelName string) (*azdext.AiModel, error) {
+	for _, model := range s.server.aiModelsSnapshot() {
+		if model.Name == modelName {
+			return model, nil
+		}
+	}
+
+	return nil, modelNotFoundError(modelName)
+}
+
+func modelNotFoundError(modelName string) error {
+	return aiStatusError(
+		codes.NotFound,
+		azdext.AiErrorReasonModelNotFound,
+		fmt.Sprintf("model not found: %q", modelName),
+		map[string]string{"model_name": modelName},
+	)
+}
+
+// aiModelsSnapshot returns a copy of the seeded AI models.
+func (s *Server) aiModelsSnapshot() []*azdext.AiModel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	models := make([]*azdext.AiModel, len(s.aiModels))
+	for i, model := range s.aiModels {
+		models[i] = proto.Clone(model).(*azdext.AiModel)
+	}
+
+	return models
+}
+
+// aiUsagesSnapshot returns a copy of the seeded quota usages of a location, keyed by usage name.
+func (s *Server) aiUsagesSnapshot(location string) map[string]*azdext.AiModelUsage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usages := map[string]*azdext.AiModelUsage{}
+	for _, usage := range s.aiUsages[location] {
+		usages[usage.Name] = proto.Clone(usage).(*azdext.AiModelUsage)
+	}
+
+	return usages
+}
+
+// aiUsageLocations returns the sorted locations usages have been seeded for.
+func (s *Server) aiUsageLocations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Sorted(maps.Keys(s.aiUsages))
+}
+
+// matchesAny returns true when no filter values are specified or any of the values is one of the filter values.
+func matchesAny(filter []string, values ...string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(values, func(value string) bool {
+		return slices.Contains(filter, value)
+	})
+}
+
+// minRemainingQuota returns the remaining quota required by a quota check, which defaults to 1.
+func minRemainingQuota(minCapacity float64) float64 {
+	if minCapacity <= 0 {
+		return 1
+	}
+
+	return minCapacity
+}
+
+// resolveCapacity returns the preferred capacity when it is valid for the sku, or the default capacity of the sku.
+func resolveCapacity(sku *azdext.AiModelSku, preferred *int32) int32 {
+	if preferred != nil {
+		capacity := *preferred
+		if capacity > 0 &&
+			(sku.MinCapacity <= 0 || capacity >= sku.MinCapacity) &&
+			(sku.MaxCapacity <= 0 || capacity <= sku.MaxCapacity) &&
+			(sku.CapacityStep <= 0 || capacity%sku.CapacityStep == 0) {
+			return capacity
+		}
+	}
+
+	return sku.DefaultCapacity
+}
+
+func requireSubscriptionId(azureContext *azdext.AzureContext) error {
+	if azureContext == nil || azureContext.Scope == nil || azureContext.Scope.SubscriptionId == "" {
+		return aiStatusError(
+			codes.InvalidArgument,
+			azdext.AiErrorReasonMissingSubscription,
+			"azure_context.scope.subscription_id is required",
+			nil,
+		)
+	}
+
+	return nil
+}
+
+func aiStatusError(code codes.Code, reason string, message string, metadata map[string]string) error {
+	st := status.New(code, message)
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   reason,
+		Domain:   azdext.AiErrorDomain,
+		Metadata: metadata,
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
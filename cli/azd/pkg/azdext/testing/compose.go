@@ -0,0 +1,112 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package testing
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// composeService is an in-memory implementation of the azd ComposeService.
+//
+// Resources added by the extension are kept with the seeded resources. Unlike azd, adding a resource with a resource id
+// doesn't set the resource id in the environment.
+type composeService struct {
+	azdext.UnimplementedComposeServiceServer
+	server *Server
+}
+
+func (s *composeService) ListResources(
+	ctx context.Context,
+	req *azdext.EmptyRequest,
+) (*azdext.ListResourcesResponse, error) {
+	return &azdext.ListResourcesResponse{Resources: s.server.ComposeResources()}, nil
+}
+
+func (s *composeService) GetResource(
+	ctx context.Context,
+	req *azdext.GetResourceRequest,
+) (*azdext.GetResourceResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	resource, has := s.server.composeResources[req.Name]
+	if !has {
+		return nil, status.Errorf(codes.NotFound, "resource %s not found", req.Name)
+	}
+
+	return &azdext.GetResourceResponse{Resource: proto.Clone(resource).(*azdext.ComposedResource)}, nil
+}
+
+func (s *composeService) ListResourceTypes(
+	ctx context.Context,
+	req *azdext.EmptyRequest,
+) (*azdext.ListResourceTypesResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	resourceTypes := make([]*azdext.ComposedResourceType, len(s.server.composeResourceTypes))
+	for i, resourceType := range s.server.composeResourceTypes {
+		resourceTypes[i] = proto.Clone(resourceType).(*azdext.ComposedResourceType)
+	}
+
+	return &azdext.ListResourceTypesResponse{ResourceTypes: resourceTypes}, nil
+}
+
+func (s *composeService) GetResourceType(
+	ctx context.Context,
+	req *azdext.GetResourceTypeRequest,
+) (*azdext.GetResourceTypeResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	for _, resourceType := range s.server.composeResourceTypes {
+		if resourceType.Name == req.TypeName {
+			return &azdext.GetResourceTypeResponse{
+				ResourceType: proto.Clone(resourceType).(*azdext.ComposedResourceType),
+			}, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "resource type %s not found", req.TypeName)
+}
+
+func (s *composeService) AddResource(
+	ctx context.Context,
+	req *azdext.AddResourceRequest,
+) (*azdext.AddResourceResponse, error) {
+	if req.Resource == nil || req.Resource.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "resource name cannot be empty")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	// Resources with the same name are updated, as azd does
+	s.server.composeResources[req.Resource.Name] = proto.Clone(req.Resource).(*azdext.ComposedResource)
+	return &azdext.AddResourceResponse{Resource: req.Resource}, nil
+}
+
+// ComposeResources returns a copy of the composability resources of the project, sorted by name.
+func (s *Server) ComposeResources() []*azdext.ComposedResource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resources := make([]*azdext.ComposedResource, 0, len(s.composeResources))
+	for _, resource := range s.composeResources {
+		resources = append(resources, proto.Clone(resource).(*azdext.ComposedResource))
+	}
+
+	slices.SortFunc(resources, func(a, b *azdext.ComposedResource) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return resources
+}
@@ -0,0 +1,117 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// containerRegistryEnvVarName is the environment value azd reads the default container registry from.
+const containerRegistryEnvVarName = "AZURE_CONTAINER_REGISTRY_ENDPOINT"
+
+// containerService is a fake of the azd ContainerService that doesn't run docker.
+//
+// Build and Package return the local image azd would create for the service, named
+// "<project>/<service>-<environment>" as azd does. Publish returns the image in the registry set by the
+// AZURE_CONTAINER_REGISTRY_ENDPOINT value of the default environment.
+type containerService struct {
+	azdext.UnimplementedContainerServiceServer
+	server *Server
+}
+
+func (s *containerService) Build(
+	ctx context.Context,
+	req *azdext.ContainerBuildRequest,
+) (*azdext.ContainerBuildResponse, error) {
+	imageName, err := s.imageName(req.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azdext.ContainerBuildResponse{
+		Result: &azdext.ServiceBuildResult{
+			Artifacts: []*azdext.Artifact{localImage(imageName + ":latest")},
+		},
+	}, nil
+}
+
+func (s *containerService) Package(
+	ctx context.Context,
+	req *azdext.ContainerPackageRequest,
+) (*azdext.ContainerPackageResponse, error) {
+	imageName, err := s.imageName(req.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azdext.ContainerPackageResponse{
+		Result: &azdext.ServicePackageResult{
+			Artifacts: []*azdext.Artifact{localImage(imageName + ":azd-deploy-0")},
+		},
+	}, nil
+}
+
+func (s *containerService) Publish(
+	ctx context.Context,
+	req *azdext.ContainerPublishRequest,
+) (*azdext.ContainerPublishResponse, error) {
+	imageName, err := s.imageName(req.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	s.server.mu.Lock()
+	env, err := s.server.resolveEnvironment("")
+	registry := ""
+	if err == nil {
+		registry = env.values[containerRegistryEnvVarName]
+	}
+	s.server.mu.Unlock()
+
+	if registry == "" {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"could not determine container registry endpoint, ensure '%s' is set in the environment",
+			containerRegistryEnvVarName,
+		)
+	}
+
+	return &azdext.ContainerPublishResponse{
+		Result: &azdext.ServicePublishResult{
+			Artifacts: []*azdext.Artifact{
+				{
+					Kind:         azdext.ArtifactKind_ARTIFACT_KIND_CONTAINER,
+					Location:     fmt.Sprintf("%s/%s:azd-deploy-0", registry, imageName),
+					LocationKind: azdext.LocationKind_LOCATION_KIND_REMOTE,
+				},
+			},
+		},
+	}, nil
+}
+
+// imageName returns the name of the image of a service of the project.
+func (s *containerService) imageName(serviceName string) (string, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, has := s.server.project.Services[serviceName]; !has {
+		return "", status.Errorf(codes.NotFound, "service %q not found in project configuration", serviceName)
+	}
+
+	return strings.ToLower(fmt.Sprintf("%s/%s-%s", s.server.project.Name, serviceName, s.server.defaultEnvironment)), nil
+}
+
+func localImage(image string) *azdext.Artifact {
+	return &azdext.Artifact{
+		Kind:         azdext.ArtifactKind_ARTIFACT_KIND_CONTAINER,
+		Location:     image,
+		LocationKind: azdext.LocationKind_LOCATION_KIND_LOCAL,
+	}
+}
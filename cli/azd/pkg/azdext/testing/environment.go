@@ -0,0 +1,272 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// environmentState is an in-memory azd environment.
+type environmentState struct {
+	name   string
+	values map[string]string
+	config config.Config
+}
+
+func (s *Server) addEnvironment(name string, values map[string]string, envConfig map[string]any) {
+	env := &environmentState{
+		name:   name,
+		values: map[string]string{"AZURE_ENV_NAME": name},
+		config: config.NewConfig(cloneMap(envConfig)),
+	}
+	maps.Copy(env.values, values)
+
+	s.environments = append(s.environments, env)
+	if s.defaultEnvironment == "" {
+		s.defaultEnvironment = name
+	}
+}
+
+// resolveEnvironment returns the environment with the specified name, or the default environment when the name is
+// empty. Must be called with the server lock held.
+func (s *Server) resolveEnvironment(name string) (*environmentState, error) {
+	if name == "" {
+		name = s.defaultEnvironment
+		if name == "" {
+			return nil, status.Error(codes.NotFound, "no default environment has been seeded")
+		}
+	}
+
+	for _, env := range s.environments {
+		if env.name == name {
+			return env, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "environment '%s' not found", name)
+}
+
+// environmentService is an in-memory implementation of the azd EnvironmentService.
+type environmentService struct {
+	azdext.UnimplementedEnvironmentServiceServer
+	server *Server
+}
+
+func (s *environmentService) GetCurrent(
+	ctx context.Context,
+	req *azdext.EmptyRequest,
+) (*azdext.EnvironmentResponse, error) {
+	return s.Get(ctx, &azdext.GetEnvironmentRequest{})
+}
+
+func (s *environmentService) List(ctx context.Context, req *azdext.EmptyRequest) (*azdext.EnvironmentListResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	environments := make([]*azdext.EnvironmentDescription, len(s.server.environments))
+	for i, env := range s.server.environments {
+		environments[i] = &azdext.EnvironmentDescription{
+			Name:    env.name,
+			Local:   true,
+			Default: env.name == s.server.defaultEnvironment,
+		}
+	}
+
+	return &azdext.EnvironmentListResponse{Environments: environments}, nil
+}
+
+func (s *environmentService) Get(
+	ctx context.Context,
+	req *azdext.GetEnvironmentRequest,
+) (*azdext.EnvironmentResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	env, err := s.server.resolveEnvironment(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azdext.EnvironmentResponse{Environment: &azdext.Environment{Name: env.name}}, nil
+}
+
+func (s *environmentService) Select(
+	ctx context.Context,
+	req *azdext.SelectEnvironmentRequest,
+) (*azdext.EmptyResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "environment name cannot be empty")
+	}
+
+	env, err := s.server.resolveEnvironment(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	s.server.defaultEnvironment = env.name
+	return &azdext.EmptyResponse{}, nil
+}
+
+func (s *environmentService) GetValues(
+	ctx context.Context,
+	req *azdext.GetEnvironmentRequest,
+) (*azdext.KeyValueListResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	env, err := s.server.resolveEnvironment(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	keyValues := []*azdext.KeyValue{}
+	for _, key := range slices.Sorted(maps.Keys(env.values)) {
+		keyValues = append(keyValues, &azdext.KeyValue{Key: key, Value: env.values[key]})
+	}
+
+	return &azdext.KeyValueListResponse{KeyValues: keyValues}, nil
+}
+
+func (s *environmentService) GetValue(ctx context.Context, req *azdext.GetEnvRequest) (*azdext.KeyValueResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	env, err := s.server.resolveEnvironment(req.EnvName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &azdext.KeyValueResponse{Key: req.Key, Value: env.values[req.Key]}, nil
+}
+
+func (s *environmentService) SetValue(ctx context.Context, req *azdext.SetEnvRequest) (*azdext.EmptyResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	env, err := s.server.resolveEnvironment(req.EnvName)
+	if err != nil {
+		return nil, err
+	}
+
+	env.values[req.Key] = req.Value
+	return &azdext.EmptyResponse{}, nil
+}
+
+func (s *environmentService) GetConfig(
+	ctx context.Context,
+	req *azdext.GetConfigRequest,
+) (*azdext.GetConfigResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	env, err := s.server.resolveEnvironment(req.EnvName)
+	if err != nil {
+		return nil, err
+	}
+
+	value, found := env.config.Get(req.Path)
+	if !found {
+		return &azdext.GetConfigResponse{}, nil
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	return &azdext.GetConfigResponse{Value: valueBytes, Found: true}, nil
+}
+
+func (s *environmentService) GetConfigString(
+	ctx context.Context,
+	req *azdext.GetConfigStringRequest,
+) (*azdext.GetConfigStringResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	env, err := s.server.resolveEnvironment(req.EnvName)
+	if err != nil {
+		return nil, err
+	}
+
+	value, found := env.config.GetString(req.Path)
+	return &azdext.GetConfigStringResponse{Value: value, Found: found}, nil
+}
+
+func (s *environmentService) GetConfigSection(
+	ctx context.Context,
+	req *azdext.GetConfigSectionRequest,
+) (*azdext.GetConfigSectionResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	env, err := s.server.resolveEnvironment(req.EnvName)
+	if err != nil {
+		return nil, err
+	}
+
+	section, found := env.config.GetMap(req.Path)
+	if !found {
+		return &azdext.GetConfigSectionResponse{}, nil
+	}
+
+	sectionBytes, err := json.Marshal(section)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal section: %w", err)
+	}
+
+	return &azdext.GetConfigSectionResponse{Section: sectionBytes, Found: true}, nil
+}
+
+func (s *environmentService) SetConfig(ctx context.Context, req *azdext.SetConfigRequest) (*azdext.EmptyResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	env, err := s.server.resolveEnvironment(req.EnvName)
+	if err != nil {
+		return nil, err
+	}
+
+	var value any
+	if err := json.Unmarshal(req.Value, &value); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unmarshal value: %v", err)
+	}
+
+	if err := env.config.Set(req.Path, value); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to set value: %v", err)
+	}
+
+	return &azdext.EmptyResponse{}, nil
+}
+
+func (s *environmentService) UnsetConfig(
+	ctx context.Context,
+	req *azdext.UnsetConfigRequest,
+) (*azdext.EmptyResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	env, err := s.server.resolveEnvironment(req.EnvName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := env.config.Unset(req.Path); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unset value: %v", err)
+	}
+
+	return &azdext.EmptyResponse{}, nil
+}
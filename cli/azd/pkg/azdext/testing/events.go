@@ -0,0 +1,225 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/grpcbroker"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// eventStream tracks the event stream opened by the extension and the events it subscribed to.
+type eventStream struct {
+	mu            sync.Mutex
+	broker        *grpcbroker.MessageBroker[azdext.EventMessage]
+	projectEvents []string
+	serviceEvents []*azdext.SubscribeServiceEvent
+	// changed is closed and replaced whenever a subscription is added
+	changed chan struct{}
+}
+
+func newEventStream() *eventStream {
+	return &eventStream{changed: make(chan struct{})}
+}
+
+func (e *eventStream) subscribe(update func()) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	update()
+	close(e.changed)
+	e.changed = make(chan struct{})
+}
+
+// waitFor blocks until the subscription check succeeds or the context is done.
+func (e *eventStream) waitFor(
+	ctx context.Context,
+	subscribed func() bool,
+) (*grpcbroker.MessageBroker[azdext.EventMessage], error) {
+	for {
+		e.mu.Lock()
+		broker, changed := e.broker, e.changed
+		found := broker != nil && subscribed()
+		e.mu.Unlock()
+
+		if found {
+			return broker, nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// eventService accepts the event stream of the extension and records its subscriptions.
+type eventService struct {
+	azdext.UnimplementedEventServiceServer
+	server *Server
+}
+
+func (s *eventService) EventStream(stream grpc.BidiStreamingServer[azdext.EventMessage, azdext.EventMessage]) error {
+	events := s.server.events
+	broker := grpcbroker.NewMessageBroker(stream, azdext.NewEventMessageEnvelope(), "azd", nil)
+
+	if err := broker.On(func(ctx context.Context, msg *azdext.SubscribeProjectEvent) (*azdext.EventMessage, error) {
+		events.subscribe(func() {
+			events.projectEvents = append(events.projectEvents, msg.EventNames...)
+		})
+		return nil, nil
+	}); err != nil {
+		return err
+	}
+
+	if err := broker.On(func(ctx context.Context, msg *azdext.SubscribeServiceEvent) (*azdext.EventMessage, error) {
+		events.subscribe(func() {
+			events.serviceEvents = append(events.serviceEvents, msg)
+		})
+		return nil, nil
+	}); err != nil {
+		return err
+	}
+
+	events.subscribe(func() {
+		events.broker = broker
+	})
+
+	if err := broker.Run(stream.Context()); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
+
+// InvokeProjectEvent invokes the project event handler the extension registered for the specified event, as azd does
+// during the matching lifecycle event, and waits until the handler completes or the context is done.
+//
+// Environment values returned by the handler are applied to the default environment. An error is returned when the
+// handler fails.
+func (s *Server) InvokeProjectEvent(ctx context.Context, eventName string) (*azdext.ProjectHandlerStatus, error) {
+	events := s.events
+	broker, err := events.waitFor(ctx, func() bool {
+		return slices.Contains(events.projectEvents, eventName)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("extension did not subscribe to project event %s: %w", eventName, err)
+	}
+
+	response, err := broker.SendAndWait(s.Context(ctx), &azdext.EventMessage{
+		MessageType: &azdext.EventMessage_InvokeProjectHandler{
+			InvokeProjectHandler: &azdext.InvokeProjectHandler{
+				EventName: eventName,
+				Project:   s.Project(),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke project event %s: %w", eventName, err)
+	}
+
+	handlerStatus := response.GetProjectHandlerStatus()
+	if handlerStatus == nil {
+		return nil, fmt.Errorf("unexpected response for project event %s", eventName)
+	}
+
+	if handlerStatus.Status == "failed" {
+		return handlerStatus, fmt.Errorf("project event handler %s failed: %s", eventName, handlerStatus.Message)
+	}
+
+	s.applyEnvValues(handlerStatus.Env)
+	return handlerStatus, nil
+}
+
+// InvokeServiceEvent invokes the service event handler the extension registered for the specified event and service,
+// as azd does during the matching lifecycle event, and waits until the handler completes or the context is done.
+// Service event subscriptions filtered by host or language only apply to matching services.
+//
+// Environment values returned by the handler are applied to the default environment. An error is returned when the
+// handler fails.
+func (s *Server) InvokeServiceEvent(
+	ctx context.Context,
+	eventName string,
+	serviceName string,
+	serviceContext *azdext.ServiceContext,
+) (*azdext.ServiceHandlerStatus, error) {
+	project := s.Project()
+	service, has := project.Services[serviceName]
+	if !has {
+		return nil, fmt.Errorf("service '%s' not found in project", serviceName)
+	}
+
+	events := s.events
+	broker, err := events.waitFor(ctx, func() bool {
+		return slices.ContainsFunc(events.serviceEvents, func(subscription *azdext.SubscribeServiceEvent) bool {
+			return slices.Contains(subscription.EventNames, eventName) &&
+				(subscription.Host == "" || subscription.Host == service.Host) &&
+				(subscription.Language == "" || subscription.Language == service.Language)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf(
+			"extension did not subscribe to service event %s for service '%s': %w", eventName, serviceName, err,
+		)
+	}
+
+	if serviceContext == nil {
+		serviceContext = &azdext.ServiceContext{}
+	}
+
+	response, err := broker.SendAndWait(s.Context(ctx), &azdext.EventMessage{
+		MessageType: &azdext.EventMessage_InvokeServiceHandler{
+			InvokeServiceHandler: &azdext.InvokeServiceHandler{
+				EventName:      eventName,
+				Project:        project,
+				Service:        service,
+				ServiceContext: proto.Clone(serviceContext).(*azdext.ServiceContext),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke service event %s: %w", eventName, err)
+	}
+
+	handlerStatus := response.GetServiceHandlerStatus()
+	if handlerStatus == nil {
+		return nil, fmt.Errorf("unexpected response for service event %s", eventName)
+	}
+
+	if handlerStatus.Status == "failed" {
+		return handlerStatus, fmt.Errorf(
+			"service event handler %s.%s failed: %s", serviceName, eventName, handlerStatus.Message,
+		)
+	}
+
+	s.applyEnvValues(handlerStatus.Env)
+	return handlerStatus, nil
+}
+
+// applyEnvValues sets the environment values returned by an event handler in the default environment.
+func (s *Server) applyEnvValues(values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	env, err := s.resolveEnvironment("")
+	if err != nil {
+		return
+	}
+
+	for key, value := range values {
+		env.values[key] = value
+	}
+}
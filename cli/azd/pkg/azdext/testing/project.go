@@ -0,0 +1,272 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package testing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// newProjectConfig creates the project config values from the additional properties of a project and the config of
+// its services.
+func newProjectConfig(project *azdext.ProjectConfig) config.Config {
+	projectConfig := config.NewConfig(project.AdditionalProperties.AsMap())
+
+	for name, service := range project.Services {
+		serviceConfig := service.AdditionalProperties.AsMap()
+		for key, value := range service.Config.AsMap() {
+			serviceConfig[key] = value
+		}
+
+		if len(serviceConfig) > 0 {
+			_ = projectConfig.Set(servicePath(name, ""), serviceConfig)
+		}
+	}
+
+	return projectConfig
+}
+
+// servicePath returns the path of a service config value within the project config.
+func servicePath(serviceName string, path string) string {
+	if path == "" {
+		return fmt.Sprintf("services.%s", serviceName)
+	}
+
+	return fmt.Sprintf("services.%s.%s", serviceName, path)
+}
+
+// projectService is an in-memory implementation of the azd ProjectService.
+//
+// Config values are kept separately from the core project fields returned by Get, which are not addressable by path.
+type projectService struct {
+	azdext.UnimplementedProjectServiceServer
+	server *Server
+}
+
+func (s *projectService) Get(ctx context.Context, req *azdext.EmptyRequest) (*azdext.GetProjectResponse, error) {
+	return &azdext.GetProjectResponse{Project: s.server.Project()}, nil
+}
+
+func (s *projectService) AddService(ctx context.Context, req *azdext.AddServiceRequest) (*azdext.EmptyResponse, error) {
+	if req.Service == nil || req.Service.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "service name cannot be empty")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if s.server.project.Services == nil {
+		s.server.project.Services = map[string]*azdext.ServiceConfig{}
+	}
+
+	s.server.project.Services[req.Service.Name] = proto.Clone(req.Service).(*azdext.ServiceConfig)
+	return &azdext.EmptyResponse{}, nil
+}
+
+func (s *projectService) GetResolvedServices(
+	ctx context.Context,
+	req *azdext.EmptyRequest,
+) (*azdext.GetResolvedServicesResponse, error) {
+	return &azdext.GetResolvedServicesResponse{Services: s.server.Project().Services}, nil
+}
+
+func (s *projectService) GetConfigSection(
+	ctx context.Context,
+	req *azdext.GetProjectConfigSectionRequest,
+) (*azdext.GetProjectConfigSectionResponse, error) {
+	section, found, err := s.server.getConfigSection(req.Path)
+	return &azdext.GetProjectConfigSectionResponse{Section: section, Found: found}, err
+}
+
+func (s *projectService) GetConfigValue(
+	ctx context.Context,
+	req *azdext.GetProjectConfigValueRequest,
+) (*azdext.GetProjectConfigValueResponse, error) {
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path cannot be empty")
+	}
+
+	value, found, err := s.server.getConfigValue(req.Path)
+	return &azdext.GetProjectConfigValueResponse{Value: value, Found: found}, err
+}
+
+func (s *projectService) SetConfigSection(
+	ctx context.Context,
+	req *azdext.SetProjectConfigSectionRequest,
+) (*azdext.EmptyResponse, error) {
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path cannot be empty")
+	}
+
+	return &azdext.EmptyResponse{}, s.server.setConfig(req.Path, req.Section.AsMap())
+}
+
+func (s *projectService) SetConfigValue(
+	ctx context.Context,
+	req *azdext.SetProjectConfigValueRequest,
+) (*azdext.EmptyResponse, error) {
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path cannot be empty")
+	}
+
+	return &azdext.EmptyResponse{}, s.server.setConfig(req.Path, req.Value.AsInterface())
+}
+
+func (s *projectService) UnsetConfig(
+	ctx context.Context,
+	req *azdext.UnsetProjectConfigRequest,
+) (*azdext.EmptyResponse, error) {
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path cannot be empty")
+	}
+
+	return &azdext.EmptyResponse{}, s.server.unsetConfig(req.Path)
+}
+
+func (s *projectService) GetServiceConfigSection(
+	ctx context.Context,
+	req *azdext.GetServiceConfigSectionRequest,
+) (*azdext.GetServiceConfigSectionResponse, error) {
+	if err := s.validateService(req.ServiceName); err != nil {
+		return nil, err
+	}
+
+	section, found, err := s.server.getConfigSection(servicePath(req.ServiceName, req.Path))
+	return &azdext.GetServiceConfigSectionResponse{Section: section, Found: found}, err
+}
+
+func (s *projectService) GetServiceConfigValue(
+	ctx context.Context,
+	req *azdext.GetServiceConfigValueRequest,
+) (*azdext.GetServiceConfigValueResponse, error) {
+	if err := s.validateService(req.ServiceName); err != nil {
+		return nil, err
+	}
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path cannot be empty")
+	}
+
+	value, found, err := s.server.getConfigValue(servicePath(req.ServiceName, req.Path))
+	return &azdext.GetServiceConfigValueResponse{Value: value, Found: found}, err
+}
+
+func (s *projectService) SetServiceConfigSection(
+	ctx context.Context,
+	req *azdext.SetServiceConfigSectionRequest,
+) (*azdext.EmptyResponse, error) {
+	if err := s.validateService(req.ServiceName); err != nil {
+		return nil, err
+	}
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path cannot be empty")
+	}
+
+	return &azdext.EmptyResponse{}, s.server.setConfig(servicePath(req.ServiceName, req.Path), req.Section.AsMap())
+}
+
+func (s *projectService) SetServiceConfigValue(
+	ctx context.Context,
+	req *azdext.SetServiceConfigValueRequest,
+) (*azdext.EmptyResponse, error) {
+	if err := s.validateService(req.ServiceName); err != nil {
+		return nil, err
+	}
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path cannot be empty")
+	}
+
+	return &azdext.EmptyResponse{}, s.server.setConfig(servicePath(req.ServiceName, req.Path), req.Value.AsInterface())
+}
+
+func (s *projectService) UnsetServiceConfig(
+	ctx context.Context,
+	req *azdext.UnsetServiceConfigRequest,
+) (*azdext.EmptyResponse, error) {
+	if err := s.validateService(req.ServiceName); err != nil {
+		return nil, err
+	}
+	if req.Path == "" {
+		return nil, status.Error(codes.InvalidArgument, "path cannot be empty")
+	}
+
+	return &azdext.EmptyResponse{}, s.server.unsetConfig(servicePath(req.ServiceName, req.Path))
+}
+
+func (s *projectService) validateService(serviceName string) error {
+	if serviceName == "" {
+		return status.Error(codes.InvalidArgument, "service name cannot be empty")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, has := s.server.project.Services[serviceName]; !has {
+		return status.Errorf(codes.NotFound, "service '%s' not found in project", serviceName)
+	}
+
+	return nil
+}
+
+func (s *Server) getConfigSection(path string) (*structpb.Struct, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	section, found := s.projectConfig.GetMap(path)
+	if !found {
+		return nil, false, nil
+	}
+
+	protoSection, err := structpb.NewStruct(section)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to convert section to protobuf struct: %w", err)
+	}
+
+	return protoSection, true, nil
+}
+
+func (s *Server) getConfigValue(path string) (*structpb.Value, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, found := s.projectConfig.Get(path)
+	if !found {
+		return nil, false, nil
+	}
+
+	protoValue, err := structpb.NewValue(value)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to convert value to protobuf value: %w", err)
+	}
+
+	return protoValue, true, nil
+}
+
+func (s *Server) setConfig(path string, value any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.projectConfig.Set(path, value); err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to set config value: %v", err)
+	}
+
+	return nil
+}
+
+func (s *Server) unsetConfig(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.projectConfig.Unset(path); err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to unset config value: %v", err)
+	}
+
+	return nil
+}
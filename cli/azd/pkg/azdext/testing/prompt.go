@@ -0,0 +1,216 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package testing
+
+import (
+	"context"
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// scriptedPrompt is the response to a single PromptService call.
+type scriptedPrompt struct {
+	method   string
+	response proto.Message
+	err      error
+}
+
+// Prompts scripts the responses of the PromptService.
+//
+// Responses are consumed in the order they were added. A prompt that doesn't match the next scripted response, or
+// that has no scripted response, fails with codes.FailedPrecondition.
+type Prompts struct {
+	mu        sync.Mutex
+	responses []scriptedPrompt
+}
+
+// Expect scripts the response of the next call to the specified PromptService method, for example "PromptAiModel".
+func (p *Prompts) Expect(method string, response proto.Message) *Prompts {
+	return p.add(scriptedPrompt{method: method, response: response})
+}
+
+// ExpectError scripts the next call to the specified PromptService method to fail, for example to simulate the user
+// cancelling the prompt.
+func (p *Prompts) ExpectError(method string, err error) *Prompts {
+	return p.add(scriptedPrompt{method: method, err: err})
+}
+
+// Confirm scripts the answer of the next confirmation prompt.
+func (p *Prompts) Confirm(value bool) *Prompts {
+	return p.Expect("Confirm", &azdext.ConfirmResponse{Value: &value})
+}
+
+// Prompt scripts the answer of the next text prompt.
+func (p *Prompts) Prompt(value string) *Prompts {
+	return p.Expect("Prompt", &azdext.PromptResponse{Value: value})
+}
+
+// Select scripts the index of the choice selected in the next select prompt.
+func (p *Prompts) Select(index int32) *Prompts {
+	return p.Expect("Select", &azdext.SelectResponse{Value: &index})
+}
+
+// MultiSelect scripts the values of the choices selected in the next multi-select prompt.
+func (p *Prompts) MultiSelect(values ...string) *Prompts {
+	choices := make([]*azdext.MultiSelectChoice, len(values))
+	for i, value := range values {
+		choices[i] = &azdext.MultiSelectChoice{Value: value, Label: value, Selected: true}
+	}
+
+	return p.Expect("MultiSelect", &azdext.MultiSelectResponse{Values: choices})
+}
+
+// Subscription scripts the subscription selected in the next subscription prompt.
+func (p *Prompts) Subscription(subscription *azdext.Subscription) *Prompts {
+	return p.Expect("PromptSubscription", &azdext.PromptSubscriptionResponse{Subscription: subscription})
+}
+
+// Location scripts the location selected in the next location prompt.
+func (p *Prompts) Location(location *azdext.Location) *Prompts {
+	return p.Expect("PromptLocation", &azdext.PromptLocationResponse{Location: location})
+}
+
+// ResourceGroup scripts the resource group selected in the next resource group prompt.
+func (p *Prompts) ResourceGroup(resourceGroup *azdext.ResourceGroup) *Prompts {
+	return p.Expect("PromptResourceGroup", &azdext.PromptResourceGroupResponse{ResourceGroup: resourceGroup})
+}
+
+// Remaining returns the number of scripted responses that have not been consumed.
+func (p *Prompts) Remaining() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.responses)
+}
+
+func (p *Prompts) add(prompt scriptedPrompt) *Prompts {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.responses = append(p.responses, prompt)
+	return p
+}
+
+// next consumes the next scripted response, which must be for the specified method.
+func next[T proto.Message](p *Prompts, method string) (T, error) {
+	var empty T
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.responses) == 0 {
+		return empty, status.Errorf(codes.FailedPrecondition, "no scripted response for prompt %s", method)
+	}
+
+	scripted := p.responses[0]
+	if scripted.method != method {
+		return empty, status.Errorf(
+			codes.FailedPrecondition, "unexpected prompt %s, the next scripted response is for %s", method, scripted.method,
+		)
+	}
+
+	p.responses = p.responses[1:]
+	if scripted.err != nil {
+		return empty, scripted.err
+	}
+
+	response, ok := scripted.response.(T)
+	if !ok {
+		return empty, status.Errorf(codes.FailedPrecondition, "scripted response for prompt %s has the wrong type", method)
+	}
+
+	return response, nil
+}
+
+// promptService answers PromptService calls from the prompt script.
+type promptService struct {
+	azdext.UnimplementedPromptServiceServer
+	prompts *Prompts
+}
+
+func (s *promptService) PromptSubscription(
+	ctx context.Context,
+	req *azdext.PromptSubscriptionRequest,
+) (*azdext.PromptSubscriptionResponse, error) {
+	return next[*azdext.PromptSubscriptionResponse](s.prompts, "PromptSubscription")
+}
+
+func (s *promptService) PromptLocation(
+	ctx context.Context,
+	req *azdext.PromptLocationRequest,
+) (*azdext.PromptLocationResponse, error) {
+	return next[*azdext.PromptLocationResponse](s.prompts, "PromptLocation")
+}
+
+func (s *promptService) PromptResourceGroup(
+	ctx context.Context,
+	req *azdext.PromptResourceGroupRequest,
+) (*azdext.PromptResourceGroupResponse, error) {
+	return next[*azdext.PromptResourceGroupResponse](s.prompts, "PromptResourceGroup")
+}
+
+func (s *promptService) Confirm(ctx context.Context, req *azdext.ConfirmRequest) (*azdext.ConfirmResponse, error) {
+	return next[*azdext.ConfirmResponse](s.prompts, "Confirm")
+}
+
+func (s *promptService) Prompt(ctx context.Context, req *azdext.PromptRequest) (*azdext.PromptResponse, error) {
+	return next[*azdext.PromptResponse](s.prompts, "Prompt")
+}
+
+func (s *promptService) Select(ctx context.Context, req *azdext.SelectRequest) (*azdext.SelectResponse, error) {
+	return next[*azdext.SelectResponse](s.prompts, "Select")
+}
+
+func (s *promptService) MultiSelect(
+	ctx context.Context,
+	req *azdext.MultiSelectRequest,
+) (*azdext.MultiSelectResponse, error) {
+	return next[*azdext.MultiSelectResponse](s.prompts, "MultiSelect")
+}
+
+func (s *promptService) PromptSubscriptionResource(
+	ctx context.Context,
+	req *azdext.PromptSubscriptionResourceRequest,
+) (*azdext.PromptSubscriptionResourceResponse, error) {
+	return next[*azdext.PromptSubscriptionResourceResponse](s.prompts, "PromptSubscriptionResource")
+}
+
+func (s *promptService) PromptResourceGroupResource(
+	ctx context.Context,
+	req *azdext.PromptResourceGroupResourceRequest,
+) (*azdext.PromptResourceGroupResourceResponse, error) {
+	return next[*azdext.PromptResourceGroupResourceResponse](s.prompts, "PromptResourceGroupResource")
+}
+
+func (s *promptService) PromptAiModel(
+	ctx context.Context,
+	req *azdext.PromptAiModelRequest,
+) (*azdext.PromptAiModelResponse, error) {
+	return next[*azdext.PromptAiModelResponse](s.prompts, "PromptAiModel")
+}
+
+func (s *promptService) PromptAiDeployment(
+	ctx context.Context,
+	req *azdext.PromptAiDeploymentRequest,
+) (*azdext.PromptAiDeploymentResponse, error) {
+	return next[*azdext.PromptAiDeploymentResponse](s.prompts, "PromptAiDeployment")
+}
+
+func (s *promptService) PromptAiLocationWithQuota(
+	ctx context.Context,
+	req *azdext.PromptAiLocationWithQuotaRequest,
+) (*azdext.PromptAiLocationWithQuotaResponse, error) {
+	return next[*azdext.PromptAiLocationWithQuotaResponse](s.prompts, "PromptAiLocationWithQuota")
+}
+
+func (s *promptService) PromptAiModelLocationWithQuota(
+	ctx context.Context,
+	req *azdext.PromptAiModelLocationWithQuotaRequest,
+) (*azdext.PromptAiModelLocationWithQuotaResponse, error) {
+	return next[*azdext.PromptAiModelLocationWithQuotaResponse](s.prompts, "PromptAiModelLocationWithQuota")
+}
@@ -0,0 +1,224 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package testing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/grpcbroker"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// providerRegistry tracks the providers registered by the extension on its provider streams, keyed by the host of a
+// service target or the language of a framework service.
+type providerRegistry[TMessage any] struct {
+	mu      sync.Mutex
+	brokers map[string]*grpcbroker.MessageBroker[TMessage]
+	// changed is closed and replaced whenever a provider is registered
+	changed chan struct{}
+}
+
+func newProviderRegistry[TMessage any]() *providerRegistry[TMessage] {
+	return &providerRegistry[TMessage]{
+		brokers: map[string]*grpcbroker.MessageBroker[TMessage]{},
+		changed: make(chan struct{}),
+	}
+}
+
+func (r *providerRegistry[TMessage]) register(name string, broker *grpcbroker.MessageBroker[TMessage]) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, has := r.brokers[name]; has {
+		return status.Errorf(codes.AlreadyExists, "provider %s already registered", name)
+	}
+
+	r.brokers[name] = broker
+	close(r.changed)
+	r.changed = make(chan struct{})
+
+	return nil
+}
+
+// unregister removes the providers registered on the stream of the broker.
+func (r *providerRegistry[TMessage]) unregister(broker *grpcbroker.MessageBroker[TMessage]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	maps.DeleteFunc(r.brokers, func(name string, registered *grpcbroker.MessageBroker[TMessage]) bool {
+		return registered == broker
+	})
+}
+
+func (r *providerRegistry[TMessage]) names() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Sorted(maps.Keys(r.brokers))
+}
+
+// waitFor blocks until a provider is registered with the specified name or the context is done.
+func (r *providerRegistry[TMessage]) waitFor(
+	ctx context.Context,
+	name string,
+) (*grpcbroker.MessageBroker[TMessage], error) {
+	for {
+		r.mu.Lock()
+		broker, changed := r.brokers[name], r.changed
+		r.mu.Unlock()
+
+		if broker != nil {
+			return broker, nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// serveProviderStream accepts the registrations of the providers of a stream until the stream is closed.
+func serveProviderStream[TMessage any, TRequest any](
+	stream grpc.BidiStreamingServer[TMessage, TMessage],
+	envelope grpcbroker.MessageEnvelope[TMessage],
+	registry *providerRegistry[TMessage],
+	register func(req *TRequest) (string, *TMessage),
+) error {
+	broker := grpcbroker.NewMessageBroker(stream, envelope, "azd", nil)
+	defer registry.unregister(broker)
+
+	if err := broker.On(func(ctx context.Context, req *TRequest) (*TMessage, error) {
+		name, response := register(req)
+		if err := registry.register(name, broker); err != nil {
+			return nil, err
+		}
+
+		return response, nil
+	}); err != nil {
+		return err
+	}
+
+	if err := broker.Run(stream.Context()); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
+
+// serviceTargetService accepts the service target providers registered by the extension.
+// Unlike azd, it doesn't require the extension to declare the service-target-provider capability.
+type serviceTargetService struct {
+	azdext.UnimplementedServiceTargetServiceServer
+	server *Server
+}
+
+func (s *serviceTargetService) Stream(
+	stream grpc.BidiStreamingServer[azdext.ServiceTargetMessage, azdext.ServiceTargetMessage],
+) error {
+	return serveProviderStream(
+		stream,
+		azdext.NewServiceTargetEnvelope(),
+		s.server.serviceTargets,
+		func(req *azdext.RegisterServiceTargetRequest) (string, *azdext.ServiceTargetMessage) {
+			return req.Host, &azdext.ServiceTargetMessage{
+				MessageType: &azdext.ServiceTargetMessage_RegisterServiceTargetResponse{
+					RegisterServiceTargetResponse: &azdext.RegisterServiceTargetResponse{},
+				},
+			}
+		},
+	)
+}
+
+// frameworkService accepts the framework service providers registered by the extension.
+// Unlike azd, it doesn't require the extension to declare the framework-service-provider capability.
+type frameworkService struct {
+	azdext.UnimplementedFrameworkServiceServer
+	server *Server
+}
+
+func (s *frameworkService) Stream(
+	stream grpc.BidiStreamingServer[azdext.FrameworkServiceMessage, azdext.FrameworkServiceMessage],
+) error {
+	return serveProviderStream(
+		stream,
+		azdext.NewFrameworkServiceEnvelope(),
+		s.server.frameworkServices,
+		func(req *azdext.RegisterFrameworkServiceRequest) (string, *azdext.FrameworkServiceMessage) {
+			return req.Language, &azdext.FrameworkServiceMessage{
+				MessageType: &azdext.FrameworkServiceMessage_RegisterFrameworkServiceResponse{
+					RegisterFrameworkServiceResponse: &azdext.RegisterFrameworkServiceResponse{},
+				},
+			}
+		},
+	)
+}
+
+// ServiceTargets returns the hosts of the service target providers registered by the extension.
+func (s *Server) ServiceTargets() []string {
+	return s.serviceTargets.names()
+}
+
+// FrameworkServices returns the languages of the framework service providers registered by the extension.
+func (s *Server) FrameworkServices() []string {
+	return s.frameworkServices.names()
+}
+
+// InvokeServiceTarget sends a request to the service target provider the extension registered for the specified host,
+// as azd does when it packages, publishes or deploys a service of that host, and waits until the provider responds or
+// the context is done. Progress reported by the provider is passed to onProgress, which may be nil.
+// As in azd, progress received after the response is dropped.
+//
+// A request id is generated when the message doesn't have one. An error is returned when the provider fails.
+func (s *Server) InvokeServiceTarget(
+	ctx context.Context,
+	host string,
+	message *azdext.ServiceTargetMessage,
+	onProgress func(message string),
+) (*azdext.ServiceTargetMessage, error) {
+	broker, err := s.serviceTargets.waitFor(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("extension did not register a service target for host %s: %w", host, err)
+	}
+
+	if message.RequestId == "" {
+		message.RequestId = uuid.NewString()
+	}
+
+	return broker.SendAndWaitWithProgress(s.Context(ctx), message, onProgress)
+}
+
+// InvokeFrameworkService sends a request to the framework service provider the extension registered for the specified
+// language, as azd does when it restores, builds or packages a service of that language, and waits until the provider
+// responds or the context is done. Progress reported by the provider is passed to onProgress, which may be nil.
+// As in azd, progress received after the response is dropped.
+//
+// A request id is generated when the message doesn't have one. An error is returned when the provider fails.
+func (s *Server) InvokeFrameworkService(
+	ctx context.Context,
+	language string,
+	message *azdext.FrameworkServiceMessage,
+	onProgress func(message string),
+) (*azdext.FrameworkServiceMessage, error) {
+	broker, err := s.frameworkServices.waitFor(ctx, language)
+	if err != nil {
+		return nil, fmt.Errorf("extension did not register a framework service for language %s: %w", language, err)
+	}
+
+	if message.RequestId == "" {
+		message.RequestId = uuid.NewString()
+	}
+
+	return broker.SendAndWaitWithProgress(s.Context(ctx), message, onProgress)
+}
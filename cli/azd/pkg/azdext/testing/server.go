@@ -0,0 +1,539 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+// Package testing provides an in-process fake of the azd gRPC server, so extensions built on azdext can be tested
+// end-to-end without azd, network access or Azure.
+//
+// The fake server keeps projects, environments and user config in memory, replays scripted prompt responses, records
+// every call made by the extension and can invoke the lifecycle event handlers registered by an ExtensionHost.
+package testing
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/extensions"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultExtensionId is the extension id used in the access token when WithExtensionId is not specified.
+const DefaultExtensionId = "test.extension"
+
+// Option configures the state of a fake azd server.
+type Option func(s *Server)
+
+// WithExtensionId sets the id of the extension under test, which azd includes in the extension access token.
+func WithExtensionId(id string) Option {
+	return func(s *Server) {
+		s.extensionId = id
+	}
+}
+
+// WithProject seeds the project returned by the ProjectService. The additional properties of the project and the
+// config of its services seed the project config paths.
+func WithProject(project *azdext.ProjectConfig) Option {
+	return func(s *Server) {
+		s.project = proto.Clone(project).(*azdext.ProjectConfig)
+		s.projectConfig = newProjectConfig(s.project)
+	}
+}
+
+// WithEnvironment seeds an environment with the specified values.
+// The first seeded environment is the default environment.
+func WithEnvironment(name string, values map[string]string) Option {
+	return func(s *Server) {
+		s.addEnvironment(name, values, nil)
+	}
+}
+
+// WithEnvironmentConfig seeds an environment with the specified values and environment config.
+// The first seeded environment is the default environment.
+func WithEnvironmentConfig(name string, values map[string]string, envConfig map[string]any) Option {
+	return func(s *Server) {
+		s.addEnvironment(name, values, envConfig)
+	}
+}
+
+// WithUserConfig seeds the user config returned by the UserConfigService.
+func WithUserConfig(userConfig map[string]any) Option {
+	return func(s *Server) {
+		s.userConfig = config.NewConfig(cloneMap(userConfig))
+	}
+}
+
+// WithDeployment seeds the deployment returned by the DeploymentService.
+func WithDeployment(deployment *azdext.Deployment, azureContext *azdext.AzureContext) Option {
+	return func(s *Server) {
+		s.deployment = deployment
+		s.azureContext = azureContext
+	}
+}
+
+// WithSubscriptions seeds the subscriptions returned by the AccountService.
+func WithSubscriptions(subscriptions ...*azdext.Subscription) Option {
+	return func(s *Server) {
+		s.subscriptions = subscriptions
+	}
+}
+
+// WithComposeResources seeds the composability resources returned by the ComposeService.
+func WithComposeResources(resources ...*azdext.ComposedResource) Option {
+	return func(s *Server) {
+		for _, resource := range resources {
+			s.composeResources[resource.Name] = proto.Clone(resource).(*azdext.ComposedResource)
+		}
+	}
+}
+
+// WithComposeResourceTypes seeds the composability resource types returned by the ComposeService.
+func WithComposeResourceTypes(resourceTypes ...*azdext.ComposedResourceType) Option {
+	return func(s *Server) {
+		s.composeResourceTypes = append(s.composeResourceTypes, resourceTypes...)
+	}
+}
+
+// WithAiModels seeds the AI models of the model catalog used by the AiModelService.
+func WithAiModels(models ...*azdext.AiModel) Option {
+	return func(s *Server) {
+		s.aiModels = append(s.aiModels, models...)
+	}
+}
+
+// WithAiModelUsages seeds the quota usages of a location used by the AiModelService.
+func WithAiModelUsages(location string, usages ...*azdext.AiModelUsage) Option {
+	return func(s *Server) {
+		s.aiUsages[location] = append(s.aiUsages[location], usages...)
+	}
+}
+
+// Call is a call made by the extension to the fake azd server.
+type Call struct {
+	// Method is the name of the called method, for example "EnvironmentService/SetValue"
+	Method string
+	// Request is the request message. It is nil for streaming calls.
+	Request proto.Message
+}
+
+// Server is an in-process fake of the azd gRPC server.
+//
+// Services that need docker, Azure or the azd command line are faked: workflows are recorded without being run,
+// containers are neither built nor pushed, AI models and quota are resolved from the seeded models and usages, and
+// service target and framework service providers are registered so they can be invoked with InvokeServiceTarget and
+// InvokeFrameworkService.
+type Server struct {
+	extensionId string
+	signingKey  []byte
+	accessToken string
+	listener    net.Listener
+	grpcServer  *grpc.Server
+	prompts     *Prompts
+	ready       chan struct{}
+	readyOnce   sync.Once
+
+	mu                   sync.Mutex
+	calls                []Call
+	project              *azdext.ProjectConfig
+	projectConfig        config.Config
+	environments         []*environmentState
+	defaultEnvironment   string
+	userConfig           config.Config
+	deployment           *azdext.Deployment
+	azureContext         *azdext.AzureContext
+	subscriptions        []*azdext.Subscription
+	composeResources     map[string]*azdext.ComposedResource
+	composeResourceTypes []*azdext.ComposedResourceType
+	aiModels             []*azdext.AiModel
+	aiUsages             map[string][]*azdext.AiModelUsage
+	events               *eventStream
+	serviceTargets       *providerRegistry[azdext.ServiceTargetMessage]
+	frameworkServices    *providerRegistry[azdext.FrameworkServiceMessage]
+}
+
+// NewServer starts a fake azd server listening on a random local port.
+func NewServer(options ...Option) (*Server, error) {
+	signingKey := make([]byte, 32)
+	if _, err := rand.Read(signingKey); err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	s := &Server{
+		extensionId:       DefaultExtensionId,
+		signingKey:        signingKey,
+		prompts:           &Prompts{},
+		ready:             make(chan struct{}),
+		project:           &azdext.ProjectConfig{Name: "test-project", Services: map[string]*azdext.ServiceConfig{}},
+		projectConfig:     config.NewEmptyConfig(),
+		userConfig:        config.NewEmptyConfig(),
+		composeResources:  map[string]*azdext.ComposedResource{},
+		aiUsages:          map[string][]*azdext.AiModelUsage{},
+		events:            newEventStream(),
+		serviceTargets:    newProviderRegistry[azdext.ServiceTargetMessage](),
+		frameworkServices: newProviderRegistry[azdext.FrameworkServiceMessage](),
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	s.listener = listener
+	s.accessToken, err = s.newAccessToken()
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	s.grpcServer = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(s.recordUnary),
+		grpc.ChainStreamInterceptor(s.recordStream),
+	)
+
+	azdext.RegisterProjectServiceServer(s.grpcServer, &projectService{server: s})
+	azdext.RegisterEnvironmentServiceServer(s.grpcServer, &environmentService{server: s})
+	azdext.RegisterUserConfigServiceServer(s.grpcServer, &userConfigService{server: s})
+	azdext.RegisterPromptServiceServer(s.grpcServer, &promptService{prompts: s.prompts})
+	azdext.RegisterEventServiceServer(s.grpcServer, &eventService{server: s})
+	azdext.RegisterExtensionServiceServer(s.grpcServer, &extensionService{server: s})
+	azdext.RegisterDeploymentServiceServer(s.grpcServer, &deploymentService{server: s})
+	azdext.RegisterAccountServiceServer(s.grpcServer, &accountService{server: s})
+	azdext.RegisterComposeServiceServer(s.grpcServer, &composeService{server: s})
+	azdext.RegisterWorkflowServiceServer(s.grpcServer, &workflowService{})
+	azdext.RegisterContainerServiceServer(s.grpcServer, &containerService{server: s})
+	azdext.RegisterAiModelServiceServer(s.grpcServer, &aiModelService{server: s})
+	azdext.RegisterServiceTargetServiceServer(s.grpcServer, &serviceTargetService{server: s})
+	azdext.RegisterFrameworkServiceServer(s.grpcServer, &frameworkService{server: s})
+
+	go func() {
+		_ = s.grpcServer.Serve(listener)
+	}()
+
+	return s, nil
+}
+
+// Start starts a fake azd server that is stopped when the test completes.
+//
+// AZD_SERVER and AZD_ACCESS_TOKEN are set for the duration of the test, so extension code that creates its client
+// with azdext.NewAzdClient() and azdext.WithAccessToken(ctx) connects to the fake server.
+func Start(t testing.TB, options ...Option) *Server {
+	t.Helper()
+
+	server, err := NewServer(options...)
+	if err != nil {
+		t.Fatalf("failed to start fake azd server: %v", err)
+	}
+
+	t.Cleanup(server.Close)
+	t.Setenv("AZD_SERVER", server.Address())
+	t.Setenv("AZD_ACCESS_TOKEN", server.AccessToken())
+
+	return server
+}
+
+// Close stops the server and closes all open streams.
+func (s *Server) Close() {
+	s.grpcServer.Stop()
+}
+
+// Address returns the address the server listens on.
+func (s *Server) Address() string {
+	return s.listener.Addr().String()
+}
+
+// AccessToken returns the access token azd would pass to the extension under test.
+func (s *Server) AccessToken() string {
+	return s.accessToken
+}
+
+// Context returns a context carrying the access token of the extension under test.
+func (s *Server) Context(ctx context.Context) context.Context {
+	return azdext.WithAccessToken(ctx, s.accessToken)
+}
+
+// NewClient creates an azd client connected to the server.
+func (s *Server) NewClient() (*azdext.AzdClient, error) {
+	return azdext.NewAzdClient(azdext.WithAddress(s.Address()))
+}
+
+// Prompts returns the prompt script used to answer PromptService calls.
+func (s *Server) Prompts() *Prompts {
+	return s.prompts
+}
+
+// WaitUntilReady blocks until the extension signals readiness or the context is done.
+func (s *Server) WaitUntilReady(ctx context.Context) error {
+	select {
+	case <-s.ready:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("extension did not signal readiness: %w", ctx.Err())
+	}
+}
+
+// Calls returns all calls made to the server so far.
+func (s *Server) Calls() []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calls := make([]Call, len(s.calls))
+	copy(calls, s.calls)
+
+	return calls
+}
+
+// CallsTo returns the calls made to the specified method, for example "PromptService/Confirm".
+func (s *Server) CallsTo(method string) []Call {
+	var calls []Call
+	for _, call := range s.Calls() {
+		if call.Method == method {
+			calls = append(calls, call)
+		}
+	}
+
+	return calls
+}
+
+// Project returns a copy of the current project.
+func (s *Server) Project() *azdext.ProjectConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return proto.Clone(s.project).(*azdext.ProjectConfig)
+}
+
+// ProjectConfig returns a copy of the project config values.
+func (s *Server) ProjectConfig() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return cloneMap(s.projectConfig.Raw())
+}
+
+// Environment returns a copy of the values of the specified environment.
+// An empty name returns the values of the default environment.
+func (s *Server) Environment(name string) map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	env, err := s.resolveEnvironment(name)
+	if err != nil {
+		return nil
+	}
+
+	values := make(map[string]string, len(env.values))
+	for key, value := range env.values {
+		values[key] = value
+	}
+
+	return values
+}
+
+// DefaultEnvironment returns the name of the default environment.
+func (s *Server) DefaultEnvironment() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.defaultEnvironment
+}
+
+// UserConfig returns a copy of the user config.
+func (s *Server) UserConfig() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return cloneMap(s.userConfig.Raw())
+}
+
+func (s *Server) newAccessToken() (string, error) {
+	claims := extensions.ExtensionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "azd",
+			Subject:   s.extensionId,
+			Audience:  []string{s.Address()},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(s.signingKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to create access token: %w", err)
+	}
+
+	return token, nil
+}
+
+func (s *Server) record(fullMethod string, request any) {
+	call := Call{Method: strings.TrimPrefix(fullMethod, "/azdext.")}
+	if message, ok := request.(proto.Message); ok {
+		call.Request = proto.Clone(message)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.calls = append(s.calls, call)
+}
+
+func (s *Server) recordUnary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	s.record(info.FullMethod, req)
+	return handler(ctx, req)
+}
+
+func (s *Server) recordStream(
+	srv any,
+	stream grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	s.record(info.FullMethod, nil)
+	return handler(srv, stream)
+}
+
+// extensionService records readiness of the extension under test.
+type extensionService struct {
+	azdext.UnimplementedExtensionServiceServer
+	server *Server
+}
+
+func (s *extensionService) Ready(ctx context.Context, req *azdext.ReadyRequest) (*azdext.ReadyResponse, error) {
+	s.server.readyOnce.Do(func() {
+		close(s.server.ready)
+	})
+
+	return &azdext.ReadyResponse{}, nil
+}
+
+func (s *extensionService) ReportError(
+	ctx context.Context,
+	req *azdext.ReportErrorRequest,
+) (*azdext.ReportErrorResponse, error) {
+	return &azdext.ReportErrorResponse{}, nil
+}
+
+// deploymentService returns the seeded deployment.
+type deploymentService struct {
+	azdext.UnimplementedDeploymentServiceServer
+	server *Server
+}
+
+func (s *deploymentService) GetDeployment(
+	ctx context.Context,
+	req *azdext.EmptyRequest,
+) (*azdext.GetDeploymentResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if s.server.deployment == nil {
+		return nil, status.Error(codes.NotFound, "no deployment has been seeded")
+	}
+
+	return &azdext.GetDeploymentResponse{Deployment: s.server.deployment}, nil
+}
+
+func (s *deploymentService) GetDeploymentContext(
+	ctx context.Context,
+	req *azdext.EmptyRequest,
+) (*azdext.GetDeploymentContextResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if s.server.azureContext == nil {
+		return nil, status.Error(codes.NotFound, "no deployment context has been seeded")
+	}
+
+	return &azdext.GetDeploymentContextResponse{AzureContext: s.server.azureContext}, nil
+}
+
+// accountService returns the seeded subscriptions.
+type accountService struct {
+	azdext.UnimplementedAccountServiceServer
+	server *Server
+}
+
+func (s *accountService) ListSubscriptions(
+	ctx context.Context,
+	req *azdext.ListSubscriptionsRequest,
+) (*azdext.ListSubscriptionsResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	subscriptions := []*azdext.Subscription{}
+	for _, subscription := range s.server.subscriptions {
+		if req.TenantId == nil || subscription.TenantId == *req.TenantId {
+			subscriptions = append(subscriptions, subscription)
+		}
+	}
+
+	return &azdext.ListSubscriptionsResponse{Subscriptions: subscriptions}, nil
+}
+
+func (s *accountService) LookupTenant(
+	ctx context.Context,
+	req *azdext.LookupTenantRequest,
+) (*azdext.LookupTenantResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	for _, subscription := range s.server.subscriptions {
+		if subscription.Id == req.SubscriptionId {
+			return &azdext.LookupTenantResponse{TenantId: subscription.TenantId}, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "subscription '%s' not found", req.SubscriptionId)
+}
+
+// workflowService validates the workflows run by the extension without running them. The workflows are available
+// through the recorded calls to "WorkflowService/Run".
+type workflowService struct {
+	azdext.UnimplementedWorkflowServiceServer
+}
+
+func (s *workflowService) Run(ctx context.Context, req *azdext.RunWorkflowRequest) (*azdext.EmptyResponse, error) {
+	if req.Workflow == nil || len(req.Workflow.Steps) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "workflow is empty")
+	}
+
+	for _, step := range req.Workflow.Steps {
+		if step.Command == nil || len(step.Command.Args) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "step command is empty")
+		}
+	}
+
+	return &azdext.EmptyResponse{}, nil
+}
+
+// cloneMap returns a deep copy of a config map.
+func cloneMap(source map[string]any) map[string]any {
+	clone := make(map[string]any, len(source))
+	for key, value := range source {
+		if nested, ok := value.(map[string]any); ok {
+			value = cloneMap(nested)
+		}
+
+		clone[key] = value
+	}
+
+	return clone
+}
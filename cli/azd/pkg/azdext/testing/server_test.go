@@ -0,0 +1,460 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package testing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func newTestProject(t *testing.T) *azdext.ProjectConfig {
+	serviceConfig, err := structpb.NewStruct(map[string]any{"replicas": 2})
+	require.NoError(t, err)
+
+	return &azdext.ProjectConfig{
+		Name: "todo",
+		Services: map[string]*azdext.ServiceConfig{
+			"api": {Name: "api", Host: "containerapp", Language: "python", Config: serviceConfig},
+			"web": {Name: "web", Host: "staticwebapp", Language: "js"},
+		},
+	}
+}
+
+func Test_Server_ExtensionHost(t *testing.T) {
+	server := Start(t, WithProject(newTestProject(t)), WithEnvironment("dev", map[string]string{"LOCATION": "eastus"}))
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	var packagedServices []string
+	host := azdext.NewExtensionHost(client).
+		WithProjectEventResultHandler("preprovision", func(
+			ctx context.Context,
+			args *azdext.ProjectEventArgs,
+		) (*azdext.ProjectEventResult, error) {
+			location, err := client.Environment().GetValue(ctx, &azdext.GetEnvRequest{Key: "LOCATION"})
+			if err != nil {
+				return nil, err
+			}
+
+			return &azdext.ProjectEventResult{
+				Env: map[string]string{"PROJECT_LOCATION": args.Project.Name + "-" + location.Value},
+			}, nil
+		}, nil).
+		WithServiceEventHandler("prepackage", func(ctx context.Context, args *azdext.ServiceEventArgs) error {
+			packagedServices = append(packagedServices, args.Service.Name)
+			return nil
+		}, &azdext.ServiceEventOptions{Host: "containerapp"}).
+		WithProjectEventHandler("predeploy", func(ctx context.Context, args *azdext.ProjectEventArgs) error {
+			return errors.New("deployment is blocked")
+		})
+
+	ctx, cancel := context.WithTimeout(server.Context(t.Context()), 10*time.Second)
+	defer cancel()
+
+	hostErr := make(chan error, 1)
+	go func() {
+		hostErr <- host.Run(ctx)
+	}()
+
+	require.NoError(t, server.WaitUntilReady(ctx))
+
+	handlerStatus, err := server.InvokeProjectEvent(ctx, "preprovision")
+	require.NoError(t, err)
+	require.Equal(t, "completed", handlerStatus.Status)
+	require.Equal(t, "todo-eastus", server.Environment("dev")["PROJECT_LOCATION"])
+
+	_, err = server.InvokeServiceEvent(ctx, "prepackage", "api", nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"api"}, packagedServices)
+
+	// The subscription is filtered by host, so the web service has no handler
+	shortCtx, shortCancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer shortCancel()
+	_, err = server.InvokeServiceEvent(shortCtx, "prepackage", "web", nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = server.InvokeProjectEvent(ctx, "predeploy")
+	require.ErrorContains(t, err, "deployment is blocked")
+
+	require.Len(t, server.CallsTo("ExtensionService/Ready"), 1)
+	require.Len(t, server.CallsTo("EnvironmentService/GetValue"), 1)
+
+	cancel()
+	require.NoError(t, <-hostErr)
+}
+
+func Test_Server_EnvironmentService(t *testing.T) {
+	server := Start(t,
+		WithEnvironment("dev", map[string]string{"KEY": "dev-value"}),
+		WithEnvironmentConfig("prod", nil, map[string]any{"infra": map[string]any{"sku": "premium"}}),
+	)
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := server.Context(t.Context())
+
+	current, err := client.Environment().GetCurrent(ctx, &azdext.EmptyRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "dev", current.Environment.Name)
+
+	_, err = client.Environment().SetValue(ctx, &azdext.SetEnvRequest{Key: "NEW_KEY", Value: "new-value"})
+	require.NoError(t, err)
+	require.Equal(t, "new-value", server.Environment("dev")["NEW_KEY"])
+
+	configValue, err := client.Environment().GetConfigString(ctx, &azdext.GetConfigStringRequest{
+		EnvName: "prod",
+		Path:    "infra.sku",
+	})
+	require.NoError(t, err)
+	require.True(t, configValue.Found)
+	require.Equal(t, "premium", configValue.Value)
+
+	_, err = client.Environment().Select(ctx, &azdext.SelectEnvironmentRequest{Name: "prod"})
+	require.NoError(t, err)
+	require.Equal(t, "prod", server.DefaultEnvironment())
+
+	_, err = client.Environment().Get(ctx, &azdext.GetEnvironmentRequest{Name: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_Server_ProjectService(t *testing.T) {
+	server := Start(t, WithProject(newTestProject(t)))
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := server.Context(t.Context())
+
+	replicas, err := client.Project().GetServiceConfigValue(ctx, &azdext.GetServiceConfigValueRequest{
+		ServiceName: "api",
+		Path:        "replicas",
+	})
+	require.NoError(t, err)
+	require.True(t, replicas.Found)
+	require.Equal(t, float64(2), replicas.Value.GetNumberValue())
+
+	_, err = client.Project().SetConfigValue(ctx, &azdext.SetProjectConfigValueRequest{
+		Path:  "myext.enabled",
+		Value: structpb.NewBoolValue(true),
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"enabled": true}, server.ProjectConfig()["myext"])
+
+	_, err = client.Project().AddService(ctx, &azdext.AddServiceRequest{
+		Service: &azdext.ServiceConfig{Name: "worker", Host: "containerapp"},
+	})
+	require.NoError(t, err)
+	require.Contains(t, server.Project().Services, "worker")
+
+	_, err = client.Project().GetServiceConfigValue(ctx, &azdext.GetServiceConfigValueRequest{
+		ServiceName: "missing",
+		Path:        "replicas",
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func Test_Server_UserConfigService(t *testing.T) {
+	server := Start(t, WithUserConfig(map[string]any{"defaults": map[string]any{"location": "westus"}}))
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := server.Context(t.Context())
+
+	location, err := client.UserConfig().GetString(ctx, &azdext.GetUserConfigStringRequest{Path: "defaults.location"})
+	require.NoError(t, err)
+	require.Equal(t, "westus", location.Value)
+
+	_, err = client.UserConfig().Set(ctx, &azdext.SetUserConfigRequest{Path: "myext.theme", Value: []byte(`"dark"`)})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"theme": "dark"}, server.UserConfig()["myext"])
+}
+
+func Test_Server_Prompts(t *testing.T) {
+	server := Start(t)
+	server.Prompts().
+		Confirm(true).
+		Select(1).
+		Subscription(&azdext.Subscription{Id: "sub-1", TenantId: "tenant-1"}).
+		ExpectError("Prompt", status.Error(codes.Canceled, "cancelled by user"))
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := server.Context(t.Context())
+
+	confirm, err := client.Prompt().Confirm(ctx, &azdext.ConfirmRequest{Options: &azdext.ConfirmOptions{}})
+	require.NoError(t, err)
+	require.True(t, *confirm.Value)
+
+	// Prompts must be called in the scripted order
+	_, err = client.Prompt().Confirm(ctx, &azdext.ConfirmRequest{Options: &azdext.ConfirmOptions{}})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	selected, err := client.Prompt().Select(ctx, &azdext.SelectRequest{Options: &azdext.SelectOptions{}})
+	require.NoError(t, err)
+	require.Equal(t, int32(1), *selected.Value)
+
+	subscription, err := client.Prompt().PromptSubscription(ctx, &azdext.PromptSubscriptionRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "sub-1", subscription.Subscription.Id)
+
+	_, err = client.Prompt().Prompt(ctx, &azdext.PromptRequest{Options: &azdext.PromptOptions{}})
+	require.Equal(t, codes.Canceled, status.Code(err))
+	require.Equal(t, 0, server.Prompts().Remaining())
+
+	_, err = client.Prompt().Prompt(ctx, &azdext.PromptRequest{Options: &azdext.PromptOptions{}})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	require.Len(t, server.CallsTo("PromptService/Confirm"), 2)
+}
+
+func Test_Server_ComposeService(t *testing.T) {
+	server := Start(t,
+		WithComposeResources(&azdext.ComposedResource{Name: "db", Type: "db.postgres"}),
+		WithComposeResourceTypes(&azdext.ComposedResourceType{Name: "db.postgres", DisplayName: "PostgreSQL"}),
+	)
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := server.Context(t.Context())
+
+	_, err = client.Compose().AddResource(ctx, &azdext.AddResourceRequest{
+		Resource: &azdext.ComposedResource{Name: "cache", Type: "db.redis"},
+	})
+	require.NoError(t, err)
+
+	resources, err := client.Compose().ListResources(ctx, &azdext.EmptyRequest{})
+	require.NoError(t, err)
+	require.Len(t, resources.Resources, 2)
+	require.Equal(t, "cache", server.ComposeResources()[0].Name)
+
+	_, err = client.Compose().GetResource(ctx, &azdext.GetResourceRequest{Name: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	resourceType, err := client.Compose().GetResourceType(ctx, &azdext.GetResourceTypeRequest{TypeName: "db.postgres"})
+	require.NoError(t, err)
+	require.Equal(t, "PostgreSQL", resourceType.ResourceType.DisplayName)
+}
+
+func Test_Server_WorkflowService(t *testing.T) {
+	server := Start(t)
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := server.Context(t.Context())
+
+	_, err = client.Workflow().Run(ctx, &azdext.RunWorkflowRequest{
+		Workflow: &azdext.Workflow{
+			Name:  "up",
+			Steps: []*azdext.WorkflowStep{{Command: &azdext.WorkflowCommand{Args: []string{"provision"}}}},
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = client.Workflow().Run(ctx, &azdext.RunWorkflowRequest{Workflow: &azdext.Workflow{Name: "empty"}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Workflows are recorded without being run
+	runs := server.CallsTo("WorkflowService/Run")
+	require.Len(t, runs, 2)
+	require.Equal(t, "up", runs[0].Request.(*azdext.RunWorkflowRequest).Workflow.Name)
+}
+
+func Test_Server_ContainerService(t *testing.T) {
+	server := Start(t, WithProject(newTestProject(t)), WithEnvironment("dev", nil))
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := server.Context(t.Context())
+
+	packaged, err := client.Container().Package(ctx, &azdext.ContainerPackageRequest{ServiceName: "api"})
+	require.NoError(t, err)
+	require.Equal(t, "todo/api-dev:azd-deploy-0", packaged.Result.Artifacts[0].Location)
+
+	_, err = client.Container().Build(ctx, &azdext.ContainerBuildRequest{ServiceName: "missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// The registry is read from the environment, as azd does
+	_, err = client.Container().Publish(ctx, &azdext.ContainerPublishRequest{ServiceName: "api"})
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = client.Environment().SetValue(ctx, &azdext.SetEnvRequest{
+		Key:   "AZURE_CONTAINER_REGISTRY_ENDPOINT",
+		Value: "myregistry.azurecr.io",
+	})
+	require.NoError(t, err)
+
+	published, err := client.Container().Publish(ctx, &azdext.ContainerPublishRequest{ServiceName: "api"})
+	require.NoError(t, err)
+	require.Equal(t, "myregistry.azurecr.io/todo/api-dev:azd-deploy-0", published.Result.Artifacts[0].Location)
+	require.Equal(t, azdext.LocationKind_LOCATION_KIND_REMOTE, published.Result.Artifacts[0].LocationKind)
+}
+
+func Test_Server_AiModelService(t *testing.T) {
+	gpt := &azdext.AiModel{
+		Name:         "gpt-4o",
+		Format:       "OpenAI",
+		Capabilities: []string{"chat"},
+		Locations:    []string{"eastus", "westus"},
+		Versions: []*azdext.AiModelVersion{
+			{
+				Version:   "2024-11-20",
+				IsDefault: true,
+				Skus: []*azdext.AiModelSku{
+					{Name: "GlobalStandard", UsageName: "OpenAI.GlobalStandard.gpt-4o", DefaultCapacity: 10},
+					{Name: "Standard", UsageName: "OpenAI.Standard.gpt-4o", DefaultCapacity: 10},
+				},
+			},
+		},
+	}
+	embeddings := &azdext.AiModel{Name: "text-embedding-3-small", Format: "OpenAI", Capabilities: []string{"embeddings"}}
+
+	server := Start(t,
+		WithAiModels(gpt, embeddings),
+		WithAiModelUsages("eastus",
+			&azdext.AiModelUsage{Name: "OpenAI.GlobalStandard.gpt-4o", CurrentValue: 95, Limit: 100},
+			&azdext.AiModelUsage{Name: "OpenAI.Standard.gpt-4o", CurrentValue: 0, Limit: 50},
+		),
+		WithAiModelUsages("westus", &azdext.AiModelUsage{Name: "OpenAI.Standard.gpt-4o", CurrentValue: 50, Limit: 50}),
+	)
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx := server.Context(t.Context())
+	azureContext := &azdext.AzureContext{Scope: &azdext.AzureScope{SubscriptionId: "sub-1"}}
+
+	models, err := client.Ai().ListModels(ctx, &azdext.ListModelsRequest{
+		AzureContext: azureContext,
+		Filter:       &azdext.AiModelFilterOptions{Capabilities: []string{"chat"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, models.Models, 1)
+	require.Equal(t, "gpt-4o", models.Models[0].Name)
+
+	// Only the sku with enough remaining quota for its capacity is resolved
+	deployments, err := client.Ai().ResolveModelDeployments(ctx, &azdext.ResolveModelDeploymentsRequest{
+		AzureContext: azureContext,
+		ModelName:    "gpt-4o",
+		Options:      &azdext.AiModelDeploymentOptions{Locations: []string{"eastus"}},
+		Quota:        &azdext.QuotaCheckOptions{},
+	})
+	require.NoError(t, err)
+	require.Len(t, deployments.Deployments, 1)
+	require.Equal(t, "Standard", deployments.Deployments[0].Sku.Name)
+	require.Equal(t, "eastus", deployments.Deployments[0].Location)
+	require.Equal(t, float64(50), *deployments.Deployments[0].RemainingQuota)
+
+	locations, err := client.Ai().ListModelLocationsWithQuota(ctx, &azdext.ListModelLocationsWithQuotaRequest{
+		AzureContext: azureContext,
+		ModelName:    "gpt-4o",
+	})
+	require.NoError(t, err)
+	require.Len(t, locations.Locations, 1)
+	require.Equal(t, "eastus", locations.Locations[0].Location.Name)
+
+	_, err = client.Ai().ResolveModelDeployments(ctx, &azdext.ResolveModelDeploymentsRequest{
+		AzureContext: azureContext,
+		ModelName:    "missing",
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.Ai().ListUsages(ctx, &azdext.ListUsagesRequest{Location: "eastus"})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+// testServiceTargetProvider deploys services by returning the endpoint of the service.
+type testServiceTargetProvider struct {
+	azdext.BaseServiceTargetProvider
+}
+
+func (p *testServiceTargetProvider) Deploy(
+	ctx context.Context,
+	serviceConfig *azdext.ServiceConfig,
+	serviceContext *azdext.ServiceContext,
+	targetResource *azdext.TargetResource,
+	progress azdext.ProgressReporter,
+) (*azdext.ServiceDeployResult, error) {
+	progress("Deploying " + serviceConfig.Name)
+
+	return &azdext.ServiceDeployResult{
+		Artifacts: []*azdext.Artifact{
+			{
+				Kind:         azdext.ArtifactKind_ARTIFACT_KIND_ENDPOINT,
+				Location:     "https://" + serviceConfig.Name + ".example.com",
+				LocationKind: azdext.LocationKind_LOCATION_KIND_REMOTE,
+			},
+		},
+	}, nil
+}
+
+func Test_Server_ServiceTargetProvider(t *testing.T) {
+	server := Start(t, WithProject(&azdext.ProjectConfig{
+		Name:     "todo",
+		Services: map[string]*azdext.ServiceConfig{"api": {Name: "api", Host: "example", Language: "go"}},
+	}))
+
+	client, err := server.NewClient()
+	require.NoError(t, err)
+	defer client.Close()
+
+	host := azdext.NewExtensionHost(client).
+		WithServiceTarget("example", func() azdext.ServiceTargetProvider {
+			return &testServiceTargetProvider{}
+		})
+
+	ctx, cancel := context.WithTimeout(server.Context(t.Context()), 10*time.Second)
+	defer cancel()
+
+	hostErr := make(chan error, 1)
+	go func() {
+		hostErr <- host.Run(ctx)
+	}()
+
+	require.NoError(t, server.WaitUntilReady(ctx))
+	require.Equal(t, []string{"example"}, server.ServiceTargets())
+
+	// Invoke the provider the same way azd does during `azd deploy`
+	serviceConfig := server.Project().Services["api"]
+	_, err = server.InvokeServiceTarget(ctx, "example", &azdext.ServiceTargetMessage{
+		MessageType: &azdext.ServiceTargetMessage_InitializeRequest{
+			InitializeRequest: &azdext.ServiceTargetInitializeRequest{ServiceConfig: serviceConfig},
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	response, err := server.InvokeServiceTarget(ctx, "example", &azdext.ServiceTargetMessage{
+		MessageType: &azdext.ServiceTargetMessage_DeployRequest{
+			DeployRequest: &azdext.ServiceTargetDeployRequest{ServiceConfig: serviceConfig},
+		},
+	}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "https://api.example.com", response.GetDeployResponse().Result.Artifacts[0].Location)
+
+	cancel()
+	require.NoError(t, <-hostErr)
+}
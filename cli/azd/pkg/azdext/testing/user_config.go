@@ -0,0 +1,101 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azdext"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// userConfigService is an in-memory implementation of the azd UserConfigService.
+type userConfigService struct {
+	azdext.UnimplementedUserConfigServiceServer
+	server *Server
+}
+
+func (s *userConfigService) Get(
+	ctx context.Context,
+	req *azdext.GetUserConfigRequest,
+) (*azdext.GetUserConfigResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	value, found := s.server.userConfig.Get(req.Path)
+	if !found {
+		return &azdext.GetUserConfigResponse{}, nil
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	return &azdext.GetUserConfigResponse{Value: valueBytes, Found: true}, nil
+}
+
+func (s *userConfigService) GetString(
+	ctx context.Context,
+	req *azdext.GetUserConfigStringRequest,
+) (*azdext.GetUserConfigStringResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	value, found := s.server.userConfig.GetString(req.Path)
+	return &azdext.GetUserConfigStringResponse{Value: value, Found: found}, nil
+}
+
+func (s *userConfigService) GetSection(
+	ctx context.Context,
+	req *azdext.GetUserConfigSectionRequest,
+) (*azdext.GetUserConfigSectionResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	section, found := s.server.userConfig.GetMap(req.Path)
+	if !found {
+		return &azdext.GetUserConfigSectionResponse{}, nil
+	}
+
+	sectionBytes, err := json.Marshal(section)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal section: %w", err)
+	}
+
+	return &azdext.GetUserConfigSectionResponse{Section: sectionBytes, Found: true}, nil
+}
+
+func (s *userConfigService) Set(ctx context.Context, req *azdext.SetUserConfigRequest) (*azdext.EmptyResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	var value any
+	if err := json.Unmarshal(req.Value, &value); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unmarshal value: %v", err)
+	}
+
+	if err := s.server.userConfig.Set(req.Path, value); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to set value: %v", err)
+	}
+
+	return &azdext.EmptyResponse{}, nil
+}
+
+func (s *userConfigService) Unset(
+	ctx context.Context,
+	req *azdext.UnsetUserConfigRequest,
+) (*azdext.EmptyResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if err := s.server.userConfig.Unset(req.Path); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to unset value: %v", err)
+	}
+
+	return &azdext.EmptyResponse{}, nil
+}
@@ -0,0 +1,179 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azsdk
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	armruntime "github.com/Azure/azure-sdk-for-go/sdk/azcore/arm/runtime"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// LogStreamClient reads log entries from endpoints that stream logs over a long-running HTTP response, such as the
+// App Service (Kudu) and Container Apps log stream endpoints.
+type LogStreamClient struct {
+	pipeline runtime.Pipeline
+}
+
+// LogStreamOptions configures how a log stream is read.
+type LogStreamOptions struct {
+	// Headers are additional headers sent with the request, for example an endpoint specific authorization header.
+	Headers map[string]string
+	// LinePrefix is prepended to every line written to the writer.
+	LinePrefix string
+	// IdleTimeout stops reading the stream when no line is received for the specified duration.
+	// Used for endpoints that never complete the response. Zero reads until the response completes.
+	IdleTimeout time.Duration
+}
+
+// NewLogStreamClient creates a new LogStreamClient.
+//
+// When a credential is specified, requests are authorized with an Azure Resource Manager bearer token. Otherwise,
+// the caller is expected to authorize requests with LogStreamOptions.Headers.
+func NewLogStreamClient(
+	credential azcore.TokenCredential,
+	armClientOptions *arm.ClientOptions,
+) (*LogStreamClient, error) {
+	logStreamOptions := &arm.ClientOptions{}
+	if armClientOptions != nil {
+		optionsCopy := *armClientOptions
+		logStreamOptions = &optionsCopy
+	}
+
+	// We do not have a Resource provider to register
+	logStreamOptions.DisableRPRegistration = true
+
+	var pipeline runtime.Pipeline
+	if credential != nil {
+		armPipeline, err := armruntime.NewPipeline(
+			"log-stream", "1.0.0", credential, runtime.PipelineOptions{}, logStreamOptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating HTTP pipeline: %w", err)
+		}
+
+		pipeline = armPipeline
+	} else {
+		pipeline = runtime.NewPipeline(
+			"log-stream", "1.0.0", runtime.PipelineOptions{}, &policy.ClientOptions{
+				Transport:        logStreamOptions.Transport,
+				PerCallPolicies:  logStreamOptions.PerCallPolicies,
+				PerRetryPolicies: logStreamOptions.PerRetryPolicies,
+				Logging:          logStreamOptions.Logging,
+				Telemetry:        logStreamOptions.Telemetry,
+			})
+	}
+
+	return &LogStreamClient{
+		pipeline: pipeline,
+	}, nil
+}
+
+// Stream reads the log stream at the specified url and writes every line to the writer, until the response completes,
+// the stream is idle for longer than the configured idle timeout or the context is cancelled.
+// Each call to the writer contains a single complete line. Cancelling the context ends the stream without an error.
+func (c *LogStreamClient) Stream(
+	ctx context.Context,
+	url string,
+	options *LogStreamOptions,
+	writer io.Writer,
+) error {
+	if options == nil {
+		options = &LogStreamOptions{}
+	}
+
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	request, err := runtime.NewRequest(streamCtx, http.MethodGet, url)
+	if err != nil {
+		return fmt.Errorf("creating log stream request: %w", err)
+	}
+
+	for key, value := range options.Headers {
+		request.Raw().Header.Set(key, value)
+	}
+
+	// The body is read as it arrives, the stream may never complete
+	runtime.SkipBodyDownload(request)
+
+	response, err := c.pipeline.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if !runtime.HasStatusCode(response, http.StatusOK) {
+		return runtime.NewResponseError(response)
+	}
+
+	lines := make(chan string)
+	scanErr := make(chan error, 1)
+
+	go func() {
+		defer close(lines)
+
+		scanner := bufio.NewScanner(response.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-streamCtx.Done():
+				return
+			}
+		}
+
+		scanErr <- scanner.Err()
+	}()
+
+	var idle <-chan time.Time
+	var idleTimer *time.Timer
+	if options.IdleTimeout > 0 {
+		idleTimer = time.NewTimer(options.IdleTimeout)
+		defer idleTimer.Stop()
+		idle = idleTimer.C
+	}
+
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				select {
+				case err := <-scanErr:
+					if err != nil && ctx.Err() == nil {
+						return fmt.Errorf("reading log stream: %w", err)
+					}
+				default:
+				}
+
+				return nil
+			}
+
+			if _, err := fmt.Fprintln(writer, options.LinePrefix+line); err != nil {
+				return err
+			}
+
+			if idleTimer != nil {
+				idleTimer.Reset(options.IdleTimeout)
+			}
+		case <-idle:
+			return nil
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return nil
+			}
+
+			return ctx.Err()
+		}
+	}
+}
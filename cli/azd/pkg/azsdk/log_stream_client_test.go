@@ -0,0 +1,95 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package azsdk
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogStreamClient(t *testing.T) {
+	t.Run("WritesLinesWithPrefix", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodGet && request.URL.Path == "/api/logstream"
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			require.Equal(t, "Bearer TOKEN", request.Header.Get("Authorization"))
+
+			return &http.Response{
+				Request:    request,
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader("first line\nsecond line\n")),
+			}, nil
+		})
+
+		client, err := NewLogStreamClient(nil, mockContext.ArmClientOptions)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		err = client.Stream(*mockContext.Context, "https://HOSTNAME/api/logstream", &LogStreamOptions{
+			Headers:    map[string]string{"Authorization": "Bearer TOKEN"},
+			LinePrefix: "[system] ",
+		}, &buf)
+		require.NoError(t, err)
+		require.Equal(t, "[system] first line\n[system] second line\n", buf.String())
+	})
+
+	t.Run("StopsWhenIdle", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		body, bodyWriter := io.Pipe()
+		defer bodyWriter.Close()
+
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.URL.Path == "/api/logstream"
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			go func() {
+				_, _ = bodyWriter.Write([]byte("backlog\n"))
+			}()
+
+			return &http.Response{
+				Request:    request,
+				StatusCode: http.StatusOK,
+				Body:       body,
+			}, nil
+		})
+
+		client, err := NewLogStreamClient(&mocks.MockCredentials{}, mockContext.ArmClientOptions)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		err = client.Stream(*mockContext.Context, "https://HOSTNAME/api/logstream", &LogStreamOptions{
+			IdleTimeout: 100 * time.Millisecond,
+		}, &buf)
+		require.NoError(t, err)
+		require.Equal(t, "backlog\n", buf.String())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.URL.Path == "/api/logstream"
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			return &http.Response{
+				Request:    request,
+				StatusCode: http.StatusForbidden,
+				Header:     http.Header{},
+				Body:       io.NopCloser(strings.NewReader("")),
+			}, nil
+		})
+
+		client, err := NewLogStreamClient(nil, mockContext.ArmClientOptions)
+		require.NoError(t, err)
+
+		err = client.Stream(*mockContext.Context, "https://HOSTNAME/api/logstream", nil, io.Discard)
+		require.Error(t, err)
+	})
+}
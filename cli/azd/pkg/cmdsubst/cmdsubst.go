@@ -92,3 +92,15 @@ func ContainsCommandInvocation(doc, commandName string) bool {
 	commandInvocationRegex := regexp.MustCompile(regexStr)
 	return commandInvocationRegex.MatchString(doc)
 }
+
+// FindCommandInvocations returns the arguments of every invocation of a command in the document 'doc'.
+func FindCommandInvocations(doc, commandName string) [][]string {
+	invocations := [][]string{}
+	for _, match := range commandInvocationRegex.FindAllStringSubmatch(doc, -1) {
+		if match[1] == commandName {
+			invocations = append(invocations, strings.Fields(strings.TrimSpace(match[2])))
+		}
+	}
+
+	return invocations
+}
@@ -304,3 +304,11 @@ func TestCommandContainsInvocation(t *testing.T) {
 	input = "$(cmd foo-1 foo-2)"
 	require.True(t, ContainsCommandInvocation(input, "cmd"))
 }
+
+func TestFindCommandInvocations(t *testing.T) {
+	require.Empty(t, FindCommandInvocations("alpha bravo charlie", "cmd"))
+	require.Empty(t, FindCommandInvocations("alpha $(otherCmd foo) charlie", "cmd"))
+
+	input := "alpha $(cmd) bravo $(otherCmd foo) charlie$( cmd vault-1  secret-1 )"
+	require.Equal(t, [][]string{{}, {"vault-1", "secret-1"}}, FindCommandInvocations(input, "cmd"))
+}
@@ -40,8 +40,7 @@ func (e *SecretOrRandomPasswordCommandExecutor) Run(
 	}
 
 	generatePassword := func() (bool, string, error) {
-		substitute, err := password.Generate(
-			password.GenerateConfig{MinLower: to.Ptr[uint](5), MinUpper: to.Ptr[uint](5), MinNumeric: to.Ptr[uint](5)})
+		substitute, err := GenerateRandomPassword()
 		return err == nil, substitute, err
 	}
 
@@ -80,3 +79,10 @@ func (e *SecretOrRandomPasswordCommandExecutor) Run(
 
 	return true, secret.Value, nil
 }
+
+// GenerateRandomPassword generates a password following the same rules as the passwords generated by
+// secretOrRandomPassword, so a rotated secret remains valid for the resources it was generated for.
+func GenerateRandomPassword() (string, error) {
+	return password.Generate(
+		password.GenerateConfig{MinLower: to.Ptr[uint](5), MinUpper: to.Ptr[uint](5), MinNumeric: to.Ptr[uint](5)})
+}
@@ -3,7 +3,9 @@
 
 // Package config provides functionality related to storing application-wide configuration data.
 //
-// Configuration data stored should not be specific to a given repository/project.
+// The user configuration is layered with the built-in defaults, a machine-wide file, the project and the selected
+// environment, see [LayeredConfig]. Configuration data stored by the user should not be specific to a given
+// repository/project.
 package config
 
 import (
@@ -241,6 +243,27 @@ func (c *config) Get(path string) (any, bool) {
 	return nil, false
 }
 
+// getRaw retrieves the value stored at the specified path, without resolving vault references
+func (c *config) getRaw(path string) (any, bool) {
+	currentNode := c.data
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		value, ok := currentNode[part]
+		if !ok || i == len(parts)-1 {
+			return value, ok
+		}
+
+		node, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		currentNode = node
+	}
+
+	return nil, false
+}
+
 // GetMap retrieves the map stored at the specified path
 func (c *config) GetMap(path string) (map[string]any, bool) {
 	value, ok := c.Get(path)
@@ -20,6 +20,7 @@ type ConfigOption struct {
 	Type          string   `yaml:"type"`
 	AllowedValues []string `yaml:"allowedValues,omitempty"`
 	Example       string   `yaml:"example,omitempty"`
+	Default       string   `yaml:"default,omitempty"`
 	EnvVar        string   `yaml:"envVar,omitempty"`
 }
 
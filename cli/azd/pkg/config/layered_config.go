@@ -0,0 +1,617 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+)
+
+// ConfigLayer is a layer of the azd configuration. Values of a layer override the values of the layers before it.
+type ConfigLayer string
+
+const (
+	// LayerDefault holds the built-in default values of the configuration options
+	LayerDefault ConfigLayer = "default"
+	// LayerSystem is the machine-wide configuration file, usually managed by an organization
+	LayerSystem ConfigLayer = "system"
+	// LayerUser is the configuration file of the user, the one 'azd config set' updates
+	LayerUser ConfigLayer = "user"
+	// LayerProject is the .azure/config.json file of the project, which can be checked into the repository
+	LayerProject ConfigLayer = "project"
+	// LayerEnvironment is the config.json file of the selected environment
+	LayerEnvironment ConfigLayer = "environment"
+	// LayerEnvVar holds the values of the environment variables of the configuration options
+	LayerEnvVar ConfigLayer = "env"
+	// LayerPolicy holds the values enforced by the azd policy of the machine, which can't be overridden
+	LayerPolicy ConfigLayer = "policy"
+)
+
+// layers are the layers of the configuration, sorted by precedence, lowest first
+var layers = []ConfigLayer{
+	LayerDefault, LayerSystem, LayerUser, LayerProject, LayerEnvironment, LayerEnvVar, LayerPolicy,
+}
+
+// systemConfigFileEnvVarName is the environment variable overriding the path of the system configuration file
+const systemConfigFileEnvVarName = "AZD_SYSTEM_CONFIG_FILE"
+
+// projectStateKeys are the keys azd stores in the .azure/config.json file of the project, which aren't configuration
+var projectStateKeys = []string{"version", "defaultEnvironment", "copilotSession"}
+
+// ConfigSource is the configuration of a layer
+type ConfigSource struct {
+	Layer ConfigLayer
+	// Path is the file the configuration is loaded from, empty for the default and environment variable layers
+	Path   string
+	Config Config
+}
+
+// ConfigValue is a value of the configuration and the layer supplying it
+type ConfigValue struct {
+	Key    string      `json:"key"`
+	Value  any         `json:"value"`
+	Layer  ConfigLayer `json:"layer"`
+	Source string      `json:"source,omitempty"`
+}
+
+// LayeredConfig is the configuration resolved from all its layers. Values are read from the layer with the highest
+// precedence defining them, while objects are merged across layers. Changes are only made to the user layer.
+//
+// The azd policy of the machine has the highest precedence: its values, objects included, replace the values of all the
+// layers, and the keys it enforces or blocks can't be changed.
+type LayeredConfig struct {
+	user Config
+	// sources are sorted by precedence, lowest first
+	sources []ConfigSource
+	policy  *Policy
+	// policySource holds the values enforced by the policy
+	policySource ConfigSource
+}
+
+// NewLayeredConfig creates the configuration resolved from the given sources, sorted by precedence, lowest first.
+func NewLayeredConfig(sources ...ConfigSource) *LayeredConfig {
+	layered := &LayeredConfig{
+		sources: sources,
+		policy:  &Policy{},
+	}
+
+	for _, source := range sources {
+		if source.Layer == LayerUser {
+			layered.user = source.Config
+		}
+	}
+
+	if layered.user == nil {
+		// The user layer goes before the layers with a higher precedence
+		layered.user = NewEmptyConfig()
+		index := slices.IndexFunc(sources, func(source ConfigSource) bool {
+			return slices.Index(layers, source.Layer) > slices.Index(layers, LayerUser)
+		})
+		if index < 0 {
+			index = len(sources)
+		}
+
+		layered.sources = slices.Insert(
+			slices.Clone(sources), index, ConfigSource{Layer: LayerUser, Config: layered.user})
+	}
+
+	return layered
+}
+
+// User returns the configuration of the user layer.
+func (c *LayeredConfig) User() Config {
+	return c.user
+}
+
+// Sources returns the configuration of each layer, sorted by precedence, lowest first.
+func (c *LayeredConfig) Sources() []ConfigSource {
+	return c.sources
+}
+
+// Enforce applies the policy to the configuration.
+func (c *LayeredConfig) Enforce(policy *Policy) {
+	c.policy = policy
+	c.policySource = policy.Source()
+}
+
+// Policy returns the policy applied to the configuration.
+func (c *LayeredConfig) Policy() *Policy {
+	return c.policy
+}
+
+// Values returns the values at the path, or all values when the path is empty, with the layer supplying each value.
+func (c *LayeredConfig) Values(path string) []ConfigValue {
+	origins := map[string]ConfigSource{}
+	addOrigins := func(source ConfigSource) {
+		var leaves []string
+		if path == "" {
+			leaves = paths(source.Config.Raw())
+		} else if value, has := source.Config.Get(path); has {
+			if node, isNode := value.(map[string]any); isNode {
+				for _, child := range paths(node) {
+					leaves = append(leaves, path+"."+child)
+				}
+			} else {
+				leaves = []string{path}
+			}
+		}
+
+		for _, leaf := range leaves {
+			if leaf == vaultKeyName {
+				continue
+			}
+
+			// Sources are sorted by precedence, the last one defining the value supplies it. A value replacing an
+			// object, or the other way around, replaces the values supplied before.
+			for key := range origins {
+				if key != leaf && (isWithinKey(key, leaf) || isWithinKey(leaf, key)) {
+					delete(origins, key)
+				}
+			}
+			origins[leaf] = source
+		}
+	}
+
+	for _, source := range c.sources {
+		addOrigins(source)
+	}
+
+	if !c.policy.IsEmpty() {
+		// Enforced objects replace the objects of all the layers, instead of being merged
+		for _, enforced := range c.policy.enforcedKeys() {
+			for key := range origins {
+				if isWithinKey(key, enforced) {
+					delete(origins, key)
+				}
+			}
+		}
+		addOrigins(c.policySource)
+
+		for _, blocked := range c.policy.Blocked {
+			for key := range origins {
+				if isWithinKey(key, blocked) {
+					delete(origins, key)
+				}
+			}
+		}
+	}
+
+	values := make([]ConfigValue, 0, len(origins))
+	for _, key := range slices.Sorted(maps.Keys(origins)) {
+		source := origins[key]
+		// Secrets are listed as their vault references, like Raw does
+		value, _ := NewConfig(source.Config.Raw()).(*config).getRaw(key)
+		values = append(values, ConfigValue{
+			Key:    key,
+			Value:  value,
+			Layer:  source.Layer,
+			Source: source.Path,
+		})
+	}
+
+	return values
+}
+
+// Raw returns the values of the user layer.
+func (c *LayeredConfig) Raw() map[string]any {
+	return c.user.Raw()
+}
+
+// ResolvedRaw returns the values of the user layer, resolving any vault references.
+func (c *LayeredConfig) ResolvedRaw() map[string]any {
+	return c.user.ResolvedRaw()
+}
+
+// Get retrieves the value at the path from the layer with the highest precedence defining it. Objects are merged
+// across layers.
+func (c *LayeredConfig) Get(path string) (any, bool) {
+	value, has := c.get(path)
+	if c.policy.IsEmpty() {
+		return value, has
+	}
+
+	for _, enforced := range c.policy.enforcedKeys() {
+		if isWithinKey(path, enforced) {
+			return c.policySource.Config.Get(path)
+		}
+	}
+
+	for _, blocked := range c.policy.Blocked {
+		if isWithinKey(path, blocked) {
+			return nil, false
+		}
+	}
+
+	// Apply the keys enforced or blocked under the path
+	node, isNode := value.(map[string]any)
+	isNode = has && isNode
+	for _, enforced := range c.policy.enforcedKeys() {
+		if isWithinKey(enforced, path) {
+			enforcedValue, _ := c.policySource.Config.Get(enforced)
+			node = withValue(node, relativeKey(enforced, path), enforcedValue)
+			isNode = true
+		}
+	}
+
+	for _, blocked := range c.policy.Blocked {
+		if isNode && isWithinKey(blocked, path) {
+			node = withValue(node, relativeKey(blocked, path), nil)
+		}
+	}
+
+	if isNode {
+		return node, true
+	}
+
+	return value, has
+}
+
+// get retrieves the value at the path from the layers, without applying the policy
+func (c *LayeredConfig) get(path string) (any, bool) {
+	var merged map[string]any
+	for _, source := range slices.Backward(c.sources) {
+		value, has := source.Config.Get(path)
+		if !has {
+			continue
+		}
+
+		node, isNode := value.(map[string]any)
+		if !isNode {
+			// A value of a layer with a higher precedence overrides the whole node
+			if merged != nil {
+				return merged, true
+			}
+
+			return value, true
+		}
+
+		merged = mergeNodes(merged, node)
+	}
+
+	if merged == nil {
+		return nil, false
+	}
+
+	return merged, true
+}
+
+// GetString retrieves the value at the path as a string
+func (c *LayeredConfig) GetString(path string) (string, bool) {
+	value, ok := c.Get(path)
+	if !ok {
+		return "", false
+	}
+
+	str, ok := value.(string)
+	return str, ok
+}
+
+// GetMap retrieves the map at the path
+func (c *LayeredConfig) GetMap(path string) (map[string]any, bool) {
+	value, ok := c.Get(path)
+	if !ok {
+		return nil, false
+	}
+
+	node, ok := value.(map[string]any)
+	return node, ok
+}
+
+// GetSlice retrieves the slice at the path
+func (c *LayeredConfig) GetSlice(path string) ([]any, bool) {
+	value, ok := c.Get(path)
+	if !ok {
+		return nil, false
+	}
+
+	node, ok := value.([]any)
+	return node, ok
+}
+
+// GetSection retrieves the value at the path and unmarshals it into the provided section
+func (c *LayeredConfig) GetSection(path string, section any) (bool, error) {
+	value, ok := c.Get(path)
+	if !ok {
+		return false, nil
+	}
+
+	jsonBytes, err := json.Marshal(value)
+	if err != nil {
+		return true, fmt.Errorf("marshalling section config: %w", err)
+	}
+
+	if err := json.Unmarshal(jsonBytes, section); err != nil {
+		return true, fmt.Errorf("unmarshalling section config: %w", err)
+	}
+
+	return true, nil
+}
+
+// Set stores the value at the path of the user layer, unless the policy enforces or blocks the path
+func (c *LayeredConfig) Set(path string, value any) error {
+	if err := c.policy.Check(path); err != nil {
+		return err
+	}
+
+	return c.user.Set(path, value)
+}
+
+// SetSecret stores the secret at the path of the user layer, unless the policy enforces or blocks the path
+func (c *LayeredConfig) SetSecret(path string, value string) error {
+	if err := c.policy.Check(path); err != nil {
+		return err
+	}
+
+	return c.user.SetSecret(path, value)
+}
+
+// Unset removes the value at the path of the user layer, unless the policy enforces or blocks the path
+func (c *LayeredConfig) Unset(path string) error {
+	if err := c.policy.Check(path); err != nil {
+		return err
+	}
+
+	return c.user.Unset(path)
+}
+
+// IsEmpty returns a value indicating whether the user layer is empty
+func (c *LayeredConfig) IsEmpty() bool {
+	return c.user.IsEmpty()
+}
+
+// relativeKey returns the key relative to its parent key, split into its parts
+func relativeKey(key string, parent string) []string {
+	if parent == "" {
+		return strings.Split(key, ".")
+	}
+
+	return strings.Split(strings.TrimPrefix(key, parent+"."), ".")
+}
+
+// mergeNodes adds the values of the node to the merged node, keeping the values already merged, which come from layers
+// with a higher precedence
+func mergeNodes(merged map[string]any, node map[string]any) map[string]any {
+	if merged == nil {
+		merged = map[string]any{}
+	}
+
+	for key, value := range node {
+		existing, has := merged[key]
+		if !has {
+			merged[key] = value
+			continue
+		}
+
+		existingNode, existingIsNode := existing.(map[string]any)
+		valueNode, valueIsNode := value.(map[string]any)
+		if existingIsNode && valueIsNode {
+			merged[key] = mergeNodes(existingNode, valueNode)
+		}
+	}
+
+	return merged
+}
+
+// ConfigScope locates the project and environment layers of the configuration
+type ConfigScope struct {
+	// ProjectConfigPath is the path of the .azure/config.json file of the project, empty outside of a project
+	ProjectConfigPath string
+	// EnvironmentConfigPath is the path of the config.json file of the selected environment, empty when no environment
+	// is selected
+	EnvironmentConfigPath string
+}
+
+// envNameEnvVarName is the same as environment.EnvNameEnvVarName, duplicated here to prevent an import cycle.
+const envNameEnvVarName = "AZURE_ENV_NAME"
+
+// CurrentConfigScope returns the scope of the project of the current directory. The environment is the one with the given
+// name, or the one selected with AZURE_ENV_NAME, or the default environment of the project.
+func CurrentConfigScope(envName string) ConfigScope {
+	azdCtx, err := azdcontext.NewAzdContext()
+	if err != nil {
+		return ConfigScope{}
+	}
+
+	if envName == "" {
+		envName = os.Getenv(envNameEnvVarName)
+	}
+	if envName == "" {
+		envName, _ = azdCtx.GetDefaultEnvironmentName()
+	}
+
+	scope := ConfigScope{
+		ProjectConfigPath: filepath.Join(azdCtx.EnvironmentDirectory(), azdcontext.ConfigFileName),
+	}
+	if envName != "" {
+		scope.EnvironmentConfigPath = filepath.Join(azdCtx.EnvironmentRoot(envName), azdcontext.ConfigFileName)
+	}
+
+	return scope
+}
+
+type layeredUserConfigManager struct {
+	userConfigManager UserConfigManager
+	fileConfigManager FileConfigManager
+	scope             func() ConfigScope
+}
+
+// NewLayeredUserConfigManager creates a UserConfigManager loading the user configuration layered with the other layers
+// of the configuration. Values are resolved from all the layers, while changes are saved to the user configuration.
+func NewLayeredUserConfigManager(
+	userConfigManager UserConfigManager,
+	fileConfigManager FileConfigManager,
+	scope func() ConfigScope,
+) UserConfigManager {
+	return &layeredUserConfigManager{
+		userConfigManager: userConfigManager,
+		fileConfigManager: fileConfigManager,
+		scope:             scope,
+	}
+}
+
+// Load loads the configuration resolved from all its layers
+func (m *layeredUserConfigManager) Load() (Config, error) {
+	userConfig, err := m.userConfigManager.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	userConfigPath, err := GetUserConfigFilePath()
+	if err != nil {
+		return nil, err
+	}
+
+	systemSource, err := m.loadFile(LayerSystem, GetSystemConfigFilePath())
+	if err != nil {
+		return nil, err
+	}
+
+	scope := m.scope()
+	projectSource, err := m.loadFile(LayerProject, scope.ProjectConfigPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range projectStateKeys {
+		if err := projectSource.Config.Unset(key); err != nil {
+			return nil, err
+		}
+	}
+
+	environmentSource, err := m.loadFile(LayerEnvironment, scope.EnvironmentConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := LoadPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	layered := NewLayeredConfig(
+		DefaultConfigSource(),
+		systemSource,
+		ConfigSource{Layer: LayerUser, Path: userConfigPath, Config: userConfig},
+		projectSource,
+		environmentSource,
+		EnvVarConfigSource(),
+	)
+	layered.Enforce(policy)
+
+	return layered, nil
+}
+
+// Save saves the user layer of the configuration
+func (m *layeredUserConfigManager) Save(c Config) error {
+	if layered, ok := c.(*LayeredConfig); ok {
+		c = layered.User()
+	}
+
+	return m.userConfigManager.Save(c)
+}
+
+// loadFile loads the configuration of the layer from the file, which is empty when the file doesn't exist
+func (m *layeredUserConfigManager) loadFile(layer ConfigLayer, path string) (ConfigSource, error) {
+	source := ConfigSource{
+		Layer:  layer,
+		Path:   path,
+		Config: NewEmptyConfig(),
+	}
+
+	if path == "" {
+		return source, nil
+	}
+
+	cfg, err := m.fileConfigManager.Load(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return source, nil
+	} else if err != nil {
+		return source, fmt.Errorf("failed loading the %s azd config from '%s': %w", layer, path, err)
+	}
+
+	log.Printf("loaded the %s azd config from '%s'", layer, path)
+	source.Config = cfg
+	return source, nil
+}
+
+// GetSystemConfigFilePath returns the path of the machine-wide configuration file, which can be overridden with the
+// AZD_SYSTEM_CONFIG_FILE environment variable.
+func GetSystemConfigFilePath() string {
+	if path := os.Getenv(systemConfigFileEnvVarName); path != "" {
+		return path
+	}
+
+	return machineConfigFilePath("config.json")
+}
+
+// machineConfigFilePath returns the path of the machine-wide file of azd with the given name
+func machineConfigFilePath(name string) string {
+	if runtime.GOOS == "windows" {
+		programData := os.Getenv("ProgramData")
+		if programData == "" {
+			programData = `C:\ProgramData`
+		}
+
+		return filepath.Join(programData, "azd", name)
+	}
+
+	return filepath.Join("/etc", "azd", name)
+}
+
+// DefaultConfigSource returns the built-in default values of the configuration options
+func DefaultConfigSource() ConfigSource {
+	cfg := NewEmptyConfig()
+	for _, option := range GetAllConfigOptions() {
+		if option.Default == "" || strings.HasPrefix(option.Key, EnvOnlyPrefix) {
+			continue
+		}
+
+		if err := cfg.Set(option.Key, option.Default); err != nil {
+			log.Printf("failed setting the default value of '%s': %v", option.Key, err)
+		}
+	}
+
+	return ConfigSource{Layer: LayerDefault, Config: cfg}
+}
+
+// EnvVarConfigSource returns the values of the configuration options set with their environment variables. Boolean values
+// of on/off options are converted to on/off.
+func EnvVarConfigSource() ConfigSource {
+	cfg := NewEmptyConfig()
+	for _, option := range GetAllConfigOptions() {
+		if option.EnvVar == "" || strings.HasPrefix(option.Key, EnvOnlyPrefix) {
+			continue
+		}
+
+		value, has := os.LookupEnv(option.EnvVar)
+		if !has {
+			continue
+		}
+
+		if slices.Equal(option.AllowedValues, []string{"on", "off"}) {
+			if enabled, err := strconv.ParseBool(value); err == nil {
+				value = "off"
+				if enabled {
+					value = "on"
+				}
+			}
+		}
+
+		if err := cfg.Set(option.Key, value); err != nil {
+			log.Printf("failed setting the value of '%s' from %s: %v", option.Key, option.EnvVar, err)
+		}
+	}
+
+	return ConfigSource{Layer: LayerEnvVar, Config: cfg}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LayeredConfig_Get(t *testing.T) {
+	layered := NewLayeredConfig(
+		ConfigSource{Layer: LayerSystem, Config: NewConfig(map[string]any{
+			"defaults": map[string]any{
+				"location":     "eastus",
+				"subscription": "SYSTEM_SUBSCRIPTION",
+			},
+			"template": "system",
+		})},
+		ConfigSource{Layer: LayerUser, Config: NewConfig(map[string]any{
+			"defaults": map[string]any{
+				"subscription": "USER_SUBSCRIPTION",
+			},
+		})},
+		ConfigSource{Layer: LayerProject, Config: NewConfig(map[string]any{
+			"defaults": map[string]any{
+				"location": "westus2",
+			},
+			"template": map[string]any{
+				"sources": "project",
+			},
+		})},
+	)
+
+	location, ok := layered.GetString("defaults.location")
+	require.True(t, ok)
+	require.Equal(t, "westus2", location)
+
+	subscription, ok := layered.GetString("defaults.subscription")
+	require.True(t, ok)
+	require.Equal(t, "USER_SUBSCRIPTION", subscription)
+
+	// Objects are merged across layers
+	defaults, ok := layered.GetMap("defaults")
+	require.True(t, ok)
+	require.Equal(t, map[string]any{"location": "westus2", "subscription": "USER_SUBSCRIPTION"}, defaults)
+
+	// An object of a layer with a higher precedence replaces a value
+	template, ok := layered.GetMap("template")
+	require.True(t, ok)
+	require.Equal(t, map[string]any{"sources": "project"}, template)
+
+	_, ok = layered.Get("missing")
+	require.False(t, ok)
+}
+
+func Test_LayeredConfig_SetOnlyChangesUserLayer(t *testing.T) {
+	project := NewConfig(map[string]any{"defaults": map[string]any{"location": "westus2"}})
+	layered := NewLayeredConfig(ConfigSource{Layer: LayerProject, Config: project})
+
+	require.NoError(t, layered.Set("defaults.location", "eastus"))
+	require.NoError(t, layered.Set("defaults.subscription", "SUBSCRIPTION_ID"))
+
+	// The project layer still has the precedence
+	location, _ := layered.GetString("defaults.location")
+	require.Equal(t, "westus2", location)
+
+	require.Equal(t, map[string]any{
+		"defaults": map[string]any{"location": "eastus", "subscription": "SUBSCRIPTION_ID"},
+	}, layered.Raw())
+	require.Equal(t, map[string]any{"defaults": map[string]any{"location": "westus2"}}, project.Raw())
+}
+
+func Test_LayeredConfig_Values(t *testing.T) {
+	layered := NewLayeredConfig(
+		ConfigSource{Layer: LayerDefault, Config: NewConfig(map[string]any{
+			"provision": map[string]any{"preflight": "on"},
+		})},
+		ConfigSource{Layer: LayerUser, Path: "user.json", Config: NewConfig(map[string]any{
+			"defaults": map[string]any{"location": "eastus", "subscription": "SUBSCRIPTION_ID"},
+			"alpha":    map[string]any{"llm": "on"},
+		})},
+		ConfigSource{Layer: LayerProject, Path: "project.json", Config: NewConfig(map[string]any{
+			"defaults": map[string]any{"location": "westus2"},
+			"alpha":    "off",
+		})},
+		ConfigSource{Layer: LayerEnvVar, Config: NewConfig(map[string]any{
+			"provision": map[string]any{"preflight": "off"},
+		})},
+	)
+
+	require.Equal(t, []ConfigValue{
+		{Key: "alpha", Value: "off", Layer: LayerProject, Source: "project.json"},
+		{Key: "defaults.location", Value: "westus2", Layer: LayerProject, Source: "project.json"},
+		{Key: "defaults.subscription", Value: "SUBSCRIPTION_ID", Layer: LayerUser, Source: "user.json"},
+		{Key: "provision.preflight", Value: "off", Layer: LayerEnvVar},
+	}, layered.Values(""))
+
+	require.Equal(t, []ConfigValue{
+		{Key: "defaults.location", Value: "westus2", Layer: LayerProject, Source: "project.json"},
+		{Key: "defaults.subscription", Value: "SUBSCRIPTION_ID", Layer: LayerUser, Source: "user.json"},
+	}, layered.Values("defaults"))
+
+	require.Empty(t, layered.Values("missing"))
+}
+
+func Test_LayeredUserConfigManager(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("AZD_CONFIG_DIR", configDir)
+	t.Setenv(systemConfigFileEnvVarName, filepath.Join(configDir, "system.json"))
+	t.Setenv("AZD_ALPHA_ENABLE_LLM", "true")
+
+	fileConfigManager := NewFileConfigManager(NewManager())
+	projectConfigPath := filepath.Join(t.TempDir(), "config.json")
+
+	require.NoError(t, fileConfigManager.Save(NewConfig(map[string]any{
+		"defaults": map[string]any{"location": "eastus", "subscription": "SYSTEM_SUBSCRIPTION"},
+	}), GetSystemConfigFilePath()))
+	require.NoError(t, fileConfigManager.Save(NewConfig(map[string]any{
+		"version":            1,
+		"defaultEnvironment": "dev",
+		"defaults":           map[string]any{"location": "westus2"},
+	}), projectConfigPath))
+
+	configManager := NewLayeredUserConfigManager(
+		NewUserConfigManager(fileConfigManager),
+		fileConfigManager,
+		func() ConfigScope {
+			return ConfigScope{
+				ProjectConfigPath:     projectConfigPath,
+				EnvironmentConfigPath: filepath.Join(t.TempDir(), "missing.json"),
+			}
+		},
+	)
+
+	azdConfig, err := configManager.Load()
+	require.NoError(t, err)
+
+	location, _ := azdConfig.GetString("defaults.location")
+	require.Equal(t, "westus2", location)
+
+	subscription, _ := azdConfig.GetString("defaults.subscription")
+	require.Equal(t, "SYSTEM_SUBSCRIPTION", subscription)
+
+	preflight, _ := azdConfig.GetString("provision.preflight")
+	require.Equal(t, "on", preflight)
+
+	llm, _ := azdConfig.GetString("alpha.llm")
+	require.Equal(t, "on", llm)
+
+	// The state of the project isn't configuration
+	_, has := azdConfig.Get("defaultEnvironment")
+	require.False(t, has)
+
+	// Only the user layer is saved
+	require.NoError(t, azdConfig.Set("defaults.subscription", "USER_SUBSCRIPTION"))
+	require.NoError(t, configManager.Save(azdConfig))
+
+	userConfig, err := NewUserConfigManager(fileConfigManager).Load()
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"defaults": map[string]any{"subscription": "USER_SUBSCRIPTION"}}, userConfig.Raw())
+
+	azdConfig, err = configManager.Load()
+	require.NoError(t, err)
+
+	values := azdConfig.(*LayeredConfig).Values("defaults.subscription")
+	require.Equal(t, []ConfigValue{{
+		Key:    "defaults.subscription",
+		Value:  "USER_SUBSCRIPTION",
+		Layer:  LayerUser,
+		Source: filepath.Join(configDir, "config.json"),
+	}}, values)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+// policyFileEnvVarName is the environment variable of an additional policy file, whose rules are added to the policy of
+// the machine
+const policyFileEnvVarName = "AZD_POLICY_FILE"
+
+// machinePolicyFilePath returns the path of the policy file of the machine
+var machinePolicyFilePath = func() string {
+	return machineConfigFilePath("policy.json")
+}
+
+// ErrPolicyViolation indicates a change of the configuration the azd policy of the machine doesn't allow.
+var ErrPolicyViolation = errors.New("azd policy violation")
+
+// Policy is the azd policy of a machine, usually managed by an organization, which enforces configuration values users
+// can't override and blocks configuration keys users can't set.
+type Policy struct {
+	// Path is the policy file, empty when the machine has no policy
+	Path string `json:"-"`
+	// Enforced are the values enforced by the policy, by configuration key. Objects are enforced as a whole.
+	Enforced map[string]any `json:"enforced,omitempty"`
+	// Blocked are the configuration keys which can't be set, like 'template.sources.awesome-azd'
+	Blocked []string `json:"blocked,omitempty"`
+	// keyPaths are the policy files of the keys added from another file than Path
+	keyPaths map[string]string
+}
+
+// PolicyViolationError is a change of the configuration the azd policy doesn't allow.
+type PolicyViolationError struct {
+	// Key is the configuration key being changed
+	Key string
+	// PolicyKey is the key enforced or blocked by the policy, which is the key or one of its parents
+	PolicyKey string
+	// Blocked is set when the policy blocks the key, instead of enforcing its value
+	Blocked bool
+	// Path is the policy file
+	Path string
+}
+
+func (e *PolicyViolationError) Error() string {
+	if e.Blocked {
+		return fmt.Sprintf("'%s' is blocked by the azd policy in '%s'", e.PolicyKey, e.Path)
+	}
+
+	return fmt.Sprintf("'%s' is enforced by the azd policy in '%s'", e.PolicyKey, e.Path)
+}
+
+func (e *PolicyViolationError) Unwrap() error {
+	return ErrPolicyViolation
+}
+
+// LoadPolicy loads the azd policy of the machine, which is empty when the policy file doesn't exist. The rules of the
+// policy file set with the AZD_POLICY_FILE environment variable are added to the policy of the machine, they never
+// replace it.
+func LoadPolicy() (*Policy, error) {
+	policy, err := loadPolicyFile(GetPolicyFilePath())
+	if errors.Is(err, os.ErrNotExist) {
+		policy = &Policy{}
+	} else if err != nil {
+		return nil, err
+	}
+
+	if path := os.Getenv(policyFileEnvVarName); path != "" {
+		// Unlike the policy of the machine, the additional policy file must exist
+		additional, err := loadPolicyFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", policyFileEnvVarName, err)
+		}
+
+		policy.add(additional)
+	}
+
+	return policy, nil
+}
+
+// loadPolicyFile loads the policy in the given file
+func loadPolicyFile(path string) (*Policy, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading the azd policy from '%s': %w", path, err)
+	}
+
+	policy := &Policy{}
+	if err := json.Unmarshal(contents, policy); err != nil {
+		return nil, fmt.Errorf("failed parsing the azd policy in '%s': %w", path, err)
+	}
+
+	for _, key := range policy.Blocked {
+		if _, has := policy.Enforced[key]; has {
+			return nil, fmt.Errorf("invalid azd policy in '%s': '%s' is both enforced and blocked", path, key)
+		}
+	}
+
+	log.Printf("loaded the azd policy from '%s'", path)
+	policy.Path = path
+	return policy, nil
+}
+
+// add adds the rules of the other policy. The keys the policy already enforces or blocks, and the keys under them, keep
+// their rules.
+func (p *Policy) add(other *Policy) {
+	if p.Path == "" {
+		p.Path = other.Path
+	}
+
+	for _, key := range other.enforcedKeys() {
+		if p.Check(key) != nil {
+			log.Printf("ignoring '%s' enforced by the azd policy in '%s', it's already enforced or blocked", key, other.Path)
+			continue
+		}
+
+		if p.Enforced == nil {
+			p.Enforced = map[string]any{}
+		}
+		p.Enforced[key] = other.Enforced[key]
+		p.setKeyPath(key, other.Path)
+	}
+
+	for _, key := range other.Blocked {
+		if p.Check(key) != nil {
+			log.Printf("ignoring '%s' blocked by the azd policy in '%s', it's already enforced or blocked", key, other.Path)
+			continue
+		}
+
+		p.Blocked = append(p.Blocked, key)
+		p.setKeyPath(key, other.Path)
+	}
+}
+
+// setKeyPath records the policy file of a key added from another file
+func (p *Policy) setKeyPath(key string, path string) {
+	if path == p.Path {
+		return
+	}
+
+	if p.keyPaths == nil {
+		p.keyPaths = map[string]string{}
+	}
+	p.keyPaths[key] = path
+}
+
+// keyPath returns the policy file enforcing or blocking the key
+func (p *Policy) keyPath(key string) string {
+	if path, has := p.keyPaths[key]; has {
+		return path
+	}
+
+	return p.Path
+}
+
+// GetPolicyFilePath returns the path of the azd policy file of the machine, next to the system configuration file.
+func GetPolicyFilePath() string {
+	return machinePolicyFilePath()
+}
+
+// Check returns a *PolicyViolationError when the policy doesn't allow changing the value at the key.
+func (p *Policy) Check(key string) error {
+	for _, enforced := range p.enforcedKeys() {
+		if isWithinKey(key, enforced) {
+			return &PolicyViolationError{Key: key, PolicyKey: enforced, Path: p.keyPath(enforced)}
+		}
+	}
+
+	for _, blocked := range p.Blocked {
+		if isWithinKey(key, blocked) {
+			return &PolicyViolationError{Key: key, PolicyKey: blocked, Blocked: true, Path: p.keyPath(blocked)}
+		}
+	}
+
+	return nil
+}
+
+// IsEmpty returns a value indicating whether the policy neither enforces nor blocks any key
+func (p *Policy) IsEmpty() bool {
+	return len(p.Enforced) == 0 && len(p.Blocked) == 0
+}
+
+// Source returns the values enforced by the policy as the policy layer of the configuration
+func (p *Policy) Source() ConfigSource {
+	cfg := NewEmptyConfig()
+	for _, key := range p.enforcedKeys() {
+		if err := cfg.Set(key, p.Enforced[key]); err != nil {
+			log.Printf("failed setting the value of '%s' enforced by the azd policy: %v", key, err)
+		}
+	}
+
+	return ConfigSource{Layer: LayerPolicy, Path: p.Path, Config: cfg}
+}
+
+// enforcedKeys returns the keys enforced by the policy, parents first
+func (p *Policy) enforcedKeys() []string {
+	return slices.Sorted(maps.Keys(p.Enforced))
+}
+
+// isWithinKey returns a value indicating whether the key is the parent key or one of its children
+func isWithinKey(key string, parent string) bool {
+	return parent == "" || key == parent || strings.HasPrefix(key, parent+".")
+}
+
+// withValue returns a copy of the node with the value at the path, copying the nodes along the path. The value is removed
+// when nil.
+func withValue(node map[string]any, path []string, value any) map[string]any {
+	result := maps.Clone(node)
+	if result == nil {
+		result = map[string]any{}
+	}
+
+	if len(path) == 1 {
+		if value == nil {
+			delete(result, path[0])
+		} else {
+			result[path[0]] = value
+		}
+
+		return result
+	}
+
+	child, isNode := result[path[0]].(map[string]any)
+	if !isNode && value == nil {
+		return result
+	}
+
+	result[path[0]] = withValue(child, path[1:], value)
+	return result
+}
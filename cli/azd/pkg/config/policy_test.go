@@ -0,0 +1,185 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LoadPolicy(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "policy.json")
+	setMachinePolicyFilePath(t, policyPath)
+
+	t.Run("MissingFile", func(t *testing.T) {
+		policy, err := LoadPolicy()
+		require.NoError(t, err)
+		require.True(t, policy.IsEmpty())
+		require.NoError(t, policy.Check("provision.preflight"))
+	})
+
+	t.Run("EnforcedAndBlocked", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(policyPath, []byte(
+			`{"enforced":{"provision.preflight":"on"},"blocked":["template.sources.awesome-azd"]}`), 0600))
+
+		policy, err := LoadPolicy()
+		require.NoError(t, err)
+		require.Equal(t, policyPath, policy.Path)
+		require.Equal(t, map[string]any{"provision.preflight": "on"}, policy.Enforced)
+		require.Equal(t, []string{"template.sources.awesome-azd"}, policy.Blocked)
+	})
+
+	t.Run("EnforcedAndBlockedKey", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(policyPath, []byte(
+			`{"enforced":{"provision.preflight":"on"},"blocked":["provision.preflight"]}`), 0600))
+
+		_, err := LoadPolicy()
+		require.ErrorContains(t, err, "'provision.preflight' is both enforced and blocked")
+	})
+
+	t.Run("InvalidFile", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(policyPath, []byte(`{"enforced":`), 0600))
+
+		_, err := LoadPolicy()
+		require.ErrorContains(t, err, "failed parsing the azd policy")
+	})
+}
+
+func Test_LoadPolicy_AdditionalFile(t *testing.T) {
+	machinePath := filepath.Join(t.TempDir(), "policy.json")
+	setMachinePolicyFilePath(t, machinePath)
+	require.NoError(t, os.WriteFile(machinePath, []byte(
+		`{"enforced":{"provision.preflight":"on"},"blocked":["template.sources.awesome-azd"]}`), 0600))
+
+	additionalPath := filepath.Join(t.TempDir(), "additional.json")
+	t.Setenv(policyFileEnvVarName, additionalPath)
+
+	t.Run("MissingFile", func(t *testing.T) {
+		_, err := LoadPolicy()
+		require.ErrorIs(t, err, os.ErrNotExist)
+		require.ErrorContains(t, err, policyFileEnvVarName)
+	})
+
+	t.Run("AddsRules", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(additionalPath, []byte(`{
+			"enforced": {"provision.preflight": "off", "telemetry.collect": "off"},
+			"blocked": ["template.sources.contoso"]
+		}`), 0600))
+
+		policy, err := LoadPolicy()
+		require.NoError(t, err)
+
+		// The rules of the machine policy can't be replaced
+		require.Equal(t, map[string]any{"provision.preflight": "on", "telemetry.collect": "off"}, policy.Enforced)
+		require.Equal(t, []string{"template.sources.awesome-azd", "template.sources.contoso"}, policy.Blocked)
+
+		violation, ok := errors.AsType[*PolicyViolationError](policy.Check("provision.preflight"))
+		require.True(t, ok)
+		require.Equal(t, machinePath, violation.Path)
+
+		violation, ok = errors.AsType[*PolicyViolationError](policy.Check("template.sources.contoso"))
+		require.True(t, ok)
+		require.Equal(t, additionalPath, violation.Path)
+	})
+}
+
+// setMachinePolicyFilePath overrides the path of the policy file of the machine for the test
+func setMachinePolicyFilePath(t *testing.T, path string) {
+	original := machinePolicyFilePath
+	machinePolicyFilePath = func() string { return path }
+	t.Cleanup(func() { machinePolicyFilePath = original })
+}
+
+func Test_Policy_Check(t *testing.T) {
+	policy := &Policy{
+		Path: "policy.json",
+		Enforced: map[string]any{
+			"provision.preflight": "on",
+			"extension.sources":   map[string]any{"contoso": map[string]any{"type": "url"}},
+		},
+		Blocked: []string{"template.sources.awesome-azd"},
+	}
+
+	require.NoError(t, policy.Check("defaults.location"))
+	require.NoError(t, policy.Check("provision"))
+	require.NoError(t, policy.Check("template.sources.contoso"))
+
+	err := policy.Check("extension.sources.azd")
+	require.True(t, errors.Is(err, ErrPolicyViolation))
+
+	violation, ok := errors.AsType[*PolicyViolationError](err)
+	require.True(t, ok)
+	require.Equal(t, &PolicyViolationError{
+		Key:       "extension.sources.azd",
+		PolicyKey: "extension.sources",
+		Path:      "policy.json",
+	}, violation)
+
+	err = policy.Check("template.sources.awesome-azd")
+	require.EqualError(t, err, "'template.sources.awesome-azd' is blocked by the azd policy in 'policy.json'")
+}
+
+func Test_LayeredConfig_Enforce(t *testing.T) {
+	layered := NewLayeredConfig(
+		ConfigSource{Layer: LayerUser, Config: NewConfig(map[string]any{
+			"provision": map[string]any{"preflight": "off"},
+			"extension": map[string]any{
+				"sources": map[string]any{"azd": map[string]any{"type": "url"}},
+			},
+			"template": map[string]any{
+				"sources": map[string]any{
+					"awesome-azd": map[string]any{},
+					"contoso":     map[string]any{"type": "file"},
+				},
+			},
+		})},
+	)
+	layered.Enforce(&Policy{
+		Path: "policy.json",
+		Enforced: map[string]any{
+			"provision.preflight": "on",
+			"extension.sources":   map[string]any{"contoso": map[string]any{"type": "url"}},
+		},
+		Blocked: []string{"template.sources.awesome-azd"},
+	})
+
+	preflight, _ := layered.GetString("provision.preflight")
+	require.Equal(t, "on", preflight)
+
+	// Enforced objects replace the objects of the layers
+	sources, ok := layered.GetMap("extension.sources")
+	require.True(t, ok)
+	require.Equal(t, map[string]any{"contoso": map[string]any{"type": "url"}}, sources)
+
+	extension, ok := layered.GetMap("extension")
+	require.True(t, ok)
+	require.Equal(t, map[string]any{"sources": map[string]any{"contoso": map[string]any{"type": "url"}}}, extension)
+
+	_, ok = layered.Get("cloud")
+	require.False(t, ok)
+
+	// Blocked keys are removed
+	_, ok = layered.Get("template.sources.awesome-azd")
+	require.False(t, ok)
+
+	templateSources, ok := layered.GetMap("template.sources")
+	require.True(t, ok)
+	require.Equal(t, map[string]any{"contoso": map[string]any{"type": "file"}}, templateSources)
+
+	require.Equal(t, []ConfigValue{
+		{Key: "extension.sources.contoso.type", Value: "url", Layer: LayerPolicy, Source: "policy.json"},
+		{Key: "provision.preflight", Value: "on", Layer: LayerPolicy, Source: "policy.json"},
+		{Key: "template.sources.contoso.type", Value: "file", Layer: LayerUser},
+	}, layered.Values(""))
+
+	// Enforced and blocked keys can't be changed
+	require.ErrorIs(t, layered.Set("provision.preflight", "off"), ErrPolicyViolation)
+	require.ErrorIs(t, layered.Unset("extension.sources.contoso"), ErrPolicyViolation)
+	require.ErrorIs(t, layered.Set("template.sources.awesome-azd", map[string]any{}), ErrPolicyViolation)
+	require.NoError(t, layered.Set("defaults.location", "westus2"))
+}
@@ -9,11 +9,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"maps"
 	"net/http"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
@@ -34,12 +37,16 @@ import (
 
 const (
 	pathLatestRevisionName                 = "properties.latestRevisionName"
+	pathLatestReadyRevisionName            = "properties.latestReadyRevisionName"
+	pathRunningStatus                      = "properties.runningStatus"
+	pathProvisioningState                  = "properties.provisioningState"
 	pathTemplate                           = "properties.template"
 	pathTemplateRevisionSuffix             = "properties.template.revisionSuffix"
 	pathTemplateContainers                 = "properties.template.containers"
 	pathConfigurationActiveRevisionsMode   = "properties.configuration.activeRevisionsMode"
 	pathConfigurationDapr                  = "properties.configuration.dapr"
 	pathConfigurationSecrets               = "properties.configuration.secrets"
+	pathConfigurationIngress               = "properties.configuration.ingress"
 	pathConfigurationIngressTraffic        = "properties.configuration.ingress.traffic"
 	pathConfigurationIngressFqdn           = "properties.configuration.ingress.fqdn"
 	pathConfigurationIngressCustomDomains  = "properties.configuration.ingress.customDomains"
@@ -74,6 +81,60 @@ type ContainerAppService interface {
 		envVars map[string]string,
 		options *ContainerAppOptions,
 	) error
+	// Adds a new revision to the specified container app without routing traffic to it
+	StageRevision(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		imageName string,
+		envVars map[string]string,
+		options *ContainerAppOptions,
+	) (*StagedRevision, error)
+	// Sets the percentage of the ingress traffic routed to each revision of the specified container app
+	SetRevisionTraffic(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		weights map[string]int32,
+		options *ContainerAppOptions,
+	) error
+	// Sets the active revisions mode of the specified container app and routes all traffic to the revision
+	SetActiveRevisionsMode(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		revisionsMode string,
+		revisionName string,
+		options *ContainerAppOptions,
+	) error
+	// Deactivates a revision of the specified container app
+	DeactivateRevision(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		revisionName string,
+	) error
+	// Gets the name of the latest revision of the specified container app
+	GetLatestRevisionName(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		options *ContainerAppOptions,
+	) (string, error)
+	// Activates a previous revision of the specified container app and routes all traffic to it
+	ActivateRevision(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		revisionName string,
+		options *ContainerAppOptions,
+	) error
 	// GetContainerAppJob gets a Container App Job by name
 	GetContainerAppJob(
 		ctx context.Context,
@@ -93,6 +154,24 @@ type ContainerAppService interface {
 		envVars map[string]string,
 		options *ContainerAppOptions,
 	) error
+	// Gets the runtime status of the specified container app
+	GetStatus(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		options *ContainerAppOptions,
+	) (*ContainerAppStatus, error)
+	// StreamLogs writes the system and console logs of the specified container app to the writer, one line per write.
+	// The writer must be safe for concurrent use since the logs of every container are streamed concurrently.
+	StreamLogs(
+		ctx context.Context,
+		subscriptionId string,
+		resourceGroupName string,
+		appName string,
+		follow bool,
+		writer io.Writer,
+	) error
 }
 
 // NewContainerAppService creates a new ContainerAppService
@@ -127,6 +206,34 @@ type ContainerAppIngressConfiguration struct {
 	HostNames []string
 }
 
+// StagedRevision is a revision added to a container app without receiving any traffic
+type StagedRevision struct {
+	// The name of the new revision
+	Name string
+	// The revision specific host name, serving only the new revision
+	HostName string
+	// The name of the revision serving the traffic of the container app when the new revision was added
+	PreviousName string
+	// The active revisions mode of the container app before it was switched to multiple revision mode
+	PreviousRevisionsMode string
+}
+
+// ContainerAppStatus is the runtime status of a container app
+type ContainerAppStatus struct {
+	// The running status of the container app, such as Running or Stopped
+	RunningStatus string
+	// The provisioning state of the container app, such as Succeeded or InProgress
+	ProvisioningState string
+	// The name of the latest revision ready to serve traffic
+	LatestReadyRevision string
+	// The percentage of the ingress traffic routed to each active revision
+	Traffic map[string]int
+	// The number of replicas running the latest ready revision
+	Replicas int
+	// The time the latest ready revision was created
+	LastDeployed *time.Time
+}
+
 // Gets the ingress configuration for the specified container app
 func (cas *containerAppService) GetIngressConfiguration(
 	ctx context.Context,
@@ -329,20 +436,454 @@ func (cas *containerAppService) AddRevision(
 	imageName string,
 	envVars map[string]string,
 	options *ContainerAppOptions,
+) error {
+	containerApp, err := cas.newRevision(ctx, subscriptionId, resourceGroupName, appName, imageName, envVars, options)
+	if err != nil {
+		return err
+	}
+
+	containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+	if err != nil {
+		return fmt.Errorf("syncing secrets: %w", err)
+	}
+
+	revisionMode, ok := containerApp.GetString(pathConfigurationActiveRevisionsMode)
+	if !ok {
+		return fmt.Errorf("container app is missing active revisions mode configuration")
+	}
+
+	// If the container app is in multiple revision mode, update the traffic to point to the new revision.
+	if revisionMode == string(armappcontainers.ActiveRevisionsModeMultiple) {
+		revisionSuffix, _ := containerApp.GetString(pathTemplateRevisionSuffix)
+		newRevisionName := fmt.Sprintf("%s--%s", appName, revisionSuffix)
+
+		if err := setTrafficWeights(containerApp, map[string]int32{newRevisionName: 100}); err != nil {
+			return err
+		}
+	}
+
+	err = cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp, options)
+	if err != nil {
+		return fmt.Errorf("updating container app revision: %w", err)
+	}
+
+	return nil
+}
+
+// Adds a new revision to the specified container app without routing traffic to it.
+// The container app is switched to multiple revision mode, so that the current revision keeps serving the traffic of the
+// app until it is shifted to the new revision with SetRevisionTraffic.
+func (cas *containerAppService) StageRevision(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	imageName string,
+	envVars map[string]string,
+	options *ContainerAppOptions,
+) (*StagedRevision, error) {
+	containerApp, err := cas.newRevision(ctx, subscriptionId, resourceGroupName, appName, imageName, envVars, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, has := containerApp.GetMap(pathConfigurationIngress); !has {
+		return nil, fmt.Errorf("container app %s does not have ingress configured to shift traffic between revisions", appName)
+	}
+
+	// The latest revision isn't necessarily serving traffic, like the deactivated revision of an aborted rollout
+	previousRevisionName, has := servingRevisionName(containerApp)
+	if !has {
+		return nil, fmt.Errorf("container app %s does not have a revision serving traffic", appName)
+	}
+
+	previousRevisionsMode, ok := containerApp.GetString(pathConfigurationActiveRevisionsMode)
+	if !ok {
+		return nil, fmt.Errorf("container app is missing active revisions mode configuration")
+	}
+
+	if err := containerApp.Set(
+		pathConfigurationActiveRevisionsMode, string(armappcontainers.ActiveRevisionsModeMultiple)); err != nil {
+		return nil, fmt.Errorf("setting active revisions mode: %w", err)
+	}
+
+	if err := setTrafficWeights(containerApp, map[string]int32{previousRevisionName: 100}); err != nil {
+		return nil, err
+	}
+
+	containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+	if err != nil {
+		return nil, fmt.Errorf("syncing secrets: %w", err)
+	}
+
+	if err := cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp, options); err != nil {
+		return nil, fmt.Errorf("updating container app revision: %w", err)
+	}
+
+	revisionSuffix, _ := containerApp.GetString(pathTemplateRevisionSuffix)
+	revisionName := fmt.Sprintf("%s--%s", appName, revisionSuffix)
+
+	revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := revisionsClient.GetRevision(ctx, resourceGroupName, appName, revisionName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting revision %s: %w", revisionName, err)
+	}
+
+	if revision.Properties == nil || revision.Properties.Fqdn == nil {
+		return nil, fmt.Errorf("revision %s does not have a host name", revisionName)
+	}
+
+	return &StagedRevision{
+		Name:                  revisionName,
+		HostName:              *revision.Properties.Fqdn,
+		PreviousName:          previousRevisionName,
+		PreviousRevisionsMode: previousRevisionsMode,
+	}, nil
+}
+
+// Sets the percentage of the ingress traffic routed to each revision of the specified container app.
+// Revisions missing from the weights don't receive any traffic.
+func (cas *containerAppService) SetRevisionTraffic(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	weights map[string]int32,
+	options *ContainerAppOptions,
 ) error {
 	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
 	if err != nil {
 		return fmt.Errorf("getting container app: %w", err)
 	}
 
+	if err := setTrafficWeights(containerApp, weights); err != nil {
+		return err
+	}
+
+	containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+	if err != nil {
+		return fmt.Errorf("syncing secrets: %w", err)
+	}
+
+	if err := cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp, options); err != nil {
+		return fmt.Errorf("updating container app traffic: %w", err)
+	}
+
+	return nil
+}
+
+// Sets the active revisions mode of the specified container app and routes all traffic to the revision.
+// In single revision mode the traffic follows the latest revision of the app, unless the revision isn't the latest
+// revision, like the previous revision of an aborted rollout, which is then pinned by name.
+func (cas *containerAppService) SetActiveRevisionsMode(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	revisionsMode string,
+	revisionName string,
+	options *ContainerAppOptions,
+) error {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return fmt.Errorf("getting container app: %w", err)
+	}
+
+	if err := containerApp.Set(pathConfigurationActiveRevisionsMode, revisionsMode); err != nil {
+		return fmt.Errorf("setting active revisions mode: %w", err)
+	}
+
+	latestRevisionName, _ := containerApp.GetString(pathLatestRevisionName)
+	if revisionsMode != string(armappcontainers.ActiveRevisionsModeMultiple) && revisionName == latestRevisionName {
+		trafficWeightsJson, err := convert.ToJsonArray([]*armappcontainers.TrafficWeight{
+			{
+				LatestRevision: to.Ptr(true),
+				Weight:         to.Ptr(int32(100)),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("converting traffic weights to JSON: %w", err)
+		}
+
+		if err := containerApp.Set(pathConfigurationIngressTraffic, trafficWeightsJson); err != nil {
+			return fmt.Errorf("setting traffic weights: %w", err)
+		}
+	} else if err := setTrafficWeights(containerApp, map[string]int32{revisionName: 100}); err != nil {
+		return err
+	}
+
+	containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+	if err != nil {
+		return fmt.Errorf("syncing secrets: %w", err)
+	}
+
+	if err := cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp, options); err != nil {
+		return fmt.Errorf("updating container app revisions mode: %w", err)
+	}
+
+	return nil
+}
+
+// Deactivates a revision of the specified container app
+func (cas *containerAppService) DeactivateRevision(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	revisionName string,
+) error {
+	revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := revisionsClient.DeactivateRevision(ctx, resourceGroupName, appName, revisionName, nil); err != nil {
+		return fmt.Errorf("deactivating revision %s: %w", revisionName, err)
+	}
+
+	return nil
+}
+
+// servingRevisionName returns the revision receiving the most ingress traffic of the container app. Traffic routed to the
+// latest revision, which is the default of single revision mode, is served by the latest ready revision.
+func servingRevisionName(containerApp config.Config) (string, bool) {
+	latestReadyRevisionName, hasLatestReady := containerApp.GetString(pathLatestReadyRevisionName)
+
+	traffic, _ := containerApp.GetSlice(pathConfigurationIngressTraffic)
+	servingRevisionName := ""
+	servingWeight := 0.0
+	for _, entry := range traffic {
+		trafficWeight, ok := entry.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		weight, _ := trafficWeight["weight"].(float64)
+		if weight <= servingWeight {
+			continue
+		}
+
+		revisionName, _ := trafficWeight["revisionName"].(string)
+		if latestRevision, _ := trafficWeight["latestRevision"].(bool); latestRevision || revisionName == "" {
+			revisionName = latestReadyRevisionName
+		}
+
+		if revisionName != "" {
+			servingRevisionName = revisionName
+			servingWeight = weight
+		}
+	}
+
+	if servingRevisionName != "" {
+		return servingRevisionName, true
+	}
+
+	return latestReadyRevisionName, hasLatestReady && latestReadyRevisionName != ""
+}
+
+// setTrafficWeights sets the ingress traffic weights of the container app, ordered by revision name
+func setTrafficWeights(containerApp config.Config, weights map[string]int32) error {
+	trafficWeights := make([]*armappcontainers.TrafficWeight, 0, len(weights))
+	for _, revisionName := range slices.Sorted(maps.Keys(weights)) {
+		trafficWeights = append(trafficWeights, &armappcontainers.TrafficWeight{
+			RevisionName: to.Ptr(revisionName),
+			Weight:       to.Ptr(weights[revisionName]),
+		})
+	}
+
+	trafficWeightsJson, err := convert.ToJsonArray(trafficWeights)
+	if err != nil {
+		return fmt.Errorf("converting traffic weights to JSON: %w", err)
+	}
+
+	if err := containerApp.Set(pathConfigurationIngressTraffic, trafficWeightsJson); err != nil {
+		return fmt.Errorf("setting traffic weights: %w", err)
+	}
+
+	return nil
+}
+
+// Gets the name of the latest revision of the specified container app
+func (cas *containerAppService) GetLatestRevisionName(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	options *ContainerAppOptions,
+) (string, error) {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return "", fmt.Errorf("getting container app: %w", err)
+	}
+
+	revisionName, has := containerApp.GetString(pathLatestRevisionName)
+	if !has {
+		return "", fmt.Errorf("container app %s does not have a revision", appName)
+	}
+
+	return revisionName, nil
+}
+
+// Gets the runtime status of the specified container app
+func (cas *containerAppService) GetStatus(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	options *ContainerAppOptions,
+) (*ContainerAppStatus, error) {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return nil, fmt.Errorf("getting container app: %w", err)
+	}
+
+	status := &ContainerAppStatus{
+		Traffic: map[string]int{},
+	}
+	status.RunningStatus, _ = containerApp.GetString(pathRunningStatus)
+	status.ProvisioningState, _ = containerApp.GetString(pathProvisioningState)
+	status.LatestReadyRevision, _ = containerApp.GetString(pathLatestReadyRevisionName)
+	latestRevision, _ := containerApp.GetString(pathLatestRevisionName)
+
+	traffic, _ := containerApp.GetSlice(pathConfigurationIngressTraffic)
+	for _, item := range traffic {
+		weight, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		revisionName, _ := weight["revisionName"].(string)
+		if isLatest, _ := weight["latestRevision"].(bool); isLatest && revisionName == "" {
+			revisionName = latestRevision
+		}
+
+		if percentage, ok := weight["weight"].(float64); ok && revisionName != "" && percentage > 0 {
+			status.Traffic[revisionName] += int(percentage)
+		}
+	}
+
+	if status.LatestReadyRevision == "" {
+		return status, nil
+	}
+
+	revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := revisionsClient.GetRevision(ctx, resourceGroupName, appName, status.LatestReadyRevision, nil)
+	if err != nil {
+		return nil, fmt.Errorf("getting revision %s: %w", status.LatestReadyRevision, err)
+	}
+
+	if revision.Properties != nil {
+		status.Replicas = int(convert.ToValueWithDefault(revision.Properties.Replicas, 0))
+		status.LastDeployed = revision.Properties.CreatedTime
+	}
+
+	return status, nil
+}
+
+// Activates a previous revision of the specified container app and routes all traffic to it.
+// Container apps in single revision mode only serve their latest revision, so the template of the previous revision is
+// deployed as a new revision instead.
+func (cas *containerAppService) ActivateRevision(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	revisionName string,
+	options *ContainerAppOptions,
+) error {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return fmt.Errorf("getting container app: %w", err)
+	}
+
+	revisionsClient, err := cas.createRevisionsClient(ctx, subscriptionId)
+	if err != nil {
+		return err
+	}
+
+	revision, err := revisionsClient.GetRevision(ctx, resourceGroupName, appName, revisionName, nil)
+	if err != nil {
+		return fmt.Errorf("getting revision %s: %w", revisionName, err)
+	}
+
+	revisionMode, ok := containerApp.GetString(pathConfigurationActiveRevisionsMode)
+	if !ok {
+		return fmt.Errorf("container app is missing active revisions mode configuration")
+	}
+
+	if revisionMode == string(armappcontainers.ActiveRevisionsModeMultiple) {
+		if revision.Properties == nil || !convert.ToValueWithDefault(revision.Properties.Active, false) {
+			if _, err := revisionsClient.ActivateRevision(ctx, resourceGroupName, appName, revisionName, nil); err != nil {
+				return fmt.Errorf("activating revision %s: %w", revisionName, err)
+			}
+		}
+
+		if err := setTrafficWeights(containerApp, map[string]int32{revisionName: 100}); err != nil {
+			return err
+		}
+	} else {
+		if revision.Properties == nil || revision.Properties.Template == nil {
+			return fmt.Errorf("revision %s does not have a template", revisionName)
+		}
+
+		template, err := convert.ToMap(revision.Properties.Template)
+		if err != nil {
+			return fmt.Errorf("converting revision template: %w", err)
+		}
+
+		if err := containerApp.Set(pathTemplate, template); err != nil {
+			return fmt.Errorf("setting template: %w", err)
+		}
+
+		if err := containerApp.Set(pathTemplateRevisionSuffix, fmt.Sprintf("azd-%d", cas.clock.Now().Unix())); err != nil {
+			return fmt.Errorf("setting revision suffix: %w", err)
+		}
+	}
+
+	containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
+	if err != nil {
+		return fmt.Errorf("syncing secrets: %w", err)
+	}
+
+	if err := cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp, options); err != nil {
+		return fmt.Errorf("activating container app revision: %w", err)
+	}
+
+	return nil
+}
+
+// newRevision gets the specified container app and updates its template with a new revision suffix, the image name and
+// the environment variables of the service.
+func (cas *containerAppService) newRevision(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	imageName string,
+	envVars map[string]string,
+	options *ContainerAppOptions,
+) (config.Config, error) {
+	containerApp, err := cas.getContainerApp(ctx, subscriptionId, resourceGroupName, appName, options)
+	if err != nil {
+		return nil, fmt.Errorf("getting container app: %w", err)
+	}
+
 	// Update the template with the new image name and suffix
 	if err := containerApp.Set(pathTemplateRevisionSuffix, fmt.Sprintf("azd-%d", cas.clock.Now().Unix())); err != nil {
-		return fmt.Errorf("setting revision suffix: %w", err)
+		return nil, fmt.Errorf("setting revision suffix: %w", err)
 	}
 
 	var containers []map[string]any
 	if ok, err := containerApp.GetSection(pathTemplateContainers, &containers); !ok || err != nil {
-		return fmt.Errorf("getting containers: %w", err)
+		return nil, fmt.Errorf("getting containers: %w", err)
 	}
 
 	containers[0]["image"] = imageName
@@ -380,46 +921,10 @@ func (cas *containerAppService) AddRevision(
 	}
 
 	if err := containerApp.Set(pathTemplateContainers, containers); err != nil {
-		return fmt.Errorf("setting containers: %w", err)
+		return nil, fmt.Errorf("setting containers: %w", err)
 	}
 
-	containerApp, err = cas.syncSecrets(ctx, subscriptionId, resourceGroupName, appName, containerApp)
-	if err != nil {
-		return fmt.Errorf("syncing secrets: %w", err)
-	}
-
-	revisionMode, ok := containerApp.GetString(pathConfigurationActiveRevisionsMode)
-	if !ok {
-		return fmt.Errorf("container app is missing active revisions mode configuration")
-	}
-
-	// If the container app is in multiple revision mode, update the traffic to point to the new revision.
-	if revisionMode == string(armappcontainers.ActiveRevisionsModeMultiple) {
-		revisionSuffix, _ := containerApp.GetString(pathTemplateRevisionSuffix)
-		newRevisionName := fmt.Sprintf("%s--%s", appName, revisionSuffix)
-
-		trafficWeights := []*armappcontainers.TrafficWeight{
-			{
-				RevisionName: &newRevisionName,
-				Weight:       to.Ptr[int32](100),
-			},
-		}
-
-		trafficWeightsJson, err := convert.ToJsonArray(trafficWeights)
-		if err != nil {
-			return fmt.Errorf("converting traffic weights to JSON: %w", err)
-		}
-		if err := containerApp.Set(pathConfigurationIngressTraffic, trafficWeightsJson); err != nil {
-			return fmt.Errorf("setting traffic weights: %w", err)
-		}
-	}
-
-	err = cas.updateContainerApp(ctx, subscriptionId, resourceGroupName, appName, containerApp, options)
-	if err != nil {
-		return fmt.Errorf("updating container app revision: %w", err)
-	}
-
-	return nil
+	return containerApp, nil
 }
 
 func (cas *containerAppService) syncSecrets(
@@ -580,6 +1085,23 @@ func (cas *containerAppService) createContainerAppsClient(
 	return client, nil
 }
 
+func (cas *containerAppService) createRevisionsClient(
+	ctx context.Context,
+	subscriptionId string,
+) (*armappcontainers.ContainerAppsRevisionsClient, error) {
+	credential, err := cas.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := armappcontainers.NewContainerAppsRevisionsClient(subscriptionId, credential, cas.armClientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("creating ContainerAppsRevisions client: %w", err)
+	}
+
+	return client, nil
+}
+
 func (cas *containerAppService) createJobsClient(
 	ctx context.Context,
 	subscriptionId string,
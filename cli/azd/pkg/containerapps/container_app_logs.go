@@ -0,0 +1,174 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package containerapps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v3"
+	"github.com/azure/azure-dev/cli/azd/pkg/azsdk"
+)
+
+// The number of recent log lines returned by the container apps log stream endpoints
+const logStreamTailLines = 100
+
+// StreamLogs writes the system logs and the console logs of every container in the latest ready revision of the
+// container app to the writer. When follow is false, only the recent logs are written.
+func (cas *containerAppService) StreamLogs(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	follow bool,
+	writer io.Writer,
+) error {
+	appClient, err := cas.createContainerAppsClient(ctx, subscriptionId, nil)
+	if err != nil {
+		return err
+	}
+
+	containerApp, err := appClient.Get(ctx, resourceGroupName, appName, nil)
+	if err != nil {
+		return fmt.Errorf("getting container app: %w", err)
+	}
+
+	if containerApp.Properties == nil || containerApp.Properties.EventStreamEndpoint == nil {
+		return fmt.Errorf("container app %s does not expose a log stream endpoint", appName)
+	}
+
+	authToken, err := appClient.GetAuthToken(ctx, resourceGroupName, appName, nil)
+	if err != nil {
+		return fmt.Errorf("getting container app auth token: %w", err)
+	}
+
+	if authToken.Properties == nil || authToken.Properties.Token == nil {
+		return fmt.Errorf("container app %s did not return an auth token", appName)
+	}
+
+	// The event stream endpoint is a full url to the system logs of the app, all log streams share its base url
+	eventStreamEndpoint := *containerApp.Properties.EventStreamEndpoint
+	baseIndex := strings.Index(eventStreamEndpoint, "/subscriptions/")
+	if baseIndex < 0 {
+		return fmt.Errorf("unexpected log stream endpoint for container app %s: %s", appName, eventStreamEndpoint)
+	}
+
+	appUrl := fmt.Sprintf(
+		"%s/subscriptions/%s/resourceGroups/%s/containerApps/%s",
+		eventStreamEndpoint[:baseIndex],
+		url.PathEscape(subscriptionId),
+		url.PathEscape(resourceGroupName),
+		url.PathEscape(appName),
+	)
+
+	query := url.Values{}
+	query.Set("follow", strconv.FormatBool(follow))
+	query.Set("tailLines", strconv.Itoa(logStreamTailLines))
+	query.Set("output", "text")
+
+	streams := map[string]string{
+		"[system] ": fmt.Sprintf("%s/eventstream?%s", appUrl, query.Encode()),
+	}
+
+	if revisionName := containerApp.Properties.LatestReadyRevisionName; revisionName != nil && *revisionName != "" {
+		containerUrls, err := cas.containerLogStreamUrls(ctx, subscriptionId, resourceGroupName, appName, *revisionName)
+		if err != nil {
+			return err
+		}
+
+		for prefix, containerUrl := range containerUrls {
+			streams[prefix] = fmt.Sprintf("%s/%s?%s", appUrl, containerUrl, query.Encode())
+		}
+	}
+
+	client, err := azsdk.NewLogStreamClient(nil, cas.armClientOptions)
+	if err != nil {
+		return fmt.Errorf("creating log stream client: %w", err)
+	}
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + *authToken.Properties.Token,
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 0, len(streams))
+	var errsMu sync.Mutex
+
+	for prefix, streamUrl := range streams {
+		wg.Go(func() {
+			err := client.Stream(ctx, streamUrl, &azsdk.LogStreamOptions{
+				Headers:    headers,
+				LinePrefix: prefix,
+			}, writer)
+			if err != nil {
+				errsMu.Lock()
+				errs = append(errs, err)
+				errsMu.Unlock()
+			}
+		})
+	}
+
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("streaming logs for container app %s: %w", appName, err)
+	}
+
+	return nil
+}
+
+// containerLogStreamUrls returns the log stream paths, relative to the container app, of every container running in
+// the replicas of the revision, keyed by the line prefix used for the container.
+func (cas *containerAppService) containerLogStreamUrls(
+	ctx context.Context,
+	subscriptionId string,
+	resourceGroupName string,
+	appName string,
+	revisionName string,
+) (map[string]string, error) {
+	credential, err := cas.credentialProvider.CredentialForSubscription(ctx, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+
+	replicasClient, err := armappcontainers.NewContainerAppsRevisionReplicasClient(
+		subscriptionId, credential, cas.armClientOptions)
+	if err != nil {
+		return nil, fmt.Errorf("creating ContainerAppsRevisionReplicas client: %w", err)
+	}
+
+	replicas, err := replicasClient.ListReplicas(ctx, resourceGroupName, appName, revisionName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("listing container app replicas: %w", err)
+	}
+
+	urls := map[string]string{}
+	for _, replica := range replicas.Value {
+		if replica == nil || replica.Name == nil || replica.Properties == nil {
+			continue
+		}
+
+		for _, container := range replica.Properties.Containers {
+			if container == nil || container.Name == nil {
+				continue
+			}
+
+			prefix := fmt.Sprintf("[%s/%s] ", *replica.Name, *container.Name)
+			urls[prefix] = fmt.Sprintf(
+				"revisions/%s/replicas/%s/containers/%s/logstream",
+				url.PathEscape(revisionName),
+				url.PathEscape(*replica.Name),
+				url.PathEscape(*container.Name),
+			)
+		}
+	}
+
+	return urls, nil
+}
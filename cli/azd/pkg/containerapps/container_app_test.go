@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
@@ -1076,3 +1077,368 @@ properties:
 	// No Dapr configuration should be injected on first deploy
 	require.Nil(t, actual.Properties.Configuration.Dapr)
 }
+
+func Test_ContainerApp_ActivateRevision(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	previousRevisionName := "APP_NAME--azd-1"
+	previousImageName := "PREVIOUS_IMAGE_NAME"
+
+	newContainerApp := func(revisionMode armappcontainers.ActiveRevisionsMode) *armappcontainers.ContainerApp {
+		return &armappcontainers.ContainerApp{
+			Location: &location,
+			Name:     &appName,
+			Properties: &armappcontainers.ContainerAppProperties{
+				LatestRevisionName: new("APP_NAME--azd-2"),
+				Configuration: &armappcontainers.Configuration{
+					ActiveRevisionsMode: to.Ptr(revisionMode),
+					Ingress:             &armappcontainers.Ingress{},
+				},
+				Template: &armappcontainers.Template{
+					RevisionSuffix: new("azd-2"),
+					Containers: []*armappcontainers.Container{
+						{
+							Image: new("CURRENT_IMAGE_NAME"),
+						},
+					},
+				},
+			},
+		}
+	}
+
+	previousRevision := &armappcontainers.Revision{
+		Name: &previousRevisionName,
+		Properties: &armappcontainers.RevisionProperties{
+			Active: to.Ptr(false),
+			Template: &armappcontainers.Template{
+				RevisionSuffix: new("azd-1"),
+				Containers: []*armappcontainers.Container{
+					{
+						Image: &previousImageName,
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("SingleRevisionMode", func(t *testing.T) {
+		containerApp := newContainerApp(armappcontainers.ActiveRevisionsModeSingle)
+
+		mockContext := mocks.NewMockContext(context.Background())
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+		_ = mockazsdk.MockContainerAppRevisionGet(
+			mockContext, subscriptionId, resourceGroup, appName, previousRevisionName, previousRevision)
+		updateContainerAppRequest := mockazsdk.MockContainerAppUpdate(
+			mockContext, subscriptionId, resourceGroup, appName, containerApp)
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+		err := cas.ActivateRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, previousRevisionName, nil)
+		require.NoError(t, err)
+
+		// The template of the previous revision is deployed as a new revision
+		var updatedContainerApp *armappcontainers.ContainerApp
+		err = mocks.ReadHttpBody(updateContainerAppRequest.Body, &updatedContainerApp)
+		require.NoError(t, err)
+		require.Equal(t, previousImageName, *updatedContainerApp.Properties.Template.Containers[0].Image)
+		require.Equal(t, "azd-0", *updatedContainerApp.Properties.Template.RevisionSuffix)
+	})
+
+	t.Run("MultipleRevisionMode", func(t *testing.T) {
+		containerApp := newContainerApp(armappcontainers.ActiveRevisionsModeMultiple)
+
+		mockContext := mocks.NewMockContext(context.Background())
+		_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+		_ = mockazsdk.MockContainerAppRevisionGet(
+			mockContext, subscriptionId, resourceGroup, appName, previousRevisionName, previousRevision)
+		updateContainerAppRequest := mockazsdk.MockContainerAppUpdate(
+			mockContext, subscriptionId, resourceGroup, appName, containerApp)
+
+		activated := false
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.Method == http.MethodPost &&
+				strings.HasSuffix(request.URL.Path, fmt.Sprintf("/revisions/%s/activate", previousRevisionName))
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			activated = true
+			return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+		})
+
+		cas := NewContainerAppService(
+			mockContext.SubscriptionCredentialProvider,
+			clock.NewMock(),
+			mockContext.ArmClientOptions,
+			mockContext.AlphaFeaturesManager,
+		)
+		err := cas.ActivateRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, previousRevisionName, nil)
+		require.NoError(t, err)
+		require.True(t, activated)
+
+		// All traffic is routed to the previous revision, the template is left untouched
+		var updatedContainerApp *armappcontainers.ContainerApp
+		err = mocks.ReadHttpBody(updateContainerAppRequest.Body, &updatedContainerApp)
+		require.NoError(t, err)
+		require.Equal(t, "CURRENT_IMAGE_NAME", *updatedContainerApp.Properties.Template.Containers[0].Image)
+		require.Len(t, updatedContainerApp.Properties.Configuration.Ingress.Traffic, 1)
+		require.Equal(t, previousRevisionName,
+			*updatedContainerApp.Properties.Configuration.Ingress.Traffic[0].RevisionName)
+		require.Equal(t, int32(100), *updatedContainerApp.Properties.Configuration.Ingress.Traffic[0].Weight)
+	})
+}
+
+func Test_ContainerApp_StageRevision(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	previousRevisionName := "APP_NAME--azd-1"
+	newRevisionName := "APP_NAME--azd-0"
+	newRevisionFqdn := "app-name--azd-0.eastus2.azurecontainerapps.io"
+
+	containerApp := &armappcontainers.ContainerApp{
+		Location: &location,
+		Name:     &appName,
+		Properties: &armappcontainers.ContainerAppProperties{
+			LatestRevisionName:      &previousRevisionName,
+			LatestReadyRevisionName: &previousRevisionName,
+			Configuration: &armappcontainers.Configuration{
+				ActiveRevisionsMode: to.Ptr(armappcontainers.ActiveRevisionsModeSingle),
+				Ingress:             &armappcontainers.Ingress{},
+			},
+			Template: &armappcontainers.Template{
+				RevisionSuffix: new("azd-1"),
+				Containers: []*armappcontainers.Container{
+					{
+						Image: new("PREVIOUS_IMAGE_NAME"),
+					},
+				},
+			},
+		},
+	}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+	updateContainerAppRequest := mockazsdk.MockContainerAppUpdate(
+		mockContext, subscriptionId, resourceGroup, appName, containerApp)
+	_ = mockazsdk.MockContainerAppRevisionGet(
+		mockContext, subscriptionId, resourceGroup, appName, newRevisionName, &armappcontainers.Revision{
+			Name: &newRevisionName,
+			Properties: &armappcontainers.RevisionProperties{
+				Fqdn: &newRevisionFqdn,
+			},
+		})
+
+	cas := NewContainerAppService(
+		mockContext.SubscriptionCredentialProvider,
+		clock.NewMock(),
+		mockContext.ArmClientOptions,
+		mockContext.AlphaFeaturesManager,
+	)
+	revision, err := cas.StageRevision(
+		*mockContext.Context, subscriptionId, resourceGroup, appName, "NEW_IMAGE_NAME", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, &StagedRevision{
+		Name:                  newRevisionName,
+		HostName:              newRevisionFqdn,
+		PreviousName:          previousRevisionName,
+		PreviousRevisionsMode: string(armappcontainers.ActiveRevisionsModeSingle),
+	}, revision)
+
+	// The app is switched to multiple revision mode and the previous revision keeps serving all traffic
+	var updatedContainerApp *armappcontainers.ContainerApp
+	err = mocks.ReadHttpBody(updateContainerAppRequest.Body, &updatedContainerApp)
+	require.NoError(t, err)
+	require.Equal(t, "NEW_IMAGE_NAME", *updatedContainerApp.Properties.Template.Containers[0].Image)
+	require.Equal(t,
+		armappcontainers.ActiveRevisionsModeMultiple, *updatedContainerApp.Properties.Configuration.ActiveRevisionsMode)
+	require.Len(t, updatedContainerApp.Properties.Configuration.Ingress.Traffic, 1)
+	require.Equal(t, previousRevisionName,
+		*updatedContainerApp.Properties.Configuration.Ingress.Traffic[0].RevisionName)
+	require.Equal(t, int32(100), *updatedContainerApp.Properties.Configuration.Ingress.Traffic[0].Weight)
+}
+
+func Test_ContainerApp_StageRevision_AfterAbortedRollout(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	servingRevisionName := "APP_NAME--azd-1"
+
+	containerApp := &armappcontainers.ContainerApp{
+		Location: &location,
+		Name:     &appName,
+		Properties: &armappcontainers.ContainerAppProperties{
+			LatestRevisionName:      &servingRevisionName,
+			LatestReadyRevisionName: &servingRevisionName,
+			Configuration: &armappcontainers.Configuration{
+				ActiveRevisionsMode: to.Ptr(armappcontainers.ActiveRevisionsModeSingle),
+				Ingress:             &armappcontainers.Ingress{},
+			},
+			Template: &armappcontainers.Template{
+				RevisionSuffix: new("azd-1"),
+				Containers: []*armappcontainers.Container{
+					{
+						Image: new("PREVIOUS_IMAGE_NAME"),
+					},
+				},
+			},
+		},
+	}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+
+	// Updates are applied to the container app returned by the GET mock, a new revision suffix creates a new
+	// revision that becomes the latest (and ready) revision of the app
+	var lastUpdate *armappcontainers.ContainerApp
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPatch && strings.Contains(request.URL.Path, "/containerApps/APP_NAME")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		if err := mocks.ReadHttpBody(request.Body, &lastUpdate); err != nil {
+			return nil, err
+		}
+
+		containerApp.Properties.Configuration = lastUpdate.Properties.Configuration
+		containerApp.Properties.Template = lastUpdate.Properties.Template
+		revisionName := fmt.Sprintf("%s--%s", appName, *lastUpdate.Properties.Template.RevisionSuffix)
+		containerApp.Properties.LatestRevisionName = &revisionName
+		containerApp.Properties.LatestReadyRevisionName = &revisionName
+
+		return mocks.CreateHttpResponseWithBody(
+			request, http.StatusAccepted, armappcontainers.ContainerAppsClientUpdateResponse{})
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && strings.Contains(request.URL.Path, "/containerApps/APP_NAME/revisions/")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		revisionName := request.URL.Path[strings.LastIndex(request.URL.Path, "/")+1:]
+		response := armappcontainers.ContainerAppsRevisionsClientGetRevisionResponse{
+			Revision: armappcontainers.Revision{
+				Name: &revisionName,
+				Properties: &armappcontainers.RevisionProperties{
+					Fqdn: new(strings.ToLower(revisionName) + ".eastus2.azurecontainerapps.io"),
+				},
+			},
+		}
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, response)
+	})
+
+	var deactivatedRevisions []string
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost && strings.HasSuffix(request.URL.Path, "/deactivate")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		revisionPath := strings.TrimSuffix(request.URL.Path, "/deactivate")
+		deactivatedRevisions = append(deactivatedRevisions, revisionPath[strings.LastIndex(revisionPath, "/")+1:])
+
+		return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+	})
+
+	clk := clock.NewMock()
+	cas := NewContainerAppService(
+		mockContext.SubscriptionCredentialProvider,
+		clk,
+		mockContext.ArmClientOptions,
+		mockContext.AlphaFeaturesManager,
+	)
+
+	// First deploy stages a revision that fails its health check and is aborted
+	abortedRevision, err := cas.StageRevision(
+		*mockContext.Context, subscriptionId, resourceGroup, appName, "BROKEN_IMAGE_NAME", nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, servingRevisionName, abortedRevision.PreviousName)
+
+	err = cas.SetRevisionTraffic(*mockContext.Context, subscriptionId, resourceGroup, appName, map[string]int32{
+		abortedRevision.Name: 0,
+		servingRevisionName:  100,
+	}, nil)
+	require.NoError(t, err)
+	err = cas.DeactivateRevision(*mockContext.Context, subscriptionId, resourceGroup, appName, abortedRevision.Name)
+	require.NoError(t, err)
+	require.Equal(t, []string{abortedRevision.Name}, deactivatedRevisions)
+	err = cas.SetActiveRevisionsMode(*mockContext.Context, subscriptionId, resourceGroup, appName,
+		abortedRevision.PreviousRevisionsMode, servingRevisionName, nil)
+	require.NoError(t, err)
+
+	// The original revisions mode is restored with the traffic pinned to the revision serving it
+	require.Equal(t,
+		armappcontainers.ActiveRevisionsModeSingle, *lastUpdate.Properties.Configuration.ActiveRevisionsMode)
+	require.Len(t, lastUpdate.Properties.Configuration.Ingress.Traffic, 1)
+	require.Equal(t, servingRevisionName, *lastUpdate.Properties.Configuration.Ingress.Traffic[0].RevisionName)
+
+	// The aborted revision is now the latest revision of the app, but the next deploy must still be staged
+	// against the revision serving traffic
+	require.Equal(t, abortedRevision.Name, *containerApp.Properties.LatestRevisionName)
+	clk.Add(time.Minute)
+
+	revision, err := cas.StageRevision(
+		*mockContext.Context, subscriptionId, resourceGroup, appName, "NEW_IMAGE_NAME", nil, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, abortedRevision.Name, revision.Name)
+	require.Equal(t, servingRevisionName, revision.PreviousName)
+
+	require.Len(t, lastUpdate.Properties.Configuration.Ingress.Traffic, 1)
+	require.Equal(t, servingRevisionName, *lastUpdate.Properties.Configuration.Ingress.Traffic[0].RevisionName)
+	require.Equal(t, int32(100), *lastUpdate.Properties.Configuration.Ingress.Traffic[0].Weight)
+}
+
+func Test_ContainerApp_GetStatus(t *testing.T) {
+	subscriptionId := "SUBSCRIPTION_ID"
+	location := "eastus2"
+	resourceGroup := "RESOURCE_GROUP"
+	appName := "APP_NAME"
+	createdTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	containerApp := &armappcontainers.ContainerApp{
+		Location: &location,
+		Name:     &appName,
+		Properties: &armappcontainers.ContainerAppProperties{
+			ProvisioningState:       to.Ptr(armappcontainers.ContainerAppProvisioningStateSucceeded),
+			RunningStatus:           to.Ptr(armappcontainers.ContainerAppRunningStatusRunning),
+			LatestRevisionName:      new("APP_NAME--azd-2"),
+			LatestReadyRevisionName: new("APP_NAME--azd-2"),
+			Configuration: &armappcontainers.Configuration{
+				Ingress: &armappcontainers.Ingress{
+					Traffic: []*armappcontainers.TrafficWeight{
+						{LatestRevision: to.Ptr(true), Weight: to.Ptr(int32(80))},
+						{RevisionName: new("APP_NAME--azd-1"), Weight: to.Ptr(int32(20))},
+					},
+				},
+			},
+		},
+	}
+
+	revision := &armappcontainers.Revision{
+		Name: new("APP_NAME--azd-2"),
+		Properties: &armappcontainers.RevisionProperties{
+			Replicas:    to.Ptr(int32(3)),
+			CreatedTime: &createdTime,
+		},
+	}
+
+	mockContext := mocks.NewMockContext(context.Background())
+	_ = mockazsdk.MockContainerAppGet(mockContext, subscriptionId, resourceGroup, appName, containerApp)
+	_ = mockazsdk.MockContainerAppRevisionGet(
+		mockContext, subscriptionId, resourceGroup, appName, "APP_NAME--azd-2", revision)
+
+	cas := NewContainerAppService(
+		mockContext.SubscriptionCredentialProvider,
+		clock.NewMock(),
+		mockContext.ArmClientOptions,
+		mockContext.AlphaFeaturesManager,
+	)
+	status, err := cas.GetStatus(*mockContext.Context, subscriptionId, resourceGroup, appName, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, "Running", status.RunningStatus)
+	require.Equal(t, "Succeeded", status.ProvisioningState)
+	require.Equal(t, "APP_NAME--azd-2", status.LatestReadyRevision)
+	require.Equal(t, map[string]int{"APP_NAME--azd-2": 80, "APP_NAME--azd-1": 20}, status.Traffic)
+	require.Equal(t, 3, status.Replicas)
+	require.True(t, createdTime.Equal(*status.LastDeployed))
+}
@@ -2,6 +2,8 @@
 // Licensed under the MIT License.
 package contracts
 
+import "time"
+
 // ShowType are the values for the language property of a ShowServiceProject
 type ShowType string
 
@@ -44,3 +46,51 @@ type ShowServiceProject struct {
 type ShowTargetArm struct {
 	ResourceIds []string `json:"resourceIds"`
 }
+
+// ShowServiceState is the running state of a deployed service as returned by `azd show --watch`
+type ShowServiceState string
+
+const (
+	ShowServiceStateRunning   ShowServiceState = "running"
+	ShowServiceStateStopped   ShowServiceState = "stopped"
+	ShowServiceStateDeploying ShowServiceState = "deploying"
+	ShowServiceStateFailed    ShowServiceState = "failed"
+	ShowServiceStateUnknown   ShowServiceState = "unknown"
+)
+
+// ShowEndpointHealth is the result of requesting the endpoint of a deployed service
+type ShowEndpointHealth string
+
+const (
+	ShowEndpointHealthHealthy   ShowEndpointHealth = "healthy"
+	ShowEndpointHealthUnhealthy ShowEndpointHealth = "unhealthy"
+	ShowEndpointHealthUnknown   ShowEndpointHealth = "unknown"
+)
+
+// ShowStatusResult is the contract for each refresh of `azd show --watch`, written as a single line of JSON
+type ShowStatusResult struct {
+	Name        string    `json:"name"`
+	Environment string    `json:"environment"`
+	Timestamp   time.Time `json:"timestamp"`
+	// Services contains the status of every service, sorted by name
+	Services []ShowServiceStatus `json:"services"`
+}
+
+// ShowServiceStatus is the contract for the runtime status of a service returned by `azd show --watch`
+type ShowServiceStatus struct {
+	Name       string           `json:"name"`
+	Host       string           `json:"host"`
+	ResourceId string           `json:"resourceId,omitempty"`
+	Status     ShowServiceState `json:"status"`
+	// Revision is the host specific version serving the service, like the active revisions of a container app or the
+	// rollout revision of a k8s deployment
+	Revision        string     `json:"revision,omitempty"`
+	ReadyReplicas   *int       `json:"readyReplicas,omitempty"`
+	DesiredReplicas *int       `json:"desiredReplicas,omitempty"`
+	LastDeployed    *time.Time `json:"lastDeployed,omitempty"`
+	Endpoint        string     `json:"endpoint,omitempty"`
+	// EndpointHealth is unknown when the service has no endpoint
+	EndpointHealth ShowEndpointHealth `json:"endpointHealth"`
+	// Error describes why the status of the service could not be fully determined
+	Error string `json:"error,omitempty"`
+}
@@ -249,11 +249,20 @@ func (p *ProvisionProvider) Preview(ctx context.Context) (*provisioning.DeployPr
 	return nil, fmt.Errorf("preview is not supported for devcenter")
 }
 
+// CheckDrift checks the drift of the environment from the configured environment definition
+func (p *ProvisionProvider) CheckDrift(ctx context.Context) (*provisioning.DriftResult, error) {
+	return nil, fmt.Errorf("drift detection is not supported for devcenter")
+}
+
 // Destroy destroys the environment by deleting the ADE environment
 func (p *ProvisionProvider) Destroy(
 	ctx context.Context,
 	options provisioning.DestroyOptions,
 ) (*provisioning.DestroyResult, error) {
+	if len(options.ResourceIds()) > 0 {
+		return nil, fmt.Errorf("deleting individual resources is not supported for devcenter")
+	}
+
 	if err := p.config.EnsureValid(); err != nil {
 		return nil, fmt.Errorf("invalid devcenter configuration, %w", err)
 	}
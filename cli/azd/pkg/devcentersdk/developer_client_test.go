@@ -37,6 +37,7 @@ func Test_DevCenter_Client(t *testing.T) {
 		auth.ExternalAuthConfiguration{},
 		azCli,
 		"",
+		"",
 	)
 	require.NoError(t, err)
 
@@ -99,8 +99,13 @@ func (c *AzdContext) SetProjectState(state ProjectState) error {
 		return err
 	}
 
-	// make sure to ignore the environment directory
+	// make sure to ignore the environment directory, unless the project already decided which files to commit, like
+	// the project-level azd configuration
 	path = filepath.Join(c.EnvironmentDirectory(), ".gitignore")
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+
 	return os.WriteFile(path, []byte("# .azure is not intended to be committed\n*"), osutil.PermissionFile)
 }
 
@@ -236,6 +241,22 @@ func writeConfig(path string, config configFile) error {
 		return fmt.Errorf("serializing config file: %w", err)
 	}
 
+	// The file also holds the project-level azd configuration, which is kept as is
+	if existing, err := os.ReadFile(path); err == nil {
+		values := map[string]any{}
+		if err := json.Unmarshal(existing, &values); err == nil {
+			delete(values, "defaultEnvironment")
+			delete(values, "copilotSession")
+			if err := json.Unmarshal(bytes, &values); err != nil {
+				return fmt.Errorf("serializing config file: %w", err)
+			}
+
+			if bytes, err = json.Marshal(values); err != nil {
+				return fmt.Errorf("serializing config file: %w", err)
+			}
+		}
+	}
+
 	if err := os.MkdirAll(filepath.Dir(path), osutil.PermissionDirectory); err != nil {
 		return fmt.Errorf("creating environment root: %w", err)
 	}
@@ -213,3 +213,30 @@ func TestProjectName(t *testing.T) {
 		})
 	}
 }
+
+func TestSetProjectState_KeepsProjectConfig(t *testing.T) {
+	ctx := NewAzdContextWithDirectory(t.TempDir())
+	require.NoError(t, os.MkdirAll(ctx.EnvironmentDirectory(), 0755))
+
+	configPath := filepath.Join(ctx.EnvironmentDirectory(), ConfigFileName)
+	require.NoError(t, os.WriteFile(
+		configPath, []byte(`{"version":1,"defaultEnvironment":"dev","defaults":{"location":"westus2"}}`), 0600))
+
+	gitignorePath := filepath.Join(ctx.EnvironmentDirectory(), ".gitignore")
+	require.NoError(t, os.WriteFile(gitignorePath, []byte("*\n!config.json"), 0600))
+
+	require.NoError(t, ctx.SetProjectState(ProjectState{DefaultEnvironment: "prod"}))
+
+	defaultEnv, err := ctx.GetDefaultEnvironmentName()
+	require.NoError(t, err)
+	require.Equal(t, "prod", defaultEnv)
+
+	contents, err := os.ReadFile(configPath)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"version":1,"defaultEnvironment":"prod","defaults":{"location":"westus2"}}`, string(contents))
+
+	// The .gitignore of the project is kept
+	gitignore, err := os.ReadFile(gitignorePath)
+	require.NoError(t, err)
+	require.Equal(t, "*\n!config.json", string(gitignore))
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package errorhandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+)
+
+// PolicyViolationHandler provides suggestions for changes of the configuration
+// the azd policy of the machine doesn't allow, naming the policy file and the
+// enforced or blocked key.
+type PolicyViolationHandler struct{}
+
+// NewPolicyViolationHandler creates a new PolicyViolationHandler.
+func NewPolicyViolationHandler() ErrorHandler {
+	return &PolicyViolationHandler{}
+}
+
+func (h *PolicyViolationHandler) Handle(
+	ctx context.Context, err error, rule ErrorSuggestionRule,
+) *ErrorWithSuggestion {
+	violation, ok := errors.AsType[*config.PolicyViolationError](err)
+	if !ok {
+		return nil
+	}
+
+	var msg, suggestion string
+	if violation.Blocked {
+		msg = fmt.Sprintf(
+			"The azd policy of your organization blocks '%s'.",
+			violation.PolicyKey,
+		)
+		suggestion = fmt.Sprintf(
+			"'%s' can't be set on this machine. "+
+				"Use another value, or contact the administrator "+
+				"of the policy in '%s' to allow it.",
+			violation.PolicyKey,
+			violation.Path,
+		)
+	} else {
+		msg = fmt.Sprintf(
+			"The azd policy of your organization enforces '%s'.",
+			violation.PolicyKey,
+		)
+		suggestion = fmt.Sprintf(
+			"Run 'azd config get %s --show-origin' to see the "+
+				"enforced value. To change it, contact the "+
+				"administrator of the policy in '%s'.",
+			violation.PolicyKey,
+			violation.Path,
+		)
+	}
+
+	// Merge links from the YAML rule
+	links := make([]ErrorLink, len(rule.Links))
+	for i, l := range rule.Links {
+		links[i] = ErrorLink(l)
+	}
+
+	return &ErrorWithSuggestion{
+		Err:        err,
+		Message:    msg,
+		Suggestion: suggestion,
+		Links:      links,
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package errorhandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicyViolationHandler_Enforced(t *testing.T) {
+	err := fmt.Errorf("failed setting configuration value: %w", &config.PolicyViolationError{
+		Key:       "provision.preflight",
+		PolicyKey: "provision.preflight",
+		Path:      "/etc/azd/policy.json",
+	})
+
+	result := NewPolicyViolationHandler().Handle(context.Background(), err, ErrorSuggestionRule{})
+
+	require.NotNil(t, result)
+	assert.Equal(t, err, result.Err)
+	assert.Equal(t, "The azd policy of your organization enforces 'provision.preflight'.", result.Message)
+	assert.Contains(t, result.Suggestion, "azd config get provision.preflight --show-origin")
+	assert.Contains(t, result.Suggestion, "/etc/azd/policy.json")
+}
+
+func TestPolicyViolationHandler_Blocked(t *testing.T) {
+	err := &config.PolicyViolationError{
+		Key:       "template.sources.awesome-azd",
+		PolicyKey: "template.sources.awesome-azd",
+		Blocked:   true,
+		Path:      "/etc/azd/policy.json",
+	}
+
+	result := NewPolicyViolationHandler().Handle(context.Background(), err, ErrorSuggestionRule{})
+
+	require.NotNil(t, result)
+	assert.Equal(t, "The azd policy of your organization blocks 'template.sources.awesome-azd'.", result.Message)
+	assert.Contains(t, result.Suggestion, "/etc/azd/policy.json")
+}
+
+func TestPolicyViolationHandler_OtherError(t *testing.T) {
+	result := NewPolicyViolationHandler().Handle(
+		context.Background(), errors.New("something else"), ErrorSuggestionRule{})
+
+	assert.Nil(t, result)
+}
+
+func TestPipeline_PolicyViolation(t *testing.T) {
+	pipeline := NewErrorHandlerPipeline(func(name string) (ErrorHandler, error) {
+		require.Equal(t, "policyViolationHandler", name)
+		return NewPolicyViolationHandler(), nil
+	})
+
+	err := fmt.Errorf("'azd down --force' can't run with --no-prompt: %w", &config.PolicyViolationError{
+		Key:       "down.forceNoPrompt",
+		PolicyKey: "down.forceNoPrompt",
+		Path:      "/etc/azd/policy.json",
+	})
+
+	result := pipeline.Process(context.Background(), err)
+
+	require.NotNil(t, result)
+	assert.Equal(t, "The azd policy of your organization enforces 'down.forceNoPrompt'.", result.Message)
+}
@@ -0,0 +1,251 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/rzip"
+)
+
+const (
+	// bundleRegistryFileName is the name of the registry file at the root of an extension bundle.
+	bundleRegistryFileName = "registry.json"
+	// bundleArtifactsDir is the directory within an extension bundle that contains the extension artifacts.
+	bundleArtifactsDir = "artifacts"
+	// bundlesCacheSubDir is the directory under the extensions cache where bundles are extracted.
+	bundlesCacheSubDir = "bundles"
+	// bundleChecksumAlgorithm is the algorithm used for artifacts that do not publish a checksum.
+	bundleChecksumAlgorithm = "sha256"
+)
+
+// BundleOptions controls which artifacts are included in an extension bundle.
+type BundleOptions struct {
+	// Platforms limits the bundled artifacts to the specified platforms (e.g. linux/amd64).
+	// All platforms are bundled when empty.
+	Platforms []string
+}
+
+// CreateBundle resolves the requested extensions and their dependencies, and writes an offline bundle archive to
+// outputPath. The bundle contains a registry.json with the resolved versions and the per-platform artifacts, and can be
+// added as an extension source of type 'bundle' on machines without internet access.
+func (m *Manager) CreateBundle(
+	ctx context.Context,
+	requirements []ExtensionRequirement,
+	outputPath string,
+	options *BundleOptions,
+) ([]*ResolvedExtension, error) {
+	if options == nil {
+		options = &BundleOptions{}
+	}
+
+	resolved, err := NewDependencyResolver(m).Resolve(ctx, requirements)
+	if err != nil {
+		return nil, err
+	}
+
+	stagingDir, err := os.MkdirTemp("", "azd-extension-bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("failed creating staging directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	registry := &Registry{
+		Extensions: make([]*ExtensionMetadata, 0, len(resolved)),
+	}
+
+	for _, r := range resolved {
+		version := *r.Version
+		version.Artifacts = map[string]ExtensionArtifact{}
+
+		for _, platform := range slices.Sorted(maps.Keys(r.Version.Artifacts)) {
+			if len(options.Platforms) > 0 && !slices.Contains(options.Platforms, platform) {
+				continue
+			}
+
+			artifact, err := m.bundleArtifact(ctx, stagingDir, r.Metadata.Id, version.Version, platform,
+				r.Version.Artifacts[platform])
+			if err != nil {
+				return nil, fmt.Errorf("failed bundling %s@%s (%s): %w", r.Metadata.Id, version.Version, platform, err)
+			}
+
+			version.Artifacts[platform] = artifact
+		}
+
+		if len(r.Version.Artifacts) > 0 && len(version.Artifacts) == 0 {
+			return nil, fmt.Errorf(
+				"extension %s@%s does not provide artifacts for platforms %s",
+				r.Metadata.Id,
+				version.Version,
+				strings.Join(options.Platforms, ", "),
+			)
+		}
+
+		metadata := *r.Metadata
+		metadata.Source = ""
+		metadata.Versions = []ExtensionVersion{version}
+		registry.Extensions = append(registry.Extensions, &metadata)
+	}
+
+	registryBytes, err := json.MarshalIndent(registry, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed marshalling bundle registry: %w", err)
+	}
+
+	registryPath := filepath.Join(stagingDir, bundleRegistryFileName)
+	if err := os.WriteFile(registryPath, registryBytes, osutil.PermissionFile); err != nil {
+		return nil, fmt.Errorf("failed writing bundle registry: %w", err)
+	}
+
+	bundleFile, err := os.Create(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating bundle file: %w", err)
+	}
+	defer bundleFile.Close()
+
+	if err := rzip.CreateFromDirectory(stagingDir, bundleFile, nil); err != nil {
+		return nil, fmt.Errorf("failed writing bundle file: %w", err)
+	}
+
+	return resolved, nil
+}
+
+// bundleArtifact downloads an artifact into the bundle staging directory, verifying its checksum, and returns the
+// artifact entry pointing at its location relative to the bundle root.
+func (m *Manager) bundleArtifact(
+	ctx context.Context,
+	stagingDir string,
+	extensionId string,
+	version string,
+	platform string,
+	artifact ExtensionArtifact,
+) (ExtensionArtifact, error) {
+	tempFilePath, err := m.downloadArtifact(ctx, artifact.URL)
+	if err != nil {
+		return artifact, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	defer os.Remove(tempFilePath)
+
+	if artifact.Checksum.Value == "" {
+		// Record a checksum so installs from the bundle are always verified
+		value, err := computeChecksum(tempFilePath, bundleChecksumAlgorithm)
+		if err != nil {
+			return artifact, err
+		}
+
+		artifact.Checksum = ExtensionChecksum{Algorithm: bundleChecksumAlgorithm, Value: value}
+	} else if err := validateChecksum(tempFilePath, artifact.Checksum); err != nil {
+		return artifact, fmt.Errorf("checksum validation failed: %w", err)
+	}
+
+	relativePath := path.Join(
+		bundleArtifactsDir,
+		extensionId,
+		version,
+		strings.ReplaceAll(platform, "/", "-"),
+		filepath.Base(tempFilePath),
+	)
+
+	targetPath := filepath.Join(stagingDir, filepath.FromSlash(relativePath))
+	if err := os.MkdirAll(filepath.Dir(targetPath), osutil.PermissionDirectory); err != nil {
+		return artifact, fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+
+	if err := copyFile(tempFilePath, targetPath); err != nil {
+		return artifact, err
+	}
+
+	artifact.URL = relativePath
+
+	return artifact, nil
+}
+
+// newBundleSource creates a new extension source from an offline bundle created by 'azd extension bundle'.
+// The bundle is extracted into the azd cache and its artifacts are installed from the local file system.
+func newBundleSource(name string, bundlePath string) (Source, error) {
+	absolutePath, err := getAbsolutePath(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed converting path '%s' to absolute path, %w", bundlePath, err)
+	}
+
+	bundleDir, err := extractBundle(absolutePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed extracting bundle '%s', %w", bundlePath, err)
+	}
+
+	registryBytes, err := os.ReadFile(filepath.Join(bundleDir, bundleRegistryFileName))
+	if err != nil {
+		return nil, fmt.Errorf("bundle '%s' does not contain a %s file, %w", bundlePath, bundleRegistryFileName, err)
+	}
+
+	var registry *Registry
+	if err := json.Unmarshal(registryBytes, &registry); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal bundle registry %w", err)
+	}
+
+	for _, extension := range registry.Extensions {
+		for i := range extension.Versions {
+			version := &extension.Versions[i]
+			for platform, artifact := range version.Artifacts {
+				localPath, err := resolveBundleArtifact(bundleDir, artifact)
+				if err != nil {
+					return nil, fmt.Errorf(
+						"invalid artifact for extension %s@%s (%s) in bundle '%s': %w",
+						extension.Id,
+						version.Version,
+						platform,
+						bundlePath,
+						err,
+					)
+				}
+
+				artifact.URL = localPath
+				version.Artifacts[platform] = artifact
+			}
+		}
+	}
+
+	return newRegistrySource(name, registry)
+}
+
+// resolveBundleArtifact returns the absolute path of a bundled artifact. Bundled artifacts must be self-contained and
+// carry a checksum so that offline installs are always verified.
+func resolveBundleArtifact(bundleDir string, artifact ExtensionArtifact) (string, error) {
+	if artifact.Checksum.Value == "" {
+		return "", fmt.Errorf("artifact '%s' is missing a checksum", artifact.URL)
+	}
+
+	if strings.Contains(artifact.URL, "://") || path.IsAbs(artifact.URL) || filepath.IsAbs(artifact.URL) {
+		return "", fmt.Errorf("artifact '%s' must be a path relative to the bundle root", artifact.URL)
+	}
+
+	localPath := filepath.Join(bundleDir, filepath.FromSlash(artifact.URL))
+	if !strings.HasPrefix(localPath, filepath.Clean(bundleDir)+string(os.PathSeparator)) {
+		return "", fmt.Errorf("artifact '%s' is outside of the bundle", artifact.URL)
+	}
+
+	return localPath, nil
+}
+
+// extractBundle extracts the bundle archive into the azd cache, keyed on the bundle checksum, and returns the
+// extracted directory. Bundles that were already extracted are reused.
+func extractBundle(bundlePath string) (string, error) {
+	configDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get config directory: %w", err)
+	}
+
+	bundlesDir := filepath.Join(configDir, cacheSubDir, extensionsCacheSubDir, bundlesCacheSubDir)
+	return rzip.ExtractToCache(bundlePath, bundlesDir, bundleRegistryFileName)
+}
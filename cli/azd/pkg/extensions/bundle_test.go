@@ -0,0 +1,118 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CreateBundle_InstallFromBundleSource(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	mockContext := mocks.NewMockContext(context.Background())
+	createRegistryMocks(mockContext)
+
+	userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+	sourceManager := NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+	lazyRunner := lazy.NewLazy(func() (*Runner, error) {
+		return NewRunner(mockContext.CommandRunner), nil
+	})
+	manager, err := NewManager(userConfigManager, sourceManager, lazyRunner, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	bundlePath := filepath.Join(t.TempDir(), "extensions.zip")
+	resolved, err := manager.CreateBundle(
+		*mockContext.Context,
+		[]ExtensionRequirement{{Id: "test.extension", Version: "1.1.0"}},
+		bundlePath,
+		&BundleOptions{Platforms: []string{"linux", "darwin", "windows"}},
+	)
+	require.NoError(t, err)
+	require.Len(t, resolved, 1)
+
+	err = sourceManager.Add(*mockContext.Context, "offline", &SourceConfig{
+		Type:     SourceKindBundle,
+		Location: bundlePath,
+	})
+	require.NoError(t, err)
+
+	// Use a fresh manager so the configured sources are reloaded
+	manager, err = NewManager(userConfigManager, sourceManager, lazyRunner, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	matches, err := manager.FindExtensions(*mockContext.Context, &FilterOptions{Id: "test.extension", Source: "offline"})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Len(t, matches[0].Versions, 1)
+
+	version := matches[0].Versions[0]
+	require.Equal(t, "1.1.0", version.Version)
+	for _, artifact := range version.Artifacts {
+		require.True(t, filepath.IsAbs(artifact.URL))
+		require.Equal(t, bundleChecksumAlgorithm, artifact.Checksum.Algorithm)
+		require.NotEmpty(t, artifact.Checksum.Value)
+	}
+
+	extensionVersion, err := manager.Install(*mockContext.Context, matches[0], "")
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", extensionVersion.Version)
+
+	installed, err := manager.GetInstalled(FilterOptions{Id: "test.extension"})
+	require.NoError(t, err)
+	require.Equal(t, "offline", installed.Source)
+}
+
+func Test_CreateBundle_UnknownPlatform(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	mockContext := mocks.NewMockContext(context.Background())
+	createRegistryMocks(mockContext)
+
+	userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+	sourceManager := NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+	manager, err := NewManager(userConfigManager, sourceManager, nil, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	_, err = manager.CreateBundle(
+		*mockContext.Context,
+		[]ExtensionRequirement{{Id: "test.extension"}},
+		filepath.Join(t.TempDir(), "extensions.zip"),
+		&BundleOptions{Platforms: []string{"plan9/amd64"}},
+	)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not provide artifacts for platforms plan9/amd64")
+}
+
+func Test_ResolveBundleArtifact(t *testing.T) {
+	bundleDir := t.TempDir()
+	checksum := ExtensionChecksum{Algorithm: "sha256", Value: "abc"}
+
+	localPath, err := resolveBundleArtifact(bundleDir, ExtensionArtifact{
+		URL:      "artifacts/test.extension/1.0.0/linux-amd64/azd-ext",
+		Checksum: checksum,
+	})
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(bundleDir, "artifacts", "test.extension", "1.0.0", "linux-amd64", "azd-ext"), localPath)
+
+	tests := map[string]ExtensionArtifact{
+		"MissingChecksum": {URL: "artifacts/azd-ext"},
+		"RemoteUrl":       {URL: "https://example.com/azd-ext", Checksum: checksum},
+		"AbsolutePath":    {URL: "/tmp/azd-ext", Checksum: checksum},
+		"PathTraversal":   {URL: "../azd-ext", Checksum: checksum},
+	}
+
+	for name, artifact := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := resolveBundleArtifact(bundleDir, artifact)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+)
+
+const (
+	// DaemonEnvVar opts into daemon mode for extensions that declare the daemon capability.
+	DaemonEnvVar = "AZD_EXT_DAEMON"
+	// DaemonIdleTimeoutEnvVar overrides the idle timeout (in seconds) after which a daemon exits.
+	DaemonIdleTimeoutEnvVar = "AZD_EXT_DAEMON_IDLE_TIMEOUT"
+	// DaemonStateFileEnvVar is set by azd on daemon processes with the path of the state file to write.
+	DaemonStateFileEnvVar = "AZD_DAEMON_STATE_FILE"
+	// DaemonSecretEnvVar is set by azd on daemon processes with the secret required to call the daemon.
+	DaemonSecretEnvVar = "AZD_DAEMON_SECRET"
+
+	// DefaultDaemonIdleTimeout is the default duration a daemon stays alive without an attached azd invocation.
+	DefaultDaemonIdleTimeout = 10 * time.Minute
+
+	daemonsDir = "daemons"
+)
+
+// ErrDaemonNotRunning indicates there is no daemon state recorded for an extension.
+var ErrDaemonNotRunning = errors.New("extension daemon is not running")
+
+// DaemonState is written by an extension daemon once it is listening, and is used by later azd invocations to
+// reconnect to the daemon.
+type DaemonState struct {
+	// Pid is the process id of the daemon
+	Pid int `json:"pid"`
+	// Address is the address of the daemon gRPC endpoint
+	Address string `json:"address"`
+	// Secret is required in the authorization metadata of every call to the daemon
+	Secret string `json:"secret"`
+}
+
+// DaemonEnabled returns true when the user opted into daemon mode and the extension supports it.
+func DaemonEnabled(extension *Extension) bool {
+	enabled, err := strconv.ParseBool(os.Getenv(DaemonEnvVar))
+	return err == nil && enabled && extension.HasCapability(DaemonCapability)
+}
+
+// DaemonIdleTimeout returns the idle timeout configured through AZD_EXT_DAEMON_IDLE_TIMEOUT.
+func DaemonIdleTimeout() time.Duration {
+	if value := os.Getenv(DaemonIdleTimeoutEnvVar); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	return DefaultDaemonIdleTimeout
+}
+
+// DaemonStatePath returns the path of the daemon state file for the specified extension.
+func DaemonStatePath(extensionId string) (string, error) {
+	userConfigDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config directory: %w", err)
+	}
+
+	return filepath.Join(userConfigDir, "extensions", daemonsDir, extensionId+".json"), nil
+}
+
+// LoadDaemonState reads the daemon state at the specified path.
+// Returns ErrDaemonNotRunning when no state has been recorded.
+func LoadDaemonState(path string) (*DaemonState, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrDaemonNotRunning
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading daemon state: %w", err)
+	}
+
+	var state DaemonState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing daemon state: %w", err)
+	}
+
+	return &state, nil
+}
+
+// Save writes the daemon state to the specified path. The state contains the daemon secret and is only readable by
+// the current user.
+func (s *DaemonState) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), osutil.PermissionDirectoryOwnerOnly); err != nil {
+		return fmt.Errorf("creating daemon state directory: %w", err)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshalling daemon state: %w", err)
+	}
+
+	// Write to a temporary file first so readers never observe a partially written state
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, osutil.PermissionFileOwnerOnly); err != nil {
+		return fmt.Errorf("writing daemon state: %w", err)
+	}
+
+	return os.Rename(tempPath, path)
+}
+
+// StopDaemon terminates the daemon of the specified extension, if one is running, and removes its state.
+func StopDaemon(extensionId string) error {
+	statePath, err := DaemonStatePath(extensionId)
+	if err != nil {
+		return err
+	}
+
+	state, err := LoadDaemonState(statePath)
+	if errors.Is(err, ErrDaemonNotRunning) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if process, err := os.FindProcess(state.Pid); err == nil {
+		if err := process.Kill(); err != nil {
+			log.Printf("failed to stop daemon for extension '%s' (pid %d): %v", extensionId, state.Pid, err)
+		}
+	}
+
+	if err := os.Remove(statePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("removing daemon state: %w", err)
+	}
+
+	return nil
+}
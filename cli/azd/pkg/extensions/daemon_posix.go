@@ -0,0 +1,15 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+//go:build !windows
+
+package extensions
+
+import "syscall"
+
+// daemonProcAttr starts the daemon in a new session so it outlives the azd process and its terminal.
+func daemonProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		Setsid: true,
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DaemonEnabled(t *testing.T) {
+	daemonExtension := &Extension{Id: "test.extension", Capabilities: []CapabilityType{DaemonCapability}}
+	regularExtension := &Extension{Id: "test.extension", Capabilities: []CapabilityType{LifecycleEventsCapability}}
+
+	t.Run("NotOptedIn", func(t *testing.T) {
+		t.Setenv(DaemonEnvVar, "")
+		require.False(t, DaemonEnabled(daemonExtension))
+	})
+
+	t.Run("OptedIn", func(t *testing.T) {
+		t.Setenv(DaemonEnvVar, "true")
+		require.True(t, DaemonEnabled(daemonExtension))
+		require.False(t, DaemonEnabled(regularExtension))
+	})
+}
+
+func Test_DaemonIdleTimeout(t *testing.T) {
+	tests := map[string]struct {
+		value    string
+		expected time.Duration
+	}{
+		"Default":  {value: "", expected: DefaultDaemonIdleTimeout},
+		"Seconds":  {value: "30", expected: 30 * time.Second},
+		"Invalid":  {value: "soon", expected: DefaultDaemonIdleTimeout},
+		"Negative": {value: "-5", expected: DefaultDaemonIdleTimeout},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(DaemonIdleTimeoutEnvVar, test.value)
+			require.Equal(t, test.expected, DaemonIdleTimeout())
+		})
+	}
+}
+
+func Test_DaemonState_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemons", "test.extension.json")
+
+	_, err := LoadDaemonState(path)
+	require.ErrorIs(t, err, ErrDaemonNotRunning)
+
+	state := &DaemonState{Pid: 1234, Address: "127.0.0.1:5000", Secret: "secret"}
+	require.NoError(t, state.Save(path))
+
+	loaded, err := LoadDaemonState(path)
+	require.NoError(t, err)
+	require.Equal(t, state, loaded)
+}
+
+func Test_StopDaemon_NotRunning(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	require.NoError(t, StopDaemon("test.extension"))
+}
@@ -0,0 +1,19 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+//go:build windows
+
+package extensions
+
+import (
+	"syscall"
+
+	"golang.org/x/sys/windows"
+)
+
+// daemonProcAttr starts the daemon detached from the console so it outlives the azd process.
+func daemonProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP | windows.DETACHED_PROCESS,
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// maxResolveIterations bounds the number of passes the resolver makes while constraints are still changing.
+const maxResolveIterations = 100
+
+// ExtensionRequirement is a top-level extension requirement, typically declared in the
+// `requiredVersions.extensions` section of azure.yaml.
+type ExtensionRequirement struct {
+	// Id is the unique identifier of the required extension
+	Id string
+	// Version is a semver constraint. An empty value or "latest" accepts any version.
+	Version string
+	// Source optionally restricts the extension to a specific extension source
+	Source string
+}
+
+// ResolvedExtension is an extension version selected by the DependencyResolver.
+type ResolvedExtension struct {
+	Metadata *ExtensionMetadata
+	Version  *ExtensionVersion
+}
+
+// extensionFinder looks up extension metadata from the configured extension sources.
+type extensionFinder interface {
+	FindExtensions(ctx context.Context, options *FilterOptions) ([]*ExtensionMetadata, error)
+}
+
+// DependencyResolver computes a consistent set of extension versions that satisfies a list of
+// requirements and all of the transitive dependencies declared by the selected versions.
+type DependencyResolver struct {
+	finder extensionFinder
+}
+
+// NewDependencyResolver creates a new DependencyResolver that looks up extensions with the specified finder.
+func NewDependencyResolver(finder extensionFinder) *DependencyResolver {
+	return &DependencyResolver{
+		finder: finder,
+	}
+}
+
+// versionConstraint is a constraint on an extension version along with where it came from.
+type versionConstraint struct {
+	constraint string
+	requiredBy string
+}
+
+// Resolve selects the highest version of each extension that satisfies every constraint placed on it,
+// either by the requirements or by the dependencies of other selected extensions.
+// The result is ordered so that dependencies appear before the extensions that depend on them.
+func (r *DependencyResolver) Resolve(
+	ctx context.Context,
+	requirements []ExtensionRequirement,
+) ([]*ResolvedExtension, error) {
+	metadata := map[string]*ExtensionMetadata{}
+	sources := map[string]string{}
+
+	rootConstraints := map[string][]versionConstraint{}
+	for _, requirement := range requirements {
+		rootConstraints[requirement.Id] = append(rootConstraints[requirement.Id], versionConstraint{
+			constraint: requirement.Version,
+			requiredBy: "project",
+		})
+		if requirement.Source != "" {
+			sources[requirement.Id] = requirement.Source
+		}
+	}
+
+	constraints := rootConstraints
+	var selected map[string]*ExtensionVersion
+
+	for range maxResolveIterations {
+		selected = map[string]*ExtensionVersion{}
+
+		for _, id := range slices.Sorted(maps.Keys(constraints)) {
+			extension, has := metadata[id]
+			if !has {
+				found, err := r.findExtension(ctx, id, sources[id])
+				if err != nil {
+					return nil, err
+				}
+
+				extension = found
+				metadata[id] = extension
+			}
+
+			version, err := selectVersion(extension, constraints[id])
+			if err != nil {
+				return nil, err
+			}
+
+			selected[id] = version
+		}
+
+		// Recompute the constraints from the requirements and the dependencies of the selected versions.
+		next := map[string][]versionConstraint{}
+		for id, values := range rootConstraints {
+			next[id] = slices.Clone(values)
+		}
+
+		for _, id := range slices.Sorted(maps.Keys(selected)) {
+			version := selected[id]
+			for _, dependency := range version.Dependencies {
+				next[dependency.Id] = append(next[dependency.Id], versionConstraint{
+					constraint: dependency.Version,
+					requiredBy: fmt.Sprintf("%s@%s", id, version.Version),
+				})
+
+				// Dependencies are looked up in the same source as the extension that declares them.
+				if _, has := sources[dependency.Id]; !has {
+					sources[dependency.Id] = metadata[id].Source
+				}
+			}
+		}
+
+		if constraintsEqual(constraints, next) {
+			return orderResolved(metadata, selected)
+		}
+
+		constraints = next
+	}
+
+	return nil, fmt.Errorf("unable to resolve a consistent set of extension versions")
+}
+
+// findExtension returns the single extension matching the id in the optional source.
+func (r *DependencyResolver) findExtension(ctx context.Context, id string, source string) (*ExtensionMetadata, error) {
+	matches, err := r.finder.FindExtensions(ctx, &FilterOptions{Id: id, Source: source})
+	if err != nil {
+		return nil, fmt.Errorf("finding extension %s: %w", id, err)
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("extension %s: %w", id, ErrExtensionNotFound)
+	}
+
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("extension %s found in multiple sources, specify exact source", id)
+	}
+
+	return matches[0], nil
+}
+
+// selectVersion returns the highest version of the extension that satisfies all constraints.
+func selectVersion(extension *ExtensionMetadata, constraints []versionConstraint) (*ExtensionVersion, error) {
+	parsed := []*semver.Constraints{}
+	for _, c := range constraints {
+		if c.constraint == "" || c.constraint == "latest" {
+			continue
+		}
+
+		constraint, err := semver.NewConstraint(c.constraint)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"invalid version constraint '%s' for extension %s required by %s: %w",
+				c.constraint,
+				extension.Id,
+				c.requiredBy,
+				err,
+			)
+		}
+
+		parsed = append(parsed, constraint)
+	}
+
+	var best *ExtensionVersion
+	var bestSemver *semver.Version
+
+	for i := range extension.Versions {
+		version, err := semver.NewVersion(extension.Versions[i].Version)
+		if err != nil {
+			continue
+		}
+
+		satisfied := true
+		for _, constraint := range parsed {
+			if !constraint.Check(version) {
+				satisfied = false
+				break
+			}
+		}
+
+		if satisfied && (bestSemver == nil || version.GreaterThan(bestSemver)) {
+			best = &extension.Versions[i]
+			bestSemver = version
+		}
+	}
+
+	if best == nil {
+		required := make([]string, len(constraints))
+		for i, c := range constraints {
+			constraint := c.constraint
+			if constraint == "" {
+				constraint = "latest"
+			}
+			required[i] = fmt.Sprintf("%s (required by %s)", constraint, c.requiredBy)
+		}
+
+		return nil, fmt.Errorf(
+			"no version of extension %s satisfies all constraints: %s",
+			extension.Id,
+			strings.Join(required, ", "),
+		)
+	}
+
+	return best, nil
+}
+
+// constraintsEqual reports whether two constraint sets are identical.
+func constraintsEqual(a map[string][]versionConstraint, b map[string][]versionConstraint) bool {
+	return maps.EqualFunc(a, b, func(x []versionConstraint, y []versionConstraint) bool {
+		return slices.Equal(x, y)
+	})
+}
+
+// orderResolved orders the selected versions so that dependencies come before their dependents.
+// Extensions without an ordering relationship are sorted by id to keep the output stable.
+func orderResolved(
+	metadata map[string]*ExtensionMetadata,
+	selected map[string]*ExtensionVersion,
+) ([]*ResolvedExtension, error) {
+	result := make([]*ResolvedExtension, 0, len(selected))
+	state := map[string]int{} // 0 = unvisited, 1 = visiting, 2 = done
+
+	var visit func(id string, path []string) error
+	visit = func(id string, path []string) error {
+		switch state[id] {
+		case 1:
+			return fmt.Errorf("circular extension dependency: %s", strings.Join(append(path, id), " -> "))
+		case 2:
+			return nil
+		}
+
+		state[id] = 1
+		version := selected[id]
+
+		dependencyIds := make([]string, len(version.Dependencies))
+		for i, dependency := range version.Dependencies {
+			dependencyIds[i] = dependency.Id
+		}
+		slices.Sort(dependencyIds)
+
+		for _, dependencyId := range dependencyIds {
+			if err := visit(dependencyId, append(path, id)); err != nil {
+				return err
+			}
+		}
+
+		state[id] = 2
+		result = append(result, &ResolvedExtension{
+			Metadata: metadata[id],
+			Version:  version,
+		})
+
+		return nil
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(selected)) {
+		if err := visit(id, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
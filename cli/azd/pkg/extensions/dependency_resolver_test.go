@@ -0,0 +1,155 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeExtensionFinder struct {
+	extensions []*ExtensionMetadata
+}
+
+func (f *fakeExtensionFinder) FindExtensions(ctx context.Context, options *FilterOptions) ([]*ExtensionMetadata, error) {
+	matches := []*ExtensionMetadata{}
+	for _, extension := range f.extensions {
+		if extension.Id == options.Id && (options.Source == "" || extension.Source == options.Source) {
+			matches = append(matches, extension)
+		}
+	}
+
+	return matches, nil
+}
+
+func newTestVersion(version string, dependencies ...ExtensionDependency) ExtensionVersion {
+	return ExtensionVersion{
+		Version:      version,
+		Dependencies: dependencies,
+		Artifacts: map[string]ExtensionArtifact{
+			"linux/amd64": {
+				URL:      "https://example.com/" + version,
+				Checksum: ExtensionChecksum{Algorithm: "sha256", Value: "checksum-" + version},
+			},
+		},
+	}
+}
+
+func resolvedVersions(resolved []*ResolvedExtension) []string {
+	result := make([]string, len(resolved))
+	for i, r := range resolved {
+		result[i] = r.Metadata.Id + "@" + r.Version.Version
+	}
+
+	return result
+}
+
+func Test_DependencyResolver_Resolve(t *testing.T) {
+	finder := &fakeExtensionFinder{
+		extensions: []*ExtensionMetadata{
+			{
+				Id:     "ext.app",
+				Source: "azd",
+				Versions: []ExtensionVersion{
+					newTestVersion("1.0.0", ExtensionDependency{Id: "ext.lib", Version: "^1.0.0"}),
+					newTestVersion("2.0.0", ExtensionDependency{Id: "ext.lib", Version: "^2.0.0"}),
+				},
+			},
+			{
+				Id:     "ext.tool",
+				Source: "azd",
+				Versions: []ExtensionVersion{
+					newTestVersion("1.0.0", ExtensionDependency{Id: "ext.lib", Version: ">=1.2.0"}),
+				},
+			},
+			{
+				Id:     "ext.lib",
+				Source: "azd",
+				Versions: []ExtensionVersion{
+					newTestVersion("1.1.0"),
+					newTestVersion("1.3.0", ExtensionDependency{Id: "ext.core"}),
+					newTestVersion("2.0.0"),
+				},
+			},
+			{
+				Id:     "ext.core",
+				Source: "azd",
+				Versions: []ExtensionVersion{
+					newTestVersion("0.9.0"),
+					newTestVersion("1.0.0"),
+				},
+			},
+		},
+	}
+
+	t.Run("ResolvesTransitiveDependencies", func(t *testing.T) {
+		resolver := NewDependencyResolver(finder)
+		resolved, err := resolver.Resolve(context.Background(), []ExtensionRequirement{
+			{Id: "ext.app", Version: "1.x"},
+			{Id: "ext.tool"},
+		})
+		require.NoError(t, err)
+
+		// Dependencies appear before their dependents
+		require.Equal(t, []string{
+			"ext.core@1.0.0",
+			"ext.lib@1.3.0",
+			"ext.app@1.0.0",
+			"ext.tool@1.0.0",
+		}, resolvedVersions(resolved))
+	})
+
+	t.Run("LatestSatisfiesAllConstraints", func(t *testing.T) {
+		resolver := NewDependencyResolver(finder)
+		resolved, err := resolver.Resolve(context.Background(), []ExtensionRequirement{
+			{Id: "ext.app", Version: "latest"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"ext.lib@2.0.0", "ext.app@2.0.0"}, resolvedVersions(resolved))
+	})
+
+	t.Run("ConflictingConstraints", func(t *testing.T) {
+		resolver := NewDependencyResolver(finder)
+		_, err := resolver.Resolve(context.Background(), []ExtensionRequirement{
+			{Id: "ext.app", Version: "2.0.0"},
+			{Id: "ext.lib", Version: "<2.0.0"},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no version of extension ext.lib satisfies all constraints")
+		require.Contains(t, err.Error(), "required by ext.app@2.0.0")
+	})
+
+	t.Run("MissingDependency", func(t *testing.T) {
+		resolver := NewDependencyResolver(&fakeExtensionFinder{
+			extensions: []*ExtensionMetadata{
+				{
+					Id:       "ext.app",
+					Versions: []ExtensionVersion{newTestVersion("1.0.0", ExtensionDependency{Id: "ext.missing"})},
+				},
+			},
+		})
+		_, err := resolver.Resolve(context.Background(), []ExtensionRequirement{{Id: "ext.app"}})
+		require.ErrorIs(t, err, ErrExtensionNotFound)
+	})
+
+	t.Run("CircularDependency", func(t *testing.T) {
+		resolver := NewDependencyResolver(&fakeExtensionFinder{
+			extensions: []*ExtensionMetadata{
+				{
+					Id:       "ext.a",
+					Versions: []ExtensionVersion{newTestVersion("1.0.0", ExtensionDependency{Id: "ext.b"})},
+				},
+				{
+					Id:       "ext.b",
+					Versions: []ExtensionVersion{newTestVersion("1.0.0", ExtensionDependency{Id: "ext.a"})},
+				},
+			},
+		})
+		_, err := resolver.Resolve(context.Background(), []ExtensionRequirement{{Id: "ext.a"}})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "circular extension dependency")
+	})
+}
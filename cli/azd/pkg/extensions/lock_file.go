@@ -0,0 +1,208 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+)
+
+const (
+	// LockFileName is the name of the extension lock file stored next to azure.yaml.
+	LockFileName = "azd.lock"
+
+	// lockFileVersion is the current version of the lock file format.
+	lockFileVersion = 1
+)
+
+var ErrLockFileNotFound = errors.New("extension lock file not found")
+
+// LockFile records the exact extension versions and artifact checksums resolved for a project so that
+// installs can be reproduced, for example in CI.
+type LockFile struct {
+	// LockVersion is the version of the lock file format
+	LockVersion int `json:"lockVersion"`
+	// Extensions is the list of locked extensions, ordered so that dependencies come first
+	Extensions []*LockedExtension `json:"extensions"`
+}
+
+// LockedExtension is a single extension entry in the lock file.
+type LockedExtension struct {
+	// Id is the unique identifier of the extension
+	Id string `json:"id"`
+	// Version is the exact version of the extension
+	Version string `json:"version"`
+	// Source is the name of the extension source the extension was resolved from
+	Source string `json:"source,omitempty"`
+	// Dependencies is the list of extension ids this extension depends on
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Artifacts is a map of artifact checksums keyed on platform (os & architecture)
+	Artifacts map[string]ExtensionChecksum `json:"artifacts,omitempty"`
+}
+
+// NewLockFile creates a lock file from the output of the DependencyResolver.
+func NewLockFile(resolved []*ResolvedExtension) *LockFile {
+	lockFile := &LockFile{
+		LockVersion: lockFileVersion,
+		Extensions:  make([]*LockedExtension, 0, len(resolved)),
+	}
+
+	for _, r := range resolved {
+		locked := &LockedExtension{
+			Id:      r.Metadata.Id,
+			Version: r.Version.Version,
+			Source:  r.Metadata.Source,
+		}
+
+		for _, dependency := range r.Version.Dependencies {
+			locked.Dependencies = append(locked.Dependencies, dependency.Id)
+		}
+		slices.Sort(locked.Dependencies)
+
+		if len(r.Version.Artifacts) > 0 {
+			locked.Artifacts = map[string]ExtensionChecksum{}
+			for platform, artifact := range r.Version.Artifacts {
+				locked.Artifacts[platform] = artifact.Checksum
+			}
+		}
+
+		lockFile.Extensions = append(lockFile.Extensions, locked)
+	}
+
+	return lockFile
+}
+
+// LoadLockFile reads the lock file at the specified path.
+// Returns ErrLockFileNotFound when the file does not exist.
+func LoadLockFile(path string) (*LockFile, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", path, ErrLockFileNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading lock file: %w", err)
+	}
+
+	var lockFile LockFile
+	if err := json.Unmarshal(data, &lockFile); err != nil {
+		return nil, fmt.Errorf("parsing lock file %s: %w", path, err)
+	}
+
+	if lockFile.LockVersion > lockFileVersion {
+		return nil, fmt.Errorf(
+			"lock file %s uses format version %d, which is newer than the supported version %d. "+
+				"Upgrade azd to use this lock file",
+			path,
+			lockFile.LockVersion,
+			lockFileVersion,
+		)
+	}
+
+	return &lockFile, nil
+}
+
+// Save writes the lock file to the specified path.
+func (l *LockFile) Save(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshalling lock file: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), osutil.PermissionFile); err != nil {
+		return fmt.Errorf("writing lock file: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the locked extension with the specified id.
+func (l *LockFile) Get(id string) (*LockedExtension, bool) {
+	for _, locked := range l.Extensions {
+		if locked.Id == id {
+			return locked, true
+		}
+	}
+
+	return nil, false
+}
+
+// applyLockedChecksums returns a copy of the extension version with artifact checksums taken from the
+// lock entry. It fails when the registry reports a different checksum than the lock file, or when the
+// lock file records artifacts that are no longer available in the registry.
+func applyLockedChecksums(version *ExtensionVersion, locked *LockedExtension) (*ExtensionVersion, error) {
+	lockedVersion := *version
+	lockedVersion.Artifacts = maps.Clone(version.Artifacts)
+
+	for _, platform := range slices.Sorted(maps.Keys(locked.Artifacts)) {
+		checksum := locked.Artifacts[platform]
+		artifact, has := lockedVersion.Artifacts[platform]
+		if !has {
+			return nil, fmt.Errorf(
+				"extension %s@%s no longer provides an artifact for platform %s recorded in the lock file",
+				locked.Id,
+				locked.Version,
+				platform,
+			)
+		}
+
+		if artifact.Checksum.Value != "" && artifact.Checksum != checksum {
+			return nil, fmt.Errorf(
+				"checksum for extension %s@%s (%s) does not match the lock file: expected %s, registry has %s",
+				locked.Id,
+				locked.Version,
+				platform,
+				checksum.Value,
+				artifact.Checksum.Value,
+			)
+		}
+
+		artifact.Checksum = checksum
+		lockedVersion.Artifacts[platform] = artifact
+	}
+
+	return &lockedVersion, nil
+}
+
+// Validate checks that the lock file contains a version for every requirement that satisfies its constraint.
+// A failure indicates the lock file is out of date with the project requirements.
+func (l *LockFile) Validate(requirements []ExtensionRequirement) error {
+	for _, requirement := range requirements {
+		locked, has := l.Get(requirement.Id)
+		if !has {
+			return fmt.Errorf("extension %s is required but missing from the lock file", requirement.Id)
+		}
+
+		if requirement.Version == "" || requirement.Version == "latest" {
+			continue
+		}
+
+		constraint, err := semver.NewConstraint(requirement.Version)
+		if err != nil {
+			return fmt.Errorf("invalid version constraint '%s' for extension %s: %w", requirement.Version, requirement.Id, err)
+		}
+
+		version, err := semver.NewVersion(locked.Version)
+		if err != nil {
+			return fmt.Errorf("invalid locked version '%s' for extension %s: %w", locked.Version, requirement.Id, err)
+		}
+
+		if !constraint.Check(version) {
+			return fmt.Errorf(
+				"locked version %s of extension %s does not satisfy the required version %s",
+				locked.Version,
+				requirement.Id,
+				requirement.Version,
+			)
+		}
+	}
+
+	return nil
+}
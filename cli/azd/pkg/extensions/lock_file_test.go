@@ -0,0 +1,121 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package extensions
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LockFile_SaveAndLoad(t *testing.T) {
+	libVersion := newTestVersion("1.3.0")
+	appVersion := newTestVersion("1.0.0", ExtensionDependency{Id: "ext.lib", Version: "^1.0.0"})
+	resolved := []*ResolvedExtension{
+		{
+			Metadata: &ExtensionMetadata{Id: "ext.lib", Source: "azd"},
+			Version:  &libVersion,
+		},
+		{
+			Metadata: &ExtensionMetadata{Id: "ext.app", Source: "azd"},
+			Version:  &appVersion,
+		},
+	}
+
+	lockFile := NewLockFile(resolved)
+	path := filepath.Join(t.TempDir(), LockFileName)
+	require.NoError(t, lockFile.Save(path))
+
+	loaded, err := LoadLockFile(path)
+	require.NoError(t, err)
+	require.Equal(t, lockFile, loaded)
+
+	app, has := loaded.Get("ext.app")
+	require.True(t, has)
+	require.Equal(t, "1.0.0", app.Version)
+	require.Equal(t, "azd", app.Source)
+	require.Equal(t, []string{"ext.lib"}, app.Dependencies)
+	require.Equal(t, ExtensionChecksum{Algorithm: "sha256", Value: "checksum-1.0.0"}, app.Artifacts["linux/amd64"])
+
+	_, has = loaded.Get("ext.unknown")
+	require.False(t, has)
+}
+
+func Test_LoadLockFile_NotFound(t *testing.T) {
+	_, err := LoadLockFile(filepath.Join(t.TempDir(), LockFileName))
+	require.ErrorIs(t, err, ErrLockFileNotFound)
+}
+
+func Test_ApplyLockedChecksums(t *testing.T) {
+	version := newTestVersion("1.0.0")
+
+	t.Run("Matching", func(t *testing.T) {
+		locked := &LockedExtension{
+			Id:      "ext.app",
+			Version: "1.0.0",
+			Artifacts: map[string]ExtensionChecksum{
+				"linux/amd64": {Algorithm: "sha256", Value: "checksum-1.0.0"},
+			},
+		}
+
+		result, err := applyLockedChecksums(&version, locked)
+		require.NoError(t, err)
+		require.Equal(t, "checksum-1.0.0", result.Artifacts["linux/amd64"].Checksum.Value)
+	})
+
+	t.Run("Mismatch", func(t *testing.T) {
+		locked := &LockedExtension{
+			Id:      "ext.app",
+			Version: "1.0.0",
+			Artifacts: map[string]ExtensionChecksum{
+				"linux/amd64": {Algorithm: "sha256", Value: "tampered"},
+			},
+		}
+
+		_, err := applyLockedChecksums(&version, locked)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match the lock file")
+
+		// The original version is never modified
+		require.Equal(t, "checksum-1.0.0", version.Artifacts["linux/amd64"].Checksum.Value)
+	})
+
+	t.Run("MissingPlatform", func(t *testing.T) {
+		locked := &LockedExtension{
+			Id:      "ext.app",
+			Version: "1.0.0",
+			Artifacts: map[string]ExtensionChecksum{
+				"windows/amd64": {Algorithm: "sha256", Value: "checksum-1.0.0"},
+			},
+		}
+
+		_, err := applyLockedChecksums(&version, locked)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "no longer provides an artifact for platform windows/amd64")
+	})
+}
+
+func Test_LockFile_Validate(t *testing.T) {
+	lockFile := &LockFile{
+		LockVersion: lockFileVersion,
+		Extensions: []*LockedExtension{
+			{Id: "ext.lib", Version: "1.3.0"},
+			{Id: "ext.app", Version: "1.0.0", Dependencies: []string{"ext.lib"}},
+		},
+	}
+
+	require.NoError(t, lockFile.Validate([]ExtensionRequirement{
+		{Id: "ext.app", Version: "^1.0.0"},
+		{Id: "ext.lib", Version: "latest"},
+	}))
+
+	err := lockFile.Validate([]ExtensionRequirement{{Id: "ext.app", Version: ">=2.0.0"}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "does not satisfy the required version >=2.0.0")
+
+	err = lockFile.Validate([]ExtensionRequirement{{Id: "ext.other"}})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "missing from the lock file")
+}
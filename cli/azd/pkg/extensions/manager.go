@@ -33,6 +33,7 @@ import (
 	"github.com/azure/azure-dev/cli/azd/pkg/lazy"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 	"github.com/azure/azure-dev/cli/azd/pkg/rzip"
+	"github.com/otiai10/copy"
 )
 
 const (
@@ -558,6 +559,11 @@ func (m *Manager) Uninstall(id string) error {
 		return fmt.Errorf("failed to get user config directory: %w", err)
 	}
 
+	// A daemon keeps the extension executable in use and must not outlive the installed version
+	if err := StopDaemon(extension.Id); err != nil {
+		log.Printf("failed to stop daemon for extension '%s': %v", extension.Id, err)
+	}
+
 	extensionDir := filepath.Join(userConfigDir, "extensions", extension.Id)
 	if err := os.MkdirAll(extensionDir, os.ModePerm); err != nil {
 		return fmt.Errorf("failed to create target directory: %w", err)
@@ -615,6 +621,144 @@ func (m *Manager) Upgrade(
 	return extensionVersion, nil
 }
 
+// InstallLocked installs the exact extension version recorded in a lock file entry.
+// Artifacts are validated against the checksums stored in the lock file rather than the registry.
+// When a different version of the extension is already installed it is replaced, and restored when the locked version
+// fails to install.
+// Returns ErrExtensionInstalled when the locked version is already installed.
+func (m *Manager) InstallLocked(ctx context.Context, locked *LockedExtension) (*ExtensionVersion, error) {
+	if locked == nil {
+		return nil, fmt.Errorf("locked extension cannot be nil")
+	}
+
+	matches, err := m.FindExtensions(ctx, &FilterOptions{Id: locked.Id, Source: locked.Source})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find extension %s: %w", locked.Id, err)
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("extension %s: %w", locked.Id, ErrExtensionNotFound)
+	}
+
+	if len(matches) > 1 {
+		return nil, fmt.Errorf("extension %s found in multiple sources, specify exact source", locked.Id)
+	}
+
+	extension := matches[0]
+
+	var lockedVersion *ExtensionVersion
+	for i := range extension.Versions {
+		if extension.Versions[i].Version == locked.Version {
+			lockedVersion, err = applyLockedChecksums(&extension.Versions[i], locked)
+			if err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	if lockedVersion == nil {
+		return nil, fmt.Errorf("version %s of extension %s is no longer available", locked.Version, locked.Id)
+	}
+
+	// Only offer the locked version so the install cannot select anything else
+	lockedExtension := *extension
+	lockedExtension.Versions = []ExtensionVersion{*lockedVersion}
+
+	installed, err := m.GetInstalled(FilterOptions{Id: locked.Id})
+	if err != nil || installed == nil {
+		return m.Install(ctx, &lockedExtension, locked.Version)
+	}
+
+	if installed.Version == locked.Version {
+		return lockedVersion, fmt.Errorf("%s %w", locked.Id, ErrExtensionInstalled)
+	}
+
+	// The installed version is kept aside and put back when the locked version fails to install, like when its
+	// download or its checksum validation fails
+	backupDir, err := m.backupInstalled(installed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to back up extension: %w", err)
+	}
+	defer os.RemoveAll(backupDir)
+
+	if err := m.Uninstall(locked.Id); err != nil {
+		return nil, fmt.Errorf("failed to uninstall extension: %w", err)
+	}
+
+	extensionVersion, err := m.Install(ctx, &lockedExtension, locked.Version)
+	if err != nil {
+		if restoreErr := m.restoreInstalled(installed, backupDir); restoreErr != nil {
+			return nil, fmt.Errorf(
+				"%w, restoring version %s of extension %s failed: %w", err, installed.Version, installed.Id, restoreErr)
+		}
+
+		return nil, err
+	}
+
+	return extensionVersion, nil
+}
+
+// backupInstalled copies the artifacts of the installed extension to a temporary directory
+func (m *Manager) backupInstalled(installed *Extension) (string, error) {
+	userConfigDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config directory: %w", err)
+	}
+
+	backupDir, err := os.MkdirTemp("", fmt.Sprintf("azd-%s-*", installed.Id))
+	if err != nil {
+		return "", err
+	}
+
+	extensionDir := filepath.Join(userConfigDir, "extensions", installed.Id)
+	if _, err := os.Stat(extensionDir); errors.Is(err, os.ErrNotExist) {
+		return backupDir, nil
+	}
+
+	if err := copy.Copy(extensionDir, backupDir); err != nil {
+		os.RemoveAll(backupDir)
+		return "", err
+	}
+
+	return backupDir, nil
+}
+
+// restoreInstalled puts the extension backed up by backupInstalled back in place, replacing any partial installation
+func (m *Manager) restoreInstalled(installed *Extension, backupDir string) error {
+	userConfigDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return fmt.Errorf("failed to get user config directory: %w", err)
+	}
+
+	extensionDir := filepath.Join(userConfigDir, "extensions", installed.Id)
+	if err := os.RemoveAll(extensionDir); err != nil {
+		return fmt.Errorf("failed to remove extension: %w", err)
+	}
+
+	if err := copy.Copy(backupDir, extensionDir); err != nil {
+		return fmt.Errorf("failed to restore extension: %w", err)
+	}
+
+	extensions, err := m.ListInstalled()
+	if err != nil {
+		return fmt.Errorf("failed to list installed extensions: %w", err)
+	}
+
+	extensions[installed.Id] = installed
+
+	if err := m.userConfig.Set(installedConfigKey, extensions); err != nil {
+		return fmt.Errorf("failed to set extensions section: %w", err)
+	}
+
+	if err := m.configManager.Save(m.userConfig); err != nil {
+		return fmt.Errorf("failed to save user config: %w", err)
+	}
+
+	log.Printf("Extension '%s' (version %s) restored\n", installed.Id, installed.Version)
+	return nil
+}
+
 // Helper function to find the artifact for the current OS
 func findArtifactForCurrentOS(version *ExtensionVersion) (*ExtensionArtifact, error) {
 	if version.Artifacts == nil {
@@ -760,40 +904,48 @@ func validateChecksum(filePath string, checksum ExtensionChecksum) error {
 		return nil
 	}
 
+	computedChecksum, err := computeChecksum(filePath, checksum.Algorithm)
+	if err != nil {
+		return err
+	}
+
+	// Compare the computed checksum with the expected checksum
+	if computedChecksum != checksum.Value {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", checksum.Value, computedChecksum)
+	}
+
+	return nil
+}
+
+// computeChecksum computes the hex encoded checksum of the file at the given path using the specified algorithm.
+func computeChecksum(filePath string, algorithm string) (string, error) {
 	var hashAlgo hash.Hash
 
 	// Select the hashing algorithm based on the input
-	switch checksum.Algorithm {
+	switch algorithm {
 	case "sha256":
 		hashAlgo = sha256.New()
 	case "sha512":
 		hashAlgo = sha512.New()
 	default:
-		return fmt.Errorf("unsupported checksum algorithm: %s", checksum.Algorithm)
+		return "", fmt.Errorf("unsupported checksum algorithm: %s", algorithm)
 	}
 
 	// Open the file for reading
 	//nolint:gosec // G703: filePath from extension install
 	file, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to open file for checksum validation: %w", err)
+		return "", fmt.Errorf("failed to open file for checksum validation: %w", err)
 	}
 	defer file.Close()
 
 	// Compute the checksum
 	if _, err := io.Copy(hashAlgo, file); err != nil {
-		return fmt.Errorf("failed to compute checksum: %w", err)
+		return "", fmt.Errorf("failed to compute checksum: %w", err)
 	}
 
 	// Convert the computed checksum to a hexadecimal string
-	computedChecksum := hex.EncodeToString(hashAlgo.Sum(nil))
-
-	// Compare the computed checksum with the expected checksum
-	if computedChecksum != checksum.Value {
-		return fmt.Errorf("checksum mismatch: expected %s, got %s", checksum.Value, computedChecksum)
-	}
-
-	return nil
+	return hex.EncodeToString(hashAlgo.Sum(nil)), nil
 }
 
 // Helper function to copy a file to the target directory
@@ -1180,3 +1180,83 @@ func Test_GetInstalled_WithSourceFilter(t *testing.T) {
 		require.ErrorIs(t, err, ErrInstalledExtensionNotFound)
 	})
 }
+
+func Test_InstallLocked(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	mockContext := mocks.NewMockContext(context.Background())
+	createRegistryMocks(mockContext)
+
+	userConfigManager := config.NewUserConfigManager(mockContext.ConfigManager)
+	sourceManager := NewSourceManager(mockContext.Container, userConfigManager, mockContext.HttpClient)
+	lazyRunner := lazy.NewLazy(func() (*Runner, error) {
+		return NewRunner(mockContext.CommandRunner), nil
+	})
+	manager, err := NewManager(userConfigManager, sourceManager, lazyRunner, mockContext.HttpClient)
+	require.NoError(t, err)
+
+	// Compute the checksum of the artifact served by the mock registry
+	artifactPath, err := manager.downloadArtifact(*mockContext.Context, sampleArtifacts["linux"].URL)
+	require.NoError(t, err)
+	artifactData, err := os.ReadFile(artifactPath)
+	require.NoError(t, err)
+	hash := sha256.Sum256(artifactData)
+	checksum := ExtensionChecksum{Algorithm: "sha256", Value: hex.EncodeToString(hash[:])}
+
+	locked := &LockedExtension{
+		Id:      "test.extension",
+		Version: "1.1.0",
+		Artifacts: map[string]ExtensionChecksum{
+			"darwin":  checksum,
+			"linux":   checksum,
+			"windows": checksum,
+		},
+	}
+
+	extensionVersion, err := manager.InstallLocked(*mockContext.Context, locked)
+	require.NoError(t, err)
+	require.Equal(t, "1.1.0", extensionVersion.Version)
+
+	// Installing the same locked version again is reported as already installed
+	_, err = manager.InstallLocked(*mockContext.Context, locked)
+	require.ErrorIs(t, err, ErrExtensionInstalled)
+
+	// A different locked version replaces the installed one
+	locked.Version = "1.2.0"
+	extensionVersion, err = manager.InstallLocked(*mockContext.Context, locked)
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", extensionVersion.Version)
+
+	installed, err := manager.GetInstalled(FilterOptions{Id: "test.extension"})
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", installed.Version)
+
+	// Artifacts that don't match the locked checksum are rejected, and the installed version is kept
+	locked.Version = "1.1.0"
+	locked.Artifacts = map[string]ExtensionChecksum{
+		"darwin":  {Algorithm: "sha256", Value: "invalid"},
+		"linux":   {Algorithm: "sha256", Value: "invalid"},
+		"windows": {Algorithm: "sha256", Value: "invalid"},
+	}
+	_, err = manager.InstallLocked(*mockContext.Context, locked)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "checksum mismatch")
+
+	installed, err = manager.GetInstalled(FilterOptions{Id: "test.extension"})
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", installed.Version)
+	userConfigDir, err := config.GetUserConfigDir()
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(userConfigDir, installed.Path))
+
+	require.NoError(t, manager.Uninstall("test.extension"))
+	_, err = manager.InstallLocked(*mockContext.Context, locked)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "checksum mismatch")
+
+	// Versions that are no longer in the registry fail
+	locked.Version = "9.9.9"
+	_, err = manager.InstallLocked(*mockContext.Context, locked)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no longer available")
+}
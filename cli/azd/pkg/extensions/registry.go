@@ -44,6 +44,8 @@ const (
 	FrameworkServiceProviderCapability CapabilityType = "framework-service-provider"
 	// Metadata capability enables extensions to provide comprehensive metadata about their commands and capabilities
 	MetadataCapability CapabilityType = "metadata"
+	// Daemon capability enables extensions to keep their listen process alive across azd invocations
+	DaemonCapability CapabilityType = "daemon"
 )
 
 type ProviderType string
@@ -5,13 +5,21 @@ package extensions
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	osexec "os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 )
 
 type InvokeOptions struct {
@@ -29,6 +37,27 @@ type InvokeOptions struct {
 	Environment string
 }
 
+// Environ returns the environment variables for the extension process, including the global AZD flags that are
+// propagated as environment variables.
+func (o *InvokeOptions) Environ() []string {
+	env := slices.Clone(o.Env)
+
+	if o.Debug {
+		env = append(env, "AZD_DEBUG=true")
+	}
+	if o.NoPrompt {
+		env = append(env, "AZD_NO_PROMPT=true")
+	}
+	if o.Cwd != "" {
+		env = append(env, fmt.Sprintf("AZD_CWD=%s", o.Cwd))
+	}
+	if o.Environment != "" {
+		env = append(env, fmt.Sprintf("AZD_ENVIRONMENT=%s", o.Environment))
+	}
+
+	return env
+}
+
 type Runner struct {
 	commandRunner exec.CommandRunner
 }
@@ -41,31 +70,14 @@ func NewRunner(commandRunner exec.CommandRunner) *Runner {
 
 // Invoke runs the extension with the provided arguments
 func (r *Runner) Invoke(ctx context.Context, extension *Extension, options *InvokeOptions) (*exec.RunResult, error) {
-	userConfigDir, err := config.GetUserConfigDir()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user config directory: %w", err)
-	}
-
 	extension.ensureInit()
 
-	extensionPath := filepath.Join(userConfigDir, extension.Path)
-	if _, err := os.Stat(extensionPath); err != nil {
-		return nil, fmt.Errorf("extension path '%s' not found: %w", extensionPath, err)
+	extensionPath, err := extensionExecutablePath(extension)
+	if err != nil {
+		return nil, err
 	}
 
-	// Propagate global AZD flags as environment variables
-	if options.Debug {
-		options.Env = append(options.Env, "AZD_DEBUG=true")
-	}
-	if options.NoPrompt {
-		options.Env = append(options.Env, "AZD_NO_PROMPT=true")
-	}
-	if options.Cwd != "" {
-		options.Env = append(options.Env, fmt.Sprintf("AZD_CWD=%s", options.Cwd))
-	}
-	if options.Environment != "" {
-		options.Env = append(options.Env, fmt.Sprintf("AZD_ENVIRONMENT=%s", options.Environment))
-	}
+	options.Env = options.Environ()
 
 	runArgs := exec.NewRunArgs(extensionPath, options.Args...)
 	if len(options.Env) > 0 {
@@ -95,6 +107,98 @@ func (r *Runner) Invoke(ctx context.Context, extension *Extension, options *Invo
 	return &runResult, nil
 }
 
+// StartDaemon starts the listen process of the extension in daemon mode, detached from the current azd process, and
+// waits until the daemon records its state. The daemon output is written to a log file next to the daemon state.
+func (r *Runner) StartDaemon(ctx context.Context, extension *Extension) (*DaemonState, error) {
+	extensionPath, err := extensionExecutablePath(extension)
+	if err != nil {
+		return nil, err
+	}
+
+	statePath, err := DaemonStatePath(extension.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(statePath), osutil.PermissionDirectoryOwnerOnly); err != nil {
+		return nil, fmt.Errorf("creating daemon state directory: %w", err)
+	}
+
+	if err := os.Remove(statePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("removing stale daemon state: %w", err)
+	}
+
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, fmt.Errorf("generating daemon secret: %w", err)
+	}
+
+	logPath := strings.TrimSuffix(statePath, filepath.Ext(statePath)) + ".log"
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, osutil.PermissionFileOwnerOnly)
+	if err != nil {
+		return nil, fmt.Errorf("creating daemon log file: %w", err)
+	}
+	defer logFile.Close()
+
+	// The daemon must outlive this azd invocation, so it is not bound to ctx
+	//nolint:gosec // G204: extensionPath is the installed extension executable
+	cmd := osexec.Command(extensionPath, "listen", "--daemon")
+	cmd.Env = append(
+		os.Environ(),
+		fmt.Sprintf("%s=%s", DaemonStateFileEnvVar, statePath),
+		fmt.Sprintf("%s=%s", DaemonSecretEnvVar, hex.EncodeToString(secretBytes)),
+		fmt.Sprintf("%s=%d", DaemonIdleTimeoutEnvVar, int(DaemonIdleTimeout().Seconds())),
+	)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.SysProcAttr = daemonProcAttr()
+
+	if err := cmd.Start(); err != nil {
+		return nil, &ExtensionRunError{Err: err, ExtensionId: extension.Id}
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			_ = cmd.Process.Kill()
+			return nil, ctx.Err()
+		case err := <-exited:
+			return nil, &ExtensionRunError{
+				Err:         fmt.Errorf("daemon exited before it was ready (see %s): %w", logPath, err),
+				ExtensionId: extension.Id,
+			}
+		case <-ticker.C:
+			state, err := LoadDaemonState(statePath)
+			if err == nil && state.Pid == cmd.Process.Pid {
+				return state, nil
+			}
+		}
+	}
+}
+
+// extensionExecutablePath returns the absolute path of the installed extension executable.
+func extensionExecutablePath(extension *Extension) (string, error) {
+	userConfigDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get user config directory: %w", err)
+	}
+
+	extensionPath := filepath.Join(userConfigDir, extension.Path)
+	if _, err := os.Stat(extensionPath); err != nil {
+		return "", fmt.Errorf("extension path '%s' not found: %w", extensionPath, err)
+	}
+
+	return extensionPath, nil
+}
+
 // ExtensionRunError represents an error that occurred while running an extension.
 type ExtensionRunError struct {
 	ExtensionId string
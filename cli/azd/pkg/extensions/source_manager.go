@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"slices"
 	"strings"
 
@@ -20,8 +21,9 @@ import (
 type SourceKind string
 
 const (
-	SourceKindFile SourceKind = "file"
-	SourceKindUrl  SourceKind = "url"
+	SourceKindFile   SourceKind = "file"
+	SourceKindUrl    SourceKind = "url"
+	SourceKindBundle SourceKind = "bundle"
 
 	baseConfigKey      string = "extension.sources"
 	installedConfigKey string = "extension.installed"
@@ -128,14 +130,14 @@ func (sm *SourceManager) Remove(ctx context.Context, name string) error {
 
 // List returns a list of extension sources.
 func (sm *SourceManager) List(ctx context.Context) ([]*SourceConfig, error) {
-	config, err := sm.configManager.Load()
+	azdConfig, err := sm.configManager.Load()
 	if err != nil {
 		return nil, fmt.Errorf("unable to load user configuration: %w", err)
 	}
 
 	allSourceConfigs := []*SourceConfig{}
 
-	rawSources, ok := config.Get(baseConfigKey)
+	rawSources, ok := azdConfig.Get(baseConfigKey)
 	if ok {
 		sourceMap := rawSources.(map[string]any)
 		for key, rawSource := range sourceMap {
@@ -160,11 +162,15 @@ func (sm *SourceManager) List(ctx context.Context) ([]*SourceConfig, error) {
 			Location: extensionRegistryUrl,
 		}
 
-		if err := sm.addInternal(defaultSource); err != nil {
+		// The azd policy can block the default source
+		err := sm.addInternal(defaultSource)
+		if errors.Is(err, config.ErrPolicyViolation) {
+			log.Printf("not defaulting extension source '%s': %v", defaultSource.Name, err)
+		} else if err != nil {
 			return nil, fmt.Errorf("unable to default template source '%s': %w", defaultSource.Name, err)
+		} else {
+			allSourceConfigs = append(allSourceConfigs, defaultSource)
 		}
-
-		allSourceConfigs = append(allSourceConfigs, defaultSource)
 	}
 
 	slices.SortFunc(allSourceConfigs, func(a, b *SourceConfig) int {
@@ -192,6 +198,8 @@ func (sm *SourceManager) CreateSource(ctx context.Context, config *SourceConfig)
 		source, err = newFileSource(config.Name, config.Location)
 	case SourceKindUrl:
 		source, err = newUrlSource(ctx, config.Name, config.Location, sm.transport)
+	case SourceKindBundle:
+		source, err = newBundleSource(config.Name, config.Location)
 	default:
 		err = sm.serviceLocator.ResolveNamed(string(config.Type), &source)
 		if err != nil {
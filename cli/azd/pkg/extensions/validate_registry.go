@@ -30,6 +30,7 @@ var ValidCapabilities = []CapabilityType{
 	ServiceTargetProviderCapability,
 	FrameworkServiceProviderCapability,
 	MetadataCapability,
+	DaemonCapability,
 }
 
 // validChecksumAlgorithms defines the supported checksum algorithms.
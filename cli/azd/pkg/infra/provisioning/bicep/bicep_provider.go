@@ -25,6 +25,7 @@ import (
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cognitiveservices/armcognitiveservices"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/account"
 	"github.com/azure/azure-dev/cli/azd/pkg/ai"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
@@ -693,6 +694,19 @@ func (p *BicepProvider) Deploy(ctx context.Context) (*provisioning.DeployResult,
 		}
 	}
 
+	// The policy gate isn't part of the optional preflight validation, so turning preflight off doesn't bypass it
+	p.console.ShowSpinner(ctx, "Evaluating policy rules", input.Step)
+	abort, policyErr := p.validatePolicy(ctx, deployment, p.path, planned.Parameters)
+	if policyErr != nil {
+		p.console.StopSpinner(ctx, "Evaluating policy rules", input.StepFailed)
+		return nil, policyErr
+	}
+	if abort {
+		p.console.StopSpinner(ctx, "Evaluating policy rules", input.StepSkipped)
+		return &provisioning.DeployResult{SkippedReason: provisioning.PreflightAbortedSkipped}, nil
+	}
+	p.console.StopSpinner(ctx, "", input.StepDone)
+
 	if !skipPreflight {
 		p.console.ShowSpinner(ctx, "Validating deployment", input.Step)
 		abort, preflightErr := p.validatePreflight(
@@ -807,6 +821,29 @@ func (p *BicepProvider) Preview(ctx context.Context) (*provisioning.DeployPrevie
 		return nil, err
 	}
 
+	changes, err := convertWhatIfChanges(deployPreviewResult)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.previewPolicy(ctx, changes); err != nil {
+		return nil, err
+	}
+
+	return &provisioning.DeployPreviewResult{
+		Preview: &provisioning.DeploymentPreview{
+			Status: *deployPreviewResult.Status,
+			Properties: &provisioning.DeploymentPreviewProperties{
+				Changes: changes,
+			},
+		},
+	}, nil
+}
+
+// convertWhatIfChanges converts the resource changes of a what-if operation, failing when the operation failed
+func convertWhatIfChanges(
+	deployPreviewResult *armresources.WhatIfOperationResult,
+) ([]*provisioning.DeploymentPreviewChange, error) {
 	if deployPreviewResult.Error != nil {
 		deploymentErr := *deployPreviewResult.Error
 		errDetailsList := make([]string, len(deploymentErr.Details))
@@ -868,14 +905,7 @@ func (p *BicepProvider) Preview(ctx context.Context) (*provisioning.DeployPrevie
 		})
 	}
 
-	return &provisioning.DeployPreviewResult{
-		Preview: &provisioning.DeploymentPreview{
-			Status: *deployPreviewResult.Status,
-			Properties: &provisioning.DeploymentPreviewProperties{
-				Changes: changes,
-			},
-		},
-	}, nil
+	return changes, nil
 }
 
 // convertPropertyChanges converts Azure SDK's WhatIfPropertyChange to our DeploymentPreviewPropertyChange
@@ -995,6 +1025,10 @@ func (p *BicepProvider) Destroy(
 		return nil, fmt.Errorf("getting resources to delete: %w", err)
 	}
 
+	if len(options.ResourceIds()) > 0 {
+		return p.destroyTargetResources(ctx, options, resourcesToDelete)
+	}
+
 	groupedResources, err := azapi.GroupByResourceGroup(resourcesToDelete)
 	if err != nil {
 		return nil, fmt.Errorf("mapping resources to resource groups: %w", err)
@@ -1011,120 +1045,225 @@ func (p *BicepProvider) Destroy(
 			return nil, fmt.Errorf("voiding deployment state: %w", err)
 		}
 	} else {
-		keyVaults, err := p.getKeyVaultsToPurge(ctx, groupedResources)
-		if err != nil {
-			return nil, fmt.Errorf("getting key vaults to purge: %w", err)
-		}
+		err := p.deleteResources(ctx, options, groupedResources, len(resourcesToDelete), func() error {
+			if err := p.destroyDeployment(ctx, deploymentToDelete); err != nil {
+				return fmt.Errorf("deleting resource groups: %w", err)
+			}
 
-		managedHSMs, err := p.getManagedHSMsToPurge(ctx, groupedResources)
+			return nil
+		})
 		if err != nil {
-			return nil, fmt.Errorf("getting managed hsms to purge: %w", err)
+			return nil, err
 		}
+	}
 
-		appConfigs, err := p.getAppConfigsToPurge(ctx, groupedResources)
-		if err != nil {
-			return nil, fmt.Errorf("getting app configurations to purge: %w", err)
-		}
+	destroyResult := &provisioning.DestroyResult{
+		InvalidatedEnvKeys: slices.Collect(maps.Keys(provisioning.OutputParametersFromArmOutputs(
+			compileResult.Template.Outputs,
+			azapi.CreateDeploymentOutput(mostRecentDeployment.Outputs),
+		))),
+	}
 
-		apiManagements, err := p.getApiManagementsToPurge(ctx, groupedResources)
-		if err != nil {
-			return nil, fmt.Errorf("getting API managements to purge: %w", err)
-		}
+	return destroyResult, nil
+}
 
-		cognitiveAccounts, err := p.getCognitiveAccountsToPurge(ctx, groupedResources)
-		if err != nil {
-			return nil, fmt.Errorf("getting cognitive accounts to purge: %w", err)
-		}
+// deleteResources prompts for the deletion of the grouped resources and deletes them with the given delete function.
+// The resources that are soft-deleted by default, such as key vaults, are purged afterwards.
+func (p *BicepProvider) deleteResources(
+	ctx context.Context,
+	options provisioning.DestroyOptions,
+	groupedResources map[string][]*azapi.Resource,
+	resourceCount int,
+	deleteFn func() error,
+) error {
+	keyVaults, err := p.getKeyVaultsToPurge(ctx, groupedResources)
+	if err != nil {
+		return fmt.Errorf("getting key vaults to purge: %w", err)
+	}
 
-		logAnalyticsWorkspaces, err := p.getLogAnalyticsWorkspacesToPurge(ctx, groupedResources)
-		if err != nil {
-			return nil, fmt.Errorf("getting log analytics workspaces to purge: %w", err)
-		}
+	managedHSMs, err := p.getManagedHSMsToPurge(ctx, groupedResources)
+	if err != nil {
+		return fmt.Errorf("getting managed hsms to purge: %w", err)
+	}
 
-		p.console.StopSpinner(ctx, "", input.StepDone)
+	appConfigs, err := p.getAppConfigsToPurge(ctx, groupedResources)
+	if err != nil {
+		return fmt.Errorf("getting app configurations to purge: %w", err)
+	}
 
-		// Prompt for confirmation before deleting resources
-		if err := p.promptDeletion(ctx, options, groupedResources, len(resourcesToDelete)); err != nil {
-			return nil, err
-		}
+	apiManagements, err := p.getApiManagementsToPurge(ctx, groupedResources)
+	if err != nil {
+		return fmt.Errorf("getting API managements to purge: %w", err)
+	}
 
-		p.console.Message(ctx, output.WithGrayFormat("Deleting your resources can take some time.\n"))
+	cognitiveAccounts, err := p.getCognitiveAccountsToPurge(ctx, groupedResources)
+	if err != nil {
+		return fmt.Errorf("getting cognitive accounts to purge: %w", err)
+	}
 
-		// Force delete Log Analytics Workspaces first if purge is enabled
-		// This must happen before deleting resource groups since force delete requires the workspace to exist
-		if options.Purge() && len(logAnalyticsWorkspaces) > 0 {
-			if err := p.forceDeleteLogAnalyticsWorkspaces(ctx, logAnalyticsWorkspaces); err != nil {
-				return nil, fmt.Errorf("force deleting log analytics workspaces: %w", err)
-			}
+	logAnalyticsWorkspaces, err := p.getLogAnalyticsWorkspacesToPurge(ctx, groupedResources)
+	if err != nil {
+		return fmt.Errorf("getting log analytics workspaces to purge: %w", err)
+	}
+
+	p.console.StopSpinner(ctx, "", input.StepDone)
+
+	// Prompt for confirmation before deleting resources
+	if err := p.promptDeletion(ctx, options, groupedResources, resourceCount); err != nil {
+		return err
+	}
+
+	p.console.Message(ctx, output.WithGrayFormat("Deleting your resources can take some time.\n"))
+
+	// Force delete Log Analytics Workspaces first if purge is enabled
+	// This must happen before deleting resource groups since force delete requires the workspace to exist
+	if options.Purge() && len(logAnalyticsWorkspaces) > 0 {
+		if err := p.forceDeleteLogAnalyticsWorkspaces(ctx, logAnalyticsWorkspaces); err != nil {
+			return fmt.Errorf("force deleting log analytics workspaces: %w", err)
 		}
+	}
 
-		if err := p.destroyDeployment(ctx, deploymentToDelete); err != nil {
-			return nil, fmt.Errorf("deleting resource groups: %w", err)
+	if err := deleteFn(); err != nil {
+		return err
+	}
+
+	keyVaultsPurge := itemToPurge{
+		resourceType: "Key Vault",
+		count:        len(keyVaults),
+		purge: func(skipPurge bool, self *itemToPurge) error {
+			return p.purgeKeyVaults(ctx, keyVaults, skipPurge)
+		},
+	}
+	managedHSMsPurge := itemToPurge{
+		resourceType: "Managed HSM",
+		count:        len(managedHSMs),
+		purge: func(skipPurge bool, self *itemToPurge) error {
+			return p.purgeManagedHSMs(ctx, managedHSMs, skipPurge)
+		},
+	}
+	appConfigsPurge := itemToPurge{
+		resourceType: "App Configuration",
+		count:        len(appConfigs),
+		purge: func(skipPurge bool, self *itemToPurge) error {
+			return p.purgeAppConfigs(ctx, appConfigs, skipPurge)
+		},
+	}
+	aPIManagement := itemToPurge{
+		resourceType: "API Management",
+		count:        len(apiManagements),
+		purge: func(skipPurge bool, self *itemToPurge) error {
+			return p.purgeAPIManagement(ctx, apiManagements, skipPurge)
+		},
+	}
+
+	var purgeItem []itemToPurge
+	for _, item := range []itemToPurge{keyVaultsPurge, managedHSMsPurge, appConfigsPurge, aPIManagement} {
+		if item.count > 0 {
+			purgeItem = append(purgeItem, item)
 		}
+	}
 
-		keyVaultsPurge := itemToPurge{
-			resourceType: "Key Vault",
-			count:        len(keyVaults),
+	// cognitive services are grouped by resource group because the name of the resource group is required to purge
+	groupByKind := cognitiveAccountsByKind(cognitiveAccounts)
+	for name, cogAccounts := range groupByKind {
+		addPurgeItem := itemToPurge{
+			resourceType: name,
+			count:        len(cogAccounts),
 			purge: func(skipPurge bool, self *itemToPurge) error {
-				return p.purgeKeyVaults(ctx, keyVaults, skipPurge)
+				return p.purgeCognitiveAccounts(ctx, self.cognitiveAccounts, skipPurge)
 			},
+			cognitiveAccounts: groupByKind[name],
 		}
-		managedHSMsPurge := itemToPurge{
-			resourceType: "Managed HSM",
-			count:        len(managedHSMs),
-			purge: func(skipPurge bool, self *itemToPurge) error {
-				return p.purgeManagedHSMs(ctx, managedHSMs, skipPurge)
-			},
+		purgeItem = append(purgeItem, addPurgeItem)
+	}
+
+	if err := p.purgeItems(ctx, purgeItem, options); err != nil {
+		return fmt.Errorf("purging resources: %w", err)
+	}
+
+	return nil
+}
+
+// destroyTargetResources deletes the resources of the deployment selected by the destroy options. The deployment and the
+// resource groups it created are kept, the deleted resources are created again the next time the infrastructure is
+// provisioned.
+func (p *BicepProvider) destroyTargetResources(
+	ctx context.Context,
+	options provisioning.DestroyOptions,
+	deploymentResources []*armresources.ResourceReference,
+) (*provisioning.DestroyResult, error) {
+	// Resources that weren't created by the deployment, like resources tagged manually, are never deleted. The caller
+	// reports them, since they may be part of the deployment of another layer.
+	targetResources := []*armresources.ResourceReference{}
+	for _, resource := range deploymentResources {
+		if resource.ID == nil {
+			continue
 		}
-		appConfigsPurge := itemToPurge{
-			resourceType: "App Configuration",
-			count:        len(appConfigs),
-			purge: func(skipPurge bool, self *itemToPurge) error {
-				return p.purgeAppConfigs(ctx, appConfigs, skipPurge)
-			},
+
+		isTarget := func(resourceId string) bool {
+			return strings.EqualFold(resourceId, *resource.ID)
 		}
-		aPIManagement := itemToPurge{
-			resourceType: "API Management",
-			count:        len(apiManagements),
-			purge: func(skipPurge bool, self *itemToPurge) error {
-				return p.purgeAPIManagement(ctx, apiManagements, skipPurge)
-			},
+
+		if slices.ContainsFunc(options.ResourceIds(), isTarget) {
+			targetResources = append(targetResources, resource)
 		}
+	}
 
-		var purgeItem []itemToPurge
-		for _, item := range []itemToPurge{keyVaultsPurge, managedHSMsPurge, appConfigsPurge, aPIManagement} {
-			if item.count > 0 {
-				purgeItem = append(purgeItem, item)
-			}
+	groupedResources, err := azapi.GroupByResourceGroup(targetResources)
+	if err != nil {
+		return nil, fmt.Errorf("mapping resources to resource groups: %w", err)
+	}
+
+	// Resource groups are never deleted when targeting resources
+	resourceCount := 0
+	for resourceGroup, resources := range groupedResources {
+		if len(resources) == 0 {
+			delete(groupedResources, resourceGroup)
 		}
+		resourceCount += len(resources)
+	}
 
-		// cognitive services are grouped by resource group because the name of the resource group is required to purge
-		groupByKind := cognitiveAccountsByKind(cognitiveAccounts)
-		for name, cogAccounts := range groupByKind {
-			addPurgeItem := itemToPurge{
-				resourceType: name,
-				count:        len(cogAccounts),
-				purge: func(skipPurge bool, self *itemToPurge) error {
-					return p.purgeCognitiveAccounts(ctx, self.cognitiveAccounts, skipPurge)
-				},
-				cognitiveAccounts: groupByKind[name],
+	if resourceCount == 0 {
+		return nil, infra.ErrDeploymentResourcesNotFound
+	}
+
+	err = p.deleteResources(ctx, options, groupedResources, resourceCount, func() error {
+		return p.deleteResourceList(ctx, groupedResources)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	deletedResourceIds := []string{}
+	for _, resource := range targetResources {
+		deletedResourceIds = append(deletedResourceIds, *resource.ID)
+	}
+
+	return &provisioning.DestroyResult{DeletedResourceIds: deletedResourceIds}, nil
+}
+
+// deleteResourceList deletes the grouped resources one by one
+func (p *BicepProvider) deleteResourceList(ctx context.Context, groupedResources map[string][]*azapi.Resource) error {
+	for _, resourceGroup := range slices.Sorted(maps.Keys(groupedResources)) {
+		for _, resource := range groupedResources[resourceGroup] {
+			resourceId, err := arm.ParseResourceID(resource.Id)
+			if err != nil {
+				return fmt.Errorf("parsing resource id %s: %w", resource.Id, err)
 			}
-			purgeItem = append(purgeItem, addPurgeItem)
-		}
 
-		if err := p.purgeItems(ctx, purgeItem, options); err != nil {
-			return nil, fmt.Errorf("purging resources: %w", err)
+			name := output.WithHighLightFormat(resource.Name)
+			p.console.ShowSpinner(ctx, fmt.Sprintf("Deleting resource %s", name), input.Step)
+			if err := p.resourceService.DeleteResource(ctx, *resourceId); err != nil {
+				p.console.StopSpinner(ctx, fmt.Sprintf("Failed deleting resource %s", name), input.StepFailed)
+				return fmt.Errorf("deleting resource %s: %w", resource.Name, err)
+			}
+			p.console.StopSpinner(ctx, fmt.Sprintf("Deleted resource %s", name), input.StepDone)
 		}
 	}
 
-	destroyResult := &provisioning.DestroyResult{
-		InvalidatedEnvKeys: slices.Collect(maps.Keys(provisioning.OutputParametersFromArmOutputs(
-			compileResult.Template.Outputs,
-			azapi.CreateDeploymentOutput(mostRecentDeployment.Outputs),
-		))),
-	}
+	p.console.Message(ctx, "")
 
-	return destroyResult, nil
+	return nil
 }
 
 // A local type for adding the resource group to a cognitive account as it is required for purging
@@ -2161,7 +2300,59 @@ func (p *BicepProvider) validatePreflight(
 		return false, fmt.Errorf("local preflight validation failed: %w", err)
 	}
 
-	// Build a UX report from the preflight results and display it.
+	abort, err := p.reportPreflightResults(ctx, results)
+	if err != nil || abort {
+		return abort, err
+	}
+
+	return false, target.ValidatePreflight(ctx, armTemplate, armParameters, tags, options)
+}
+
+// validatePolicy evaluates the policy rules of the project against the resources predicted by the Bicep snapshot of the
+// deployment. Unlike the optional preflight checks, the policy is evaluated even when preflight validation is turned off,
+// and the deployment is blocked when a rule with error severity can't be evaluated.
+// Returns true when the deployment should be aborted.
+func (p *BicepProvider) validatePolicy(
+	ctx context.Context,
+	target infra.Deployment,
+	modulePath string,
+	armParameters azure.ArmParameters,
+) (bool, error) {
+	rules, err := p.policyRules()
+	if err != nil {
+		return false, err
+	}
+
+	if len(rules) == 0 {
+		return false, nil
+	}
+
+	snapshot, err := newLocalArmPreflight(modulePath, p.bicepCli, target).snapshot(ctx, armParameters)
+	if err != nil {
+		if hasErrorSeverityRule(rules) {
+			return false, &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf("evaluating policy rules: %w", err),
+				Suggestion: "Policy rules with error severity must be evaluated before provisioning. Upgrade Bicep " +
+					"to a version supporting 'bicep snapshot', or suppress the rules in the 'infra.policy.suppress' " +
+					"section of azure.yaml.",
+			}
+		}
+
+		log.Printf("policy: skipping warning rules, bicep snapshot unavailable: %v", err)
+		return false, nil
+	}
+
+	results, err := evaluatePolicyRules(rules, snapshot)
+	if err != nil {
+		return false, err
+	}
+
+	return p.reportPreflightResults(ctx, results)
+}
+
+// reportPreflightResults displays the preflight results. Returns true when the deployment should be aborted, because of
+// errors or because the user chose not to continue after warnings.
+func (p *BicepProvider) reportPreflightResults(ctx context.Context, results []PreflightCheckResult) (bool, error) {
 	if len(results) > 0 {
 		report := &ux.PreflightReport{}
 		for _, result := range results {
@@ -2196,7 +2387,62 @@ func (p *BicepProvider) validatePreflight(
 		}
 	}
 
-	return false, target.ValidatePreflight(ctx, armTemplate, armParameters, tags, options)
+	return false, nil
+}
+
+// policyRules loads the policy rules of the infrastructure, excluding the rules suppressed in azure.yaml.
+// Without rule files listed in azure.yaml, the policy.yaml file of the infra folder is used when present.
+func (p *BicepProvider) policyRules() ([]*policyRule, error) {
+	var paths []string
+	for _, path := range p.options.Policy.Rules {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(p.projectPath, path)
+		}
+		paths = append(paths, path)
+	}
+
+	if len(paths) == 0 {
+		defaultPath := filepath.Join(p.options.Path, defaultPolicyFileName)
+		if _, err := os.Stat(defaultPath); err == nil {
+			paths = append(paths, defaultPath)
+		}
+	}
+
+	return loadPolicyRules(paths, p.options.Policy.Suppress)
+}
+
+// previewPolicy evaluates the policy rules against the resources of a what-if result, reporting the violations in the
+// preflight report. Nothing is deployed by a preview, so violations are reported without blocking it.
+func (p *BicepProvider) previewPolicy(ctx context.Context, changes []*provisioning.DeploymentPreviewChange) error {
+	rules, err := p.policyRules()
+	if err != nil {
+		return err
+	}
+
+	var resources []map[string]any
+	for _, change := range changes {
+		// Deleted resources have no After state and aren't subject to the policy anymore
+		if state, ok := change.After.(map[string]any); ok {
+			resources = append(resources, state)
+		}
+	}
+
+	report := &ux.PreflightReport{}
+	for _, rule := range rules {
+		if result := rule.result(rule.evaluate(resources)); result != nil {
+			report.Items = append(report.Items, ux.PreflightReportItem{
+				IsError: result.Severity == PreflightCheckError,
+				Message: result.Message,
+			})
+		}
+	}
+
+	if len(report.Items) > 0 {
+		p.console.StopSpinner(ctx, "Generating infrastructure preview", input.StepDone)
+		p.console.MessageUxItem(ctx, report)
+	}
+
+	return nil
 }
 
 // checkRoleAssignmentPermissions is a PreflightCheckFn that verifies the current principal
@@ -8,10 +8,13 @@ import (
 	"context"
 	_ "embed"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"maps"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -223,6 +226,110 @@ func TestBicepDestroy(t *testing.T) {
 	})
 }
 
+func TestBicepDestroyResources(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+	prepareStateMocks(mockContext)
+	prepareDestroyMocks(mockContext)
+
+	keyVaultId := fmt.Sprintf("/subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP/providers/%s/kv-123",
+		string(azapi.AzureResourceTypeKeyVault))
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && strings.HasSuffix(request.URL.Path, "/resources")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armresources.ResourceListResult{
+			Value: []*armresources.GenericResourceExpanded{
+				{
+					ID:       new(keyVaultId),
+					Name:     new("kv-123"),
+					Type:     new(string(azapi.AzureResourceTypeKeyVault)),
+					Location: new("eastus2"),
+				},
+				{
+					ID: new(fmt.Sprintf("/subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP/providers/%s/app-123",
+						string(azapi.AzureResourceTypeWebSite))),
+					Name:     new("app-123"),
+					Type:     new(string(azapi.AzureResourceTypeWebSite)),
+					Location: new("eastus2"),
+				},
+			},
+		})
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet &&
+			strings.HasSuffix(request.URL.Path, "/subscriptions/SUBSCRIPTION_ID/providers/Microsoft.KeyVault")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armresources.Provider{
+			Namespace: new("Microsoft.KeyVault"),
+			ResourceTypes: []*armresources.ProviderResourceType{
+				{
+					ResourceType: new("vaults"),
+					APIVersions:  []*string{new("2024-12-01-preview"), new("2023-07-01")},
+				},
+			},
+		})
+	})
+
+	var deletedPaths []string
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodDelete
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		deletedPaths = append(deletedPaths, request.URL.Path)
+		require.Equal(t, "2023-07-01", request.URL.Query().Get("api-version"))
+		return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+	})
+
+	purged := false
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost && strings.HasSuffix(request.URL.Path, "deletedVaults/kv-123/purge")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		purged = true
+		return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+	})
+
+	infraProvider := createBicepProvider(t, mockContext)
+
+	destroyOptions := provisioning.NewDestroyResourcesOptions(true, true, []string{keyVaultId})
+	destroyResult, err := infraProvider.Destroy(*mockContext.Context, destroyOptions)
+
+	require.NoError(t, err)
+	require.NotNil(t, destroyResult)
+	require.Empty(t, destroyResult.InvalidatedEnvKeys)
+	require.Equal(t, []string{keyVaultId}, destroyResult.DeletedResourceIds)
+
+	// Only the key vault is deleted, the resource group and the deployment are kept
+	require.Equal(t, []string{keyVaultId}, deletedPaths)
+	require.True(t, purged)
+}
+
+func TestBicepDestroyResourcesNotInDeployment(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+	prepareStateMocks(mockContext)
+	prepareDestroyMocks(mockContext)
+
+	deleted := false
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodDelete
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		deleted = true
+		return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+	})
+
+	// A resource tagged with the name of a service, but created outside of the deployment
+	taggedId := "/subscriptions/SUBSCRIPTION_ID/resourceGroups/RESOURCE_GROUP/providers/Microsoft.Web/sites/app-tagged"
+
+	infraProvider := createBicepProvider(t, mockContext)
+
+	destroyOptions := provisioning.NewDestroyResourcesOptions(true, true, []string{taggedId})
+	_, err := infraProvider.Destroy(*mockContext.Context, destroyOptions)
+
+	require.ErrorIs(t, err, infra.ErrDeploymentResourcesNotFound)
+	require.False(t, deleted)
+}
+
 func TestBicepDestroyLogAnalyticsWorkspace(t *testing.T) {
 	t.Run("WithPurge", func(t *testing.T) {
 		mockContext := mocks.NewMockContext(context.Background())
@@ -1666,6 +1773,268 @@ func TestPreviewWithNilResourceState(t *testing.T) {
 	assert.Equal(t, "app3", changes[2].Name)
 }
 
+func TestPreviewPolicy(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost &&
+			strings.Contains(request.URL.Path, "/providers/Microsoft.Resources/deployments/") &&
+			strings.HasSuffix(request.URL.Path, "/whatIf")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		storage := func(name string, publicNetworkAccess string) map[string]any {
+			return map[string]any{
+				"type":       "Microsoft.Storage/storageAccounts",
+				"name":       name,
+				"properties": map[string]any{"publicNetworkAccess": publicNetworkAccess},
+			}
+		}
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, armresources.WhatIfOperationResult{
+			Status: new("Succeeded"),
+			Properties: &armresources.WhatIfOperationProperties{
+				Changes: []*armresources.WhatIfChange{
+					{
+						ChangeType: to.Ptr(armresources.ChangeTypeCreate),
+						ResourceID: new("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st1"),
+						After:      storage("st1", "Enabled"),
+					},
+					{
+						ChangeType: to.Ptr(armresources.ChangeTypeModify),
+						ResourceID: new("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st2"),
+						Before:     storage("st2", "Enabled"),
+						After:      storage("st2", "Disabled"),
+					},
+					{
+						// Deleted resources aren't evaluated
+						ChangeType: to.Ptr(armresources.ChangeTypeDelete),
+						ResourceID: new("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/st3"),
+						Before:     storage("st3", "Enabled"),
+					},
+				},
+			},
+		})
+	})
+
+	policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(policyFile, []byte(`
+rules:
+  - id: storage-no-public-access
+    severity: error
+    resourceTypes: [Microsoft.Storage/storageAccounts]
+    field: properties.publicNetworkAccess
+    equals: Disabled
+`), 0600))
+
+	infraProvider := createBicepProvider(t, mockContext)
+	infraProvider.options.Policy.Rules = []string{policyFile}
+
+	result, err := infraProvider.Preview(*mockContext.Context)
+	require.NoError(t, err)
+	require.Len(t, result.Preview.Properties.Changes, 3)
+
+	consoleOutput := mockContext.Console.Output()
+	require.Len(t, consoleOutput, 1)
+	require.Contains(t, consoleOutput[0], "policy storage-no-public-access")
+	require.Contains(t, consoleOutput[0], "st1 (Microsoft.Storage/storageAccounts)")
+	require.NotContains(t, consoleOutput[0], "st2")
+	require.NotContains(t, consoleOutput[0], "st3")
+
+	// Suppressed rules aren't evaluated
+	infraProvider.options.Policy.Suppress = []string{"storage-no-public-access"}
+
+	_, err = infraProvider.Preview(*mockContext.Context)
+	require.NoError(t, err)
+	require.Len(t, mockContext.Console.Output(), 1)
+}
+
+func TestValidatePolicySnapshotUnavailable(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(args.Cmd, "bicep") && args.Args[0] == "snapshot"
+	}).SetError(errors.New("unrecognized command 'snapshot'"))
+
+	policyFile := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(policyFile, []byte(`
+rules:
+  - id: storage-no-public-access
+    severity: error
+    resourceTypes: [Microsoft.Storage/storageAccounts]
+    field: properties.publicNetworkAccess
+    equals: Disabled
+  - id: owner-tag
+    field: tags.owner
+    exists: true
+`), 0600))
+
+	infraProvider := createBicepProvider(t, mockContext)
+	infraProvider.options.Policy.Rules = []string{policyFile}
+	modulePath := filepath.Join(t.TempDir(), "main.bicep")
+	// Rules with error severity that can't be evaluated block the deployment
+	_, err := infraProvider.validatePolicy(*mockContext.Context, nil, modulePath, azure.ArmParameters{})
+	require.ErrorContains(t, err, "evaluating policy rules")
+
+	// Warning rules are skipped
+	infraProvider.options.Policy.Suppress = []string{"storage-no-public-access"}
+	abort, err := infraProvider.validatePolicy(*mockContext.Context, nil, modulePath, azure.ArmParameters{})
+	require.NoError(t, err)
+	require.False(t, abort)
+}
+
+func TestBicepCheckDrift(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareBicepMocks(mockContext)
+
+	lastDeployment := testEnvDeployment
+	lastDeployment.Properties = &armresources.DeploymentPropertiesExtended{
+		Parameters: map[string]any{
+			"environmentName": map[string]any{"type": "String", "value": "deployed-env"},
+			"kvSecret":        map[string]any{"type": "SecureString"},
+		},
+		ProvisioningState: to.Ptr(armresources.ProvisioningStateSucceeded),
+		Timestamp:         new(time.Now()),
+	}
+	deploymentsPageBytes, _ := json.Marshal(&armresources.DeploymentListResult{
+		Value: []*armresources.DeploymentExtended{&lastDeployment},
+	})
+
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodGet && strings.HasSuffix(
+			request.URL.Path,
+			"/SUBSCRIPTION_ID/providers/Microsoft.Resources/deployments/",
+		)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(deploymentsPageBytes)),
+		}, nil
+	})
+
+	deployedTemplate := map[string]any{"contentVersion": "1.0.0.0", "resources": []any{}}
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost && strings.HasSuffix(
+			request.URL.Path,
+			"/SUBSCRIPTION_ID/providers/Microsoft.Resources/deployments/test-env/exportTemplate",
+		)
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		bodyBytes, _ := json.Marshal(armresources.DeploymentExportResult{Template: deployedTemplate})
+		return &http.Response{
+			Request:    request,
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(bodyBytes)),
+		}, nil
+	})
+
+	var whatIfRequest armresources.DeploymentWhatIf
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return request.Method == http.MethodPost && strings.HasSuffix(request.URL.Path, "/whatIf")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		if err := mocks.ReadHttpBody(request.Body, &whatIfRequest); err != nil {
+			return nil, err
+		}
+
+		whatIfResult := armresources.WhatIfOperationResult{
+			Status: new("Succeeded"),
+			Properties: &armresources.WhatIfOperationProperties{
+				Changes: []*armresources.WhatIfChange{
+					// Deleted outside of azd
+					{
+						ChangeType: to.Ptr(armresources.ChangeTypeCreate),
+						ResourceID: new("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/sites/app1"),
+						After: map[string]any{
+							"type": "Microsoft.Web/sites",
+							"name": "app1",
+						},
+					},
+					// Modified outside of azd
+					{
+						ChangeType: to.Ptr(armresources.ChangeTypeModify),
+						ResourceID: new("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/sites/app2"),
+						Before: map[string]any{
+							"type": "Microsoft.Web/sites",
+							"name": "app2",
+						},
+						After: map[string]any{
+							"type": "Microsoft.Web/sites",
+							"name": "app2",
+						},
+						Delta: []*armresources.WhatIfPropertyChange{
+							{
+								Path:               new("properties.httpsOnly"),
+								PropertyChangeType: to.Ptr(armresources.PropertyChangeTypeModify),
+								Before:             false,
+								After:              true,
+							},
+						},
+					},
+					// Only changes without effect
+					{
+						ChangeType: to.Ptr(armresources.ChangeTypeModify),
+						ResourceID: new("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/sites/app3"),
+						Before: map[string]any{
+							"type": "Microsoft.Web/sites",
+							"name": "app3",
+						},
+						After: map[string]any{
+							"type": "Microsoft.Web/sites",
+							"name": "app3",
+						},
+						Delta: []*armresources.WhatIfPropertyChange{
+							{
+								Path:               new("properties.siteConfig"),
+								PropertyChangeType: to.Ptr(armresources.PropertyChangeTypeNoEffect),
+							},
+						},
+					},
+					{
+						ChangeType: to.Ptr(armresources.ChangeTypeNoChange),
+						ResourceID: new("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Web/sites/app4"),
+						After: map[string]any{
+							"type": "Microsoft.Web/sites",
+							"name": "app4",
+						},
+					},
+				},
+			},
+		}
+
+		bodyBytes, _ := json.Marshal(whatIfResult)
+		return &http.Response{
+			Request:    request,
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewBuffer(bodyBytes)),
+		}, nil
+	})
+
+	infraProvider := createBicepProvider(t, mockContext)
+
+	result, err := infraProvider.CheckDrift(*mockContext.Context)
+	require.NoError(t, err)
+
+	// what-if runs the deployed template with the deployed parameters
+	require.Equal(t, deployedTemplate, whatIfRequest.Properties.Template)
+	parameters, ok := whatIfRequest.Properties.Parameters.(map[string]any)
+	require.True(t, ok)
+	require.Equal(t, "deployed-env", parameters["environmentName"].(map[string]any)["value"])
+	require.Contains(t, parameters, "kvSecret")
+	require.NotContains(t, parameters, "location")
+
+	require.True(t, result.HasDrift())
+	require.Len(t, result.Changes, 2)
+
+	assert.Equal(t, provisioning.ChangeTypeDelete, result.Changes[0].ChangeType)
+	assert.Equal(t, "app1", result.Changes[0].Name)
+	assert.Nil(t, result.Changes[0].After)
+
+	assert.Equal(t, provisioning.ChangeTypeModify, result.Changes[1].ChangeType)
+	assert.Equal(t, "app2", result.Changes[1].Name)
+	require.Len(t, result.Changes[1].Delta, 1)
+	assert.Equal(t, true, result.Changes[1].Delta[0].Before)
+	assert.Equal(t, false, result.Changes[1].Delta[0].After)
+}
+
 func TestArrayParameterViaEnvVarSimple(t *testing.T) {
 	// Test that array/object parameters are correctly identified and handled using provisioning types
 	env := environment.NewWithValues("test-env", map[string]string{
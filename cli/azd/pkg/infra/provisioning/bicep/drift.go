@@ -0,0 +1,159 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/azure"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+)
+
+// CheckDrift runs what-if with the template and parameters of the last deployment, reporting the changes made to the
+// provisioned resources since. The local template is only compiled to resolve the scope of the deployment and the values
+// of the secure parameters, which are never returned by Azure.
+func (p *BicepProvider) CheckDrift(ctx context.Context) (*provisioning.DriftResult, error) {
+	planned, err := p.plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := p.generateDeploymentObject(planned)
+	if err != nil {
+		return nil, err
+	}
+
+	p.console.ShowSpinner(ctx, "Retrieving the last deployment", input.Step)
+	lastDeployment, err := p.latestDeploymentResult(ctx, deployment)
+	if err != nil {
+		return nil, &internal.ErrorWithSuggestion{
+			Err:        fmt.Errorf("finding the last deployment: %w", err),
+			Suggestion: "Run 'azd provision' to provision the infrastructure before checking it for drift.",
+		}
+	}
+
+	template, err := deployment.Deployment(lastDeployment.Name).ExportTemplate(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("exporting the template of deployment '%s': %w", lastDeployment.Name, err)
+	}
+
+	parameters, err := deployedParameters(lastDeployment.Parameters, planned.Parameters)
+	if err != nil {
+		return nil, fmt.Errorf("reading the parameters of deployment '%s': %w", lastDeployment.Name, err)
+	}
+
+	p.console.ShowSpinner(ctx, "Comparing resources to the last deployment", input.Step)
+	whatIfResult, err := deployment.DeployPreview(ctx, template, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := convertWhatIfChanges(whatIfResult)
+	if err != nil {
+		return nil, err
+	}
+
+	return &provisioning.DriftResult{
+		Changes: driftChanges(changes),
+	}, nil
+}
+
+// deployedParameters returns the parameters of a deployment. Azure omits the values of secure parameters, which are
+// resolved from the local parameters instead.
+func deployedParameters(deployed any, local azure.ArmParameters) (azure.ArmParameters, error) {
+	raw, err := json.Marshal(deployed)
+	if err != nil {
+		return nil, err
+	}
+
+	var values map[string]map[string]any
+	if err := json.Unmarshal(raw, &values); err != nil {
+		return nil, err
+	}
+
+	parameters := azure.ArmParameters{}
+	for name, parameter := range values {
+		if value, has := parameter["value"]; has {
+			parameters[name] = azure.ArmParameter{Value: value}
+			continue
+		}
+
+		if localParameter, has := local[name]; has {
+			parameters[name] = localParameter
+		}
+	}
+
+	return parameters, nil
+}
+
+// driftChanges converts the what-if changes restoring the last deployment into the changes made outside of azd.
+// Changes without effect are dropped.
+func driftChanges(changes []*provisioning.DeploymentPreviewChange) []*provisioning.DeploymentPreviewChange {
+	var drift []*provisioning.DeploymentPreviewChange
+	for _, change := range changes {
+		switch change.ChangeType {
+		case provisioning.ChangeTypeCreate:
+			// Restoring the deployment would create the resource, it was deleted outside of azd
+			change.ChangeType = provisioning.ChangeTypeDelete
+		case provisioning.ChangeTypeDelete:
+			change.ChangeType = provisioning.ChangeTypeCreate
+		case provisioning.ChangeTypeModify:
+			change.Delta = effectivePropertyChanges(change.Delta)
+			if len(change.Delta) == 0 {
+				continue
+			}
+		default:
+			continue
+		}
+
+		change.Before, change.After = change.After, change.Before
+		change.Delta = invertPropertyChanges(change.Delta)
+		drift = append(drift, change)
+	}
+
+	return drift
+}
+
+// effectivePropertyChanges drops the property changes reported by what-if that have no effect
+func effectivePropertyChanges(
+	changes []provisioning.DeploymentPreviewPropertyChange,
+) []provisioning.DeploymentPreviewPropertyChange {
+	var effective []provisioning.DeploymentPreviewPropertyChange
+	for _, change := range changes {
+		if change.ChangeType != provisioning.PropertyChangeTypeNoEffect {
+			effective = append(effective, change)
+		}
+	}
+
+	return effective
+}
+
+// invertPropertyChanges converts the property changes restoring the last deployment into the changes made outside of azd
+func invertPropertyChanges(
+	changes []provisioning.DeploymentPreviewPropertyChange,
+) []provisioning.DeploymentPreviewPropertyChange {
+	if changes == nil {
+		return nil
+	}
+
+	inverted := make([]provisioning.DeploymentPreviewPropertyChange, 0, len(changes))
+	for _, change := range changes {
+		switch change.ChangeType {
+		case provisioning.PropertyChangeTypeCreate:
+			change.ChangeType = provisioning.PropertyChangeTypeDelete
+		case provisioning.PropertyChangeTypeDelete:
+			change.ChangeType = provisioning.PropertyChangeTypeCreate
+		}
+
+		change.Before, change.After = change.After, change.Before
+		change.Children = invertPropertyChanges(change.Children)
+		inverted = append(inverted, change)
+	}
+
+	return inverted
+}
@@ -347,6 +347,45 @@ func (l *localArmPreflight) validate(
 		return nil, fmt.Errorf("parsing ARM template: %w", err)
 	}
 
+	// If the snapshot fails (e.g., older Bicep binary without snapshot support), skip local
+	// preflight rather than blocking the deployment.
+	data, err := l.snapshot(ctx, armParameters)
+	if err != nil {
+		log.Printf("local preflight: skipping checks, bicep snapshot unavailable: %v", err)
+		return nil, nil
+	}
+
+	var snapshot snapshotResult
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("parsing bicep snapshot: %w", err)
+	}
+
+	props := analyzeResources(snapshot.PredictedResources)
+
+	valCtx := &validationContext{
+		Console:           console,
+		Props:             props,
+		ResourcesSnapshot: json.RawMessage(data),
+		SnapshotResources: snapshot.PredictedResources,
+	}
+
+	var results []PreflightCheckResult
+	for _, check := range l.checks {
+		result, err := check(ctx, valCtx)
+		if err != nil {
+			return results, fmt.Errorf("preflight check failed: %w", err)
+		}
+		if result != nil {
+			results = append(results, *result)
+		}
+	}
+
+	return results, nil
+}
+
+// snapshot runs the Bicep snapshot command for the module with the given parameters, returning the deployment snapshot
+// with the predicted resources of the deployment.
+func (l *localArmPreflight) snapshot(ctx context.Context, armParameters azure.ArmParameters) ([]byte, error) {
 	// Determine the .bicepparam file to use for the snapshot.
 	// If the module path already points to a .bicepparam file, use it directly.
 	// Otherwise, create a temporary .bicepparam file next to the .bicep module with the resolved parameters.
@@ -393,40 +432,12 @@ func (l *localArmPreflight) validate(
 	// Run the Bicep snapshot command to produce a deployment snapshot from the bicepparam file.
 	// The snapshot contains the fully resolved deployment graph with expressions evaluated,
 	// conditions applied, and copy loops expanded.
-	// If the snapshot fails (e.g., older Bicep binary without snapshot support), skip local
-	// preflight rather than blocking the deployment.
 	data, err := l.bicepCli.Snapshot(ctx, bicepParamFile, snapshotOpts)
 	if err != nil {
-		log.Printf("local preflight: skipping checks, bicep snapshot unavailable: %v", err)
-		return nil, nil
-	}
-
-	var snapshot snapshotResult
-	if err := json.Unmarshal(data, &snapshot); err != nil {
-		return nil, fmt.Errorf("parsing bicep snapshot: %w", err)
-	}
-
-	props := analyzeResources(snapshot.PredictedResources)
-
-	valCtx := &validationContext{
-		Console:           console,
-		Props:             props,
-		ResourcesSnapshot: json.RawMessage(data),
-		SnapshotResources: snapshot.PredictedResources,
-	}
-
-	var results []PreflightCheckResult
-	for _, check := range l.checks {
-		result, err := check(ctx, valCtx)
-		if err != nil {
-			return results, fmt.Errorf("preflight check failed: %w", err)
-		}
-		if result != nil {
-			results = append(results, *result)
-		}
+		return nil, fmt.Errorf("running bicep snapshot: %w", err)
 	}
 
-	return results, nil
+	return data, nil
 }
 
 // parseTemplate unmarshals a raw ARM template into the parser's own armTemplate structure.
@@ -0,0 +1,261 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/braydonk/yaml"
+)
+
+// defaultPolicyFileName is the name of the policy rule file loaded from the infra folder when azure.yaml doesn't list any
+const defaultPolicyFileName = "policy.yaml"
+
+// policyRuleSet is a declarative set of policy rules, such as:
+//
+//	rules:
+//	  - id: storage-no-public-access
+//	    description: Storage accounts must disable public network access
+//	    severity: error
+//	    resourceTypes: [Microsoft.Storage/storageAccounts]
+//	    field: properties.publicNetworkAccess
+//	    equals: Disabled
+type policyRuleSet struct {
+	Rules []*policyRule `yaml:"rules"`
+}
+
+// policyRule asserts the value of a single field of the resources it applies to. Exactly one of the conditions (equals,
+// notEquals, in, notIn or exists) must be set.
+type policyRule struct {
+	// The unique ID of the rule, used to suppress it in azure.yaml
+	Id          string `yaml:"id"`
+	Description string `yaml:"description,omitempty"`
+	// warning or error. Errors block the deployment. (Default: warning)
+	Severity string `yaml:"severity,omitempty"`
+	// The resource types the rule applies to. The rule applies to every resource when empty.
+	ResourceTypes []string `yaml:"resourceTypes,omitempty"`
+	// The dotted path of the field of the resource, such as properties.publicNetworkAccess or tags.owner
+	Field string `yaml:"field"`
+
+	Equals    any   `yaml:"equals,omitempty"`
+	NotEquals any   `yaml:"notEquals,omitempty"`
+	In        []any `yaml:"in,omitempty"`
+	NotIn     []any `yaml:"notIn,omitempty"`
+	Exists    *bool `yaml:"exists,omitempty"`
+}
+
+// loadPolicyRules loads the policy rules of the given files, excluding the suppressed rules
+func loadPolicyRules(paths []string, suppress []string) ([]*policyRule, error) {
+	var rules []*policyRule
+	ids := map[string]string{}
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading policy rules: %w", err)
+		}
+
+		var ruleSet policyRuleSet
+		if err := yaml.Unmarshal(content, &ruleSet); err != nil {
+			return nil, fmt.Errorf("parsing policy rules %s: %w", path, err)
+		}
+
+		for index, rule := range ruleSet.Rules {
+			if err := rule.validate(); err != nil {
+				return nil, fmt.Errorf("invalid policy rule %d in %s: %w", index+1, path, err)
+			}
+
+			if definedIn, has := ids[strings.ToLower(rule.Id)]; has {
+				return nil, fmt.Errorf("policy rule '%s' in %s is already defined in %s", rule.Id, path, definedIn)
+			}
+			ids[strings.ToLower(rule.Id)] = path
+
+			if slices.ContainsFunc(suppress, func(id string) bool { return strings.EqualFold(id, rule.Id) }) {
+				continue
+			}
+
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules, nil
+}
+
+// validate checks the rule has an ID, a field, a valid severity and a single condition
+func (r *policyRule) validate() error {
+	if r.Id == "" {
+		return errors.New("id is required")
+	}
+
+	if r.Field == "" {
+		return fmt.Errorf("%s: field is required", r.Id)
+	}
+
+	switch strings.ToLower(r.Severity) {
+	case "", "warning", "error":
+	default:
+		return fmt.Errorf("%s: severity must be 'warning' or 'error', got '%s'", r.Id, r.Severity)
+	}
+
+	conditions := 0
+	for _, set := range []bool{r.Equals != nil, r.NotEquals != nil, r.In != nil, r.NotIn != nil, r.Exists != nil} {
+		if set {
+			conditions++
+		}
+	}
+
+	if conditions != 1 {
+		return fmt.Errorf("%s: exactly one of equals, notEquals, in, notIn or exists must be set", r.Id)
+	}
+
+	return nil
+}
+
+// severity returns the preflight severity of a violation of the rule
+func (r *policyRule) severity() PreflightCheckSeverity {
+	if strings.EqualFold(r.Severity, "error") {
+		return PreflightCheckError
+	}
+
+	return PreflightCheckWarning
+}
+
+// hasErrorSeverityRule reports whether any of the rules blocks the deployment when violated
+func hasErrorSeverityRule(rules []*policyRule) bool {
+	return slices.ContainsFunc(rules, func(rule *policyRule) bool { return rule.severity() == PreflightCheckError })
+}
+
+// appliesTo reports whether the rule applies to resources of the given type
+func (r *policyRule) appliesTo(resourceType string) bool {
+	return len(r.ResourceTypes) == 0 ||
+		slices.ContainsFunc(r.ResourceTypes, func(t string) bool { return strings.EqualFold(t, resourceType) })
+}
+
+// evaluate returns the resources violating the rule, described by their name and type. Resources whose field can't be
+// resolved before deployment, such as a value computed from a reference to another resource, are not reported.
+func (r *policyRule) evaluate(resources []map[string]any) []string {
+	var violations []string
+	for _, resource := range resources {
+		resourceType, _ := resource["type"].(string)
+		if !r.appliesTo(resourceType) {
+			continue
+		}
+
+		value, exists := fieldValue(resource, r.Field)
+		if exists && isArmExpression(value) {
+			continue
+		}
+
+		if !r.satisfied(value, exists) {
+			name, _ := resource["name"].(string)
+			violations = append(violations, fmt.Sprintf("%s (%s)", name, resourceType))
+		}
+	}
+
+	return violations
+}
+
+// satisfied reports whether the value of the field of a resource satisfies the condition of the rule
+func (r *policyRule) satisfied(value any, exists bool) bool {
+	switch {
+	case r.Exists != nil:
+		return exists == *r.Exists
+	case r.Equals != nil:
+		return exists && policyValueEquals(value, r.Equals)
+	case r.NotEquals != nil:
+		return !exists || !policyValueEquals(value, r.NotEquals)
+	case r.In != nil:
+		return exists && slices.ContainsFunc(r.In, func(allowed any) bool { return policyValueEquals(value, allowed) })
+	default:
+		return !exists || !slices.ContainsFunc(r.NotIn, func(denied any) bool { return policyValueEquals(value, denied) })
+	}
+}
+
+// evaluatePolicyRules evaluates the rules against the resources predicted by a Bicep snapshot, returning a result for each
+// violated rule
+func evaluatePolicyRules(rules []*policyRule, snapshotData []byte) ([]PreflightCheckResult, error) {
+	var snapshot struct {
+		PredictedResources []map[string]any `json:"predictedResources"`
+	}
+	if err := json.Unmarshal(snapshotData, &snapshot); err != nil {
+		return nil, fmt.Errorf("parsing bicep snapshot: %w", err)
+	}
+
+	var results []PreflightCheckResult
+	for _, rule := range rules {
+		if result := rule.result(rule.evaluate(snapshot.PredictedResources)); result != nil {
+			results = append(results, *result)
+		}
+	}
+
+	return results, nil
+}
+
+// result returns the preflight result reporting the given violations of the rule, or nil when there are none
+func (r *policyRule) result(violations []string) *PreflightCheckResult {
+	if len(violations) == 0 {
+		return nil
+	}
+
+	description := r.Description
+	if description == "" {
+		description = fmt.Sprintf("%s does not satisfy the policy", r.Field)
+	}
+
+	return &PreflightCheckResult{
+		Severity: r.severity(),
+		Message: fmt.Sprintf(
+			"policy %s: %s. Violated by: %s", r.Id, description, strings.Join(violations, ", ")),
+	}
+}
+
+// fieldValue returns the value of the field at the given dotted path of a resource
+func fieldValue(resource map[string]any, path string) (any, bool) {
+	var value any = resource
+	for segment := range strings.SplitSeq(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			// A parent computed at deployment time, such as tags from a parameter, leaves the field unresolved
+			if isArmExpression(value) {
+				return value, true
+			}
+			return nil, false
+		}
+
+		// Resource property names are case-insensitive
+		found := false
+		for key, child := range object {
+			if strings.EqualFold(key, segment) {
+				value = child
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, false
+		}
+	}
+
+	return value, value != nil
+}
+
+// isArmExpression reports whether the value is an ARM template expression left unresolved until deployment
+func isArmExpression(value any) bool {
+	expression, ok := value.(string)
+	return ok && strings.HasPrefix(expression, "[") && strings.HasSuffix(expression, "]") &&
+		!strings.HasPrefix(expression, "[[")
+}
+
+// policyValueEquals compares the value of a field with a value of a rule. Values read from YAML and JSON may have different
+// types, such as int and float64, so their text representations are compared. Strings are compared case-insensitively like
+// most Azure resource property values.
+func policyValueEquals(value any, expected any) bool {
+	return strings.EqualFold(fmt.Sprint(value), fmt.Sprint(expected))
+}
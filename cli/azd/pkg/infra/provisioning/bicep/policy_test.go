@@ -0,0 +1,205 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package bicep
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicyRules = `
+rules:
+  - id: storage-no-public-access
+    description: Storage accounts must disable public network access
+    severity: error
+    resourceTypes: [Microsoft.Storage/storageAccounts]
+    field: properties.publicNetworkAccess
+    equals: Disabled
+  - id: allowed-plan-skus
+    resourceTypes: [Microsoft.Web/serverFarms]
+    field: sku.name
+    in: [B1, P1v3]
+  - id: owner-tag
+    description: Resources must have an owner tag
+    field: tags.owner
+    exists: true
+`
+
+func writePolicyRules(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestLoadPolicyRules(t *testing.T) {
+	path := writePolicyRules(t, testPolicyRules)
+
+	rules, err := loadPolicyRules([]string{path}, nil)
+	require.NoError(t, err)
+	require.Len(t, rules, 3)
+	require.Equal(t, PreflightCheckError, rules[0].severity())
+	require.Equal(t, PreflightCheckWarning, rules[1].severity())
+
+	rules, err = loadPolicyRules([]string{path}, []string{"Owner-Tag"})
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	require.Equal(t, "allowed-plan-skus", rules[1].Id)
+}
+
+func TestLoadPolicyRulesInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "MissingId",
+			content: "rules:\n  - field: sku.name\n    equals: B1\n",
+			err:     "id is required",
+		},
+		{
+			name:    "MissingField",
+			content: "rules:\n  - id: sku\n    equals: B1\n",
+			err:     "field is required",
+		},
+		{
+			name:    "InvalidSeverity",
+			content: "rules:\n  - id: sku\n    severity: fatal\n    field: sku.name\n    equals: B1\n",
+			err:     "severity must be 'warning' or 'error'",
+		},
+		{
+			name:    "NoCondition",
+			content: "rules:\n  - id: sku\n    field: sku.name\n",
+			err:     "exactly one of",
+		},
+		{
+			name:    "SeveralConditions",
+			content: "rules:\n  - id: sku\n    field: sku.name\n    equals: B1\n    notEquals: F1\n",
+			err:     "exactly one of",
+		},
+		{
+			name: "DuplicateId",
+			content: "rules:\n  - id: sku\n    field: sku.name\n    equals: B1\n" +
+				"  - id: SKU\n    field: sku.tier\n    equals: Basic\n",
+			err: "already defined",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadPolicyRules([]string{writePolicyRules(t, tt.content)}, nil)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestPolicyRuleEvaluate(t *testing.T) {
+	rules, err := loadPolicyRules([]string{writePolicyRules(t, testPolicyRules)}, nil)
+	require.NoError(t, err)
+
+	resources := []map[string]any{
+		{
+			"type":       "Microsoft.Storage/storageAccounts",
+			"name":       "public",
+			"tags":       map[string]any{"owner": "team"},
+			"properties": map[string]any{"publicNetworkAccess": "Enabled"},
+		},
+		{
+			"type":       "Microsoft.Storage/storageAccounts",
+			"name":       "private",
+			"tags":       map[string]any{"Owner": "team"},
+			"properties": map[string]any{"publicNetworkAccess": "disabled"},
+		},
+		{
+			// Values resolved at deployment time can't be evaluated
+			"type":       "Microsoft.Storage/storageAccounts",
+			"name":       "computed",
+			"tags":       "[parameters('tags')]",
+			"properties": map[string]any{"publicNetworkAccess": "[parameters('publicNetworkAccess')]"},
+		},
+		{
+			"type": "Microsoft.Web/serverFarms",
+			"name": "plan",
+			"sku":  map[string]any{"name": "P2v3"},
+		},
+	}
+
+	require.Equal(t, []string{"public (Microsoft.Storage/storageAccounts)"}, rules[0].evaluate(resources))
+	require.Equal(t, []string{"plan (Microsoft.Web/serverFarms)"}, rules[1].evaluate(resources))
+	require.Equal(t, []string{"plan (Microsoft.Web/serverFarms)"}, rules[2].evaluate(resources))
+
+	result := rules[0].result(rules[0].evaluate(resources))
+	require.Equal(t, PreflightCheckError, result.Severity)
+	require.Equal(t,
+		"policy storage-no-public-access: Storage accounts must disable public network access. "+
+			"Violated by: public (Microsoft.Storage/storageAccounts)",
+		result.Message)
+
+	require.Nil(t, rules[0].result(nil))
+}
+
+func TestPolicyRuleConditions(t *testing.T) {
+	resource := map[string]any{
+		"type": "Microsoft.Web/sites",
+		"name": "app",
+		"properties": map[string]any{
+			"httpsOnly":   true,
+			"minReplicas": float64(2),
+		},
+	}
+
+	tests := []struct {
+		name      string
+		rule      *policyRule
+		satisfied bool
+	}{
+		{"EqualsBool", &policyRule{Field: "properties.httpsOnly", Equals: true}, true},
+		{"EqualsNumber", &policyRule{Field: "properties.minReplicas", Equals: 2}, true},
+		{"EqualsMissing", &policyRule{Field: "properties.clientCertEnabled", Equals: true}, false},
+		{"NotEquals", &policyRule{Field: "properties.httpsOnly", NotEquals: false}, true},
+		{"NotEqualsMissing", &policyRule{Field: "properties.clientCertEnabled", NotEquals: true}, true},
+		{"In", &policyRule{Field: "properties.minReplicas", In: []any{1, 2}}, true},
+		{"NotIn", &policyRule{Field: "properties.minReplicas", NotIn: []any{0, 1}}, true},
+		{"NotInMatch", &policyRule{Field: "properties.minReplicas", NotIn: []any{2}}, false},
+		{"Exists", &policyRule{Field: "properties.httpsOnly", Exists: new(true)}, true},
+		{"NotExists", &policyRule{Field: "properties.httpsOnly", Exists: new(false)}, false},
+		{"ResourceTypeMismatch", &policyRule{
+			ResourceTypes: []string{"Microsoft.Web/serverFarms"}, Field: "sku.name", Exists: new(true)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := tt.rule.evaluate([]map[string]any{resource})
+			require.Equal(t, tt.satisfied, len(violations) == 0)
+		})
+	}
+}
+
+func TestEvaluatePolicyRules(t *testing.T) {
+	rules, err := loadPolicyRules([]string{writePolicyRules(t, testPolicyRules)}, []string{"owner-tag"})
+	require.NoError(t, err)
+
+	snapshot, err := json.Marshal(map[string]any{
+		"predictedResources": []map[string]any{
+			{
+				"type":       "Microsoft.Storage/storageAccounts",
+				"name":       "storage",
+				"properties": map[string]any{"publicNetworkAccess": "Enabled"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	results, err := evaluatePolicyRules(rules, snapshot)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, PreflightCheckError, results[0].Severity)
+	require.Contains(t, results[0].Message, "storage (Microsoft.Storage/storageAccounts)")
+	require.True(t, hasErrorSeverityRule(rules))
+	require.False(t, hasErrorSeverityRule(rules[1:]))
+}
@@ -0,0 +1,210 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// CostEstimate is the estimated monthly cost of the resources of a provision preview, before and after the changes of the
+// preview are applied.
+type CostEstimate struct {
+	Currency string `json:"currency"`
+	// The estimated monthly cost of the priced resources as they are currently provisioned
+	CurrentMonthlyCost float64 `json:"currentMonthlyCost"`
+	// The estimated monthly cost of the priced resources once the changes are applied
+	MonthlyCost float64 `json:"monthlyCost"`
+	// The difference between the estimated monthly cost once the changes are applied and the current one
+	MonthlyCostDelta float64         `json:"monthlyCostDelta"`
+	Resources        []*ResourceCost `json:"resources"`
+}
+
+// ResourceCost is the estimated monthly cost of a single resource. The costs are nil when the resource doesn't exist, before
+// it is created or after it is deleted, or when its price is unknown.
+type ResourceCost struct {
+	ChangeType         ChangeType `json:"changeType"`
+	ResourceType       string     `json:"resourceType"`
+	Name               string     `json:"name"`
+	Sku                string     `json:"sku,omitempty"`
+	Location           string     `json:"location,omitempty"`
+	Capacity           float64    `json:"capacity,omitempty"`
+	CurrentMonthlyCost *float64   `json:"currentMonthlyCost,omitempty"`
+	MonthlyCost        *float64   `json:"monthlyCost,omitempty"`
+	// Priced is false when the price of the resource is unknown, its cost is then excluded from the estimate
+	Priced bool `json:"priced"`
+}
+
+// CostEstimator estimates the monthly cost of provisioned resources from their SKU, location and capacity
+type CostEstimator struct {
+	priceSource PriceSource
+}
+
+// NewCostEstimator creates a new CostEstimator looking up prices from the given price source
+func NewCostEstimator(priceSource PriceSource) *CostEstimator {
+	return &CostEstimator{
+		priceSource: priceSource,
+	}
+}
+
+// resourcePricing is the priced unit of a resource and its capacity
+type resourcePricing struct {
+	query    PriceQuery
+	capacity float64
+}
+
+// Estimate estimates the monthly cost of the resources of a provision preview, comparing the current state of every
+// resource with its state once the changes are applied.
+func (e *CostEstimator) Estimate(ctx context.Context, changes []*DeploymentPreviewChange) (*CostEstimate, error) {
+	estimate := &CostEstimate{
+		Currency:  defaultCurrency,
+		Resources: []*ResourceCost{},
+	}
+
+	prices := map[PriceQuery]*Price{}
+	lookup := func(pricing *resourcePricing) (*float64, error) {
+		price, has := prices[pricing.query]
+		if !has {
+			var err error
+			price, err = e.priceSource.Price(ctx, pricing.query)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"looking up the price of %s %s: %w", pricing.query.ResourceType, pricing.query.Sku, err)
+			}
+			prices[pricing.query] = price
+		}
+
+		if price == nil {
+			return nil, nil
+		}
+
+		if price.Currency != "" {
+			estimate.Currency = price.Currency
+		}
+
+		return new(price.MonthlyPrice * pricing.capacity), nil
+	}
+
+	for _, change := range changes {
+		before, after := changeStates(change)
+		if before == nil && after == nil {
+			continue
+		}
+
+		resourceCost := &ResourceCost{
+			ChangeType:   change.ChangeType,
+			ResourceType: change.ResourceType,
+			Name:         change.Name,
+			Priced:       true,
+		}
+
+		if before != nil {
+			cost, err := lookup(before)
+			if err != nil {
+				return nil, err
+			}
+
+			resourceCost.CurrentMonthlyCost = cost
+			resourceCost.Priced = resourceCost.Priced && cost != nil
+		}
+
+		if after != nil {
+			cost, err := lookup(after)
+			if err != nil {
+				return nil, err
+			}
+
+			resourceCost.MonthlyCost = cost
+			resourceCost.Priced = resourceCost.Priced && cost != nil
+		}
+
+		described := after
+		if described == nil {
+			described = before
+		}
+		resourceCost.Sku = described.query.Sku
+		resourceCost.Location = described.query.Location
+		resourceCost.Capacity = described.capacity
+
+		if resourceCost.Priced {
+			if resourceCost.CurrentMonthlyCost != nil {
+				estimate.CurrentMonthlyCost += *resourceCost.CurrentMonthlyCost
+			}
+			if resourceCost.MonthlyCost != nil {
+				estimate.MonthlyCost += *resourceCost.MonthlyCost
+			}
+		}
+
+		estimate.Resources = append(estimate.Resources, resourceCost)
+	}
+
+	estimate.MonthlyCostDelta = estimate.MonthlyCost - estimate.CurrentMonthlyCost
+
+	return estimate, nil
+}
+
+// changeStates returns the priced unit of a resource before and after the change is applied. The states are nil when the
+// resource doesn't exist or has no SKU.
+func changeStates(change *DeploymentPreviewChange) (before *resourcePricing, after *resourcePricing) {
+	switch change.ChangeType {
+	case ChangeTypeCreate:
+		return nil, newResourcePricing(change.After)
+	case ChangeTypeDelete:
+		return newResourcePricing(change.Before), nil
+	case ChangeTypeNoChange, ChangeTypeIgnore:
+		// The resource is left untouched, its cost doesn't change
+		state := change.Before
+		if state == nil {
+			state = change.After
+		}
+		pricing := newResourcePricing(state)
+		return pricing, pricing
+	default:
+		return newResourcePricing(change.Before), newResourcePricing(change.After)
+	}
+}
+
+// newResourcePricing reads the SKU, location and capacity of a resource from its state, as returned by what-if.
+// nil is returned for resources without a SKU.
+func newResourcePricing(state any) *resourcePricing {
+	resource, ok := state.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	resourceType, _ := resource["type"].(string)
+	location, _ := resource["location"].(string)
+	properties, _ := resource["properties"].(map[string]any)
+
+	pricing := &resourcePricing{
+		query: PriceQuery{
+			ResourceType: resourceType,
+			Location:     normalizeLocation(location),
+		},
+		capacity: 1,
+	}
+
+	// Most resources declare their SKU at the top level, some in their properties
+	sku, _ := resource["sku"].(map[string]any)
+	if sku == nil {
+		sku, _ = properties["sku"].(map[string]any)
+	}
+
+	if sku != nil {
+		pricing.query.Sku, _ = sku["name"].(string)
+		if capacity, ok := sku["capacity"].(float64); ok && capacity > 0 {
+			pricing.capacity = capacity
+		}
+	} else if strings.EqualFold(resourceType, "Microsoft.Compute/virtualMachines") {
+		hardwareProfile, _ := properties["hardwareProfile"].(map[string]any)
+		pricing.query.Sku, _ = hardwareProfile["vmSize"].(string)
+	}
+
+	if pricing.query.Sku == "" {
+		return nil
+	}
+
+	return pricing
+}
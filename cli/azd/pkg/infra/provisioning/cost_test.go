@@ -0,0 +1,265 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostEstimatorEstimate(t *testing.T) {
+	priceSheet := &PriceSheet{
+		Prices: []*PriceSheetItem{
+			{ResourceType: "Microsoft.Web/serverFarms", Sku: "P1v3", MonthlyPrice: 100},
+			{ResourceType: "Microsoft.Web/serverFarms", Sku: "P1v3", Location: "westus", MonthlyPrice: 110},
+			{ResourceType: "Microsoft.Cache/Redis", Sku: "Basic", MonthlyPrice: 16},
+			{ResourceType: "Microsoft.Cache/Redis", Sku: "Standard", MonthlyPrice: 40},
+		},
+	}
+
+	resource := func(resourceType string, sku map[string]any) map[string]any {
+		return map[string]any{
+			"type":     resourceType,
+			"location": "eastus",
+			"sku":      sku,
+		}
+	}
+
+	changes := []*DeploymentPreviewChange{
+		{
+			ChangeType:   ChangeTypeCreate,
+			ResourceType: "App Service plan",
+			Name:         "plan",
+			After:        resource("Microsoft.Web/serverFarms", map[string]any{"name": "P1v3", "capacity": float64(2)}),
+		},
+		{
+			ChangeType:   ChangeTypeModify,
+			ResourceType: "Cache for Redis",
+			Name:         "cache",
+			Before:       resource("Microsoft.Cache/Redis", map[string]any{"name": "Basic"}),
+			After:        resource("Microsoft.Cache/Redis", map[string]any{"name": "Standard"}),
+		},
+		{
+			ChangeType:   ChangeTypeDelete,
+			ResourceType: "Cache for Redis",
+			Name:         "old-cache",
+			Before:       resource("Microsoft.Cache/Redis", map[string]any{"name": "Basic"}),
+		},
+		{
+			ChangeType:   ChangeTypeCreate,
+			ResourceType: "Storage account",
+			Name:         "storage",
+			After:        resource("Microsoft.Storage/storageAccounts", map[string]any{"name": "Standard_LRS"}),
+		},
+		{
+			// Resources without a SKU aren't priced
+			ChangeType:   ChangeTypeCreate,
+			ResourceType: "Log Analytics workspace",
+			Name:         "logs",
+			After:        map[string]any{"type": "Microsoft.OperationalInsights/workspaces", "location": "eastus"},
+		},
+	}
+
+	estimate, err := NewCostEstimator(priceSheet).Estimate(context.Background(), changes)
+	require.NoError(t, err)
+
+	require.Equal(t, "USD", estimate.Currency)
+	require.Len(t, estimate.Resources, 4)
+
+	plan := estimate.Resources[0]
+	require.True(t, plan.Priced)
+	require.Equal(t, "P1v3", plan.Sku)
+	require.Equal(t, float64(2), plan.Capacity)
+	require.Nil(t, plan.CurrentMonthlyCost)
+	require.Equal(t, float64(200), *plan.MonthlyCost)
+
+	cache := estimate.Resources[1]
+	require.Equal(t, float64(16), *cache.CurrentMonthlyCost)
+	require.Equal(t, float64(40), *cache.MonthlyCost)
+
+	oldCache := estimate.Resources[2]
+	require.Equal(t, float64(16), *oldCache.CurrentMonthlyCost)
+	require.Nil(t, oldCache.MonthlyCost)
+
+	require.False(t, estimate.Resources[3].Priced)
+
+	require.Equal(t, float64(32), estimate.CurrentMonthlyCost)
+	require.Equal(t, float64(240), estimate.MonthlyCost)
+	require.Equal(t, float64(208), estimate.MonthlyCostDelta)
+}
+
+func TestPriceSheetFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"currency": "EUR",
+		"prices": [
+			{ "resourceType": "Microsoft.Web/serverFarms", "sku": "B1", "location": "westeurope", "monthlyPrice": 12.5 }
+		]
+	}`), 0600))
+
+	priceSource := NewPriceSheetFile(path)
+
+	price, err := priceSource.Price(context.Background(), PriceQuery{
+		ResourceType: "Microsoft.Web/serverfarms",
+		Sku:          "B1",
+		Location:     "West Europe",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &Price{MonthlyPrice: 12.5, Currency: "EUR"}, price)
+
+	price, err = priceSource.Price(context.Background(), PriceQuery{
+		ResourceType: "Microsoft.Web/serverFarms",
+		Sku:          "B1",
+		Location:     "eastus",
+	})
+	require.NoError(t, err)
+	require.Nil(t, price)
+
+	_, err = NewPriceSheetFile(filepath.Join(t.TempDir(), "missing.json")).Price(context.Background(), PriceQuery{})
+	require.ErrorContains(t, err, "reading price sheet")
+}
+
+func TestRetailPriceSource(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+
+	var filter string
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return strings.HasPrefix(request.URL.String(), "https://prices.azure.com/api/retail/prices")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		filter = request.URL.Query().Get("$filter")
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, map[string]any{
+			"Items": []map[string]any{
+				{"currencyCode": "USD", "retailPrice": 0.5, "unitOfMeasure": "1 GB/Month"},
+				{"currencyCode": "USD", "retailPrice": 0.1, "unitOfMeasure": "1 Hour"},
+			},
+		})
+	})
+
+	priceSource := NewRetailPriceSource(mockContext.HttpClient, "")
+
+	price, err := priceSource.Price(*mockContext.Context, PriceQuery{
+		ResourceType: "Microsoft.Web/serverFarms",
+		Sku:          "P1v3",
+		Location:     "eastus",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "USD", price.Currency)
+	require.InDelta(t, 73, price.MonthlyPrice, 0.001)
+	require.Contains(t, filter, "serviceName eq 'Azure App Service'")
+	require.Contains(t, filter, "armRegionName eq 'eastus'")
+
+	// Resource types without a retail service aren't looked up
+	price, err = priceSource.Price(*mockContext.Context, PriceQuery{
+		ResourceType: "Microsoft.Storage/storageAccounts",
+		Sku:          "Standard_LRS",
+		Location:     "eastus",
+	})
+	require.NoError(t, err)
+	require.Nil(t, price)
+}
+
+func TestRetailPriceSourcePages(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+
+	nextPageLink := "https://prices.azure.com/api/retail/prices?$filter=serviceName&$skip=100"
+	var pages []string
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return strings.HasPrefix(request.URL.String(), "https://prices.azure.com/api/retail/prices")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		pages = append(pages, request.URL.String())
+		if request.URL.Query().Get("$skip") == "" {
+			// The first page only has meters billed per use
+			return mocks.CreateHttpResponseWithBody(request, http.StatusOK, map[string]any{
+				"Items": []map[string]any{
+					{"currencyCode": "USD", "retailPrice": 0.5, "unitOfMeasure": "1 GB/Month"},
+				},
+				"NextPageLink": nextPageLink,
+			})
+		}
+
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, map[string]any{
+			"Items": []map[string]any{
+				{"currencyCode": "USD", "retailPrice": 0.1, "unitOfMeasure": "1 Hour"},
+			},
+		})
+	})
+
+	priceSource := NewRetailPriceSource(mockContext.HttpClient, "")
+
+	price, err := priceSource.Price(*mockContext.Context, PriceQuery{
+		ResourceType: "Microsoft.Web/serverFarms",
+		Sku:          "P1v3",
+		Location:     "eastus",
+	})
+	require.NoError(t, err)
+	require.InDelta(t, 73, price.MonthlyPrice, 0.001)
+	require.Len(t, pages, 2)
+	require.Equal(t, nextPageLink, pages[1])
+}
+
+func TestRetailPriceSourceVirtualMachines(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+
+	var filter string
+	mockContext.HttpClient.When(func(request *http.Request) bool {
+		return strings.HasPrefix(request.URL.String(), "https://prices.azure.com/api/retail/prices")
+	}).RespondFn(func(request *http.Request) (*http.Response, error) {
+		filter = request.URL.Query().Get("$filter")
+		return mocks.CreateHttpResponseWithBody(request, http.StatusOK, map[string]any{
+			"Items": []map[string]any{
+				{
+					"currencyCode": "USD", "retailPrice": 0.02, "unitOfMeasure": "1 Hour",
+					"skuName": "D2s v3 Spot", "productName": "Virtual Machines DSv3 Series",
+				},
+				{
+					"currencyCode": "USD", "retailPrice": 0.03, "unitOfMeasure": "1 Hour",
+					"skuName": "D2s v3 Low Priority", "productName": "Virtual Machines DSv3 Series",
+				},
+				{
+					"currencyCode": "USD", "retailPrice": 0.2, "unitOfMeasure": "1 Hour",
+					"skuName": "D2s v3", "productName": "Virtual Machines DSv3 Series Windows",
+				},
+				{
+					"currencyCode": "USD", "retailPrice": 0.1, "unitOfMeasure": "1 Hour",
+					"skuName": "D2s v3", "productName": "Virtual Machines DSv3 Series",
+				},
+			},
+		})
+	})
+
+	priceSource := NewRetailPriceSource(mockContext.HttpClient, "")
+
+	// Spot, Low Priority and Windows meters are skipped unless the SKU asks for them
+	price, err := priceSource.Price(*mockContext.Context, PriceQuery{
+		ResourceType: "Microsoft.Compute/virtualMachines",
+		Sku:          "Standard_D2s_v3",
+		Location:     "eastus",
+	})
+	require.NoError(t, err)
+	require.InDelta(t, 73, price.MonthlyPrice, 0.001)
+
+	price, err = priceSource.Price(*mockContext.Context, PriceQuery{
+		ResourceType: "Microsoft.Compute/virtualMachines",
+		Sku:          "D2s v3 Spot",
+		Location:     "eastus",
+	})
+	require.NoError(t, err)
+	require.InDelta(t, 14.6, price.MonthlyPrice, 0.001)
+
+	// Single quotes of the values are escaped in the filter
+	_, err = priceSource.Price(*mockContext.Context, PriceQuery{
+		ResourceType: "Microsoft.Compute/virtualMachines",
+		Sku:          "D2s' or skuName ne '",
+		Location:     "eastus",
+	})
+	require.NoError(t, err)
+	require.Contains(t, filter, "(armSkuName eq 'D2s'' or skuName ne ''' or skuName eq 'D2s'' or skuName ne ''')")
+}
@@ -296,6 +296,26 @@ func (m *Manager) Preview(ctx context.Context) (*DeployPreviewResult, error) {
 	return &filteredResult, nil
 }
 
+// CheckDrift reports the changes made to the provisioned resources outside of azd since they were last provisioned.
+func (m *Manager) CheckDrift(ctx context.Context) (*DriftResult, error) {
+	driftResult, err := m.provider.CheckDrift(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("checking infrastructure drift: %w", err)
+	}
+
+	// Unlike the preview, resources without a display name are kept since any drift must be reported
+	for _, change := range driftResult.Changes {
+		if mappingName := azapi.GetResourceTypeDisplayName(azapi.AzureResourceType(change.ResourceType)); mappingName != "" {
+			change.ResourceType = mappingName
+		}
+	}
+
+	// make sure any spinner is stopped
+	m.console.StopSpinner(ctx, "", input.StepDone)
+
+	return driftResult, nil
+}
+
 // Destroys the Azure infrastructure for the specified project
 func (m *Manager) Destroy(ctx context.Context, options DestroyOptions) (*DestroyResult, error) {
 	destroyResult, err := m.provider.Destroy(ctx, options)
@@ -34,6 +34,8 @@ type DestroyOptions struct {
 	force bool
 	// Whether or not to purge any key vaults associated with the deployment
 	purge bool
+	// The ids of the resources to delete. When empty, all the resources of the deployment are deleted.
+	resourceIds []string
 }
 
 type StateOptions struct {
@@ -59,6 +61,11 @@ func (o *DestroyOptions) Force() bool {
 	return o.force
 }
 
+// ResourceIds returns the ids of the resources to delete, or nil when all the resources of the deployment are deleted
+func (o *DestroyOptions) ResourceIds() []string {
+	return o.resourceIds
+}
+
 func NewDestroyOptions(force bool, purge bool) DestroyOptions {
 	return DestroyOptions{
 		force: force,
@@ -66,6 +73,16 @@ func NewDestroyOptions(force bool, purge bool) DestroyOptions {
 	}
 }
 
+// NewDestroyResourcesOptions creates the options to delete only the given resources of a deployment. The deployment
+// itself and the resource groups it created are kept.
+func NewDestroyResourcesOptions(force bool, purge bool, resourceIds []string) DestroyOptions {
+	return DestroyOptions{
+		force:       force,
+		purge:       purge,
+		resourceIds: resourceIds,
+	}
+}
+
 func NewActionOptions(formatter output.Formatter, interactive bool) ActionOptions {
 	return ActionOptions{
 		formatter:   formatter,
@@ -0,0 +1,319 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
+)
+
+// PriceSheetFileEnvVarName is the name of the environment variable with the path of an offline price sheet. When set, cost
+// estimates use the prices of the sheet instead of the Azure retail prices.
+const PriceSheetFileEnvVarName = "AZD_PRICE_SHEET_FILE"
+
+const (
+	defaultCurrency = "USD"
+	hoursPerMonth   = 730
+	daysPerMonth    = hoursPerMonth / 24.0
+)
+
+// PriceQuery identifies a priced unit of an Azure resource
+type PriceQuery struct {
+	// The Azure resource type, such as Microsoft.Web/serverFarms
+	ResourceType string
+	// The name of the SKU of the resource
+	Sku string
+	// The Azure location of the resource, such as eastus
+	Location string
+}
+
+// Price is the price of a single unit of an Azure resource
+type Price struct {
+	// The price of running one unit of the resource for a month
+	MonthlyPrice float64
+	Currency     string
+}
+
+// PriceSource looks up the prices of Azure resources
+type PriceSource interface {
+	// Price returns the price of a single unit of the resource, or nil when the price is unknown
+	Price(ctx context.Context, query PriceQuery) (*Price, error)
+}
+
+// NewPriceSource creates the price source used for cost estimates. The offline price sheet configured with
+// AZD_PRICE_SHEET_FILE is used when set, otherwise prices are looked up from the Azure retail prices.
+func NewPriceSource(transporter policy.Transporter) PriceSource {
+	if path := os.Getenv(PriceSheetFileEnvVarName); path != "" {
+		return NewPriceSheetFile(path)
+	}
+
+	return NewRetailPriceSource(transporter, "")
+}
+
+// PriceSheet is an offline list of prices, used in tests and environments without access to the Azure retail prices.
+type PriceSheet struct {
+	// The currency of all the prices of the sheet. (Default: USD)
+	Currency string            `json:"currency,omitempty"`
+	Prices   []*PriceSheetItem `json:"prices"`
+}
+
+// PriceSheetItem is the price of a resource type and SKU. Items without a location apply to every location.
+type PriceSheetItem struct {
+	ResourceType string  `json:"resourceType"`
+	Sku          string  `json:"sku"`
+	Location     string  `json:"location,omitempty"`
+	MonthlyPrice float64 `json:"monthlyPrice"`
+}
+
+// Price returns the price of the item matching the query, preferring the items specific to the location of the query
+func (s *PriceSheet) Price(ctx context.Context, query PriceQuery) (*Price, error) {
+	var match *PriceSheetItem
+	for _, item := range s.Prices {
+		if !strings.EqualFold(item.ResourceType, query.ResourceType) || !strings.EqualFold(item.Sku, query.Sku) {
+			continue
+		}
+
+		if normalizeLocation(item.Location) == normalizeLocation(query.Location) {
+			match = item
+			break
+		}
+
+		if item.Location == "" && match == nil {
+			match = item
+		}
+	}
+
+	if match == nil {
+		return nil, nil
+	}
+
+	currency := s.Currency
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	return &Price{
+		MonthlyPrice: match.MonthlyPrice,
+		Currency:     currency,
+	}, nil
+}
+
+// priceSheetFile is a price sheet loaded from a JSON file the first time a price is looked up
+type priceSheetFile struct {
+	path  string
+	sheet *PriceSheet
+	err   error
+	once  sync.Once
+}
+
+// NewPriceSheetFile creates a price source from the JSON price sheet at the given path
+func NewPriceSheetFile(path string) PriceSource {
+	return &priceSheetFile{
+		path: path,
+	}
+}
+
+func (f *priceSheetFile) Price(ctx context.Context, query PriceQuery) (*Price, error) {
+	f.once.Do(func() {
+		content, err := os.ReadFile(f.path)
+		if err != nil {
+			f.err = fmt.Errorf("reading price sheet: %w", err)
+			return
+		}
+
+		var sheet PriceSheet
+		if err := json.Unmarshal(content, &sheet); err != nil {
+			f.err = fmt.Errorf("parsing price sheet %s: %w", f.path, err)
+			return
+		}
+
+		f.sheet = &sheet
+	})
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	return f.sheet.Price(ctx, query)
+}
+
+// retailServiceNames maps the resource types that can be priced from the Azure retail prices to the name of their service
+var retailServiceNames = map[string]string{
+	"microsoft.apimanagement/service":           "API Management",
+	"microsoft.cache/redis":                     "Redis Cache",
+	"microsoft.compute/virtualmachines":         "Virtual Machines",
+	"microsoft.containerregistry/registries":    "Container Registry",
+	"microsoft.dbformysql/flexibleservers":      "Azure Database for MySQL",
+	"microsoft.dbforpostgresql/flexibleservers": "Azure Database for PostgreSQL",
+	"microsoft.eventhub/namespaces":             "Event Hubs",
+	"microsoft.search/searchservices":           "Azure Cognitive Search",
+	"microsoft.servicebus/namespaces":           "Service Bus",
+	"microsoft.signalrservice/signalr":          "SignalR",
+	"microsoft.web/serverfarms":                 "Azure App Service",
+}
+
+// defaultRetailPricesEndpoint is the public endpoint of the Azure retail prices, which requires no authentication
+const defaultRetailPricesEndpoint = "https://prices.azure.com/api/retail/prices"
+
+// retailPriceSource looks up the pay-as-you-go prices of Azure resources from the Azure retail prices
+type retailPriceSource struct {
+	transporter policy.Transporter
+	endpoint    string
+}
+
+// NewRetailPriceSource creates a price source for the Azure retail prices. The default endpoint is used when the given
+// endpoint is empty.
+func NewRetailPriceSource(transporter policy.Transporter, endpoint string) PriceSource {
+	if endpoint == "" {
+		endpoint = defaultRetailPricesEndpoint
+	}
+
+	return &retailPriceSource{
+		transporter: transporter,
+		endpoint:    endpoint,
+	}
+}
+
+// retailPricesResponse is the model type for a page of the Azure retail prices
+type retailPricesResponse struct {
+	Items []retailPriceItem `json:"Items"`
+	// NextPageLink is the URL of the next page of the prices, empty on the last page
+	NextPageLink string `json:"NextPageLink"`
+}
+
+// retailPriceItem is the model type for a single meter of the Azure retail prices
+type retailPriceItem struct {
+	CurrencyCode  string  `json:"currencyCode"`
+	RetailPrice   float64 `json:"retailPrice"`
+	UnitOfMeasure string  `json:"unitOfMeasure"`
+	SkuName       string  `json:"skuName"`
+	MeterName     string  `json:"meterName"`
+	ProductName   string  `json:"productName"`
+}
+
+// retailPriceVariants are the meters of a SKU billed at a different price than its regular pay-as-you-go meter. They're
+// only used when the SKU asks for them.
+var retailPriceVariants = []string{"spot", "low priority", "windows"}
+
+func (s *retailPriceSource) Price(ctx context.Context, query PriceQuery) (*Price, error) {
+	serviceName, has := retailServiceNames[strings.ToLower(query.ResourceType)]
+	if !has || query.Sku == "" || query.Location == "" {
+		return nil, nil
+	}
+
+	filter := fmt.Sprintf(
+		"serviceName eq '%s' and armRegionName eq '%s' and priceType eq 'Consumption' and "+
+			"(armSkuName eq '%s' or skuName eq '%s')",
+		odataString(serviceName),
+		odataString(normalizeLocation(query.Location)),
+		odataString(query.Sku),
+		odataString(query.Sku))
+
+	values := url.Values{}
+	values.Set("$filter", filter)
+
+	// The prices of a SKU can span multiple pages, like when some of its meters are billed per use
+	for pageUrl := s.endpoint + "?" + values.Encode(); pageUrl != ""; {
+		prices, err := s.page(ctx, pageUrl)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range prices.Items {
+			if isRetailPriceVariant(item, query.Sku) {
+				continue
+			}
+
+			// Meters billed per use, such as storage or operations, depend on the usage and can't be estimated
+			monthlyPrice, has := monthlyRetailPrice(item)
+			if !has {
+				continue
+			}
+
+			return &Price{
+				MonthlyPrice: monthlyPrice,
+				Currency:     item.CurrencyCode,
+			}, nil
+		}
+
+		pageUrl = prices.NextPageLink
+	}
+
+	log.Printf("no retail price found for %s %s in %s", query.ResourceType, query.Sku, query.Location)
+	return nil, nil
+}
+
+// page requests a page of the retail prices
+func (s *retailPriceSource) page(ctx context.Context, pageUrl string) (*retailPricesResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.transporter.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("requesting retail prices: %w", err)
+	}
+	defer res.Body.Close()
+
+	if !runtime.HasStatusCode(res, http.StatusOK) {
+		return nil, fmt.Errorf("requesting retail prices: unexpected status %d", res.StatusCode)
+	}
+
+	var prices retailPricesResponse
+	if err := json.NewDecoder(res.Body).Decode(&prices); err != nil {
+		return nil, fmt.Errorf("reading retail prices: %w", err)
+	}
+
+	return &prices, nil
+}
+
+// isRetailPriceVariant returns true when the meter is a variant of the SKU, like a Spot or Windows virtual machine, which
+// the SKU doesn't ask for
+func isRetailPriceVariant(item retailPriceItem, sku string) bool {
+	sku = strings.ToLower(sku)
+	meter := strings.ToLower(strings.Join([]string{item.SkuName, item.MeterName, item.ProductName}, " "))
+
+	for _, variant := range retailPriceVariants {
+		if strings.Contains(meter, variant) && !strings.Contains(sku, variant) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// odataString escapes the single quotes of a value used as a string literal of an OData filter
+func odataString(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
+// monthlyRetailPrice converts the price of a meter billed for the time a resource runs into a monthly price
+func monthlyRetailPrice(item retailPriceItem) (float64, bool) {
+	switch strings.ToLower(item.UnitOfMeasure) {
+	case "1 hour", "1/hour":
+		return item.RetailPrice * hoursPerMonth, true
+	case "1/day", "1 day":
+		return item.RetailPrice * daysPerMonth, true
+	case "1/month", "1 month":
+		return item.RetailPrice, true
+	default:
+		return 0, false
+	}
+}
+
+// normalizeLocation converts a location display name, such as East US, into its name
+func normalizeLocation(location string) string {
+	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
+}
@@ -24,6 +24,10 @@ const (
 	Test         ProviderKind = "test"
 )
 
+// TestProviderEnvVarName is the internal environment variable set by 'azd template test' for the azd processes it runs,
+// the test provider is only registered for these processes.
+const TestProviderEnvVarName = "AZD_TEMPLATE_TEST_PROVIDER"
+
 type Mode string
 
 const (
@@ -40,6 +44,8 @@ type Options struct {
 	Module           string         `yaml:"module,omitempty"`
 	Name             string         `yaml:"name,omitempty"`
 	DeploymentStacks map[string]any `yaml:"deploymentStacks,omitempty"`
+	// Policy rules evaluated against the resources before they are provisioned.
+	Policy PolicyOptions `yaml:"policy,omitempty"`
 	// Provisioning options for each individually defined layer.
 	Layers []Options `yaml:"layers,omitempty"`
 
@@ -51,6 +57,14 @@ type Options struct {
 	Mode Mode `yaml:"-"`
 }
 
+// PolicyOptions configures the policy rules evaluated against the resources before they are provisioned.
+type PolicyOptions struct {
+	// Paths of the policy rule files, relative to the project. (Default: policy.yaml in the infra folder, when present)
+	Rules []string `yaml:"rules,omitempty"`
+	// IDs of the policy rules that are not evaluated.
+	Suppress []string `yaml:"suppress,omitempty"`
+}
+
 // GetWithDefaults merges the provided infra options with the default provisioning options
 func (o Options) GetWithDefaults(other ...Options) (Options, error) {
 	mergedOptions := Options{}
@@ -121,7 +135,8 @@ func (o *Options) Validate() error {
 	}
 
 	anyIncompatibleFieldsSet := func() bool {
-		return o.Name != "" || o.Module != "" || o.Path != "" || o.DeploymentStacks != nil
+		return o.Name != "" || o.Module != "" || o.Path != "" || o.DeploymentStacks != nil ||
+			len(o.Policy.Rules) > 0 || len(o.Policy.Suppress) > 0
 	}
 
 	if len(o.Layers) > 0 && anyIncompatibleFieldsSet() {
@@ -160,9 +175,24 @@ type DeployPreviewResult struct {
 	Preview *DeploymentPreview
 }
 
+// DriftResult defines the changes made to the provisioned resources outside of azd, since the infrastructure was last
+// provisioned.
+type DriftResult struct {
+	// The resources that no longer match the infrastructure that was last provisioned. The change type describes the
+	// change made outside of azd, its Before state is the provisioned state and its After state the current one.
+	Changes []*DeploymentPreviewChange
+}
+
+// HasDrift returns true when any resource was changed outside of azd.
+func (r *DriftResult) HasDrift() bool {
+	return len(r.Changes) > 0
+}
+
 type DestroyResult struct {
 	// InvalidatedEnvKeys is a list of keys that should be removed from the environment after the destroy is complete.
 	InvalidatedEnvKeys []string
+	// DeletedResourceIds are the ids of the deleted resources when the destroy options select the resources to delete.
+	DeletedResourceIds []string
 }
 
 type StateResult struct {
@@ -185,6 +215,9 @@ type Provider interface {
 	State(ctx context.Context, options *StateOptions) (*StateResult, error)
 	Deploy(ctx context.Context) (*DeployResult, error)
 	Preview(ctx context.Context) (*DeployPreviewResult, error)
+	// CheckDrift compares the provisioned resources against the infrastructure that was last provisioned, reporting the
+	// changes made to them outside of azd.
+	CheckDrift(ctx context.Context) (*DriftResult, error)
 	Destroy(ctx context.Context, options DestroyOptions) (*DestroyResult, error)
 	EnsureEnv(ctx context.Context) error
 	Parameters(ctx context.Context) ([]Parameter, error)
@@ -54,8 +54,8 @@ func NewEnvRefreshResultFromState(state *State) contracts.EnvRefreshResult {
 // Defaults to `Bicep` if no provider is specified
 func ParseProvider(kind ProviderKind) (ProviderKind, error) {
 	switch kind {
-	// For the time being we need to include `Test` here for the unit tests to work as expected
-	// App builds will pass this test but fail resolving the provider since `Test` won't be registered in the container
+	// `Test` doesn't create any resource. It's used by unit tests and is only registered in the container for the
+	// azd processes run by 'azd template test', other app builds fail resolving it.
 	case NotSpecified, Bicep, Terraform, Test:
 		return kind, nil
 	}
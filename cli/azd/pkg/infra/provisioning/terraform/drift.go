@@ -0,0 +1,178 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package terraform
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/arm"
+	"github.com/azure/azure-dev/cli/azd/pkg/infra/provisioning"
+)
+
+// terraformPlanOutput is the model type for the JSON output of a saved plan.
+// see https://developer.hashicorp.com/terraform/internals/json-format#plan-representation for more information on the
+// shape of the JSON data.
+type terraformPlanOutput struct {
+	ResourceDrift []terraformResourceChange `json:"resource_drift"`
+}
+
+// terraformResourceChange is the model type for a change to a resource in a plan.
+type terraformResourceChange struct {
+	Address string          `json:"address"`
+	Mode    string          `json:"mode"`
+	Type    string          `json:"type"`
+	Name    string          `json:"name"`
+	Change  terraformChange `json:"change"`
+}
+
+// terraformChange is the model type for the actions and values of a change.
+type terraformChange struct {
+	Actions []string       `json:"actions"`
+	Before  map[string]any `json:"before"`
+	After   map[string]any `json:"after"`
+}
+
+// CheckDrift runs a refresh-only terraform plan, reporting the changes made to the resources tracked by the terraform
+// state outside of terraform. The state itself is left untouched.
+func (t *TerraformProvider) CheckDrift(ctx context.Context) (*provisioning.DriftResult, error) {
+	isRemoteBackendConfig, err := t.isRemoteBackendConfig()
+	if err != nil {
+		return nil, fmt.Errorf("reading backend config: %w", err)
+	}
+
+	modulePath := t.modulePath()
+
+	initRes, err := t.init(ctx, isRemoteBackendConfig)
+	if err != nil {
+		return nil, fmt.Errorf("terraform init failed: %s , err: %w", initRes, err)
+	}
+
+	err = t.createInputParametersFile(ctx, t.parametersTemplateFilePath(), t.parametersFilePath())
+	if err != nil {
+		return nil, fmt.Errorf("creating parameters file: %w", err)
+	}
+
+	driftPlanFilePath := t.driftPlanFilePath()
+	defer func() {
+		if err := os.Remove(driftPlanFilePath); err != nil && !os.IsNotExist(err) {
+			log.Printf("failed removing drift plan file %s: %v", driftPlanFilePath, err)
+		}
+	}()
+
+	planArgs := append(t.createPlanArgs(isRemoteBackendConfig), "-refresh-only")
+	runResult, err := t.cli.Plan(ctx, modulePath, driftPlanFilePath, planArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("terraform refresh-only plan failed:%s err %w", runResult, err)
+	}
+
+	showResult, err := t.cli.Show(ctx, modulePath, driftPlanFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("showing drift plan failed: %s, err:%w", showResult, err)
+	}
+
+	var planOutput terraformPlanOutput
+	if err := json.Unmarshal([]byte(showResult), &planOutput); err != nil {
+		return nil, fmt.Errorf("reading drift plan: %w", err)
+	}
+
+	return &provisioning.DriftResult{
+		Changes: driftChanges(planOutput.ResourceDrift),
+	}, nil
+}
+
+// Gets the path to the staging .azure drift plan file path
+func (t *TerraformProvider) driftPlanFilePath() string {
+	planFilename := fmt.Sprintf("%s.drift.tfplan", t.options.Module)
+	return filepath.Join(t.projectPath, ".azure", t.env.Name(), t.options.Path, planFilename)
+}
+
+// driftChanges converts the resource drift of a plan into the changes made outside of terraform
+func driftChanges(resourceDrift []terraformResourceChange) []*provisioning.DeploymentPreviewChange {
+	var changes []*provisioning.DeploymentPreviewChange
+	for _, drift := range resourceDrift {
+		if drift.Mode != terraformModeManaged {
+			continue
+		}
+
+		var changeType provisioning.ChangeType
+		switch {
+		case slices.Contains(drift.Change.Actions, "delete"):
+			changeType = provisioning.ChangeTypeDelete
+		case slices.Contains(drift.Change.Actions, "update"):
+			changeType = provisioning.ChangeTypeModify
+		default:
+			continue
+		}
+
+		change := &provisioning.DeploymentPreviewChange{
+			ChangeType:   changeType,
+			ResourceType: drift.Type,
+			Name:         drift.Address,
+			Before:       drift.Change.Before,
+			After:        drift.Change.After,
+			Delta:        propertyChanges(drift.Change.Before, drift.Change.After),
+		}
+
+		// Report azurerm resources with their Azure resource id and type
+		if resourceId, ok := drift.Change.Before["id"].(string); ok {
+			change.ResourceId = provisioning.Resource{Id: resourceId}
+			if parsedId, err := arm.ParseResourceID(resourceId); err == nil {
+				change.ResourceType = parsedId.ResourceType.String()
+				change.Name = parsedId.Name
+			}
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// propertyChanges compares the top level attributes of a resource before and after it changed
+func propertyChanges(before map[string]any, after map[string]any) []provisioning.DeploymentPreviewPropertyChange {
+	if after == nil {
+		return nil
+	}
+
+	paths := map[string]struct{}{}
+	for path := range before {
+		paths[path] = struct{}{}
+	}
+	for path := range after {
+		paths[path] = struct{}{}
+	}
+
+	var changes []provisioning.DeploymentPreviewPropertyChange
+	for _, path := range slices.Sorted(maps.Keys(paths)) {
+		beforeValue, inBefore := before[path]
+		afterValue, inAfter := after[path]
+		if reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+
+		changeType := provisioning.PropertyChangeTypeModify
+		if !inBefore || beforeValue == nil {
+			changeType = provisioning.PropertyChangeTypeCreate
+		} else if !inAfter || afterValue == nil {
+			changeType = provisioning.PropertyChangeTypeDelete
+		}
+
+		changes = append(changes, provisioning.DeploymentPreviewPropertyChange{
+			ChangeType: changeType,
+			Path:       path,
+			Before:     beforeValue,
+			After:      afterValue,
+		})
+	}
+
+	return changes
+}
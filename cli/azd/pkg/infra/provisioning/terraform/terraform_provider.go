@@ -240,6 +240,10 @@ func (t *TerraformProvider) Destroy(
 	ctx context.Context,
 	options provisioning.DestroyOptions,
 ) (*provisioning.DestroyResult, error) {
+	if len(options.ResourceIds()) > 0 {
+		return nil, fmt.Errorf("deleting individual resources is not supported for terraform")
+	}
+
 	isRemoteBackendConfig, err := t.isRemoteBackendConfig()
 	if err != nil {
 		return nil, fmt.Errorf("reading backend config: %w", err)
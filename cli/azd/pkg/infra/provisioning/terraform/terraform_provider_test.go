@@ -81,6 +81,73 @@ func TestTerraformDestroy(t *testing.T) {
 	require.Contains(t, destroyResult.InvalidatedEnvKeys, "RG_NAME")
 }
 
+func TestTerraformCheckDrift(t *testing.T) {
+	skipIfTerraformNotInstalled(t)
+	mockContext := mocks.NewMockContext(context.Background())
+	prepareGenericMocks(mockContext.CommandRunner)
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && strings.Contains(command, "init")
+	}).Respond(exec.RunResult{
+		Stdout: "Terraform has been successfully initialized!",
+	})
+
+	var planArgs []string
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && strings.Contains(command, "plan")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		planArgs = args.Args
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	//nolint:lll
+	showOutput := `{
+		"resource_drift": [
+			{
+				"address": "azurerm_resource_group.rg",
+				"mode": "managed",
+				"type": "azurerm_resource_group",
+				"name": "rg",
+				"change": {
+					"actions": ["update"],
+					"before": {"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-test-env", "location": "westus2", "tags": {"azd-env-name": "test-env"}},
+					"after": {"id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-test-env", "location": "westus2", "tags": {"azd-env-name": "test-env", "owner": "portal"}}
+				}
+			},
+			{
+				"address": "data.azurerm_client_config.current",
+				"mode": "data",
+				"type": "azurerm_client_config",
+				"name": "current",
+				"change": {"actions": ["update"], "before": {}, "after": {"object_id": "1"}}
+			}
+		]
+	}`
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return args.Cmd == "terraform" && strings.Contains(command, "show")
+	}).Respond(exec.RunResult{
+		Stdout: showOutput,
+	})
+
+	infraProvider := createTerraformProvider(t, mockContext)
+	driftResult, err := infraProvider.CheckDrift(*mockContext.Context)
+	require.NoError(t, err)
+
+	require.Contains(t, planArgs, "-refresh-only")
+	require.Contains(t, planArgs, fmt.Sprintf("-out=%s", infraProvider.driftPlanFilePath()))
+
+	require.True(t, driftResult.HasDrift())
+	require.Len(t, driftResult.Changes, 1)
+
+	change := driftResult.Changes[0]
+	require.Equal(t, provisioning.ChangeTypeModify, change.ChangeType)
+	require.Equal(t, "Microsoft.Resources/resourceGroups", change.ResourceType)
+	require.Equal(t, "rg-test-env", change.Name)
+	require.Len(t, change.Delta, 1)
+	require.Equal(t, "tags", change.Delta[0].Path)
+	require.Equal(t, provisioning.PropertyChangeTypeModify, change.Delta[0].ChangeType)
+}
+
 func TestTerraformState(t *testing.T) {
 	skipIfTerraformNotInstalled(t)
 	mockContext := mocks.NewMockContext(context.Background())
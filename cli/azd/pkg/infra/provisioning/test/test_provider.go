@@ -107,6 +107,11 @@ func (p *TestProvider) Preview(ctx context.Context) (*provisioning.DeployPreview
 	}, nil
 }
 
+// CheckDrift reports no drift since the test provider doesn't provision any resource
+func (p *TestProvider) CheckDrift(ctx context.Context) (*provisioning.DriftResult, error) {
+	return &provisioning.DriftResult{}, nil
+}
+
 func (p *TestProvider) Destroy(
 	ctx context.Context,
 	options provisioning.DestroyOptions,
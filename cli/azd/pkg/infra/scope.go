@@ -60,6 +60,8 @@ type Deployment interface {
 		template azure.RawArmTemplate,
 		parameters azure.ArmParameters,
 	) (*armresources.WhatIfOperationResult, error)
+	// ExportTemplate fetches the template of this deployment.
+	ExportTemplate(ctx context.Context) (azure.RawArmTemplate, error)
 	// Deployment fetches information about this deployment.
 	Get(ctx context.Context) (*azapi.ResourceDeployment, error)
 	// Operations returns all the operations for this deployment.
@@ -122,6 +124,11 @@ func (s *ResourceGroupDeployment) DeployPreview(
 		ctx, s.subscriptionId, s.resourceGroupName, s.name, template, parameters)
 }
 
+// ExportTemplate fetches the template of the deployment.
+func (s *ResourceGroupDeployment) ExportTemplate(ctx context.Context) (azure.RawArmTemplate, error) {
+	return s.deploymentService.ExportResourceGroupDeploymentTemplate(ctx, s.subscriptionId, s.resourceGroupName, s.name)
+}
+
 // GetDeployment fetches the result of the most recent deployment.
 func (s *ResourceGroupDeployment) Get(ctx context.Context) (*azapi.ResourceDeployment, error) {
 	return s.deploymentService.GetResourceGroupDeployment(ctx, s.subscriptionId, s.resourceGroupName, s.name)
@@ -333,6 +340,11 @@ func (s *SubscriptionDeployment) DeployPreview(
 		ctx, s.subscriptionId, s.location, s.name, template, parameters)
 }
 
+// ExportTemplate fetches the template of the deployment.
+func (s *SubscriptionDeployment) ExportTemplate(ctx context.Context) (azure.RawArmTemplate, error) {
+	return s.deploymentService.ExportSubscriptionDeploymentTemplate(ctx, s.subscriptionId, s.name)
+}
+
 // GetDeployment fetches the result of the most recent deployment.
 func (s *SubscriptionDeployment) Get(ctx context.Context) (*azapi.ResourceDeployment, error) {
 	return s.deploymentService.GetSubscriptionDeployment(ctx, s.subscriptionId, s.name)
@@ -0,0 +1,135 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ux
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/fatih/color"
+)
+
+// CostEstimate defines a ux item for displaying the estimated monthly cost of a provision preview.
+type CostEstimate struct {
+	Currency           string          `json:"currency"`
+	CurrentMonthlyCost float64         `json:"currentMonthlyCost"`
+	MonthlyCost        float64         `json:"monthlyCost"`
+	MonthlyCostDelta   float64         `json:"monthlyCostDelta"`
+	Resources          []*ResourceCost `json:"resources"`
+}
+
+// ResourceCost is the estimated monthly cost of a single resource of a provision preview.
+type ResourceCost struct {
+	Operation          OperationType `json:"operation"`
+	Type               string        `json:"type"`
+	Name               string        `json:"name"`
+	Sku                string        `json:"sku,omitempty"`
+	Location           string        `json:"location,omitempty"`
+	Capacity           float64       `json:"capacity,omitempty"`
+	CurrentMonthlyCost *float64      `json:"currentMonthlyCost,omitempty"`
+	MonthlyCost        *float64      `json:"monthlyCost,omitempty"`
+	Priced             bool          `json:"priced"`
+}
+
+func (ce *CostEstimate) ToString(currentIndentation string) string {
+	if len(ce.Resources) == 0 {
+		return ""
+	}
+
+	title := fmt.Sprintf("%sEstimated monthly cost (%s):", currentIndentation, ce.Currency)
+
+	descriptions := make([]string, len(ce.Resources))
+	var maxActionLen, maxTypeLen, maxDescriptionLen int
+	for index, resource := range ce.Resources {
+		descriptions[index] = resource.Name
+		if resource.Sku != "" {
+			descriptions[index] = fmt.Sprintf("%s (%s)", resource.Name, resource.sku())
+		}
+
+		maxActionLen = max(maxActionLen, len(resource.Operation.String()))
+		maxTypeLen = max(maxTypeLen, len(resource.Type))
+		maxDescriptionLen = max(maxDescriptionLen, len(descriptions[index]))
+	}
+
+	lines := []string{}
+	unpriced := 0
+	for index, resource := range ce.Resources {
+		action := resource.Operation.String()
+		cost := output.WithGrayFormat("not priced")
+		if resource.Priced {
+			cost = fmt.Sprintf("%s => %s", formatCost(resource.CurrentMonthlyCost), formatCost(resource.MonthlyCost))
+		} else {
+			unpriced++
+		}
+
+		lines = append(lines, fmt.Sprintf("%s%s %s : %s : %s",
+			currentIndentation,
+			colorType(resource.Operation)(action+strings.Repeat(" ", maxActionLen-len(action))+" :"),
+			resource.Type+strings.Repeat(" ", maxTypeLen-len(resource.Type)),
+			descriptions[index]+strings.Repeat(" ", maxDescriptionLen-len(descriptions[index])),
+			cost,
+		))
+	}
+
+	total := fmt.Sprintf("%sTotal: %s => %s %s (%s)",
+		currentIndentation,
+		formatCost(&ce.CurrentMonthlyCost),
+		formatCost(&ce.MonthlyCost),
+		ce.Currency,
+		formatCostDelta(ce.MonthlyCostDelta),
+	)
+
+	note := "Estimates use pay-as-you-go list prices and exclude usage-based charges."
+	if unpriced > 0 {
+		note += fmt.Sprintf(" %d resource(s) could not be priced.", unpriced)
+	}
+
+	return fmt.Sprintf("%s\n\n%s\n\n%s\n%s%s",
+		title, strings.Join(lines, "\n"), total, currentIndentation, output.WithGrayFormat(note))
+}
+
+// sku describes the SKU and the capacity of the resource
+func (rc *ResourceCost) sku() string {
+	if rc.Capacity > 1 {
+		return fmt.Sprintf("%s x%g", rc.Sku, rc.Capacity)
+	}
+
+	return rc.Sku
+}
+
+// formatCost formats a monthly cost, a nil cost is a resource that doesn't exist
+func formatCost(cost *float64) string {
+	if cost == nil {
+		return "0.00"
+	}
+
+	return fmt.Sprintf("%.2f", *cost)
+}
+
+// formatCostDelta formats the difference between two monthly costs, colored by whether the cost increases
+func formatCostDelta(delta float64) string {
+	switch {
+	case delta > 0:
+		return color.YellowString("+%.2f", delta)
+	case delta < 0:
+		return color.GreenString("%.2f", delta)
+	default:
+		return "no change"
+	}
+}
+
+func (ce *CostEstimate) MarshalJSON() ([]byte, error) {
+	// The alias type drops the MarshalJSON method, which would otherwise recurse
+	type costEstimate CostEstimate
+
+	return json.Marshal(contracts.EventEnvelope{
+		Type:      contracts.ConsoleMessageEventDataType,
+		Timestamp: time.Now(),
+		Data:      (*costEstimate)(ce),
+	})
+}
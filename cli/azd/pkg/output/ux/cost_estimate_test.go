@@ -0,0 +1,60 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ux
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/test/snapshot"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostEstimate(t *testing.T) {
+	ce := &CostEstimate{
+		Currency:           "USD",
+		CurrentMonthlyCost: 32,
+		MonthlyCost:        240,
+		MonthlyCostDelta:   208,
+		Resources: []*ResourceCost{
+			{
+				Operation:   OperationTypeCreate,
+				Type:        "App Service plan",
+				Name:        "plan",
+				Sku:         "P1v3",
+				Capacity:    2,
+				MonthlyCost: new(float64(200)),
+				Priced:      true,
+			},
+			{
+				Operation:          OperationTypeModify,
+				Type:               "Cache for Redis",
+				Name:               "cache",
+				Sku:                "Standard",
+				Capacity:           1,
+				CurrentMonthlyCost: new(float64(16)),
+				MonthlyCost:        new(float64(40)),
+				Priced:             true,
+			},
+			{
+				Operation: OperationTypeCreate,
+				Type:      "Storage account",
+				Name:      "storage",
+				Sku:       "Standard_LRS",
+				Capacity:  1,
+			},
+		},
+	}
+
+	output := ce.ToString("   ")
+	snapshot.SnapshotT(t, output)
+}
+
+func TestCostEstimateNoResources(t *testing.T) {
+	ce := &CostEstimate{
+		Currency:  "USD",
+		Resources: []*ResourceCost{},
+	}
+
+	require.Equal(t, "", ce.ToString("   "))
+}
@@ -0,0 +1,161 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ux
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/pkg/output"
+	"github.com/fatih/color"
+)
+
+// ShowStatus defines a ux item for displaying the live status of the services of a project, as refreshed by
+// `azd show --watch`.
+type ShowStatus struct {
+	Status *contracts.ShowStatusResult
+	// Interval is the time between two refreshes of the status
+	Interval time.Duration
+}
+
+func (s *ShowStatus) ToString(currentIndentation string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s%s (%s) as of %s, refreshing every %s. Press %s to stop.\n\n",
+		currentIndentation,
+		color.HiMagentaString(s.Status.Name),
+		s.Status.Environment,
+		s.Status.Timestamp.Local().Format(time.TimeOnly),
+		s.Interval,
+		output.WithHighLightFormat("Ctrl+C"),
+	))
+
+	if len(s.Status.Services) == 0 {
+		sb.WriteString(currentIndentation + output.WithGrayFormat("No services are defined in azure.yaml.") + "\n")
+		return sb.String()
+	}
+
+	rows := [][]string{{"Service", "Host", "Status", "Revision", "Replicas", "Last deployed", "Endpoint"}}
+	for _, service := range s.Status.Services {
+		rows = append(rows, []string{
+			service.Name,
+			service.Host,
+			string(service.Status),
+			valueOrDash(service.Revision),
+			replicas(service.ReadyReplicas, service.DesiredReplicas),
+			s.lastDeployed(service.LastDeployed),
+			endpoint(service.Endpoint, service.EndpointHealth),
+		})
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for column, value := range row {
+			widths[column] = max(widths[column], len(value))
+		}
+	}
+
+	for index, row := range rows {
+		cells := make([]string, len(row))
+		for column, value := range row {
+			// Pad before coloring so escape sequences don't break the alignment
+			cells[column] = value + strings.Repeat(" ", widths[column]-len(value))
+		}
+
+		if index == 0 {
+			header := strings.TrimRight(strings.Join(cells, "  "), " ")
+			sb.WriteString(currentIndentation + output.WithBold("%s", header) + "\n")
+			continue
+		}
+
+		service := s.Status.Services[index-1]
+		cells[2] = colorServiceState(service.Status)("%s", cells[2])
+		cells[6] = colorEndpointHealth(service.EndpointHealth)("%s", strings.TrimRight(cells[6], " "))
+
+		sb.WriteString(currentIndentation + strings.Join(cells, "  ") + "\n")
+		if service.Error != "" {
+			sb.WriteString(currentIndentation + output.WithGrayFormat("  %s", service.Error) + "\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// lastDeployed describes the time of the last deployment relative to the time of the status
+func (s *ShowStatus) lastDeployed(lastDeployed *time.Time) string {
+	if lastDeployed == nil {
+		return "-"
+	}
+
+	elapsed := s.Status.Timestamp.Sub(*lastDeployed)
+	if elapsed < time.Minute {
+		return "just now"
+	}
+
+	// Only keep the largest unit, like 2 hours instead of 2 hours 13 minutes 5 seconds
+	return strings.Join(strings.Fields(DurationAsText(elapsed))[:2], " ") + " ago"
+}
+
+// replicas describes the ready and the desired replica counts of a service, when known
+func replicas(ready *int, desired *int) string {
+	switch {
+	case ready != nil && desired != nil:
+		return fmt.Sprintf("%d/%d", *ready, *desired)
+	case ready != nil:
+		return fmt.Sprint(*ready)
+	default:
+		return "-"
+	}
+}
+
+// endpoint describes the endpoint of a service and its health, when known
+func endpoint(endpoint string, health contracts.ShowEndpointHealth) string {
+	if endpoint == "" {
+		return "-"
+	}
+
+	if health == contracts.ShowEndpointHealthUnknown {
+		return endpoint
+	}
+
+	return fmt.Sprintf("%s (%s)", endpoint, health)
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
+
+func colorServiceState(state contracts.ShowServiceState) func(format string, a ...any) string {
+	switch state {
+	case contracts.ShowServiceStateRunning:
+		return color.GreenString
+	case contracts.ShowServiceStateDeploying:
+		return color.YellowString
+	case contracts.ShowServiceStateFailed:
+		return color.RedString
+	default:
+		return color.HiBlackString
+	}
+}
+
+func colorEndpointHealth(health contracts.ShowEndpointHealth) func(format string, a ...any) string {
+	switch health {
+	case contracts.ShowEndpointHealthHealthy:
+		return color.GreenString
+	case contracts.ShowEndpointHealthUnhealthy:
+		return color.RedString
+	default:
+		return fmt.Sprintf
+	}
+}
+
+func (s *ShowStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Status)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package ux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/contracts"
+	"github.com/azure/azure-dev/cli/azd/test/snapshot"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowStatus(t *testing.T) {
+	timestamp := time.Date(2024, 1, 2, 15, 4, 5, 0, time.Local)
+
+	ss := &ShowStatus{
+		Interval: 10 * time.Second,
+		Status: &contracts.ShowStatusResult{
+			Name:        "todo",
+			Environment: "dev",
+			Timestamp:   timestamp,
+			Services: []contracts.ShowServiceStatus{
+				{
+					Name:           "api",
+					Host:           "containerapp",
+					Status:         contracts.ShowServiceStateRunning,
+					Revision:       "api--azd-2 80%, api--azd-1 20%",
+					ReadyReplicas:  new(3),
+					LastDeployed:   new(timestamp.Add(-2*time.Hour - 13*time.Minute)),
+					Endpoint:       "https://api.example.com/",
+					EndpointHealth: contracts.ShowEndpointHealthHealthy,
+				},
+				{
+					Name:            "worker",
+					Host:            "aks",
+					Status:          contracts.ShowServiceStateDeploying,
+					Revision:        "4",
+					ReadyReplicas:   new(1),
+					DesiredReplicas: new(2),
+					LastDeployed:    new(timestamp.Add(-20 * time.Second)),
+					EndpointHealth:  contracts.ShowEndpointHealthUnknown,
+				},
+				{
+					Name:           "web",
+					Host:           "staticwebapp",
+					Status:         contracts.ShowServiceStateUnknown,
+					EndpointHealth: contracts.ShowEndpointHealthUnknown,
+					Error:          "the service has not been deployed",
+				},
+			},
+		},
+	}
+
+	output := ss.ToString("  ")
+	snapshot.SnapshotT(t, output)
+}
+
+func TestShowStatusJson(t *testing.T) {
+	ss := &ShowStatus{
+		Status: &contracts.ShowStatusResult{
+			Name:        "todo",
+			Environment: "dev",
+			Timestamp:   time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+			Services: []contracts.ShowServiceStatus{
+				{
+					Name:           "api",
+					Host:           "appservice",
+					Status:         contracts.ShowServiceStateStopped,
+					ReadyReplicas:  new(0),
+					EndpointHealth: contracts.ShowEndpointHealthUnhealthy,
+				},
+			},
+		},
+	}
+
+	data, err := ss.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"name": "todo",
+		"environment": "dev",
+		"timestamp": "2024-01-02T15:04:05Z",
+		"services": [
+			{
+				"name": "api",
+				"host": "appservice",
+				"status": "stopped",
+				"readyReplicas": 0,
+				"endpointHealth": "unhealthy"
+			}
+		]
+	}`, string(data))
+}
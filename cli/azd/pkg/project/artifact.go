@@ -22,6 +22,10 @@ const (
 
 	// MetadataKeyNote adds a note line below the artifact output.
 	MetadataKeyNote = "note"
+
+	// MetadataKeyRevision records the host specific revision created by a deployment, like the container app revision
+	// name. It is set on the deploy result artifacts and captured in the deployment history.
+	MetadataKeyRevision = "revision"
 )
 
 // ArtifactKind represents well-known artifact types in the Azure Developer CLI
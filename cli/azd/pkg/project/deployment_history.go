@@ -0,0 +1,368 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+)
+
+const (
+	// DeploymentHistoryLimit is the number of deployments retained in the history of each service
+	DeploymentHistoryLimit = 10
+
+	deploymentHistoryVersion  = 1
+	deploymentHistoryDirName  = "deployments"
+	deploymentHistoryFileName = "history.json"
+	deploymentIdFormat        = "20060102-150405"
+)
+
+// DeploymentStatus is the outcome of a recorded deployment
+type DeploymentStatus string
+
+const (
+	// DeploymentStatusSucceeded is the status of deployments that completed and passed their health checks
+	DeploymentStatusSucceeded DeploymentStatus = "succeeded"
+	// DeploymentStatusUnhealthy is the status of deployments that completed but failed their health checks
+	DeploymentStatusUnhealthy DeploymentStatus = "unhealthy"
+)
+
+// DeploymentRecord is an entry in the deployment history of a service in an environment
+type DeploymentRecord struct {
+	// Id uniquely identifies the deployment within the history of the service
+	Id        string            `json:"id"`
+	Service   string            `json:"service"`
+	Host      ServiceTargetKind `json:"host"`
+	Timestamp time.Time         `json:"timestamp"`
+	// Image is the tag or digest reference of the deployed container image
+	Image string `json:"image,omitempty"`
+	// Package is the path of the retained copy of the deployed zip package
+	Package string `json:"package,omitempty"`
+	// Revision is the host specific revision created by the deployment, like the container app revision name or the pod
+	// template hash of the k8s deployment rollout
+	Revision string `json:"revision,omitempty"`
+	// GitCommit is the commit checked out in the project directory at the time of the deployment
+	GitCommit string `json:"gitCommit,omitempty"`
+	// RollbackOf is the id of the restored deployment when the deployment is a rollback
+	RollbackOf string `json:"rollbackOf,omitempty"`
+	// Status is the outcome of the deployment. Deployments recorded before statuses were tracked have no status.
+	Status DeploymentStatus `json:"status,omitempty"`
+}
+
+// Restorable returns true when the service can be rolled back to the deployment, which excludes deployments that are
+// known to be bad.
+func (r *DeploymentRecord) Restorable() bool {
+	return r.Status != DeploymentStatusUnhealthy
+}
+
+type deploymentHistoryFile struct {
+	Version     int                 `json:"version"`
+	Deployments []*DeploymentRecord `json:"deployments"`
+}
+
+// DeploymentHistoryManager records the deployments of services per environment, so that a service can be rolled back
+// to a previous deployment.
+type DeploymentHistoryManager struct {
+	azdCtx *azdcontext.AzdContext
+	gitCli *git.Cli
+}
+
+// NewDeploymentHistoryManager creates a new DeploymentHistoryManager
+func NewDeploymentHistoryManager(azdCtx *azdcontext.AzdContext, gitCli *git.Cli) *DeploymentHistoryManager {
+	return &DeploymentHistoryManager{
+		azdCtx: azdCtx,
+		gitCli: gitCli,
+	}
+}
+
+// List returns the deployments recorded in the environment, newest first.
+// When serviceName is set, only the deployments of the service are returned.
+func (m *DeploymentHistoryManager) List(
+	ctx context.Context,
+	envName string,
+	serviceName string,
+) ([]*DeploymentRecord, error) {
+	history, err := m.load(envName)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []*DeploymentRecord{}
+	for _, record := range slices.Backward(history.Deployments) {
+		if serviceName == "" || record.Service == serviceName {
+			records = append(records, record)
+		}
+	}
+
+	return records, nil
+}
+
+// Record adds the deployment of the service to the history of the environment.
+// Zip packages are copied into the environment directory since they are usually removed after the deployment.
+func (m *DeploymentHistoryManager) Record(
+	ctx context.Context,
+	envName string,
+	serviceConfig *ServiceConfig,
+	serviceContext *ServiceContext,
+	deployResult *ServiceDeployResult,
+) (*DeploymentRecord, error) {
+	record := m.newRecord(ctx, serviceConfig, deployResult)
+
+	if artifact, found := serviceContext.Publish.FindFirst(WithKind(ArtifactKindContainer)); found {
+		record.Image = artifact.Location
+	} else if artifact, found := serviceContext.Package.FindFirst(WithKind(ArtifactKindContainer)); found {
+		record.Image = artifact.Location
+	}
+
+	return record, m.add(envName, record, serviceContext)
+}
+
+// RecordRollback adds the rollback of the service to the restored deployment to the history of the environment.
+func (m *DeploymentHistoryManager) RecordRollback(
+	ctx context.Context,
+	envName string,
+	serviceConfig *ServiceConfig,
+	restored *DeploymentRecord,
+	deployResult *ServiceDeployResult,
+) (*DeploymentRecord, error) {
+	record := m.newRecord(ctx, serviceConfig, deployResult)
+	record.Image = restored.Image
+	record.Package = restored.Package
+	record.RollbackOf = restored.Id
+
+	// The restored deployment describes what is running again, the current commit is unrelated to it
+	record.GitCommit = restored.GitCommit
+	if record.Revision == "" {
+		record.Revision = restored.Revision
+	}
+
+	return record, m.add(envName, record, nil)
+}
+
+// SetStatus updates the status of a recorded deployment, such as when the deployed service fails its health checks.
+func (m *DeploymentHistoryManager) SetStatus(
+	ctx context.Context,
+	envName string,
+	record *DeploymentRecord,
+	status DeploymentStatus,
+) error {
+	history, err := m.load(envName)
+	if err != nil {
+		return err
+	}
+
+	index := slices.IndexFunc(history.Deployments, func(existing *DeploymentRecord) bool {
+		return existing.Service == record.Service && existing.Id == record.Id
+	})
+	if index < 0 {
+		return fmt.Errorf("deployment '%s' of service '%s' was not found", record.Id, record.Service)
+	}
+
+	history.Deployments[index].Status = status
+	record.Status = status
+
+	return m.save(envName, history)
+}
+
+func (m *DeploymentHistoryManager) newRecord(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	deployResult *ServiceDeployResult,
+) *DeploymentRecord {
+	record := &DeploymentRecord{
+		Service:   serviceConfig.Name,
+		Host:      serviceConfig.Host,
+		Timestamp: time.Now().UTC(),
+		Status:    DeploymentStatusSucceeded,
+	}
+
+	if deployResult != nil {
+		for _, artifact := range deployResult.Artifacts {
+			if revision := artifact.Metadata[MetadataKeyRevision]; revision != "" {
+				record.Revision = revision
+				break
+			}
+		}
+	}
+
+	commit, err := m.gitCli.GetCurrentCommit(ctx, m.azdCtx.ProjectDirectory())
+	if err != nil {
+		log.Printf("not recording the git commit of the deployment: %v", err)
+	} else {
+		record.GitCommit = commit
+	}
+
+	return record
+}
+
+// add persists the record, retaining the zip package of the service context when set, and prunes the deployments of
+// the service that exceed DeploymentHistoryLimit.
+func (m *DeploymentHistoryManager) add(envName string, record *DeploymentRecord, serviceContext *ServiceContext) error {
+	history, err := m.load(envName)
+	if err != nil {
+		return err
+	}
+
+	record.Id = record.Timestamp.Format(deploymentIdFormat)
+	for suffix := 2; slices.ContainsFunc(history.Deployments, func(existing *DeploymentRecord) bool {
+		return existing.Service == record.Service && existing.Id == record.Id
+	}); suffix++ {
+		record.Id = fmt.Sprintf("%s-%d", record.Timestamp.Format(deploymentIdFormat), suffix)
+	}
+
+	if serviceContext != nil {
+		if artifact, found := serviceContext.Package.FindFirst(WithKind(ArtifactKindArchive)); found {
+			packagePath, err := m.retainPackage(envName, record, artifact.Location)
+			if err != nil {
+				return err
+			}
+
+			record.Package = packagePath
+		}
+	}
+
+	history.Deployments = append(history.Deployments, record)
+
+	// Prune the oldest deployments of the service, keeping packages still referenced by rollbacks
+	var pruned []*DeploymentRecord
+	count := 0
+	for _, existing := range slices.Backward(history.Deployments) {
+		if existing.Service != record.Service {
+			continue
+		}
+
+		count++
+		if count > DeploymentHistoryLimit {
+			pruned = append(pruned, existing)
+		}
+	}
+
+	history.Deployments = slices.DeleteFunc(history.Deployments, func(existing *DeploymentRecord) bool {
+		return slices.Contains(pruned, existing)
+	})
+
+	for _, prunedRecord := range pruned {
+		if prunedRecord.Package == "" || slices.ContainsFunc(history.Deployments, func(kept *DeploymentRecord) bool {
+			return kept.Package == prunedRecord.Package
+		}) {
+			continue
+		}
+
+		if err := os.Remove(prunedRecord.Package); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("failed removing package of deployment '%s': %v", prunedRecord.Id, err)
+		}
+	}
+
+	return m.save(envName, history)
+}
+
+// retainPackage copies the zip package into the deployment history directory of the environment.
+func (m *DeploymentHistoryManager) retainPackage(envName string, record *DeploymentRecord, source string) (string, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return "", fmt.Errorf("reading deployment package: %w", err)
+	}
+
+	// Directories are deployed as-is by some hosts, only zip packages can be redeployed later
+	if info.IsDir() {
+		return "", nil
+	}
+
+	target := filepath.Join(m.historyDir(envName), record.Service, record.Id+filepath.Ext(source))
+	if err := os.MkdirAll(filepath.Dir(target), osutil.PermissionDirectory); err != nil {
+		return "", fmt.Errorf("creating deployment history directory: %w", err)
+	}
+
+	sourceFile, err := os.Open(source)
+	if err != nil {
+		return "", fmt.Errorf("reading deployment package: %w", err)
+	}
+	defer sourceFile.Close()
+
+	targetFile, err := os.Create(target)
+	if err != nil {
+		return "", fmt.Errorf("retaining deployment package: %w", err)
+	}
+	defer targetFile.Close()
+
+	if _, err := io.Copy(targetFile, sourceFile); err != nil {
+		return "", fmt.Errorf("retaining deployment package: %w", err)
+	}
+
+	return target, nil
+}
+
+func (m *DeploymentHistoryManager) historyDir(envName string) string {
+	return filepath.Join(m.azdCtx.EnvironmentRoot(envName), deploymentHistoryDirName)
+}
+
+func (m *DeploymentHistoryManager) load(envName string) (*deploymentHistoryFile, error) {
+	historyDir := m.historyDir(envName)
+	history := &deploymentHistoryFile{}
+
+	data, err := os.ReadFile(filepath.Join(historyDir, deploymentHistoryFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return history, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading deployment history: %w", err)
+	}
+
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("parsing deployment history: %w", err)
+	}
+
+	// Packages are stored relative to the history directory so the project directory can be moved
+	for _, record := range history.Deployments {
+		if record.Package != "" && !filepath.IsAbs(record.Package) {
+			record.Package = filepath.Join(historyDir, filepath.FromSlash(record.Package))
+		}
+	}
+
+	return history, nil
+}
+
+func (m *DeploymentHistoryManager) save(envName string, history *deploymentHistoryFile) error {
+	historyDir := m.historyDir(envName)
+	if err := os.MkdirAll(historyDir, osutil.PermissionDirectory); err != nil {
+		return fmt.Errorf("creating deployment history directory: %w", err)
+	}
+
+	stored := &deploymentHistoryFile{
+		Version:     deploymentHistoryVersion,
+		Deployments: make([]*DeploymentRecord, 0, len(history.Deployments)),
+	}
+
+	for _, record := range history.Deployments {
+		storedRecord := *record
+		if relativePath, err := filepath.Rel(historyDir, record.Package); record.Package != "" && err == nil {
+			storedRecord.Package = filepath.ToSlash(relativePath)
+		}
+
+		stored.Deployments = append(stored.Deployments, &storedRecord)
+	}
+
+	data, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return fmt.Errorf("serializing deployment history: %w", err)
+	}
+
+	if err := os.WriteFile(
+		filepath.Join(historyDir, deploymentHistoryFileName), data, osutil.PermissionFile); err != nil {
+		return fmt.Errorf("writing deployment history: %w", err)
+	}
+
+	return nil
+}
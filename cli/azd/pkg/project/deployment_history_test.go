@@ -0,0 +1,173 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment/azdcontext"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/tools/git"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DeploymentHistory_Record(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "git -C") && strings.Contains(command, "rev-parse HEAD")
+	}).Respond(exec.NewRunResult(0, "0123456789abcdef\n", ""))
+
+	azdCtx := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	manager := NewDeploymentHistoryManager(azdCtx, git.NewCli(mockContext.CommandRunner))
+
+	zipPath := filepath.Join(t.TempDir(), "api.zip")
+	require.NoError(t, os.WriteFile(zipPath, []byte("package"), osutil.PermissionFile))
+
+	apiConfig := &ServiceConfig{Name: "api", Host: AppServiceTarget}
+	webConfig := &ServiceConfig{Name: "web", Host: ContainerAppTarget}
+
+	apiContext := NewServiceContext()
+	require.NoError(t, apiContext.Package.Add(&Artifact{
+		Kind:         ArtifactKindArchive,
+		Location:     zipPath,
+		LocationKind: LocationKindLocal,
+	}))
+
+	webContext := NewServiceContext()
+	require.NoError(t, webContext.Publish.Add(&Artifact{
+		Kind:         ArtifactKindContainer,
+		Location:     "contoso.azurecr.io/web:azd-deploy-1",
+		LocationKind: LocationKindRemote,
+	}))
+
+	webResult := &ServiceDeployResult{
+		Artifacts: ArtifactCollection{
+			{
+				Kind:         ArtifactKindResource,
+				Location:     "/subscriptions/SUB/resourceGroups/RG/providers/Microsoft.App/containerApps/web",
+				LocationKind: LocationKindRemote,
+				Metadata:     map[string]string{MetadataKeyRevision: "web--azd-1"},
+			},
+		},
+	}
+
+	first, err := manager.Record(*mockContext.Context, "dev", apiConfig, apiContext, &ServiceDeployResult{})
+	require.NoError(t, err)
+	second, err := manager.Record(*mockContext.Context, "dev", apiConfig, apiContext, &ServiceDeployResult{})
+	require.NoError(t, err)
+	web, err := manager.Record(*mockContext.Context, "dev", webConfig, webContext, webResult)
+	require.NoError(t, err)
+
+	require.NotEqual(t, first.Id, second.Id)
+	require.Equal(t, "0123456789abcdef", first.GitCommit)
+	require.Equal(t, "contoso.azurecr.io/web:azd-deploy-1", web.Image)
+	require.Equal(t, "web--azd-1", web.Revision)
+
+	// The package is retained even though the original is removed after the deployment
+	require.NoError(t, os.Remove(zipPath))
+	contents, err := os.ReadFile(second.Package)
+	require.NoError(t, err)
+	require.Equal(t, "package", string(contents))
+
+	records, err := manager.List(*mockContext.Context, "dev", "")
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, web.Id, records[0].Id)
+
+	records, err = manager.List(*mockContext.Context, "dev", "api")
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, second.Id, records[0].Id)
+	require.Equal(t, first.Id, records[1].Id)
+	require.Equal(t, second.Package, records[0].Package)
+
+	rollback, err := manager.RecordRollback(*mockContext.Context, "dev", apiConfig, records[1], &ServiceDeployResult{})
+	require.NoError(t, err)
+	require.Equal(t, first.Id, rollback.RollbackOf)
+	require.Equal(t, first.Package, rollback.Package)
+
+	records, err = manager.List(*mockContext.Context, "dev", "api")
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	require.Equal(t, rollback.Id, records[0].Id)
+}
+
+func Test_DeploymentHistory_Prune(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "rev-parse HEAD")
+	}).Respond(exec.NewRunResult(128, "", "fatal: not a git repository (or any of the parent directories): .git"))
+
+	azdCtx := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	manager := NewDeploymentHistoryManager(azdCtx, git.NewCli(mockContext.CommandRunner))
+
+	zipPath := filepath.Join(t.TempDir(), "api.zip")
+	require.NoError(t, os.WriteFile(zipPath, []byte("package"), osutil.PermissionFile))
+
+	serviceConfig := &ServiceConfig{Name: "api", Host: AppServiceTarget}
+	serviceContext := NewServiceContext()
+	require.NoError(t, serviceContext.Package.Add(&Artifact{
+		Kind:         ArtifactKindArchive,
+		Location:     zipPath,
+		LocationKind: LocationKindLocal,
+	}))
+
+	var last *DeploymentRecord
+	for range DeploymentHistoryLimit + 2 {
+		record, err := manager.Record(*mockContext.Context, "dev", serviceConfig, serviceContext, &ServiceDeployResult{})
+		require.NoError(t, err)
+		require.Empty(t, record.GitCommit)
+		last = record
+	}
+
+	records, err := manager.List(*mockContext.Context, "dev", "api")
+	require.NoError(t, err)
+	require.Len(t, records, DeploymentHistoryLimit)
+	require.Equal(t, last.Id, records[0].Id)
+
+	// Packages of pruned deployments are removed
+	packages, err := os.ReadDir(filepath.Dir(records[0].Package))
+	require.NoError(t, err)
+	require.Len(t, packages, DeploymentHistoryLimit)
+	for _, record := range records {
+		require.FileExists(t, record.Package)
+	}
+}
+
+func Test_DeploymentHistory_SetStatus(t *testing.T) {
+	mockContext := mocks.NewMockContext(context.Background())
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "rev-parse HEAD")
+	}).Respond(exec.NewRunResult(0, "0123456789abcdef\n", ""))
+	azdCtx := azdcontext.NewAzdContextWithDirectory(t.TempDir())
+	manager := NewDeploymentHistoryManager(azdCtx, git.NewCli(mockContext.CommandRunner))
+
+	apiConfig := &ServiceConfig{Name: "api", Host: ContainerAppTarget}
+	record, err := manager.Record(*mockContext.Context, "dev", apiConfig, NewServiceContext(), &ServiceDeployResult{})
+	require.NoError(t, err)
+	require.Equal(t, DeploymentStatusSucceeded, record.Status)
+	require.True(t, record.Restorable())
+
+	require.NoError(t, manager.SetStatus(*mockContext.Context, "dev", record, DeploymentStatusUnhealthy))
+	require.False(t, record.Restorable())
+
+	records, err := manager.List(*mockContext.Context, "dev", "api")
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, DeploymentStatusUnhealthy, records[0].Status)
+	require.False(t, records[0].Restorable())
+
+	// Deployments recorded before statuses were tracked can be restored
+	require.True(t, (&DeploymentRecord{}).Restorable())
+
+	err = manager.SetStatus(*mockContext.Context, "dev", &DeploymentRecord{Service: "api", Id: "missing"},
+		DeploymentStatusUnhealthy)
+	require.Error(t, err)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+)
+
+// DeploymentStrategyKind is the way the new version of a service is rolled out by 'azd deploy'
+type DeploymentStrategyKind string
+
+const (
+	// DeploymentStrategyBlueGreen deploys the new version next to the current version and switches all traffic to it
+	// once it is healthy
+	DeploymentStrategyBlueGreen DeploymentStrategyKind = "blueGreen"
+	// DeploymentStrategyCanary shifts the traffic to the new version in steps, verifying its health at every step
+	DeploymentStrategyCanary DeploymentStrategyKind = "canary"
+)
+
+// defaultDeploymentSlot is the App Service deployment slot receiving the new version when not configured
+const defaultDeploymentSlot = "staging"
+
+// ErrRolloutAborted is returned when a progressive rollout was rolled back because the new version was unhealthy
+var ErrRolloutAborted = errors.New("rollout aborted")
+
+// DeploymentOptions configures how 'azd deploy' rolls out a service
+type DeploymentOptions struct {
+	// The optional progressive delivery strategy. When not set, the new version receives all traffic immediately.
+	Strategy *DeploymentStrategy `yaml:"strategy,omitempty"`
+}
+
+// DeploymentStrategy configures the progressive rollout of a new version of a service
+type DeploymentStrategy struct {
+	// The kind of rollout, blueGreen or canary
+	Type DeploymentStrategyKind `yaml:"type"`
+	// The traffic steps of a canary rollout
+	Steps []DeploymentStep `yaml:"steps,omitempty"`
+	// The health check gating each step of the rollout, requested on the endpoint of the new version. When the health
+	// check fails, the rollout is rolled back.
+	HealthCheck *HealthCheck `yaml:"healthCheck,omitempty"`
+	// The App Service deployment slot receiving the new version. (Default: staging)
+	Slot string `yaml:"slot,omitempty"`
+}
+
+// DeploymentStep is a step of a canary rollout
+type DeploymentStep struct {
+	// The percentage of the traffic routed to the new version
+	Weight int `yaml:"weight"`
+	// The time to wait after routing the traffic, before the health of the new version is verified again
+	Wait time.Duration `yaml:"wait,omitempty"`
+}
+
+// Validate verifies the strategy is well formed
+func (s *DeploymentStrategy) Validate() error {
+	switch s.Type {
+	case DeploymentStrategyBlueGreen:
+		if len(s.Steps) > 0 {
+			return fmt.Errorf("steps are only supported by the '%s' deployment strategy", DeploymentStrategyCanary)
+		}
+	case DeploymentStrategyCanary:
+		if len(s.Steps) == 0 {
+			return fmt.Errorf("the '%s' deployment strategy requires at least one step", DeploymentStrategyCanary)
+		}
+
+		previous := 0
+		for _, step := range s.Steps {
+			if step.Weight <= previous || step.Weight > 100 {
+				return fmt.Errorf(
+					"invalid step weight %d, weights must increase between 1 and 100 percent", step.Weight)
+			}
+
+			if step.Wait < 0 {
+				return fmt.Errorf("invalid step wait %s", step.Wait)
+			}
+
+			previous = step.Weight
+		}
+	default:
+		return fmt.Errorf(
+			"unsupported deployment strategy '%s', supported strategies are '%s' and '%s'",
+			s.Type, DeploymentStrategyBlueGreen, DeploymentStrategyCanary)
+	}
+
+	if s.HealthCheck != nil {
+		if s.HealthCheck.Path == "" {
+			return errors.New("the health check of the deployment strategy requires a path")
+		}
+
+		if s.HealthCheck.Command != nil {
+			return errors.New("the health check of the deployment strategy doesn't support a command")
+		}
+
+		if err := s.HealthCheck.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// slot returns the App Service deployment slot receiving the new version
+func (s *DeploymentStrategy) slot() string {
+	if s.Slot == "" {
+		return defaultDeploymentSlot
+	}
+
+	return s.Slot
+}
+
+// rolloutTarget shifts the traffic of a service between the version currently serving it and a new version
+type rolloutTarget interface {
+	// SetTraffic routes the percentage of the traffic to the new version
+	SetTraffic(ctx context.Context, percentage int) error
+	// Promote routes all traffic to the new version
+	Promote(ctx context.Context) error
+	// Abort routes all traffic back to the current version
+	Abort(ctx context.Context) error
+}
+
+// rollout shifts the traffic of the service to its new version as configured by the strategy. The health check of the
+// strategy is verified against the endpoint of the new version before any traffic is routed to it and after every step.
+// When the health check fails, all traffic is routed back to the current version and ErrRolloutAborted is returned.
+func rollout(
+	ctx context.Context,
+	strategy *DeploymentStrategy,
+	target rolloutTarget,
+	prober *HealthProber,
+	endpoint string,
+	progress *async.Progress[ServiceProgress],
+) error {
+	verify := func(percentage int) error {
+		if strategy.HealthCheck == nil {
+			return nil
+		}
+
+		progress.SetProgress(NewServiceProgress("Verifying the health of the new version"))
+		probeErr := prober.Check(ctx, strategy.HealthCheck, endpoint)
+		if probeErr == nil {
+			return nil
+		}
+
+		// The traffic is routed back even when the health check failed because the rollout was cancelled
+		progress.SetProgress(NewServiceProgress("Rolling back to the previous version"))
+		if err := target.Abort(context.WithoutCancel(ctx)); err != nil {
+			return fmt.Errorf("rolling back after the failed health probe (%w): %w", probeErr, err)
+		}
+
+		return &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"%w, the new version was unhealthy with %d%% of the traffic: %w", ErrRolloutAborted, percentage, probeErr),
+			Suggestion: "All traffic was routed back to the previous version. Inspect the logs of the new version with " +
+				"'azd logs' and run 'azd deploy' again once it is fixed.",
+		}
+	}
+
+	if err := verify(0); err != nil {
+		return err
+	}
+
+	for _, step := range strategy.Steps {
+		// The last step routing all traffic is completed by promoting the new version
+		if step.Weight == 100 {
+			break
+		}
+
+		progress.SetProgress(NewServiceProgress(fmt.Sprintf("Routing %d%% of the traffic to the new version", step.Weight)))
+		if err := target.SetTraffic(ctx, step.Weight); err != nil {
+			if abortErr := target.Abort(context.WithoutCancel(ctx)); abortErr != nil {
+				log.Printf("failed rolling back to the previous version: %v", abortErr)
+			}
+
+			return fmt.Errorf("routing %d%% of the traffic to the new version: %w", step.Weight, err)
+		}
+
+		if step.Wait > 0 {
+			progress.SetProgress(NewServiceProgress(
+				fmt.Sprintf("Waiting %s with %d%% of the traffic on the new version", step.Wait, step.Weight)))
+
+			select {
+			case <-ctx.Done():
+				// Route the traffic back even though the rollout was cancelled, the new version would otherwise keep
+				// serving its share of the traffic
+				if abortErr := target.Abort(context.WithoutCancel(ctx)); abortErr != nil {
+					log.Printf("failed rolling back to the previous version: %v", abortErr)
+				}
+
+				return ctx.Err()
+			case <-time.After(step.Wait):
+			}
+		}
+
+		if err := verify(step.Weight); err != nil {
+			return err
+		}
+	}
+
+	progress.SetProgress(NewServiceProgress("Routing all traffic to the new version"))
+	if err := target.Promote(ctx); err != nil {
+		return fmt.Errorf("promoting the new version: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,217 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DeploymentStrategy_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy DeploymentStrategy
+		wantErr  bool
+	}{
+		{
+			name:     "BlueGreen",
+			strategy: DeploymentStrategy{Type: DeploymentStrategyBlueGreen},
+		},
+		{
+			name: "Canary",
+			strategy: DeploymentStrategy{
+				Type:        DeploymentStrategyCanary,
+				Steps:       []DeploymentStep{{Weight: 10, Wait: time.Minute}, {Weight: 50}, {Weight: 100}},
+				HealthCheck: &HealthCheck{Path: "/health"},
+			},
+		},
+		{
+			name:     "UnknownType",
+			strategy: DeploymentStrategy{Type: "rolling"},
+			wantErr:  true,
+		},
+		{
+			name:     "BlueGreenWithSteps",
+			strategy: DeploymentStrategy{Type: DeploymentStrategyBlueGreen, Steps: []DeploymentStep{{Weight: 50}}},
+			wantErr:  true,
+		},
+		{
+			name:     "CanaryWithoutSteps",
+			strategy: DeploymentStrategy{Type: DeploymentStrategyCanary},
+			wantErr:  true,
+		},
+		{
+			name: "CanaryDecreasingWeights",
+			strategy: DeploymentStrategy{
+				Type:  DeploymentStrategyCanary,
+				Steps: []DeploymentStep{{Weight: 50}, {Weight: 10}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "HealthCheckWithoutPath",
+			strategy: DeploymentStrategy{
+				Type:        DeploymentStrategyBlueGreen,
+				HealthCheck: &HealthCheck{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "HealthCheckWithCommand",
+			strategy: DeploymentStrategy{
+				Type: DeploymentStrategyBlueGreen,
+				HealthCheck: &HealthCheck{
+					Path:    "/health",
+					Command: &ext.HookConfig{Run: "./smoke-test.sh"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.strategy.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+// fakeRolloutTarget records the traffic changes of a rollout
+type fakeRolloutTarget struct {
+	calls []string
+	// cancel, when set, cancels the rollout while the traffic is changed
+	cancel context.CancelFunc
+}
+
+func (f *fakeRolloutTarget) SetTraffic(ctx context.Context, percentage int) error {
+	f.calls = append(f.calls, fmt.Sprintf("traffic %d", percentage))
+	if f.cancel != nil {
+		f.cancel()
+		return ctx.Err()
+	}
+
+	return nil
+}
+
+func (f *fakeRolloutTarget) Promote(ctx context.Context) error {
+	f.calls = append(f.calls, "promote")
+	return nil
+}
+
+func (f *fakeRolloutTarget) Abort(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f.calls = append(f.calls, "abort")
+	return nil
+}
+
+func Test_Rollout(t *testing.T) {
+	strategy := &DeploymentStrategy{
+		Type:  DeploymentStrategyCanary,
+		Steps: []DeploymentStep{{Weight: 10}, {Weight: 50}, {Weight: 100}},
+		HealthCheck: &HealthCheck{
+			Path:    "/health",
+			Retries: new(0),
+		},
+	}
+
+	runRollout := func(t *testing.T, healthyProbes int) (*fakeRolloutTarget, []string, error) {
+		mockContext := mocks.NewMockContext(context.Background())
+
+		var probes []string
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.URL.Host == "app--new.azurecontainerapps.io"
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			probes = append(probes, request.URL.Path)
+			if len(probes) > healthyProbes {
+				return mocks.CreateEmptyHttpResponse(request, http.StatusServiceUnavailable)
+			}
+
+			return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+		})
+
+		target := &fakeRolloutTarget{}
+		progress := async.NewNoopProgress[ServiceProgress]()
+		err := rollout(
+			*mockContext.Context,
+			strategy,
+			target,
+			NewHealthProber(mockContext.HttpClient),
+			"https://app--new.azurecontainerapps.io/",
+			progress,
+		)
+
+		return target, probes, err
+	}
+
+	t.Run("Healthy", func(t *testing.T) {
+		target, probes, err := runRollout(t, 3)
+		require.NoError(t, err)
+		require.Equal(t, []string{"traffic 10", "traffic 50", "promote"}, target.calls)
+		require.Equal(t, []string{"/health", "/health", "/health"}, probes)
+	})
+
+	t.Run("Unhealthy", func(t *testing.T) {
+		target, _, err := runRollout(t, 1)
+		require.ErrorIs(t, err, ErrRolloutAborted)
+		require.Equal(t, []string{"traffic 10", "abort"}, target.calls)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		ctx, cancel := context.WithCancel(*mockContext.Context)
+		cancel()
+
+		target := &fakeRolloutTarget{}
+		err := rollout(
+			ctx,
+			&DeploymentStrategy{
+				Type:  DeploymentStrategyCanary,
+				Steps: []DeploymentStep{{Weight: 10, Wait: time.Hour}, {Weight: 100}},
+			},
+			target,
+			NewHealthProber(mockContext.HttpClient),
+			"https://app--new.azurecontainerapps.io/",
+			async.NewNoopProgress[ServiceProgress](),
+		)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, []string{"traffic 10", "abort"}, target.calls)
+	})
+
+	t.Run("CancelledDuringTrafficChange", func(t *testing.T) {
+		mockContext := mocks.NewMockContext(context.Background())
+		ctx, cancel := context.WithCancel(*mockContext.Context)
+		defer cancel()
+
+		target := &fakeRolloutTarget{cancel: cancel}
+		err := rollout(
+			ctx,
+			&DeploymentStrategy{
+				Type:  DeploymentStrategyCanary,
+				Steps: []DeploymentStep{{Weight: 10}, {Weight: 100}},
+			},
+			target,
+			NewHealthProber(mockContext.HttpClient),
+			"https://app--new.azurecontainerapps.io/",
+			async.NewNoopProgress[ServiceProgress](),
+		)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, []string{"traffic 10", "abort"}, target.calls)
+	})
+}
@@ -0,0 +1,267 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/internal"
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+	"github.com/azure/azure-dev/cli/azd/pkg/exec"
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/pkg/input"
+	"github.com/azure/azure-dev/cli/azd/pkg/ioc"
+)
+
+const (
+	defaultHealthCheckTimeout = 30 * time.Second
+	defaultHealthCheckRetries = 3
+)
+
+// healthCheckHookName is the name of the hook running the custom command of a health check
+const healthCheckHookName = "healthcheck"
+
+// ErrServiceUnhealthy is returned when a service failed its health check after being deployed
+var ErrServiceUnhealthy = errors.New("service unhealthy")
+
+// HealthCheck configures how the health of a service is verified after it is deployed
+type HealthCheck struct {
+	// The path requested on the primary endpoint of the service, or an absolute URL
+	Path string `yaml:"path,omitempty"`
+	// The expected status code of the response. When not set any 2xx status code is considered healthy.
+	ExpectedStatus int `yaml:"expectedStatus,omitempty"`
+	// The time to wait for a response of every attempt. (Default: 30s)
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	// The number of attempts made after the first failed attempt. (Default: 3)
+	Retries *int `yaml:"retries,omitempty"`
+	// An optional command, such as a smoke test, run after the endpoints are healthy
+	Command *ext.HookConfig `yaml:"command,omitempty"`
+}
+
+// Validate verifies the health check is well formed
+func (hc *HealthCheck) Validate() error {
+	if hc.Path == "" && hc.Command == nil {
+		return errors.New("the health check requires a path, a command or both")
+	}
+
+	if hc.Timeout < 0 {
+		return fmt.Errorf("invalid health check timeout %s", hc.Timeout)
+	}
+
+	if hc.Retries != nil && *hc.Retries < 0 {
+		return fmt.Errorf("invalid health check retries %d", *hc.Retries)
+	}
+
+	return nil
+}
+
+// timeout returns the time to wait for the response of a single attempt
+func (hc *HealthCheck) timeout() time.Duration {
+	if hc.Timeout == 0 {
+		return defaultHealthCheckTimeout
+	}
+
+	return hc.Timeout
+}
+
+// retries returns the number of attempts made after the first failed attempt
+func (hc *HealthCheck) retries() int {
+	if hc.Retries == nil {
+		return defaultHealthCheckRetries
+	}
+
+	return *hc.Retries
+}
+
+// Check requests the path of the health check on the endpoint until it responds with the expected status or all
+// retries are exhausted. The last failure is returned when the endpoint wasn't healthy.
+func (p *HealthProber) Check(ctx context.Context, check *HealthCheck, endpoint string) error {
+	checkUrl, err := resolveProbeUrl(endpoint, check.Path)
+	if err != nil {
+		return err
+	}
+
+	attempts := check.retries() + 1
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, check.timeout())
+		err := p.probeOnce(attemptCtx, check.ExpectedStatus, checkUrl)
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+
+		log.Printf("health check %s failed (attempt %d of %d): %v", checkUrl, attempt, attempts, err)
+
+		if attempt == attempts {
+			return fmt.Errorf("%s was unhealthy after %d attempts: %w", checkUrl, attempts, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(p.interval):
+		}
+	}
+}
+
+// HealthChecker verifies the health of services after they are deployed
+type HealthChecker struct {
+	serviceManager ServiceManager
+	prober         *HealthProber
+	commandRunner  exec.CommandRunner
+	envManager     environment.Manager
+	console        input.Console
+	env            *environment.Environment
+	serviceLocator ioc.ServiceLocator
+}
+
+// NewHealthChecker creates a new HealthChecker
+func NewHealthChecker(
+	serviceManager ServiceManager,
+	prober *HealthProber,
+	commandRunner exec.CommandRunner,
+	envManager environment.Manager,
+	console input.Console,
+	env *environment.Environment,
+	serviceLocator ioc.ServiceLocator,
+) *HealthChecker {
+	return &HealthChecker{
+		serviceManager: serviceManager,
+		prober:         prober,
+		commandRunner:  commandRunner,
+		envManager:     envManager,
+		console:        console,
+		env:            env,
+		serviceLocator: serviceLocator,
+	}
+}
+
+// Check verifies the health of the deployed service as configured by its health check. The path of the health check is
+// requested on the primary endpoint of the service, after which the custom command of the health check is run.
+// Services without a health check are always considered healthy.
+func (hc *HealthChecker) Check(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	progress *async.Progress[ServiceProgress],
+) error {
+	check := serviceConfig.HealthCheck
+	if check == nil {
+		return nil
+	}
+
+	if err := check.Validate(); err != nil {
+		return fmt.Errorf("invalid health check for service '%s': %w", serviceConfig.Name, err)
+	}
+
+	if check.Path != "" {
+		endpoints, err := hc.endpoints(ctx, serviceConfig)
+		if err != nil {
+			return err
+		}
+
+		endpoint, has := primaryEndpoint(endpoints)
+		if !has {
+			return &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf(
+					"%w: service '%s' has no endpoints to check", ErrServiceUnhealthy, serviceConfig.Name),
+				Suggestion: "Remove 'healthCheck.path' from the service and verify its health with " +
+					"'healthCheck.command' instead.",
+			}
+		}
+
+		progress.SetProgress(NewServiceProgress(fmt.Sprintf("Checking the health of %s", endpoint)))
+		if err := hc.prober.Check(ctx, check, endpoint); err != nil {
+			return &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf("%w: service '%s' failed its health check: %w",
+					ErrServiceUnhealthy, serviceConfig.Name, err),
+				Suggestion: fmt.Sprintf(
+					"The service was deployed but isn't responding as expected. Inspect its logs with "+
+						"'azd logs %s', then run 'azd deploy %s' again once it is fixed.",
+					serviceConfig.Name, serviceConfig.Name),
+			}
+		}
+	}
+
+	if check.Command != nil {
+		progress.SetProgress(NewServiceProgress("Running the health check command"))
+		if err := hc.runCommand(ctx, serviceConfig, check.Command); err != nil {
+			return &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf("%w: the health check command of service '%s' failed: %w",
+					ErrServiceUnhealthy, serviceConfig.Name, err),
+				Suggestion: fmt.Sprintf(
+					"Review the output of the health check command, inspect the logs of the service with "+
+						"'azd logs %s', then run 'azd deploy %s' again once it is fixed.",
+					serviceConfig.Name, serviceConfig.Name),
+			}
+		}
+	}
+
+	return nil
+}
+
+// endpoints returns the endpoints of the deployed service
+func (hc *HealthChecker) endpoints(ctx context.Context, serviceConfig *ServiceConfig) ([]string, error) {
+	serviceTarget, err := hc.serviceManager.GetServiceTarget(ctx, serviceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("getting service target: %w", err)
+	}
+
+	targetResource, err := hc.serviceManager.GetTargetResource(ctx, serviceConfig, serviceTarget)
+	if err != nil {
+		return nil, fmt.Errorf("getting target resource: %w", err)
+	}
+
+	endpoints, err := serviceTarget.Endpoints(ctx, serviceConfig, targetResource)
+	if err != nil {
+		return nil, fmt.Errorf("getting endpoints of service '%s': %w", serviceConfig.Name, err)
+	}
+
+	return endpoints, nil
+}
+
+// primaryEndpoint returns the first endpoint of the service that is an HTTP URL. Service targets list their production
+// endpoint first, followed by secondary endpoints like the host names of deployment slots. Endpoints that aren't URLs,
+// like the labelled endpoints of AI services, are skipped.
+func primaryEndpoint(endpoints []string) (string, bool) {
+	for _, endpoint := range endpoints {
+		endpointUrl, err := url.Parse(endpoint)
+		if err != nil || endpointUrl.Host == "" {
+			continue
+		}
+
+		if endpointUrl.Scheme == "http" || endpointUrl.Scheme == "https" {
+			return endpoint, true
+		}
+	}
+
+	return "", false
+}
+
+// runCommand runs the custom command of the health check from the directory of the service, the same way hooks are run
+func (hc *HealthChecker) runCommand(ctx context.Context, serviceConfig *ServiceConfig, command *ext.HookConfig) error {
+	hooks := map[string][]*ext.HookConfig{
+		string(ext.HookTypePost) + healthCheckHookName: {command},
+	}
+
+	hooksManager := ext.NewHooksManager(serviceConfig.Path(), hc.commandRunner)
+	hooksRunner := ext.NewHooksRunner(
+		hooksManager,
+		hc.commandRunner,
+		hc.envManager,
+		hc.console,
+		serviceConfig.Path(),
+		hooks,
+		hc.env,
+		hc.serviceLocator,
+	)
+
+	return hooksRunner.RunHooks(ctx, ext.HookTypePost, nil, healthCheckHookName)
+}
@@ -0,0 +1,173 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/test/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HealthCheck_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		check   HealthCheck
+		wantErr bool
+	}{
+		{
+			name:  "Path",
+			check: HealthCheck{Path: "/health", ExpectedStatus: http.StatusOK, Timeout: time.Second, Retries: new(0)},
+		},
+		{
+			name:  "Command",
+			check: HealthCheck{Command: &ext.HookConfig{Run: "./smoke-test.sh"}},
+		},
+		{
+			name:    "Empty",
+			check:   HealthCheck{},
+			wantErr: true,
+		},
+		{
+			name:    "NegativeTimeout",
+			check:   HealthCheck{Path: "/health", Timeout: -time.Second},
+			wantErr: true,
+		},
+		{
+			name:    "NegativeRetries",
+			check:   HealthCheck{Path: "/health", Retries: new(-1)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.check.Validate()
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_HealthProber_Check(t *testing.T) {
+	check := &HealthCheck{
+		Path:           "/health",
+		ExpectedStatus: http.StatusNoContent,
+		Retries:        new(2),
+	}
+
+	runCheck := func(t *testing.T, failingAttempts int) (int, error) {
+		mockContext := mocks.NewMockContext(context.Background())
+
+		attempts := 0
+		mockContext.HttpClient.When(func(request *http.Request) bool {
+			return request.URL.String() == "https://api.contoso.com/health"
+		}).RespondFn(func(request *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts <= failingAttempts {
+				return mocks.CreateEmptyHttpResponse(request, http.StatusOK)
+			}
+
+			return mocks.CreateEmptyHttpResponse(request, http.StatusNoContent)
+		})
+
+		prober := NewHealthProber(mockContext.HttpClient)
+		prober.interval = time.Millisecond
+
+		err := prober.Check(*mockContext.Context, check, "https://api.contoso.com")
+		return attempts, err
+	}
+
+	t.Run("HealthyAfterRetry", func(t *testing.T) {
+		attempts, err := runCheck(t, 2)
+		require.NoError(t, err)
+		require.Equal(t, 3, attempts)
+	})
+
+	t.Run("Unhealthy", func(t *testing.T) {
+		attempts, err := runCheck(t, 3)
+		require.ErrorContains(t, err, "unhealthy after 3 attempts: expected status 204, got 200")
+		require.Equal(t, 3, attempts)
+	})
+}
+
+func Test_ResolveProbeUrl(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		path     string
+		want     string
+	}{
+		{
+			name:     "Path",
+			endpoint: "https://api.contoso.com",
+			path:     "/health",
+			want:     "https://api.contoso.com/health",
+		},
+		{
+			name:     "PathWithQuery",
+			endpoint: "https://api.contoso.com/",
+			path:     "/health?ready=1&full=true",
+			want:     "https://api.contoso.com/health?ready=1&full=true",
+		},
+		{
+			name:     "AbsoluteUrl",
+			endpoint: "https://api.contoso.com/",
+			path:     "https://status.contoso.com/api",
+			want:     "https://status.contoso.com/api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			probeUrl, err := resolveProbeUrl(tt.endpoint, tt.path)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, probeUrl)
+		})
+	}
+}
+
+func Test_PrimaryEndpoint(t *testing.T) {
+	tests := []struct {
+		name      string
+		endpoints []string
+		want      string
+		wantHas   bool
+	}{
+		{
+			name:      "ProductionBeforeSlots",
+			endpoints: []string{"https://app.azurewebsites.net/", "https://app-staging.azurewebsites.net/"},
+			want:      "https://app.azurewebsites.net/",
+			wantHas:   true,
+		},
+		{
+			name:      "SkipsLabels",
+			endpoints: []string{"Deployment: https://portal.azure.com/", "http://api.contoso.com/"},
+			want:      "http://api.contoso.com/",
+			wantHas:   true,
+		},
+		{
+			name:      "OnlyLabels",
+			endpoints: []string{"Scoring: https://endpoint.inference.ml.azure.com/score"},
+		},
+		{
+			name: "None",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, has := primaryEndpoint(tt.endpoints)
+			require.Equal(t, tt.wantHas, has)
+			require.Equal(t, tt.want, endpoint)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+)
+
+// defaultHealthProbeInterval is the time waited between the failed attempt of a health check and the next attempt
+const defaultHealthProbeInterval = 5 * time.Second
+
+// HealthProber sends the HTTP requests of health checks to the endpoints of deployed services
+type HealthProber struct {
+	transporter policy.Transporter
+	interval    time.Duration
+}
+
+// NewHealthProber creates a new HealthProber
+func NewHealthProber(transporter policy.Transporter) *HealthProber {
+	return &HealthProber{
+		transporter: transporter,
+		interval:    defaultHealthProbeInterval,
+	}
+}
+
+// probeOnce requests the URL and verifies the response has the expected status, or any 2xx status when not set
+func (p *HealthProber) probeOnce(ctx context.Context, expectedStatus int, probeUrl string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeUrl, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := p.transporter.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// Drain the body so the connection can be reused by the next probe
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if expectedStatus != 0 {
+		if res.StatusCode != expectedStatus {
+			return fmt.Errorf("expected status %d, got %d", expectedStatus, res.StatusCode)
+		}
+
+		return nil
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+// resolveProbeUrl resolves the path against the endpoint, unless the path already is an absolute URL. The path may have a
+// query, such as /health?ready=1.
+func resolveProbeUrl(endpoint string, path string) (string, error) {
+	base, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("parsing endpoint %s: %w", endpoint, err)
+	}
+
+	ref, err := url.Parse(path)
+	if err != nil {
+		return "", fmt.Errorf("parsing health check path %s: %w", path, err)
+	}
+
+	return base.ResolveReference(ref).String(), nil
+}
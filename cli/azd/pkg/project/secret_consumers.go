@@ -0,0 +1,101 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+)
+
+// SecretConsumer is a service or a hook of the project consuming a secret through an environment variable
+type SecretConsumer struct {
+	// Service is the name of the service consuming the secret, or empty for a project hook
+	Service string
+	// Hook is the name of the hook consuming the secret through its secrets, or empty when the service consumes the
+	// secret through its environment
+	Hook string
+	// EnvKey is the environment variable referencing the secret
+	EnvKey string
+}
+
+// SecretConsumers returns the services and the hooks consuming any of the given environment variables, either through
+// the env of a service or through the secrets of a hook. Consumers are sorted by service, then by hook.
+func SecretConsumers(projectConfig *ProjectConfig, envKeys []string) []SecretConsumer {
+	consumers := []SecretConsumer{}
+	if len(envKeys) == 0 {
+		return consumers
+	}
+
+	isSecretKey := func(key string) bool {
+		return slices.Contains(envKeys, key)
+	}
+
+	consumers = append(consumers, hookSecretConsumers("", projectConfig.Hooks, isSecretKey)...)
+
+	for _, serviceConfig := range projectConfig.Services {
+		for _, key := range slices.Sorted(maps.Keys(serviceConfig.Environment)) {
+			var referenced string
+			_, _ = serviceConfig.Environment[key].Envsubst(func(name string) string {
+				if referenced == "" && isSecretKey(name) {
+					referenced = name
+				}
+				return ""
+			})
+
+			if referenced != "" {
+				consumers = append(consumers, SecretConsumer{Service: serviceConfig.Name, EnvKey: referenced})
+				break
+			}
+		}
+
+		consumers = append(consumers, hookSecretConsumers(serviceConfig.Name, serviceConfig.Hooks, isSecretKey)...)
+	}
+
+	slices.SortFunc(consumers, func(a, b SecretConsumer) int {
+		if c := strings.Compare(a.Service, b.Service); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Hook, b.Hook)
+	})
+
+	return consumers
+}
+
+// hookSecretConsumers returns the hooks whose secrets map an environment variable referencing the secret
+func hookSecretConsumers(service string, hooks HooksConfig, isSecretKey func(string) bool) []SecretConsumer {
+	consumers := []SecretConsumer{}
+	for name, hookConfigs := range hooks {
+		for _, hookConfig := range hookConfigs {
+			if key, has := hookSecretKey(hookConfig, isSecretKey); has {
+				consumers = append(consumers, SecretConsumer{Service: service, Hook: name, EnvKey: key})
+				break
+			}
+		}
+	}
+
+	return consumers
+}
+
+// hookSecretKey returns the environment variable referencing the secret in the secrets of the hook, including its
+// platform specific overrides
+func hookSecretKey(hookConfig *ext.HookConfig, isSecretKey func(string) bool) (string, bool) {
+	if hookConfig == nil {
+		return "", false
+	}
+
+	for _, value := range hookConfig.Secrets {
+		if isSecretKey(value) {
+			return value, true
+		}
+	}
+
+	if key, has := hookSecretKey(hookConfig.Windows, isSecretKey); has {
+		return key, true
+	}
+
+	return hookSecretKey(hookConfig.Posix, isSecretKey)
+}
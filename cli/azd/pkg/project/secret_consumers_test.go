@@ -0,0 +1,54 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/ext"
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SecretConsumers(t *testing.T) {
+	projectConfig := &ProjectConfig{
+		Hooks: HooksConfig{
+			"postprovision": {{Secrets: map[string]string{"PASSWORD": "DB_PASSWORD"}}},
+			"predeploy":     {{Secrets: map[string]string{"TOKEN": "API_TOKEN"}}},
+		},
+		Services: map[string]*ServiceConfig{
+			"web": {
+				Name: "web",
+				Environment: osutil.ExpandableMap{
+					"API_URL":           osutil.NewExpandableString("${API_URL}"),
+					"CONNECTION_STRING": osutil.NewExpandableString("Server=db;Password=${DB_PASSWORD}"),
+				},
+			},
+			"api": {
+				Name: "api",
+				Hooks: HooksConfig{
+					"prepackage": {{
+						Posix: &ext.HookConfig{Secrets: map[string]string{"PASSWORD": "DB_PASSWORD"}},
+					}},
+				},
+			},
+			"worker": {
+				Name: "worker",
+				Environment: osutil.ExpandableMap{
+					"API_TOKEN": osutil.NewExpandableString("${API_TOKEN}"),
+				},
+			},
+		},
+	}
+
+	consumers := SecretConsumers(projectConfig, []string{"DB_PASSWORD"})
+	require.Equal(t, []SecretConsumer{
+		{Hook: "postprovision", EnvKey: "DB_PASSWORD"},
+		{Service: "api", Hook: "prepackage", EnvKey: "DB_PASSWORD"},
+		{Service: "web", EnvKey: "DB_PASSWORD"},
+	}, consumers)
+
+	require.Empty(t, SecretConsumers(projectConfig, nil))
+	require.Empty(t, SecretConsumers(projectConfig, []string{"UNUSED"}))
+}
@@ -38,6 +38,10 @@ type ServiceConfig struct {
 	Docker DockerProjectOptions `yaml:"docker,omitempty"`
 	// The optional K8S / AKS options
 	K8s AksOptions `yaml:"k8s,omitempty"`
+	// The optional progressive delivery options used by 'azd deploy'
+	Rollout DeploymentOptions `yaml:"rollout,omitempty"`
+	// The optional health check verified after the service is deployed
+	HealthCheck *HealthCheck `yaml:"healthCheck,omitempty"`
 	// Infrastructure module path relative to the root infra folder
 	Module string `yaml:"module,omitempty"`
 	// The infrastructure provisioning configuration
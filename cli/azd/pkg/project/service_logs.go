@@ -0,0 +1,44 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+)
+
+// ServiceLogOptions configures how the logs of a deployed service are streamed.
+type ServiceLogOptions struct {
+	// Follow keeps streaming new log entries until the context is cancelled.
+	Follow bool
+	// Since only includes log entries newer than the specified duration.
+	// Only honored by streamers implementing ServiceLogSinceStreamer.
+	Since time.Duration
+}
+
+// ServiceLogStreamer is implemented by service targets that can stream the console and system logs of a
+// deployed service.
+type ServiceLogStreamer interface {
+	// StreamLogs writes the logs of the service deployed to the target resource to the writer.
+	// The writer must be safe for concurrent use since hosts may stream the logs of several replicas concurrently.
+	StreamLogs(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		options *ServiceLogOptions,
+		writer io.Writer,
+	) error
+}
+
+// ServiceLogSinceStreamer is implemented by log streamers that can only stream the log entries newer than
+// ServiceLogOptions.Since. Log streams of other hosts always start with their most recent entries.
+type ServiceLogSinceStreamer interface {
+	ServiceLogStreamer
+
+	// SupportsLogsSince returns true when the streamer honors ServiceLogOptions.Since.
+	SupportsLogsSince() bool
+}
@@ -557,6 +557,23 @@ func (sm *serviceManager) Deploy(
 		}
 	}
 
+	if strategy := serviceConfig.Rollout.Strategy; strategy != nil {
+		if err := strategy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid deployment strategy for service '%s': %w", serviceConfig.Name, err)
+		}
+
+		if serviceConfig.Host != ContainerAppTarget && serviceConfig.Host != AppServiceTarget {
+			return nil, &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf(
+					"deployment strategies are not supported for service '%s' with host '%s'",
+					serviceConfig.Name, serviceConfig.Host),
+				Suggestion: fmt.Sprintf(
+					"Remove 'rollout.strategy' from the service, strategies are supported by the '%s' and '%s' hosts.",
+					ContainerAppTarget, AppServiceTarget),
+			}
+		}
+	}
+
 	// Ensure package has been performed if no package artifacts exist
 	if len(serviceContext.Package) == 0 {
 		if _, err := sm.Package(ctx, serviceConfig, serviceContext, progress, &PackageOptions{}); err != nil {
@@ -0,0 +1,48 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/async"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+)
+
+// ServiceRollbacker is implemented by service targets that can restore a previous deployment of a service.
+type ServiceRollbacker interface {
+	// Rollback restores the deployment described by the record on the target resource.
+	Rollback(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+		record *DeploymentRecord,
+		progress *async.Progress[ServiceProgress],
+	) (*ServiceDeployResult, error)
+}
+
+// newPackageRollbackContext creates a service context that deploys the zip package retained for the deployment record,
+// for hosts that are rolled back by redeploying their previous package.
+func newPackageRollbackContext(record *DeploymentRecord) (*ServiceContext, error) {
+	if record.Package == "" {
+		return nil, fmt.Errorf("deployment '%s' did not retain a zip package", record.Id)
+	}
+
+	if _, err := os.Stat(record.Package); err != nil {
+		return nil, fmt.Errorf("reading the package retained for deployment '%s': %w", record.Id, err)
+	}
+
+	serviceContext := NewServiceContext()
+	if err := serviceContext.Package.Add(&Artifact{
+		Kind:         ArtifactKindArchive,
+		Location:     record.Package,
+		LocationKind: LocationKindLocal,
+	}); err != nil {
+		return nil, err
+	}
+
+	return serviceContext, nil
+}
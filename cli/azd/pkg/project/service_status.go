@@ -0,0 +1,82 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/azure/azure-dev/cli/azd/pkg/environment"
+)
+
+// ServiceState is the running state of a deployed service
+type ServiceState string
+
+const (
+	ServiceStateRunning   ServiceState = "Running"
+	ServiceStateStopped   ServiceState = "Stopped"
+	ServiceStateDeploying ServiceState = "Deploying"
+	ServiceStateFailed    ServiceState = "Failed"
+	ServiceStateUnknown   ServiceState = "Unknown"
+)
+
+// ServiceStatus is the runtime status of a deployed service, as reported by its host
+type ServiceStatus struct {
+	State ServiceState
+	// Revision is the host specific version serving the service, like the active revisions of a container app, the slots
+	// of an app service receiving traffic or the rollout revision of a k8s deployment
+	Revision string
+	// ReadyReplicas is the number of replicas, instances or pods running the service, when known
+	ReadyReplicas *int
+	// DesiredReplicas is the number of replicas the host is expected to run, when known
+	DesiredReplicas *int
+	// LastDeployed is the time the service was last deployed or updated, when known
+	LastDeployed *time.Time
+}
+
+// ServiceStatusReporter is implemented by service targets that can report the runtime status of a deployed service.
+type ServiceStatusReporter interface {
+	// Status returns the runtime status of the service deployed to the target resource.
+	Status(
+		ctx context.Context,
+		serviceConfig *ServiceConfig,
+		targetResource *environment.TargetResource,
+	) (*ServiceStatus, error)
+}
+
+// newAppServiceStatus maps the status of a web app or function app to the status of the service. The revision lists the
+// production slot and the deployment slots receiving a share of the traffic.
+func newAppServiceStatus(appStatus *azapi.AppServiceStatus) *ServiceStatus {
+	status := &ServiceStatus{
+		State:         ServiceStateUnknown,
+		Revision:      "production",
+		ReadyReplicas: new(appStatus.Instances),
+		LastDeployed:  appStatus.LastModified,
+	}
+
+	switch {
+	case strings.EqualFold(appStatus.State, "Running"):
+		status.State = ServiceStateRunning
+	case strings.EqualFold(appStatus.State, "Stopped"):
+		status.State = ServiceStateStopped
+	}
+
+	if len(appStatus.SlotTraffic) > 0 {
+		production := 100.0
+		slots := []string{}
+		for _, slot := range slices.Sorted(maps.Keys(appStatus.SlotTraffic)) {
+			production -= appStatus.SlotTraffic[slot]
+			slots = append(slots, fmt.Sprintf("%s %g%%", slot, appStatus.SlotTraffic[slot]))
+		}
+
+		status.Revision = fmt.Sprintf("production %g%%, %s", production, strings.Join(slots, ", "))
+	}
+
+	return status
+}
@@ -0,0 +1,37 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package project
+
+import (
+	"testing"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_NewAppServiceStatus(t *testing.T) {
+	t.Run("Running", func(t *testing.T) {
+		status := newAppServiceStatus(&azapi.AppServiceStatus{State: "Running", Instances: 2})
+
+		require.Equal(t, ServiceStateRunning, status.State)
+		require.Equal(t, "production", status.Revision)
+		require.Equal(t, 2, *status.ReadyReplicas)
+		require.Nil(t, status.DesiredReplicas)
+	})
+
+	t.Run("SlotTraffic", func(t *testing.T) {
+		status := newAppServiceStatus(&azapi.AppServiceStatus{
+			State:       "Stopped",
+			SlotTraffic: map[string]float64{"staging": 20, "canary": 5},
+		})
+
+		require.Equal(t, ServiceStateStopped, status.State)
+		require.Equal(t, "production 75%, canary 5%, staging 20%", status.Revision)
+	})
+
+	t.Run("UnknownState", func(t *testing.T) {
+		status := newAppServiceStatus(&azapi.AppServiceStatus{})
+		require.Equal(t, ServiceStateUnknown, status.State)
+	})
+}
@@ -7,13 +7,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"maps"
 	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/internal/mapper"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
@@ -239,8 +243,6 @@ func (t *aksTarget) Deploy(
 		return nil, fmt.Errorf("validating target resource: %w", err)
 	}
 
-	artifacts := ArtifactCollection{}
-
 	// Sync environment
 	t.kubectl.SetEnv(t.env.Dotenv())
 
@@ -283,6 +285,77 @@ func (t *aksTarget) Deploy(
 		return nil, errors.New("no deployment manifests found")
 	}
 
+	return t.deployResult(ctx, serviceConfig, targetResource, progress)
+}
+
+// Rollback rolls the k8s deployment of the service back to the rollout of the recorded deployment
+func (t *aksTarget) Rollback(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	record *DeploymentRecord,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	if err := t.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	namespace, err := t.connectCluster(ctx, serviceConfig, targetResource)
+	if err != nil {
+		return nil, err
+	}
+
+	deploymentName := t.getDeploymentName(serviceConfig)
+	flags := &kubectl.KubeCliFlags{Namespace: namespace}
+
+	// Without a recorded revision the deployment is rolled back to its previous revision
+	revision := ""
+	if record.Revision != "" {
+		// k8s renumbers the revision of a rollout every time the deployment is rolled back to it, so the revision is
+		// resolved from the replica set of the recorded rollout
+		replicaSet, err := t.findReplicaSet(ctx, deploymentName, namespace, func(replicaSet *kubectl.ReplicaSet) bool {
+			return replicaSet.Metadata.Labels[kubectl.PodTemplateHashLabel] == record.Revision
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if replicaSet == nil {
+			return nil, &internal.ErrorWithSuggestion{
+				Err: fmt.Errorf(
+					"the rollout of deployment '%s' recorded by deployment '%s' is no longer retained by the cluster",
+					deploymentName, record.Id),
+				Suggestion: "k8s only retains the number of rollouts set by the 'revisionHistoryLimit' of the " +
+					"deployment. Check out the commit of the deployment and run 'azd deploy " + serviceConfig.Name +
+					"' to redeploy it instead.",
+			}
+		}
+
+		revision = fmt.Sprint(replicaSet.Metadata.Annotations[kubectl.DeploymentRevisionAnnotation])
+	}
+
+	progress.SetProgress(NewServiceProgress(fmt.Sprintf("Rolling back deployment %s", deploymentName)))
+	if _, err := t.kubectl.RolloutUndo(ctx, deploymentName, revision, flags); err != nil {
+		return nil, err
+	}
+
+	progress.SetProgress(NewServiceProgress("Waiting for rollout to complete"))
+	if _, err := t.kubectl.RolloutStatus(ctx, deploymentName, flags); err != nil {
+		return nil, err
+	}
+
+	return t.deployResult(ctx, serviceConfig, targetResource, progress)
+}
+
+// deployResult creates the endpoint and resource artifacts of the service deployed to the cluster
+func (t *aksTarget) deployResult(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	artifacts := ArtifactCollection{}
+
 	progress.SetProgress(NewServiceProgress("Fetching endpoints for AKS service"))
 	endpointArtifacts, err := t.getEndpointArtifacts(ctx, serviceConfig)
 	if err != nil {
@@ -304,6 +377,11 @@ func (t *aksTarget) Deploy(
 
 	var resourceArtifact *Artifact
 	if err := mapper.Convert(targetResource, &resourceArtifact); err == nil {
+		// Record the rollout of the deployment so the service can be rolled back to it
+		if revision := t.deploymentRevision(ctx, serviceConfig); revision != "" {
+			resourceArtifact.Metadata[MetadataKeyRevision] = revision
+		}
+
 		if err := artifacts.Add(resourceArtifact); err != nil {
 			return nil, fmt.Errorf("failed to add resource artifact: %w", err)
 		}
@@ -565,6 +643,196 @@ func (t *aksTarget) getEndpointArtifacts(
 	return allArtifacts, nil
 }
 
+// SupportsLogsSince returns true since the logs of pods can be limited to recent entries
+func (t *aksTarget) SupportsLogsSince() bool {
+	return true
+}
+
+// StreamLogs streams the logs of all containers in the pods of the service deployment
+func (t *aksTarget) StreamLogs(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options *ServiceLogOptions,
+	writer io.Writer,
+) error {
+	if err := t.validateTargetResource(targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	namespace, err := t.connectCluster(ctx, serviceConfig, targetResource)
+	if err != nil {
+		return err
+	}
+
+	deploymentName := t.getDeploymentName(serviceConfig)
+	deployment, err := kubectl.GetResource[kubectl.Deployment](
+		ctx, t.kubectl, kubectl.ResourceTypeDeployment, deploymentName, &kubectl.KubeCliFlags{Namespace: namespace},
+	)
+	if err != nil {
+		return fmt.Errorf("failed retrieving deployment '%s': %w", deploymentName, err)
+	}
+
+	// Stream the logs of every pod selected by the deployment, not only a single pod
+	matchLabels := deployment.Spec.Selector.MatchLabels
+	if len(matchLabels) == 0 {
+		return fmt.Errorf("deployment '%s' does not define a pod selector", deploymentName)
+	}
+
+	selector := make([]string, 0, len(matchLabels))
+	for _, key := range slices.Sorted(maps.Keys(matchLabels)) {
+		selector = append(selector, fmt.Sprintf("%s=%s", key, matchLabels[key]))
+	}
+
+	return t.kubectl.Logs(ctx, &kubectl.LogsOptions{
+		Selector: strings.Join(selector, ","),
+		Follow:   options.Follow,
+		Since:    options.Since,
+	}, &kubectl.KubeCliFlags{Namespace: namespace}, writer)
+}
+
+// Status returns the rollout revision and the ready replicas of the service deployment
+func (t *aksTarget) Status(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+) (*ServiceStatus, error) {
+	if err := t.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	namespace, err := t.connectCluster(ctx, serviceConfig, targetResource)
+	if err != nil {
+		return nil, err
+	}
+
+	deploymentName := t.getDeploymentName(serviceConfig)
+	deployment, err := kubectl.GetResource[kubectl.Deployment](
+		ctx, t.kubectl, kubectl.ResourceTypeDeployment, deploymentName, &kubectl.KubeCliFlags{Namespace: namespace},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving deployment '%s': %w", deploymentName, err)
+	}
+
+	status := &ServiceStatus{
+		State:           ServiceStateRunning,
+		ReadyReplicas:   new(deployment.Status.ReadyReplicas),
+		DesiredReplicas: new(deployment.Spec.Replicas),
+	}
+
+	if revision, has := deployment.Metadata.Annotations[kubectl.DeploymentRevisionAnnotation]; has {
+		status.Revision = fmt.Sprint(revision)
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type != kubectl.DeploymentConditionProgressing {
+			continue
+		}
+
+		status.LastDeployed = condition.LastUpdateTime
+		// The rollout failed to progress before its deadline
+		if condition.Status == "False" {
+			status.State = ServiceStateFailed
+		}
+	}
+
+	switch {
+	case status.State == ServiceStateFailed:
+	case deployment.Spec.Replicas == 0:
+		status.State = ServiceStateStopped
+	case deployment.Status.UpdatedReplicas < deployment.Spec.Replicas ||
+		deployment.Status.ReadyReplicas < deployment.Spec.Replicas:
+		status.State = ServiceStateDeploying
+	}
+
+	return status, nil
+}
+
+// connectCluster configures kubectl to use the AKS cluster of the service and returns the k8s namespace of the service
+func (t *aksTarget) connectCluster(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+) (string, error) {
+	if err := tools.EnsureInstalled(ctx, t.kubectl); err != nil {
+		return "", err
+	}
+
+	t.kubectl.SetEnv(t.env.Dotenv())
+	if kubeConfigPath := t.env.Getenv(kubectl.KubeConfigEnvVarName); kubeConfigPath != "" {
+		t.kubectl.SetKubeConfig(kubeConfigPath)
+	}
+
+	namespace := t.getK8sNamespace(serviceConfig)
+	if _, err := t.ensureClusterContext(ctx, serviceConfig, targetResource, namespace); err != nil {
+		return "", err
+	}
+
+	return namespace, nil
+}
+
+// deploymentRevision returns the pod template hash of the current rollout of the k8s deployment of the service, or an
+// empty string when it can't be retrieved. Unlike the revision number of the rollout, the hash doesn't change when the
+// deployment is rolled back to the rollout.
+func (t *aksTarget) deploymentRevision(ctx context.Context, serviceConfig *ServiceConfig) string {
+	deploymentName := t.getDeploymentName(serviceConfig)
+	namespace := t.getK8sNamespace(serviceConfig)
+	deployment, err := kubectl.GetResource[kubectl.Deployment](
+		ctx,
+		t.kubectl,
+		kubectl.ResourceTypeDeployment,
+		deploymentName,
+		&kubectl.KubeCliFlags{Namespace: namespace},
+	)
+	if err != nil {
+		log.Printf("failed retrieving revision of deployment '%s': %v", deploymentName, err)
+		return ""
+	}
+
+	revision, has := deployment.Metadata.Annotations[kubectl.DeploymentRevisionAnnotation]
+	if !has {
+		return ""
+	}
+
+	replicaSet, err := t.findReplicaSet(ctx, deploymentName, namespace, func(replicaSet *kubectl.ReplicaSet) bool {
+		return fmt.Sprint(replicaSet.Metadata.Annotations[kubectl.DeploymentRevisionAnnotation]) == fmt.Sprint(revision)
+	})
+	if err != nil || replicaSet == nil {
+		log.Printf("failed retrieving the replica set of revision %v of deployment '%s': %v", revision, deploymentName, err)
+		return ""
+	}
+
+	return replicaSet.Metadata.Labels[kubectl.PodTemplateHashLabel]
+}
+
+// findReplicaSet returns the replica set of the k8s deployment matching the predicate, or nil when none matches
+func (t *aksTarget) findReplicaSet(
+	ctx context.Context,
+	deploymentName string,
+	namespace string,
+	predicate func(replicaSet *kubectl.ReplicaSet) bool,
+) (*kubectl.ReplicaSet, error) {
+	replicaSets, err := kubectl.GetResources[kubectl.ReplicaSet](
+		ctx, t.kubectl, kubectl.ResourceTypeReplicaSet, &kubectl.KubeCliFlags{Namespace: namespace},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed retrieving the replica sets of deployment '%s': %w", deploymentName, err)
+	}
+
+	for i := range replicaSets.Items {
+		replicaSet := &replicaSets.Items[i]
+		isOwned := slices.ContainsFunc(replicaSet.Metadata.OwnerReferences, func(owner kubectl.OwnerReference) bool {
+			return owner.Kind == "Deployment" && owner.Name == deploymentName
+		})
+
+		if isOwned && predicate(replicaSet) {
+			return replicaSet, nil
+		}
+	}
+
+	return nil, nil
+}
+
 func (t *aksTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
@@ -886,6 +1154,15 @@ func (t *aksTarget) getIngressEndpoints(
 	return artifacts, nil
 }
 
+func (t *aksTarget) getDeploymentName(serviceConfig *ServiceConfig) string {
+	deploymentName := serviceConfig.K8s.Deployment.Name
+	if deploymentName == "" {
+		deploymentName = serviceConfig.Name
+	}
+
+	return deploymentName
+}
+
 func (t *aksTarget) getK8sNamespace(serviceConfig *ServiceConfig) string {
 	namespace := serviceConfig.K8s.Namespace
 	if namespace == "" {
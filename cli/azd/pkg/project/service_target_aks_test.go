@@ -13,11 +13,13 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerregistry/armcontainerregistry"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/containerservice/armcontainerservice/v2"
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/pkg/alpha"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
@@ -279,6 +281,178 @@ func Test_AKS_Publish_NoContainer(t *testing.T) {
 	require.Len(t, publishResult.Artifacts, 0)
 }
 
+func Test_AKS_StreamLogs(t *testing.T) {
+	tempDir := t.TempDir()
+	ostest.Chdir(t, tempDir)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	err := setupMocksForAksTarget(mockContext)
+	require.NoError(t, err)
+	mockContext.CommandRunner.MockToolInPath("kubectl", nil)
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "kubectl version")
+	}).Respond(exec.NewRunResult(0, `{"clientVersion": {"gitVersion": "v1.30.0"}}`, ""))
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "kubectl get deployment api")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		deployment := &kubectl.Deployment{
+			Resource: kubectl.Resource{
+				Metadata: kubectl.ResourceMetadata{Name: "api"},
+			},
+			Spec: kubectl.DeploymentSpec{
+				Selector: kubectl.LabelSelector{
+					MatchLabels: map[string]string{"app": "api", "tier": "backend"},
+				},
+			},
+		}
+		jsonBytes, _ := json.Marshal(deployment)
+
+		return exec.NewRunResult(0, string(jsonBytes), ""), nil
+	})
+
+	var logsArgs exec.RunArgs
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "kubectl logs")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		logsArgs = args
+		_, _ = args.StdOut.Write([]byte("[pod/api-1/api] started\n"))
+
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	serviceConfig := createTestServiceConfig(tempDir, AksTarget, ServiceLanguageTypeScript)
+	env := createEnv()
+	azdCtx := createTestAzdContext(t, env)
+
+	serviceTarget := createAksServiceTarget(mockContext, serviceConfig, env, nil, azdCtx)
+	logStreamer, ok := serviceTarget.(ServiceLogStreamer)
+	require.True(t, ok)
+
+	scope := environment.NewTargetResource("SUB_ID", "RG_ID", "", string(azapi.AzureResourceTypeManagedCluster))
+
+	var logs strings.Builder
+	err = logStreamer.StreamLogs(*mockContext.Context, serviceConfig, scope, &ServiceLogOptions{
+		Since: time.Hour,
+	}, &logs)
+	require.NoError(t, err)
+
+	require.Equal(t, "[pod/api-1/api] started\n", logs.String())
+	require.Contains(t, logsArgs.Args, "app=api,tier=backend")
+	require.Contains(t, logsArgs.Args, "--since=1h0m0s")
+	require.NotContains(t, logsArgs.Args, "--follow")
+}
+
+func Test_AKS_Rollback(t *testing.T) {
+	tempDir := t.TempDir()
+	ostest.Chdir(t, tempDir)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	err := setupMocksForAksTarget(mockContext)
+	require.NoError(t, err)
+	mockContext.CommandRunner.MockToolInPath("kubectl", nil)
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "kubectl version")
+	}).Respond(exec.NewRunResult(0, `{"clientVersion": {"gitVersion": "v1.30.0"}}`, ""))
+
+	// The rollout of the recorded deployment is renumbered by k8s when the deployment is rolled back to it
+	revisions := map[string]string{"abc123": "3", "def456": "5"}
+	currentRevision := "5"
+
+	var undoArgs exec.RunArgs
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "kubectl rollout undo")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		undoArgs = args
+		revisions["abc123"] = "6"
+		currentRevision = "6"
+		return exec.NewRunResult(0, "", ""), nil
+	})
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "kubectl get deployment api ")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		deployment := &kubectl.Deployment{
+			Resource: kubectl.Resource{
+				Metadata: kubectl.ResourceMetadata{
+					Name:        "api",
+					Annotations: map[string]any{kubectl.DeploymentRevisionAnnotation: currentRevision},
+				},
+			},
+		}
+		jsonBytes, _ := json.Marshal(deployment)
+
+		return exec.NewRunResult(0, string(jsonBytes), ""), nil
+	})
+
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "kubectl get replicaset")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		replicaSet := func(owner string, hash string, revision string) kubectl.ReplicaSet {
+			return kubectl.ReplicaSet{
+				Metadata: kubectl.ResourceMetadata{
+					Name:            fmt.Sprintf("%s-%s", owner, hash),
+					Labels:          map[string]string{kubectl.PodTemplateHashLabel: hash},
+					Annotations:     map[string]any{kubectl.DeploymentRevisionAnnotation: revision},
+					OwnerReferences: []kubectl.OwnerReference{{Kind: "Deployment", Name: owner}},
+				},
+			}
+		}
+
+		list := &kubectl.List[kubectl.ReplicaSet]{
+			Items: []kubectl.ReplicaSet{
+				// Replica sets of other deployments are ignored
+				replicaSet("web", "abc123", "9"),
+				replicaSet("api", "abc123", revisions["abc123"]),
+				replicaSet("api", "def456", revisions["def456"]),
+			},
+		}
+		jsonBytes, _ := json.Marshal(list)
+
+		return exec.NewRunResult(0, string(jsonBytes), ""), nil
+	})
+
+	serviceConfig := createTestServiceConfig(tempDir, AksTarget, ServiceLanguageTypeScript)
+	env := createEnv()
+	azdCtx := createTestAzdContext(t, env)
+
+	serviceTarget := createAksServiceTarget(mockContext, serviceConfig, env, nil, azdCtx)
+	rollbacker, ok := serviceTarget.(ServiceRollbacker)
+	require.True(t, ok)
+
+	scope := environment.NewTargetResource("SUB_ID", "RG_ID", "", string(azapi.AzureResourceTypeManagedCluster))
+	rollback := func(record *DeploymentRecord) (*ServiceDeployResult, error) {
+		return async.RunWithProgress(
+			func(progress ServiceProgress) {},
+			func(progress *async.Progress[ServiceProgress]) (*ServiceDeployResult, error) {
+				return rollbacker.Rollback(*mockContext.Context, serviceConfig, scope, record, progress)
+			},
+		)
+	}
+
+	t.Run("RecordedRollout", func(t *testing.T) {
+		result, err := rollback(&DeploymentRecord{Id: "20240101-000000", Service: "api", Revision: "abc123"})
+		require.NoError(t, err)
+
+		require.Equal(t, []string{"rollout", "undo", "deployment/api", "--to-revision=3", "-n", "Test-App"}, undoArgs.Args)
+
+		// The pod template hash of the rollout is recorded on the resource artifact
+		resourceArtifact, has := result.Artifacts.FindFirst(WithKind(ArtifactKindResource))
+		require.True(t, has)
+		require.Equal(t, "abc123", resourceArtifact.Metadata[MetadataKeyRevision])
+	})
+
+	t.Run("RolloutNoLongerRetained", func(t *testing.T) {
+		undoArgs = exec.RunArgs{}
+
+		_, err := rollback(&DeploymentRecord{Id: "20240101-000000", Service: "api", Revision: "0ld000"})
+		var suggestionErr *internal.ErrorWithSuggestion
+		require.ErrorAs(t, err, &suggestionErr)
+		require.Contains(t, suggestionErr.Suggestion, "revisionHistoryLimit")
+		require.Empty(t, undoArgs.Args)
+	})
+}
+
 func Test_Resolve_Cluster_Name(t *testing.T) {
 	tempDir := t.TempDir()
 	ostest.Chdir(t, tempDir)
@@ -1150,3 +1324,62 @@ func (m *mockUserConfigManager) Save(config config.Config) error {
 	args := m.Called(config)
 	return args.Error(0)
 }
+
+func Test_AKS_Status(t *testing.T) {
+	tempDir := t.TempDir()
+	ostest.Chdir(t, tempDir)
+
+	mockContext := mocks.NewMockContext(context.Background())
+	err := setupMocksForAksTarget(mockContext)
+	require.NoError(t, err)
+	mockContext.CommandRunner.MockToolInPath("kubectl", nil)
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "kubectl version")
+	}).Respond(exec.NewRunResult(0, `{"clientVersion": {"gitVersion": "v1.30.0"}}`, ""))
+
+	lastUpdated := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockContext.CommandRunner.When(func(args exec.RunArgs, command string) bool {
+		return strings.Contains(command, "kubectl get deployment api")
+	}).RespondFn(func(args exec.RunArgs) (exec.RunResult, error) {
+		deployment := &kubectl.Deployment{
+			Resource: kubectl.Resource{
+				Metadata: kubectl.ResourceMetadata{
+					Name:        "api",
+					Annotations: map[string]any{kubectl.DeploymentRevisionAnnotation: "4"},
+				},
+			},
+			Spec: kubectl.DeploymentSpec{Replicas: 3},
+			Status: kubectl.DeploymentStatus{
+				ReadyReplicas:   2,
+				UpdatedReplicas: 3,
+				Conditions: []kubectl.DeploymentCondition{
+					{Type: "Available", Status: "True"},
+					{Type: kubectl.DeploymentConditionProgressing, Status: "True", LastUpdateTime: &lastUpdated},
+				},
+			},
+		}
+		jsonBytes, _ := json.Marshal(deployment)
+
+		return exec.NewRunResult(0, string(jsonBytes), ""), nil
+	})
+
+	serviceConfig := createTestServiceConfig(tempDir, AksTarget, ServiceLanguageTypeScript)
+	env := createEnv()
+	azdCtx := createTestAzdContext(t, env)
+
+	serviceTarget := createAksServiceTarget(mockContext, serviceConfig, env, nil, azdCtx)
+	reporter, ok := serviceTarget.(ServiceStatusReporter)
+	require.True(t, ok)
+
+	scope := environment.NewTargetResource("SUB_ID", "RG_ID", "", string(azapi.AzureResourceTypeManagedCluster))
+
+	status, err := reporter.Status(*mockContext.Context, serviceConfig, scope)
+	require.NoError(t, err)
+
+	// Not all replicas are ready yet
+	require.Equal(t, ServiceStateDeploying, status.State)
+	require.Equal(t, "4", status.Revision)
+	require.Equal(t, 2, *status.ReadyReplicas)
+	require.Equal(t, 3, *status.DesiredReplicas)
+	require.True(t, lastUpdated.Equal(*status.LastDeployed))
+}
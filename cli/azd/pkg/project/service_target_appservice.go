@@ -6,9 +6,12 @@ package project
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"slices"
 	"strings"
 
+	"github.com/azure/azure-dev/cli/azd/internal"
 	"github.com/azure/azure-dev/cli/azd/internal/mapper"
 	"github.com/azure/azure-dev/cli/azd/pkg/async"
 	"github.com/azure/azure-dev/cli/azd/pkg/azapi"
@@ -18,9 +21,10 @@ import (
 )
 
 type appServiceTarget struct {
-	env     *environment.Environment
-	cli     *azapi.AzureClient
-	console input.Console
+	env          *environment.Environment
+	cli          *azapi.AzureClient
+	console      input.Console
+	healthProber *HealthProber
 }
 
 // NewAppServiceTarget creates a new instance of the AppServiceTarget
@@ -28,11 +32,13 @@ func NewAppServiceTarget(
 	env *environment.Environment,
 	azCli *azapi.AzureClient,
 	console input.Console,
+	healthProber *HealthProber,
 ) ServiceTarget {
 	return &appServiceTarget{
-		env:     env,
-		cli:     azCli,
-		console: console,
+		env:          env,
+		cli:          azCli,
+		console:      console,
+		healthProber: healthProber,
 	}
 }
 
@@ -122,6 +128,14 @@ func (st *appServiceTarget) Deploy(
 		return nil, fmt.Errorf("no zip artifacts found in service context")
 	}
 
+	if strategy := serviceConfig.Rollout.Strategy; strategy != nil {
+		if err := st.rolloutSlot(ctx, serviceConfig, strategy, targetResource, zipFilePath, progress); err != nil {
+			return nil, err
+		}
+
+		return st.deployResult(ctx, serviceConfig, targetResource, progress)
+	}
+
 	// Determine deployment targets based on deployment history and slots
 	deployTargets, err := st.determineDeploymentTargets(ctx, serviceConfig, targetResource, progress)
 	if err != nil {
@@ -187,6 +201,16 @@ func (st *appServiceTarget) Deploy(
 		}
 	}
 
+	return st.deployResult(ctx, serviceConfig, targetResource, progress)
+}
+
+// deployResult creates the endpoint and resource artifacts of the web app the service was deployed to
+func (st *appServiceTarget) deployResult(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
 	progress.SetProgress(NewServiceProgress("Fetching endpoints for app service"))
 	endpoints, err := st.Endpoints(ctx, serviceConfig, targetResource)
 	if err != nil {
@@ -219,6 +243,110 @@ func (st *appServiceTarget) Deploy(
 	}, nil
 }
 
+// rolloutSlot deploys the zip package to the deployment slot of the strategy and shifts the production traffic to it,
+// swapping the slot with production once all traffic is routed to it. When the new version is unhealthy, all traffic is
+// routed back to production, which keeps serving the previous version.
+func (st *appServiceTarget) rolloutSlot(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	strategy *DeploymentStrategy,
+	targetResource *environment.TargetResource,
+	zipFilePath string,
+	progress *async.Progress[ServiceProgress],
+) error {
+	slotName := strategy.slot()
+
+	progress.SetProgress(NewServiceProgress("Checking deployment slots"))
+	slots, err := st.cli.GetAppServiceSlots(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+	)
+	if err != nil {
+		return fmt.Errorf("getting deployment slots: %w", err)
+	}
+
+	if !slices.ContainsFunc(slots, func(slot azapi.AppServiceSlot) bool { return slot.Name == slotName }) {
+		return &internal.ErrorWithSuggestion{
+			Err: fmt.Errorf(
+				"the '%s' deployment strategy of service '%s' requires the deployment slot '%s'",
+				strategy.Type, serviceConfig.Name, slotName),
+			Suggestion: fmt.Sprintf(
+				"Add a deployment slot named '%s' to the web app in your infrastructure and run 'azd provision', "+
+					"or set 'rollout.strategy.slot' to an existing slot.", slotName),
+		}
+	}
+
+	zipFile, err := os.Open(zipFilePath)
+	if err != nil {
+		return fmt.Errorf("failed reading deployment zip file: %w", err)
+	}
+	defer zipFile.Close()
+
+	progress.SetProgress(NewServiceProgress(fmt.Sprintf("Uploading deployment package to slot '%s'", slotName)))
+	_, err = st.cli.DeployAppServiceSlotZip(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slotName,
+		zipFile,
+		func(logProgress string) { progress.SetProgress(NewServiceProgress(logProgress)) },
+	)
+	if err != nil {
+		return fmt.Errorf("deploying service %s to slot '%s': %w", serviceConfig.Name, slotName, err)
+	}
+
+	slotProperties, err := st.cli.GetAppServiceSlotProperties(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		slotName,
+	)
+	if err != nil {
+		return fmt.Errorf("fetching slot properties: %w", err)
+	}
+	if len(slotProperties.HostNames) == 0 {
+		return fmt.Errorf("slot '%s' of service %s does not have a host name to probe", slotName, serviceConfig.Name)
+	}
+
+	return rollout(ctx, strategy, &slotRollout{
+		cli:               st.cli,
+		subscriptionId:    targetResource.SubscriptionId(),
+		resourceGroupName: targetResource.ResourceGroupName(),
+		appName:           targetResource.ResourceName(),
+		slotName:          slotName,
+	}, st.healthProber, fmt.Sprintf("https://%s/", slotProperties.HostNames[0]), progress)
+}
+
+// slotRollout shifts the production traffic of a web app to a deployment slot
+type slotRollout struct {
+	cli               *azapi.AzureClient
+	subscriptionId    string
+	resourceGroupName string
+	appName           string
+	slotName          string
+}
+
+func (r *slotRollout) SetTraffic(ctx context.Context, percentage int) error {
+	return r.cli.SetAppServiceSlotTraffic(
+		ctx, r.subscriptionId, r.resourceGroupName, r.appName, r.slotName, float64(percentage))
+}
+
+func (r *slotRollout) Promote(ctx context.Context) error {
+	if err := r.SetTraffic(ctx, 0); err != nil {
+		return err
+	}
+
+	return r.cli.SwapAppServiceSlot(ctx, r.subscriptionId, r.resourceGroupName, r.appName, r.slotName)
+}
+
+func (r *slotRollout) Abort(ctx context.Context) error {
+	return r.SetTraffic(ctx, 0)
+}
+
 // deploymentTarget represents a target for deployment (main app or a slot)
 type deploymentTarget struct {
 	SlotName string // Empty string means main app
@@ -397,6 +525,67 @@ func (st *appServiceTarget) Endpoints(
 	return endpoints, nil
 }
 
+// StreamLogs streams the console and application logs of the web app
+func (st *appServiceTarget) StreamLogs(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options *ServiceLogOptions,
+	writer io.Writer,
+) error {
+	if err := st.validateTargetResource(targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	return st.cli.StreamAppServiceLogs(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		options.Follow,
+		writer,
+	)
+}
+
+// Status returns the state, instance count and slot traffic of the web app
+func (st *appServiceTarget) Status(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+) (*ServiceStatus, error) {
+	if err := st.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	appStatus, err := st.cli.GetAppServiceStatus(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fetching service status: %w", err)
+	}
+
+	return newAppServiceStatus(appStatus), nil
+}
+
+// Rollback redeploys the zip package retained for the recorded deployment to the web app
+func (st *appServiceTarget) Rollback(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	record *DeploymentRecord,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	serviceContext, err := newPackageRollbackContext(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return st.Deploy(ctx, serviceConfig, serviceContext, targetResource, progress)
+}
+
 func (st *appServiceTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
@@ -8,12 +8,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/appcontainers/armappcontainers/v3"
 	"github.com/azure/azure-dev/cli/azd/internal/mapper"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing"
 	"github.com/azure/azure-dev/cli/azd/internal/tracing/fields"
@@ -40,6 +44,7 @@ type containerAppTarget struct {
 	armDeployments      *azapi.StandardDeployments
 	console             input.Console
 	commandRunner       exec.CommandRunner
+	healthProber        *HealthProber
 
 	bicepCli func() (*bicep.Cli, error)
 }
@@ -57,6 +62,7 @@ func NewContainerAppTarget(
 	deploymentService *azapi.StandardDeployments,
 	console input.Console,
 	commandRunner exec.CommandRunner,
+	healthProber *HealthProber,
 ) ServiceTarget {
 	return &containerAppTarget{
 		env:                 env,
@@ -67,6 +73,7 @@ func NewContainerAppTarget(
 		armDeployments:      deploymentService,
 		console:             console,
 		commandRunner:       commandRunner,
+		healthProber:        healthProber,
 	}
 }
 
@@ -224,6 +231,12 @@ func (at *containerAppTarget) Deploy(
 		}
 	}
 
+	strategy := serviceConfig.Rollout.Strategy
+	if strategy != nil && controlledRevision {
+		return nil, fmt.Errorf(
+			"deployment strategies are not supported when revisions are deployed with the '%s' infra module", moduleName)
+	}
+
 	if controlledRevision {
 		tracing.AppendUsageAttributeUnique(fields.FeaturesKey.String(fields.FeatRevisionDeployment))
 
@@ -299,6 +312,10 @@ func (at *containerAppTarget) Deploy(
 
 		isJob := isJobResource(targetResource)
 
+		if isJob && strategy != nil {
+			return nil, fmt.Errorf("deployment strategies are not supported for container app jobs")
+		}
+
 		if isJob {
 			tracing.AppendUsageAttributeUnique(fields.FeaturesKey.String(fields.FeatJobDeployment))
 			resourceTypeContainer = azapi.AzureResourceTypeContainerAppJob
@@ -329,35 +346,213 @@ func (at *containerAppTarget) Deploy(
 				return nil, fmt.Errorf("expanding environment variables: %w", err)
 			}
 
-			progress.SetProgress(NewServiceProgress("Updating container app revision"))
-			err = at.containerAppService.AddRevision(
-				ctx,
-				targetResource.SubscriptionId(),
-				targetResource.ResourceGroupName(),
-				resourceName,
-				imageName,
-				envVars,
-				&containerAppOptions,
-			)
-			if err != nil {
-				return nil, fmt.Errorf("updating container app service: %w", err)
+			if strategy != nil {
+				err = at.rolloutRevision(
+					ctx, strategy, targetResource, resourceName, imageName, envVars, &containerAppOptions, progress)
+				if err != nil {
+					return nil, err
+				}
+			} else {
+				progress.SetProgress(NewServiceProgress("Updating container app revision"))
+				err = at.containerAppService.AddRevision(
+					ctx,
+					targetResource.SubscriptionId(),
+					targetResource.ResourceGroupName(),
+					resourceName,
+					imageName,
+					envVars,
+					&containerAppOptions,
+				)
+				if err != nil {
+					return nil, fmt.Errorf("updating container app service: %w", err)
+				}
 			}
 		}
 	}
 
 	progress.SetProgress(NewServiceProgress("Fetching endpoints for service"))
 
-	// Create deployment deployArtifacts
-	deployArtifacts := ArtifactCollection{}
-
 	target := environment.NewTargetResource(
 		targetResource.SubscriptionId(),
 		targetResource.ResourceGroupName(),
 		resourceName,
 		string(resourceTypeContainer))
 
+	return at.deployResult(ctx, serviceConfig, target)
+}
+
+// rolloutRevision adds a new revision to the container app without traffic and shifts the traffic to it as configured by
+// the deployment strategy. When the new revision is unhealthy, the traffic is routed back to the previous revision and the
+// new revision is deactivated.
+func (at *containerAppTarget) rolloutRevision(
+	ctx context.Context,
+	strategy *DeploymentStrategy,
+	targetResource *environment.TargetResource,
+	resourceName string,
+	imageName string,
+	envVars map[string]string,
+	options *containerapps.ContainerAppOptions,
+	progress *async.Progress[ServiceProgress],
+) error {
+	progress.SetProgress(NewServiceProgress("Adding container app revision"))
+	revision, err := at.containerAppService.StageRevision(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		resourceName,
+		imageName,
+		envVars,
+		options,
+	)
+	if err != nil {
+		return fmt.Errorf("adding container app revision: %w", err)
+	}
+
+	return rollout(ctx, strategy, &revisionRollout{
+		containerAppService: at.containerAppService,
+		subscriptionId:      targetResource.SubscriptionId(),
+		resourceGroupName:   targetResource.ResourceGroupName(),
+		appName:             resourceName,
+		revision:            revision,
+		options:             options,
+	}, at.healthProber, fmt.Sprintf("https://%s/", revision.HostName), progress)
+}
+
+// revisionRollout shifts the traffic of a container app from its previous revision to a new revision
+type revisionRollout struct {
+	containerAppService containerapps.ContainerAppService
+	subscriptionId      string
+	resourceGroupName   string
+	appName             string
+	revision            *containerapps.StagedRevision
+	options             *containerapps.ContainerAppOptions
+}
+
+func (r *revisionRollout) SetTraffic(ctx context.Context, percentage int) error {
+	return r.containerAppService.SetRevisionTraffic(
+		ctx, r.subscriptionId, r.resourceGroupName, r.appName, map[string]int32{
+			r.revision.Name:         int32(percentage),
+			r.revision.PreviousName: int32(100 - percentage),
+		}, r.options)
+}
+
+// Promote routes all traffic to the new revision, deactivates the previous revision and restores the original revisions
+// mode of the container app
+func (r *revisionRollout) Promote(ctx context.Context) error {
+	if err := r.SetTraffic(ctx, 100); err != nil {
+		return err
+	}
+
+	err := r.containerAppService.DeactivateRevision(
+		ctx, r.subscriptionId, r.resourceGroupName, r.appName, r.revision.PreviousName)
+	if err != nil {
+		return err
+	}
+
+	return r.restoreRevisionsMode(ctx, r.revision.Name)
+}
+
+// Abort routes all traffic back to the previous revision, deactivates the new revision and restores the original
+// revisions mode of the container app
+func (r *revisionRollout) Abort(ctx context.Context) error {
+	if err := r.SetTraffic(ctx, 0); err != nil {
+		return err
+	}
+
+	err := r.containerAppService.DeactivateRevision(
+		ctx, r.subscriptionId, r.resourceGroupName, r.appName, r.revision.Name)
+	if err != nil {
+		return err
+	}
+
+	return r.restoreRevisionsMode(ctx, r.revision.PreviousName)
+}
+
+// restoreRevisionsMode switches the container app back to the revisions mode it had before the new revision was staged
+func (r *revisionRollout) restoreRevisionsMode(ctx context.Context, servingRevisionName string) error {
+	if r.revision.PreviousRevisionsMode == string(armappcontainers.ActiveRevisionsModeMultiple) {
+		return nil
+	}
+
+	return r.containerAppService.SetActiveRevisionsMode(
+		ctx,
+		r.subscriptionId,
+		r.resourceGroupName,
+		r.appName,
+		r.revision.PreviousRevisionsMode,
+		servingRevisionName,
+		r.options,
+	)
+}
+
+// Rollback reactivates the container app revision created by the recorded deployment
+func (at *containerAppTarget) Rollback(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	record *DeploymentRecord,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	if err := at.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	if isJobResource(targetResource) {
+		return nil, fmt.Errorf("rolling back container app jobs is not supported")
+	}
+
+	if record.Revision == "" {
+		return nil, fmt.Errorf("deployment '%s' did not record a container app revision", record.Id)
+	}
+
+	progress.SetProgress(NewServiceProgress(fmt.Sprintf("Activating revision %s", record.Revision)))
+	err := at.containerAppService.ActivateRevision(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		record.Revision,
+		&containerapps.ContainerAppOptions{
+			ApiVersion: serviceConfig.ApiVersion,
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	progress.SetProgress(NewServiceProgress("Fetching endpoints for service"))
+
+	return at.deployResult(ctx, serviceConfig, targetResource)
+}
+
+// deployResult creates the resource and endpoint artifacts of the container app or job the service was deployed to
+func (at *containerAppTarget) deployResult(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	target *environment.TargetResource,
+) (*ServiceDeployResult, error) {
+	deployArtifacts := ArtifactCollection{}
+
 	var resourceArtifact *Artifact
 	if err := mapper.Convert(target, &resourceArtifact); err == nil {
+		if !isJobResource(target) {
+			// Record the revision serving the app so the deployment can be rolled back to it
+			revisionName, err := at.containerAppService.GetLatestRevisionName(
+				ctx,
+				target.SubscriptionId(),
+				target.ResourceGroupName(),
+				target.ResourceName(),
+				&containerapps.ContainerAppOptions{
+					ApiVersion: serviceConfig.ApiVersion,
+				},
+			)
+			if err != nil {
+				log.Printf("failed getting latest revision of container app '%s': %v", target.ResourceName(), err)
+			} else {
+				resourceArtifact.Metadata[MetadataKeyRevision] = revisionName
+			}
+		}
+
 		if err := deployArtifacts.Add(resourceArtifact); err != nil {
 			return nil, fmt.Errorf("failed to add resource artifact: %w", err)
 		}
@@ -418,6 +613,87 @@ func (at *containerAppTarget) Endpoints(
 	}
 }
 
+// StreamLogs streams the system logs and the console logs of the containers of the container app
+func (at *containerAppTarget) StreamLogs(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options *ServiceLogOptions,
+	writer io.Writer,
+) error {
+	if isJobResource(targetResource) {
+		return fmt.Errorf("streaming logs is not supported for container app jobs")
+	}
+
+	if targetResource.ResourceName() == "" {
+		return fmt.Errorf("container app for service '%s' has not been deployed", serviceConfig.Name)
+	}
+
+	return at.containerAppService.StreamLogs(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		options.Follow,
+		writer,
+	)
+}
+
+// Status returns the running state, active revisions and replica count of the container app
+func (at *containerAppTarget) Status(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+) (*ServiceStatus, error) {
+	if isJobResource(targetResource) {
+		return nil, fmt.Errorf("status is not supported for container app jobs")
+	}
+
+	if targetResource.ResourceName() == "" {
+		return nil, fmt.Errorf("container app for service '%s' has not been deployed", serviceConfig.Name)
+	}
+
+	appStatus, err := at.containerAppService.GetStatus(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		&containerapps.ContainerAppOptions{ApiVersion: serviceConfig.ApiVersion},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fetching service status: %w", err)
+	}
+
+	status := &ServiceStatus{
+		State:         ServiceStateUnknown,
+		Revision:      appStatus.LatestReadyRevision,
+		ReadyReplicas: new(appStatus.Replicas),
+		LastDeployed:  appStatus.LastDeployed,
+	}
+
+	switch {
+	case strings.EqualFold(appStatus.ProvisioningState, "InProgress"):
+		status.State = ServiceStateDeploying
+	case strings.EqualFold(appStatus.ProvisioningState, "Failed"):
+		status.State = ServiceStateFailed
+	case strings.EqualFold(appStatus.RunningStatus, "Running"):
+		status.State = ServiceStateRunning
+	case strings.EqualFold(appStatus.RunningStatus, "Stopped"):
+		status.State = ServiceStateStopped
+	}
+
+	// Show the traffic split when several revisions are active
+	if len(appStatus.Traffic) > 1 {
+		revisions := []string{}
+		for _, revision := range slices.Sorted(maps.Keys(appStatus.Traffic)) {
+			revisions = append(revisions, fmt.Sprintf("%s %d%%", revision, appStatus.Traffic[revision]))
+		}
+		status.Revision = strings.Join(revisions, ", ")
+	}
+
+	return status, nil
+}
+
 func (at *containerAppTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
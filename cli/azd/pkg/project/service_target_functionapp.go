@@ -6,6 +6,7 @@ package project
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -245,6 +246,67 @@ func (f *functionAppTarget) Endpoints(
 	}
 }
 
+// StreamLogs streams the host and function logs of the function app
+func (f *functionAppTarget) StreamLogs(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	options *ServiceLogOptions,
+	writer io.Writer,
+) error {
+	if err := f.validateTargetResource(targetResource); err != nil {
+		return fmt.Errorf("validating target resource: %w", err)
+	}
+
+	return f.cli.StreamAppServiceLogs(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		options.Follow,
+		writer,
+	)
+}
+
+// Status returns the state, instance count and slot traffic of the function app
+func (f *functionAppTarget) Status(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+) (*ServiceStatus, error) {
+	if err := f.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	appStatus, err := f.cli.GetAppServiceStatus(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fetching service status: %w", err)
+	}
+
+	return newAppServiceStatus(appStatus), nil
+}
+
+// Rollback redeploys the zip package retained for the recorded deployment to the function app
+func (f *functionAppTarget) Rollback(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+	record *DeploymentRecord,
+	progress *async.Progress[ServiceProgress],
+) (*ServiceDeployResult, error) {
+	serviceContext, err := newPackageRollbackContext(record)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Deploy(ctx, serviceConfig, serviceContext, targetResource, progress)
+}
+
 func (f *functionAppTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
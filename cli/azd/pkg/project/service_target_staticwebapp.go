@@ -250,6 +250,45 @@ func (at *staticWebAppTarget) Endpoints(
 	}
 }
 
+// Status returns the build status of the default environment of the static web app
+func (at *staticWebAppTarget) Status(
+	ctx context.Context,
+	serviceConfig *ServiceConfig,
+	targetResource *environment.TargetResource,
+) (*ServiceStatus, error) {
+	if err := at.validateTargetResource(targetResource); err != nil {
+		return nil, fmt.Errorf("validating target resource: %w", err)
+	}
+
+	envProps, err := at.cli.GetStaticWebAppEnvironmentProperties(
+		ctx,
+		targetResource.SubscriptionId(),
+		targetResource.ResourceGroupName(),
+		targetResource.ResourceName(),
+		DefaultStaticWebAppEnvironmentName,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("fetching service status: %w", err)
+	}
+
+	status := &ServiceStatus{
+		State:        ServiceStateUnknown,
+		Revision:     DefaultStaticWebAppEnvironmentName,
+		LastDeployed: envProps.LastUpdated,
+	}
+
+	switch envProps.Status {
+	case "Ready":
+		status.State = ServiceStateRunning
+	case "WaitingForDeployment", "Uploading", "Deploying":
+		status.State = ServiceStateDeploying
+	case "Failed":
+		status.State = ServiceStateFailed
+	}
+
+	return status, nil
+}
+
 func (at *staticWebAppTarget) validateTargetResource(
 	targetResource *environment.TargetResource,
 ) error {
@@ -7,12 +7,17 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
 )
 
 // OnZipFn is a function that is invoked on each file or directory,
@@ -221,6 +226,65 @@ func ExtractToDirectory(artifactPath string, targetDirectory string) error {
 	return nil
 }
 
+// ExtractToCache extracts the zip archive into a directory of cacheDir keyed on the checksum of the archive, and returns
+// the extracted directory. Archives that were already extracted, whose directory contains markerFile, are reused.
+func ExtractToCache(artifactPath string, cacheDir string, markerFile string) (string, error) {
+	checksum, err := fileChecksum(artifactPath)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(cacheDir, osutil.PermissionDirectoryOwnerOnly); err != nil {
+		return "", fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	extractedDir := filepath.Join(cacheDir, checksum[:32])
+	if _, err := os.Stat(filepath.Join(extractedDir, markerFile)); err == nil {
+		return extractedDir, nil
+	}
+
+	// Extract into a temporary directory first so a partially extracted archive is never used
+	tempDir, err := os.MkdirTemp(cacheDir, "extract-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := ExtractToDirectory(artifactPath, tempDir); err != nil {
+		return "", err
+	}
+
+	if err := os.RemoveAll(extractedDir); err != nil {
+		return "", err
+	}
+
+	if err := os.Rename(tempDir, extractedDir); err != nil {
+		return "", fmt.Errorf("failed to move extracted archive: %w", err)
+	}
+
+	return extractedDir, nil
+}
+
+// fileChecksum returns the hex encoded SHA256 checksum of a file
+func fileChecksum(path string) (checksum string, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil && err == nil {
+			err = fmt.Errorf("failed to close %s: %w", path, closeErr)
+		}
+	}()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", fmt.Errorf("failed to compute checksum: %w", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
 // ExtractTarGzToDirectory extracts a .tar.gz archive to the specified target directory
 func ExtractTarGzToDirectory(artifactPath string, targetDirectory string) error {
 	// Open the tar.gz file
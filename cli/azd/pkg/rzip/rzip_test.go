@@ -273,3 +273,39 @@ func TestExtractTarGzToDirectory(t *testing.T) {
 		require.Equal(expectedContent, string(content))
 	}
 }
+
+func TestExtractToCache(t *testing.T) {
+	require := require.New(t)
+	tempDir := t.TempDir()
+
+	sourceDir := filepath.Join(tempDir, "source")
+	require.NoError(os.MkdirAll(filepath.Join(sourceDir, "subdir"), 0755))
+	require.NoError(os.WriteFile(filepath.Join(sourceDir, "marker.json"), []byte("{}"), 0600))
+	require.NoError(os.WriteFile(filepath.Join(sourceDir, "subdir", "file.txt"), []byte("content"), 0600))
+
+	zipPath := filepath.Join(tempDir, "archive.zip")
+	zipFile, err := os.Create(zipPath)
+	require.NoError(err)
+	require.NoError(rzip.CreateFromDirectory(sourceDir, zipFile, nil))
+	require.NoError(zipFile.Close())
+
+	cacheDir := filepath.Join(tempDir, "cache")
+	extractedDir, err := rzip.ExtractToCache(zipPath, cacheDir, "marker.json")
+	require.NoError(err)
+	require.Equal(cacheDir, filepath.Dir(extractedDir))
+
+	content, err := os.ReadFile(filepath.Join(extractedDir, "subdir", "file.txt"))
+	require.NoError(err)
+	require.Equal("content", string(content))
+
+	// Extracted archives are reused, leaving no temporary directories behind
+	require.NoError(os.WriteFile(filepath.Join(extractedDir, "reused.txt"), nil, 0600))
+	reusedDir, err := rzip.ExtractToCache(zipPath, cacheDir, "marker.json")
+	require.NoError(err)
+	require.Equal(extractedDir, reusedDir)
+	require.FileExists(filepath.Join(reusedDir, "reused.txt"))
+
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(err)
+	require.Len(entries, 1)
+}
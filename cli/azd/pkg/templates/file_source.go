@@ -4,20 +4,34 @@
 package templates
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
+	"github.com/azure/azure-dev/cli/azd/pkg/rzip"
+)
+
+const (
+	// bundleTemplatesFileName is the name of the templates file at the root of a template bundle.
+	bundleTemplatesFileName = "templates.json"
 )
 
 // newFileTemplateSource creates a new template source from a file.
+// The file is either a JSON list of templates, or a .zip bundle containing a templates.json file at its root
+// together with the template directories it references.
 func newFileTemplateSource(name string, path string) (Source, error) {
 	absolutePath, err := getAbsolutePath(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed converting path '%s' to absolute path, %w", path, err)
 	}
 
+	if strings.EqualFold(filepath.Ext(absolutePath), ".zip") {
+		return newBundleTemplateSource(name, absolutePath)
+	}
+
 	templateBytes, err := os.ReadFile(absolutePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed reading file '%s', %w", path, err)
@@ -65,3 +79,58 @@ func getAbsolutePath(filePath string) (string, error) {
 
 	return "", fmt.Errorf("file '%s' was not found, %w", filePath, os.ErrNotExist)
 }
+
+// newBundleTemplateSource creates a new template source from a template bundle archive so templates can be initialized
+// without network access. Relative repository paths in the bundled templates.json resolve to directories within the
+// bundle.
+func newBundleTemplateSource(name string, bundlePath string) (Source, error) {
+	bundleDir, err := extractTemplateBundle(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed extracting template bundle '%s', %w", bundlePath, err)
+	}
+
+	templateBytes, err := os.ReadFile(filepath.Join(bundleDir, bundleTemplatesFileName))
+	if err != nil {
+		return nil, fmt.Errorf(
+			"template bundle '%s' does not contain a %s file, %w", bundlePath, bundleTemplatesFileName, err)
+	}
+
+	var templates []*Template
+	if err := json.Unmarshal(templateBytes, &templates); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal templates JSON %w", err)
+	}
+
+	for _, template := range templates {
+		if template.RepositoryPath == "" || isRemoteURI(template.RepositoryPath) ||
+			filepath.IsAbs(template.RepositoryPath) {
+			continue
+		}
+
+		templateDir := filepath.Join(bundleDir, filepath.FromSlash(template.RepositoryPath))
+		if !strings.HasPrefix(templateDir, filepath.Clean(bundleDir)+string(os.PathSeparator)) {
+			return nil, fmt.Errorf(
+				"template '%s' repository path '%s' is outside of the bundle", template.Name, template.RepositoryPath)
+		}
+
+		if info, err := os.Stat(templateDir); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf(
+				"template '%s' repository path '%s' was not found in the bundle", template.Name, template.RepositoryPath)
+		}
+
+		template.RepositoryPath = templateDir
+	}
+
+	return newTemplateSource(name, templates)
+}
+
+// extractTemplateBundle extracts the bundle archive into the azd cache, keyed on the bundle checksum, and returns the
+// extracted directory. Bundles that were already extracted are reused.
+func extractTemplateBundle(bundlePath string) (string, error) {
+	configDir, err := config.GetUserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	bundlesDir := filepath.Join(configDir, "cache", "templates", "bundles")
+	return rzip.ExtractToCache(bundlePath, bundlesDir, bundleTemplatesFileName)
+}
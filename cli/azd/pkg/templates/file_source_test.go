@@ -4,12 +4,14 @@
 package templates
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/config"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
+	"github.com/azure/azure-dev/cli/azd/pkg/rzip"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,3 +56,52 @@ func Test_NewFileTemplateSource_FileDoesNotExist(t *testing.T) {
 	_, err := newFileTemplateSource(name, path)
 	require.Error(t, err)
 }
+
+func createTemplateBundle(t *testing.T, templatesJson string) string {
+	stagingDir := t.TempDir()
+	err := os.MkdirAll(filepath.Join(stagingDir, "todo-nodejs"), osutil.PermissionDirectory)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(stagingDir, "todo-nodejs", "azure.yaml"), []byte("name: todo"), osutil.PermissionFile)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(stagingDir, "templates.json"), []byte(templatesJson), osutil.PermissionFile)
+	require.NoError(t, err)
+
+	bundlePath := filepath.Join(t.TempDir(), "templates.zip")
+	bundleFile, err := os.Create(bundlePath)
+	require.NoError(t, err)
+	defer bundleFile.Close()
+
+	require.NoError(t, rzip.CreateFromDirectory(stagingDir, bundleFile, nil))
+
+	return bundlePath
+}
+
+func Test_NewFileTemplateSource_Bundle(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	bundlePath := createTemplateBundle(t, `[
+		{"name": "todo", "repositoryPath": "todo-nodejs"},
+		{"name": "remote", "repositoryPath": "https://github.com/Azure-Samples/todo-python-mongo"}
+	]`)
+
+	source, err := newFileTemplateSource("offline", bundlePath)
+	require.NoError(t, err)
+
+	templates, err := source.ListTemplates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+
+	require.True(t, filepath.IsAbs(templates[0].RepositoryPath))
+	require.FileExists(t, filepath.Join(templates[0].RepositoryPath, "azure.yaml"))
+	require.Equal(t, "https://github.com/Azure-Samples/todo-python-mongo", templates[1].RepositoryPath)
+}
+
+func Test_NewFileTemplateSource_BundleMissingTemplate(t *testing.T) {
+	t.Setenv("AZD_CONFIG_DIR", t.TempDir())
+
+	bundlePath := createTemplateBundle(t, `[{"name": "missing", "repositoryPath": "missing-template"}]`)
+
+	_, err := newFileTemplateSource("offline", bundlePath)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "was not found in the bundle")
+}
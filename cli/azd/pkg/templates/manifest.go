@@ -0,0 +1,173 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/braydonk/yaml"
+)
+
+// ManifestFileName is the name of the manifest declaring the inputs of a parameterized template, at the root of the
+// template. The manifest is removed from the project when the template is rendered.
+const ManifestFileName = "azd-template.yaml"
+
+// InputType is the type of the value of a template input
+type InputType string
+
+const (
+	InputTypeString InputType = "string"
+	InputTypeBool   InputType = "bool"
+	InputTypeInt    InputType = "int"
+)
+
+// Manifest declares the inputs of a parameterized template and the files included depending on their values.
+type Manifest struct {
+	// Inputs are the values prompted for when the template is initialized, in prompt order
+	Inputs []*Input `yaml:"inputs,omitempty"`
+	// Include are the files and folders only included in the project when their condition is met
+	Include []*IncludeRule `yaml:"include,omitempty"`
+}
+
+// Input is a value of a parameterized template, available to the rendered files as {{ .<name> }}
+type Input struct {
+	Name string    `yaml:"name"`
+	Type InputType `yaml:"type,omitempty"`
+	// Prompt is the message displayed when prompting for the value, defaults to the name of the input
+	Prompt  string `yaml:"prompt,omitempty"`
+	Default string `yaml:"default,omitempty"`
+	// Allowed restricts the value to a set of values, which are offered as choices when prompting
+	Allowed []string `yaml:"allowed,omitempty"`
+	// Pattern is a regular expression the whole value must match
+	Pattern string `yaml:"pattern,omitempty"`
+}
+
+// IncludeRule includes a file or a folder of the template only when its condition is met
+type IncludeRule struct {
+	// Path is the slash separated path of the file or the folder, relative to the root of the template.
+	// Glob patterns are supported.
+	Path string `yaml:"path"`
+	// When is a text/template pipeline evaluated with the input values, like `.database` or `eq .database "postgres"`
+	When string `yaml:"when"`
+}
+
+// LoadManifest loads the manifest of the template at the given directory. A nil manifest is returned when the template
+// isn't parameterized.
+func LoadManifest(templateDir string) (*Manifest, error) {
+	content, err := os.ReadFile(filepath.Join(templateDir, ManifestFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("reading template manifest: %w", err)
+	}
+
+	manifest := &Manifest{}
+	if err := yaml.Unmarshal(content, manifest); err != nil {
+		return nil, fmt.Errorf("parsing template manifest %s: %w", ManifestFileName, err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid template manifest %s: %w", ManifestFileName, err)
+	}
+
+	return manifest, nil
+}
+
+// Validate checks the inputs and the include rules of the manifest are well formed
+func (m *Manifest) Validate() error {
+	names := map[string]struct{}{}
+	for _, input := range m.Inputs {
+		if input.Name == "" {
+			return errors.New("input name is required")
+		}
+
+		if _, has := names[input.Name]; has {
+			return fmt.Errorf("input '%s' is declared more than once", input.Name)
+		}
+		names[input.Name] = struct{}{}
+
+		switch input.Type {
+		case "", InputTypeString, InputTypeBool, InputTypeInt:
+		default:
+			return fmt.Errorf("input '%s' has unsupported type '%s'", input.Name, input.Type)
+		}
+
+		if input.Pattern != "" {
+			if _, err := regexp.Compile(input.Pattern); err != nil {
+				return fmt.Errorf("input '%s' has an invalid pattern: %w", input.Name, err)
+			}
+		}
+
+		if input.Default != "" {
+			if _, err := input.Parse(input.Default); err != nil {
+				return fmt.Errorf("invalid default value: %w", err)
+			}
+		}
+	}
+
+	for _, rule := range m.Include {
+		if rule.Path == "" || rule.When == "" {
+			return errors.New("include rules require a path and a when condition")
+		}
+
+		if _, err := filepath.Match(rule.Path, ""); err != nil {
+			return fmt.Errorf("include path '%s' is invalid: %w", rule.Path, err)
+		}
+	}
+
+	return nil
+}
+
+// Input returns the input with the given name, if declared
+func (m *Manifest) Input(name string) (*Input, bool) {
+	index := slices.IndexFunc(m.Inputs, func(input *Input) bool { return input.Name == name })
+	if index == -1 {
+		return nil, false
+	}
+
+	return m.Inputs[index], true
+}
+
+// Parse validates a value of the input and converts it to the type of the input
+func (i *Input) Parse(value string) (any, error) {
+	if len(i.Allowed) > 0 && !slices.Contains(i.Allowed, value) {
+		return nil, fmt.Errorf(
+			"value '%s' of input '%s' must be one of: %s", value, i.Name, strings.Join(i.Allowed, ", "))
+	}
+
+	if i.Pattern != "" {
+		pattern, err := regexp.Compile("^(?:" + i.Pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("input '%s' has an invalid pattern: %w", i.Name, err)
+		}
+
+		if !pattern.MatchString(value) {
+			return nil, fmt.Errorf("value '%s' of input '%s' must match '%s'", value, i.Name, i.Pattern)
+		}
+	}
+
+	switch i.Type {
+	case InputTypeBool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("value '%s' of input '%s' must be true or false", value, i.Name)
+		}
+		return parsed, nil
+	case InputTypeInt:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("value '%s' of input '%s' must be an integer", value, i.Name)
+		}
+		return parsed, nil
+	default:
+		return value, nil
+	}
+}
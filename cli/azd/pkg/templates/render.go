@@ -0,0 +1,128 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// RenderedFileSuffix is the suffix of the files of a parameterized template rendered with text/template. The suffix is
+// removed from the name of the rendered file.
+const RenderedFileSuffix = ".tmpl"
+
+// Render renders the parameterized template at the given directory in place with the given input values.
+// Files and folders whose include condition isn't met are removed, files ending with [RenderedFileSuffix] are rendered
+// and the manifest is removed.
+func Render(templateDir string, manifest *Manifest, values map[string]any) error {
+	for _, rule := range manifest.Include {
+		included, err := rule.Evaluate(values)
+		if err != nil {
+			return err
+		}
+
+		if !included {
+			if err := removeMatches(templateDir, rule.Path); err != nil {
+				return fmt.Errorf("excluding '%s': %w", rule.Path, err)
+			}
+		}
+	}
+
+	err := filepath.WalkDir(templateDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(d.Name(), RenderedFileSuffix) {
+			return err
+		}
+
+		return renderFile(filePath, values)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(templateDir, ManifestFileName)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("removing template manifest: %w", err)
+	}
+
+	return nil
+}
+
+// Evaluate reports whether the condition of the rule is met with the given input values
+func (r *IncludeRule) Evaluate(values map[string]any) (bool, error) {
+	condition, err := template.New(r.Path).
+		Option("missingkey=error").
+		Parse("{{ if " + r.When + " }}true{{ end }}")
+	if err != nil {
+		return false, fmt.Errorf("parsing condition of '%s': %w", r.Path, err)
+	}
+
+	var result bytes.Buffer
+	if err := condition.Execute(&result, values); err != nil {
+		return false, fmt.Errorf("evaluating condition of '%s': %w", r.Path, err)
+	}
+
+	return result.String() == "true", nil
+}
+
+// removeMatches removes the files and the folders of the template matching the slash separated pattern
+func removeMatches(templateDir string, pattern string) error {
+	return filepath.WalkDir(templateDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil || filePath == templateDir {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(templateDir, filePath)
+		if err != nil {
+			return err
+		}
+
+		if matched, _ := path.Match(pattern, filepath.ToSlash(relativePath)); !matched {
+			return nil
+		}
+
+		if err := os.RemoveAll(filePath); err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+
+		return nil
+	})
+}
+
+// renderFile renders a template file to the file without the [RenderedFileSuffix], then removes the template file
+func renderFile(filePath string, values map[string]any) error {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	fileTemplate, err := template.New(filepath.Base(filePath)).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("parsing template file %s: %w", filePath, err)
+	}
+
+	var rendered bytes.Buffer
+	if err := fileTemplate.Execute(&rendered, values); err != nil {
+		return fmt.Errorf("rendering template file %s: %w", filePath, err)
+	}
+
+	if err := os.WriteFile(strings.TrimSuffix(filePath, RenderedFileSuffix), rendered.Bytes(), info.Mode()); err != nil {
+		return err
+	}
+
+	return os.Remove(filePath)
+}
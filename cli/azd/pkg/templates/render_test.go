@@ -0,0 +1,146 @@
+// Copyright (c) Microsoft Corporation. All rights reserved.
+// Licensed under the MIT License.
+
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadManifest(t *testing.T) {
+	t.Run("NotParameterized", func(t *testing.T) {
+		manifest, err := LoadManifest(t.TempDir())
+		require.NoError(t, err)
+		require.Nil(t, manifest)
+	})
+
+	tests := []struct {
+		name     string
+		manifest string
+		err      string
+	}{
+		{
+			name:     "Valid",
+			manifest: "inputs:\n  - name: region\n    allowed: [eastus, westus]\n    default: eastus\n",
+		},
+		{
+			name:     "DuplicateInput",
+			manifest: "inputs:\n  - name: region\n  - name: region\n",
+			err:      "declared more than once",
+		},
+		{
+			name:     "UnsupportedType",
+			manifest: "inputs:\n  - name: replicas\n    type: float\n",
+			err:      "unsupported type",
+		},
+		{
+			name:     "InvalidDefault",
+			manifest: "inputs:\n  - name: replicas\n    type: int\n    default: many\n",
+			err:      "must be an integer",
+		},
+		{
+			name:     "IncludeWithoutCondition",
+			manifest: "include:\n  - path: infra/db\n",
+			err:      "require a path and a when condition",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, ManifestFileName), []byte(tt.manifest), 0600))
+
+			manifest, err := LoadManifest(dir)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, manifest)
+		})
+	}
+}
+
+func TestInputParse(t *testing.T) {
+	input := &Input{Name: "replicas", Type: InputTypeInt, Pattern: "[1-9]"}
+
+	value, err := input.Parse("3")
+	require.NoError(t, err)
+	require.Equal(t, 3, value)
+
+	// The pattern must match the whole value
+	_, err = input.Parse("30")
+	require.ErrorContains(t, err, "must match")
+
+	input = &Input{Name: "database", Type: InputTypeBool}
+	value, err = input.Parse("true")
+	require.NoError(t, err)
+	require.Equal(t, true, value)
+
+	input = &Input{Name: "database", Allowed: []string{"postgres", "mysql"}}
+	_, err = input.Parse("sqlite")
+	require.ErrorContains(t, err, "must be one of: postgres, mysql")
+}
+
+func TestRender(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		ManifestFileName:             "inputs:\n  - name: database\n",
+		"azure.yaml.tmpl":            "name: todo\n{{- if eq .database \"postgres\" }}\ndatabase: postgres{{ end }}\n",
+		"infra/postgres/main.bicep":  "// postgres",
+		"infra/mysql/main.bicep":     "// mysql",
+		"src/api/postgres.py":        "# postgres",
+		"src/api/mysql.py":           "# mysql",
+		".github/workflows/ci.yml":   "run: ${{ secrets.TOKEN }}",
+		"src/api/settings.json.tmpl": `{"database": "{{ .database }}"}`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+
+	manifest := &Manifest{
+		Inputs: []*Input{{Name: "database"}},
+		Include: []*IncludeRule{
+			{Path: "infra/postgres", When: `eq .database "postgres"`},
+			{Path: "infra/mysql", When: `eq .database "mysql"`},
+			{Path: "src/api/mysql.*", When: `eq .database "mysql"`},
+		},
+	}
+
+	err := Render(dir, manifest, map[string]any{"database": "postgres"})
+	require.NoError(t, err)
+
+	require.NoFileExists(t, filepath.Join(dir, ManifestFileName))
+	require.FileExists(t, filepath.Join(dir, "infra", "postgres", "main.bicep"))
+	require.NoDirExists(t, filepath.Join(dir, "infra", "mysql"))
+	require.FileExists(t, filepath.Join(dir, "src", "api", "postgres.py"))
+	require.NoFileExists(t, filepath.Join(dir, "src", "api", "mysql.py"))
+
+	content, err := os.ReadFile(filepath.Join(dir, "azure.yaml"))
+	require.NoError(t, err)
+	require.Equal(t, "name: todo\ndatabase: postgres\n", string(content))
+
+	content, err = os.ReadFile(filepath.Join(dir, "src", "api", "settings.json"))
+	require.NoError(t, err)
+	require.Equal(t, `{"database": "postgres"}`, string(content))
+	require.NoFileExists(t, filepath.Join(dir, "src", "api", "settings.json.tmpl"))
+
+	// Files without the template suffix are copied verbatim
+	content, err = os.ReadFile(filepath.Join(dir, ".github", "workflows", "ci.yml"))
+	require.NoError(t, err)
+	require.Equal(t, "run: ${{ secrets.TOKEN }}", string(content))
+}
+
+func TestRenderMissingInput(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "azure.yaml.tmpl"), []byte("name: {{ .name }}"), 0600))
+
+	err := Render(dir, &Manifest{}, map[string]any{})
+	require.ErrorContains(t, err, "rendering template file")
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
@@ -102,7 +103,7 @@ func NewSourceManager(
 
 // List returns a list of template sources.
 func (sm *sourceManager) List(ctx context.Context) ([]*SourceConfig, error) {
-	config, err := sm.configManager.Load()
+	azdConfig, err := sm.configManager.Load()
 	if err != nil {
 		return nil, fmt.Errorf("unable to load user configuration: %w", err)
 	}
@@ -117,7 +118,7 @@ func (sm *sourceManager) List(ctx context.Context) ([]*SourceConfig, error) {
 		return allSourceConfigs, nil
 	}
 
-	rawSources, ok := config.Get(baseConfigKey)
+	rawSources, ok := azdConfig.Get(baseConfigKey)
 	if ok {
 		sourceMap := rawSources.(map[string]any)
 		for key, rawSource := range sourceMap {
@@ -142,8 +143,12 @@ func (sm *sourceManager) List(ctx context.Context) ([]*SourceConfig, error) {
 		}
 	} else {
 		// In the use case where template sources have never been configured,
-		// add Awesome-Azd as the default template source.
-		if err := sm.addInternal(SourceAwesomeAzd); err != nil {
+		// add Awesome-Azd as the default template source, unless the azd policy blocks it.
+		err := sm.addInternal(SourceAwesomeAzd)
+		if errors.Is(err, config.ErrPolicyViolation) {
+			log.Printf("not defaulting template source '%s': %v", SourceAwesomeAzd.Key, err)
+			return allSourceConfigs, nil
+		} else if err != nil {
 			return nil, fmt.Errorf("unable to default template source '%s': %w", SourceAwesomeAzd.Key, err)
 		}
 		allSourceConfigs = append(allSourceConfigs, SourceAwesomeAzd)
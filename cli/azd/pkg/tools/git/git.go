@@ -91,6 +91,42 @@ func (cli *Cli) ShallowClone(ctx context.Context, repositoryPath string, branch
 	return nil
 }
 
+// FetchCommit fetches a single commit of a repository into the target directory and checks it out, without the history
+// of the repository.
+func (cli *Cli) FetchCommit(ctx context.Context, repositoryPath string, commit string, target string) error {
+	if err := cli.InitRepo(ctx, target); err != nil {
+		return err
+	}
+
+	// Like ShallowClone, default authentication is kept for private repos within a codespace.
+	runArgs := exec.NewRunArgs("git", "-C", target, "fetch", "--depth", "1", repositoryPath, commit)
+	if _, err := cli.commandRunner.Run(ctx, runArgs); err != nil {
+		return fmt.Errorf("failed to fetch commit %s of repository %s: %w", commit, repositoryPath, err)
+	}
+
+	runArgs = newRunArgs("-C", target, "checkout", "--quiet", "--detach", "FETCH_HEAD")
+	if _, err := cli.commandRunner.Run(ctx, runArgs); err != nil {
+		return fmt.Errorf("failed to check out commit %s: %w", commit, err)
+	}
+
+	return nil
+}
+
+// MergeFile merges the changes from the base file to the other file into the current file, in place.
+// Conflicting changes are written with conflict markers and their count is returned.
+func (cli *Cli) MergeFile(ctx context.Context, currentPath string, basePath string, otherPath string) (int, error) {
+	runArgs := newRunArgs(
+		"merge-file", "-L", "current", "-L", "base", "-L", "template", currentPath, basePath, otherPath)
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+
+	// The exit code is the number of conflicts, negative exit codes (255 and above 127 on unix) are errors
+	if err != nil && (res.ExitCode <= 0 || res.ExitCode > 127) {
+		return 0, fmt.Errorf("failed to merge file %s: %w", currentPath, err)
+	}
+
+	return res.ExitCode, nil
+}
+
 var noSuchRemoteRegex = regexp.MustCompile("(fatal|error): No such remote")
 var notGitRepositoryRegex = regexp.MustCompile("(fatal|error): not a git repository")
 var ErrNoSuchRemote = errors.New("no such remote")
@@ -123,6 +159,19 @@ func (cli *Cli) GetCurrentBranch(ctx context.Context, repositoryPath string) (st
 	return strings.TrimSpace(res.Stdout), nil
 }
 
+// GetCurrentCommit returns the full hash of the commit checked out in the repository
+func (cli *Cli) GetCurrentCommit(ctx context.Context, repositoryPath string) (string, error) {
+	runArgs := newRunArgs("-C", repositoryPath, "rev-parse", "HEAD")
+	res, err := cli.commandRunner.Run(ctx, runArgs)
+	if notGitRepositoryRegex.MatchString(res.Stderr) {
+		return "", ErrNotRepository
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get current commit: %w", err)
+	}
+
+	return strings.TrimSpace(res.Stdout), nil
+}
+
 func (cli *Cli) GetRepoRoot(ctx context.Context, repositoryPath string) (string, error) {
 	runArgs := newRunArgs("-C", repositoryPath, "rev-parse", "--show-toplevel")
 	res, err := cli.commandRunner.Run(ctx, runArgs)
@@ -7,12 +7,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"maps"
 	"os"
 	"path/filepath"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/tools"
@@ -242,6 +244,61 @@ func (cli *Cli) RolloutStatus(
 	return &res, nil
 }
 
+// Rolls back the deployment to the specified revision, or to the previous revision when revision is empty
+func (cli *Cli) RolloutUndo(
+	ctx context.Context,
+	deploymentName string,
+	revision string,
+	flags *KubeCliFlags,
+) (*exec.RunResult, error) {
+	args := []string{"rollout", "undo", fmt.Sprintf("deployment/%s", deploymentName)}
+	if revision != "" {
+		args = append(args, fmt.Sprintf("--to-revision=%s", revision))
+	}
+
+	res, err := cli.Exec(ctx, flags, args...)
+	if err != nil {
+		return nil, fmt.Errorf("deployment rollback failed, %w", err)
+	}
+
+	return &res, nil
+}
+
+// K8s logs options
+type LogsOptions struct {
+	// The label selector of the pods to retrieve logs from, for example 'app=todo-api'
+	Selector string
+	// Keeps streaming new logs until the command is cancelled
+	Follow bool
+	// Only returns logs newer than the specified duration
+	Since time.Duration
+}
+
+// Writes the logs of all containers in the pods matching the selector to the writer, prefixed with the pod and
+// container names
+func (cli *Cli) Logs(ctx context.Context, options *LogsOptions, flags *KubeCliFlags, writer io.Writer) error {
+	runArgs := exec.
+		NewRunArgs("kubectl", "logs", "-l", options.Selector, "--all-containers", "--prefix", "--ignore-errors").
+		// Allow more than the default of 5 concurrent log streams for services with many replicas
+		AppendParams("--max-log-requests", "20").
+		WithStdOut(writer)
+
+	if options.Follow {
+		runArgs = runArgs.AppendParams("--follow")
+	}
+
+	if options.Since > 0 {
+		runArgs = runArgs.AppendParams(fmt.Sprintf("--since=%s", options.Since))
+	}
+
+	_, err := cli.executeCommandWithArgs(ctx, runArgs, flags)
+	if err != nil && ctx.Err() == nil {
+		return fmt.Errorf("failed getting logs: %w", err)
+	}
+
+	return nil
+}
+
 // Executes a k8s CLI command from the specified arguments and flags
 func (cli *Cli) Exec(ctx context.Context, flags *KubeCliFlags, args ...string) (exec.RunResult, error) {
 	runArgs := exec.
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/azure/azure-dev/cli/azd/pkg/exec"
 	"github.com/azure/azure-dev/cli/azd/pkg/osutil"
@@ -128,6 +129,37 @@ func Test_Command_Args(t *testing.T) {
 				return err
 			},
 		},
+		"rollout-undo": {
+			mockCommandPredicate: "kubectl rollout undo",
+			expectedCmd:          "kubectl",
+			expectedArgs: []string{
+				"rollout", "undo", "deployment/deployment-name", "--to-revision=3", "-n", "test-namespace",
+			},
+			testFn: func() error {
+				_, err := cli.RolloutUndo(*mockContext.Context, "deployment-name", "3", &KubeCliFlags{
+					Namespace: "test-namespace",
+				})
+
+				return err
+			},
+		},
+		"logs": {
+			mockCommandPredicate: "kubectl logs",
+			expectedCmd:          "kubectl",
+			expectedArgs: []string{
+				"logs", "-l", "app=api", "--all-containers", "--prefix", "--ignore-errors", "--max-log-requests", "20",
+				"--follow", "--since=1h0m0s", "-n", "test-namespace",
+			},
+			testFn: func() error {
+				return cli.Logs(*mockContext.Context, &LogsOptions{
+					Selector: "app=api",
+					Follow:   true,
+					Since:    time.Hour,
+				}, &KubeCliFlags{
+					Namespace: "test-namespace",
+				}, io.Discard)
+			},
+		},
 		"exec": {
 			mockCommandPredicate: "kubectl get deployment",
 			expectedCmd:          "kubectl",
@@ -6,15 +6,23 @@ package kubectl
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 )
 
 type ResourceType string
 
 const (
 	ResourceTypeDeployment ResourceType = "deployment"
+	ResourceTypeReplicaSet ResourceType = "replicaset"
 	ResourceTypeIngress    ResourceType = "ing"
 	ResourceTypeService    ResourceType = "svc"
 	KubeConfigEnvVarName   string       = "KUBECONFIG"
+	// The annotation set by k8s on deployments and their replica sets with the revision number of the rollout. The
+	// revision of a replica set changes when the deployment is rolled back to it.
+	DeploymentRevisionAnnotation string = "deployment.kubernetes.io/revision"
+	// The label set by k8s on the replica sets of a deployment with the hash of their pod template, which identifies a
+	// rollout of the deployment regardless of its revision number
+	PodTemplateHashLabel string = "pod-template-hash"
 )
 
 type Resource struct {
@@ -35,22 +43,48 @@ type ResourceWithSpec[T any, S any] struct {
 }
 
 type ResourceMetadata struct {
-	Name        string `json:"name"      yaml:"name"`
-	Namespace   string `json:"namespace" yaml:"namespace"`
-	Annotations map[string]any
+	Name            string `json:"name"      yaml:"name"`
+	Namespace       string `json:"namespace" yaml:"namespace"`
+	Annotations     map[string]any
+	Labels          map[string]string `json:"labels"          yaml:"labels"`
+	OwnerReferences []OwnerReference  `json:"ownerReferences" yaml:"ownerReferences"`
+}
+
+type OwnerReference struct {
+	Kind string `json:"kind" yaml:"kind"`
+	Name string `json:"name" yaml:"name"`
 }
 
 type Deployment ResourceWithSpec[DeploymentSpec, DeploymentStatus]
 
+// ReplicaSet is a replica set created by a deployment for each of its rollouts
+type ReplicaSet Resource
+
 type DeploymentSpec struct {
-	Replicas int `json:"replicas" yaml:"replicas"`
+	Replicas int           `json:"replicas" yaml:"replicas"`
+	Selector LabelSelector `json:"selector" yaml:"selector"`
+}
+
+type LabelSelector struct {
+	MatchLabels map[string]string `json:"matchLabels" yaml:"matchLabels"`
 }
 
 type DeploymentStatus struct {
-	AvailableReplicas int `json:"availableReplicas" yaml:"availableReplicas"`
-	ReadyReplicas     int `json:"readyReplicas"     yaml:"readyReplicas"`
-	Replicas          int `json:"replicas"          yaml:"replicas"`
-	UpdatedReplicas   int `json:"updatedReplicas"   yaml:"updatedReplicas"`
+	AvailableReplicas int                   `json:"availableReplicas" yaml:"availableReplicas"`
+	ReadyReplicas     int                   `json:"readyReplicas"     yaml:"readyReplicas"`
+	Replicas          int                   `json:"replicas"          yaml:"replicas"`
+	UpdatedReplicas   int                   `json:"updatedReplicas"   yaml:"updatedReplicas"`
+	Conditions        []DeploymentCondition `json:"conditions"        yaml:"conditions"`
+}
+
+// DeploymentConditionProgressing is the condition of a deployment reporting the progress of its rollout
+const DeploymentConditionProgressing = "Progressing"
+
+type DeploymentCondition struct {
+	Type           string     `json:"type"           yaml:"type"`
+	Status         string     `json:"status"         yaml:"status"`
+	Reason         string     `json:"reason"         yaml:"reason"`
+	LastUpdateTime *time.Time `json:"lastUpdateTime" yaml:"lastUpdateTime"`
 }
 
 type Ingress ResourceWithSpec[IngressSpec, IngressStatus]
@@ -90,6 +90,7 @@ func createBlobClient(
 		auth.ExternalAuthConfiguration{},
 		azCli,
 		"",
+		"",
 	)
 	require.NoError(t, err)
 